@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"errors"
+
+	"github.com/hyperledger/fabric/bccsp"
+)
+
+// AES256KeyGenOpts contains options for generating an AES 256 bits key that resides in the token
+// and cannot be extracted from it. Such a key can only be used for encrypting and decrypting data
+// in CBC mode with PKCS#7 padding, as requested with bccsp.AESCBCPKCS7ModeOpts.
+type AES256KeyGenOpts struct{}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (opts *AES256KeyGenOpts) Algorithm() string {
+	return bccsp.AES256
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *AES256KeyGenOpts) Ephemeral() bool {
+	return false
+}
+
+type aesKey struct {
+	ski []byte
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *aesKey) Bytes() ([]byte, error) {
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *aesKey) SKI() []byte {
+	return k.ski
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *aesKey) Symmetric() bool {
+	return true
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *aesKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *aesKey) PublicKey() (bccsp.Key, error) {
+	return nil, errors.New("Cannot call this method on a symmetric key.")
+}
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package pkcs11
 
 import (
+	"crypto/aes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/asn1"
 	"encoding/hex"
@@ -205,6 +207,14 @@ func (csp *Provider) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 
 		k = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pub}}
 
+	case *AES256KeyGenOpts:
+		ski, err := csp.generateAESKey(32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed generating AES 256 key")
+		}
+
+		k = &aesKey{ski}
+
 	default:
 		return csp.BCCSP.KeyGen(opts)
 	}
@@ -234,6 +244,11 @@ func (csp *Provider) GetKey(ski []byte) (bccsp.Key, error) {
 
 	pubKey, isPriv, err := csp.getECKey(ski)
 	if err != nil {
+		if err := csp.findAESKey(ski); err == nil {
+			key := &aesKey{ski}
+			csp.cacheKey(ski, key)
+			return key, nil
+		}
 		logger.Debugf("Key not found using PKCS11: %v", err)
 		return csp.BCCSP.GetKey(ski)
 	}
@@ -329,6 +344,48 @@ func (csp *Provider) verifyECDSA(k ecdsaPublicKey, signature, digest []byte) (bo
 	return csp.verifyP11ECDSA(k.ski, digest, r, s, k.pub.Curve.Params().BitSize/8)
 }
 
+// Encrypt encrypts plaintext using key k.
+// The opts argument should be appropriate for the algorithm used.
+func (csp *Provider) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) ([]byte, error) {
+	key, ok := k.(*aesKey)
+	if !ok {
+		return csp.BCCSP.Encrypt(k, plaintext, opts)
+	}
+	if err := checkAESModeOpts(opts); err != nil {
+		return nil, err
+	}
+	return csp.encryptP11AES(key.ski, plaintext)
+}
+
+// Decrypt decrypts ciphertext using key k.
+// The opts argument should be appropriate for the algorithm used.
+func (csp *Provider) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) ([]byte, error) {
+	key, ok := k.(*aesKey)
+	if !ok {
+		return csp.BCCSP.Decrypt(k, ciphertext, opts)
+	}
+	if err := checkAESModeOpts(opts); err != nil {
+		return nil, err
+	}
+	return csp.decryptP11AES(key.ski, ciphertext)
+}
+
+// checkAESModeOpts makes sure that the mode requested for an AES key of the token is CBC with PKCS#7
+// padding and a random IV, which is the only mode supported for such keys
+func checkAESModeOpts(opts interface{}) error {
+	switch o := opts.(type) {
+	case *bccsp.AESCBCPKCS7ModeOpts:
+		if o != nil && o.IV == nil && o.PRNG == nil {
+			return nil
+		}
+	case bccsp.AESCBCPKCS7ModeOpts:
+		if o.IV == nil && o.PRNG == nil {
+			return nil
+		}
+	}
+	return errors.Errorf("Mode not recognized or not supported for a PKCS11 AES key [%T]", opts)
+}
+
 func (csp *Provider) getSession() (session pkcs11.SessionHandle, err error) {
 	for {
 		select {
@@ -607,6 +664,127 @@ func (csp *Provider) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool)
 	return ski, pubGoKey, nil
 }
 
+// generateAESKey generates an AES key of the given length in bytes in the token. As the value of the key
+// cannot be extracted from the token, the SKI of the key is a random identifier.
+func (csp *Provider) generateAESKey(length int) (ski []byte, err error) {
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	ski = make([]byte, 32)
+	if _, err = rand.Read(ski); err != nil {
+		return nil, fmt.Errorf("Could not generate SKI [%s]", err)
+	}
+
+	keyT := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, length),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, hex.EncodeToString(ski)),
+
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+	}
+	if csp.immutable {
+		keyT = append(keyT, pkcs11.NewAttribute(pkcs11.CKA_MODIFIABLE, false))
+	}
+
+	key, err := csp.ctx.GenerateKey(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_GEN, nil)},
+		keyT,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("P11: AES key generate failed [%s]", err)
+	}
+	logger.Infof("Generated new P11 AES key, SKI %x\n", ski)
+
+	if logger.IsEnabledFor(zapcore.DebugLevel) {
+		listAttrs(csp.ctx, session, key)
+	}
+
+	return ski, nil
+}
+
+// findAESKey looks for an AES key by SKI, stored in CKA_ID
+func (csp *Provider) findAESKey(ski []byte) (err error) {
+	session, err := csp.getSession()
+	if err != nil {
+		return err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	_, err = csp.findKeyPairFromSKI(session, ski, secretKeyType)
+	return err
+}
+
+// encryptP11AES encrypts msg in CBC mode with PKCS#7 padding, prepending the random IV to the ciphertext
+// as the software implementation does
+func (csp *Provider) encryptP11AES(ski []byte, msg []byte) (ciphertext []byte, err error) {
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	key, err := csp.findKeyPairFromSKI(session, ski, secretKeyType)
+	if err != nil {
+		return nil, fmt.Errorf("Secret key not found [%s]", err)
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err = rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("Could not generate IV [%s]", err)
+	}
+	err = csp.ctx.EncryptInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_CBC_PAD, iv)}, key)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt-initialize failed [%s]", err)
+	}
+	ct, err := csp.ctx.Encrypt(session, msg)
+	if err != nil {
+		return nil, fmt.Errorf("P11: encrypt failed [%s]", err)
+	}
+
+	return append(iv, ct...), nil
+}
+
+// decryptP11AES decrypts a ciphertext produced by encryptP11AES
+func (csp *Provider) decryptP11AES(ski []byte, ciphertext []byte) (msg []byte, err error) {
+	if len(ciphertext) < 2*aes.BlockSize || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size and include an IV")
+	}
+
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	key, err := csp.findKeyPairFromSKI(session, ski, secretKeyType)
+	if err != nil {
+		return nil, fmt.Errorf("Secret key not found [%s]", err)
+	}
+
+	iv := ciphertext[:aes.BlockSize]
+	err = csp.ctx.DecryptInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_CBC_PAD, iv)}, key)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt-initialize failed [%s]", err)
+	}
+	msg, err = csp.ctx.Decrypt(session, ciphertext[aes.BlockSize:])
+	if err != nil {
+		return nil, fmt.Errorf("P11: decrypt failed [%s]", err)
+	}
+
+	return msg, nil
+}
+
 func (csp *Provider) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error) {
 	session, err := csp.getSession()
 	if err != nil {
@@ -685,6 +863,7 @@ type keyType int8
 const (
 	publicKeyType keyType = iota
 	privateKeyType
+	secretKeyType
 )
 
 func (csp *Provider) cachedHandle(keyType keyType, ski []byte) (pkcs11.ObjectHandle, bool) {
@@ -718,8 +897,11 @@ func (csp *Provider) findKeyPairFromSKI(session pkcs11.SessionHandle, ski []byte
 	}
 
 	ktype := pkcs11.CKO_PUBLIC_KEY
-	if keyType == privateKeyType {
+	switch keyType {
+	case privateKeyType:
 		ktype = pkcs11.CKO_PRIVATE_KEY
+	case secretKeyType:
+		ktype = pkcs11.CKO_SECRET_KEY
 	}
 
 	template := []*pkcs11.Attribute{
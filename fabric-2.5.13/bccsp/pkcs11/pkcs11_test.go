@@ -888,3 +888,33 @@ func TestHandleSessionReturn(t *testing.T) {
 	require.EqualError(t, err, "Failed generating ECDSA P256 key: P11: keypair generate failed [pkcs11: 0xB3: CKR_SESSION_HANDLE_INVALID]")
 	require.Empty(t, csp.sessPool, "sessionPool should be empty")
 }
+
+func TestAESKey(t *testing.T) {
+	csp, cleanup := newProvider(t, defaultOptions())
+	defer cleanup()
+
+	k, err := csp.KeyGen(&AES256KeyGenOpts{})
+	require.NoError(t, err)
+	require.True(t, k.Symmetric())
+	require.True(t, k.Private())
+	_, err = k.Bytes()
+	require.EqualError(t, err, "Not supported.")
+
+	ct, err := csp.Encrypt(k, []byte("Hello World"), &bccsp.AESCBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	_, err = csp.Encrypt(k, []byte("Hello World"), &bccsp.AESCBCPKCS7ModeOpts{IV: make([]byte, 16)})
+	require.EqualError(t, err, "Mode not recognized or not supported for a PKCS11 AES key [*bccsp.AESCBCPKCS7ModeOpts]")
+
+	// the key is found in the token by its SKI
+	csp.clearCaches()
+	k2, err := csp.GetKey(k.SKI())
+	require.NoError(t, err)
+	require.IsType(t, &aesKey{}, k2)
+
+	pt, err := csp.Decrypt(k2, ct, bccsp.AESCBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("Hello World"), pt)
+
+	_, err = csp.Decrypt(k2, ct[:16], &bccsp.AESCBCPKCS7ModeOpts{})
+	require.EqualError(t, err, "Invalid ciphertext. It must be a multiple of the block size and include an IV")
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric/internal/ledgerutil/compare"
 	"github.com/hyperledger/fabric/internal/ledgerutil/identifytxs"
+	"github.com/hyperledger/fabric/internal/ledgerutil/pvtdataexport"
 	"github.com/hyperledger/fabric/internal/ledgerutil/verify"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
@@ -27,10 +28,15 @@ const (
 		"from ledgerutil compare."
 	blockStorePathDesc = "Path to file system of target peer, used to access block store. Defaults to '/var/hyperledger/production'. " +
 		"IMPORTANT: If the configuration for target peer's file system path was changed, the new path MUST be provided."
-	blockStorePathDefault = "/var/hyperledger/production"
-	outputDirIdDesc       = "Location for identified transactions json results output directory. Default is the current directory."
-	verifyErrorMessage    = "Verify Ledger Error:"
-	outputDirVerifyDesc   = "Location for verification result output directory. Default is the current directory."
+	blockStorePathDefault     = "/var/hyperledger/production"
+	outputDirIdDesc           = "Location for identified transactions json results output directory. Default is the current directory."
+	verifyErrorMessage        = "Verify Ledger Error:"
+	outputDirVerifyDesc       = "Location for verification result output directory. Default is the current directory."
+	pvtdataExportErrorMessage = "Private Data Export Error: "
+	fileSystemPathDesc        = "Path to file system of target peer, used to access the state database. Defaults to '/var/hyperledger/production'. " +
+		"IMPORTANT: If the configuration for target peer's file system path was changed, the new path MUST be provided. The peer must be stopped."
+	collectionsDesc  = "Collection to export, in the format <namespace>:<collection>. Can be repeated to export several collections."
+	outputDirPvtDesc = "Location for exported private data output directory. Default is the current directory."
 )
 
 var (
@@ -51,6 +57,13 @@ var (
 	blockStorePathVerify = verifyApp.Arg("blockStorePath", blockStorePathDesc).Default(blockStorePathDefault).String()
 	outputDirVerify      = verifyApp.Flag("outputDir", outputDirVerifyDesc).Short('o').String()
 
+	pvtdataApp            = app.Command("pvtdata", "Private data of the collections held by a peer.")
+	pvtdataExportApp      = pvtdataApp.Command("export", "Export the current private state of collections from the state database of a stopped peer.")
+	pvtdataChannelID      = pvtdataExportApp.Arg("channelID", "Channel of the collections.").Required().String()
+	fileSystemPathPvtdata = pvtdataExportApp.Arg("fileSystemPath", fileSystemPathDesc).Default(blockStorePathDefault).String()
+	pvtdataCollections    = pvtdataExportApp.Flag("collection", collectionsDesc).Short('c').Required().Strings()
+	outputDirPvtdata      = pvtdataExportApp.Flag("outputDir", outputDirPvtDesc).Short('o').String()
+
 	args = os.Args[1:]
 )
 
@@ -135,5 +148,43 @@ func main() {
 			fmt.Printf("\nSuccessfully executed verify tool. Some error(s) are found.\n")
 			os.Exit(1)
 		}
+
+	case pvtdataExportApp.FullCommand():
+
+		// Determine result json file location
+		if *outputDirPvtdata == "" {
+			*outputDirPvtdata, err = os.Getwd()
+			if err != nil {
+				fmt.Printf("%s%s\n", pvtdataExportErrorMessage, err)
+				os.Exit(1)
+			}
+		}
+
+		var collections []*pvtdataexport.Collection
+		for _, c := range *pvtdataCollections {
+			collection, err := pvtdataexport.ParseCollection(c)
+			if err != nil {
+				fmt.Printf("%s%s\n", pvtdataExportErrorMessage, err)
+				os.Exit(1)
+			}
+			collections = append(collections, collection)
+		}
+
+		summaries, err := pvtdataexport.ExportPvtData(*fileSystemPathPvtdata, *pvtdataChannelID, collections, *outputDirPvtdata)
+		if err != nil {
+			fmt.Printf("%s%s\n", pvtdataExportErrorMessage, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\nSuccessfully exported private data. Results saved to %s.\n", *outputDirPvtdata)
+		mismatches := 0
+		for _, s := range summaries {
+			fmt.Printf("%s:%s: %d keys exported, %d hash mismatches, %d keys missing a private value\n",
+				s.Namespace, s.Collection, s.Entries, s.HashMismatches, s.MissingValues)
+			mismatches += s.HashMismatches
+		}
+		if mismatches > 0 {
+			os.Exit(2)
+		}
 	}
 }
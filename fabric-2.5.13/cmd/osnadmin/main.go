@@ -58,6 +58,26 @@ func executeForArgs(args []string) (output string, exit int, err error) {
 	remove := channel.Command("remove", "Remove a channel from an Ordering Service Node (OSN).")
 	removeChannelID := remove.Flag("channelID", "Channel ID").Short('c').Required().String()
 
+	raft := channel.Command("raft", "Raft actions")
+
+	raftStatus := raft.Command("status", "Show the status of the Raft node of an Ordering Service Node (OSN) for a channel, including its write-ahead log and snapshots.")
+	raftStatusChannelID := raftStatus.Flag("channelID", "Channel ID").Short('c').Required().String()
+
+	raftSnapshot := raft.Command("snapshot", "Take a snapshot of the Raft node of an Ordering Service Node (OSN) for a channel, and purge the write-ahead log files and snapshots that precede it.")
+	raftSnapshotChannelID := raftSnapshot.Flag("channelID", "Channel ID").Short('c').Required().String()
+
+	transferLeader := channel.Command("transfer-leader", "Transfer the leadership of the Raft cluster of a channel to another consenter. The request can be sent to any consenter of the channel.")
+	transferLeaderChannelID := transferLeader.Flag("channelID", "Channel ID").Short('c').Required().String()
+	transferLeaderTo := transferLeader.Flag("to", "Raft ID of the consenter to transfer the leadership to. If not set, the leadership is transferred from the Ordering Service Node (OSN) to any recently active consenter.").Uint64()
+
+	maintenance := app.Command("maintenance", "Maintenance mode actions")
+
+	maintenanceStatus := maintenance.Command("status", "Show whether an Ordering Service Node (OSN) is in maintenance mode, and the channels it is the Raft leader of.")
+
+	maintenanceEnter := maintenance.Command("enter", "Put an Ordering Service Node (OSN) in maintenance mode. It stops accepting broadcast requests, and transfers the leadership of the Raft clusters it leads to other consenters. Maintenance mode ends when the OSN restarts.")
+
+	maintenanceExit := maintenance.Command("exit", "Take an Ordering Service Node (OSN) out of maintenance mode, so that it accepts broadcast requests again.")
+
 	command, err := app.Parse(args)
 	if err != nil {
 		return "", 1, err
@@ -121,6 +141,18 @@ func executeForArgs(args []string) (output string, exit int, err error) {
 		resp, err = osnadmin.ListAllChannels(osnURL, caCertPool, tlsClientCert)
 	case remove.FullCommand():
 		resp, err = osnadmin.Remove(osnURL, *removeChannelID, caCertPool, tlsClientCert)
+	case raftStatus.FullCommand():
+		resp, err = osnadmin.RaftStatus(osnURL, *raftStatusChannelID, caCertPool, tlsClientCert)
+	case raftSnapshot.FullCommand():
+		resp, err = osnadmin.RaftSnapshot(osnURL, *raftSnapshotChannelID, caCertPool, tlsClientCert)
+	case transferLeader.FullCommand():
+		resp, err = osnadmin.TransferLeader(osnURL, *transferLeaderChannelID, *transferLeaderTo, caCertPool, tlsClientCert)
+	case maintenanceStatus.FullCommand():
+		resp, err = osnadmin.MaintenanceStatus(osnURL, caCertPool, tlsClientCert)
+	case maintenanceEnter.FullCommand():
+		resp, err = osnadmin.EnterMaintenance(osnURL, caCertPool, tlsClientCert)
+	case maintenanceExit.FullCommand():
+		resp, err = osnadmin.ExitMaintenance(osnURL, caCertPool, tlsClientCert)
 	}
 	if err != nil {
 		return errorOutput(err), 1, nil
@@ -335,6 +335,324 @@ var _ = Describe("osnadmin", func() {
 		})
 	})
 
+	Describe("Raft", func() {
+		BeforeEach(func() {
+			mockChannelManagement.RaftStatusReturns(types.RaftStatus{
+				Name:           "tell-me-your-secrets",
+				NodeID:         1,
+				State:          "StateLeader",
+				Term:           2,
+				Leader:         1,
+				CommittedIndex: 20,
+				AppliedIndex:   20,
+				LastSnapshot:   &types.RaftSnapshot{Term: 2, Index: 15, BlockNumber: 9},
+				SnapshotFiles:  2,
+				WALFiles:       3,
+				WALSize:        192000000,
+			}, nil)
+			mockChannelManagement.RaftSnapshotReturns(types.RaftStatus{
+				Name:           "tell-me-your-secrets",
+				NodeID:         1,
+				State:          "StateLeader",
+				Term:           2,
+				Leader:         1,
+				CommittedIndex: 20,
+				AppliedIndex:   20,
+				LastSnapshot:   &types.RaftSnapshot{Term: 2, Index: 20, BlockNumber: 12},
+				SnapshotFiles:  1,
+				WALFiles:       1,
+				WALSize:        64000000,
+			}, nil)
+		})
+
+		It("uses the channel participation API to show the Raft status of a channel", func() {
+			args := []string{
+				"channel",
+				"raft",
+				"status",
+				"--orderer-address", ordererURL,
+				"--channelID", channelID,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedStatus := types.RaftStatus{
+				Name:           "tell-me-your-secrets",
+				URL:            "/participation/v1/channels/tell-me-your-secrets",
+				NodeID:         1,
+				State:          "StateLeader",
+				Term:           2,
+				Leader:         1,
+				CommittedIndex: 20,
+				AppliedIndex:   20,
+				LastSnapshot:   &types.RaftSnapshot{Term: 2, Index: 15, BlockNumber: 9},
+				SnapshotFiles:  2,
+				WALFiles:       3,
+				WALSize:        192000000,
+			}
+			checkStatusOutput(output, exit, err, 200, expectedStatus)
+			Expect(mockChannelManagement.RaftStatusArgsForCall(0)).To(Equal(channelID))
+		})
+
+		It("uses the channel participation API to take a Raft snapshot of a channel", func() {
+			args := []string{
+				"channel",
+				"raft",
+				"snapshot",
+				"--orderer-address", ordererURL,
+				"-c", channelID,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedStatus := types.RaftStatus{
+				Name:           "tell-me-your-secrets",
+				URL:            "/participation/v1/channels/tell-me-your-secrets",
+				NodeID:         1,
+				State:          "StateLeader",
+				Term:           2,
+				Leader:         1,
+				CommittedIndex: 20,
+				AppliedIndex:   20,
+				LastSnapshot:   &types.RaftSnapshot{Term: 2, Index: 20, BlockNumber: 12},
+				SnapshotFiles:  1,
+				WALFiles:       1,
+				WALSize:        64000000,
+			}
+			checkStatusOutput(output, exit, err, 200, expectedStatus)
+			Expect(mockChannelManagement.RaftSnapshotCallCount()).To(Equal(1))
+			Expect(mockChannelManagement.RaftSnapshotArgsForCall(0)).To(Equal(channelID))
+		})
+
+		Context("when the orderer is not a Raft consenter for the channel", func() {
+			BeforeEach(func() {
+				mockChannelManagement.RaftStatusReturns(types.RaftStatus{}, types.ErrNotRaftConsenter)
+			})
+
+			It("returns 404 not found", func() {
+				args := []string{
+					"channel",
+					"raft",
+					"status",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--ca-file", ordererCACert,
+					"--client-cert", clientCert,
+					"--client-key", clientKey,
+				}
+				output, exit, err := executeForArgs(args)
+				expectedOutput := types.ErrorResponse{
+					Error: "cannot get Raft status: orderer is not a consenter of an etcdraft cluster for this channel",
+				}
+				checkStatusOutput(output, exit, err, 404, expectedOutput)
+			})
+		})
+
+		Context("when the channel ID is missing", func() {
+			It("returns an error", func() {
+				args := []string{
+					"channel",
+					"raft",
+					"snapshot",
+					"--orderer-address", ordererURL,
+				}
+				output, exit, err := executeForArgs(args)
+				checkFlagError(output, exit, err, "required flag --channelID not provided")
+			})
+		})
+
+		Context("when TLS is disabled", func() {
+			BeforeEach(func() {
+				tlsConfig = nil
+			})
+
+			It("uses the channel participation API to take a Raft snapshot of a channel", func() {
+				args := []string{
+					"channel",
+					"raft",
+					"snapshot",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--no-status",
+				}
+				output, exit, err := executeForArgs(args)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exit).To(Equal(0))
+				Expect(output).To(ContainSubstring(`"walSize": 64000000`))
+			})
+		})
+	})
+
+	Describe("TransferLeader", func() {
+		BeforeEach(func() {
+			mockChannelManagement.TransferLeadershipReturns(types.RaftStatus{
+				Name:   "tell-me-your-secrets",
+				NodeID: 1,
+				State:  "StateFollower",
+				Term:   3,
+				Leader: 2,
+			}, nil)
+		})
+
+		It("uses the channel participation API to transfer the leadership of a channel", func() {
+			args := []string{
+				"channel",
+				"transfer-leader",
+				"--orderer-address", ordererURL,
+				"--channelID", channelID,
+				"--to", "2",
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedStatus := types.RaftStatus{
+				Name:   "tell-me-your-secrets",
+				URL:    "/participation/v1/channels/tell-me-your-secrets",
+				NodeID: 1,
+				State:  "StateFollower",
+				Term:   3,
+				Leader: 2,
+			}
+			checkStatusOutput(output, exit, err, 200, expectedStatus)
+			Expect(mockChannelManagement.TransferLeadershipCallCount()).To(Equal(1))
+			actualChannelID, to := mockChannelManagement.TransferLeadershipArgsForCall(0)
+			Expect(actualChannelID).To(Equal(channelID))
+			Expect(to).To(Equal(uint64(2)))
+		})
+
+		It("uses the channel participation API to transfer the leadership of a channel to any consenter", func() {
+			args := []string{
+				"channel",
+				"transfer-leader",
+				"--orderer-address", ordererURL,
+				"-c", channelID,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exit).To(Equal(0))
+			Expect(output).To(HavePrefix("Status: 200\n"))
+			_, to := mockChannelManagement.TransferLeadershipArgsForCall(0)
+			Expect(to).To(BeZero())
+		})
+
+		Context("when the leadership transfer fails", func() {
+			BeforeEach(func() {
+				mockChannelManagement.TransferLeadershipReturns(types.RaftStatus{}, errors.New("failed to transfer leadership: leadership transferee is not a consenter"))
+			})
+
+			It("returns 400 bad request", func() {
+				args := []string{
+					"channel",
+					"transfer-leader",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--to", "7",
+					"--ca-file", ordererCACert,
+					"--client-cert", clientCert,
+					"--client-key", clientKey,
+				}
+				output, exit, err := executeForArgs(args)
+				expectedOutput := types.ErrorResponse{
+					Error: "cannot transfer leadership: failed to transfer leadership: leadership transferee is not a consenter",
+				}
+				checkStatusOutput(output, exit, err, 400, expectedOutput)
+			})
+		})
+
+		Context("when the --to flag is not a Raft ID", func() {
+			It("returns an error", func() {
+				args := []string{
+					"channel",
+					"transfer-leader",
+					"--orderer-address", ordererURL,
+					"--channelID", channelID,
+					"--to", "orderer1.example.com:7050",
+				}
+				output, exit, err := executeForArgs(args)
+				Expect(err).To(HaveOccurred())
+				Expect(exit).To(Equal(1))
+				Expect(output).To(BeEmpty())
+				Expect(mockChannelManagement.TransferLeadershipCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Maintenance", func() {
+		BeforeEach(func() {
+			mockChannelManagement.MaintenanceStatusReturns(types.MaintenanceStatus{
+				Enabled:  false,
+				LeaderOf: []string{"tell-me-your-secrets"},
+			})
+			mockChannelManagement.EnterMaintenanceReturns(types.MaintenanceStatus{
+				Enabled: true,
+				LeadershipTransfers: []types.LeadershipTransfer{
+					{Name: "tell-me-your-secrets", Leader: 2},
+				},
+			})
+			mockChannelManagement.ExitMaintenanceReturns(types.MaintenanceStatus{
+				Enabled: false,
+			})
+		})
+
+		It("uses the channel participation API to show the maintenance status", func() {
+			args := []string{
+				"maintenance",
+				"status",
+				"--orderer-address", ordererURL,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedStatus := types.MaintenanceStatus{
+				Enabled:  false,
+				LeaderOf: []string{"tell-me-your-secrets"},
+			}
+			checkStatusOutput(output, exit, err, 200, expectedStatus)
+		})
+
+		It("uses the channel participation API to enter maintenance mode", func() {
+			args := []string{
+				"maintenance",
+				"enter",
+				"--orderer-address", ordererURL,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+			}
+			output, exit, err := executeForArgs(args)
+			expectedStatus := types.MaintenanceStatus{
+				Enabled: true,
+				LeadershipTransfers: []types.LeadershipTransfer{
+					{Name: "tell-me-your-secrets", Leader: 2},
+				},
+			}
+			checkStatusOutput(output, exit, err, 200, expectedStatus)
+			Expect(mockChannelManagement.EnterMaintenanceCallCount()).To(Equal(1))
+		})
+
+		It("uses the channel participation API to exit maintenance mode", func() {
+			args := []string{
+				"maintenance",
+				"exit",
+				"--orderer-address", ordererURL,
+				"--ca-file", ordererCACert,
+				"--client-cert", clientCert,
+				"--client-key", clientKey,
+				"--no-status",
+			}
+			output, exit, err := executeForArgs(args)
+			checkOutput(output, exit, err, types.MaintenanceStatus{Enabled: false})
+			Expect(mockChannelManagement.ExitMaintenanceCallCount()).To(Equal(1))
+		})
+	})
+
 	Describe("Join", func() {
 		var blockPath string
 
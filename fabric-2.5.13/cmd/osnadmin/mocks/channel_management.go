@@ -32,6 +32,26 @@ type ChannelManagement struct {
 	channelListReturnsOnCall map[int]struct {
 		result1 types.ChannelList
 	}
+	EnterMaintenanceStub        func() types.MaintenanceStatus
+	enterMaintenanceMutex       sync.RWMutex
+	enterMaintenanceArgsForCall []struct {
+	}
+	enterMaintenanceReturns struct {
+		result1 types.MaintenanceStatus
+	}
+	enterMaintenanceReturnsOnCall map[int]struct {
+		result1 types.MaintenanceStatus
+	}
+	ExitMaintenanceStub        func() types.MaintenanceStatus
+	exitMaintenanceMutex       sync.RWMutex
+	exitMaintenanceArgsForCall []struct {
+	}
+	exitMaintenanceReturns struct {
+		result1 types.MaintenanceStatus
+	}
+	exitMaintenanceReturnsOnCall map[int]struct {
+		result1 types.MaintenanceStatus
+	}
 	JoinChannelStub        func(string, *common.Block, bool) (types.ChannelInfo, error)
 	joinChannelMutex       sync.RWMutex
 	joinChannelArgsForCall []struct {
@@ -47,6 +67,42 @@ type ChannelManagement struct {
 		result1 types.ChannelInfo
 		result2 error
 	}
+	MaintenanceStatusStub        func() types.MaintenanceStatus
+	maintenanceStatusMutex       sync.RWMutex
+	maintenanceStatusArgsForCall []struct {
+	}
+	maintenanceStatusReturns struct {
+		result1 types.MaintenanceStatus
+	}
+	maintenanceStatusReturnsOnCall map[int]struct {
+		result1 types.MaintenanceStatus
+	}
+	RaftSnapshotStub        func(string) (types.RaftStatus, error)
+	raftSnapshotMutex       sync.RWMutex
+	raftSnapshotArgsForCall []struct {
+		arg1 string
+	}
+	raftSnapshotReturns struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	raftSnapshotReturnsOnCall map[int]struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	RaftStatusStub        func(string) (types.RaftStatus, error)
+	raftStatusMutex       sync.RWMutex
+	raftStatusArgsForCall []struct {
+		arg1 string
+	}
+	raftStatusReturns struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	raftStatusReturnsOnCall map[int]struct {
+		result1 types.RaftStatus
+		result2 error
+	}
 	RemoveChannelStub        func(string) error
 	removeChannelMutex       sync.RWMutex
 	removeChannelArgsForCall []struct {
@@ -58,6 +114,20 @@ type ChannelManagement struct {
 	removeChannelReturnsOnCall map[int]struct {
 		result1 error
 	}
+	TransferLeadershipStub        func(string, uint64) (types.RaftStatus, error)
+	transferLeadershipMutex       sync.RWMutex
+	transferLeadershipArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+	}
+	transferLeadershipReturns struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	transferLeadershipReturnsOnCall map[int]struct {
+		result1 types.RaftStatus
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -68,15 +138,16 @@ func (fake *ChannelManagement) ChannelInfo(arg1 string) (types.ChannelInfo, erro
 	fake.channelInfoArgsForCall = append(fake.channelInfoArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.ChannelInfoStub
+	fakeReturns := fake.channelInfoReturns
 	fake.recordInvocation("ChannelInfo", []interface{}{arg1})
 	fake.channelInfoMutex.Unlock()
-	if fake.ChannelInfoStub != nil {
-		return fake.ChannelInfoStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.channelInfoReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -130,15 +201,16 @@ func (fake *ChannelManagement) ChannelList() types.ChannelList {
 	ret, specificReturn := fake.channelListReturnsOnCall[len(fake.channelListArgsForCall)]
 	fake.channelListArgsForCall = append(fake.channelListArgsForCall, struct {
 	}{})
+	stub := fake.ChannelListStub
+	fakeReturns := fake.channelListReturns
 	fake.recordInvocation("ChannelList", []interface{}{})
 	fake.channelListMutex.Unlock()
-	if fake.ChannelListStub != nil {
-		return fake.ChannelListStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.channelListReturns
 	return fakeReturns.result1
 }
 
@@ -177,6 +249,112 @@ func (fake *ChannelManagement) ChannelListReturnsOnCall(i int, result1 types.Cha
 	}{result1}
 }
 
+func (fake *ChannelManagement) EnterMaintenance() types.MaintenanceStatus {
+	fake.enterMaintenanceMutex.Lock()
+	ret, specificReturn := fake.enterMaintenanceReturnsOnCall[len(fake.enterMaintenanceArgsForCall)]
+	fake.enterMaintenanceArgsForCall = append(fake.enterMaintenanceArgsForCall, struct {
+	}{})
+	stub := fake.EnterMaintenanceStub
+	fakeReturns := fake.enterMaintenanceReturns
+	fake.recordInvocation("EnterMaintenance", []interface{}{})
+	fake.enterMaintenanceMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ChannelManagement) EnterMaintenanceCallCount() int {
+	fake.enterMaintenanceMutex.RLock()
+	defer fake.enterMaintenanceMutex.RUnlock()
+	return len(fake.enterMaintenanceArgsForCall)
+}
+
+func (fake *ChannelManagement) EnterMaintenanceCalls(stub func() types.MaintenanceStatus) {
+	fake.enterMaintenanceMutex.Lock()
+	defer fake.enterMaintenanceMutex.Unlock()
+	fake.EnterMaintenanceStub = stub
+}
+
+func (fake *ChannelManagement) EnterMaintenanceReturns(result1 types.MaintenanceStatus) {
+	fake.enterMaintenanceMutex.Lock()
+	defer fake.enterMaintenanceMutex.Unlock()
+	fake.EnterMaintenanceStub = nil
+	fake.enterMaintenanceReturns = struct {
+		result1 types.MaintenanceStatus
+	}{result1}
+}
+
+func (fake *ChannelManagement) EnterMaintenanceReturnsOnCall(i int, result1 types.MaintenanceStatus) {
+	fake.enterMaintenanceMutex.Lock()
+	defer fake.enterMaintenanceMutex.Unlock()
+	fake.EnterMaintenanceStub = nil
+	if fake.enterMaintenanceReturnsOnCall == nil {
+		fake.enterMaintenanceReturnsOnCall = make(map[int]struct {
+			result1 types.MaintenanceStatus
+		})
+	}
+	fake.enterMaintenanceReturnsOnCall[i] = struct {
+		result1 types.MaintenanceStatus
+	}{result1}
+}
+
+func (fake *ChannelManagement) ExitMaintenance() types.MaintenanceStatus {
+	fake.exitMaintenanceMutex.Lock()
+	ret, specificReturn := fake.exitMaintenanceReturnsOnCall[len(fake.exitMaintenanceArgsForCall)]
+	fake.exitMaintenanceArgsForCall = append(fake.exitMaintenanceArgsForCall, struct {
+	}{})
+	stub := fake.ExitMaintenanceStub
+	fakeReturns := fake.exitMaintenanceReturns
+	fake.recordInvocation("ExitMaintenance", []interface{}{})
+	fake.exitMaintenanceMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ChannelManagement) ExitMaintenanceCallCount() int {
+	fake.exitMaintenanceMutex.RLock()
+	defer fake.exitMaintenanceMutex.RUnlock()
+	return len(fake.exitMaintenanceArgsForCall)
+}
+
+func (fake *ChannelManagement) ExitMaintenanceCalls(stub func() types.MaintenanceStatus) {
+	fake.exitMaintenanceMutex.Lock()
+	defer fake.exitMaintenanceMutex.Unlock()
+	fake.ExitMaintenanceStub = stub
+}
+
+func (fake *ChannelManagement) ExitMaintenanceReturns(result1 types.MaintenanceStatus) {
+	fake.exitMaintenanceMutex.Lock()
+	defer fake.exitMaintenanceMutex.Unlock()
+	fake.ExitMaintenanceStub = nil
+	fake.exitMaintenanceReturns = struct {
+		result1 types.MaintenanceStatus
+	}{result1}
+}
+
+func (fake *ChannelManagement) ExitMaintenanceReturnsOnCall(i int, result1 types.MaintenanceStatus) {
+	fake.exitMaintenanceMutex.Lock()
+	defer fake.exitMaintenanceMutex.Unlock()
+	fake.ExitMaintenanceStub = nil
+	if fake.exitMaintenanceReturnsOnCall == nil {
+		fake.exitMaintenanceReturnsOnCall = make(map[int]struct {
+			result1 types.MaintenanceStatus
+		})
+	}
+	fake.exitMaintenanceReturnsOnCall[i] = struct {
+		result1 types.MaintenanceStatus
+	}{result1}
+}
+
 func (fake *ChannelManagement) JoinChannel(arg1 string, arg2 *common.Block, arg3 bool) (types.ChannelInfo, error) {
 	fake.joinChannelMutex.Lock()
 	ret, specificReturn := fake.joinChannelReturnsOnCall[len(fake.joinChannelArgsForCall)]
@@ -185,15 +363,16 @@ func (fake *ChannelManagement) JoinChannel(arg1 string, arg2 *common.Block, arg3
 		arg2 *common.Block
 		arg3 bool
 	}{arg1, arg2, arg3})
+	stub := fake.JoinChannelStub
+	fakeReturns := fake.joinChannelReturns
 	fake.recordInvocation("JoinChannel", []interface{}{arg1, arg2, arg3})
 	fake.joinChannelMutex.Unlock()
-	if fake.JoinChannelStub != nil {
-		return fake.JoinChannelStub(arg1, arg2, arg3)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.joinChannelReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -242,21 +421,203 @@ func (fake *ChannelManagement) JoinChannelReturnsOnCall(i int, result1 types.Cha
 	}{result1, result2}
 }
 
+func (fake *ChannelManagement) MaintenanceStatus() types.MaintenanceStatus {
+	fake.maintenanceStatusMutex.Lock()
+	ret, specificReturn := fake.maintenanceStatusReturnsOnCall[len(fake.maintenanceStatusArgsForCall)]
+	fake.maintenanceStatusArgsForCall = append(fake.maintenanceStatusArgsForCall, struct {
+	}{})
+	stub := fake.MaintenanceStatusStub
+	fakeReturns := fake.maintenanceStatusReturns
+	fake.recordInvocation("MaintenanceStatus", []interface{}{})
+	fake.maintenanceStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ChannelManagement) MaintenanceStatusCallCount() int {
+	fake.maintenanceStatusMutex.RLock()
+	defer fake.maintenanceStatusMutex.RUnlock()
+	return len(fake.maintenanceStatusArgsForCall)
+}
+
+func (fake *ChannelManagement) MaintenanceStatusCalls(stub func() types.MaintenanceStatus) {
+	fake.maintenanceStatusMutex.Lock()
+	defer fake.maintenanceStatusMutex.Unlock()
+	fake.MaintenanceStatusStub = stub
+}
+
+func (fake *ChannelManagement) MaintenanceStatusReturns(result1 types.MaintenanceStatus) {
+	fake.maintenanceStatusMutex.Lock()
+	defer fake.maintenanceStatusMutex.Unlock()
+	fake.MaintenanceStatusStub = nil
+	fake.maintenanceStatusReturns = struct {
+		result1 types.MaintenanceStatus
+	}{result1}
+}
+
+func (fake *ChannelManagement) MaintenanceStatusReturnsOnCall(i int, result1 types.MaintenanceStatus) {
+	fake.maintenanceStatusMutex.Lock()
+	defer fake.maintenanceStatusMutex.Unlock()
+	fake.MaintenanceStatusStub = nil
+	if fake.maintenanceStatusReturnsOnCall == nil {
+		fake.maintenanceStatusReturnsOnCall = make(map[int]struct {
+			result1 types.MaintenanceStatus
+		})
+	}
+	fake.maintenanceStatusReturnsOnCall[i] = struct {
+		result1 types.MaintenanceStatus
+	}{result1}
+}
+
+func (fake *ChannelManagement) RaftSnapshot(arg1 string) (types.RaftStatus, error) {
+	fake.raftSnapshotMutex.Lock()
+	ret, specificReturn := fake.raftSnapshotReturnsOnCall[len(fake.raftSnapshotArgsForCall)]
+	fake.raftSnapshotArgsForCall = append(fake.raftSnapshotArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RaftSnapshotStub
+	fakeReturns := fake.raftSnapshotReturns
+	fake.recordInvocation("RaftSnapshot", []interface{}{arg1})
+	fake.raftSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelManagement) RaftSnapshotCallCount() int {
+	fake.raftSnapshotMutex.RLock()
+	defer fake.raftSnapshotMutex.RUnlock()
+	return len(fake.raftSnapshotArgsForCall)
+}
+
+func (fake *ChannelManagement) RaftSnapshotCalls(stub func(string) (types.RaftStatus, error)) {
+	fake.raftSnapshotMutex.Lock()
+	defer fake.raftSnapshotMutex.Unlock()
+	fake.RaftSnapshotStub = stub
+}
+
+func (fake *ChannelManagement) RaftSnapshotArgsForCall(i int) string {
+	fake.raftSnapshotMutex.RLock()
+	defer fake.raftSnapshotMutex.RUnlock()
+	argsForCall := fake.raftSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChannelManagement) RaftSnapshotReturns(result1 types.RaftStatus, result2 error) {
+	fake.raftSnapshotMutex.Lock()
+	defer fake.raftSnapshotMutex.Unlock()
+	fake.RaftSnapshotStub = nil
+	fake.raftSnapshotReturns = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) RaftSnapshotReturnsOnCall(i int, result1 types.RaftStatus, result2 error) {
+	fake.raftSnapshotMutex.Lock()
+	defer fake.raftSnapshotMutex.Unlock()
+	fake.RaftSnapshotStub = nil
+	if fake.raftSnapshotReturnsOnCall == nil {
+		fake.raftSnapshotReturnsOnCall = make(map[int]struct {
+			result1 types.RaftStatus
+			result2 error
+		})
+	}
+	fake.raftSnapshotReturnsOnCall[i] = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) RaftStatus(arg1 string) (types.RaftStatus, error) {
+	fake.raftStatusMutex.Lock()
+	ret, specificReturn := fake.raftStatusReturnsOnCall[len(fake.raftStatusArgsForCall)]
+	fake.raftStatusArgsForCall = append(fake.raftStatusArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RaftStatusStub
+	fakeReturns := fake.raftStatusReturns
+	fake.recordInvocation("RaftStatus", []interface{}{arg1})
+	fake.raftStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelManagement) RaftStatusCallCount() int {
+	fake.raftStatusMutex.RLock()
+	defer fake.raftStatusMutex.RUnlock()
+	return len(fake.raftStatusArgsForCall)
+}
+
+func (fake *ChannelManagement) RaftStatusCalls(stub func(string) (types.RaftStatus, error)) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = stub
+}
+
+func (fake *ChannelManagement) RaftStatusArgsForCall(i int) string {
+	fake.raftStatusMutex.RLock()
+	defer fake.raftStatusMutex.RUnlock()
+	argsForCall := fake.raftStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChannelManagement) RaftStatusReturns(result1 types.RaftStatus, result2 error) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = nil
+	fake.raftStatusReturns = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) RaftStatusReturnsOnCall(i int, result1 types.RaftStatus, result2 error) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = nil
+	if fake.raftStatusReturnsOnCall == nil {
+		fake.raftStatusReturnsOnCall = make(map[int]struct {
+			result1 types.RaftStatus
+			result2 error
+		})
+	}
+	fake.raftStatusReturnsOnCall[i] = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChannelManagement) RemoveChannel(arg1 string) error {
 	fake.removeChannelMutex.Lock()
 	ret, specificReturn := fake.removeChannelReturnsOnCall[len(fake.removeChannelArgsForCall)]
 	fake.removeChannelArgsForCall = append(fake.removeChannelArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.RemoveChannelStub
+	fakeReturns := fake.removeChannelReturns
 	fake.recordInvocation("RemoveChannel", []interface{}{arg1})
 	fake.removeChannelMutex.Unlock()
-	if fake.RemoveChannelStub != nil {
-		return fake.RemoveChannelStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.removeChannelReturns
 	return fakeReturns.result1
 }
 
@@ -302,6 +663,71 @@ func (fake *ChannelManagement) RemoveChannelReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *ChannelManagement) TransferLeadership(arg1 string, arg2 uint64) (types.RaftStatus, error) {
+	fake.transferLeadershipMutex.Lock()
+	ret, specificReturn := fake.transferLeadershipReturnsOnCall[len(fake.transferLeadershipArgsForCall)]
+	fake.transferLeadershipArgsForCall = append(fake.transferLeadershipArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+	}{arg1, arg2})
+	stub := fake.TransferLeadershipStub
+	fakeReturns := fake.transferLeadershipReturns
+	fake.recordInvocation("TransferLeadership", []interface{}{arg1, arg2})
+	fake.transferLeadershipMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelManagement) TransferLeadershipCallCount() int {
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
+	return len(fake.transferLeadershipArgsForCall)
+}
+
+func (fake *ChannelManagement) TransferLeadershipCalls(stub func(string, uint64) (types.RaftStatus, error)) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = stub
+}
+
+func (fake *ChannelManagement) TransferLeadershipArgsForCall(i int) (string, uint64) {
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
+	argsForCall := fake.transferLeadershipArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChannelManagement) TransferLeadershipReturns(result1 types.RaftStatus, result2 error) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = nil
+	fake.transferLeadershipReturns = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelManagement) TransferLeadershipReturnsOnCall(i int, result1 types.RaftStatus, result2 error) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = nil
+	if fake.transferLeadershipReturnsOnCall == nil {
+		fake.transferLeadershipReturnsOnCall = make(map[int]struct {
+			result1 types.RaftStatus
+			result2 error
+		})
+	}
+	fake.transferLeadershipReturnsOnCall[i] = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChannelManagement) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -309,10 +735,22 @@ func (fake *ChannelManagement) Invocations() map[string][][]interface{} {
 	defer fake.channelInfoMutex.RUnlock()
 	fake.channelListMutex.RLock()
 	defer fake.channelListMutex.RUnlock()
+	fake.enterMaintenanceMutex.RLock()
+	defer fake.enterMaintenanceMutex.RUnlock()
+	fake.exitMaintenanceMutex.RLock()
+	defer fake.exitMaintenanceMutex.RUnlock()
 	fake.joinChannelMutex.RLock()
 	defer fake.joinChannelMutex.RUnlock()
+	fake.maintenanceStatusMutex.RLock()
+	defer fake.maintenanceStatusMutex.RUnlock()
+	fake.raftSnapshotMutex.RLock()
+	defer fake.raftSnapshotMutex.RUnlock()
+	fake.raftStatusMutex.RLock()
+	defer fake.raftStatusMutex.RUnlock()
 	fake.removeChannelMutex.RLock()
 	defer fake.removeChannelMutex.RUnlock()
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -22,6 +22,12 @@ type channelManagement interface {
 	ChannelInfo(channelID string) (types.ChannelInfo, error)
 	JoinChannel(channelID string, configBlock *cb.Block, isAppChannel bool) (types.ChannelInfo, error)
 	RemoveChannel(channelID string) error
+	RaftStatus(channelID string) (types.RaftStatus, error)
+	RaftSnapshot(channelID string) (types.RaftStatus, error)
+	TransferLeadership(channelID string, to uint64) (types.RaftStatus, error)
+	MaintenanceStatus() types.MaintenanceStatus
+	EnterMaintenance() types.MaintenanceStatus
+	ExitMaintenance() types.MaintenanceStatus
 }
 
 func TestOsnadmin(t *testing.T) {
@@ -0,0 +1,35 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// ErrorResponse is the body of the response of a handler to a request that failed.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// SendResponse writes the JSON encoding of payload as the body of a response with the given status
+// code. An error payload is sent as an ErrorResponse. A failure to write the body is logged to logger.
+func SendResponse(resp http.ResponseWriter, code int, payload interface{}, logger *flogging.FabricLogger) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		logger.Errorw("failed to encode payload", "error", err)
+	}
+}
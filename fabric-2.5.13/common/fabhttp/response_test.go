@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabhttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hyperledger/fabric/common/fabhttp"
+	"github.com/hyperledger/fabric/common/flogging"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SendResponse", func() {
+	var (
+		resp   *httptest.ResponseRecorder
+		logger *flogging.FabricLogger
+	)
+
+	BeforeEach(func() {
+		resp = httptest.NewRecorder()
+		logger = flogging.MustGetLogger("test")
+	})
+
+	It("encodes the payload as JSON", func() {
+		fabhttp.SendResponse(resp, http.StatusOK, map[string]string{"key": "value"}, logger)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Body).To(MatchJSON(`{"key":"value"}`))
+	})
+
+	It("sends an error as an error response", func() {
+		fabhttp.SendResponse(resp, http.StatusBadRequest, errors.New("oops"), logger)
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body).To(MatchJSON(`{"error":"oops"}`))
+	})
+})
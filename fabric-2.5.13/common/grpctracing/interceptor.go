@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpctracing
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const instrumentationName = "github.com/hyperledger/fabric/common/grpctracing"
+
+// UnaryServerInterceptor records a span for each unary request, continuing the trace propagated by the client.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		setStatus(span, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records a span for each stream, continuing the trace propagated by the client.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(svc interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(stream.Context(), info.FullMethod)
+		defer span.End()
+
+		wrappedStream := grpc_middleware.WrapServerStream(stream)
+		wrappedStream.WrappedContext = ctx
+
+		err := handler(svc, wrappedStream)
+		setStatus(span, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor records a span for each unary request, and propagates its trace context to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startClientSpan(ctx, method, cc.Target())
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		setStatus(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor records a span for each stream, and propagates its trace context to the server. The span
+// ends when the stream fails, is closed by the server, or its context is done.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startClientSpan(ctx, method, cc.Target())
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			setStatus(span, err)
+			span.End()
+			return nil, err
+		}
+		if !span.IsRecording() {
+			span.End()
+			return stream, nil
+		}
+
+		wrappedStream := &clientStream{ClientStream: stream, span: span}
+		go func() {
+			<-ctx.Done()
+			wrappedStream.end(nil)
+		}()
+		return wrappedStream, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	span trace.Span
+	once sync.Once
+}
+
+func (cs *clientStream) RecvMsg(m interface{}) error {
+	err := cs.ClientStream.RecvMsg(m)
+	if err == io.EOF {
+		cs.end(nil)
+	} else if err != nil {
+		cs.end(err)
+	}
+	return err
+}
+
+func (cs *clientStream) end(err error) {
+	cs.once.Do(func() {
+		setStatus(cs.span, err)
+		cs.span.End()
+	})
+}
+
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	return otel.Tracer(instrumentationName).Start(
+		ctx,
+		fullMethod,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(methodAttributes(fullMethod)...),
+	)
+}
+
+func startClientSpan(ctx context.Context, fullMethod, target string) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(instrumentationName).Start(
+		ctx,
+		fullMethod,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(methodAttributes(fullMethod)...),
+		trace.WithAttributes(attribute.String("server.address", target)),
+	)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+func methodAttributes(fullMethod string) []attribute.KeyValue {
+	service, method := "", strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		service, method = method[:i], method[i+1:]
+	}
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+	}
+}
+
+func setStatus(span trace.Span, err error) {
+	st, _ := status.FromError(err)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", st.Code().String()))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+}
+
+// metadataCarrier carries trace context in gRPC metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
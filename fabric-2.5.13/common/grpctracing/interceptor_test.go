@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpctracing_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/grpcmetrics/testpb"
+	"github.com/hyperledger/fabric/common/grpctracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	timeout = 5 * time.Second
+	tick    = 10 * time.Millisecond
+)
+
+type echoServer struct {
+	testpb.UnimplementedEchoServiceServer
+}
+
+func (*echoServer) Echo(ctx context.Context, msg *testpb.Message) (*testpb.Message, error) {
+	if msg.Message == "fail" {
+		return nil, status.Error(grpccodes.Aborted, "failed")
+	}
+	return msg, nil
+}
+
+func (*echoServer) EchoStream(stream testpb.EchoService_EchoStreamServer) error {
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func setup(t *testing.T) (testpb.EchoServiceClient, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(grpctracing.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(grpctracing.StreamServerInterceptor()),
+	)
+	testpb.RegisterEchoServiceServer(server, &echoServer{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(
+		listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(grpctracing.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(grpctracing.StreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return testpb.NewEchoServiceClient(conn), recorder
+}
+
+func spansByKind(spans []sdktrace.ReadOnlySpan) map[trace.SpanKind]sdktrace.ReadOnlySpan {
+	result := map[trace.SpanKind]sdktrace.ReadOnlySpan{}
+	for _, span := range spans {
+		result[span.SpanKind()] = span
+	}
+	return result
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	client, recorder := setup(t)
+
+	_, err := client.Echo(context.Background(), &testpb.Message{Message: "hello"})
+	require.NoError(t, err)
+
+	spans := spansByKind(recorder.Ended())
+	require.Len(t, spans, 2)
+	clientSpan, serverSpan := spans[trace.SpanKindClient], spans[trace.SpanKindServer]
+	require.Equal(t, "/testpb.EchoService/Echo", clientSpan.Name())
+	require.Equal(t, "/testpb.EchoService/Echo", serverSpan.Name())
+	require.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID(), "trace propagated to server")
+	require.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+	require.Contains(t, serverSpan.Attributes(), attribute.String("rpc.service", "testpb.EchoService"))
+	require.Contains(t, serverSpan.Attributes(), attribute.String("rpc.method", "Echo"))
+	require.Contains(t, serverSpan.Attributes(), attribute.String("rpc.grpc.status_code", "OK"))
+	require.Equal(t, codes.Unset, serverSpan.Status().Code)
+}
+
+func TestUnaryInterceptorsError(t *testing.T) {
+	client, recorder := setup(t)
+
+	_, err := client.Echo(context.Background(), &testpb.Message{Message: "fail"})
+	require.Error(t, err)
+
+	for _, span := range recorder.Ended() {
+		require.Equal(t, codes.Error, span.Status().Code)
+		require.Equal(t, "failed", span.Status().Description)
+		require.Contains(t, span.Attributes(), attribute.String("rpc.grpc.status_code", "Aborted"))
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	client, recorder := setup(t)
+
+	stream, err := client.EchoStream(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&testpb.Message{Message: "hello"}))
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+
+	require.Eventually(t, func() bool { return len(recorder.Ended()) == 2 }, timeout, tick)
+	spans := spansByKind(recorder.Ended())
+	clientSpan, serverSpan := spans[trace.SpanKindClient], spans[trace.SpanKindServer]
+	require.Equal(t, "/testpb.EchoService/EchoStream", clientSpan.Name())
+	require.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID(), "trace propagated to server")
+	require.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+}
+
+func TestStreamClientInterceptorContextDone(t *testing.T) {
+	client, recorder := setup(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := client.EchoStream(ctx)
+	require.NoError(t, err)
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, ok := spansByKind(recorder.Ended())[trace.SpanKindClient]
+		return ok
+	}, timeout, tick)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSampler(t *testing.T) {
+	sampled := func(sampler sdktrace.Sampler, parent trace.SpanContext) bool {
+		result := sampler.ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: trace.ContextWithSpanContext(context.Background(), parent),
+			TraceID:       trace.TraceID{0xff},
+			Name:          "test",
+		})
+		return result.Decision == sdktrace.RecordAndSample
+	}
+	parent := func(flags trace.TraceFlags, remote bool) trace.SpanContext {
+		return trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0xff},
+			SpanID:     trace.SpanID{1},
+			TraceFlags: flags,
+			Remote:     remote,
+		})
+	}
+
+	never, always := newSampler(0), newSampler(1)
+
+	t.Run("RemoteParentSampled", func(t *testing.T) {
+		require.False(t, sampled(never, parent(trace.FlagsSampled, true)), "client sampling flag is not trusted")
+		require.True(t, sampled(always, parent(trace.FlagsSampled, true)))
+	})
+
+	t.Run("RemoteParentNotSampled", func(t *testing.T) {
+		require.False(t, sampled(never, parent(0, true)))
+		require.True(t, sampled(always, parent(0, true)))
+	})
+
+	t.Run("LocalParent", func(t *testing.T) {
+		require.True(t, sampled(never, parent(trace.FlagsSampled, false)))
+		require.False(t, sampled(always, parent(0, false)))
+	})
+
+	t.Run("Root", func(t *testing.T) {
+		require.False(t, sampled(never, trace.SpanContext{}))
+		require.True(t, sampled(always, trace.SpanContext{}))
+	})
+}
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metadata"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+var logger = flogging.MustGetLogger("tracing")
+
+const (
+	// DisabledProvider does not record or propagate traces.
+	DisabledProvider = "disabled"
+	// OTLPProvider exports spans to an OpenTelemetry collector over OTLP/gRPC, and propagates W3C trace context.
+	OTLPProvider = "otlp"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// Config configures the tracing of requests.
+type Config struct {
+	// Provider is either DisabledProvider or OTLPProvider.
+	Provider string
+	// Endpoint is the address of the collector to which spans are exported.
+	Endpoint string
+	// Insecure connects to the collector without TLS.
+	Insecure bool
+	// SampleRatio is the fraction of the traces that are sampled, including the traces continued from the trace
+	// context of a client request.
+	SampleRatio float64
+}
+
+// Initialize installs the global tracer provider and propagator used to trace requests, according to the
+// configuration. It returns a function that flushes the spans that have not been exported yet, and stops tracing.
+// When tracing is disabled, the global no-op tracer provider is left in place and trace context is not propagated.
+func Initialize(serviceName, instanceID string, config Config) (shutdown func(), err error) {
+	switch config.Provider {
+	case "", DisabledProvider:
+		return func() {}, nil
+	case OTLPProvider:
+	default:
+		return nil, errors.Errorf("unknown tracing provider: %s", config.Provider)
+	}
+
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(context.Background(), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(newSampler(config.SampleRatio)),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+			semconv.ServiceInstanceID(instanceID),
+			semconv.ServiceVersion(metadata.Version),
+		)),
+	)
+
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warnf("Tracing error: %s", err)
+	}))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	logger.Infof("Exporting trace spans to %s", config.Endpoint)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			logger.Warnf("Failed to flush trace spans: %s", err)
+		}
+	}, nil
+}
+
+// newSampler returns a sampler that samples the given fraction of the traces. The sampling decision of a remote
+// parent span, which is sent by the client in the trace context of a request, is not trusted, so that clients cannot
+// make the node record every request. Since the decision depends only on the trace ID, the peers and orderers
+// configured with the same ratio sample the same traces. Child spans of local spans follow the decision of their parent.
+func newSampler(ratio float64) sdktrace.Sampler {
+	ratioSampler := sdktrace.TraceIDRatioBased(ratio)
+	return sdktrace.ParentBased(
+		ratioSampler,
+		sdktrace.WithRemoteParentSampled(ratioSampler),
+		sdktrace.WithRemoteParentNotSampled(ratioSampler),
+	)
+}
+
+// BytesMapCarrier carries trace context in a map of byte values, such as the decorations of a chaincode input.
+type BytesMapCarrier map[string][]byte
+
+// Get returns the value associated with the key.
+func (c BytesMapCarrier) Get(key string) string {
+	return string(c[key])
+}
+
+// Set stores the key-value pair.
+func (c BytesMapCarrier) Set(key, value string) {
+	c[key] = []byte(value)
+}
+
+// Keys lists the keys stored in the carrier.
+func (c BytesMapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Inject adds the trace context of the context to the carrier, if trace context is propagated.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
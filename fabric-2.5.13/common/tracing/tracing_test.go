@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInitializeDisabled(t *testing.T) {
+	for _, provider := range []string{"", tracing.DisabledProvider} {
+		shutdown, err := tracing.Initialize("test", "instance", tracing.Config{Provider: provider})
+		require.NoError(t, err)
+		shutdown()
+	}
+}
+
+func TestInitializeUnknownProvider(t *testing.T) {
+	_, err := tracing.Initialize("test", "instance", tracing.Config{Provider: "bogus"})
+	require.EqualError(t, err, "unknown tracing provider: bogus")
+}
+
+func TestInject(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	decorations := map[string][]byte{"existing": []byte("value")}
+	tracing.Inject(ctx, tracing.BytesMapCarrier(decorations))
+
+	require.Equal(t, []byte("00-01000000000000000000000000000000-0200000000000000-01"), decorations["traceparent"])
+	require.Equal(t, []byte("value"), decorations["existing"])
+
+	extracted := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(context.Background(), tracing.BytesMapCarrier(decorations)))
+	require.Equal(t, spanContext.TraceID(), extracted.TraceID())
+	require.Equal(t, spanContext.SpanID(), extracted.SpanID())
+}
@@ -63,6 +63,10 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.pResourcePolicyMap[resources.Snapshot_submitrequest] = policy.Admins
 	d.pResourcePolicyMap[resources.Snapshot_cancelrequest] = policy.Admins
 	d.pResourcePolicyMap[resources.Snapshot_listpending] = policy.Admins
+	d.cResourcePolicyMap[resources.Snapshot_fetch] = CHANNELREADERS
+
+	//-------------- private data ---------------
+	d.pResourcePolicyMap[resources.Pvtdata_export] = policy.Admins
 
 	//-------------- LSCC --------------
 	//p resources (implemented by the chaincode currently)
@@ -87,6 +91,7 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTxInvalidationInfo] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
@@ -111,6 +116,12 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	// Gateway resources
 	d.cResourcePolicyMap[resources.Gateway_CommitStatus] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Gateway_ChaincodeEvents] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_StorePreparedTransaction] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Gateway_GetPreparedTransaction] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_DeletePreparedTransaction] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Gateway_BlockEvents] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_FilteredBlockEvents] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_BlockAndPrivateDataEvents] = CHANNELREADERS
 
 	return d
 }
@@ -27,6 +27,10 @@ const (
 	Snapshot_submitrequest = "snapshot/submitrequest"
 	Snapshot_cancelrequest = "snapshot/cancelrequest"
 	Snapshot_listpending   = "snapshot/listpending"
+	Snapshot_fetch         = "snapshot/fetch"
+
+	// private data resources
+	Pvtdata_export = "pvtdata/export"
 
 	// Lscc resources
 	Lscc_Install                   = "lscc/Install"
@@ -40,11 +44,12 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	// Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo          = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber      = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash        = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID    = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID        = "qscc/GetBlockByTxID"
+	Qscc_GetTxInvalidationInfo = "qscc/GetTxInvalidationInfo"
 
 	// Cscc resources
 	Cscc_JoinChain            = "cscc/JoinChain"
@@ -63,6 +68,12 @@ const (
 	Event_FilteredBlock = "event/FilteredBlock"
 
 	// Gateway resources
-	Gateway_CommitStatus    = "gateway/CommitStatus"
-	Gateway_ChaincodeEvents = "gateway/ChaincodeEvents"
+	Gateway_CommitStatus              = "gateway/CommitStatus"
+	Gateway_ChaincodeEvents           = "gateway/ChaincodeEvents"
+	Gateway_StorePreparedTransaction  = "gateway/StorePreparedTransaction"
+	Gateway_GetPreparedTransaction    = "gateway/GetPreparedTransaction"
+	Gateway_DeletePreparedTransaction = "gateway/DeletePreparedTransaction"
+	Gateway_BlockEvents               = "gateway/BlockEvents"
+	Gateway_FilteredBlockEvents       = "gateway/FilteredBlockEvents"
+	Gateway_BlockAndPrivateDataEvents = "gateway/BlockAndPrivateDataEvents"
 )
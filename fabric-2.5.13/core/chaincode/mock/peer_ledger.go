@@ -8,6 +8,8 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 	ledgera "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/hotkeys"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 )
 
 type PeerLedger struct {
@@ -154,6 +156,16 @@ type PeerLedger struct {
 		result1 ledger.ConfigHistoryRetriever
 		result2 error
 	}
+	GetHotKeysStub        func() []*hotkeys.HotKey
+	getHotKeysMutex       sync.RWMutex
+	getHotKeysArgsForCall []struct {
+	}
+	getHotKeysReturns struct {
+		result1 []*hotkeys.HotKey
+	}
+	getHotKeysReturnsOnCall map[int]struct {
+		result1 []*hotkeys.HotKey
+	}
 	GetMissingPvtDataTrackerStub        func() (ledger.MissingPvtDataTracker, error)
 	getMissingPvtDataTrackerMutex       sync.RWMutex
 	getMissingPvtDataTrackerArgsForCall []struct {
@@ -207,6 +219,19 @@ type PeerLedger struct {
 		result1 *peer.ProcessedTransaction
 		result2 error
 	}
+	GetTxInvalidationInfoStub        func(string) (*txinvalidation.TxInvalidationInfo, error)
+	getTxInvalidationInfoMutex       sync.RWMutex
+	getTxInvalidationInfoArgsForCall []struct {
+		arg1 string
+	}
+	getTxInvalidationInfoReturns struct {
+		result1 *txinvalidation.TxInvalidationInfo
+		result2 error
+	}
+	getTxInvalidationInfoReturnsOnCall map[int]struct {
+		result1 *txinvalidation.TxInvalidationInfo
+		result2 error
+	}
 	GetTxValidationCodeByTxIDStub        func(string) (peer.TxValidationCode, uint64, error)
 	getTxValidationCodeByTxIDMutex       sync.RWMutex
 	getTxValidationCodeByTxIDArgsForCall []struct {
@@ -1005,6 +1030,58 @@ func (fake *PeerLedger) GetConfigHistoryRetrieverReturnsOnCall(i int, result1 le
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetHotKeys() []*hotkeys.HotKey {
+	fake.getHotKeysMutex.Lock()
+	ret, specificReturn := fake.getHotKeysReturnsOnCall[len(fake.getHotKeysArgsForCall)]
+	fake.getHotKeysArgsForCall = append(fake.getHotKeysArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetHotKeys", []interface{}{})
+	fake.getHotKeysMutex.Unlock()
+	if fake.GetHotKeysStub != nil {
+		return fake.GetHotKeysStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.getHotKeysReturns
+	return fakeReturns.result1
+}
+
+func (fake *PeerLedger) GetHotKeysCallCount() int {
+	fake.getHotKeysMutex.RLock()
+	defer fake.getHotKeysMutex.RUnlock()
+	return len(fake.getHotKeysArgsForCall)
+}
+
+func (fake *PeerLedger) GetHotKeysCalls(stub func() []*hotkeys.HotKey) {
+	fake.getHotKeysMutex.Lock()
+	defer fake.getHotKeysMutex.Unlock()
+	fake.GetHotKeysStub = stub
+}
+
+func (fake *PeerLedger) GetHotKeysReturns(result1 []*hotkeys.HotKey) {
+	fake.getHotKeysMutex.Lock()
+	defer fake.getHotKeysMutex.Unlock()
+	fake.GetHotKeysStub = nil
+	fake.getHotKeysReturns = struct {
+		result1 []*hotkeys.HotKey
+	}{result1}
+}
+
+func (fake *PeerLedger) GetHotKeysReturnsOnCall(i int, result1 []*hotkeys.HotKey) {
+	fake.getHotKeysMutex.Lock()
+	defer fake.getHotKeysMutex.Unlock()
+	fake.GetHotKeysStub = nil
+	if fake.getHotKeysReturnsOnCall == nil {
+		fake.getHotKeysReturnsOnCall = make(map[int]struct {
+			result1 []*hotkeys.HotKey
+		})
+	}
+	fake.getHotKeysReturnsOnCall[i] = struct {
+		result1 []*hotkeys.HotKey
+	}{result1}
+}
+
 func (fake *PeerLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, error) {
 	fake.getMissingPvtDataTrackerMutex.Lock()
 	ret, specificReturn := fake.getMissingPvtDataTrackerReturnsOnCall[len(fake.getMissingPvtDataTrackerArgsForCall)]
@@ -1251,6 +1328,69 @@ func (fake *PeerLedger) GetTransactionByIDReturnsOnCall(i int, result1 *peer.Pro
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetTxInvalidationInfo(arg1 string) (*txinvalidation.TxInvalidationInfo, error) {
+	fake.getTxInvalidationInfoMutex.Lock()
+	ret, specificReturn := fake.getTxInvalidationInfoReturnsOnCall[len(fake.getTxInvalidationInfoArgsForCall)]
+	fake.getTxInvalidationInfoArgsForCall = append(fake.getTxInvalidationInfoArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetTxInvalidationInfo", []interface{}{arg1})
+	fake.getTxInvalidationInfoMutex.Unlock()
+	if fake.GetTxInvalidationInfoStub != nil {
+		return fake.GetTxInvalidationInfoStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getTxInvalidationInfoReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) GetTxInvalidationInfoCallCount() int {
+	fake.getTxInvalidationInfoMutex.RLock()
+	defer fake.getTxInvalidationInfoMutex.RUnlock()
+	return len(fake.getTxInvalidationInfoArgsForCall)
+}
+
+func (fake *PeerLedger) GetTxInvalidationInfoCalls(stub func(string) (*txinvalidation.TxInvalidationInfo, error)) {
+	fake.getTxInvalidationInfoMutex.Lock()
+	defer fake.getTxInvalidationInfoMutex.Unlock()
+	fake.GetTxInvalidationInfoStub = stub
+}
+
+func (fake *PeerLedger) GetTxInvalidationInfoArgsForCall(i int) string {
+	fake.getTxInvalidationInfoMutex.RLock()
+	defer fake.getTxInvalidationInfoMutex.RUnlock()
+	argsForCall := fake.getTxInvalidationInfoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) GetTxInvalidationInfoReturns(result1 *txinvalidation.TxInvalidationInfo, result2 error) {
+	fake.getTxInvalidationInfoMutex.Lock()
+	defer fake.getTxInvalidationInfoMutex.Unlock()
+	fake.GetTxInvalidationInfoStub = nil
+	fake.getTxInvalidationInfoReturns = struct {
+		result1 *txinvalidation.TxInvalidationInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GetTxInvalidationInfoReturnsOnCall(i int, result1 *txinvalidation.TxInvalidationInfo, result2 error) {
+	fake.getTxInvalidationInfoMutex.Lock()
+	defer fake.getTxInvalidationInfoMutex.Unlock()
+	fake.GetTxInvalidationInfoStub = nil
+	if fake.getTxInvalidationInfoReturnsOnCall == nil {
+		fake.getTxInvalidationInfoReturnsOnCall = make(map[int]struct {
+			result1 *txinvalidation.TxInvalidationInfo
+			result2 error
+		})
+	}
+	fake.getTxInvalidationInfoReturnsOnCall[i] = struct {
+		result1 *txinvalidation.TxInvalidationInfo
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) GetTxValidationCodeByTxID(arg1 string) (peer.TxValidationCode, uint64, error) {
 	fake.getTxValidationCodeByTxIDMutex.Lock()
 	ret, specificReturn := fake.getTxValidationCodeByTxIDReturnsOnCall[len(fake.getTxValidationCodeByTxIDArgsForCall)]
@@ -1695,6 +1835,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getBlocksIteratorMutex.RUnlock()
 	fake.getConfigHistoryRetrieverMutex.RLock()
 	defer fake.getConfigHistoryRetrieverMutex.RUnlock()
+	fake.getHotKeysMutex.RLock()
+	defer fake.getHotKeysMutex.RUnlock()
 	fake.getMissingPvtDataTrackerMutex.RLock()
 	defer fake.getMissingPvtDataTrackerMutex.RUnlock()
 	fake.getPvtDataAndBlockByNumMutex.RLock()
@@ -1703,6 +1845,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getPvtDataByNumMutex.RUnlock()
 	fake.getTransactionByIDMutex.RLock()
 	defer fake.getTransactionByIDMutex.RUnlock()
+	fake.getTxInvalidationInfoMutex.RLock()
+	defer fake.getTxInvalidationInfoMutex.RUnlock()
 	fake.getTxValidationCodeByTxIDMutex.RLock()
 	defer fake.getTxValidationCodeByTxIDMutex.RUnlock()
 	fake.newHistoryQueryExecutorMutex.RLock()
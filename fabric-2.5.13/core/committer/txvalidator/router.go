@@ -21,6 +21,14 @@ type Validator interface {
 	Validate(block *common.Block) error
 }
 
+// InvalidationMessagesProvider is implemented by the validators that retain a description of the
+// reasons for which the transactions of the most recently validated block were marked invalid
+type InvalidationMessagesProvider interface {
+	// InvalidationMessages returns the descriptions keyed by transaction index, or nil if
+	// the given block is not the most recently validated one
+	InvalidationMessages(blockNum uint64) map[uint64]string
+}
+
 //go:generate mockery -dir . -name CapabilityProvider -case underscore -output mocks
 
 // CapabilityProvider contains functions to retrieve capability information for a channel
@@ -48,3 +56,16 @@ func (v *ValidationRouter) Validate(block *common.Block) error {
 		return v.V14Validator.Validate(block)
 	}
 }
+
+// InvalidationMessages returns the descriptions of the reasons for which the transactions of the given block
+// were marked invalid, if the validator currently in use retains them
+func (v *ValidationRouter) InvalidationMessages(blockNum uint64) map[uint64]string {
+	validator := v.V14Validator
+	if v.Capabilities().V2_0Validation() {
+		validator = v.V20Validator
+	}
+	if p, ok := validator.(InvalidationMessagesProvider); ok {
+		return p.InvalidationMessages(blockNum)
+	}
+	return nil
+}
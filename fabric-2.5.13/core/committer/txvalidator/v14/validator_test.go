@@ -38,9 +38,11 @@ import (
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/handlers/validation/builtin"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/hotkeys"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	mocktxvalidator "github.com/hyperledger/fabric/core/mocks/txvalidator"
 	mocks2 "github.com/hyperledger/fabric/discovery/support/mocks"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
@@ -1455,6 +1457,18 @@ func (m *mockLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCo
 	return args.Get(0).(peer.TxValidationCode), args.Get(1).(uint64), nil
 }
 
+// GetTxInvalidationInfo returns the invalidation info of a given tx
+func (m *mockLedger) GetTxInvalidationInfo(txID string) (*txinvalidation.TxInvalidationInfo, error) {
+	args := m.Called(txID)
+	return args.Get(0).(*txinvalidation.TxInvalidationInfo), args.Error(1)
+}
+
+// GetHotKeys returns the keys that caused the most conflicts
+func (m *mockLedger) GetHotKeys() []*hotkeys.HotKey {
+	args := m.Called()
+	return args.Get(0).([]*hotkeys.HotKey)
+}
+
 // NewTxSimulator creates new transaction simulator
 func (m *mockLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
 	args := m.Called()
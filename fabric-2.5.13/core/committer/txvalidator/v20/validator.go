@@ -8,6 +8,8 @@ package txvalidator
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -111,6 +113,16 @@ type TxValidator struct {
 	LedgerResources  LedgerResources
 	Dispatcher       Dispatcher
 	CryptoProvider   bccsp.BCCSP
+
+	invalidationMessagesLock sync.Mutex
+	invalidationMessages     *blockInvalidationMessages
+}
+
+// blockInvalidationMessages holds the descriptions of the reasons for which
+// the transactions of a block were marked invalid, keyed by transaction index
+type blockInvalidationMessages struct {
+	blockNum uint64
+	messages map[uint64]string
 }
 
 var logger = flogging.MustGetLogger("committer.txvalidator")
@@ -124,6 +136,7 @@ type blockValidationRequest struct {
 type blockValidationResult struct {
 	tIdx           int
 	validationCode peer.TxValidationCode
+	message        string
 	err            error
 	txid           string
 }
@@ -188,6 +201,8 @@ func (v *TxValidator) Validate(block *common.Block) error {
 	txsfltr := txflags.New(len(block.Data.Data))
 	// array of txids
 	txidArray := make([]string, len(block.Data.Data))
+	// descriptions of the reasons for invalidation
+	invalidationMessages := map[uint64]string{}
 
 	results := make(chan *blockValidationResult)
 	go func() {
@@ -232,6 +247,8 @@ func (v *TxValidator) Validate(block *common.Block) error {
 
 			if res.validationCode == peer.TxValidationCode_VALID {
 				txidArray[res.tIdx] = res.txid
+			} else if res.message != "" {
+				invalidationMessages[uint64(res.tIdx)] = res.message
 			}
 		}
 	}
@@ -258,12 +275,31 @@ func (v *TxValidator) Validate(block *common.Block) error {
 
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsfltr
 
+	v.invalidationMessagesLock.Lock()
+	v.invalidationMessages = &blockInvalidationMessages{
+		blockNum: block.Header.Number,
+		messages: invalidationMessages,
+	}
+	v.invalidationMessagesLock.Unlock()
+
 	elapsedValidation := time.Since(startValidation) / time.Millisecond // duration in ms
 	logger.Infof("[%s] Validated block [%d] in %dms", v.ChannelID, block.Header.Number, elapsedValidation)
 
 	return nil
 }
 
+// InvalidationMessages returns the descriptions of the reasons for which the transactions of the given block
+// were marked invalid, keyed by transaction index. Only the messages of the most recently validated block are
+// retained; nil is returned for any other block
+func (v *TxValidator) InvalidationMessages(blockNum uint64) map[uint64]string {
+	v.invalidationMessagesLock.Lock()
+	defer v.invalidationMessagesLock.Unlock()
+	if v.invalidationMessages == nil || v.invalidationMessages.blockNum != blockNum {
+		return nil
+	}
+	return v.invalidationMessages.messages
+}
+
 // allValidated returns error if some of the validation flags have not been set
 // during validation
 func (v *TxValidator) allValidated(txsfltr txflags.ValidationFlags, block *common.Block) error {
@@ -312,6 +348,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 		results <- &blockValidationResult{
 			tIdx:           tIdx,
 			validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
+			message:        err.Error(),
 		}
 		return
 	} else if env != nil {
@@ -341,6 +378,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 			results <- &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
+				message:        err.Error(),
 			}
 			return
 		}
@@ -353,6 +391,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 			results <- &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_TARGET_CHAIN_NOT_FOUND,
+				message:        fmt.Sprintf("channel %s does not exist", channel),
 			}
 			return
 		}
@@ -390,6 +429,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 					results <- &blockValidationResult{
 						tIdx:           tIdx,
 						validationCode: cde,
+						message:        err.Error(),
 					}
 					return
 				}
@@ -423,6 +463,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 			results <- &blockValidationResult{
 				tIdx:           tIdx,
 				validationCode: peer.TxValidationCode_UNKNOWN_TX_TYPE,
+				message:        fmt.Sprintf("unknown transaction type [%s]", common.HeaderType(chdr.Type)),
 			}
 			return
 		}
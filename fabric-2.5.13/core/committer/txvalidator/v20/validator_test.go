@@ -319,6 +319,11 @@ func TestInvokeNoRWSet(t *testing.T) {
 	err := v.Validate(b)
 	require.NoError(t, err)
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+
+	messages := v.InvalidationMessages(2)
+	require.Len(t, messages, 1)
+	require.Contains(t, messages[0], "signature set did not satisfy policy")
+	require.Nil(t, v.InvalidationMessages(1))
 }
 
 // SerializedIdentity mock for the parallel validation test
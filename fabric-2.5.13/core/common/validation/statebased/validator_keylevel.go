@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api/policies"
@@ -277,10 +278,30 @@ func (klv *KeyLevelValidator) Validate(cc string, blockNum, txNum uint64, rwsetB
 		proto.Unmarshal(ccEP, endorsementPolicy)
 		logger.Warnw("Endorsment policy failure", "error", err, "chaincode", cc, "endorsementPolicy", endorsementPolicy, "endorsingIdentities", protoutil.LogMessageForSerializedIdentities(signatureSet))
 
+		// the organizations of the endorsers are added to the error so that the reason
+		// for the failure is recorded along with the invalidated transaction
+		if pe, ok := err.(*commonerrors.VSCCEndorsementPolicyError); ok {
+			return policyErr(errors.WithMessagef(pe.Err, "endorsements provided by organizations %v", endorsingMSPIDs(signatureSet)))
+		}
 	}
 	return err
 }
 
+// endorsingMSPIDs returns the distinct MSP IDs of the identities of the given signed data
+func endorsingMSPIDs(signatureSet []*protoutil.SignedData) []string {
+	var mspIDs []string
+	seen := map[string]bool{}
+	for _, sd := range signatureSet {
+		sID := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(sd.Identity, sID); err != nil || seen[sID.Mspid] {
+			continue
+		}
+		seen[sID.Mspid] = true
+		mspIDs = append(mspIDs, sID.Mspid)
+	}
+	return mspIDs
+}
+
 // PostValidate implements the function of the StateBasedValidator interface
 func (klv *KeyLevelValidator) PostValidate(cc string, blockNum, txNum uint64, err error) {
 	klv.vpmgr.SetTxValidationResult(cc, blockNum, txNum, err)
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -492,3 +493,26 @@ func TestKeylevelValidationFailure(t *testing.T) {
 	require.Error(t, err)
 	require.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
 }
+
+func TestKeylevelValidationFailureReportsEndorsingOrgs(t *testing.T) {
+	t.Parallel()
+
+	mr := &mockState{}
+	ms := &mockStateFetcher{FetchStateRv: mr}
+	pm := &KeyLevelValidationParameterManagerImpl{PolicyTranslator: &mockTranslator{}, StateFetcher: ms}
+	pe := &mockPolicyEvaluator{EvaluateRV: fmt.Errorf("signature set did not satisfy policy")}
+	validator := NewKeyLevelValidator(NewV13Evaluator(pe, pm), pm)
+
+	rwsb := rwsetBytes(t, "cc")
+	block := buildBlockWithTxs(buildTXWithRwset(rwsb))
+	validator.PreValidate(0, block)
+
+	endorsements := []*pb.Endorsement{
+		{Signature: []byte("signature"), Endorser: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("id1")})},
+		{Signature: []byte("signature"), Endorser: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("id2")})},
+	}
+
+	err := validator.Validate("cc", 1, 0, rwsb, []byte("barf"), []byte("CCEP"), endorsements)
+	require.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
+	require.Contains(t, err.Error(), "endorsements provided by organizations [Org1MSP]")
+}
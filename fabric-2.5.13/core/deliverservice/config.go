@@ -23,6 +23,8 @@ const (
 	DefaultReConnectBackoffThreshold   = time.Hour * 1
 	DefaultReConnectTotalTimeThreshold = time.Second * 60 * 60
 	DefaultConnectionTimeout           = time.Second * 3
+	DefaultCrossCheckInterval          = time.Second * 5
+	DefaultSuspicionThreshold          = time.Second * 30
 )
 
 // DeliverServiceConfig is the struct that defines the deliverservice configuration.
@@ -46,6 +48,19 @@ type DeliverServiceConfig struct {
 	// OrdererEndpointOverrides is a map of orderer addresses which should be
 	// re-mapped to a different orderer endpoint.
 	OrdererEndpointOverrides map[string]*orderers.Endpoint
+
+	// CrossCheckEnabled enables the gossip free block delivery mode, in which every peer
+	// pulls blocks from an ordering service node, and cross-checks its progress with
+	// other ordering service nodes in order to detect withheld blocks.
+	CrossCheckEnabled bool
+	// CrossCheckOrderers is the number of other ordering service nodes to cross-check
+	// with, zero means all of them.
+	CrossCheckOrderers int
+	// CrossCheckInterval sets the interval between cross-checks.
+	CrossCheckInterval time.Duration
+	// SuspicionThreshold sets the time an ordering service node is given to deliver a block
+	// other ordering service nodes already have, before the peer switches to another one.
+	SuspicionThreshold time.Duration
 }
 
 type AddressOverride struct {
@@ -62,8 +77,13 @@ func GlobalConfig() *DeliverServiceConfig {
 }
 
 func LoadOverridesMap() (map[string]*orderers.Endpoint, error) {
+	return LoadOverridesMapFrom(viper.GetViper())
+}
+
+// LoadOverridesMapFrom loads the orderer endpoint overrides from the given viper instance.
+func LoadOverridesMapFrom(v *viper.Viper) (map[string]*orderers.Endpoint, error) {
 	var overrides []AddressOverride
-	err := viper.UnmarshalKey("peer.deliveryclient.addressOverrides", &overrides)
+	err := v.UnmarshalKey("peer.deliveryclient.addressOverrides", &overrides)
 	if err != nil {
 		return nil, errors.WithMessage(err, "could not unmarshal peer.deliveryclient.addressOverrides")
 	}
@@ -141,6 +161,21 @@ func (c *DeliverServiceConfig) loadDeliverServiceConfig() {
 		c.ConnectionTimeout = DefaultConnectionTimeout
 	}
 
+	c.CrossCheckEnabled = viper.GetBool("peer.deliveryclient.crossCheck.enabled")
+	c.CrossCheckOrderers = viper.GetInt("peer.deliveryclient.crossCheck.orderers")
+	c.CrossCheckInterval = viper.GetDuration("peer.deliveryclient.crossCheck.interval")
+	if c.CrossCheckInterval == 0 {
+		c.CrossCheckInterval = DefaultCrossCheckInterval
+	}
+	c.SuspicionThreshold = viper.GetDuration("peer.deliveryclient.crossCheck.suspicionThreshold")
+	if c.SuspicionThreshold == 0 {
+		c.SuspicionThreshold = DefaultSuspicionThreshold
+	}
+	if c.CrossCheckEnabled && c.BlockGossipEnabled {
+		logger.Infof("peer.deliveryclient.crossCheck.enabled is set, block gossip is disabled")
+		c.BlockGossipEnabled = false
+	}
+
 	c.KeepaliveOptions = comm.DefaultKeepaliveOptions
 	if viper.IsSet("peer.keepalive.deliveryClient.interval") {
 		c.KeepaliveOptions.ClientInterval = viper.GetDuration("peer.keepalive.deliveryClient.interval")
@@ -88,11 +88,15 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.deliveryclient.connTimeout", "10s")
 	viper.Set("peer.keepalive.deliveryClient.interval", "5s")
 	viper.Set("peer.keepalive.deliveryClient.timeout", "2s")
+	viper.Set("peer.deliveryclient.crossCheck.enabled", true)
+	viper.Set("peer.deliveryclient.crossCheck.orderers", 2)
+	viper.Set("peer.deliveryclient.crossCheck.interval", "3s")
+	viper.Set("peer.deliveryclient.crossCheck.suspicionThreshold", "15s")
 
 	coreConfig := deliverservice.GlobalConfig()
 
 	expectedConfig := &deliverservice.DeliverServiceConfig{
-		BlockGossipEnabled:          true,
+		BlockGossipEnabled:          false,
 		PeerTLSEnabled:              true,
 		ReConnectBackoffThreshold:   25 * time.Second,
 		ReconnectTotalTimeThreshold: 20 * time.Second,
@@ -107,6 +111,10 @@ func TestGlobalConfig(t *testing.T) {
 		SecOpts: comm.SecureOptions{
 			UseTLS: true,
 		},
+		CrossCheckEnabled:  true,
+		CrossCheckOrderers: 2,
+		CrossCheckInterval: 3 * time.Second,
+		SuspicionThreshold: 15 * time.Second,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
@@ -125,6 +133,8 @@ func TestGlobalConfigDefault(t *testing.T) {
 		ReconnectTotalTimeThreshold: deliverservice.DefaultReConnectTotalTimeThreshold,
 		ConnectionTimeout:           deliverservice.DefaultConnectionTimeout,
 		KeepaliveOptions:            comm.DefaultKeepaliveOptions,
+		CrossCheckInterval:          deliverservice.DefaultCrossCheckInterval,
+		SuspicionThreshold:          deliverservice.DefaultSuspicionThreshold,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
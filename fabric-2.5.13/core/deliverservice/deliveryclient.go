@@ -139,6 +139,14 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 		BlockGossipDisabled: !d.conf.DeliverServiceConfig.BlockGossipEnabled,
 		InitialRetryDelay:   100 * time.Millisecond,
 		YieldLeadership:     !d.conf.IsStaticLeader,
+		CrossCheckEnabled:   d.conf.DeliverServiceConfig.CrossCheckEnabled,
+		CrossCheckOrderers:  d.conf.DeliverServiceConfig.CrossCheckOrderers,
+		CrossCheckInterval:  d.conf.DeliverServiceConfig.CrossCheckInterval,
+		SuspicionThreshold:  d.conf.DeliverServiceConfig.SuspicionThreshold,
+	}
+
+	if dc.CrossCheckEnabled {
+		logger.Infow("This peer will cross-check the blocks it retrieves from ordering service with other ordering service nodes", "channel", chainID)
 	}
 
 	if dc.BlockGossipDisabled {
@@ -26,10 +26,17 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-var endorserLogger = flogging.MustGetLogger("endorser")
+var (
+	endorserLogger = flogging.MustGetLogger("endorser")
+	tracer         = otel.Tracer("github.com/hyperledger/fabric/core/endorser")
+)
 
 // The Jira issue that documents Endorser flow along with its relationship to
 // the lifecycle chaincode - https://jira.hyperledger.org/browse/FAB-181
@@ -60,7 +67,7 @@ type Support interface {
 	IsSysCC(name string) bool
 
 	// Execute - execute proposal, return original response of chaincode
-	Execute(txParams *ccprovider.TransactionParams, name string, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error)
+	Execute(ctx context.Context, txParams *ccprovider.TransactionParams, name string, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error)
 
 	// ExecuteLegacyInit - executes a deployment proposal, return original response of chaincode
 	ExecuteLegacyInit(txParams *ccprovider.TransactionParams, name, version string, spec *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error)
@@ -104,7 +111,17 @@ type Endorser struct {
 }
 
 // call specified chaincode (system or user)
-func (e *Endorser) callChaincode(txParams *ccprovider.TransactionParams, input *pb.ChaincodeInput, chaincodeName string) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (e *Endorser) callChaincode(ctx context.Context, txParams *ccprovider.TransactionParams, input *pb.ChaincodeInput, chaincodeName string) (res *pb.Response, ccevent *pb.ChaincodeEvent, err error) {
+	ctx, span := tracer.Start(ctx, "endorser.callChaincode", trace.WithAttributes(attribute.String("fabric.chaincode", chaincodeName)))
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		} else if res.GetStatus() >= shim.ERRORTHRESHOLD {
+			span.SetStatus(codes.Error, res.GetMessage())
+		}
+		span.End()
+	}()
+
 	defer func(start time.Time) {
 		logger := endorserLogger.WithOptions(zap.AddCallerSkip(1))
 		logger = decorateLogger(logger, txParams)
@@ -117,7 +134,7 @@ func (e *Endorser) callChaincode(txParams *ccprovider.TransactionParams, input *
 		"chaincode", chaincodeName,
 	}
 
-	res, ccevent, err := e.Support.Execute(txParams, chaincodeName, input)
+	res, ccevent, err = e.Support.Execute(ctx, txParams, chaincodeName, input)
 	if err != nil {
 		e.Metrics.SimulationFailure.With(meterLabels...).Add(1)
 		return nil, nil, err
@@ -175,7 +192,7 @@ func (e *Endorser) callChaincode(txParams *ccprovider.TransactionParams, input *
 }
 
 // SimulateProposal simulates the proposal by calling the chaincode
-func (e *Endorser) simulateProposal(txParams *ccprovider.TransactionParams, chaincodeName string, chaincodeInput *pb.ChaincodeInput) (*pb.Response, []byte, *pb.ChaincodeEvent, *pb.ChaincodeInterest, error) {
+func (e *Endorser) simulateProposal(ctx context.Context, txParams *ccprovider.TransactionParams, chaincodeName string, chaincodeInput *pb.ChaincodeInput) (*pb.Response, []byte, *pb.ChaincodeEvent, *pb.ChaincodeInterest, error) {
 	logger := decorateLogger(endorserLogger, txParams)
 
 	meterLabels := []string{
@@ -184,7 +201,7 @@ func (e *Endorser) simulateProposal(txParams *ccprovider.TransactionParams, chai
 	}
 
 	// ---3. execute the proposal and get simulation results
-	res, ccevent, err := e.callChaincode(txParams, chaincodeInput, chaincodeName)
+	res, ccevent, err := e.callChaincode(ctx, txParams, chaincodeInput, chaincodeName)
 	if err != nil {
 		logger.Errorf("failed to invoke chaincode %s, error: %+v", chaincodeName, err)
 		return nil, nil, nil, nil, err
@@ -306,6 +323,9 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	startTime := time.Now()
 	e.Metrics.ProposalsReceived.Add(1)
 
+	ctx, span := tracer.Start(ctx, "endorser.ProcessProposal")
+	defer span.End()
+
 	addr := util.ExtractRemoteAddress(ctx)
 	endorserLogger.Debug("request from", addr)
 
@@ -331,6 +351,12 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		}
 	}
 
+	span.SetAttributes(
+		attribute.String("fabric.channel", up.ChannelHeader.ChannelId),
+		attribute.String("fabric.chaincode", up.ChaincodeName),
+		attribute.String("fabric.txid", up.ChannelHeader.TxId),
+	)
+
 	// 0 -- check and validate
 	err = e.preProcess(up, channel)
 	if err != nil {
@@ -347,7 +373,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		e.Metrics.ProposalDuration.With(meterLabels...).Observe(time.Since(startTime).Seconds())
 	}()
 
-	pResp, err := e.ProcessProposalSuccessfullyOrError(up)
+	pResp, err := e.ProcessProposalSuccessfullyOrError(ctx, up)
 	if err != nil {
 		endorserLogger.Warnw("Failed to invoke chaincode", "channel", up.ChannelHeader.ChannelId, "chaincode", up.ChaincodeName, "error", err.Error())
 		// Return a nil error since clients are expected to look at the ProposalResponse response status code (500) and message.
@@ -366,7 +392,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	return pResp, nil
 }
 
-func (e *Endorser) ProcessProposalSuccessfullyOrError(up *UnpackedProposal) (*pb.ProposalResponse, error) {
+func (e *Endorser) ProcessProposalSuccessfullyOrError(ctx context.Context, up *UnpackedProposal) (*pb.ProposalResponse, error) {
 	txParams := &ccprovider.TransactionParams{
 		ChannelID:  up.ChannelHeader.ChannelId,
 		TxID:       up.ChannelHeader.TxId,
@@ -406,7 +432,7 @@ func (e *Endorser) ProcessProposalSuccessfullyOrError(up *UnpackedProposal) (*pb
 	}
 
 	// 1 -- simulate
-	res, simulationResult, ccevent, ccInterest, err := e.simulateProposal(txParams, up.ChaincodeName, up.Input)
+	res, simulationResult, ccevent, ccInterest, err := e.simulateProposal(ctx, txParams, up.ChaincodeName, up.Input)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error in simulation")
 	}
@@ -490,7 +490,7 @@ var _ = Describe("Endorser", func() {
 		_, err := e.ProcessProposal(context.Background(), signedProposal)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(fakeSupport.ExecuteCallCount()).To(Equal(1))
-		txParams, chaincodeName, input := fakeSupport.ExecuteArgsForCall(0)
+		_, txParams, chaincodeName, input := fakeSupport.ExecuteArgsForCall(0)
 		Expect(txParams.ChannelID).To(Equal("channel-id"))
 		Expect(txParams.SignedProp).To(Equal(signedProposal))
 		Expect(txParams.TXSimulator).To(Equal(fakeTxSimulator))
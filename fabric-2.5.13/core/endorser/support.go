@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package endorser
 
 import (
+	"context"
 	"fmt"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode"
@@ -116,11 +118,16 @@ func (s *SupportImpl) ExecuteLegacyInit(txParams *ccprovider.TransactionParams,
 }
 
 // Execute a proposal and return the chaincode response
-func (s *SupportImpl) Execute(txParams *ccprovider.TransactionParams, name string, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
+func (s *SupportImpl) Execute(ctx context.Context, txParams *ccprovider.TransactionParams, name string, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
 	// decorate the chaincode input
 	decorators := library.InitRegistry(library.Config{}).Lookup(library.Decoration).([]decoration.Decorator)
 	input.Decorations = make(map[string][]byte)
 	input = decoration.Apply(txParams.Proposal, input, decorators...)
+	// propagate the trace context to the chaincode, which can read it from the decorations
+	if input.Decorations == nil {
+		input.Decorations = make(map[string][]byte)
+	}
+	tracing.Inject(ctx, tracing.BytesMapCarrier(input.Decorations))
 	txParams.ProposalDecorations = input.Decorations
 
 	return s.ChaincodeSupport.Execute(txParams, name, input)
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hotkeys
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/fabhttp"
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// HotKeysFunc returns the hot keys of a channel, and false if the channel does not exist.
+type HotKeysFunc func(channelID string) ([]*HotKey, bool)
+
+// HotKeysResponse is the response of the hot keys handler.
+type HotKeysResponse struct {
+	Channel string    `json:"channel"`
+	HotKeys []*HotKey `json:"hot_keys"`
+}
+
+// Handler serves the keys of a channel that caused the most conflicts, for the channel
+// specified via the query parameter "channel".
+type Handler struct {
+	HotKeys HotKeysFunc
+	Logger  *flogging.FabricLogger
+}
+
+func NewHandler(hotKeys HotKeysFunc) *Handler {
+	return &Handler{
+		HotKeys: hotKeys,
+		Logger:  flogging.MustGetLogger("ledger.hotkeys"),
+	}
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		fabhttp.SendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method), h.Logger)
+		return
+	}
+
+	channelID := req.URL.Query().Get("channel")
+	if channelID == "" {
+		fabhttp.SendResponse(resp, http.StatusBadRequest, fmt.Errorf("missing query parameter: channel"), h.Logger)
+		return
+	}
+
+	hotKeys, ok := h.HotKeys(channelID)
+	if !ok {
+		fabhttp.SendResponse(resp, http.StatusNotFound, fmt.Errorf("channel does not exist: %s", channelID), h.Logger)
+		return
+	}
+	if hotKeys == nil {
+		hotKeys = []*HotKey{}
+	}
+	fabhttp.SendResponse(resp, http.StatusOK, &HotKeysResponse{Channel: channelID, HotKeys: hotKeys}, h.Logger)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hotkeys
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler(func(channelID string) ([]*HotKey, bool) {
+		switch channelID {
+		case "mychannel":
+			return []*HotKey{{Namespace: "ns", Key: "key1", Conflicts: 2, LastBlock: 5}}, true
+		case "emptychannel":
+			return nil, true
+		default:
+			return nil, false
+		}
+	})
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "hot keys",
+			method:       http.MethodGet,
+			url:          "/ledger/hotkeys?channel=mychannel",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"channel":"mychannel","hot_keys":[{"namespace":"ns","key":"key1","conflicts":2,"error":0,"last_block":5}]}`,
+		},
+		{
+			name:         "no hot keys",
+			method:       http.MethodGet,
+			url:          "/ledger/hotkeys?channel=emptychannel",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"channel":"emptychannel","hot_keys":[]}`,
+		},
+		{
+			name:         "missing channel",
+			method:       http.MethodGet,
+			url:          "/ledger/hotkeys",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"missing query parameter: channel"}`,
+		},
+		{
+			name:         "unknown channel",
+			method:       http.MethodGet,
+			url:          "/ledger/hotkeys?channel=unknown",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"channel does not exist: unknown"}`,
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodPost,
+			url:          "/ledger/hotkeys?channel=mychannel",
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: `{"error":"invalid request method: POST"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest(tt.method, tt.url, nil))
+			require.Equal(t, tt.expectedCode, resp.Code)
+			require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+			require.JSONEq(t, tt.expectedBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hotkeys
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// HotKey reports the number of transactions that were invalidated because of a conflict on a key,
+// or on the results of a range query, of a namespace.
+type HotKey struct {
+	Namespace  string `json:"namespace"`
+	Collection string `json:"collection,omitempty"`
+	// Key is the conflicting key, or the start key of a conflicting range query. It is empty for a private data key,
+	// in which case KeyHash carries the hex encoded hash of the key.
+	Key     string `json:"key,omitempty"`
+	KeyHash string `json:"key_hash,omitempty"`
+	// RangeQuery is true if the conflict was on the results of a range query, in which case RangeEnd is the end key
+	// of the range query. An empty end key represents an unbounded range query.
+	RangeQuery bool   `json:"range_query,omitempty"`
+	RangeEnd   string `json:"range_end,omitempty"`
+	// Conflicts is the number of conflicts counted for the key. As the tracker is bounded, the count may be
+	// overestimated by at most Error.
+	Conflicts uint64 `json:"conflicts"`
+	Error     uint64 `json:"error"`
+	// LastBlock is the number of the last block in which a conflict on the key was found.
+	LastBlock uint64 `json:"last_block"`
+}
+
+type hotKeyID struct {
+	namespace, collection, key, keyHash string
+	rangeQuery                          bool
+	rangeEnd                            string
+}
+
+func (h *HotKey) id() hotKeyID {
+	return hotKeyID{
+		namespace:  h.Namespace,
+		collection: h.Collection,
+		key:        h.Key,
+		keyHash:    h.KeyHash,
+		rangeQuery: h.RangeQuery,
+		rangeEnd:   h.RangeEnd,
+	}
+}
+
+// Tracker keeps the approximate top-K keys by number of conflicts, using at most capacity entries, by
+// means of the space-saving algorithm. When a key that is not tracked conflicts while the tracker is full,
+// the key with the least number of conflicts is evicted and the new key inherits its count as the error.
+// A key that conflicts more often than 1/capacity of all the conflicts is guaranteed to be tracked.
+type Tracker struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[hotKeyID]*HotKey
+}
+
+// NewTracker constructs a Tracker that keeps at most capacity keys. A capacity less than 1 disables the tracking.
+func NewTracker(capacity int) *Tracker {
+	return &Tracker{
+		capacity: capacity,
+		entries:  map[hotKeyID]*HotKey{},
+	}
+}
+
+// Add records a conflict on the key identified by the namespace, collection, key, key hash and range
+// fields of hotKey, found in the block blockNum.
+func (t *Tracker) Add(hotKey *HotKey, blockNum uint64) {
+	if t.capacity < 1 {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	id := hotKey.id()
+	if e, ok := t.entries[id]; ok {
+		e.Conflicts++
+		e.LastBlock = blockNum
+		return
+	}
+
+	e := &HotKey{
+		Namespace:  hotKey.Namespace,
+		Collection: hotKey.Collection,
+		Key:        hotKey.Key,
+		KeyHash:    hotKey.KeyHash,
+		RangeQuery: hotKey.RangeQuery,
+		RangeEnd:   hotKey.RangeEnd,
+		Conflicts:  1,
+		LastBlock:  blockNum,
+	}
+	if len(t.entries) >= t.capacity {
+		var minID hotKeyID
+		var min *HotKey
+		for id, entry := range t.entries {
+			if min == nil || less(entry, min) {
+				minID, min = id, entry
+			}
+		}
+		delete(t.entries, minID)
+		e.Conflicts += min.Conflicts
+		e.Error = min.Conflicts
+	}
+	t.entries[id] = e
+}
+
+// Top returns a copy of the tracked keys in the decreasing order of the number of conflicts.
+func (t *Tracker) Top() []*HotKey {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	top := make([]*HotKey, 0, len(t.entries))
+	for _, e := range t.entries {
+		c := *e
+		top = append(top, &c)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		return less(top[j], top[i])
+	})
+	return top
+}
+
+// less orders the keys by number of conflicts and, for the same number of conflicts, places the
+// least recently conflicting key first so that it is evicted first
+func less(a, b *HotKey) bool {
+	if a.Conflicts != b.Conflicts {
+		return a.Conflicts < b.Conflicts
+	}
+	if a.LastBlock != b.LastBlock {
+		return a.LastBlock < b.LastBlock
+	}
+	ai, bi := a.id(), b.id()
+	switch {
+	case ai.namespace != bi.namespace:
+		return ai.namespace > bi.namespace
+	case ai.collection != bi.collection:
+		return ai.collection > bi.collection
+	case ai.key != bi.key:
+		return ai.key > bi.key
+	case ai.keyHash != bi.keyHash:
+		return ai.keyHash > bi.keyHash
+	case ai.rangeQuery != bi.rangeQuery:
+		return ai.rangeQuery
+	default:
+		return ai.rangeEnd > bi.rangeEnd
+	}
+}
+
+// maxKeyPrefixLength bounds the length of a key prefix
+const maxKeyPrefixLength = 32
+
+// KeyPrefix returns the part of a key that identifies the kind of data stored under the key, so as to
+// aggregate the conflicts without creating a label value per key. For a composite key, this is the object type.
+// Otherwise, it is the leading run of letters of the key (e.g., "asset" for "asset1234" or "user" for "user:alice").
+func KeyPrefix(key string) string {
+	var prefix string
+	if strings.HasPrefix(key, compositeKeyNamespace) {
+		prefix = strings.SplitN(key[len(compositeKeyNamespace):], minUnicodeRuneValue, 2)[0]
+	} else {
+		end := strings.IndexFunc(key, func(r rune) bool { return !unicode.IsLetter(r) })
+		if end == -1 {
+			end = len(key)
+		}
+		prefix = key[:end]
+	}
+	if len(prefix) > maxKeyPrefixLength {
+		prefix = strings.ToValidUTF8(prefix[:maxKeyPrefixLength], "")
+	}
+	return prefix
+}
+
+// compositeKeyNamespace and minUnicodeRuneValue mirror the delimiters used by the chaincode shim
+// for composite keys
+const (
+	compositeKeyNamespace = "\x00"
+	minUnicodeRuneValue   = "\x00"
+)
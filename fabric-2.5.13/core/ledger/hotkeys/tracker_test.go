@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hotkeys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker(3)
+	key := func(k string) *HotKey { return &HotKey{Namespace: "ns", Key: k} }
+
+	tracker.Add(key("key1"), 1)
+	tracker.Add(key("key2"), 1)
+	tracker.Add(key("key1"), 2)
+	tracker.Add(&HotKey{Namespace: "ns", Collection: "coll", KeyHash: "6b657931"}, 2)
+	require.Equal(t, []*HotKey{
+		{Namespace: "ns", Key: "key1", Conflicts: 2, LastBlock: 2},
+		{Namespace: "ns", Collection: "coll", KeyHash: "6b657931", Conflicts: 1, LastBlock: 2},
+		{Namespace: "ns", Key: "key2", Conflicts: 1, LastBlock: 1},
+	}, tracker.Top())
+
+	// key2 has the least conflicts and is the least recently conflicting key
+	tracker.Add(&HotKey{Namespace: "ns", Key: "key1", RangeQuery: true}, 3)
+	require.Equal(t, []*HotKey{
+		{Namespace: "ns", Key: "key1", RangeQuery: true, Conflicts: 2, Error: 1, LastBlock: 3},
+		{Namespace: "ns", Key: "key1", Conflicts: 2, LastBlock: 2},
+		{Namespace: "ns", Collection: "coll", KeyHash: "6b657931", Conflicts: 1, LastBlock: 2},
+	}, tracker.Top())
+
+	t.Run("returns a copy", func(t *testing.T) {
+		top := tracker.Top()
+		top[0].Conflicts = 100
+		require.Equal(t, uint64(2), tracker.Top()[0].Conflicts)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		tracker := NewTracker(0)
+		tracker.Add(key("key1"), 1)
+		require.Empty(t, tracker.Top())
+	})
+}
+
+func TestTrackerKeepsFrequentKeys(t *testing.T) {
+	tracker := NewTracker(5)
+	for i := 0; i < 1000; i++ {
+		if i%4 == 0 {
+			tracker.Add(&HotKey{Namespace: "ns", Key: "hot"}, uint64(i))
+			continue
+		}
+		tracker.Add(&HotKey{Namespace: "ns", Key: testKey(i)}, uint64(i))
+	}
+	top := tracker.Top()
+	require.Len(t, top, 5)
+	require.Equal(t, "hot", top[0].Key)
+	require.Equal(t, uint64(250), top[0].Conflicts-top[0].Error)
+}
+
+func TestKeyPrefix(t *testing.T) {
+	tests := []struct {
+		key, prefix string
+	}{
+		{"asset1234", "asset"},
+		{"user:alice", "user"},
+		{"ASSET", "ASSET"},
+		{"1234", ""},
+		{"", ""},
+		{"\x00balance\x00alice\x00", "balance"},
+		{"\x00\x00", ""},
+		{"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz", "abcdefghijklmnopqrstuvwxyzabcdef"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.prefix, KeyPrefix(tt.key), "key %q", tt.key)
+	}
+}
+
+func testKey(i int) string {
+	return "key" + string(rune('a'+i%26)) + string(rune('a'+i/26%26))
+}
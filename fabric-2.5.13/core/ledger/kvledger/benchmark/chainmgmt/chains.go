@@ -43,6 +43,7 @@ func newChainsMgr(mgrConf *ChainMgrConf, batchConf *BatchConf, initOp chainInitO
 	dataDir := filepath.Join(mgrConf.DataDir, "ledgersData")
 	ledgermgmtInitializer := ledgermgmttest.NewInitializer(dataDir)
 	ledgermgmtInitializer.Config.HistoryDBConfig.Enabled = true
+	ledgermgmtInitializer.Config.StateDBConfig.ValidationParallelism = mgrConf.ValidationParallelism
 	if os.Getenv("useCouchDB") == "true" {
 		couchdbAddr, set := os.LookupEnv("COUCHDB_ADDR")
 		if !set {
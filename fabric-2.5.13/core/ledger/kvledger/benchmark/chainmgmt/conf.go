@@ -12,6 +12,8 @@ type ChainMgrConf struct {
 	DataDir string
 	// NumChains field specifies the number of chains to instantiate
 	NumChains int
+	// ValidationParallelism specifies the maximum number of transactions of a block that are mvcc-validated concurrently
+	ValidationParallelism int
 }
 
 // BatchConf captures the batch related configurations
@@ -64,6 +64,8 @@ func confFromTestParams(testParams []string) *configuration {
 	// chainMgrConf
 	dataDir := flags.String("DataDir", conf.chainMgrConf.DataDir, "Dir for ledger data")
 	numChains := flags.Int("NumChains", conf.chainMgrConf.NumChains, "Number of chains")
+	validationParallelism := flags.Int("ValidationParallelism",
+		conf.chainMgrConf.ValidationParallelism, "Max number of Txs of a block that are mvcc-validated concurrently")
 
 	// txConf
 	numParallelTxsPerChain := flags.Int("NumParallelTxPerChain",
@@ -95,6 +97,7 @@ func confFromTestParams(testParams []string) *configuration {
 
 	conf.chainMgrConf.DataDir = *dataDir
 	conf.chainMgrConf.NumChains = *numChains
+	conf.chainMgrConf.ValidationParallelism = *validationParallelism
 	conf.txConf.numParallelTxsPerChain = *numParallelTxsPerChain
 	conf.txConf.numTotalTxs = *numTotalTxs
 	conf.txConf.numWritesPerTx = *numWritesPerTx
@@ -19,6 +19,8 @@ NumWritesPerTx=4
 NumReadsPerTx=4
 BatchSize=50
 KVSize=200
+# ValidationParallelism - max number of transactions of a block that are mvcc-validated concurrently (0 or 1 for sequential)
+ValidationParallelism=0
 
 #####################################################################################################################
 # Following variables controls what experiments to run. Typically, you would wish to run only selected experiments. 
@@ -41,5 +43,7 @@ ArrayKVSize=(100 200 500 1000 2000)
 ArrayBatchSize=(10 20 100 500)
 # Run experiments with varying "NumTotalTx" (keeping remaining params as default - see function 'varyNumTxs' in file runbenchmarks.sh)
 ArrayNumTxs=(100000 200000 500000 1000000)
+# Run experiments with varying "ValidationParallelism" (keeping remaining params as default - see function 'varyValidationParallelism' in file runbenchmarks.sh)
+ArrayValidationParallelism=(1 2 4 8 16)
 # Whether to run experiment with large amount of data (see function 'runLargeDataExperiment' in file runbenchmarks.sh)
 RunLargeDataExperiment=true
\ No newline at end of file
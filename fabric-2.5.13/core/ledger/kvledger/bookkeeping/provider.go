@@ -22,6 +22,8 @@ const (
 	MetadataPresenceIndicator
 	// SnapshotRequest maintains the information for snapshot requests
 	SnapshotRequest
+	// TxInvalidationInfo maintains the details of the reasons for which transactions were marked invalid
+	TxInvalidationInfo
 )
 
 // Provider provides db handle to different bookkeepers
@@ -50,7 +52,7 @@ func (p *Provider) Close() {
 
 // Drop drops channel-specific data from the config history db
 func (p *Provider) Drop(ledgerID string) error {
-	for _, cat := range []Category{PvtdataExpiry, MetadataPresenceIndicator, SnapshotRequest} {
+	for _, cat := range []Category{PvtdataExpiry, MetadataPresenceIndicator, SnapshotRequest, TxInvalidationInfo} {
 		if err := p.dbProvider.Drop(dbName(ledgerID, cat)); err != nil {
 			return err
 		}
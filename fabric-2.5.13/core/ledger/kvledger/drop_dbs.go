@@ -12,7 +12,9 @@ func dropDBs(rootFSPath string) error {
 	// During block commits to stateDB, the transaction manager updates the bookkeeperDB and one of the
 	// state listener updates the config historyDB. As we drop the stateDB, we need to drop the
 	// configHistoryDB and bookkeeperDB too so that during the peer startup after the reset/rollback,
-	// we can get a correct configHistoryDB.
+	// we can get a correct configHistoryDB. Dropping the bookkeeperDB also drops the index of the
+	// reasons for which the transactions were invalidated, which would otherwise retain the entries
+	// of the blocks that are rolled back.
 	// Note that it is necessary to drop the stateDB first before dropping the config history and
 	// bookkeeper. Suppose if the config or bookkeeper is dropped first and the peer reset/rollback
 	// command fails before dropping the stateDB, peer cannot start with consistent data (if the
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
+	"github.com/hyperledger/fabric/core/ledger/hotkeys"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history"
@@ -33,6 +34,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -59,6 +61,7 @@ type kvLedger struct {
 	historyDB              *history.DB
 	configHistoryRetriever *collectionConfigHistoryRetriever
 	snapshotMgr            *snapshotMgr
+	txInvalidationInfo     *txInvalidationInfoStore
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
 	commitHash             []byte
@@ -105,6 +108,12 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		hashProvider:         initializer.hashProvider,
 		config:               initializer.config,
 		blockAPIsRWLock:      &sync.RWMutex{},
+		txInvalidationInfo: &txInvalidationInfoStore{
+			dbHandle: initializer.bookkeeperProvider.GetDBHandle(ledgerID, bookkeeping.TxInvalidationInfo),
+		},
+	}
+	if initializer.config.TxInvalidationInfoConfig != nil {
+		l.txInvalidationInfo.retentionBlocks = initializer.config.TxInvalidationInfoConfig.RetentionBlocks
 	}
 
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, l, initializer.ccInfoProvider})
@@ -130,6 +139,9 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		CustomTxProcessors:  initializer.customTxProcessors,
 		HashFunc:            rwsetHashFunc,
 	}
+	if initializer.config.StateDBConfig != nil {
+		txmgrInitializer.ValidationParallelism = initializer.config.StateDBConfig.ValidationParallelism
+	}
 	if err := l.initTxMgr(txmgrInitializer); err != nil {
 		return nil, err
 	}
@@ -169,6 +181,9 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 	if err := l.recoverDBs(); err != nil {
 		return nil, err
 	}
+	if err := l.syncTxInvalidationInfoWithBlockstore(); err != nil {
+		return nil, err
+	}
 	l.configHistoryRetriever = &collectionConfigHistoryRetriever{
 		Retriever:                     initializer.configHistoryMgr.GetRetriever(ledgerID),
 		DeployedChaincodeInfoProvider: txmgrInitializer.CCInfoProvider,
@@ -474,6 +489,19 @@ func (l *kvLedger) filterYetToCommitBlocks(blocksPvtData map[uint64][]*ledger.Tx
 
 // recommitLostBlocks retrieves blocks in specified range and commit the write set to either
 // state DB or history DB or both
+// syncTxInvalidationInfoWithBlockstore removes the invalidation info that was persisted for a block
+// whose commit to the block store did not complete
+func (l *kvLedger) syncTxInvalidationInfoWithBlockstore() error {
+	info, err := l.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if info.Height == 0 {
+		return nil
+	}
+	return l.txInvalidationInfo.removeAbove(info.Height - 1)
+}
+
 func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64, recoverables ...recoverable) error {
 	logger.Infof("Recommitting lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	var err error
@@ -567,6 +595,17 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 	return txValidationCode, blkNum, err
 }
 
+// GetHotKeys returns the keys that caused the most conflicts amongst the transactions committed since the ledger was opened
+func (l *kvLedger) GetHotKeys() []*hotkeys.HotKey {
+	return l.stats.getHotKeys()
+}
+
+// GetTxInvalidationInfo returns the details of the reason for which a transaction was marked invalid during
+// the commit of the block by this peer. A nil value is returned if no such details are available
+func (l *kvLedger) GetTxInvalidationInfo(txID string) (*txinvalidation.TxInvalidationInfo, error) {
+	return l.txInvalidationInfo.get(txID)
+}
+
 // NewTxSimulator returns new `ledger.TxSimulator`
 func (l *kvLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
 	return l.txmgr.NewTxSimulator(txid)
@@ -687,6 +726,12 @@ func (l *kvLedger) commit(pvtdataAndBlock *ledger.BlockAndPvtData, commitOpts *l
 		}] = u.Version
 	}
 
+	// the invalidation info is persisted ahead of the block so that it is available by the time
+	// the status of a transaction can be retrieved from the block store
+	if err = l.txInvalidationInfo.add(blockNo, txstatsInfo); err != nil {
+		return err
+	}
+
 	if err = l.commitToPvtAndBlockStore(pvtdataAndBlock, purgeMarkers); err != nil {
 		return err
 	}
@@ -801,6 +846,7 @@ func (l *kvLedger) updateBlockStats(
 	l.stats.updateBlockstorageAndPvtdataCommitTime(blockstorageAndPvtdataCommitTime)
 	l.stats.updateStatedbCommitTime(statedbCommitTime)
 	l.stats.updateTransactionsStats(txstatsInfo)
+	l.stats.updateConflictStats(txstatsInfo)
 }
 
 func (l *kvLedger) addBlockCommitHash(block *common.Block, updateBatchBytes []byte) {
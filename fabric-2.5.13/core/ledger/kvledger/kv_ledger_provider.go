@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/msgs"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	"github.com/hyperledger/fabric/internal/fileutil"
 	"github.com/hyperledger/fabric/protoutil"
@@ -155,9 +156,17 @@ func (p *Provider) initBlockStoreProvider() error {
 }
 
 func (p *Provider) initPvtDataStoreProvider() error {
+	encryptionProvider, err := pvtdataencryption.NewProvider(
+		p.initializer.CryptoProvider,
+		p.initializer.Config.PrivateDataConfig.EncryptionEnabled,
+	)
+	if err != nil {
+		return err
+	}
 	privateDataConfig := &pvtdatastorage.PrivateDataConfig{
-		PrivateDataConfig: p.initializer.Config.PrivateDataConfig,
-		StorePath:         PvtDataStorePath(p.initializer.Config.RootFSPath),
+		PrivateDataConfig:  p.initializer.Config.PrivateDataConfig,
+		StorePath:          PvtDataStorePath(p.initializer.Config.RootFSPath),
+		EncryptionProvider: encryptionProvider,
 	}
 	ledgerIDs, err := p.idStore.getActiveAndInactiveLedgerIDs()
 	if err != nil {
@@ -374,7 +383,7 @@ func (p *Provider) open(ledgerID string, bootSnapshotMetadata *SnapshotMetadata,
 		bookkeeperProvider:       p.bookkeepingProvider,
 		ccInfoProvider:           p.initializer.DeployedChaincodeInfoProvider,
 		ccLifecycleEventProvider: p.initializer.ChaincodeLifecycleEventProvider,
-		stats:                    p.stats.ledgerStats(ledgerID),
+		stats:                    p.stats.ledgerStats(ledgerID, p.initializer.Config.StateDBConfig.HotKeysCapacity),
 		customTxProcessors:       p.initializer.CustomTxProcessors,
 		hashProvider:             p.initializer.HashProvider,
 		config:                   p.initializer.Config,
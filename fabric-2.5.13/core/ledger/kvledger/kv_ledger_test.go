@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -1317,6 +1318,103 @@ func TestCommitNotificationsOnBlockCommit(t *testing.T) {
 	)
 }
 
+func TestTxInvalidationInfo(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	l, err := provider.CreateFromGenesisBlock(gb)
+	require.NoError(t, err)
+
+	s, err := l.NewTxSimulator("")
+	require.NoError(t, err)
+	_, err = s.GetState("ns1", "key1")
+	require.NoError(t, err)
+	require.NoError(t, s.SetState("ns1", "key1", []byte("val1")))
+	sr, err := s.GetTxSimulationResults()
+	require.NoError(t, err)
+	srBytes, err := sr.GetPubSimulationBytes()
+	require.NoError(t, err)
+	s.Done()
+
+	var txs []*testutil.TxDetails
+	for _, txID := range []string{"txid_1", "txid_2", "txid_3"} {
+		txs = append(txs, &testutil.TxDetails{
+			Type:              common.HeaderType_ENDORSER_TRANSACTION,
+			TxID:              txID,
+			ChaincodeName:     "foo",
+			ChaincodeVersion:  "v1",
+			SimulationResults: srBytes, // same read-sets: txid_2 should cause mvcc conflict
+		})
+	}
+	block := testutil.ConstructBlockFromBlockDetails(
+		t, &testutil.BlockDetails{
+			BlockNum:     1,
+			PreviousHash: protoutil.BlockHeaderHash(gb.Header),
+			Txs:          txs,
+		}, false,
+	)
+	txsFilter := txflags.NewWithValues(3, peer.TxValidationCode_VALID)
+	txsFilter.SetFlag(2, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+
+	require.NoError(t, l.CommitLegacy(
+		&ledger.BlockAndPvtData{
+			Block:                block,
+			InvalidationMessages: map[uint64]string{2: "signature set did not satisfy policy"},
+		},
+		&ledger.CommitOptions{},
+	))
+
+	info, err := l.GetTxInvalidationInfo("txid_1")
+	require.NoError(t, err)
+	require.Nil(t, info)
+
+	info, err = l.GetTxInvalidationInfo("txid_2")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(
+		&txinvalidation.TxInvalidationInfo{
+			TxId:           "txid_2",
+			BlockNumber:    1,
+			TxNumber:       1,
+			ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+			Message:        "key [key1] in namespace [ns1] read at version [none] was updated by a preceding transaction in the same block",
+			ReadConflict: &txinvalidation.ReadConflict{
+				Namespace:      "ns1",
+				Key:            "key1",
+				UpdatedInBlock: true,
+			},
+		},
+		info,
+	))
+
+	info, err = l.GetTxInvalidationInfo("txid_3")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(
+		&txinvalidation.TxInvalidationInfo{
+			TxId:           "txid_3",
+			BlockNumber:    1,
+			TxNumber:       2,
+			ValidationCode: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+			Message:        "signature set did not satisfy policy",
+		},
+		info,
+	))
+
+	// the invalidation info of the blocks that are rolled back is dropped
+	provider.Close()
+	require.NoError(t, RollbackKVLedger(conf.RootFSPath, "testLedger", 0))
+	provider = testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+	l, err = provider.Open("testLedger")
+	require.NoError(t, err)
+	defer l.Close()
+	info, err = l.GetTxInvalidationInfo("txid_3")
+	require.NoError(t, err)
+	require.Nil(t, info)
+}
+
 func testutilPersistExplicitCollectionConfig(
 	t *testing.T,
 	provider *Provider,
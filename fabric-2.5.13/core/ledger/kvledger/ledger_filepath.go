@@ -60,6 +60,16 @@ func CompletedSnapshotsPath(snapshotRootDir string) string {
 	return filepath.Join(snapshotRootDir, "completed")
 }
 
+// DownloadedSnapshotsPath returns the absolute path that is used for the snapshots downloaded from other peers
+func DownloadedSnapshotsPath(snapshotRootDir string) string {
+	return filepath.Join(snapshotRootDir, "downloaded")
+}
+
+// DownloadedSnapshotDirForLedger returns the absolute path of the dir for the snapshot of a ledger downloaded from another peer
+func DownloadedSnapshotDirForLedger(snapshotRootDir, ledgerID string) string {
+	return filepath.Join(DownloadedSnapshotsPath(snapshotRootDir), ledgerID)
+}
+
 // SnapshotsDirForLedger returns the absolute path of the dir for the snapshots for a specified ledger
 func SnapshotsDirForLedger(snapshotRootDir, ledgerID string) string {
 	return filepath.Join(CompletedSnapshotsPath(snapshotRootDir), ledgerID)
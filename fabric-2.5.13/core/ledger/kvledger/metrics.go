@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"encoding/hex"
 	"time"
 
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger/hotkeys"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
 )
 
@@ -18,6 +20,7 @@ type stats struct {
 	blockAndPvtdataStoreCommitTime metrics.Histogram
 	statedbCommitTime              metrics.Histogram
 	transactionsCount              metrics.Counter
+	conflictsCount                 metrics.Counter
 }
 
 func newStats(metricsProvider metrics.Provider) *stats {
@@ -26,17 +29,34 @@ func newStats(metricsProvider metrics.Provider) *stats {
 	stats.blockAndPvtdataStoreCommitTime = metricsProvider.NewHistogram(blockAndPvtdataStoreCommitTimeOpts)
 	stats.statedbCommitTime = metricsProvider.NewHistogram(statedbCommitTimeOpts)
 	stats.transactionsCount = metricsProvider.NewCounter(transactionCountOpts)
+	stats.conflictsCount = metricsProvider.NewCounter(conflictsCountOpts)
 	return stats
 }
 
+// maxConflictKeyPrefixes bounds the number of distinct key prefixes, across the namespaces and collections
+// of a channel, for which the conflicts are counted separately, so as to bound the cardinality of the
+// key_prefix label. The conflicts on the keys with any other prefix are counted under otherKeyPrefix
+const maxConflictKeyPrefixes = 100
+
+const otherKeyPrefix = "other"
+
+type keyPrefixID struct {
+	namespace, collection, keyPrefix string
+}
+
 type ledgerStats struct {
-	stats    *stats
-	ledgerid string
+	stats       *stats
+	ledgerid    string
+	hotKeys     *hotkeys.Tracker
+	keyPrefixes map[keyPrefixID]struct{}
 }
 
-func (s *stats) ledgerStats(ledgerid string) *ledgerStats {
+func (s *stats) ledgerStats(ledgerid string, hotKeysCapacity int) *ledgerStats {
 	return &ledgerStats{
-		s, ledgerid,
+		stats:       s,
+		ledgerid:    ledgerid,
+		hotKeys:     hotkeys.NewTracker(hotKeysCapacity),
+		keyPrefixes: map[keyPrefixID]struct{}{},
 	}
 }
 
@@ -75,6 +95,64 @@ func (s *ledgerStats) updateTransactionsStats(
 	}
 }
 
+// updateConflictStats counts the transactions invalidated because of a conflict on a key or on the results
+// of a range query, by namespace and key prefix, and records the conflicting keys in the hot keys tracker
+func (s *ledgerStats) updateConflictStats(
+	txstatsInfo []*validation.TxStatInfo,
+) {
+	for _, txstat := range txstatsInfo {
+		info := txstat.InvalidationInfo
+		if info == nil {
+			continue
+		}
+
+		var hotKey *hotkeys.HotKey
+		var keyPrefix string
+		switch {
+		case info.ReadConflict != nil:
+			c := info.ReadConflict
+			hotKey = &hotkeys.HotKey{Namespace: c.Namespace, Collection: c.Collection, Key: c.Key}
+			if c.Collection != "" {
+				hotKey.KeyHash = hex.EncodeToString(c.KeyHash)
+			}
+			keyPrefix = hotkeys.KeyPrefix(c.Key)
+		case info.RangeQueryConflict != nil:
+			c := info.RangeQueryConflict
+			hotKey = &hotkeys.HotKey{Namespace: c.Namespace, Key: c.StartKey, RangeQuery: true, RangeEnd: c.EndKey}
+			keyPrefix = hotkeys.KeyPrefix(c.StartKey)
+		default:
+			continue
+		}
+
+		s.stats.conflictsCount.With(
+			"channel", s.ledgerid,
+			"namespace", hotKey.Namespace,
+			"collection", hotKey.Collection,
+			"key_prefix", s.boundedKeyPrefix(hotKey.Namespace, hotKey.Collection, keyPrefix),
+		).Add(1)
+		s.hotKeys.Add(hotKey, info.BlockNumber)
+	}
+}
+
+// boundedKeyPrefix returns the key prefix as is if it has been seen before or if the limit on the number of
+// distinct key prefixes has not been reached, and otherKeyPrefix otherwise
+func (s *ledgerStats) boundedKeyPrefix(namespace, collection, keyPrefix string) string {
+	id := keyPrefixID{namespace: namespace, collection: collection, keyPrefix: keyPrefix}
+	if _, ok := s.keyPrefixes[id]; ok {
+		return keyPrefix
+	}
+	if len(s.keyPrefixes) >= maxConflictKeyPrefixes {
+		return otherKeyPrefix
+	}
+	s.keyPrefixes[id] = struct{}{}
+	return keyPrefix
+}
+
+// getHotKeys returns the keys that caused the most conflicts since the ledger was opened
+func (s *ledgerStats) getHotKeys() []*hotkeys.HotKey {
+	return s.hotKeys.Top()
+}
+
 var (
 	blockProcessingTimeOpts = metrics.HistogramOpts{
 		Namespace:    "ledger",
@@ -114,4 +192,13 @@ var (
 		LabelNames:   []string{"channel", "transaction_type", "chaincode", "validation_code"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{transaction_type}.%{chaincode}.%{validation_code}",
 	}
+
+	conflictsCountOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "conflicts_count",
+		Help:         "Number of transactions invalidated because of a conflict on a key or on the results of a range query. The key prefixes beyond the first 100 of a channel are counted as other.",
+		LabelNames:   []string{"channel", "namespace", "collection", "key_prefix"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{namespace}.%{collection}.%{key_prefix}",
+	}
 )
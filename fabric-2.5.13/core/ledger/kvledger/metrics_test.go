@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -17,8 +18,10 @@ import (
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/hotkeys"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
 	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/stretchr/testify/require"
 )
 
@@ -142,12 +145,110 @@ func TestStatsBlockCommit(t *testing.T) {
 	)
 }
 
+func TestConflictStats(t *testing.T) {
+	testMetricProvider := testutilConstructMetricProvider()
+	stats := newStats(testMetricProvider.fakeProvider).ledgerStats("ledger1", 10)
+
+	stats.updateConflictStats(
+		[]*validation.TxStatInfo{
+			{
+				ValidationCode: peer.TxValidationCode_VALID,
+			},
+			{
+				ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+				InvalidationInfo: &txinvalidation.TxInvalidationInfo{
+					BlockNumber:  5,
+					ReadConflict: &txinvalidation.ReadConflict{Namespace: "mycc", Key: "asset1"},
+				},
+			},
+			{
+				ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+				InvalidationInfo: &txinvalidation.TxInvalidationInfo{
+					BlockNumber:  5,
+					ReadConflict: &txinvalidation.ReadConflict{Namespace: "mycc", Collection: "coll1", KeyHash: []byte("hash")},
+				},
+			},
+			{
+				ValidationCode: peer.TxValidationCode_PHANTOM_READ_CONFLICT,
+				InvalidationInfo: &txinvalidation.TxInvalidationInfo{
+					BlockNumber:        5,
+					RangeQueryConflict: &txinvalidation.RangeQueryConflict{Namespace: "mycc", StartKey: "asset1", EndKey: "asset9"},
+				},
+			},
+			{
+				ValidationCode: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+				InvalidationInfo: &txinvalidation.TxInvalidationInfo{
+					BlockNumber: 5,
+					Message:     "policy not satisfied",
+				},
+			},
+		},
+	)
+
+	fakeConflictsCount := testMetricProvider.fakeConflictsCount
+	require.Equal(t, 3, fakeConflictsCount.AddCallCount())
+	require.Equal(t,
+		[]string{"channel", "ledger1", "namespace", "mycc", "collection", "", "key_prefix", "asset"},
+		fakeConflictsCount.WithArgsForCall(0),
+	)
+	require.Equal(t,
+		[]string{"channel", "ledger1", "namespace", "mycc", "collection", "coll1", "key_prefix", ""},
+		fakeConflictsCount.WithArgsForCall(1),
+	)
+	require.Equal(t,
+		[]string{"channel", "ledger1", "namespace", "mycc", "collection", "", "key_prefix", "asset"},
+		fakeConflictsCount.WithArgsForCall(2),
+	)
+
+	require.Equal(t,
+		[]*hotkeys.HotKey{
+			{Namespace: "mycc", Key: "asset1", Conflicts: 1, LastBlock: 5},
+			{Namespace: "mycc", Key: "asset1", RangeQuery: true, RangeEnd: "asset9", Conflicts: 1, LastBlock: 5},
+			{Namespace: "mycc", Collection: "coll1", KeyHash: "68617368", Conflicts: 1, LastBlock: 5},
+		},
+		stats.getHotKeys(),
+	)
+}
+
+func TestConflictStatsKeyPrefixLimit(t *testing.T) {
+	testMetricProvider := testutilConstructMetricProvider()
+	stats := newStats(testMetricProvider.fakeProvider).ledgerStats("ledger1", 10)
+
+	readConflict := func(key string) *validation.TxStatInfo {
+		return &validation.TxStatInfo{
+			ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+			InvalidationInfo: &txinvalidation.TxInvalidationInfo{
+				ReadConflict: &txinvalidation.ReadConflict{Namespace: "mycc", Key: key},
+			},
+		}
+	}
+	var txstatsInfo []*validation.TxStatInfo
+	for i := 0; i < maxConflictKeyPrefixes; i++ {
+		// distinct prefixes "a" to "z", "ba" to "bz", "cca" to "ccz", ...
+		txstatsInfo = append(txstatsInfo, readConflict(strings.Repeat(string(rune('a'+i/26)), i/26)+string(rune('a'+i%26))+"1"))
+	}
+	txstatsInfo = append(txstatsInfo, readConflict("newprefix1"), readConflict("a1"))
+	stats.updateConflictStats(txstatsInfo)
+
+	fakeConflictsCount := testMetricProvider.fakeConflictsCount
+	require.Equal(t, maxConflictKeyPrefixes+2, fakeConflictsCount.AddCallCount())
+	require.Equal(t,
+		[]string{"channel", "ledger1", "namespace", "mycc", "collection", "", "key_prefix", "other"},
+		fakeConflictsCount.WithArgsForCall(maxConflictKeyPrefixes),
+	)
+	require.Equal(t,
+		[]string{"channel", "ledger1", "namespace", "mycc", "collection", "", "key_prefix", "a"},
+		fakeConflictsCount.WithArgsForCall(maxConflictKeyPrefixes+1),
+	)
+}
+
 type testMetricProvider struct {
 	fakeProvider                              *metricsfakes.Provider
 	fakeBlockProcessingTimeHist               *metricsfakes.Histogram
 	fakeBlockstorageCommitWithPvtDataTimeHist *metricsfakes.Histogram
 	fakeStatedbCommitTimeHist                 *metricsfakes.Histogram
 	fakeTransactionsCount                     *metricsfakes.Counter
+	fakeConflictsCount                        *metricsfakes.Counter
 }
 
 func testutilConstructMetricProvider() *testMetricProvider {
@@ -156,6 +257,7 @@ func testutilConstructMetricProvider() *testMetricProvider {
 	fakeBlockstorageCommitWithPvtDataTimeHist := testutilConstructHist()
 	fakeStatedbCommitTimeHist := testutilConstructHist()
 	fakeTransactionsCount := testutilConstructCounter()
+	fakeConflictsCount := testutilConstructCounter()
 	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
 		// return a gauge for metrics in common/ledger
 		return testutilConstructGauge()
@@ -178,6 +280,8 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		switch opts.Name {
 		case transactionCountOpts.Name:
 			return fakeTransactionsCount
+		case conflictsCountOpts.Name:
+			return fakeConflictsCount
 		}
 		return nil
 	}
@@ -187,6 +291,7 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		fakeBlockstorageCommitWithPvtDataTimeHist,
 		fakeStatedbCommitTimeHist,
 		fakeTransactionsCount,
+		fakeConflictsCount,
 	}
 }
 
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/pkg/errors"
+)
+
+// RotatePvtdataEncryptionKeys rotates the keys used for encrypting the private data of all the ledgers and
+// returns the IDs of the ledgers. The data encryption keys of the pvtdata store of each ledger are re-wrapped
+// with a new key encryption key generated by the crypto provider, so the private data that is already stored
+// does not need to be re-encrypted. This function is to be invoked while the peer is shut down.
+func RotatePvtdataEncryptionKeys(config *ledger.Config, csp bccsp.BCCSP) ([]string, error) {
+	if !config.PrivateDataConfig.EncryptionEnabled {
+		return nil, errors.New("encryption of private data is not enabled")
+	}
+
+	// Ensure the routine is invoked while the peer is down.
+	fileLock := leveldbhelper.NewFileLock(fileLockPath(config.RootFSPath))
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.WithMessage(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	idStore, err := openIDStore(LedgerProviderPath(config.RootFSPath))
+	if err != nil {
+		return nil, err
+	}
+	defer idStore.db.Close()
+
+	ledgerIDs, err := idStore.getActiveAndInactiveLedgerIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	encryptionProvider, err := pvtdataencryption.NewProvider(csp, true)
+	if err != nil {
+		return nil, err
+	}
+	pvtdataStoreProvider, err := pvtdatastorage.NewProvider(
+		&pvtdatastorage.PrivateDataConfig{
+			PrivateDataConfig:  config.PrivateDataConfig,
+			StorePath:          PvtDataStorePath(config.RootFSPath),
+			EncryptionProvider: encryptionProvider,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer pvtdataStoreProvider.Close()
+
+	for _, ledgerID := range ledgerIDs {
+		rotated, err := pvtdataStoreProvider.RotateEncryptionKeys(ledgerID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "rotating the encryption keys of the pvtdata store of ledger [%s]", ledgerID)
+		}
+		if !rotated {
+			logger.Infow("Skipping the rotation of encryption keys of the pvtdata store that has never been encrypted", "ledgerID", ledgerID)
+			continue
+		}
+		logger.Infow("Rotated the encryption keys of the pvtdata store", "ledgerID", ledgerID)
+	}
+	return ledgerIDs, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/sw"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatePvtdataEncryptionKeys(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	conf.PrivateDataConfig.EncryptionEnabled = true
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevel(t.TempDir())
+	require.NoError(t, err)
+	newProvider := func() *Provider {
+		provider, err := NewProvider(
+			&ledger.Initializer{
+				DeployedChaincodeInfoProvider:   &mock.DeployedChaincodeInfoProvider{},
+				MetricsProvider:                 &disabled.Provider{},
+				Config:                          conf,
+				HashProvider:                    cryptoProvider,
+				CryptoProvider:                  cryptoProvider,
+				HealthCheckRegistry:             &mock.HealthCheckRegistry{},
+				ChaincodeLifecycleEventProvider: &mock.ChaincodeLifecycleEventProvider{},
+				MembershipInfoProvider:          &mock.MembershipInfoProvider{},
+			},
+		)
+		require.NoError(t, err)
+		return provider
+	}
+
+	provider := newProvider()
+	for _, ledgerID := range []string{"ledger1", "ledger2"} {
+		genesisBlock, err := configtxtest.MakeGenesisBlock(ledgerID)
+		require.NoError(t, err)
+		_, err = provider.CreateFromGenesisBlock(genesisBlock)
+		require.NoError(t, err)
+	}
+
+	t.Run("peer is running", func(t *testing.T) {
+		_, err := RotatePvtdataEncryptionKeys(conf, cryptoProvider)
+		require.ErrorContains(t, err, "as another peer node command is executing, wait for that command to complete its execution or terminate it before retrying")
+	})
+	provider.Close()
+
+	ledgerIDs, err := RotatePvtdataEncryptionKeys(conf, cryptoProvider)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"ledger1", "ledger2"}, ledgerIDs)
+
+	provider = newProvider()
+	defer provider.Close()
+	lgr, err := provider.Open("ledger1")
+	require.NoError(t, err)
+	lgr.Close()
+
+	t.Run("encryption not enabled", func(t *testing.T) {
+		conf.PrivateDataConfig.EncryptionEnabled = false
+		defer func() { conf.PrivateDataConfig.EncryptionEnabled = true }()
+		_, err := RotatePvtdataEncryptionKeys(conf, cryptoProvider)
+		require.EqualError(t, err, "encryption of private data is not enabled")
+	})
+}
@@ -33,7 +33,7 @@ import (
 
 const (
 	SnapshotSignableMetadataFileName   = "_snapshot_signable_metadata.json"
-	snapshotAdditionalMetadataFileName = "_snapshot_additional_metadata.json"
+	SnapshotAdditionalMetadataFileName = "_snapshot_additional_metadata.json"
 	jsonFileIndent                     = "    "
 	simpleKeyValueDB                   = "SimpleKeyValueDB"
 )
@@ -214,7 +214,7 @@ func (l *kvLedger) generateSnapshotMetadataFiles(
 	if err != nil {
 		return errors.Wrap(err, "error while marshalling snapshot additional metadata to JSON")
 	}
-	return fileutil.CreateAndSyncFile(filepath.Join(dir, snapshotAdditionalMetadataFileName), additionalMetadataBytes, 0o444)
+	return fileutil.CreateAndSyncFile(filepath.Join(dir, SnapshotAdditionalMetadataFileName), additionalMetadataBytes, 0o444)
 }
 
 // CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider
@@ -355,7 +355,7 @@ func loadSnapshotMetadataJSONs(snapshotDir string) (*SnapshotMetadataJSONs, erro
 	if err != nil {
 		return nil, err
 	}
-	additionalMetadataFilePath := filepath.Join(snapshotDir, snapshotAdditionalMetadataFileName)
+	additionalMetadataFilePath := filepath.Join(snapshotDir, SnapshotAdditionalMetadataFileName)
 	additionalMetadataBytes, err := ioutil.ReadFile(additionalMetadataFilePath)
 	if err != nil {
 		return nil, err
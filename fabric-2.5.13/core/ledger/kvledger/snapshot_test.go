@@ -645,7 +645,7 @@ func testCreateLedgerFromSnapshotErrorPaths(t *testing.T, originalSnapshotDir st
 		require.NoError(t, err)
 
 		signableMetadataFile = filepath.Join(snapshotDirForTest, SnapshotSignableMetadataFileName)
-		additionalMetadataFile = filepath.Join(snapshotDirForTest, snapshotAdditionalMetadataFileName)
+		additionalMetadataFile = filepath.Join(snapshotDirForTest, SnapshotAdditionalMetadataFileName)
 
 		provider = testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
 		cleanup = func() {
@@ -692,7 +692,7 @@ func testCreateLedgerFromSnapshotErrorPaths(t *testing.T, originalSnapshotDir st
 		init(t)
 		defer cleanup()
 
-		require.NoError(t, os.Remove(filepath.Join(snapshotDirForTest, snapshotAdditionalMetadataFileName)))
+		require.NoError(t, os.Remove(filepath.Join(snapshotDirForTest, SnapshotAdditionalMetadataFileName)))
 		_, _, err := provider.CreateFromSnapshot(snapshotDirForTest)
 		require.EqualError(t,
 			err,
@@ -909,7 +909,7 @@ func verifySnapshotOutput(
 
 	// verify the contents of the file snapshot_metadata_hash.json
 	mh := &snapshotAdditionalMetadata{}
-	mhJSON, err := ioutil.ReadFile(filepath.Join(snapshotDir, snapshotAdditionalMetadataFileName))
+	mhJSON, err := ioutil.ReadFile(filepath.Join(snapshotDir, SnapshotAdditionalMetadataFileName))
 	require.NoError(t, err)
 	require.NoError(t, json.Unmarshal(mhJSON, mh))
 	require.Equal(t,
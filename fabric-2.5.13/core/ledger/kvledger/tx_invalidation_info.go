@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
+	"github.com/pkg/errors"
+)
+
+var (
+	// txIDKeyPrefix prefixes the keys that map a transaction id to its invalidation info
+	txIDKeyPrefix = []byte{'t'}
+	// blockNumKeyPrefix prefixes the keys that index the transaction ids by the number of the block
+	// that contains the transaction, which are used for removing the entries of the older blocks
+	blockNumKeyPrefix = []byte{'b'}
+)
+
+// txInvalidationInfoStore maintains an index, keyed by transaction id, of the details of the reasons for which
+// the transactions were marked invalid during the commit of the blocks by this peer.
+//
+// The index lives in the bookkeeper db and hence is dropped along with the other derived databases during a
+// ledger reset, rollback, or rebuild; it is not rebuilt when the blocks are recommitted as the reasons are
+// not recomputed during the recovery of the state db.
+type txInvalidationInfoStore struct {
+	dbHandle *leveldbhelper.DBHandle
+	// retentionBlocks is the number of most recent blocks for which the entries are retained.
+	// A value of 0 retains the entries for all the blocks
+	retentionBlocks uint64
+}
+
+// add persists the invalidation info of the invalid transactions of a block. A transaction marked as a duplicate
+// is skipped so that the entry of the transaction with the same id, if any, is not overwritten. The entries of
+// the blocks that fall out of the retention window are removed in the same batch
+func (s *txInvalidationInfoStore) add(blockNum uint64, txsStatInfo []*validation.TxStatInfo) error {
+	batch := s.dbHandle.NewUpdateBatch()
+	for _, txStatInfo := range txsStatInfo {
+		info := txStatInfo.InvalidationInfo
+		if info == nil || info.TxId == "" || info.ValidationCode == peer.TxValidationCode_DUPLICATE_TXID {
+			continue
+		}
+		infoBytes, err := proto.Marshal(info)
+		if err != nil {
+			return errors.Wrapf(err, "error while marshalling invalidation info for txID [%s]", info.TxId)
+		}
+		batch.Put(encodeTxIDKey(info.TxId), infoBytes)
+		batch.Put(encodeBlockNumKey(blockNum, info.TxId), []byte{})
+	}
+	if s.retentionBlocks > 0 && blockNum >= s.retentionBlocks {
+		if err := s.addDeletes(batch, 0, blockNum-s.retentionBlocks+1); err != nil {
+			return err
+		}
+	}
+	return s.dbHandle.WriteBatch(batch, true)
+}
+
+// removeAbove removes the entries of the blocks above the given block number. This is invoked at the ledger
+// startup so as to discard the entries of a block whose commit did not complete
+func (s *txInvalidationInfoStore) removeAbove(blockNum uint64) error {
+	batch := s.dbHandle.NewUpdateBatch()
+	if err := s.addDeletes(batch, blockNum+1, 0); err != nil {
+		return err
+	}
+	return s.dbHandle.WriteBatch(batch, true)
+}
+
+// addDeletes adds to the batch the deletion of the entries of the blocks in the range [startBlockNum, endBlockNum).
+// An endBlockNum of 0 denotes a range that is unbounded
+func (s *txInvalidationInfoStore) addDeletes(batch *leveldbhelper.UpdateBatch, startBlockNum, endBlockNum uint64) error {
+	endKey := append([]byte{}, blockNumKeyPrefix...)
+	endKey[0]++
+	if endBlockNum > 0 {
+		endKey = encodeBlockNumKey(endBlockNum, "")
+	}
+	itr, err := s.dbHandle.GetIterator(encodeBlockNumKey(startBlockNum, ""), endKey)
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+	for itr.Next() {
+		key := itr.Key()
+		batch.Delete(key)
+		batch.Delete(encodeTxIDKey(string(key[len(blockNumKeyPrefix)+8:])))
+	}
+	return errors.Wrap(itr.Error(), "error while iterating over invalidation info")
+}
+
+// get returns the invalidation info for the given transaction id or nil if none exists
+func (s *txInvalidationInfoStore) get(txID string) (*txinvalidation.TxInvalidationInfo, error) {
+	infoBytes, err := s.dbHandle.Get(encodeTxIDKey(txID))
+	if err != nil || infoBytes == nil {
+		return nil, err
+	}
+	info := &txinvalidation.TxInvalidationInfo{}
+	if err := proto.Unmarshal(infoBytes, info); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshalling invalidation info for txID [%s]", txID)
+	}
+	return info, nil
+}
+
+func encodeTxIDKey(txID string) []byte {
+	return append(append([]byte{}, txIDKeyPrefix...), txID...)
+}
+
+func encodeBlockNumKey(blockNum uint64, txID string) []byte {
+	key := append([]byte{}, blockNumKeyPrefix...)
+	key = binary.BigEndian.AppendUint64(key, blockNum)
+	return append(key, txID...)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxInvalidationInfoStore(t *testing.T) {
+	p, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: t.TempDir()})
+	require.NoError(t, err)
+	defer p.Close()
+	s := &txInvalidationInfoStore{
+		dbHandle:        p.GetDBHandle("ledger1"),
+		retentionBlocks: 3,
+	}
+
+	txStatInfo := func(txID string, code peer.TxValidationCode) *validation.TxStatInfo {
+		return &validation.TxStatInfo{
+			InvalidationInfo: &txinvalidation.TxInvalidationInfo{TxId: txID, ValidationCode: code},
+		}
+	}
+	requireInfo := func(txID string, exists bool) {
+		info, err := s.get(txID)
+		require.NoError(t, err)
+		require.Equal(t, exists, info != nil, "txID %s", txID)
+	}
+
+	for blockNum := uint64(1); blockNum <= 4; blockNum++ {
+		require.NoError(t, s.add(blockNum, []*validation.TxStatInfo{
+			{},
+			txStatInfo(fmt.Sprintf("tx%d-1", blockNum), peer.TxValidationCode_MVCC_READ_CONFLICT),
+			txStatInfo(fmt.Sprintf("tx%d-2", blockNum), peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE),
+		}))
+	}
+	// a duplicate does not overwrite the entry of the original transaction
+	require.NoError(t, s.add(5, []*validation.TxStatInfo{txStatInfo("tx4-1", peer.TxValidationCode_DUPLICATE_TXID)}))
+	info, err := s.get("tx4-1")
+	require.NoError(t, err)
+	require.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, info.ValidationCode)
+
+	// the entries of the blocks 1 and 2 are out of the retention window of the blocks 3 to 5
+	requireInfo("tx1-1", false)
+	requireInfo("tx1-2", false)
+	requireInfo("tx2-1", false)
+	requireInfo("tx2-2", false)
+	requireInfo("tx3-1", true)
+	requireInfo("tx4-2", true)
+
+	require.NoError(t, s.removeAbove(3))
+	requireInfo("tx3-1", true)
+	requireInfo("tx3-2", true)
+	requireInfo("tx4-1", false)
+	requireInfo("tx4-2", false)
+
+	t.Run("unlimited retention", func(t *testing.T) {
+		s := &txInvalidationInfoStore{dbHandle: p.GetDBHandle("ledger2")}
+		for blockNum := uint64(0); blockNum < 10; blockNum++ {
+			require.NoError(t, s.add(blockNum, []*validation.TxStatInfo{
+				txStatInfo(fmt.Sprintf("tx%d", blockNum), peer.TxValidationCode_MVCC_READ_CONFLICT),
+			}))
+		}
+		info, err := s.get("tx0")
+		require.NoError(t, err)
+		require.NotNil(t, info)
+	})
+}
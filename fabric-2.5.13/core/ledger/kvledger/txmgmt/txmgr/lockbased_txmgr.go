@@ -89,6 +89,8 @@ type Initializer struct {
 	CCInfoProvider      ledger.DeployedChaincodeInfoProvider
 	CustomTxProcessors  map[common.HeaderType]ledger.CustomTxProcessor
 	HashFunc            rwsetutil.HashFunc
+	// ValidationParallelism is the maximum number of transactions of a block that are mvcc-validated concurrently
+	ValidationParallelism int
 }
 
 // NewLockBasedTxMgr constructs a new instance of NewLockBasedTxMgr
@@ -120,7 +122,9 @@ func NewLockBasedTxMgr(initializer *Initializer) (*LockBasedTxMgr, error) {
 		txmgr,
 		initializer.DB,
 		initializer.CustomTxProcessors,
-		initializer.HashFunc)
+		initializer.HashFunc,
+		initializer.ValidationParallelism,
+	)
 	return txmgr, nil
 }
 
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
@@ -47,22 +48,28 @@ type TxStatInfo struct {
 	ChaincodeID           *peer.ChaincodeID
 	ChaincodeEventData    []byte
 	NumCollections        int
+	// InvalidationInfo captures the details of the reason for an invalid transaction
+	InvalidationInfo *txinvalidation.TxInvalidationInfo
 }
 
 // NewCommitBatchPreparer constructs a validator that internally manages statebased validator and in addition
-// handles the tasks that are agnostic to a particular validation scheme such as parsing the block and handling the pvt data
+// handles the tasks that are agnostic to a particular validation scheme such as parsing the block and handling the pvt data.
+// The parameter validationParallelism specifies the maximum number of transactions of a block that are mvcc-validated
+// concurrently; a value less than 2 results in the sequential validation of the transactions
 func NewCommitBatchPreparer(
 	postOrderSimulatorProvider PostOrderSimulatorProvider,
 	db *privacyenabledstate.DB,
 	customTxProcessors map[common.HeaderType]ledger.CustomTxProcessor,
 	hashFunc rwsetutil.HashFunc,
+	validationParallelism int,
 ) *CommitBatchPreparer {
 	return &CommitBatchPreparer{
 		postOrderSimulatorProvider,
 		db,
 		&validator{
-			db:       db,
-			hashFunc: hashFunc,
+			db:          db,
+			hashFunc:    hashFunc,
+			parallelism: validationParallelism,
 		},
 		customTxProcessors,
 	}
@@ -107,9 +114,28 @@ func (p *CommitBatchPreparer) ValidateAndPrepareBatch(blockAndPvtdata *ledger.Bl
 	postprocessProtoBlock(blk, internalBlock)
 	logger.Debug("ValidateAndPrepareBatch() complete")
 
+	for _, tx := range internalBlock.txs {
+		if tx.invalidationInfo != nil {
+			txsStatInfo[tx.indexInBlock].InvalidationInfo = tx.invalidationInfo
+		}
+	}
 	txsFilter := txflags.ValidationFlags(blk.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 	for i := range txsFilter {
 		txsStatInfo[i].ValidationCode = txsFilter.Flag(i)
+		if txsFilter.IsValid(i) {
+			continue
+		}
+		info := txsStatInfo[i].InvalidationInfo
+		if info == nil {
+			info = &txinvalidation.TxInvalidationInfo{
+				Message: blockAndPvtdata.InvalidationMessages[uint64(i)],
+			}
+			txsStatInfo[i].InvalidationInfo = info
+		}
+		info.TxId = txsStatInfo[i].TxIDFromChannelHeader
+		info.BlockNumber = blk.Header.Number
+		info.TxNumber = uint64(i)
+		info.ValidationCode = txsStatInfo[i].ValidationCode
 	}
 	return &privacyenabledstate.UpdateBatch{
 		PubUpdates:  pubAndHashUpdates.publicUpdates,
@@ -233,6 +259,7 @@ func preprocessProtoBlock(postOrderSimulatorProvider PostOrderSimulatorProvider,
 			respPayload, err := protoutil.GetActionFromEnvelope(envBytes)
 			if err != nil {
 				txsFilter.SetFlag(txIndex, peer.TxValidationCode_NIL_TXACTION)
+				txStatInfo.InvalidationInfo = &txinvalidation.TxInvalidationInfo{Message: err.Error()}
 				continue
 			}
 			txStatInfo.ChaincodeID = respPayload.ChaincodeId
@@ -240,6 +267,7 @@ func preprocessProtoBlock(postOrderSimulatorProvider PostOrderSimulatorProvider,
 			txRWSet = &rwsetutil.TxRwSet{}
 			if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
 				txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_OTHER_REASON)
+				txStatInfo.InvalidationInfo = &txinvalidation.TxInvalidationInfo{Message: err.Error()}
 				continue
 			}
 		} else {
@@ -253,6 +281,7 @@ func preprocessProtoBlock(postOrderSimulatorProvider PostOrderSimulatorProvider,
 			)
 			if _, ok := err.(*ledger.InvalidTxError); ok {
 				txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_OTHER_REASON)
+				txStatInfo.InvalidationInfo = &txinvalidation.TxInvalidationInfo{Message: err.Error()}
 				continue
 			}
 			if err != nil {
@@ -272,6 +301,7 @@ func preprocessProtoBlock(postOrderSimulatorProvider PostOrderSimulatorProvider,
 					" marked as invalid. Reason code [%s]",
 					chdr.GetChannelId(), blk.Header.Number, txIndex, chdr.GetTxId(), peer.TxValidationCode_INVALID_WRITESET)
 				txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_WRITESET)
+				txStatInfo.InvalidationInfo = &txinvalidation.TxInvalidationInfo{Message: err.Error()}
 				continue
 			}
 			b.txs = append(b.txs, &transaction{
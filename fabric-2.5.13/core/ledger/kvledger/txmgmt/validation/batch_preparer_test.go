@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation/mock"
 	mocklgr "github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	lutils "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
@@ -297,7 +298,7 @@ func TestTxStatsInfoWithConfigTx(t *testing.T) {
 	defer testDBEnv.Cleanup()
 	testDB := testDBEnv.GetDBHandle("emptydb")
 
-	v := NewCommitBatchPreparer(nil, testDB, nil, testHashFunc)
+	v := NewCommitBatchPreparer(nil, testDB, nil, testHashFunc, 0)
 
 	gb := testutil.ConstructTestBlocks(t, 1)[0]
 	_, _, txStatsInfo, err := v.ValidateAndPrepareBatch(&ledger.BlockAndPvtData{Block: gb}, true)
@@ -329,7 +330,7 @@ func TestTXMgrContainsPostOrderWrites(t *testing.T) {
 		common.HeaderType_CONFIG: fakeTxProcessor,
 	}
 
-	v := NewCommitBatchPreparer(mockSimulatorProvider, testDB, customTxProcessors, testHashFunc)
+	v := NewCommitBatchPreparer(mockSimulatorProvider, testDB, customTxProcessors, testHashFunc, 0)
 	blocks := testutil.ConstructTestBlocks(t, 2)
 
 	// block with config tx that produces post order writes
@@ -369,7 +370,7 @@ func TestTxStatsInfo(t *testing.T) {
 	defer testDBEnv.Cleanup()
 	testDB := testDBEnv.GetDBHandle("emptydb")
 
-	v := NewCommitBatchPreparer(nil, testDB, nil, testHashFunc)
+	v := NewCommitBatchPreparer(nil, testDB, nil, testHashFunc, 0)
 
 	// create a block with 4 endorser transactions
 	tx1SimulationResults, _ := testutilGenerateTxSimulationResultsAsBytes(t,
@@ -448,7 +449,13 @@ func TestTxStatsInfo(t *testing.T) {
 	blk.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
 
 	// collect the validation stats for the block and check against the expected stats
-	_, _, txStatsInfo, err := v.ValidateAndPrepareBatch(&ledger.BlockAndPvtData{Block: blk}, true)
+	_, _, txStatsInfo, err := v.ValidateAndPrepareBatch(
+		&ledger.BlockAndPvtData{
+			Block:                blk,
+			InvalidationMessages: map[uint64]string{2: "bad payload"},
+		},
+		true,
+	)
 	require.NoError(t, err)
 	expectedTxStatInfo := []*TxStatInfo{
 		{
@@ -464,11 +471,30 @@ func TestTxStatsInfo(t *testing.T) {
 			ValidationCode:        peer.TxValidationCode_MVCC_READ_CONFLICT,
 			ChaincodeID:           &peer.ChaincodeID{Name: "cc_2", Version: "cc_2_v1"},
 			ChaincodeEventData:    []byte("cc2_events_data"),
+			InvalidationInfo: &txinvalidation.TxInvalidationInfo{
+				TxId:           "tx_2",
+				BlockNumber:    5,
+				TxNumber:       1,
+				ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+				Message:        "key [key1] in namespace [ns1] read at version [none] was updated by a preceding transaction in the same block",
+				ReadConflict: &txinvalidation.ReadConflict{
+					Namespace:      "ns1",
+					Key:            "key1",
+					UpdatedInBlock: true,
+				},
+			},
 		},
 		{
 			TxIDFromChannelHeader: "tx_3",
 			TxType:                -1,
 			ValidationCode:        peer.TxValidationCode_BAD_PAYLOAD,
+			InvalidationInfo: &txinvalidation.TxInvalidationInfo{
+				TxId:           "tx_3",
+				BlockNumber:    5,
+				TxNumber:       2,
+				ValidationCode: peer.TxValidationCode_BAD_PAYLOAD,
+				Message:        "bad payload",
+			},
 		},
 		{
 			TxIDFromChannelHeader: "tx_4",
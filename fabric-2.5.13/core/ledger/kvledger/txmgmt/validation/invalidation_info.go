@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
+)
+
+func readConflictInfo(conflict *txinvalidation.ReadConflict) *txinvalidation.TxInvalidationInfo {
+	var subject string
+	if conflict.Collection == "" {
+		subject = fmt.Sprintf("key [%s] in namespace [%s]", conflict.Key, conflict.Namespace)
+	} else {
+		subject = fmt.Sprintf("key hash [%x] in collection [%s:%s]", conflict.KeyHash, conflict.Namespace, conflict.Collection)
+	}
+
+	message := fmt.Sprintf("%s read at version %s has committed version %s",
+		subject, versionString(conflict.ReadVersion), versionString(conflict.CommittedVersion))
+	if conflict.UpdatedInBlock {
+		message = fmt.Sprintf("%s read at version %s was updated by a preceding transaction in the same block",
+			subject, versionString(conflict.ReadVersion))
+	}
+	return &txinvalidation.TxInvalidationInfo{
+		Message:      message,
+		ReadConflict: conflict,
+	}
+}
+
+func rangeQueryConflictInfo(conflict *txinvalidation.RangeQueryConflict) *txinvalidation.TxInvalidationInfo {
+	return &txinvalidation.TxInvalidationInfo{
+		Message: fmt.Sprintf("results of the range query [%s, %s) in namespace [%s] changed since simulation",
+			conflict.StartKey, conflict.EndKey, conflict.Namespace),
+		RangeQueryConflict: conflict,
+	}
+}
+
+func toProtoVersion(height *version.Height) *kvrwset.Version {
+	if height == nil {
+		return nil
+	}
+	return &kvrwset.Version{BlockNum: height.BlockNum, TxNum: height.TxNum}
+}
+
+func versionString(v *kvrwset.Version) string {
+	if v == nil {
+		return "[none]"
+	}
+	return fmt.Sprintf("[%d:%d]", v.BlockNum, v.TxNum)
+}
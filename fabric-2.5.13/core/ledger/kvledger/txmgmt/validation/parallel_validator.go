@@ -0,0 +1,278 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+)
+
+// validateAndPrepareBatchInParallel produces the same validation codes and updates as validateAndPrepareBatch
+// but performs the mvcc validation and the preparation of the writes of the non-conflicting transactions
+// of a block concurrently.
+//
+// The transactions are first assigned to levels of a dependency graph. Two transactions conflict if one of them
+// writes a key that the other reads or writes, or writes a key that falls in a range queried by the other. A
+// transaction is placed at a level higher than every conflicting transaction that precedes it in the block.
+// Consequently, all the transactions at a level can be validated against the updates accumulated from the lower
+// levels, as these contain the writes of every conflicting preceding transaction that turned out to be valid and
+// none of the writes of a conflicting succeeding transaction. Writes of the valid transactions at a level are
+// applied to the accumulated updates in the order of the transactions in the block, before processing the next level.
+func (v *validator) validateAndPrepareBatchInParallel(blk *block) (*publicAndHashUpdates, []*AppInitiatedPurgeUpdate, error) {
+	updates := newPubAndHashUpdates()
+	levels := buildDependencyLevels(blk.txs)
+	logger.Debugf("Block [%d]: validating [%d] transactions in [%d] dependency levels", blk.num, len(blk.txs), len(levels))
+
+	for _, txs := range levels {
+		if err := v.runConcurrently(txs, func(tx *transaction) error {
+			validationCode, invalidationInfo, err := v.validateTx(tx.rwset, updates)
+			if err != nil {
+				return err
+			}
+			tx.validationCode = validationCode
+			tx.invalidationInfo = invalidationInfo
+			return nil
+		}); err != nil {
+			return nil, nil, err
+		}
+
+		ops := make(map[*transaction]txOps, len(txs))
+		var opsLock sync.Mutex
+		if err := v.runConcurrently(txs, func(tx *transaction) error {
+			if tx.validationCode != peer.TxValidationCode_VALID {
+				return nil
+			}
+			txops, err := prepareTxOps(tx.rwset, updates, v.db)
+			if err != nil {
+				return err
+			}
+			opsLock.Lock()
+			ops[tx] = txops
+			opsLock.Unlock()
+			return nil
+		}); err != nil {
+			return nil, nil, err
+		}
+
+		for _, tx := range txs {
+			if tx.validationCode != peer.TxValidationCode_VALID {
+				logger.Warningf("Block [%d] Transaction index [%d] TxId [%s] marked as invalid by state validator. Reason code [%s]",
+					blk.num, tx.indexInBlock, tx.id, tx.validationCode.String())
+				continue
+			}
+			logger.Debugf("Block [%d] Transaction index [%d] TxId [%s] marked as valid by state validator. ContainsPostOrderWrites [%t]", blk.num, tx.indexInBlock, tx.id, tx.containsPostOrderWrites)
+			updates.applyTxOps(ops[tx], version.NewHeight(blk.num, uint64(tx.indexInBlock)), tx.containsPostOrderWrites)
+		}
+	}
+
+	purgeTracker := newPvtdataPurgeTracker()
+	for _, tx := range blk.txs {
+		if tx.validationCode == peer.TxValidationCode_VALID {
+			purgeTracker.update(tx.rwset, version.NewHeight(blk.num, uint64(tx.indexInBlock)))
+		}
+	}
+	return updates, purgeTracker.getUpdates(), nil
+}
+
+// runConcurrently invokes f for each of the transactions, using at most v.parallelism goroutines,
+// and returns the first error encountered
+func (v *validator) runConcurrently(txs []*transaction, f func(tx *transaction) error) error {
+	if len(txs) == 1 {
+		return f(txs[0])
+	}
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	semaphore := make(chan struct{}, v.parallelism)
+	for _, tx := range txs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(tx *transaction) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			if err := f(tx); err != nil {
+				errOnce.Do(func() { firstErr = err })
+			}
+		}(tx)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// buildDependencyLevels groups the transactions into levels such that every transaction is placed at a level higher
+// than all the preceding transactions in the block that it conflicts with. The transactions within a level retain
+// their order in the block. A transaction with post-order writes (e.g., a config transaction) conflicts with all the
+// other transactions.
+func buildDependencyLevels(txs []*transaction) [][]*transaction {
+	tracker := newConflictTracker()
+	var levels [][]*transaction
+	for _, tx := range txs {
+		fp := newTxFootprint(tx.rwset)
+		level := 0
+		if tx.containsPostOrderWrites {
+			level = len(levels)
+		} else {
+			level = tracker.minLevel(fp)
+		}
+		if level == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[level] = append(levels[level], tx)
+		tracker.add(fp, level)
+		if tx.containsPostOrderWrites {
+			tracker.barrierLevel = level + 1
+		}
+	}
+	return levels
+}
+
+// txFootprint captures the keys read and written by a transaction in the public and hashed data spaces
+type txFootprint struct {
+	reads        []compositeKey
+	writes       []compositeKey
+	rangeQueries []nsRange
+}
+
+type nsRange struct {
+	ns, startKey, endKey string
+}
+
+// contains returns true if the key could fall in the range. The range is treated as
+// inclusive of the end key and an empty end key is treated as unbounded
+func (r nsRange) contains(ns, key string) bool {
+	return r.ns == ns && key >= r.startKey && (r.endKey == "" || key <= r.endKey)
+}
+
+func newTxFootprint(txRWSet *rwsetutil.TxRwSet) *txFootprint {
+	fp := &txFootprint{}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		if kvRWSet := nsRWSet.KvRwSet; kvRWSet != nil {
+			for _, kvRead := range kvRWSet.Reads {
+				fp.reads = append(fp.reads, compositeKey{ns: ns, key: kvRead.Key})
+			}
+			for _, rqi := range kvRWSet.RangeQueriesInfo {
+				fp.rangeQueries = append(fp.rangeQueries, nsRange{ns: ns, startKey: rqi.StartKey, endKey: rqi.EndKey})
+			}
+			for _, kvWrite := range kvRWSet.Writes {
+				fp.writes = append(fp.writes, compositeKey{ns: ns, key: kvWrite.Key})
+			}
+			for _, kvMetadataWrite := range kvRWSet.MetadataWrites {
+				fp.writes = append(fp.writes, compositeKey{ns: ns, key: kvMetadataWrite.Key})
+			}
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			coll := collHashedRWSet.CollectionName
+			hashedRWSet := collHashedRWSet.HashedRwSet
+			if hashedRWSet == nil {
+				continue
+			}
+			for _, kvReadHash := range hashedRWSet.HashedReads {
+				fp.reads = append(fp.reads, compositeKey{ns: ns, coll: coll, key: string(kvReadHash.KeyHash)})
+			}
+			for _, kvWriteHash := range hashedRWSet.HashedWrites {
+				fp.writes = append(fp.writes, compositeKey{ns: ns, coll: coll, key: string(kvWriteHash.KeyHash)})
+			}
+			for _, metadataWriteHash := range hashedRWSet.MetadataWrites {
+				fp.writes = append(fp.writes, compositeKey{ns: ns, coll: coll, key: string(metadataWriteHash.KeyHash)})
+			}
+		}
+	}
+	return fp
+}
+
+type levelledRange struct {
+	nsRange
+	level int
+}
+
+type levelledKey struct {
+	key   string
+	level int
+}
+
+// conflictTracker records the highest level of the transactions, added so far, that read or write a key
+// or query a range, so that the minimum level for a succeeding transaction can be computed
+type conflictTracker struct {
+	readLevels   map[compositeKey]int
+	writeLevels  map[compositeKey]int
+	nsWrites     map[string][]levelledKey
+	rangeQueries []levelledRange
+	barrierLevel int
+}
+
+func newConflictTracker() *conflictTracker {
+	return &conflictTracker{
+		readLevels:  map[compositeKey]int{},
+		writeLevels: map[compositeKey]int{},
+		nsWrites:    map[string][]levelledKey{},
+	}
+}
+
+func (c *conflictTracker) minLevel(fp *txFootprint) int {
+	level := c.barrierLevel
+	above := func(l int) {
+		if l+1 > level {
+			level = l + 1
+		}
+	}
+
+	for _, k := range fp.reads {
+		if l, ok := c.writeLevels[k]; ok {
+			above(l)
+		}
+	}
+	for _, k := range fp.writes {
+		if l, ok := c.writeLevels[k]; ok {
+			above(l)
+		}
+		if l, ok := c.readLevels[k]; ok {
+			above(l)
+		}
+		if k.coll != "" {
+			continue
+		}
+		for _, r := range c.rangeQueries {
+			if r.contains(k.ns, k.key) {
+				above(r.level)
+			}
+		}
+	}
+	for _, r := range fp.rangeQueries {
+		for _, w := range c.nsWrites[r.ns] {
+			if r.contains(r.ns, w.key) {
+				above(w.level)
+			}
+		}
+	}
+	return level
+}
+
+func (c *conflictTracker) add(fp *txFootprint, level int) {
+	for _, k := range fp.reads {
+		if l, ok := c.readLevels[k]; !ok || level > l {
+			c.readLevels[k] = level
+		}
+	}
+	for _, k := range fp.writes {
+		if l, ok := c.writeLevels[k]; !ok || level > l {
+			c.writeLevels[k] = level
+		}
+		if k.coll == "" {
+			c.nsWrites[k.ns] = append(c.nsWrites[k.ns], levelledKey{key: k.key, level: level})
+		}
+	}
+	for _, r := range fp.rangeQueries {
+		c.rangeQueries = append(c.rangeQueries, levelledRange{nsRange: r, level: level})
+	}
+}
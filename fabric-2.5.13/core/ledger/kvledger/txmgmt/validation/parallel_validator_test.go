@@ -0,0 +1,209 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDependencyLevels(t *testing.T) {
+	rangeQueryBuilder := func(start, end string) *rwsetutil.RWSetBuilder {
+		b := rwsetutil.NewRWSetBuilder()
+		b.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: start, EndKey: end, ItrExhausted: true})
+		return b
+	}
+
+	// tx0 writes key1
+	b0 := rwsetutil.NewRWSetBuilder()
+	b0.AddToWriteSet("ns1", "key1", []byte("value"))
+	// tx1 reads key2 - independent of tx0
+	b1 := rwsetutil.NewRWSetBuilder()
+	b1.AddToReadSet("ns1", "key2", nil)
+	// tx2 reads key1 - depends on tx0
+	b2 := rwsetutil.NewRWSetBuilder()
+	b2.AddToReadSet("ns1", "key1", nil)
+	// tx3 writes key2 - depends on tx1 which reads key2
+	b3 := rwsetutil.NewRWSetBuilder()
+	b3.AddToWriteSet("ns1", "key2", []byte("value"))
+	// tx4 range query covers key1 and key2 - depends on tx0 and tx3
+	b4 := rangeQueryBuilder("key0", "key5")
+	// tx5 writes key9 - outside the range of tx4, so independent
+	b5 := rwsetutil.NewRWSetBuilder()
+	b5.AddToWriteSet("ns1", "key9", []byte("value"))
+	// tx6 unbounded range query - depends on all the preceding writers (tx0, tx3, tx5)
+	b6 := rangeQueryBuilder("key", "")
+	// tx7 writes a hashed key and the same key in another namespace - independent
+	b7 := rwsetutil.NewRWSetBuilder()
+	b7.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value"))
+	b7.AddToWriteSet("ns2", "key1", []byte("value"))
+	// tx8 reads the hashed key - depends on tx7
+	b8 := rwsetutil.NewRWSetBuilder()
+	b8.AddToHashedReadSet("ns1", "coll1", "key1", nil)
+
+	rwsets := getTestPubSimulationRWSet(t, b0, b1, b2, b3, b4, b5, b6, b7, b8)
+	var txs []*transaction
+	for i, rwset := range rwsets {
+		txs = append(txs, &transaction{indexInBlock: i, rwset: rwset})
+	}
+
+	levels := buildDependencyLevels(txs)
+	require.Equal(t, [][]int{{0, 1, 5, 7}, {2, 3, 8}, {4, 6}}, levelIndexes(levels))
+
+	t.Run("post order writes", func(t *testing.T) {
+		txs := []*transaction{
+			{indexInBlock: 0, rwset: rwsets[0]},
+			{indexInBlock: 1, rwset: rwsets[1], containsPostOrderWrites: true},
+			{indexInBlock: 2, rwset: rwsets[7]},
+			{indexInBlock: 3, rwset: rwsets[1]},
+		}
+		require.Equal(t, [][]int{{0}, {1}, {2, 3}}, levelIndexes(buildDependencyLevels(txs)))
+	})
+}
+
+// TestParallelValidationIsDeterministic validates randomly generated blocks, with a high degree of conflicts amongst
+// the transactions, sequentially as well as in parallel and verifies that both produce identical results
+func TestParallelValidationIsDeterministic(t *testing.T) {
+	testDBEnv := testEnvs[levelDBtestEnvName]
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	numKeys := 20
+	batch := privacyenabledstate.NewUpdateBatch()
+	for i := 0; i < numKeys; i++ {
+		batch.PubUpdates.Put("ns1", testKey(i), []byte("value"), version.NewHeight(1, uint64(i)))
+		batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash(testKey(i)), []byte("value"), version.NewHeight(1, uint64(i)))
+	}
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, uint64(numKeys))))
+
+	sequentialValidator := &validator{db: db, hashFunc: testHashFunc}
+	parallelValidator := &validator{db: db, hashFunc: testHashFunc, parallelism: 4}
+
+	for seed := int64(0); seed < 50; seed++ {
+		t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(seed))
+			rwsets := getTestPubSimulationRWSet(t, randomTestTxs(rnd, 50, numKeys)...)
+
+			sequentialBlk := testBlock(rwsets)
+			expectedUpdates, expectedPurges, err := sequentialValidator.validateAndPrepareBatch(sequentialBlk, true)
+			require.NoError(t, err)
+
+			parallelBlk := testBlock(rwsets)
+			updates, purges, err := parallelValidator.validateAndPrepareBatch(parallelBlk, true)
+			require.NoError(t, err)
+
+			var numInvalid int
+			for i := range sequentialBlk.txs {
+				require.Equal(t, sequentialBlk.txs[i].validationCode, parallelBlk.txs[i].validationCode, "tx %d", i)
+				if sequentialBlk.txs[i].validationCode != peer.TxValidationCode_VALID {
+					numInvalid++
+				}
+			}
+			require.NotZero(t, numInvalid)
+			require.Equal(t, expectedUpdates, updates)
+			require.ElementsMatch(t, expectedPurges, purges)
+		})
+	}
+}
+
+func randomTestTxs(rnd *rand.Rand, numTxs, numKeys int) []*rwsetutil.RWSetBuilder {
+	randomVersion := func(i int) *version.Height {
+		switch rnd.Intn(4) {
+		case 0:
+			return nil
+		case 1:
+			return version.NewHeight(1, uint64(rnd.Intn(numKeys)))
+		default:
+			return version.NewHeight(1, uint64(i))
+		}
+	}
+
+	var builders []*rwsetutil.RWSetBuilder
+	for t := 0; t < numTxs; t++ {
+		b := rwsetutil.NewRWSetBuilder()
+		for n := rnd.Intn(3); n > 0; n-- {
+			i := rnd.Intn(numKeys)
+			b.AddToReadSet("ns1", testKey(i), randomVersion(i))
+		}
+		for n := rnd.Intn(2); n > 0; n-- {
+			i := rnd.Intn(numKeys)
+			b.AddToHashedReadSet("ns1", "coll1", testKey(i), randomVersion(i))
+		}
+		if rnd.Intn(5) == 0 {
+			start := rnd.Intn(numKeys)
+			end := start + 1 + rnd.Intn(3)
+			rqi := &kvrwset.RangeQueryInfo{StartKey: testKey(start), EndKey: testKey(end), ItrExhausted: true}
+			var reads []*kvrwset.KVRead
+			for i := start; i < end && i < numKeys; i++ {
+				reads = append(reads, rwsetutil.NewKVRead(testKey(i), version.NewHeight(1, uint64(i))))
+			}
+			rwsetutil.SetRawReads(rqi, reads)
+			b.AddToRangeQuerySet("ns1", rqi)
+		}
+		for n := 1 + rnd.Intn(2); n > 0; n-- {
+			key := testKey(rnd.Intn(numKeys))
+			// deletes are limited to the keys not present in the db so that a metadata write, which is limited to
+			// the keys present in the db, never follows a delete within the block
+			newKey := testKey(numKeys + rnd.Intn(5))
+			switch rnd.Intn(6) {
+			case 0:
+				b.AddToWriteSet("ns1", newKey, nil)
+			case 1:
+				b.AddToMetadataWriteSet("ns1", key, map[string][]byte{"entry": []byte(fmt.Sprintf("metadata-%d", t))})
+			case 2:
+				b.AddToPvtAndHashedWriteSet("ns1", "coll1", key, []byte(fmt.Sprintf("value-%d", t)))
+			case 3:
+				b.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", newKey)
+			case 4:
+				b.AddToWriteSet("ns1", newKey, []byte(fmt.Sprintf("value-%d", t)))
+			default:
+				b.AddToWriteSet("ns1", key, []byte(fmt.Sprintf("value-%d", t)))
+			}
+		}
+		builders = append(builders, b)
+	}
+	return builders
+}
+
+func testBlock(rwsets []*rwsetutil.TxRwSet) *block {
+	blk := &block{num: 2}
+	for i, rwset := range rwsets {
+		blk.txs = append(blk.txs, &transaction{
+			id:             fmt.Sprintf("txid-%d", i),
+			indexInBlock:   i,
+			validationCode: peer.TxValidationCode_VALID,
+			rwset:          rwset,
+		})
+	}
+	return blk
+}
+
+func testKey(i int) string {
+	return fmt.Sprintf("key%03d", i)
+}
+
+func levelIndexes(levels [][]*transaction) [][]int {
+	var indexes [][]int
+	for _, txs := range levels {
+		var l []int
+		for _, tx := range txs {
+			l = append(l, tx.indexInBlock)
+		}
+		indexes = append(indexes, l)
+	}
+	return indexes
+}
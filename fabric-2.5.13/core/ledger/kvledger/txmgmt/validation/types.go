@@ -11,6 +11,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 )
 
 // block is used to used to hold the information from its proto format to a structure
@@ -27,6 +28,7 @@ type transaction struct {
 	id                      string
 	rwset                   *rwsetutil.TxRwSet
 	validationCode          peer.TxValidationCode
+	invalidationInfo        *txinvalidation.TxInvalidationInfo
 	containsPostOrderWrites bool
 }
 
@@ -84,13 +86,19 @@ func (u *publicAndHashUpdates) applyWriteSet(
 	db *privacyenabledstate.DB,
 	containsPostOrderWrites bool,
 ) error {
-	u.publicUpdates.ContainsPostOrderWrites =
-		u.publicUpdates.ContainsPostOrderWrites || containsPostOrderWrites
 	txops, err := prepareTxOps(txRWSet, u, db)
 	logger.Debugf("txops=%#v", txops)
 	if err != nil {
 		return err
 	}
+	u.applyTxOps(txops, txHeight, containsPostOrderWrites)
+	return nil
+}
+
+// applyTxOps adds (or deletes) the key/values prepared for a transaction to the publicAndHashUpdates
+func (u *publicAndHashUpdates) applyTxOps(txops txOps, txHeight *version.Height, containsPostOrderWrites bool) {
+	u.publicUpdates.ContainsPostOrderWrites =
+		u.publicUpdates.ContainsPostOrderWrites || containsPostOrderWrites
 	for compositeKey, keyops := range txops {
 		if compositeKey.coll == "" {
 			ns, key := compositeKey.ns, compositeKey.key
@@ -108,5 +116,4 @@ func (u *publicAndHashUpdates) applyWriteSet(
 			}
 		}
 	}
-	return nil
 }
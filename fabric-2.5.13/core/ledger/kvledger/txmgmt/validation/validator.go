@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 )
 
 // validator validates a tx against the latest committed state
@@ -20,6 +21,9 @@ import (
 type validator struct {
 	db       *privacyenabledstate.DB
 	hashFunc rwsetutil.HashFunc
+	// parallelism is the maximum number of transactions of a block that are validated concurrently.
+	// A value less than 2 results in the sequential validation of the transactions
+	parallelism int
 }
 
 // preLoadCommittedVersionOfRSet loads committed version of all keys in each
@@ -89,17 +93,21 @@ func (v *validator) validateAndPrepareBatch(blk *block, doMVCCValidation bool) (
 		}
 	}
 
+	if doMVCCValidation && v.parallelism > 1 && len(blk.txs) > 1 {
+		return v.validateAndPrepareBatchInParallel(blk)
+	}
+
 	updates := newPubAndHashUpdates()
 	purgeTracker := newPvtdataPurgeTracker()
 
 	for _, tx := range blk.txs {
-		var validationCode peer.TxValidationCode
-		var err error
-		if validationCode, err = v.validateEndorserTX(tx.rwset, doMVCCValidation, updates); err != nil {
+		validationCode, invalidationInfo, err := v.validateEndorserTX(tx.rwset, doMVCCValidation, updates)
+		if err != nil {
 			return nil, nil, err
 		}
 
 		tx.validationCode = validationCode
+		tx.invalidationInfo = invalidationInfo
 		if validationCode == peer.TxValidationCode_VALID {
 			logger.Debugf("Block [%d] Transaction index [%d] TxId [%s] marked as valid by state validator. ContainsPostOrderWrites [%t]", blk.num, tx.indexInBlock, tx.id, tx.containsPostOrderWrites)
 
@@ -121,71 +129,77 @@ func (v *validator) validateAndPrepareBatch(blk *block, doMVCCValidation bool) (
 func (v *validator) validateEndorserTX(
 	txRWSet *rwsetutil.TxRwSet,
 	doMVCCValidation bool,
-	updates *publicAndHashUpdates) (peer.TxValidationCode, error) {
-	validationCode := peer.TxValidationCode_VALID
-	var err error
+	updates *publicAndHashUpdates) (peer.TxValidationCode, *txinvalidation.TxInvalidationInfo, error) {
 	// mvcc validation, may invalidate transaction
 	if doMVCCValidation {
-		validationCode, err = v.validateTx(txRWSet, updates)
+		return v.validateTx(txRWSet, updates)
 	}
-	return validationCode, err
+	return peer.TxValidationCode_VALID, nil, nil
 }
 
-func (v *validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *publicAndHashUpdates) (peer.TxValidationCode, error) {
+// validateTx performs the mvcc and the phantom read checks of a transaction. For an invalid transaction, it returns
+// the details of the first conflict encountered
+func (v *validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *publicAndHashUpdates) (peer.TxValidationCode, *txinvalidation.TxInvalidationInfo, error) {
 	// Uncomment the following only for local debugging. Don't want to print data in the logs in production
 	// logger.Debugf("validateTx - validating txRWSet: %s", spew.Sdump(txRWSet))
 	for _, nsRWSet := range txRWSet.NsRwSets {
 		ns := nsRWSet.NameSpace
 		// Validate public reads
-		if valid, err := v.validateReadSet(ns, nsRWSet.KvRwSet.Reads, updates.publicUpdates); !valid || err != nil {
+		if conflict, err := v.validateReadSet(ns, nsRWSet.KvRwSet.Reads, updates.publicUpdates); conflict != nil || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+			return peer.TxValidationCode_MVCC_READ_CONFLICT, readConflictInfo(conflict), nil
 		}
 		// Validate range queries for phantom items
-		if valid, err := v.validateRangeQueries(ns, nsRWSet.KvRwSet.RangeQueriesInfo, updates.publicUpdates); !valid || err != nil {
+		if conflict, err := v.validateRangeQueries(ns, nsRWSet.KvRwSet.RangeQueriesInfo, updates.publicUpdates); conflict != nil || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil
+			return peer.TxValidationCode_PHANTOM_READ_CONFLICT, rangeQueryConflictInfo(conflict), nil
 		}
 		// Validate hashes for private reads
-		if valid, err := v.validateNsHashedReadSets(ns, nsRWSet.CollHashedRwSets, updates.hashUpdates); !valid || err != nil {
+		if conflict, err := v.validateNsHashedReadSets(ns, nsRWSet.CollHashedRwSets, updates.hashUpdates); conflict != nil || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+			return peer.TxValidationCode_MVCC_READ_CONFLICT, readConflictInfo(conflict), nil
 		}
 	}
-	return peer.TxValidationCode_VALID, nil
+	return peer.TxValidationCode_VALID, nil, nil
 }
 
 // //////////////////////////////////////////////////////////////////////////////
 // ///                 Validation of public read-set
 // //////////////////////////////////////////////////////////////////////////////
-func (v *validator) validateReadSet(ns string, kvReads []*kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+func (v *validator) validateReadSet(ns string, kvReads []*kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (*txinvalidation.ReadConflict, error) {
 	for _, kvRead := range kvReads {
-		if valid, err := v.validateKVRead(ns, kvRead, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateKVRead(ns, kvRead, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 // validateKVRead performs mvcc check for a key read during transaction simulation.
 // i.e., it checks whether a key/version combination is already updated in the statedb (by an already committed block)
-// or in the updates (by a preceding valid transaction in the current block)
-func (v *validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+// or in the updates (by a preceding valid transaction in the current block). A nil conflict is returned if the check passes
+func (v *validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (*txinvalidation.ReadConflict, error) {
 	readVersion := rwsetutil.NewVersion(kvRead.Version)
+	conflict := &txinvalidation.ReadConflict{
+		Namespace:   ns,
+		Key:         kvRead.Key,
+		ReadVersion: kvRead.Version,
+	}
 	if updates.Exists(ns, kvRead.Key) {
 		logger.Warnw("Transaction invalidation due to version mismatch, key in readset has been updated in a prior transaction in this block",
 			"namespace", ns, "key", kvRead.Key, "readVersion", readVersion)
-		return false, nil
+		conflict.UpdatedInBlock = true
+		return conflict, nil
 	}
 	committedVersion, err := v.db.GetVersion(ns, kvRead.Key)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	logger.Debugw("Comparing readset version to committed version",
@@ -194,21 +208,30 @@ func (v *validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *p
 	if !version.AreSame(committedVersion, readVersion) {
 		logger.Warnw("Transaction invalidation due to version mismatch, readset version does not match committed version",
 			"namespace", ns, "key", kvRead.Key, "readVersion", readVersion, "committedVersion", committedVersion)
-		return false, nil
+		conflict.CommittedVersion = toProtoVersion(committedVersion)
+		return conflict, nil
 	}
-	return true, nil
+	return nil, nil
 }
 
 // //////////////////////////////////////////////////////////////////////////////
 // ///                 Validation of range queries
 // //////////////////////////////////////////////////////////////////////////////
-func (v *validator) validateRangeQueries(ns string, rangeQueriesInfo []*kvrwset.RangeQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+func (v *validator) validateRangeQueries(ns string, rangeQueriesInfo []*kvrwset.RangeQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (*txinvalidation.RangeQueryConflict, error) {
 	for _, rqi := range rangeQueriesInfo {
-		if valid, err := v.validateRangeQuery(ns, rqi, updates); !valid || err != nil {
-			return valid, err
+		valid, err := v.validateRangeQuery(ns, rqi, updates)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return &txinvalidation.RangeQueryConflict{
+				Namespace: ns,
+				StartKey:  rqi.StartKey,
+				EndKey:    rqi.EndKey,
+			}, nil
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 // validateRangeQuery performs a phantom read check i.e., it
@@ -247,38 +270,45 @@ func (v *validator) validateRangeQuery(ns string, rangeQueryInfo *kvrwset.RangeQ
 // ///                 Validation of hashed read-set
 // //////////////////////////////////////////////////////////////////////////////
 func (v *validator) validateNsHashedReadSets(ns string, collHashedRWSets []*rwsetutil.CollHashedRwSet,
-	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+	updates *privacyenabledstate.HashedUpdateBatch) (*txinvalidation.ReadConflict, error) {
 	for _, collHashedRWSet := range collHashedRWSets {
-		if valid, err := v.validateCollHashedReadSet(ns, collHashedRWSet.CollectionName, collHashedRWSet.HashedRwSet.HashedReads, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateCollHashedReadSet(ns, collHashedRWSet.CollectionName, collHashedRWSet.HashedRwSet.HashedReads, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 func (v *validator) validateCollHashedReadSet(ns, coll string, kvReadHashes []*kvrwset.KVReadHash,
-	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+	updates *privacyenabledstate.HashedUpdateBatch) (*txinvalidation.ReadConflict, error) {
 	for _, kvReadHash := range kvReadHashes {
-		if valid, err := v.validateKVReadHash(ns, coll, kvReadHash, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateKVReadHash(ns, coll, kvReadHash, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 // validateKVReadHash performs mvcc check for a hash of a key that is present in the private data space
 // i.e., it checks whether a key/version combination is already updated in the statedb (by an already committed block)
-// or in the updates (by a preceding valid transaction in the current block)
-func (v *validator) validateKVReadHash(ns, coll string, kvReadHash *kvrwset.KVReadHash, updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+// or in the updates (by a preceding valid transaction in the current block). A nil conflict is returned if the check passes
+func (v *validator) validateKVReadHash(ns, coll string, kvReadHash *kvrwset.KVReadHash, updates *privacyenabledstate.HashedUpdateBatch) (*txinvalidation.ReadConflict, error) {
 	readHashVersion := rwsetutil.NewVersion(kvReadHash.Version)
+	conflict := &txinvalidation.ReadConflict{
+		Namespace:   ns,
+		Collection:  coll,
+		KeyHash:     kvReadHash.KeyHash,
+		ReadVersion: kvReadHash.Version,
+	}
 	if updates.Contains(ns, coll, kvReadHash.KeyHash) {
 		logger.Warnw("Transaction invalidation due to hash version mismatch, hash key in readset has been updated in a prior transaction in this block",
 			"namespace", ns, "collection", coll, "keyHash", kvReadHash.KeyHash, "readHashVersion", readHashVersion)
-		return false, nil
+		conflict.UpdatedInBlock = true
+		return conflict, nil
 	}
 	committedVersion, err := v.db.GetKeyHashVersion(ns, coll, kvReadHash.KeyHash)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	logger.Debugw("Comparing hash readset version to committed version",
@@ -287,9 +317,10 @@ func (v *validator) validateKVReadHash(ns, coll string, kvReadHash *kvrwset.KVRe
 	if !version.AreSame(committedVersion, readHashVersion) {
 		logger.Warnw("Transaction invalidation due to hash version mismatch, readset version does not match committed version",
 			"namespace", ns, "collection", coll, "keyHash", kvReadHash.KeyHash, "readVersion", readHashVersion, "committedVersion", committedVersion)
-		return false, nil
+		conflict.CommittedVersion = toProtoVersion(committedVersion)
+		return conflict, nil
 	}
-	return true, nil
+	return nil, nil
 }
 
 type AppInitiatedPurgeUpdate struct {
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/stretchr/testify/require"
 )
@@ -237,6 +238,66 @@ func TestValidator(t *testing.T) {
 	checkValidation(t, testValidator, getTestPubSimulationRWSet(t, rwsetBuilder4, rwsetBuilder5), []int{1})
 }
 
+func TestValidatorInvalidationInfo(t *testing.T) {
+	testDBEnv := testEnvs[levelDBtestEnvName]
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), []byte("value1"), version.NewHeight(1, 2))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 2)))
+
+	testValidator := &validator{db: db, hashFunc: testHashFunc}
+
+	// tx0 read an older version of key1
+	rwsetBuilder0 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder0.AddToReadSet("ns1", "key1", version.NewHeight(0, 5))
+	// tx1 read an older version of a private key
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToHashedReadSet("ns1", "coll1", "key1", version.NewHeight(1, 1))
+	// tx2 performed a range query that did not see key2
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rqi := &kvrwset.RangeQueryInfo{StartKey: "key1", EndKey: "key3", ItrExhausted: true}
+	rwsetutil.SetRawReads(rqi, []*kvrwset.KVRead{rwsetutil.NewKVRead("key1", version.NewHeight(1, 0))})
+	rwsetBuilder2.AddToRangeQuerySet("ns1", rqi)
+
+	blk := &block{num: 2}
+	for i, rwset := range getTestPubSimulationRWSet(t, rwsetBuilder0, rwsetBuilder1, rwsetBuilder2) {
+		blk.txs = append(blk.txs, &transaction{indexInBlock: i, validationCode: peer.TxValidationCode_VALID, rwset: rwset})
+	}
+	_, _, err := testValidator.validateAndPrepareBatch(blk, true)
+	require.NoError(t, err)
+
+	require.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, blk.txs[0].validationCode)
+	require.Equal(t,
+		&txinvalidation.TxInvalidationInfo{
+			Message: "key [key1] in namespace [ns1] read at version [0:5] has committed version [1:0]",
+			ReadConflict: &txinvalidation.ReadConflict{
+				Namespace:        "ns1",
+				Key:              "key1",
+				ReadVersion:      &kvrwset.Version{BlockNum: 0, TxNum: 5},
+				CommittedVersion: &kvrwset.Version{BlockNum: 1, TxNum: 0},
+			},
+		},
+		blk.txs[0].invalidationInfo,
+	)
+
+	require.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, blk.txs[1].validationCode)
+	conflict := blk.txs[1].invalidationInfo.ReadConflict
+	require.Equal(t, "coll1", conflict.Collection)
+	require.Equal(t, util.ComputeStringHash("key1"), conflict.KeyHash)
+	require.Equal(t, &kvrwset.Version{BlockNum: 1, TxNum: 2}, conflict.CommittedVersion)
+
+	require.Equal(t, peer.TxValidationCode_PHANTOM_READ_CONFLICT, blk.txs[2].validationCode)
+	require.Equal(t,
+		&txinvalidation.RangeQueryConflict{Namespace: "ns1", StartKey: "key1", EndKey: "key3"},
+		blk.txs[2].invalidationInfo.RangeQueryConflict,
+	)
+}
+
 func TestPhantomValidation(t *testing.T) {
 	testDBEnv := testEnvs[levelDBtestEnvName]
 	testDBEnv.Init(t)
@@ -20,6 +20,8 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger/hotkeys"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 )
 
 const (
@@ -38,6 +40,7 @@ type Initializer struct {
 	Config                          *Config
 	CustomTxProcessors              map[common.HeaderType]CustomTxProcessor
 	HashProvider                    HashProvider
+	CryptoProvider                  bccsp.BCCSP
 }
 
 // Config is a structure used to configure a ledger provider.
@@ -52,6 +55,9 @@ type Config struct {
 	HistoryDBConfig *HistoryDBConfig
 	// SnapshotsConfig holds the configuration parameters for the snapshots.
 	SnapshotsConfig *SnapshotsConfig
+	// TxInvalidationInfoConfig holds the configuration parameters for the details of the reasons for
+	// which the transactions are invalidated.
+	TxInvalidationInfoConfig *TxInvalidationInfoConfig
 }
 
 // StateDBConfig is a structure used to configure the state parameters for the ledger.
@@ -62,6 +68,15 @@ type StateDBConfig struct {
 	// CouchDB is the configuration for CouchDB.  It is used when StateDatabase
 	// is set to "CouchDB".
 	CouchDB *CouchDBConfig
+	// ValidationParallelism is the maximum number of transactions of a block that are
+	// mvcc-validated concurrently. Transactions that do not conflict with each other are
+	// validated concurrently and the validation results remain the same as that of the
+	// sequential validation. A value less than 2 disables the parallel validation.
+	ValidationParallelism int
+	// HotKeysCapacity is the maximum number of keys per channel for which the number of conflicts
+	// is tracked in order to report the keys that cause the most conflicts. A value less than 1
+	// disables the tracking.
+	HotKeysCapacity int
 }
 
 // CouchDBConfig is a structure used to configure a CouchInstance.
@@ -120,6 +135,9 @@ type PrivateDataConfig struct {
 	DeprioritizedDataReconcilerInterval time.Duration
 	// PurgedKeyAuditLogging specifies whether to log private data keys purged from private data store (INFO level) when explicitly purged via chaincode
 	PurgedKeyAuditLogging bool
+	// EncryptionEnabled specifies whether to encrypt the private data at rest in the private data store
+	// and in the transient store, using the keys managed through the crypto provider of the peer
+	EncryptionEnabled bool
 }
 
 // HistoryDBConfig is a structure used to configure the transaction history database.
@@ -127,6 +145,14 @@ type HistoryDBConfig struct {
 	Enabled bool
 }
 
+// TxInvalidationInfoConfig is a structure used to configure the retention of the details of the
+// reasons for which the transactions are invalidated.
+type TxInvalidationInfoConfig struct {
+	// RetentionBlocks is the number of most recent blocks for which the details are retained.
+	// A value of 0 retains the details for all the blocks.
+	RetentionBlocks uint64
+}
+
 // SnapshotsConfig is a structure used to configure snapshot function
 type SnapshotsConfig struct {
 	// RootDir is the top-level directory for the snapshots.
@@ -167,6 +193,12 @@ type PeerLedger interface {
 	GetBlockByTxID(txID string) (*common.Block, error)
 	// GetTxValidationCodeByTxID returns transaction validation code and block number in which the transaction was committed
 	GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode, uint64, error)
+	// GetTxInvalidationInfo returns the details of the reason for which a transaction was marked invalid.
+	// A nil value is returned if the transaction is not known to have been invalidated by this peer
+	GetTxInvalidationInfo(txID string) (*txinvalidation.TxInvalidationInfo, error)
+	// GetHotKeys returns the keys that caused the most conflicts amongst the transactions committed since the
+	// ledger was opened, in the decreasing order of the number of conflicts
+	GetHotKeys() []*hotkeys.HotKey
 	// NewTxSimulator gives handle to a transaction simulator.
 	// A client can obtain more than one 'TxSimulator's for parallel execution.
 	// Any snapshoting/synchronization should be performed at the implementation level if required
@@ -374,11 +406,14 @@ type MissingPvtData struct {
 type TxMissingPvtData map[uint64][]*MissingPvtData
 
 // BlockAndPvtData encapsulates the block and a map that contains the tuples <seqInBlock, *TxPvtData>
-// The map is expected to contain the entries only for the transactions that has associated pvt data
+// The map is expected to contain the entries only for the transactions that has associated pvt data.
+// InvalidationMessages optionally carries, for the transactions marked invalid by the committer's
+// validator, a description of the reason keyed by the index of the transaction in the block
 type BlockAndPvtData struct {
-	Block          *common.Block
-	PvtData        TxPvtDataMap
-	MissingPvtData TxMissingPvtData
+	Block                *common.Block
+	PvtData              TxPvtDataMap
+	MissingPvtData       TxMissingPvtData
+	InvalidationMessages map[uint64]string
 }
 
 // ReconciledPvtdata contains the private data for a block for reconciliation
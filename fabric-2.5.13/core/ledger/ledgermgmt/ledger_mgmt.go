@@ -13,6 +13,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -59,6 +60,7 @@ type Initializer struct {
 	Config                          *ledger.Config
 	HashProvider                    ledger.HashProvider
 	EbMetadataProvider              MetadataProvider
+	CryptoProvider                  bccsp.BCCSP
 }
 
 // NewLedgerMgr creates a new LedgerMgr
@@ -79,6 +81,7 @@ func NewLedgerMgr(initializer *Initializer) *LedgerMgr {
 			Config:                          initializer.Config,
 			CustomTxProcessors:              initializer.CustomTxProcessors,
 			HashProvider:                    initializer.HashProvider,
+			CryptoProvider:                  initializer.CryptoProvider,
 		},
 	)
 	if err != nil {
@@ -0,0 +1,301 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataencryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("pvtdataencryption")
+
+const (
+	// envelopeMarker and envelopeVersion are the first two bytes of an encrypted value. As a
+	// marshaled protobuf message can neither start with a nil byte nor contain a field tag with the
+	// field number zero, an encrypted value is distinguishable from a value that was stored in plaintext,
+	// including the values that are a marshaled protobuf message prefixed by a nil byte.
+	envelopeMarker  = byte(0x00)
+	envelopeVersion = byte(0x01)
+
+	dekLength      = 32
+	dekIDLength    = 4
+	nonceLength    = 12
+	envelopeHeader = 2 + dekIDLength + nonceLength
+)
+
+// DB is the subset of the functions of a leveldb handle that is used for storing the key record of a store
+type DB interface {
+	Get(key []byte) ([]byte, error)
+	Put(key []byte, value []byte, sync bool) error
+}
+
+// Provider constructs the Encrypter for the private data of a store. The data encryption keys (DEKs) of a
+// store are generated by the peer and are kept in the store itself, wrapped by a key encryption key (KEK)
+// that is generated by and held in the crypto provider: in the keystore of the software crypto provider, or in
+// the token, from which it cannot be extracted, with the PKCS#11 crypto provider.
+type Provider struct {
+	csp     bccsp.BCCSP
+	enabled bool
+}
+
+// NewProvider constructs a Provider. If enabled is false, the values are stored in plaintext but the values that
+// were encrypted before the encryption was disabled can still be read.
+func NewProvider(csp bccsp.BCCSP, enabled bool) (*Provider, error) {
+	if enabled && csp == nil {
+		return nil, errors.New("a crypto provider is required for encrypting private data")
+	}
+	return &Provider{
+		csp:     csp,
+		enabled: enabled,
+	}, nil
+}
+
+// Encrypter returns the Encrypter for the store whose key record is kept in db under recordKey. If the encryption
+// is enabled and the store does not have a key record yet, a new KEK and a new DEK are generated. A nil Encrypter,
+// which stores the values in plaintext, is returned if the encryption is not enabled and the store has never been
+// encrypted. A nil Provider always returns a nil Encrypter.
+func (p *Provider) Encrypter(db DB, recordKey []byte) (*Encrypter, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	record, err := loadKeyRecord(db, recordKey)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		if !p.enabled {
+			return nil, nil
+		}
+		if record, err = p.newKeyRecord(); err != nil {
+			return nil, err
+		}
+		if err := saveKeyRecord(db, recordKey, record); err != nil {
+			return nil, err
+		}
+		logger.Infof("Generated the encryption keys for private data, key encryption key [%x]", record.KekSki)
+	}
+
+	if p.csp == nil {
+		return nil, errors.New("a crypto provider is required for decrypting private data that was stored encrypted")
+	}
+	deks, err := p.unwrapDEKs(record)
+	if err != nil {
+		return nil, err
+	}
+	return newEncrypter(deks, record.ActiveDekId, p.enabled)
+}
+
+// RotateKeys generates a new KEK and re-wraps the DEKs of the store with it, so that the values that are
+// already stored do not need to be re-encrypted. A new DEK is also generated and used for encrypting the values
+// that are stored from now on. The previous KEK is not deleted from the crypto provider, which an operator may
+// remove once the rotation has been performed for all the stores that were using it.
+func (p *Provider) RotateKeys(db DB, recordKey []byte) error {
+	record, err := loadKeyRecord(db, recordKey)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return errors.New("the store does not have encryption keys")
+	}
+	deks, err := p.unwrapDEKs(record)
+	if err != nil {
+		return err
+	}
+
+	kek, err := p.csp.KeyGen(kekGenOpts(p.csp))
+	if err != nil {
+		return errors.WithMessage(err, "error while generating key encryption key")
+	}
+	newDEKID := record.ActiveDekId + 1
+	deks[newDEKID], err = newDEK()
+	if err != nil {
+		return err
+	}
+
+	rotated := &KeyRecord{
+		KekSki:      kek.SKI(),
+		ActiveDekId: newDEKID,
+	}
+	for id := uint32(1); id <= newDEKID; id++ {
+		dek, ok := deks[id]
+		if !ok {
+			continue
+		}
+		wrapped, err := p.wrapDEK(kek, dek)
+		if err != nil {
+			return err
+		}
+		rotated.Deks = append(rotated.Deks, &WrappedDEK{Id: id, WrappedKey: wrapped})
+	}
+	if err := saveKeyRecord(db, recordKey, rotated); err != nil {
+		return err
+	}
+	logger.Infof("Rotated the encryption keys for private data, key encryption key [%x] replaced by [%x]", record.KekSki, rotated.KekSki)
+	return nil
+}
+
+func (p *Provider) newKeyRecord() (*KeyRecord, error) {
+	kek, err := p.csp.KeyGen(kekGenOpts(p.csp))
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while generating key encryption key")
+	}
+	dek, err := newDEK()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := p.wrapDEK(kek, dek)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyRecord{
+		KekSki:      kek.SKI(),
+		ActiveDekId: 1,
+		Deks:        []*WrappedDEK{{Id: 1, WrappedKey: wrapped}},
+	}, nil
+}
+
+func (p *Provider) wrapDEK(kek bccsp.Key, dek []byte) ([]byte, error) {
+	wrapped, err := p.csp.Encrypt(kek, dek, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while wrapping data encryption key")
+	}
+	return wrapped, nil
+}
+
+func (p *Provider) unwrapDEKs(record *KeyRecord) (map[uint32][]byte, error) {
+	kek, err := p.csp.GetKey(record.KekSki)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while retrieving key encryption key [%x]", record.KekSki)
+	}
+	deks := map[uint32][]byte{}
+	for _, w := range record.Deks {
+		dek, err := p.csp.Decrypt(kek, w.WrappedKey, &bccsp.AESCBCPKCS7ModeOpts{})
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while unwrapping data encryption key [%d]", w.Id)
+		}
+		if len(dek) != dekLength {
+			return nil, errors.Errorf("unexpected length [%d] of data encryption key [%d]", len(dek), w.Id)
+		}
+		deks[w.Id] = dek
+	}
+	return deks, nil
+}
+
+func newDEK() ([]byte, error) {
+	dek := make([]byte, dekLength)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, errors.Wrap(err, "error while generating data encryption key")
+	}
+	return dek, nil
+}
+
+func loadKeyRecord(db DB, recordKey []byte) (*KeyRecord, error) {
+	b, err := db.Get(recordKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while retrieving encryption keys")
+	}
+	if b == nil {
+		return nil, nil
+	}
+	record := &KeyRecord{}
+	if err := proto.Unmarshal(b, record); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling encryption keys")
+	}
+	return record, nil
+}
+
+func saveKeyRecord(db DB, recordKey []byte, record *KeyRecord) error {
+	b, err := proto.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "error while marshalling encryption keys")
+	}
+	return errors.WithMessage(db.Put(recordKey, b, true), "error while storing encryption keys")
+}
+
+// Encrypter encrypts and decrypts the values of a store with AES-GCM, binding each value to its key in the
+// database. A nil Encrypter stores the values in plaintext.
+type Encrypter struct {
+	aeads    map[uint32]cipher.AEAD
+	activeID uint32
+	encrypt  bool
+}
+
+func newEncrypter(deks map[uint32][]byte, activeID uint32, encrypt bool) (*Encrypter, error) {
+	e := &Encrypter{
+		aeads:    map[uint32]cipher.AEAD{},
+		activeID: activeID,
+		encrypt:  encrypt,
+	}
+	for id, dek := range deks {
+		block, err := aes.NewCipher(dek)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while constructing cipher for data encryption key [%d]", id)
+		}
+		if e.aeads[id], err = cipher.NewGCM(block); err != nil {
+			return nil, errors.Wrapf(err, "error while constructing cipher for data encryption key [%d]", id)
+		}
+	}
+	if _, ok := e.aeads[activeID]; !ok {
+		return nil, errors.Errorf("active data encryption key [%d] not found", activeID)
+	}
+	return e, nil
+}
+
+// Encrypt encrypts the value stored under the key in the database. The value is returned as is if the
+// encryption is not enabled.
+func (e *Encrypter) Encrypt(key, value []byte) ([]byte, error) {
+	if e == nil || !e.encrypt {
+		return value, nil
+	}
+	envelope := make([]byte, envelopeHeader, envelopeHeader+len(value)+e.aeads[e.activeID].Overhead())
+	envelope[0] = envelopeMarker
+	envelope[1] = envelopeVersion
+	binary.BigEndian.PutUint32(envelope[2:], e.activeID)
+	nonce := envelope[2+dekIDLength : envelopeHeader]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "error while generating nonce")
+	}
+	return e.aeads[e.activeID].Seal(envelope, nonce, value, key), nil
+}
+
+// Decrypt decrypts the value stored under the key in the database. A value that was stored in plaintext
+// is returned as is.
+func (e *Encrypter) Decrypt(key, value []byte) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if e == nil {
+		return nil, errors.New("encountered an encrypted value but no encryption keys are available")
+	}
+	if len(value) < envelopeHeader {
+		return nil, errors.Errorf("unexpected length [%d] of encrypted value", len(value))
+	}
+	dekID := binary.BigEndian.Uint32(value[2:])
+	aead, ok := e.aeads[dekID]
+	if !ok {
+		return nil, errors.Errorf("data encryption key [%d] not found", dekID)
+	}
+	plaintext, err := aead.Open(nil, value[2+dekIDLength:envelopeHeader], value[envelopeHeader:], key)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while decrypting value")
+	}
+	return plaintext, nil
+}
+
+// IsEncrypted returns true if the value was stored encrypted
+func IsEncrypted(value []byte) bool {
+	return len(value) >= 2 && value[0] == envelopeMarker && value[1] == envelopeVersion
+}
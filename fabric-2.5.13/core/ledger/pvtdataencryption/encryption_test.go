@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataencryption
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var recordKey = []byte("record")
+
+type memDB map[string][]byte
+
+func (m memDB) Get(key []byte) ([]byte, error) {
+	return m[string(key)], nil
+}
+
+func (m memDB) Put(key []byte, value []byte, sync bool) error {
+	m[string(key)] = value
+	return nil
+}
+
+type failingDB struct{}
+
+func (failingDB) Get(key []byte) ([]byte, error) {
+	return nil, errors.New("db-error")
+}
+
+func (failingDB) Put(key []byte, value []byte, sync bool) error {
+	return errors.New("db-error")
+}
+
+func newTestCSP(t *testing.T) bccsp.BCCSP {
+	csp, err := sw.NewDefaultSecurityLevel(t.TempDir())
+	require.NoError(t, err)
+	return csp
+}
+
+func TestEncrypter(t *testing.T) {
+	csp := newTestCSP(t)
+	provider, err := NewProvider(csp, true)
+	require.NoError(t, err)
+	db := memDB{}
+
+	e, err := provider.Encrypter(db, recordKey)
+	require.NoError(t, err)
+	require.NotNil(t, e)
+
+	value := []byte("private-value")
+	encrypted, err := e.Encrypt([]byte("key1"), value)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+	require.NotContains(t, string(encrypted), "private-value")
+
+	decrypted, err := e.Decrypt([]byte("key1"), encrypted)
+	require.NoError(t, err)
+	require.Equal(t, value, decrypted)
+
+	t.Run("value bound to its key", func(t *testing.T) {
+		_, err := e.Decrypt([]byte("key2"), encrypted)
+		require.EqualError(t, err, "error while decrypting value: cipher: message authentication failed")
+	})
+
+	t.Run("plaintext value", func(t *testing.T) {
+		plaintext := append([]byte{0}, []byte("\x0aproto")...)
+		decrypted, err := e.Decrypt([]byte("key1"), plaintext)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		e, err := provider.Encrypter(db, recordKey)
+		require.NoError(t, err)
+		decrypted, err := e.Decrypt([]byte("key1"), encrypted)
+		require.NoError(t, err)
+		require.Equal(t, value, decrypted)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		provider, err := NewProvider(csp, false)
+		require.NoError(t, err)
+
+		e, err := provider.Encrypter(db, recordKey)
+		require.NoError(t, err)
+		stored, err := e.Encrypt([]byte("key1"), value)
+		require.NoError(t, err)
+		require.Equal(t, value, stored)
+		decrypted, err := e.Decrypt([]byte("key1"), encrypted)
+		require.NoError(t, err)
+		require.Equal(t, value, decrypted)
+
+		e, err = provider.Encrypter(memDB{}, recordKey)
+		require.NoError(t, err)
+		require.Nil(t, e)
+		_, err = e.Decrypt([]byte("key1"), encrypted)
+		require.EqualError(t, err, "encountered an encrypted value but no encryption keys are available")
+	})
+
+	t.Run("nil provider", func(t *testing.T) {
+		var provider *Provider
+		e, err := provider.Encrypter(db, recordKey)
+		require.NoError(t, err)
+		require.Nil(t, e)
+		stored, err := e.Encrypt([]byte("key1"), value)
+		require.NoError(t, err)
+		require.Equal(t, value, stored)
+	})
+}
+
+func TestRotateKeys(t *testing.T) {
+	csp := newTestCSP(t)
+	provider, err := NewProvider(csp, true)
+	require.NoError(t, err)
+	db := memDB{}
+
+	e, err := provider.Encrypter(db, recordKey)
+	require.NoError(t, err)
+	encryptedBeforeRotation, err := e.Encrypt([]byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+	recordBeforeRotation, err := loadKeyRecord(db, recordKey)
+	require.NoError(t, err)
+
+	require.NoError(t, provider.RotateKeys(db, recordKey))
+	record, err := loadKeyRecord(db, recordKey)
+	require.NoError(t, err)
+	require.NotEqual(t, recordBeforeRotation.KekSki, record.KekSki)
+	require.Equal(t, uint32(2), record.ActiveDekId)
+	require.Len(t, record.Deks, 2)
+
+	e, err = provider.Encrypter(db, recordKey)
+	require.NoError(t, err)
+	decrypted, err := e.Decrypt([]byte("key1"), encryptedBeforeRotation)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), decrypted)
+
+	encrypted, err := e.Encrypt([]byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1, 0, 0, 0, 2}, encrypted[:6])
+	decrypted, err = e.Decrypt([]byte("key2"), encrypted)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), decrypted)
+
+	t.Run("no keys", func(t *testing.T) {
+		require.EqualError(t, provider.RotateKeys(memDB{}, recordKey), "the store does not have encryption keys")
+	})
+}
+
+func TestEncrypterErrors(t *testing.T) {
+	csp := newTestCSP(t)
+
+	t.Run("no crypto provider", func(t *testing.T) {
+		_, err := NewProvider(nil, true)
+		require.EqualError(t, err, "a crypto provider is required for encrypting private data")
+
+		provider, err := NewProvider(csp, true)
+		require.NoError(t, err)
+		db := memDB{}
+		_, err = provider.Encrypter(db, recordKey)
+		require.NoError(t, err)
+
+		provider, err = NewProvider(nil, false)
+		require.NoError(t, err)
+		_, err = provider.Encrypter(db, recordKey)
+		require.EqualError(t, err, "a crypto provider is required for decrypting private data that was stored encrypted")
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		provider, err := NewProvider(csp, true)
+		require.NoError(t, err)
+		_, err = provider.Encrypter(failingDB{}, recordKey)
+		require.EqualError(t, err, "error while retrieving encryption keys: db-error")
+	})
+
+	t.Run("unknown key encryption key", func(t *testing.T) {
+		db := memDB{}
+		db.Put(recordKey, protoMarshal(t, &KeyRecord{KekSki: []byte("unknown"), ActiveDekId: 1}), true)
+		provider, err := NewProvider(csp, true)
+		require.NoError(t, err)
+		_, err = provider.Encrypter(db, recordKey)
+		require.ErrorContains(t, err, "error while retrieving key encryption key [756e6b6e6f776e]")
+	})
+
+	t.Run("unknown data encryption key", func(t *testing.T) {
+		provider, err := NewProvider(csp, true)
+		require.NoError(t, err)
+		e, err := provider.Encrypter(memDB{}, recordKey)
+		require.NoError(t, err)
+		_, err = e.Decrypt([]byte("key1"), []byte{0, 1, 0, 0, 0, 9, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+		require.EqualError(t, err, "data encryption key [9] not found")
+		_, err = e.Decrypt([]byte("key1"), []byte{0, 1, 0, 0})
+		require.EqualError(t, err, "unexpected length [4] of encrypted value")
+	})
+}
+
+func protoMarshal(t *testing.T, m proto.Message) []byte {
+	b, err := proto.Marshal(m)
+	require.NoError(t, err)
+	return b
+}
@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: key_record.proto
+
+package pvtdataencryption
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// KeyRecord holds the data encryption keys of a store, each wrapped by the key encryption key
+// that is identified by kek_ski and managed by the crypto provider (bccsp) of the peer
+type KeyRecord struct {
+	KekSki               []byte        `protobuf:"bytes,1,opt,name=kek_ski,json=kekSki,proto3" json:"kek_ski,omitempty"`
+	ActiveDekId          uint32        `protobuf:"varint,2,opt,name=active_dek_id,json=activeDekId,proto3" json:"active_dek_id,omitempty"`
+	Deks                 []*WrappedDEK `protobuf:"bytes,3,rep,name=deks,proto3" json:"deks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *KeyRecord) Reset()         { *m = KeyRecord{} }
+func (m *KeyRecord) String() string { return proto.CompactTextString(m) }
+func (*KeyRecord) ProtoMessage()    {}
+func (*KeyRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf8678c929a5728d, []int{0}
+}
+
+func (m *KeyRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyRecord.Unmarshal(m, b)
+}
+func (m *KeyRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyRecord.Marshal(b, m, deterministic)
+}
+func (m *KeyRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyRecord.Merge(m, src)
+}
+func (m *KeyRecord) XXX_Size() int {
+	return xxx_messageInfo_KeyRecord.Size(m)
+}
+func (m *KeyRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyRecord proto.InternalMessageInfo
+
+func (m *KeyRecord) GetKekSki() []byte {
+	if m != nil {
+		return m.KekSki
+	}
+	return nil
+}
+
+func (m *KeyRecord) GetActiveDekId() uint32 {
+	if m != nil {
+		return m.ActiveDekId
+	}
+	return 0
+}
+
+func (m *KeyRecord) GetDeks() []*WrappedDEK {
+	if m != nil {
+		return m.Deks
+	}
+	return nil
+}
+
+// WrappedDEK is a data encryption key encrypted with the key encryption key
+type WrappedDEK struct {
+	Id                   uint32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WrappedKey           []byte   `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WrappedDEK) Reset()         { *m = WrappedDEK{} }
+func (m *WrappedDEK) String() string { return proto.CompactTextString(m) }
+func (*WrappedDEK) ProtoMessage()    {}
+func (*WrappedDEK) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf8678c929a5728d, []int{1}
+}
+
+func (m *WrappedDEK) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WrappedDEK.Unmarshal(m, b)
+}
+func (m *WrappedDEK) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WrappedDEK.Marshal(b, m, deterministic)
+}
+func (m *WrappedDEK) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WrappedDEK.Merge(m, src)
+}
+func (m *WrappedDEK) XXX_Size() int {
+	return xxx_messageInfo_WrappedDEK.Size(m)
+}
+func (m *WrappedDEK) XXX_DiscardUnknown() {
+	xxx_messageInfo_WrappedDEK.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WrappedDEK proto.InternalMessageInfo
+
+func (m *WrappedDEK) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WrappedDEK) GetWrappedKey() []byte {
+	if m != nil {
+		return m.WrappedKey
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*KeyRecord)(nil), "pvtdataencryption.KeyRecord")
+	proto.RegisterType((*WrappedDEK)(nil), "pvtdataencryption.WrappedDEK")
+}
+
+func init() { proto.RegisterFile("key_record.proto", fileDescriptor_cf8678c929a5728d) }
+
+var fileDescriptor_cf8678c929a5728d = []byte{
+	// 235 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0xc1, 0x4b, 0xf3, 0x40,
+	0x10, 0x47, 0x49, 0xfa, 0xd1, 0x0f, 0x27, 0x8d, 0xe8, 0x5e, 0xcc, 0x45, 0x0c, 0x39, 0xe5, 0x94,
+	0xa0, 0x1e, 0xa5, 0x17, 0xa9, 0x07, 0xc9, 0x6d, 0x3d, 0x08, 0x5e, 0xc2, 0x66, 0x67, 0x6c, 0x97,
+	0xd1, 0xee, 0xb2, 0x5d, 0x2b, 0x8b, 0xff, 0xbc, 0xb0, 0x2d, 0x78, 0xe8, 0xf5, 0xcd, 0x83, 0x37,
+	0xfc, 0xe0, 0x82, 0x29, 0x8e, 0x9e, 0xb4, 0xf5, 0xd8, 0x39, 0x6f, 0x83, 0x15, 0x97, 0x6e, 0x1f,
+	0x50, 0x05, 0x45, 0x5b, 0xed, 0xa3, 0x0b, 0xc6, 0x6e, 0x9b, 0x1f, 0x38, 0x1b, 0x28, 0xca, 0x64,
+	0x89, 0x2b, 0xf8, 0xcf, 0xc4, 0xe3, 0x8e, 0x4d, 0x95, 0xd5, 0x59, 0xbb, 0x90, 0x73, 0x26, 0x7e,
+	0x61, 0x23, 0x1a, 0x28, 0x95, 0x0e, 0x66, 0x4f, 0x23, 0x12, 0x8f, 0x06, 0xab, 0xbc, 0xce, 0xda,
+	0x52, 0x16, 0x07, 0xb8, 0x22, 0x7e, 0x46, 0x71, 0x0b, 0xff, 0x90, 0x78, 0x57, 0xcd, 0xea, 0x59,
+	0x5b, 0xdc, 0x5d, 0x77, 0x27, 0xad, 0xee, 0xd5, 0x2b, 0xe7, 0x08, 0x57, 0x4f, 0x83, 0x4c, 0x6a,
+	0xb3, 0x04, 0xf8, 0x63, 0xe2, 0x1c, 0x72, 0x83, 0x29, 0x5c, 0xca, 0xdc, 0xa0, 0xb8, 0x81, 0xe2,
+	0xfb, 0x70, 0x1d, 0x99, 0x62, 0x4a, 0x2e, 0x24, 0x1c, 0xd1, 0x40, 0xf1, 0x71, 0xf9, 0xf6, 0xb0,
+	0x36, 0x61, 0xf3, 0x35, 0x75, 0xda, 0x7e, 0xf6, 0x9b, 0xe8, 0xc8, 0x7f, 0x10, 0xae, 0xc9, 0xf7,
+	0xef, 0x6a, 0xf2, 0x46, 0xf7, 0xda, 0x7a, 0xea, 0x8f, 0xe8, 0xe4, 0x9d, 0x69, 0x9e, 0x46, 0xb9,
+	0xff, 0x1d, 0x00, 0x8f, 0x39, 0x92, 0xd3, 0x28, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,16 @@
+//go:build !pkcs11
+// +build !pkcs11
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataencryption
+
+import "github.com/hyperledger/fabric/bccsp"
+
+func kekGenOpts(csp bccsp.BCCSP) bccsp.KeyGenOpts {
+	return &bccsp.AES256KeyGenOpts{Temporary: false}
+}
@@ -0,0 +1,24 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataencryption
+
+import (
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/pkcs11"
+)
+
+// kekGenOpts returns the options for generating a KEK with csp. With the PKCS#11 crypto provider the KEK is
+// generated in, and never leaves, the token.
+func kekGenOpts(csp bccsp.BCCSP) bccsp.KeyGenOpts {
+	if _, ok := csp.(*pkcs11.Provider); ok {
+		return &pkcs11.AES256KeyGenOpts{}
+	}
+	return &bccsp.AES256KeyGenOpts{Temporary: false}
+}
@@ -0,0 +1,54 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataencryption
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/pkcs11"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncrypterPKCS11(t *testing.T) {
+	ks, err := sw.NewFileBasedKeyStore(nil, t.TempDir(), false)
+	require.NoError(t, err)
+	lib, pin, label := pkcs11.FindPKCS11Lib()
+	csp, err := pkcs11.New(pkcs11.PKCS11Opts{
+		Library:  lib,
+		Label:    label,
+		Pin:      pin,
+		Hash:     "SHA2",
+		Security: 256,
+	}, ks)
+	require.NoError(t, err)
+
+	provider, err := NewProvider(csp, true)
+	require.NoError(t, err)
+	db := memDB{}
+	e, err := provider.Encrypter(db, recordKey)
+	require.NoError(t, err)
+	ciphertext, err := e.Encrypt([]byte("key1"), []byte("value"))
+	require.NoError(t, err)
+
+	// the KEK is not in the keystore but in the token
+	record, err := loadKeyRecord(db, recordKey)
+	require.NoError(t, err)
+	_, err = ks.GetKey(record.KekSki)
+	require.Error(t, err)
+
+	provider, err = NewProvider(csp, true)
+	require.NoError(t, err)
+	e, err = provider.Encrypter(db, recordKey)
+	require.NoError(t, err)
+	plaintext, err := e.Decrypt([]byte("key1"), ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), plaintext)
+}
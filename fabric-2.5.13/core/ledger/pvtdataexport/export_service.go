@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataexport
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("pvtdataexport")
+
+// DefaultMaxBatchBytes is the size of the private data values above which a batch of
+// entries is sent to the client
+const DefaultMaxBatchBytes = 1024 * 1024
+
+// ExportService implements PvtDataExportServer grpc interface. It streams the current
+// private state of the collections for which this peer holds the private data.
+type ExportService struct {
+	LedgerGetter LedgerGetter
+	ACLProvider  ACLProvider
+	// MaxBatchBytes is the size of the private data values above which a batch is sent.
+	// DefaultMaxBatchBytes is used if not set.
+	MaxBatchBytes int
+}
+
+// LedgerGetter gets the PeerLedger associated with a channel.
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// ACLProvider checks ACL for a channelless resource
+type ACLProvider interface {
+	CheckACLNoChannel(resName string, idinfo interface{}) error
+}
+
+// Export streams the private data entries of the requested collections. The entries are read
+// in batches, each with its own query executor, so that a slow client does not hold up the
+// commit of blocks. Therefore, an export is not a point-in-time view of the private state;
+// each entry is however consistent with the value hash it carries.
+func (s *ExportService) Export(signedRequest *SignedExportRequest, stream PvtDataExport_ExportServer) error {
+	request := &ExportRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return errors.Wrap(err, "failed to unmarshal private data export request")
+	}
+
+	if err := s.checkACL(request, signedRequest); err != nil {
+		return err
+	}
+
+	if request.ChannelId == "" {
+		return errors.New("missing channel ID")
+	}
+	if len(request.Collections) == 0 {
+		return errors.New("no collections requested")
+	}
+	for _, c := range request.Collections {
+		if c.Namespace == "" || c.Collection == "" {
+			return errors.Errorf("invalid collection selector [%s:%s], both namespace and collection are required", c.Namespace, c.Collection)
+		}
+	}
+
+	lgr := s.LedgerGetter.GetLedger(request.ChannelId)
+	if lgr == nil {
+		return errors.Errorf("cannot find ledger for channel %s", request.ChannelId)
+	}
+
+	for _, c := range request.Collections {
+		logger.Infow("Exporting private data", "channel", request.ChannelId, "namespace", c.Namespace, "collection", c.Collection)
+		count := 0
+		startKey := ""
+		for {
+			entries, nextKey, err := s.readBatch(lgr, c, startKey)
+			if err != nil {
+				return err
+			}
+			if len(entries) > 0 {
+				if err := stream.Send(&ExportResponse{Entries: entries}); err != nil {
+					return err
+				}
+				count += len(entries)
+			}
+			if nextKey == "" {
+				break
+			}
+			startKey = nextKey
+		}
+		logger.Infow("Exported private data", "channel", request.ChannelId, "namespace", c.Namespace, "collection", c.Collection, "entries", count)
+	}
+	return nil
+}
+
+// readBatch reads the private data entries of a collection starting from the given key, until the size
+// of the values read exceeds the maximum size of a batch. It returns the key from which the next batch
+// starts, or an empty string if all the entries of the collection have been read.
+func (s *ExportService) readBatch(lgr ledger.PeerLedger, c *CollectionSelector, startKey string) ([]*PvtDataEntry, string, error) {
+	qe, err := lgr.NewQueryExecutor()
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "failed to create query executor")
+	}
+	defer qe.Done()
+
+	itr, err := qe.GetPrivateDataRangeScanIterator(c.Namespace, c.Collection, startKey, "")
+	if err != nil {
+		return nil, "", errors.WithMessagef(err, "failed to read private data of collection [%s:%s]", c.Namespace, c.Collection)
+	}
+	defer itr.Close()
+
+	maxBatchBytes := s.MaxBatchBytes
+	if maxBatchBytes <= 0 {
+		maxBatchBytes = DefaultMaxBatchBytes
+	}
+
+	var entries []*PvtDataEntry
+	size := 0
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, "", errors.WithMessagef(err, "failed to read private data of collection [%s:%s]", c.Namespace, c.Collection)
+		}
+		if res == nil {
+			return entries, "", nil
+		}
+		kv := res.(*queryresult.KV)
+		if size >= maxBatchBytes {
+			return entries, kv.Key, nil
+		}
+		valueHash, err := qe.GetPrivateDataHash(c.Namespace, c.Collection, kv.Key)
+		if err != nil {
+			return nil, "", errors.WithMessagef(err, "failed to read hash of private data key [%s] of collection [%s:%s]", kv.Key, c.Namespace, c.Collection)
+		}
+		entries = append(entries, &PvtDataEntry{
+			Namespace:  c.Namespace,
+			Collection: c.Collection,
+			Key:        kv.Key,
+			Value:      kv.Value,
+			KeyHash:    util.ComputeStringHash(kv.Key),
+			ValueHash:  valueHash,
+		})
+		size += len(kv.Value)
+	}
+}
+
+func (s *ExportService) checkACL(request *ExportRequest, signedRequest *SignedExportRequest) error {
+	signatureHdr := request.SignatureHeader
+	if signatureHdr == nil {
+		return errors.New("missing signature header")
+	}
+
+	expirationTime := crypto.ExpiresAt(signatureHdr.Creator)
+	if !expirationTime.IsZero() && time.Now().After(expirationTime) {
+		return errors.New("client identity expired")
+	}
+
+	return s.ACLProvider.CheckACLNoChannel(
+		resources.Pvtdata_export,
+		[]*protoutil.SignedData{{
+			Identity:  signatureHdr.Creator,
+			Data:      signedRequest.Request,
+			Signature: signedRequest.Signature,
+		}},
+	)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataexport
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataexport/mock"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+//go:generate counterfeiter -o mock/ledger_getter.go -fake-name LedgerGetter . ledgerGetter
+//go:generate counterfeiter -o mock/acl_provider.go -fake-name ACLProvider . aclProvider
+//go:generate counterfeiter -o mock/peer_ledger.go -fake-name PeerLedger . peerLedger
+//go:generate counterfeiter -o mock/query_executor.go -fake-name QueryExecutor . queryExecutor
+
+type ledgerGetter interface {
+	LedgerGetter
+}
+
+type aclProvider interface {
+	ACLProvider
+}
+
+type peerLedger interface {
+	ledger.PeerLedger
+}
+
+type queryExecutor interface {
+	ledger.QueryExecutor
+}
+
+// kvIterator iterates over the key-values of a collection, in the order of the keys
+type kvIterator struct {
+	kvs []*queryresult.KV
+}
+
+func (i *kvIterator) Next() (commonledger.QueryResult, error) {
+	if len(i.kvs) == 0 {
+		return nil, nil
+	}
+	kv := i.kvs[0]
+	i.kvs = i.kvs[1:]
+	return kv, nil
+}
+
+func (i *kvIterator) Close() {}
+
+func TestExport(t *testing.T) {
+	pvtData := map[string]map[string][]byte{
+		"ns1:coll1": {"key1": []byte("value1"), "key2": []byte("value2"), "key3": []byte("value3")},
+		"ns1:coll2": {"key1": []byte("value4")},
+	}
+	newQueryExecutor := func() *mock.QueryExecutor {
+		qe := &mock.QueryExecutor{}
+		qe.GetPrivateDataRangeScanIteratorStub = func(namespace, collection, startKey, endKey string) (commonledger.ResultsIterator, error) {
+			kvs, ok := pvtData[namespace+":"+collection]
+			if !ok {
+				return nil, errors.Errorf("collection [%s] not defined in the collection config for chaincode [%s]", collection, namespace)
+			}
+			itr := &kvIterator{}
+			for k, v := range kvs {
+				if k >= startKey {
+					itr.kvs = append(itr.kvs, &queryresult.KV{Namespace: namespace, Key: k, Value: v})
+				}
+			}
+			sort.Slice(itr.kvs, func(i, j int) bool { return itr.kvs[i].Key < itr.kvs[j].Key })
+			return itr, nil
+		}
+		qe.GetPrivateDataHashStub = func(namespace, collection, key string) ([]byte, error) {
+			if key == "key3" {
+				// the hash in the public state does not match the private value
+				return util.ComputeHash([]byte("tampered")), nil
+			}
+			return util.ComputeHash(pvtData[namespace+":"+collection][key]), nil
+		}
+		return qe
+	}
+
+	fakeLedger := &mock.PeerLedger{}
+	var queryExecutors []*mock.QueryExecutor
+	fakeLedger.NewQueryExecutorStub = func() (ledger.QueryExecutor, error) {
+		qe := newQueryExecutor()
+		queryExecutors = append(queryExecutors, qe)
+		return qe, nil
+	}
+	fakeLedgerGetter := &mock.LedgerGetter{}
+	fakeLedgerGetter.GetLedgerStub = func(channelID string) ledger.PeerLedger {
+		if channelID == "mychannel" {
+			return fakeLedger
+		}
+		return nil
+	}
+	fakeACLProvider := &mock.ACLProvider{}
+	client := startExportService(t, &ExportService{
+		LedgerGetter:  fakeLedgerGetter,
+		ACLProvider:   fakeACLProvider,
+		MaxBatchBytes: 10,
+	})
+
+	t.Run("export collections", func(t *testing.T) {
+		queryExecutors = nil
+		signedRequest := createSignedRequest("mychannel", &CollectionSelector{Namespace: "ns1", Collection: "coll2"}, &CollectionSelector{Namespace: "ns1", Collection: "coll1"})
+		responses, err := export(client, signedRequest)
+		require.NoError(t, err)
+
+		// the batches are closed once their values exceed 10 bytes
+		require.Len(t, responses, 3)
+		require.Len(t, responses[1].Entries, 2)
+		var entries []*PvtDataEntry
+		for _, resp := range responses {
+			entries = append(entries, resp.Entries...)
+		}
+		require.Len(t, entries, 4)
+		for i, expected := range []struct{ collection, key, value string }{
+			{"coll2", "key1", "value4"},
+			{"coll1", "key1", "value1"},
+			{"coll1", "key2", "value2"},
+			{"coll1", "key3", "value3"},
+		} {
+			require.Equal(t, "ns1", entries[i].Namespace)
+			require.Equal(t, expected.collection, entries[i].Collection)
+			require.Equal(t, expected.key, entries[i].Key)
+			require.Equal(t, []byte(expected.value), entries[i].Value)
+			require.Equal(t, util.ComputeStringHash(expected.key), entries[i].KeyHash)
+		}
+		require.Equal(t, util.ComputeHash([]byte("value1")), entries[1].ValueHash)
+		require.Equal(t, util.ComputeHash([]byte("tampered")), entries[3].ValueHash)
+
+		// every batch is read with its own query executor, which is released afterwards
+		require.Len(t, queryExecutors, 3)
+		for _, qe := range queryExecutors {
+			require.Equal(t, 1, qe.DoneCallCount())
+		}
+		_, _, startKey, _ := queryExecutors[2].GetPrivateDataRangeScanIteratorArgsForCall(0)
+		require.Equal(t, "key3", startKey)
+
+		resName, idinfo := fakeACLProvider.CheckACLNoChannelArgsForCall(0)
+		require.Equal(t, resources.Pvtdata_export, resName)
+		signedData := idinfo.([]*protoutil.SignedData)
+		require.Len(t, signedData, 1)
+		require.Equal(t, []byte("creator"), signedData[0].Identity)
+		require.Equal(t, []byte("signature"), signedData[0].Signature)
+		require.Equal(t, signedRequest.Request, signedData[0].Data)
+	})
+
+	t.Run("access denied", func(t *testing.T) {
+		fakeACLProvider.CheckACLNoChannelReturns(errors.New("access denied"))
+		defer fakeACLProvider.CheckACLNoChannelReturns(nil)
+		responses, err := export(client, createSignedRequest("mychannel", &CollectionSelector{Namespace: "ns1", Collection: "coll1"}))
+		require.EqualError(t, err, "rpc error: code = Unknown desc = access denied")
+		require.Empty(t, responses)
+	})
+
+	tests := []struct {
+		name          string
+		signedRequest *SignedExportRequest
+		errMsg        string
+	}{
+		{
+			name:          "unmarshal error",
+			signedRequest: &SignedExportRequest{Request: []byte("dummy")},
+			errMsg:        "failed to unmarshal private data export request",
+		},
+		{
+			name:          "missing signature header",
+			signedRequest: &SignedExportRequest{Request: protoutil.MarshalOrPanic(&ExportRequest{ChannelId: "mychannel"})},
+			errMsg:        "missing signature header",
+		},
+		{
+			name:          "missing channel ID",
+			signedRequest: createSignedRequest("", &CollectionSelector{Namespace: "ns1", Collection: "coll1"}),
+			errMsg:        "missing channel ID",
+		},
+		{
+			name:          "no collections",
+			signedRequest: createSignedRequest("mychannel"),
+			errMsg:        "no collections requested",
+		},
+		{
+			name:          "invalid collection selector",
+			signedRequest: createSignedRequest("mychannel", &CollectionSelector{Namespace: "ns1"}),
+			errMsg:        "invalid collection selector [ns1:], both namespace and collection are required",
+		},
+		{
+			name:          "cannot find ledger",
+			signedRequest: createSignedRequest("otherchannel", &CollectionSelector{Namespace: "ns1", Collection: "coll1"}),
+			errMsg:        "cannot find ledger for channel otherchannel",
+		},
+		{
+			name:          "undefined collection",
+			signedRequest: createSignedRequest("mychannel", &CollectionSelector{Namespace: "ns1", Collection: "coll3"}),
+			errMsg:        "failed to read private data of collection [ns1:coll3]: collection [coll3] not defined in the collection config for chaincode [ns1]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := export(client, test.signedRequest)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "desc = "+test.errMsg)
+		})
+	}
+}
+
+func createSignedRequest(channelID string, collections ...*CollectionSelector) *SignedExportRequest {
+	request := &ExportRequest{
+		SignatureHeader: &common.SignatureHeader{
+			Creator: []byte("creator"),
+			Nonce:   []byte("nonce"),
+		},
+		ChannelId:   channelID,
+		Collections: collections,
+	}
+	return &SignedExportRequest{
+		Request:   protoutil.MarshalOrPanic(request),
+		Signature: []byte("signature"),
+	}
+}
+
+func startExportService(t *testing.T, svc *ExportService) PvtDataExportClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	RegisterPvtDataExportServer(server, svc)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewPvtDataExportClient(conn)
+}
+
+func export(client PvtDataExportClient, signedRequest *SignedExportRequest) ([]*ExportResponse, error) {
+	stream, err := client.Export(context.Background(), signedRequest)
+	if err != nil {
+		return nil, err
+	}
+	var responses []*ExportResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return responses, nil
+		}
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, resp)
+	}
+}
@@ -0,0 +1,452 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pvtdata_export.proto
+
+package pvtdataexport
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// CollectionSelector identifies a private data collection of a chaincode
+type CollectionSelector struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectionSelector) Reset()         { *m = CollectionSelector{} }
+func (m *CollectionSelector) String() string { return proto.CompactTextString(m) }
+func (*CollectionSelector) ProtoMessage()    {}
+func (*CollectionSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c18860de5e527050, []int{0}
+}
+
+func (m *CollectionSelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionSelector.Unmarshal(m, b)
+}
+func (m *CollectionSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionSelector.Marshal(b, m, deterministic)
+}
+func (m *CollectionSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionSelector.Merge(m, src)
+}
+func (m *CollectionSelector) XXX_Size() int {
+	return xxx_messageInfo_CollectionSelector.Size(m)
+}
+func (m *CollectionSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionSelector proto.InternalMessageInfo
+
+func (m *CollectionSelector) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *CollectionSelector) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+// ExportRequest requests the current private state of the selected collections of a channel
+type ExportRequest struct {
+	SignatureHeader      *common.SignatureHeader `protobuf:"bytes,1,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	ChannelId            string                  `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Collections          []*CollectionSelector   `protobuf:"bytes,3,rep,name=collections,proto3" json:"collections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
+func (m *ExportRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()    {}
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c18860de5e527050, []int{1}
+}
+
+func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportRequest.Unmarshal(m, b)
+}
+func (m *ExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRequest.Merge(m, src)
+}
+func (m *ExportRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportRequest.Size(m)
+}
+func (m *ExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportRequest proto.InternalMessageInfo
+
+func (m *ExportRequest) GetSignatureHeader() *common.SignatureHeader {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *ExportRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ExportRequest) GetCollections() []*CollectionSelector {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+// SignedExportRequest contains a marshalled ExportRequest and the signature
+type SignedExportRequest struct {
+	Request              []byte   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedExportRequest) Reset()         { *m = SignedExportRequest{} }
+func (m *SignedExportRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedExportRequest) ProtoMessage()    {}
+func (*SignedExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c18860de5e527050, []int{2}
+}
+
+func (m *SignedExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedExportRequest.Unmarshal(m, b)
+}
+func (m *SignedExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedExportRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedExportRequest.Merge(m, src)
+}
+func (m *SignedExportRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedExportRequest.Size(m)
+}
+func (m *SignedExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedExportRequest proto.InternalMessageInfo
+
+func (m *SignedExportRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedExportRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// PvtDataEntry is the current value of a private data key, along with the hash of the key
+// and the hash of the value that are maintained in the public state of the channel.
+// The value_hash is the value returned by GetPrivateDataHash for the key, and is expected
+// to match the SHA-256 hash of the value.
+type PvtDataEntry struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	Key                  string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	KeyHash              []byte   `protobuf:"bytes,5,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	ValueHash            []byte   `protobuf:"bytes,6,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PvtDataEntry) Reset()         { *m = PvtDataEntry{} }
+func (m *PvtDataEntry) String() string { return proto.CompactTextString(m) }
+func (*PvtDataEntry) ProtoMessage()    {}
+func (*PvtDataEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c18860de5e527050, []int{3}
+}
+
+func (m *PvtDataEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataEntry.Unmarshal(m, b)
+}
+func (m *PvtDataEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataEntry.Marshal(b, m, deterministic)
+}
+func (m *PvtDataEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataEntry.Merge(m, src)
+}
+func (m *PvtDataEntry) XXX_Size() int {
+	return xxx_messageInfo_PvtDataEntry.Size(m)
+}
+func (m *PvtDataEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataEntry proto.InternalMessageInfo
+
+func (m *PvtDataEntry) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PvtDataEntry) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *PvtDataEntry) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *PvtDataEntry) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *PvtDataEntry) GetKeyHash() []byte {
+	if m != nil {
+		return m.KeyHash
+	}
+	return nil
+}
+
+func (m *PvtDataEntry) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+// ExportResponse carries a batch of the exported private data entries
+type ExportResponse struct {
+	Entries              []*PvtDataEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ExportResponse) Reset()         { *m = ExportResponse{} }
+func (m *ExportResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportResponse) ProtoMessage()    {}
+func (*ExportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c18860de5e527050, []int{4}
+}
+
+func (m *ExportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportResponse.Unmarshal(m, b)
+}
+func (m *ExportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportResponse.Merge(m, src)
+}
+func (m *ExportResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportResponse.Size(m)
+}
+func (m *ExportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportResponse proto.InternalMessageInfo
+
+func (m *ExportResponse) GetEntries() []*PvtDataEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CollectionSelector)(nil), "pvtdataexport.CollectionSelector")
+	proto.RegisterType((*ExportRequest)(nil), "pvtdataexport.ExportRequest")
+	proto.RegisterType((*SignedExportRequest)(nil), "pvtdataexport.SignedExportRequest")
+	proto.RegisterType((*PvtDataEntry)(nil), "pvtdataexport.PvtDataEntry")
+	proto.RegisterType((*ExportResponse)(nil), "pvtdataexport.ExportResponse")
+}
+
+func init() { proto.RegisterFile("pvtdata_export.proto", fileDescriptor_c18860de5e527050) }
+
+var fileDescriptor_c18860de5e527050 = []byte{
+	// 413 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x52, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0x56, 0x08, 0xeb, 0xe8, 0x6b, 0x0b, 0x93, 0x37, 0x09, 0x33, 0x18, 0x1a, 0x39, 0xed, 0x94,
+	0xa0, 0x22, 0x84, 0xb8, 0x6e, 0x4c, 0x94, 0x43, 0x25, 0xe4, 0xde, 0x38, 0x10, 0xb9, 0xc9, 0xa3,
+	0x89, 0x9a, 0xda, 0xc1, 0x76, 0x2a, 0xf2, 0xa7, 0xb8, 0xf2, 0xf7, 0x50, 0xec, 0xa4, 0x6d, 0x0a,
+	0xb7, 0x9d, 0xfc, 0xfc, 0x7d, 0x9f, 0xdf, 0xfb, 0xde, 0x27, 0xc3, 0x45, 0xb9, 0x35, 0x29, 0x37,
+	0x3c, 0xc6, 0x5f, 0xa5, 0x54, 0x26, 0x2c, 0x95, 0x34, 0x92, 0x4c, 0x5a, 0xd4, 0x81, 0x97, 0xe7,
+	0x89, 0xdc, 0x6c, 0xa4, 0x88, 0xdc, 0xe1, 0x34, 0x01, 0x03, 0x72, 0x27, 0x8b, 0x02, 0x13, 0x93,
+	0x4b, 0xb1, 0xc0, 0xa6, 0x90, 0x8a, 0xbc, 0x82, 0xa1, 0xe0, 0x1b, 0xd4, 0x25, 0x4f, 0x90, 0x7a,
+	0xd7, 0xde, 0xcd, 0x90, 0xed, 0x01, 0xf2, 0x1a, 0x20, 0xd9, 0xbd, 0xa1, 0x8f, 0x2c, 0x7d, 0x80,
+	0x04, 0x7f, 0x3c, 0x98, 0xdc, 0xdb, 0x99, 0x0c, 0x7f, 0x56, 0xa8, 0x0d, 0xb9, 0x85, 0x33, 0x9d,
+	0xaf, 0x04, 0x37, 0x95, 0xc2, 0x38, 0x43, 0x9e, 0xa2, 0xb2, 0x6d, 0x47, 0xd3, 0xe7, 0x61, 0x6b,
+	0x67, 0xd1, 0xf1, 0x33, 0x4b, 0xb3, 0x67, 0xba, 0x0f, 0x90, 0x2b, 0x80, 0x24, 0xe3, 0x42, 0x60,
+	0x11, 0xe7, 0x69, 0x3b, 0x75, 0xd8, 0x22, 0x5f, 0x52, 0x72, 0x07, 0xa3, 0xbd, 0x05, 0x4d, 0xfd,
+	0x6b, 0xff, 0x66, 0x34, 0x7d, 0x13, 0xf6, 0x22, 0x08, 0xff, 0x5d, 0x95, 0x1d, 0xbe, 0x0a, 0xe6,
+	0x70, 0xde, 0xf8, 0xc0, 0xb4, 0x6f, 0x9f, 0xc2, 0xa9, 0x72, 0xa5, 0x75, 0x3d, 0x66, 0xdd, 0xb5,
+	0x09, 0x6a, 0xe7, 0xd3, 0x7a, 0x1a, 0xb3, 0x3d, 0x10, 0xfc, 0xf6, 0x60, 0xfc, 0x75, 0x6b, 0x3e,
+	0x71, 0xc3, 0xef, 0x85, 0x51, 0xf5, 0xc3, 0x72, 0x25, 0x67, 0xe0, 0xaf, 0xb1, 0xa6, 0xbe, 0x25,
+	0x9a, 0x92, 0x5c, 0xc0, 0xc9, 0x96, 0x17, 0x15, 0xd2, 0xc7, 0x76, 0xb4, 0xbb, 0x90, 0x17, 0xf0,
+	0x64, 0x8d, 0x75, 0x9c, 0x71, 0x9d, 0xd1, 0x13, 0xe7, 0x77, 0x8d, 0xf5, 0x8c, 0xeb, 0xac, 0x09,
+	0xd1, 0x6a, 0x1c, 0x39, 0x70, 0x86, 0x2d, 0xd2, 0xd0, 0xc1, 0x67, 0x78, 0xda, 0x6d, 0xae, 0x4b,
+	0x29, 0x34, 0x92, 0xf7, 0x70, 0x8a, 0xc2, 0xa8, 0x1c, 0x35, 0xf5, 0x6c, 0xa4, 0x2f, 0x8f, 0x22,
+	0x3d, 0xdc, 0x8f, 0x75, 0xda, 0xe9, 0x77, 0x98, 0x74, 0x84, 0x95, 0x91, 0x39, 0x0c, 0xda, 0x2a,
+	0x38, 0x6a, 0xf0, 0x9f, 0xc0, 0x2f, 0xaf, 0x8e, 0x34, 0x7d, 0x53, 0x6f, 0xbd, 0xdb, 0x8f, 0xdf,
+	0x3e, 0xac, 0x72, 0x93, 0x55, 0xcb, 0xe6, 0xfb, 0x44, 0x59, 0x5d, 0xa2, 0x2a, 0x30, 0x5d, 0xa1,
+	0x8a, 0x7e, 0xf0, 0xa5, 0xca, 0x93, 0x28, 0x91, 0x0a, 0xa3, 0x16, 0xea, 0xf5, 0x5a, 0x0e, 0xec,
+	0xc7, 0x7f, 0xf7, 0x77, 0x00, 0xeb, 0xfd, 0x9b, 0xe9, 0x34, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PvtDataExportClient is the client API for PvtDataExport service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PvtDataExportClient interface {
+	// Export streams the private data entries of the requested collections, ordered by
+	// collection in the order of the request and by key within a collection
+	Export(ctx context.Context, in *SignedExportRequest, opts ...grpc.CallOption) (PvtDataExport_ExportClient, error)
+}
+
+type pvtDataExportClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPvtDataExportClient(cc grpc.ClientConnInterface) PvtDataExportClient {
+	return &pvtDataExportClient{cc}
+}
+
+func (c *pvtDataExportClient) Export(ctx context.Context, in *SignedExportRequest, opts ...grpc.CallOption) (PvtDataExport_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PvtDataExport_serviceDesc.Streams[0], "/pvtdataexport.PvtDataExport/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pvtDataExportExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PvtDataExport_ExportClient interface {
+	Recv() (*ExportResponse, error)
+	grpc.ClientStream
+}
+
+type pvtDataExportExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *pvtDataExportExportClient) Recv() (*ExportResponse, error) {
+	m := new(ExportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PvtDataExportServer is the server API for PvtDataExport service.
+type PvtDataExportServer interface {
+	// Export streams the private data entries of the requested collections, ordered by
+	// collection in the order of the request and by key within a collection
+	Export(*SignedExportRequest, PvtDataExport_ExportServer) error
+}
+
+// UnimplementedPvtDataExportServer can be embedded to have forward compatible implementations.
+type UnimplementedPvtDataExportServer struct {
+}
+
+func (*UnimplementedPvtDataExportServer) Export(req *SignedExportRequest, srv PvtDataExport_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+
+func RegisterPvtDataExportServer(s *grpc.Server, srv PvtDataExportServer) {
+	s.RegisterService(&_PvtDataExport_serviceDesc, srv)
+}
+
+func _PvtDataExport_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PvtDataExportServer).Export(m, &pvtDataExportExportServer{stream})
+}
+
+type PvtDataExport_ExportServer interface {
+	Send(*ExportResponse) error
+	grpc.ServerStream
+}
+
+type pvtDataExportExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *pvtDataExportExportServer) Send(m *ExportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _PvtDataExport_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pvtdataexport.PvtDataExport",
+	HandlerType: (*PvtDataExportServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Export",
+			Handler:       _PvtDataExport_Export_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pvtdata_export.proto",
+}
@@ -34,6 +34,7 @@ var (
 	purgeMarkerKeyPrefix             = []byte{'c'}
 	purgeMarkerCollKeyPrefix         = []byte{'d'}
 	purgeMarkerForReconKeyPrefix     = []byte{'e'}
+	encryptionKeysKey                = []byte{'f'}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
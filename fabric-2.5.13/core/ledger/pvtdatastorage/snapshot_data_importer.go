@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/pkg/errors"
 )
@@ -37,6 +38,7 @@ type SnapshotDataImporter struct {
 
 	rowsSorter *snapshotRowsSorter
 	db         *leveldbhelper.DBHandle
+	encrypter  *pvtdataencryption.Encrypter
 }
 
 func newSnapshotDataImporter(
@@ -45,6 +47,7 @@ func newSnapshotDataImporter(
 	membershipProvider ledger.MembershipInfoProvider,
 	configHistoryRetriever *confighistory.Retriever,
 	tempDirRoot string,
+	encrypter *pvtdataencryption.Encrypter,
 ) (*SnapshotDataImporter, error) {
 	rowsSorter, err := newSnapshotRowsSorter(tempDirRoot)
 	if err != nil {
@@ -55,6 +58,7 @@ func newSnapshotDataImporter(
 		eligibilityAndBTLCache: newEligibilityAndBTLCache(ledgerID, membershipProvider, configHistoryRetriever),
 		rowsSorter:             rowsSorter,
 		db:                     dbHandle,
+		encrypter:              encrypter,
 	}, nil
 }
 
@@ -95,7 +99,7 @@ func (i *SnapshotDataImporter) Done() error {
 		}
 		if row == nil {
 			// iterator exhausted. Commit all pending writes
-			return dbUpdates.commitToDB(i.db)
+			return dbUpdates.commitToDB(i.db, i.encrypter)
 		}
 
 		namespace := row.ns
@@ -109,7 +113,7 @@ func (i *SnapshotDataImporter) Done() error {
 			currentBlockNum = blkNum
 			// commit is to be invoked only on block boundaries because we write data for one block only once
 			if dbUpdates.numKVHashesEntries() >= maxBatchLenForSnapshotImport {
-				if err := dbUpdates.commitToDB(i.db); err != nil {
+				if err := dbUpdates.commitToDB(i.db, i.encrypter); err != nil {
 					return err
 				}
 				dbUpdates = newDBUpdates()
@@ -346,7 +350,7 @@ func (u *dbUpdates) numKVHashesEntries() int {
 	return len(u.bootKVHashes)
 }
 
-func (u *dbUpdates) commitToDB(db *leveldbhelper.DBHandle) error {
+func (u *dbUpdates) commitToDB(db *leveldbhelper.DBHandle, encrypter *pvtdataencryption.Encrypter) error {
 	batch := db.NewUpdateBatch()
 	for k, v := range u.elgMissingDataEntries {
 		encKey := encodeElgPrioMissingDataKey(&k)
@@ -372,6 +376,9 @@ func (u *dbUpdates) commitToDB(db *leveldbhelper.DBHandle) error {
 		if err != nil {
 			return err
 		}
+		if encVal, err = encrypter.Encrypt(encKey, encVal); err != nil {
+			return err
+		}
 		batch.Put(encKey, encVal)
 	}
 
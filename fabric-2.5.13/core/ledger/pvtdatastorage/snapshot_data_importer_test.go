@@ -60,6 +60,7 @@ func TestSnapshotImporter(t *testing.T) {
 			newMockMembershipProvider(myMSPID),
 			configHistoryMgr.GetRetriever(ledgerID),
 			testDir,
+			nil,
 		)
 		require.NoError(t, err)
 
@@ -440,6 +441,7 @@ func TestSnapshotImporterErrorPropagation(t *testing.T) {
 			newMockMembershipProvider(myMSPID),
 			configHistoryMgr.GetRetriever(ledgerID),
 			testDir,
+			nil,
 		)
 		require.NoError(t, err)
 		return snapshotDataImporter, configHistoryMgr
@@ -829,7 +831,7 @@ func TestDBUpdates(t *testing.T) {
 		dbUpdates := newDBUpdates()
 		dbUpdates.upsertElgMissingDataEntry("ns-1", "coll-1", 1, 10)
 		dbUpdates.upsertElgMissingDataEntry("ns-1", "coll-1", 1, 50)
-		require.NoError(t, dbUpdates.commitToDB(db))
+		require.NoError(t, dbUpdates.commitToDB(db, nil))
 		verifier.verifyElgMissingDataEntry(
 			&missingDataKey{
 				nsCollBlk{"ns-1", "coll-1", 1},
@@ -846,7 +848,7 @@ func TestDBUpdates(t *testing.T) {
 		dbUpdates := newDBUpdates()
 		dbUpdates.upsertInelgMissingDataEntry("ns-1", "coll-1", 1, 10)
 		dbUpdates.upsertInelgMissingDataEntry("ns-1", "coll-1", 1, 50)
-		require.NoError(t, dbUpdates.commitToDB(db))
+		require.NoError(t, dbUpdates.commitToDB(db, nil))
 		verifier.verifyInelgMissingDataEntry(
 			&missingDataKey{
 				nsCollBlk{"ns-1", "coll-1", 1},
@@ -863,7 +865,7 @@ func TestDBUpdates(t *testing.T) {
 		dbUpdates := newDBUpdates()
 		dbUpdates.upsertBootKVHashes("ns-1", "coll-1", 1, 2, []byte("key-hash"), []byte("value-hash"))
 		dbUpdates.upsertBootKVHashes("ns-1", "coll-1", 1, 2, []byte("another-key-hash"), []byte("another-value-hash"))
-		require.NoError(t, dbUpdates.commitToDB(db))
+		require.NoError(t, dbUpdates.commitToDB(db, nil))
 		verifier.verifyBootKVHashesEntry(
 			&bootKVHashesKey{
 				blkNum: 1,
@@ -895,7 +897,7 @@ func TestDBUpdates(t *testing.T) {
 		dbUpdates.upsertExpiryEntry(5, 2, "ns-1", "coll-1", 10)
 		dbUpdates.upsertExpiryEntry(5, 2, "ns-1", "coll-1", 11)
 		dbUpdates.upsertExpiryEntry(5, 2, "ns-1", "coll-2", 12)
-		require.NoError(t, dbUpdates.commitToDB(db))
+		require.NoError(t, dbUpdates.commitToDB(db, nil))
 
 		verifier.verifyExpiryEntry(
 			&expiryKey{
@@ -925,7 +927,7 @@ func TestDBUpdates(t *testing.T) {
 		dbUpdates := newDBUpdates()
 		dbUpdates.elgMissingDataEntries[missingDataKey{}] = &bitset.BitSet{}
 		dbProvider.Close()
-		err := dbUpdates.commitToDB(db)
+		err := dbUpdates.commitToDB(db, nil)
 		require.Contains(t, err.Error(), "leveldb: closed")
 	})
 }
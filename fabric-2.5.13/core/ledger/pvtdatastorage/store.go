@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/pkg/errors"
@@ -43,6 +44,10 @@ type PrivateDataConfig struct {
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.PrivateDataConfig and not exposed to other components.
 	StorePath string
+	// EncryptionProvider supplies the keys for encrypting the private data at rest. It is
+	// constructed by the ledger component from ledger.PrivateDataConfig and the crypto provider
+	// of the peer. A nil EncryptionProvider stores the private data in plaintext.
+	EncryptionProvider *pvtdataencryption.Provider
 }
 
 // Store manages the permanent storage of private write sets for a ledger
@@ -54,6 +59,7 @@ type Store struct {
 	maxBatchSize          int
 	purgeInterval         uint64
 	purgedKeyAuditLogging bool
+	encrypter             *pvtdataencryption.Encrypter
 
 	isEmpty            bool
 	lastCommittedBlock uint64
@@ -209,6 +215,10 @@ func (p *Provider) SnapshotDataImporterFor(
 	if err := db.WriteBatch(batch, true); err != nil {
 		return nil, errors.WithMessage(err, "error while writing snapshot info to db")
 	}
+	encrypter, err := p.pvtData.EncryptionProvider.Encrypter(db, encryptionKeysKey)
+	if err != nil {
+		return nil, err
+	}
 
 	return newSnapshotDataImporter(
 		ledgerID,
@@ -216,12 +226,17 @@ func (p *Provider) SnapshotDataImporterFor(
 		membershipProvider,
 		configHistoryRetriever,
 		tempDirRoot,
+		encrypter,
 	)
 }
 
 // OpenStore returns a handle to a store
 func (p *Provider) OpenStore(ledgerid string) (*Store, error) {
 	dbHandle := p.dbProvider.GetDBHandle(ledgerid)
+	encrypter, err := p.pvtData.EncryptionProvider.Encrypter(dbHandle, encryptionKeysKey)
+	if err != nil {
+		return nil, err
+	}
 	s := &Store{
 		db:                                  dbHandle,
 		ledgerid:                            ledgerid,
@@ -229,6 +244,7 @@ func (p *Provider) OpenStore(ledgerid string) (*Store, error) {
 		maxBatchSize:                        p.pvtData.MaxBatchSize,
 		purgeInterval:                       uint64(p.pvtData.PurgeInterval),
 		purgedKeyAuditLogging:               p.pvtData.PurgedKeyAuditLogging,
+		encrypter:                           encrypter,
 		deprioritizedDataReconcilerInterval: p.pvtData.DeprioritizedDataReconcilerInterval,
 		accessDeprioMissingDataAfter:        time.Now().Add(p.pvtData.DeprioritizedDataReconcilerInterval),
 		collElgProcSync: &collElgProcSync{
@@ -255,6 +271,25 @@ func (p *Provider) Drop(ledgerid string) error {
 	return p.dbProvider.Drop(ledgerid)
 }
 
+// RotateEncryptionKeys re-wraps the data encryption keys of the pvtdata store of a ledger with a new key
+// encryption key and switches to a new data encryption key for the data that is committed from now on.
+// The pvtdata store of a ledger that has never been encrypted is skipped and false is returned.
+// This function is expected to be invoked when the ledger is not open.
+func (p *Provider) RotateEncryptionKeys(ledgerid string) (bool, error) {
+	if p.pvtData.EncryptionProvider == nil {
+		return false, errors.New("encryption of private data is not configured")
+	}
+	db := p.dbProvider.GetDBHandle(ledgerid)
+	keys, err := db.Get(encryptionKeysKey)
+	if err != nil || keys == nil {
+		return false, err
+	}
+	if err := p.pvtData.EncryptionProvider.RotateKeys(db, encryptionKeysKey); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 //////// store functions  ////////////////
 //////////////////////////////////////////
 
@@ -328,6 +363,9 @@ func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtD
 		if val, err = encodeDataValue(dataEntry.value); err != nil {
 			return err
 		}
+		if val, err = s.encrypter.Encrypt(key, val); err != nil {
+			return err
+		}
 		batch.Put(key, val)
 	}
 
@@ -513,6 +551,9 @@ func (s *Store) GetPvtDataByBlockNum(blockNum uint64, filter ledger.PvtNsCollFil
 			currentTxWsetAssember = newTxPvtdataAssembler(blockNum, currentTxNum)
 		}
 
+		if dataValueBytes, err = s.encrypter.Decrypt(dataKeyBytes, dataValueBytes); err != nil {
+			return nil, err
+		}
 		dataValue, err := decodeDataValue(dataValueBytes)
 		if err != nil {
 			return nil, err
@@ -754,19 +795,21 @@ func (s *Store) FetchBootKVHashes(blkNum, txNum uint64, ns, coll string) (map[st
 			"unexpected call. Boot KV Hashes are persisted only for the data imported from snapshot",
 		)
 	}
-	encVal, err := s.db.Get(
-		encodeBootKVHashesKey(
-			&bootKVHashesKey{
-				blkNum: blkNum,
-				txNum:  txNum,
-				ns:     ns,
-				coll:   coll,
-			},
-		),
+	encKey := encodeBootKVHashesKey(
+		&bootKVHashesKey{
+			blkNum: blkNum,
+			txNum:  txNum,
+			ns:     ns,
+			coll:   coll,
+		},
 	)
+	encVal, err := s.db.Get(encKey)
 	if err != nil || encVal == nil {
 		return nil, err
 	}
+	if encVal, err = s.encrypter.Decrypt(encKey, encVal); err != nil {
+		return nil, err
+	}
 	bootKVHashes, err := decodeBootKVHashesVal(encVal)
 	if err != nil {
 		return nil, err
@@ -871,7 +914,7 @@ func (s *Store) deleteDataMarkedForPurge() error {
 	maxBatchSize := 4 * 1024 * 1024 // 4Mb
 	purgeMarkerCounter := 0
 	hashedIndexCounter := 0
-	p := newPurgeUpdatesProcessor(s.ledgerid, s.db, s.purgedKeyAuditLogging, maxBatchSize)
+	p := newPurgeUpdatesProcessor(s.ledgerid, s.db, s.encrypter, s.purgedKeyAuditLogging, maxBatchSize)
 	pStart, pEnd := rangeScanKeysForPurgeMarkers()
 
 	// get the purge markers that need to be processed at this block height
@@ -956,10 +999,14 @@ func (s *Store) retrieveExpiryEntries(minBlkNum, maxBlkNum uint64) ([]*expiryEnt
 func (s *Store) retrieveDataEntries(dataKeys []*dataKey) ([]*dataEntry, error) {
 	dataEntries := []*dataEntry{}
 	for _, k := range dataKeys {
-		v, err := s.db.Get(encodeDataKey(k))
+		encKey := encodeDataKey(k)
+		v, err := s.db.Get(encKey)
 		if err != nil {
 			return nil, err
 		}
+		if v, err = s.encrypter.Decrypt(encKey, v); err != nil {
+			return nil, err
+		}
 
 		collWS, err := decodeDataValue(v)
 		if err != nil {
@@ -1169,6 +1216,7 @@ func (c *collElgProcSync) waitForDone() {
 type purgeUpdatesProcessor struct {
 	ledgerid     string
 	db           *leveldbhelper.DBHandle
+	encrypter    *pvtdataencryption.Encrypter
 	batch        *leveldbhelper.UpdateBatch
 	maxBatchSize int
 
@@ -1180,10 +1228,11 @@ type purgeUpdatesProcessor struct {
 
 // newPurgeUpdatesProcessor is used for processing the purge markers - i.e., delete the private data versions that are marked for purge from
 // the pvtdata store.
-func newPurgeUpdatesProcessor(ledgerid string, db *leveldbhelper.DBHandle, purgedKeyAuditLogging bool, maxBatchSize int) *purgeUpdatesProcessor {
+func newPurgeUpdatesProcessor(ledgerid string, db *leveldbhelper.DBHandle, encrypter *pvtdataencryption.Encrypter, purgedKeyAuditLogging bool, maxBatchSize int) *purgeUpdatesProcessor {
 	return &purgeUpdatesProcessor{
 		ledgerid:              ledgerid,
 		db:                    db,
+		encrypter:             encrypter,
 		purgedKeyAuditLogging: purgedKeyAuditLogging,
 		maxBatchSize:          maxBatchSize,
 		pvtWrites:             map[string]*rwsetutil.CollPvtRwSet{},
@@ -1208,6 +1257,9 @@ func (p *purgeUpdatesProcessor) process(hashedIndexKey, hashedIndexVal []byte) e
 		if err != nil {
 			return err
 		}
+		if dataValue, err = p.encrypter.Decrypt(dataKey, dataValue); err != nil {
+			return err
+		}
 		collPvtRWSetProto, err := decodeDataValue(dataValue)
 		if err != nil {
 			return err
@@ -1263,6 +1315,9 @@ func (p *purgeUpdatesProcessor) commitPendingChanges() error {
 		if err != nil {
 			return err
 		}
+		if encDataValue, err = p.encrypter.Encrypt([]byte(k), encDataValue); err != nil {
+			return err
+		}
 		p.batch.Put([]byte(k), encDataValue)
 	}
 	if err := p.db.WriteBatch(p.batch, true); err != nil {
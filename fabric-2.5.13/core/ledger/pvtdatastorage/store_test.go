@@ -18,9 +18,11 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/stretchr/testify/require"
@@ -776,6 +778,110 @@ func TestDrop(t *testing.T) {
 	require.EqualError(t, env.TestStoreProvider.Drop(ledgerid), "internal leveldb error while obtaining db iterator: leveldb: closed")
 }
 
+func TestStoreEncryption(t *testing.T) {
+	ledgerid := "TestStoreEncryption"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	csp, err := sw.NewDefaultSecurityLevel(t.TempDir())
+	require.NoError(t, err)
+	conf := pvtDataConf()
+	conf.EncryptionProvider, err = pvtdataencryption.NewProvider(csp, true)
+	require.NoError(t, err)
+
+	env := NewTestStoreEnv(t, ledgerid, btlPolicy, conf)
+	defer env.Cleanup()
+
+	verifyStoredEncrypted := func(blkNum uint64, expectEncrypted bool) {
+		startKey, endKey := getDataKeysForRangeScanByBlockNum(blkNum)
+		itr, err := env.TestStore.db.GetIterator(startKey, endKey)
+		require.NoError(t, err)
+		defer itr.Release()
+		numValues := 0
+		for itr.Next() {
+			require.Equal(t, expectEncrypted, pvtdataencryption.IsEncrypted(itr.Value()))
+			require.Equal(t, !expectEncrypted, strings.Contains(string(itr.Value()), "value-ns-1"))
+			numValues++
+		}
+		require.NotZero(t, numValues)
+	}
+
+	verifyRetrieval := func(blkNum uint64, expectedData []*ledger.TxPvtData) {
+		retrievedData, err := env.TestStore.GetPvtDataByBlockNum(blkNum, nil)
+		require.NoError(t, err)
+		require.Len(t, retrievedData, len(expectedData))
+		for i, data := range retrievedData {
+			require.Equal(t, expectedData[i].SeqInBlock, data.SeqInBlock)
+			require.True(t, proto.Equal(expectedData[i].WriteSet, data.WriteSet))
+		}
+	}
+
+	blk1Data := []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"}),
+	}
+	blk1MissingData := make(ledger.TxMissingPvtData)
+	blk1MissingData.Add(1, "ns-1", "coll-1", true)
+	require.NoError(t, env.TestStore.Commit(0, nil, nil, nil))
+	require.NoError(t, env.TestStore.Commit(1, blk1Data, blk1MissingData, nil))
+	verifyStoredEncrypted(1, true)
+	verifyRetrieval(1, blk1Data)
+
+	// the reconciled data is encrypted as well
+	reconciledData := produceSamplePvtdata(t, 1, []string{"ns-1:coll-1"})
+	require.NoError(t, env.TestStore.CommitPvtDataOfOldBlocks(
+		map[uint64][]*ledger.TxPvtData{1: {reconciledData}},
+		nil,
+	))
+	verifyStoredEncrypted(1, true)
+	verifyRetrieval(1, []*ledger.TxPvtData{reconciledData, blk1Data[0]})
+
+	// rotating the keys retains the access to the data stored before the rotation
+	env.TestStoreProvider.Close()
+	env.TestStoreProvider, err = NewProvider(conf)
+	require.NoError(t, err)
+	rotated, err := env.TestStoreProvider.RotateEncryptionKeys(ledgerid)
+	require.NoError(t, err)
+	require.True(t, rotated)
+	rotated, err = env.TestStoreProvider.RotateEncryptionKeys("non-existing-ledger")
+	require.NoError(t, err)
+	require.False(t, rotated)
+	env.CloseAndReopen()
+	verifyRetrieval(1, []*ledger.TxPvtData{reconciledData, blk1Data[0]})
+
+	blk2Data := []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 1, []string{"ns-1:coll-1"}),
+	}
+	require.NoError(t, env.TestStore.Commit(2, blk2Data, nil, nil))
+	verifyStoredEncrypted(2, true)
+	verifyRetrieval(2, blk2Data)
+
+	// disabling the encryption retains the access to the data stored encrypted
+	conf.EncryptionProvider, err = pvtdataencryption.NewProvider(csp, false)
+	require.NoError(t, err)
+	env.CloseAndReopen()
+	verifyRetrieval(1, []*ledger.TxPvtData{reconciledData, blk1Data[0]})
+	verifyRetrieval(2, blk2Data)
+
+	blk3Data := []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 1, []string{"ns-1:coll-1"}),
+	}
+	require.NoError(t, env.TestStore.Commit(3, blk3Data, nil, nil))
+	verifyStoredEncrypted(3, false)
+	verifyRetrieval(3, blk3Data)
+
+	// the encrypted data cannot be read without the crypto provider
+	conf.EncryptionProvider, err = pvtdataencryption.NewProvider(nil, false)
+	require.NoError(t, err)
+	env.TestStoreProvider.Close()
+	env.TestStoreProvider, err = NewProvider(conf)
+	require.NoError(t, err)
+	_, err = env.TestStoreProvider.OpenStore(ledgerid)
+	require.EqualError(t, err, "a crypto provider is required for decrypting private data that was stored encrypted")
+}
+
 func TestStoreFilterPurgedKeys(t *testing.T) {
 	ledgerid := "TestStoreFilterPurgedKeys"
 	btlPolicy := btltestutil.SampleBTLPolicy(
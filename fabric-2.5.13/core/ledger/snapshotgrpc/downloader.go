@@ -0,0 +1,241 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Signer signs the snapshot file requests sent to the remote peer
+type Signer interface {
+	Sign(msg []byte) ([]byte, error)
+	Serialize() ([]byte, error)
+}
+
+// Downloader downloads a completed snapshot from a remote peer via the SnapshotTransfer service.
+// A download that was interrupted can be resumed by invoking Download again with the same
+// destination directory; the files that were already downloaded and verified are skipped and
+// partially downloaded files are resumed from their current size.
+type Downloader struct {
+	Client SnapshotTransferClient
+	Signer Signer
+}
+
+// Download fetches the snapshot of the channel at the specified block number into destDir and
+// verifies every file against the hashes listed in the snapshot signable metadata. A block number
+// 0 selects the most recent snapshot available on the remote peer. The metadata files are written
+// only after all the other files have been verified, so that destDir can be passed to the
+// joinbysnapshot operation only once the download is complete.
+func (d *Downloader) Download(ctx context.Context, channelID string, blockNumber uint64, destDir string) (*kvledger.SnapshotSignableMetadata, error) {
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create dir [%s]", destDir)
+	}
+
+	signableMetadataBytes := &bytes.Buffer{}
+	blockNumber, err := d.fetch(ctx, channelID, blockNumber, kvledger.SnapshotSignableMetadataFileName, 0, signableMetadataBytes)
+	if err != nil {
+		return nil, err
+	}
+	additionalMetadataBytes := &bytes.Buffer{}
+	if _, err := d.fetch(ctx, channelID, blockNumber, kvledger.SnapshotAdditionalMetadataFileName, 0, additionalMetadataBytes); err != nil {
+		return nil, err
+	}
+
+	signableMetadata := &kvledger.SnapshotSignableMetadata{}
+	if err := json.Unmarshal(signableMetadataBytes.Bytes(), signableMetadata); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot signable metadata")
+	}
+	additionalMetadata := &struct {
+		SnapshotHashInHex string `json:"snapshot_hash"`
+	}{}
+	if err := json.Unmarshal(additionalMetadataBytes.Bytes(), additionalMetadata); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot additional metadata")
+	}
+	if h := sha256.Sum256(signableMetadataBytes.Bytes()); hex.EncodeToString(h[:]) != additionalMetadata.SnapshotHashInHex {
+		return nil, errors.Errorf("hash mismatch for file [%s]. Expected hash = [%s], Actual hash = [%s]",
+			kvledger.SnapshotSignableMetadataFileName, additionalMetadata.SnapshotHashInHex, hex.EncodeToString(h[:]),
+		)
+	}
+	if signableMetadata.ChannelName != channelID {
+		return nil, errors.Errorf("snapshot is for channel [%s], expected [%s]", signableMetadata.ChannelName, channelID)
+	}
+
+	fileNames := make([]string, 0, len(signableMetadata.FilesAndHashes))
+	for f := range signableMetadata.FilesAndHashes {
+		if f != filepath.Base(f) {
+			return nil, errors.Errorf("invalid file name [%s] in snapshot signable metadata", f)
+		}
+		fileNames = append(fileNames, f)
+	}
+	sort.Strings(fileNames)
+
+	for _, f := range fileNames {
+		if err := d.downloadFile(ctx, channelID, blockNumber, destDir, f, signableMetadata.FilesAndHashes[f]); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, content := range map[string][]byte{
+		kvledger.SnapshotSignableMetadataFileName:   signableMetadataBytes.Bytes(),
+		kvledger.SnapshotAdditionalMetadataFileName: additionalMetadataBytes.Bytes(),
+	} {
+		if err := fileutil.CreateAndSyncFileAtomically(destDir, name+".tmp", name, content, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return signableMetadata, nil
+}
+
+// downloadFile ensures that the local copy of a snapshot file matches the expected hash, fetching
+// the missing content from the remote peer. If resuming a partially downloaded file does not
+// produce the expected hash, the file is downloaded again from the beginning.
+func (d *Downloader) downloadFile(ctx context.Context, channelID string, blockNumber uint64, dir, fileName, expectedHash string) error {
+	filePath := filepath.Join(dir, fileName)
+	size, hash, err := sizeAndHash(filePath)
+	if err != nil {
+		return err
+	}
+	if size > 0 && hash == expectedHash {
+		logger.Debugw("Snapshot file already downloaded", "file", fileName)
+		return nil
+	}
+
+	for _, offset := range []int64{size, 0} {
+		if err := d.fetchIntoFile(ctx, channelID, blockNumber, filePath, offset); err != nil {
+			return err
+		}
+		if _, hash, err = sizeAndHash(filePath); err != nil {
+			return err
+		}
+		if hash == expectedHash {
+			return nil
+		}
+		if offset == 0 {
+			break
+		}
+		logger.Warnw("Hash mismatch after resuming download of snapshot file, downloading it again", "file", fileName)
+	}
+	return errors.Errorf("hash mismatch for file [%s]. Expected hash = [%s], Actual hash = [%s]", fileName, expectedHash, hash)
+}
+
+func (d *Downloader) fetchIntoFile(ctx context.Context, channelID string, blockNumber uint64, filePath string, offset int64) error {
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file [%s]", filePath)
+	}
+	defer f.Close()
+
+	if err := f.Truncate(offset); err != nil {
+		return errors.Wrapf(err, "failed to truncate file [%s]", filePath)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "failed to seek file [%s]", filePath)
+	}
+	if _, err := d.fetch(ctx, channelID, blockNumber, filepath.Base(filePath), offset, f); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// fetch streams the content of a snapshot file from the remote peer into w, starting at the given
+// offset, and returns the block number of the snapshot that served the request.
+func (d *Downloader) fetch(ctx context.Context, channelID string, blockNumber uint64, fileName string, offset int64, w io.Writer) (uint64, error) {
+	signedRequest, err := d.signedRequest(channelID, blockNumber, fileName, offset)
+	if err != nil {
+		return 0, err
+	}
+
+	stream, err := d.Client.FetchFile(ctx, signedRequest)
+	if err != nil {
+		return 0, errors.WithMessagef(err, "failed to fetch file [%s]", fileName)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return blockNumber, nil
+		}
+		if err != nil {
+			return 0, errors.WithMessagef(err, "failed to fetch file [%s]", fileName)
+		}
+		if blockNumber != 0 && chunk.BlockNumber != blockNumber {
+			return 0, errors.Errorf("received chunk of snapshot at block %d, expected block %d", chunk.BlockNumber, blockNumber)
+		}
+		if chunk.Offset != offset {
+			return 0, errors.Errorf("received chunk of file [%s] at offset %d, expected offset %d", fileName, chunk.Offset, offset)
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			return 0, errors.Wrapf(err, "failed to write file [%s]", fileName)
+		}
+		blockNumber = chunk.BlockNumber
+		offset += int64(len(chunk.Data))
+	}
+}
+
+func (d *Downloader) signedRequest(channelID string, blockNumber uint64, fileName string, offset int64) (*SignedSnapshotFileRequest, error) {
+	creator, err := d.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize signer")
+	}
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	requestBytes := protoutil.MarshalOrPanic(&SnapshotFileRequest{
+		SignatureHeader: &cb.SignatureHeader{
+			Creator: creator,
+			Nonce:   nonce,
+		},
+		ChannelId:   channelID,
+		BlockNumber: blockNumber,
+		FileName:    fileName,
+		Offset:      offset,
+	})
+	signature, err := d.Signer.Sign(requestBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to sign snapshot file request")
+	}
+	return &SignedSnapshotFileRequest{
+		Request:   requestBytes,
+		Signature: signature,
+	}, nil
+}
+
+// sizeAndHash returns the size and the hex encoded SHA256 hash of a file, or zero values if the
+// file does not exist
+func sizeAndHash(filePath string) (int64, string, error) {
+	f, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "failed to open file [%s]", filePath)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "failed to read file [%s]", filePath)
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
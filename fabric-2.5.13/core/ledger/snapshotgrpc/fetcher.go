@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/pkg/errors"
+)
+
+// PeerFetcher downloads, on behalf of this peer, the snapshots by which it joins channels from other
+// peers. The snapshot of a channel is downloaded into a dir per channel under the downloaded snapshots
+// dir of the peer, so that an interrupted download is resumed when the channel is joined again.
+type PeerFetcher struct {
+	SnapshotsRootDir string
+	// ClientConfig is used to connect to the remote peers. Its server root CAs are used when the
+	// TLS root cert of the remote peer is not provided.
+	ClientConfig comm.ClientConfig
+	Signer       Signer
+
+	lock          sync.Mutex
+	inProgressDir string
+}
+
+// Fetch starts downloading the snapshot of the channel at the given block number from the peer at the
+// given address, and invokes join with the snapshot dir once the snapshot is downloaded and verified.
+// A block number 0 selects the most recent snapshot available on the remote peer. Only one snapshot
+// is downloaded at a time.
+func (f *PeerFetcher) Fetch(channelID, address string, tlsRootCert []byte, blockNumber uint64, join func(snapshotDir string) error) error {
+	if channelID == "" {
+		return errors.New("missing channel ID")
+	}
+	if address == "" {
+		return errors.New("missing address of the peer to download the snapshot from")
+	}
+	snapshotDir := kvledger.DownloadedSnapshotDirForLedger(f.SnapshotsRootDir, channelID)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.inProgressDir != "" {
+		return errors.Errorf("a snapshot is already being downloaded into [%s]", f.inProgressDir)
+	}
+
+	config := f.ClientConfig
+	if len(tlsRootCert) != 0 {
+		config.SecOpts.ServerRootCAs = [][]byte{tlsRootCert}
+	}
+	conn, err := config.Dial(address)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to connect to peer %s", address)
+	}
+	f.inProgressDir = snapshotDir
+	go func() {
+		defer f.done()
+		defer conn.Close()
+
+		logger.Infow("Downloading snapshot", "channel", channelID, "peer", address, "dir", snapshotDir)
+		downloader := &Downloader{
+			Client: NewSnapshotTransferClient(conn),
+			Signer: f.Signer,
+		}
+		metadata, err := downloader.Download(context.Background(), channelID, blockNumber, snapshotDir)
+		if err != nil {
+			logger.Errorw("Failed to download snapshot, join the channel from the same peer again to resume the download", "channel", channelID, "peer", address, "error", err)
+			return
+		}
+		logger.Infow("Downloaded and verified snapshot", "channel", channelID, "blockNumber", metadata.LastBlockNumber)
+
+		if err := join(snapshotDir); err != nil {
+			logger.Errorw("Failed to join channel by downloaded snapshot", "channel", channelID, "dir", snapshotDir, "error", err)
+		}
+	}()
+	return nil
+}
+
+// InProgressDir returns the dir into which a snapshot is being downloaded, or an empty string if no
+// snapshot is being downloaded.
+func (f *PeerFetcher) InProgressDir() string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.inProgressDir
+}
+
+func (f *PeerFetcher) done() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.inProgressDir = ""
+}
@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestPeerFetcher(t *testing.T) {
+	remoteRootDir := t.TempDir()
+	channelID := "testpeerfetcher"
+	snapshotDir := createTestSnapshot(t, remoteRootDir, channelID, 10)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	RegisterSnapshotTransferServer(server, &TransferService{
+		SnapshotsRootDir: remoteRootDir,
+		ACLProvider:      &mock.ChannelACLProvider{},
+	})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	localRootDir := t.TempDir()
+	fetcher := &PeerFetcher{
+		SnapshotsRootDir: localRootDir,
+		ClientConfig:     comm.ClientConfig{DialTimeout: time.Second},
+		Signer:           testSigner{},
+	}
+
+	joinC := make(chan string)
+	releaseJoin := make(chan struct{})
+	join := func(dir string) error {
+		joinC <- dir
+		<-releaseJoin
+		return nil
+	}
+	require.NoError(t, fetcher.Fetch(channelID, lis.Addr().String(), nil, 0, join))
+
+	expectedDir := kvledger.DownloadedSnapshotDirForLedger(localRootDir, channelID)
+	require.Equal(t, expectedDir, fetcher.InProgressDir())
+	err = fetcher.Fetch(channelID, lis.Addr().String(), nil, 0, join)
+	require.EqualError(t, err, "a snapshot is already being downloaded into ["+expectedDir+"]")
+
+	select {
+	case dir := <-joinC:
+		require.Equal(t, expectedDir, dir)
+	case <-time.After(time.Minute):
+		t.Fatal("snapshot was not downloaded")
+	}
+	requireSameDirContent(t, snapshotDir, expectedDir)
+	require.Equal(t, expectedDir, fetcher.InProgressDir(), "in progress until joined")
+	close(releaseJoin)
+	require.Eventually(t, func() bool { return fetcher.InProgressDir() == "" }, time.Minute, 10*time.Millisecond)
+
+	require.EqualError(t, fetcher.Fetch("", lis.Addr().String(), nil, 0, join), "missing channel ID")
+	require.EqualError(t, fetcher.Fetch(channelID, "", nil, 0, join), "missing address of the peer to download the snapshot from")
+}
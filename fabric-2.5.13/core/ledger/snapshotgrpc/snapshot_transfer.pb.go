@@ -0,0 +1,351 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: snapshot_transfer.proto
+
+package snapshotgrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SnapshotFileRequest requests the content of a single file of a completed snapshot
+type SnapshotFileRequest struct {
+	SignatureHeader *common.SignatureHeader `protobuf:"bytes,1,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	ChannelId       string                  `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// block_number identifies the snapshot; 0 selects the most recent completed snapshot
+	BlockNumber uint64 `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// file_name is the name of the file within the snapshot directory
+	FileName string `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// offset is the position in the file from which the content is streamed,
+	// used for resuming an interrupted transfer
+	Offset               int64    `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotFileRequest) Reset()         { *m = SnapshotFileRequest{} }
+func (m *SnapshotFileRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotFileRequest) ProtoMessage()    {}
+func (*SnapshotFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d79237daa74c615f, []int{0}
+}
+
+func (m *SnapshotFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotFileRequest.Unmarshal(m, b)
+}
+func (m *SnapshotFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotFileRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotFileRequest.Merge(m, src)
+}
+func (m *SnapshotFileRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotFileRequest.Size(m)
+}
+func (m *SnapshotFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotFileRequest proto.InternalMessageInfo
+
+func (m *SnapshotFileRequest) GetSignatureHeader() *common.SignatureHeader {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *SnapshotFileRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *SnapshotFileRequest) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *SnapshotFileRequest) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *SnapshotFileRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+// SignedSnapshotFileRequest contains a marshalled SnapshotFileRequest and the signature
+type SignedSnapshotFileRequest struct {
+	Request              []byte   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedSnapshotFileRequest) Reset()         { *m = SignedSnapshotFileRequest{} }
+func (m *SignedSnapshotFileRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedSnapshotFileRequest) ProtoMessage()    {}
+func (*SignedSnapshotFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d79237daa74c615f, []int{1}
+}
+
+func (m *SignedSnapshotFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedSnapshotFileRequest.Unmarshal(m, b)
+}
+func (m *SignedSnapshotFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedSnapshotFileRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedSnapshotFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedSnapshotFileRequest.Merge(m, src)
+}
+func (m *SignedSnapshotFileRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedSnapshotFileRequest.Size(m)
+}
+func (m *SignedSnapshotFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedSnapshotFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedSnapshotFileRequest proto.InternalMessageInfo
+
+func (m *SignedSnapshotFileRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedSnapshotFileRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// SnapshotFileChunk carries a part of the content of a snapshot file
+type SnapshotFileChunk struct {
+	// block_number is the last block number of the snapshot the chunk belongs to
+	BlockNumber          uint64   `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotFileChunk) Reset()         { *m = SnapshotFileChunk{} }
+func (m *SnapshotFileChunk) String() string { return proto.CompactTextString(m) }
+func (*SnapshotFileChunk) ProtoMessage()    {}
+func (*SnapshotFileChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d79237daa74c615f, []int{2}
+}
+
+func (m *SnapshotFileChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotFileChunk.Unmarshal(m, b)
+}
+func (m *SnapshotFileChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotFileChunk.Marshal(b, m, deterministic)
+}
+func (m *SnapshotFileChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotFileChunk.Merge(m, src)
+}
+func (m *SnapshotFileChunk) XXX_Size() int {
+	return xxx_messageInfo_SnapshotFileChunk.Size(m)
+}
+func (m *SnapshotFileChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotFileChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotFileChunk proto.InternalMessageInfo
+
+func (m *SnapshotFileChunk) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *SnapshotFileChunk) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *SnapshotFileChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SnapshotFileRequest)(nil), "snapshotgrpc.SnapshotFileRequest")
+	proto.RegisterType((*SignedSnapshotFileRequest)(nil), "snapshotgrpc.SignedSnapshotFileRequest")
+	proto.RegisterType((*SnapshotFileChunk)(nil), "snapshotgrpc.SnapshotFileChunk")
+}
+
+func init() { proto.RegisterFile("snapshot_transfer.proto", fileDescriptor_d79237daa74c615f) }
+
+var fileDescriptor_d79237daa74c615f = []byte{
+	// 361 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0x4f, 0x6f, 0x9b, 0x40,
+	0x10, 0xc5, 0xb5, 0xb6, 0xeb, 0x96, 0x35, 0x52, 0xdd, 0xb5, 0x54, 0x53, 0xb7, 0x55, 0xa9, 0x2f,
+	0xe5, 0x04, 0x95, 0x23, 0x45, 0x39, 0x3b, 0x92, 0x95, 0x5c, 0x7c, 0x58, 0x47, 0x8a, 0x94, 0x0b,
+	0x5a, 0x60, 0xf8, 0x23, 0xc3, 0x2e, 0x59, 0x96, 0x43, 0xbe, 0x65, 0x3e, 0x52, 0xc4, 0x1a, 0x62,
+	0x62, 0x27, 0x27, 0x76, 0xde, 0x8c, 0xde, 0xbc, 0x9f, 0x06, 0x3c, 0xaf, 0x38, 0x2b, 0xab, 0x54,
+	0x28, 0x5f, 0x49, 0xc6, 0xab, 0x18, 0xa4, 0x5b, 0x4a, 0xa1, 0x04, 0x31, 0xbb, 0x46, 0x22, 0xcb,
+	0x70, 0x31, 0x0b, 0x45, 0x51, 0x08, 0xee, 0x1d, 0x3e, 0x87, 0x91, 0xe5, 0x33, 0xc2, 0xb3, 0x5d,
+	0x3b, 0xb5, 0xc9, 0x72, 0xa0, 0xf0, 0x58, 0x43, 0xa5, 0xc8, 0x1a, 0x4f, 0xab, 0x2c, 0xe1, 0x4c,
+	0xd5, 0x12, 0xfc, 0x14, 0x58, 0x04, 0xd2, 0x42, 0x36, 0x72, 0x26, 0xab, 0xb9, 0xdb, 0x1a, 0xec,
+	0xba, 0xfe, 0x8d, 0x6e, 0xd3, 0xaf, 0xd5, 0x5b, 0x81, 0xfc, 0xc6, 0x38, 0x4c, 0x19, 0xe7, 0x90,
+	0xfb, 0x59, 0x64, 0x0d, 0x6c, 0xe4, 0x18, 0xd4, 0x68, 0x95, 0xdb, 0x88, 0xfc, 0xc5, 0x66, 0x90,
+	0x8b, 0x70, 0xef, 0xf3, 0xba, 0x08, 0x40, 0x5a, 0x43, 0x1b, 0x39, 0x23, 0x3a, 0xd1, 0xda, 0x56,
+	0x4b, 0xe4, 0x27, 0x36, 0xe2, 0x2c, 0x07, 0x9f, 0xb3, 0x02, 0xac, 0x91, 0x36, 0xf8, 0xd2, 0x08,
+	0x5b, 0x56, 0x00, 0xf9, 0x8e, 0xc7, 0x22, 0x8e, 0x2b, 0x50, 0xd6, 0x27, 0x1b, 0x39, 0x43, 0xda,
+	0x56, 0xcb, 0x1d, 0xfe, 0xd1, 0x44, 0x83, 0xe8, 0x3d, 0x2e, 0x0b, 0x7f, 0x96, 0x87, 0xa7, 0xc6,
+	0x31, 0x69, 0x57, 0x92, 0x5f, 0xd8, 0x78, 0x05, 0xd0, 0x61, 0x4d, 0x7a, 0x14, 0x96, 0x01, 0xfe,
+	0xd6, 0xb7, 0xbb, 0x4e, 0x6b, 0xbe, 0x3f, 0x23, 0x40, 0xe7, 0x04, 0xc7, 0x90, 0x83, 0x7e, 0x48,
+	0x42, 0xf0, 0x28, 0x62, 0x8a, 0x69, 0x68, 0x93, 0xea, 0xf7, 0x6a, 0x8f, 0xa7, 0xdd, 0x8e, 0xbb,
+	0xf6, 0x90, 0xe4, 0x1e, 0x1b, 0x1b, 0x50, 0x61, 0xda, 0x2c, 0x25, 0xff, 0xdc, 0xfe, 0x41, 0xdd,
+	0x0f, 0x29, 0x17, 0x7f, 0x4e, 0x06, 0x4f, 0x93, 0xff, 0x47, 0xeb, 0xab, 0x87, 0xcb, 0x24, 0x53,
+	0x69, 0x1d, 0x34, 0xe7, 0xf4, 0xd2, 0xa7, 0x12, 0x64, 0x0e, 0x51, 0x02, 0xd2, 0x8b, 0x59, 0x20,
+	0xb3, 0xd0, 0x0b, 0x85, 0x04, 0xaf, 0x95, 0xfa, 0x6e, 0xc1, 0x58, 0xff, 0x39, 0x17, 0x2f, 0x03,
+	0x00, 0x0a, 0x94, 0x82, 0x4d, 0x77, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SnapshotTransferClient is the client API for SnapshotTransfer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SnapshotTransferClient interface {
+	// FetchFile streams the content of a snapshot file, starting at the requested offset
+	FetchFile(ctx context.Context, in *SignedSnapshotFileRequest, opts ...grpc.CallOption) (SnapshotTransfer_FetchFileClient, error)
+}
+
+type snapshotTransferClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnapshotTransferClient(cc grpc.ClientConnInterface) SnapshotTransferClient {
+	return &snapshotTransferClient{cc}
+}
+
+func (c *snapshotTransferClient) FetchFile(ctx context.Context, in *SignedSnapshotFileRequest, opts ...grpc.CallOption) (SnapshotTransfer_FetchFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SnapshotTransfer_serviceDesc.Streams[0], "/snapshotgrpc.SnapshotTransfer/FetchFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &snapshotTransferFetchFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SnapshotTransfer_FetchFileClient interface {
+	Recv() (*SnapshotFileChunk, error)
+	grpc.ClientStream
+}
+
+type snapshotTransferFetchFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *snapshotTransferFetchFileClient) Recv() (*SnapshotFileChunk, error) {
+	m := new(SnapshotFileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SnapshotTransferServer is the server API for SnapshotTransfer service.
+type SnapshotTransferServer interface {
+	// FetchFile streams the content of a snapshot file, starting at the requested offset
+	FetchFile(*SignedSnapshotFileRequest, SnapshotTransfer_FetchFileServer) error
+}
+
+// UnimplementedSnapshotTransferServer can be embedded to have forward compatible implementations.
+type UnimplementedSnapshotTransferServer struct {
+}
+
+func (*UnimplementedSnapshotTransferServer) FetchFile(req *SignedSnapshotFileRequest, srv SnapshotTransfer_FetchFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method FetchFile not implemented")
+}
+
+func RegisterSnapshotTransferServer(s *grpc.Server, srv SnapshotTransferServer) {
+	s.RegisterService(&_SnapshotTransfer_serviceDesc, srv)
+}
+
+func _SnapshotTransfer_FetchFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedSnapshotFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SnapshotTransferServer).FetchFile(m, &snapshotTransferFetchFileServer{stream})
+}
+
+type SnapshotTransfer_FetchFileServer interface {
+	Send(*SnapshotFileChunk) error
+	grpc.ServerStream
+}
+
+type snapshotTransferFetchFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *snapshotTransferFetchFileServer) Send(m *SnapshotFileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _SnapshotTransfer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "snapshotgrpc.SnapshotTransfer",
+	HandlerType: (*SnapshotTransferServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FetchFile",
+			Handler:       _SnapshotTransfer_FetchFile_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "snapshot_transfer.proto",
+}
@@ -0,0 +1,189 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("snapshotgrpc")
+
+// DefaultChunkSize is the maximum number of bytes of a snapshot file carried in a single chunk
+const DefaultChunkSize = 1024 * 1024
+
+// TransferService implements SnapshotTransferServer grpc interface. It streams the files of
+// the snapshots that are available under the completed snapshots directory of this peer.
+type TransferService struct {
+	SnapshotsRootDir string
+	ACLProvider      ChannelACLProvider
+	// ChunkSize is the maximum number of bytes sent in a chunk. DefaultChunkSize is used if not set.
+	ChunkSize int
+}
+
+// ChannelACLProvider checks ACL for a channel resource
+type ChannelACLProvider interface {
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// FetchFile streams the requested file of a completed snapshot, starting from the requested offset.
+// Only the snapshot metadata files and the files listed in the signable metadata can be fetched.
+func (s *TransferService) FetchFile(signedRequest *SignedSnapshotFileRequest, stream SnapshotTransfer_FetchFileServer) error {
+	request := &SnapshotFileRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return errors.Wrap(err, "failed to unmarshal snapshot file request")
+	}
+
+	if request.ChannelId == "" {
+		return errors.New("missing channel ID")
+	}
+
+	if err := s.checkACL(request, signedRequest); err != nil {
+		return err
+	}
+
+	blockNumber := request.BlockNumber
+	if blockNumber == 0 {
+		var err error
+		if blockNumber, err = s.mostRecentSnapshot(request.ChannelId); err != nil {
+			return err
+		}
+	}
+
+	snapshotDir := kvledger.SnapshotDirForLedgerBlockNum(s.SnapshotsRootDir, request.ChannelId, blockNumber)
+	if err := checkFileInSnapshot(snapshotDir, request.FileName); err != nil {
+		return err
+	}
+
+	f, err := os.Open(filepath.Join(snapshotDir, request.FileName))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file [%s] of snapshot at block %d", request.FileName, blockNumber)
+	}
+	defer f.Close()
+
+	if request.Offset < 0 {
+		return errors.Errorf("invalid offset %d", request.Offset)
+	}
+	if _, err := f.Seek(request.Offset, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "failed to seek to offset %d in file [%s]", request.Offset, request.FileName)
+	}
+
+	logger.Debugw("Streaming snapshot file", "channel", request.ChannelId, "blockNumber", blockNumber, "file", request.FileName, "offset", request.Offset)
+
+	chunkSize := s.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	buf := make([]byte, chunkSize)
+	offset := request.Offset
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			chunk := &SnapshotFileChunk{
+				BlockNumber: blockNumber,
+				Offset:      offset,
+				Data:        buf[:n],
+			}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read file [%s]", request.FileName)
+		}
+	}
+}
+
+func (s *TransferService) checkACL(request *SnapshotFileRequest, signedRequest *SignedSnapshotFileRequest) error {
+	signatureHdr := request.SignatureHeader
+	if signatureHdr == nil {
+		return errors.New("missing signature header")
+	}
+
+	expirationTime := crypto.ExpiresAt(signatureHdr.Creator)
+	if !expirationTime.IsZero() && time.Now().After(expirationTime) {
+		return errors.New("client identity expired")
+	}
+
+	return s.ACLProvider.CheckACL(
+		resources.Snapshot_fetch,
+		request.ChannelId,
+		[]*protoutil.SignedData{{
+			Identity:  signatureHdr.Creator,
+			Data:      signedRequest.Request,
+			Signature: signedRequest.Signature,
+		}},
+	)
+}
+
+// mostRecentSnapshot returns the highest block number amongst the completed snapshots of a channel
+func (s *TransferService) mostRecentSnapshot(channelID string) (uint64, error) {
+	entries, err := ioutil.ReadDir(kvledger.SnapshotsDirForLedger(s.SnapshotsRootDir, channelID))
+	if err != nil && !os.IsNotExist(err) {
+		return 0, errors.Wrapf(err, "failed to list snapshots for channel %s", channelID)
+	}
+
+	var found bool
+	var mostRecent uint64
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		blockNumber, err := strconv.ParseUint(e.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		if !found || blockNumber > mostRecent {
+			found, mostRecent = true, blockNumber
+		}
+	}
+	if !found {
+		return 0, errors.Errorf("no completed snapshot found for channel %s", channelID)
+	}
+	return mostRecent, nil
+}
+
+// checkFileInSnapshot ensures that the requested file is a part of the snapshot, so that arbitrary
+// files on the peer's filesystem cannot be read via this service
+func checkFileInSnapshot(snapshotDir, fileName string) error {
+	switch fileName {
+	case "":
+		return errors.New("missing file name")
+	case kvledger.SnapshotSignableMetadataFileName, kvledger.SnapshotAdditionalMetadataFileName:
+		return nil
+	}
+
+	metadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName))
+	if err != nil {
+		return errors.Wrap(err, "failed to read snapshot signable metadata")
+	}
+	metadata := &kvledger.SnapshotSignableMetadata{}
+	if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+		return errors.Wrap(err, "failed to unmarshal snapshot signable metadata")
+	}
+	if _, ok := metadata.FilesAndHashes[fileName]; !ok {
+		return errors.Errorf("file [%s] is not a part of the snapshot", fileName)
+	}
+	return nil
+}
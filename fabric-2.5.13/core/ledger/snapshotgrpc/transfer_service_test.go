@@ -0,0 +1,272 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+//go:generate counterfeiter -o mock/channel_acl_provider.go -fake-name ChannelACLProvider . channelACLProvider
+
+type channelACLProvider interface {
+	ChannelACLProvider
+}
+
+type testSigner struct{}
+
+func (testSigner) Sign(msg []byte) ([]byte, error) {
+	return []byte("signature"), nil
+}
+
+func (testSigner) Serialize() ([]byte, error) {
+	return []byte("creator"), nil
+}
+
+func TestSnapshotTransfer(t *testing.T) {
+	rootDir := t.TempDir()
+	channelID := "testsnapshottransfer"
+	createTestSnapshot(t, rootDir, channelID, 5)
+	snapshotDir := createTestSnapshot(t, rootDir, channelID, 10)
+
+	fakeACLProvider := &mock.ChannelACLProvider{}
+	client := startTransferService(t, &TransferService{
+		SnapshotsRootDir: rootDir,
+		ACLProvider:      fakeACLProvider,
+		ChunkSize:        100,
+	})
+	downloader := &Downloader{Client: client, Signer: testSigner{}}
+
+	t.Run("download most recent snapshot", func(t *testing.T) {
+		destDir := filepath.Join(t.TempDir(), "download")
+		metadata, err := downloader.Download(context.Background(), channelID, 0, destDir)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), metadata.LastBlockNumber)
+		requireSameDirContent(t, snapshotDir, destDir)
+
+		resName, cid, idinfo := fakeACLProvider.CheckACLArgsForCall(0)
+		require.Equal(t, resources.Snapshot_fetch, resName)
+		require.Equal(t, channelID, cid)
+		signedData := idinfo.([]*protoutil.SignedData)
+		require.Len(t, signedData, 1)
+		require.Equal(t, []byte("creator"), signedData[0].Identity)
+		require.Equal(t, []byte("signature"), signedData[0].Signature)
+	})
+
+	t.Run("download specific snapshot", func(t *testing.T) {
+		destDir := t.TempDir()
+		metadata, err := downloader.Download(context.Background(), channelID, 5, destDir)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), metadata.LastBlockNumber)
+		requireSameDirContent(t, kvledger.SnapshotDirForLedgerBlockNum(rootDir, channelID, 5), destDir)
+	})
+
+	t.Run("resume interrupted download", func(t *testing.T) {
+		destDir := t.TempDir()
+		partial, err := ioutil.ReadFile(filepath.Join(snapshotDir, "public_state.data"))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(destDir, "public_state.data"), partial[:250], 0o644))
+		// a partial file that does not match the remote content is downloaded again from the beginning
+		require.NoError(t, ioutil.WriteFile(filepath.Join(destDir, "txids.data"), []byte("corrupted"), 0o644))
+
+		_, err = downloader.Download(context.Background(), channelID, 10, destDir)
+		require.NoError(t, err)
+		requireSameDirContent(t, snapshotDir, destDir)
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		corruptedRootDir := t.TempDir()
+		corruptedDir := createTestSnapshot(t, corruptedRootDir, channelID, 10)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(corruptedDir, "txids.data"), []byte("tampered"), 0o644))
+		client := startTransferService(t, &TransferService{
+			SnapshotsRootDir: corruptedRootDir,
+			ACLProvider:      &mock.ChannelACLProvider{},
+		})
+		downloader := &Downloader{Client: client, Signer: testSigner{}}
+
+		destDir := t.TempDir()
+		_, err := downloader.Download(context.Background(), channelID, 0, destDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "hash mismatch for file [txids.data]")
+		_, err = os.Stat(filepath.Join(destDir, kvledger.SnapshotSignableMetadataFileName))
+		require.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("channel mismatch", func(t *testing.T) {
+		otherRootDir := t.TempDir()
+		createTestSnapshot(t, otherRootDir, "otherchannel", 10)
+		require.NoError(t, os.Rename(
+			kvledger.SnapshotsDirForLedger(otherRootDir, "otherchannel"),
+			kvledger.SnapshotsDirForLedger(otherRootDir, channelID),
+		))
+		client := startTransferService(t, &TransferService{
+			SnapshotsRootDir: otherRootDir,
+			ACLProvider:      &mock.ChannelACLProvider{},
+		})
+		downloader := &Downloader{Client: client, Signer: testSigner{}}
+		_, err := downloader.Download(context.Background(), channelID, 0, t.TempDir())
+		require.EqualError(t, err, "snapshot is for channel [otherchannel], expected [testsnapshottransfer]")
+	})
+
+	tests := []struct {
+		name          string
+		signedRequest *SignedSnapshotFileRequest
+		aclErr        error
+		errMsg        string
+	}{
+		{
+			name:          "unmarshal error",
+			signedRequest: &SignedSnapshotFileRequest{Request: []byte("dummy")},
+			errMsg:        "failed to unmarshal snapshot file request",
+		},
+		{
+			name:          "missing channel ID",
+			signedRequest: createSignedFileRequest("", 10, kvledger.SnapshotSignableMetadataFileName, 0),
+			errMsg:        "missing channel ID",
+		},
+		{
+			name:          "missing signature header",
+			signedRequest: &SignedSnapshotFileRequest{Request: protoutil.MarshalOrPanic(&SnapshotFileRequest{ChannelId: channelID})},
+			errMsg:        "missing signature header",
+		},
+		{
+			name:          "acl error",
+			signedRequest: createSignedFileRequest(channelID, 10, kvledger.SnapshotSignableMetadataFileName, 0),
+			aclErr:        fmt.Errorf("fake-check-acl-error"),
+			errMsg:        "fake-check-acl-error",
+		},
+		{
+			name:          "no snapshot",
+			signedRequest: createSignedFileRequest("unknownchannel", 0, kvledger.SnapshotSignableMetadataFileName, 0),
+			errMsg:        "no completed snapshot found for channel unknownchannel",
+		},
+		{
+			name:          "missing file name",
+			signedRequest: createSignedFileRequest(channelID, 10, "", 0),
+			errMsg:        "missing file name",
+		},
+		{
+			name:          "file not in snapshot",
+			signedRequest: createSignedFileRequest(channelID, 10, "../5/txids.data", 0),
+			errMsg:        "file [../5/txids.data] is not a part of the snapshot",
+		},
+		{
+			name:          "unknown snapshot",
+			signedRequest: createSignedFileRequest(channelID, 7, "txids.data", 0),
+			errMsg:        "failed to read snapshot signable metadata",
+		},
+		{
+			name:          "invalid offset",
+			signedRequest: createSignedFileRequest(channelID, 10, "txids.data", -1),
+			errMsg:        "invalid offset -1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeACLProvider.CheckACLReturns(test.aclErr)
+			stream, err := client.FetchFile(context.Background(), test.signedRequest)
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), test.errMsg)
+		})
+	}
+}
+
+func createTestSnapshot(t *testing.T, rootDir, channelID string, blockNumber uint64) string {
+	snapshotDir := kvledger.SnapshotDirForLedgerBlockNum(rootDir, channelID, blockNumber)
+	require.NoError(t, os.MkdirAll(snapshotDir, 0o755))
+
+	filesAndHashes := map[string]string{}
+	for file, size := range map[string]int{"txids.data": 120, "public_state.data": 1024, "empty.data": 0} {
+		content := make([]byte, size)
+		_, err := rand.Read(content)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, file), content, 0o644))
+		h := sha256.Sum256(content)
+		filesAndHashes[file] = hex.EncodeToString(h[:])
+	}
+
+	signableMetadata, err := (&kvledger.SnapshotSignableMetadata{
+		ChannelName:     channelID,
+		LastBlockNumber: blockNumber,
+		FilesAndHashes:  filesAndHashes,
+	}).ToJSON()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName), signableMetadata, 0o644))
+
+	h := sha256.Sum256(signableMetadata)
+	additionalMetadata, err := json.Marshal(map[string]string{"snapshot_hash": hex.EncodeToString(h[:])})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, kvledger.SnapshotAdditionalMetadataFileName), additionalMetadata, 0o644))
+	return snapshotDir
+}
+
+func startTransferService(t *testing.T, svc *TransferService) SnapshotTransferClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	RegisterSnapshotTransferServer(server, svc)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewSnapshotTransferClient(conn)
+}
+
+func requireSameDirContent(t *testing.T, expectedDir, actualDir string) {
+	expectedFiles, err := ioutil.ReadDir(expectedDir)
+	require.NoError(t, err)
+	actualFiles, err := ioutil.ReadDir(actualDir)
+	require.NoError(t, err)
+	require.Len(t, actualFiles, len(expectedFiles))
+
+	for _, f := range expectedFiles {
+		expected, err := ioutil.ReadFile(filepath.Join(expectedDir, f.Name()))
+		require.NoError(t, err)
+		actual, err := ioutil.ReadFile(filepath.Join(actualDir, f.Name()))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, "content mismatch for file %s", f.Name())
+	}
+}
+
+func createSignedFileRequest(channelID string, blockNumber uint64, fileName string, offset int64) *SignedSnapshotFileRequest {
+	request := &SnapshotFileRequest{
+		SignatureHeader: &common.SignatureHeader{
+			Creator: []byte("creator"),
+			Nonce:   []byte("nonce-ignored"),
+		},
+		ChannelId:   channelID,
+		BlockNumber: blockNumber,
+		FileName:    fileName,
+		Offset:      offset,
+	}
+	return &SignedSnapshotFileRequest{
+		Request:   protoutil.MarshalOrPanic(request),
+		Signature: []byte("dummy-signatures"),
+	}
+}
@@ -0,0 +1,305 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: invalidation_info.proto
+
+package txinvalidation
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	kvrwset "github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// TxInvalidationInfo captures the details of the reason for which a committing peer marked a transaction as invalid
+type TxInvalidationInfo struct {
+	TxId           string                `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	BlockNumber    uint64                `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TxNumber       uint64                `protobuf:"varint,3,opt,name=tx_number,json=txNumber,proto3" json:"tx_number,omitempty"`
+	ValidationCode peer.TxValidationCode `protobuf:"varint,4,opt,name=validation_code,json=validationCode,proto3,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
+	// message is a human readable description of the reason, e.g., the endorsement policy evaluation failure
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// read_conflict is set when the transaction failed the mvcc check of a key that it read
+	ReadConflict *ReadConflict `protobuf:"bytes,6,opt,name=read_conflict,json=readConflict,proto3" json:"read_conflict,omitempty"`
+	// range_query_conflict is set when the transaction failed the phantom read check of a range query
+	RangeQueryConflict   *RangeQueryConflict `protobuf:"bytes,7,opt,name=range_query_conflict,json=rangeQueryConflict,proto3" json:"range_query_conflict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *TxInvalidationInfo) Reset()         { *m = TxInvalidationInfo{} }
+func (m *TxInvalidationInfo) String() string { return proto.CompactTextString(m) }
+func (*TxInvalidationInfo) ProtoMessage()    {}
+func (*TxInvalidationInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6bb4d737ee168aa6, []int{0}
+}
+
+func (m *TxInvalidationInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxInvalidationInfo.Unmarshal(m, b)
+}
+func (m *TxInvalidationInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxInvalidationInfo.Marshal(b, m, deterministic)
+}
+func (m *TxInvalidationInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxInvalidationInfo.Merge(m, src)
+}
+func (m *TxInvalidationInfo) XXX_Size() int {
+	return xxx_messageInfo_TxInvalidationInfo.Size(m)
+}
+func (m *TxInvalidationInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxInvalidationInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxInvalidationInfo proto.InternalMessageInfo
+
+func (m *TxInvalidationInfo) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TxInvalidationInfo) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *TxInvalidationInfo) GetTxNumber() uint64 {
+	if m != nil {
+		return m.TxNumber
+	}
+	return 0
+}
+
+func (m *TxInvalidationInfo) GetValidationCode() peer.TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return peer.TxValidationCode_VALID
+}
+
+func (m *TxInvalidationInfo) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *TxInvalidationInfo) GetReadConflict() *ReadConflict {
+	if m != nil {
+		return m.ReadConflict
+	}
+	return nil
+}
+
+func (m *TxInvalidationInfo) GetRangeQueryConflict() *RangeQueryConflict {
+	if m != nil {
+		return m.RangeQueryConflict
+	}
+	return nil
+}
+
+// ReadConflict identifies a key whose version read during simulation does not match the version at the time of commit
+type ReadConflict struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// collection and key_hash are set in place of key for a read of private data
+	Collection  string           `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	Key         string           `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	KeyHash     []byte           `protobuf:"bytes,4,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	ReadVersion *kvrwset.Version `protobuf:"bytes,5,opt,name=read_version,json=readVersion,proto3" json:"read_version,omitempty"`
+	// committed_version is not set if the key does not exist in the state or has been updated
+	// by a preceding transaction in the same block
+	CommittedVersion     *kvrwset.Version `protobuf:"bytes,6,opt,name=committed_version,json=committedVersion,proto3" json:"committed_version,omitempty"`
+	UpdatedInBlock       bool             `protobuf:"varint,7,opt,name=updated_in_block,json=updatedInBlock,proto3" json:"updated_in_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ReadConflict) Reset()         { *m = ReadConflict{} }
+func (m *ReadConflict) String() string { return proto.CompactTextString(m) }
+func (*ReadConflict) ProtoMessage()    {}
+func (*ReadConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6bb4d737ee168aa6, []int{1}
+}
+
+func (m *ReadConflict) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadConflict.Unmarshal(m, b)
+}
+func (m *ReadConflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadConflict.Marshal(b, m, deterministic)
+}
+func (m *ReadConflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadConflict.Merge(m, src)
+}
+func (m *ReadConflict) XXX_Size() int {
+	return xxx_messageInfo_ReadConflict.Size(m)
+}
+func (m *ReadConflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadConflict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadConflict proto.InternalMessageInfo
+
+func (m *ReadConflict) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ReadConflict) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *ReadConflict) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ReadConflict) GetKeyHash() []byte {
+	if m != nil {
+		return m.KeyHash
+	}
+	return nil
+}
+
+func (m *ReadConflict) GetReadVersion() *kvrwset.Version {
+	if m != nil {
+		return m.ReadVersion
+	}
+	return nil
+}
+
+func (m *ReadConflict) GetCommittedVersion() *kvrwset.Version {
+	if m != nil {
+		return m.CommittedVersion
+	}
+	return nil
+}
+
+func (m *ReadConflict) GetUpdatedInBlock() bool {
+	if m != nil {
+		return m.UpdatedInBlock
+	}
+	return false
+}
+
+// RangeQueryConflict identifies a range query whose results changed between simulation and commit
+type RangeQueryConflict struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	StartKey             string   `protobuf:"bytes,2,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey               string   `protobuf:"bytes,3,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RangeQueryConflict) Reset()         { *m = RangeQueryConflict{} }
+func (m *RangeQueryConflict) String() string { return proto.CompactTextString(m) }
+func (*RangeQueryConflict) ProtoMessage()    {}
+func (*RangeQueryConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6bb4d737ee168aa6, []int{2}
+}
+
+func (m *RangeQueryConflict) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RangeQueryConflict.Unmarshal(m, b)
+}
+func (m *RangeQueryConflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RangeQueryConflict.Marshal(b, m, deterministic)
+}
+func (m *RangeQueryConflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeQueryConflict.Merge(m, src)
+}
+func (m *RangeQueryConflict) XXX_Size() int {
+	return xxx_messageInfo_RangeQueryConflict.Size(m)
+}
+func (m *RangeQueryConflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeQueryConflict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RangeQueryConflict proto.InternalMessageInfo
+
+func (m *RangeQueryConflict) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *RangeQueryConflict) GetStartKey() string {
+	if m != nil {
+		return m.StartKey
+	}
+	return ""
+}
+
+func (m *RangeQueryConflict) GetEndKey() string {
+	if m != nil {
+		return m.EndKey
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*TxInvalidationInfo)(nil), "txinvalidation.TxInvalidationInfo")
+	proto.RegisterType((*ReadConflict)(nil), "txinvalidation.ReadConflict")
+	proto.RegisterType((*RangeQueryConflict)(nil), "txinvalidation.RangeQueryConflict")
+}
+
+func init() { proto.RegisterFile("invalidation_info.proto", fileDescriptor_6bb4d737ee168aa6) }
+
+var fileDescriptor_6bb4d737ee168aa6 = []byte{
+	// 499 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xcf, 0x8e, 0xda, 0x30,
+	0x10, 0xc6, 0x05, 0xcb, 0xbf, 0x0c, 0x94, 0x52, 0xb7, 0xea, 0xa6, 0xbb, 0xab, 0x8a, 0xd2, 0x0b,
+	0xa7, 0x44, 0xda, 0xbd, 0x54, 0x95, 0x7a, 0xd8, 0xdd, 0x4b, 0xd1, 0x4a, 0x95, 0x1a, 0xa1, 0x3d,
+	0xf4, 0x62, 0x19, 0x7b, 0x20, 0x11, 0x89, 0x4d, 0x1d, 0x43, 0x93, 0x27, 0xe9, 0xe3, 0xf5, 0x55,
+	0xaa, 0x38, 0x01, 0x82, 0x50, 0xd5, 0x13, 0x9e, 0x6f, 0xbe, 0xf9, 0xb0, 0x7f, 0x76, 0xe0, 0x32,
+	0x92, 0x3b, 0x16, 0x47, 0x82, 0x99, 0x48, 0x49, 0x1a, 0xc9, 0xa5, 0xf2, 0x36, 0x5a, 0x19, 0x45,
+	0x86, 0x26, 0xab, 0xb7, 0xae, 0xde, 0x6e, 0x10, 0xb5, 0x6f, 0x34, 0x93, 0x29, 0xe3, 0x85, 0x52,
+	0xfa, 0xae, 0x3e, 0xc6, 0x28, 0x56, 0xa8, 0x7d, 0xfd, 0x2b, 0x45, 0xe3, 0xaf, 0x77, 0xfb, 0x5f,
+	0x6a, 0x17, 0xa5, 0x69, 0xf2, 0xa7, 0x09, 0x64, 0x9e, 0xcd, 0x6a, 0x79, 0x33, 0xb9, 0x54, 0xe4,
+	0x35, 0xb4, 0x4d, 0x46, 0x23, 0xe1, 0x36, 0xc6, 0x8d, 0xa9, 0x13, 0xb4, 0x4c, 0x36, 0x13, 0xe4,
+	0x03, 0x0c, 0x16, 0xb1, 0xe2, 0x6b, 0x2a, 0xb7, 0xc9, 0x02, 0xb5, 0xdb, 0x1c, 0x37, 0xa6, 0xad,
+	0xa0, 0x6f, 0xb5, 0x6f, 0x56, 0x22, 0xd7, 0xe0, 0x98, 0x6c, 0xdf, 0xbf, 0xb0, 0xfd, 0x9e, 0xc9,
+	0xaa, 0xe6, 0x3d, 0xbc, 0xac, 0x9d, 0x88, 0x2b, 0x81, 0x6e, 0x6b, 0xdc, 0x98, 0x0e, 0x6f, 0xdd,
+	0x72, 0x33, 0xa9, 0x37, 0xcf, 0x9e, 0x0f, 0x86, 0x47, 0x25, 0x30, 0x18, 0xee, 0x4e, 0x6a, 0xe2,
+	0x42, 0x37, 0xc1, 0x34, 0x65, 0x2b, 0x74, 0xdb, 0x76, 0x67, 0xfb, 0x92, 0xdc, 0xc3, 0x0b, 0x8d,
+	0x4c, 0x50, 0xae, 0xe4, 0x32, 0x8e, 0xb8, 0x71, 0x3b, 0xe3, 0xc6, 0xb4, 0x7f, 0x7b, 0xe3, 0x9d,
+	0xd2, 0xf2, 0x02, 0x64, 0xe2, 0xb1, 0xf2, 0x04, 0x03, 0x5d, 0xab, 0xc8, 0x1c, 0xde, 0x68, 0x26,
+	0x57, 0x48, 0x7f, 0x6e, 0x51, 0xe7, 0xc7, 0xa4, 0xae, 0x4d, 0x9a, 0x9c, 0x25, 0x15, 0xde, 0xef,
+	0x85, 0xf5, 0x90, 0x47, 0xf4, 0x99, 0x36, 0xf9, 0xdd, 0x84, 0x41, 0xfd, 0x4f, 0xc9, 0x0d, 0x38,
+	0x92, 0x25, 0x98, 0x6e, 0x18, 0xc7, 0x8a, 0xef, 0x51, 0x20, 0xef, 0x01, 0xb8, 0x8a, 0x63, 0xb4,
+	0x37, 0x69, 0x11, 0x3b, 0x41, 0x4d, 0x21, 0x23, 0xb8, 0x58, 0x63, 0x6e, 0xd9, 0x3a, 0x41, 0xb1,
+	0x24, 0xef, 0xa0, 0xb7, 0xc6, 0x9c, 0x86, 0x2c, 0x0d, 0x2d, 0xcf, 0x41, 0xd0, 0x5d, 0x63, 0xfe,
+	0x95, 0xa5, 0x21, 0xb9, 0x03, 0x7b, 0x42, 0xba, 0x43, 0x9d, 0x16, 0x71, 0x6d, 0x7b, 0x92, 0x91,
+	0x57, 0x3d, 0x06, 0xef, 0xb9, 0xd4, 0x83, 0x7e, 0xe1, 0xaa, 0x0a, 0xf2, 0x05, 0x5e, 0x71, 0x95,
+	0x24, 0x91, 0x31, 0x78, 0x9c, 0xec, 0xfc, 0x63, 0x72, 0x74, 0xb0, 0xee, 0xc7, 0xa7, 0x30, 0xda,
+	0x6e, 0x04, 0x2b, 0x86, 0x23, 0x49, 0xed, 0xe3, 0xb0, 0x04, 0x7b, 0xc1, 0xb0, 0xd2, 0x67, 0xf2,
+	0xa1, 0x50, 0x27, 0x21, 0x90, 0x73, 0x86, 0xff, 0xc1, 0x73, 0x0d, 0x4e, 0x6a, 0x98, 0x36, 0xb4,
+	0x80, 0x50, 0xd2, 0xe9, 0x59, 0xe1, 0x09, 0x73, 0x72, 0x09, 0x5d, 0x94, 0x82, 0x1e, 0xf9, 0x74,
+	0x50, 0x8a, 0x27, 0xcc, 0x1f, 0x3e, 0xff, 0xf8, 0xb4, 0x8a, 0x4c, 0xb8, 0x5d, 0x78, 0x5c, 0x25,
+	0x7e, 0x98, 0x6f, 0x50, 0x57, 0x1f, 0xc7, 0x92, 0x2d, 0x74, 0xc4, 0x7d, 0xae, 0x34, 0xfa, 0x95,
+	0x74, 0x7a, 0xcd, 0x8b, 0x8e, 0x7d, 0x9b, 0x77, 0x7f, 0x07, 0x00, 0x0f, 0x5f, 0x35, 0x08, 0x90,
+	0x03, 0x00, 0x00,
+}
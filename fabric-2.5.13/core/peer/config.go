@@ -203,6 +203,20 @@ type Config struct {
 	// StatsdPrefix provides the prefix that prepended to all emitted statsd metrics.
 	StatsdPrefix string
 
+	// ----- Tracing config -----
+
+	// TracingProvider provides the tracing provider, which is one of otlp or
+	// disabled.
+	TracingProvider string
+	// TracingOTLPEndpoint provides the address of the OpenTelemetry collector
+	// to which spans are exported.
+	TracingOTLPEndpoint string
+	// TracingOTLPInsecure connects to the collector without TLS.
+	TracingOTLPInsecure bool
+	// TracingSampleRatio is the fraction of the traces started by the peer
+	// that are sampled.
+	TracingSampleRatio float64
+
 	// ----- Docker config ------
 
 	// DockerCert is the path to the PEM encoded TLS client certificate required to access
@@ -327,6 +341,11 @@ func (c *Config) load() error {
 	c.StatsdWriteInterval = viper.GetDuration("metrics.statsd.writeInterval")
 	c.StatsdPrefix = viper.GetString("metrics.statsd.prefix")
 
+	c.TracingProvider = viper.GetString("tracing.provider")
+	c.TracingOTLPEndpoint = viper.GetString("tracing.otlp.endpoint")
+	c.TracingOTLPInsecure = viper.GetBool("tracing.otlp.insecure")
+	c.TracingSampleRatio = viper.GetFloat64("tracing.sampleRatio")
+
 	c.DockerCert = config.GetPath("vm.docker.tls.cert.file")
 	c.DockerKey = config.GetPath("vm.docker.tls.key.file")
 	c.DockerCA = config.GetPath("vm.docker.tls.ca.file")
@@ -310,6 +310,11 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("metrics.statsd.writeInterval", "10s")
 	viper.Set("metrics.statsd.prefix", "testPrefix")
 
+	viper.Set("tracing.provider", "otlp")
+	viper.Set("tracing.otlp.endpoint", "localhost:4317")
+	viper.Set("tracing.otlp.insecure", true)
+	viper.Set("tracing.sampleRatio", 0.5)
+
 	viper.Set("chaincode.pull", false)
 	viper.Set("chaincode.externalBuilders", &[]ExternalBuilder{
 		{
@@ -380,6 +385,11 @@ func TestGlobalConfig(t *testing.T) {
 		StatsdWriteInterval: 10 * time.Second,
 		StatsdPrefix:        "testPrefix",
 
+		TracingProvider:     "otlp",
+		TracingOTLPEndpoint: "localhost:4317",
+		TracingOTLPInsecure: true,
+		TracingSampleRatio:  0.5,
+
 		DockerCert: filepath.Join(cwd, "test/vm/tls/cert/file"),
 		DockerKey:  filepath.Join(cwd, "test/vm/tls/key/file"),
 		DockerCA:   filepath.Join(cwd, "test/vm/tls/ca/file"),
@@ -389,6 +399,20 @@ func TestGlobalConfig(t *testing.T) {
 			EndorsementTimeout: 10 * time.Second,
 			BroadcastTimeout:   10 * time.Second,
 			DialTimeout:        60 * time.Second,
+			EndorserSelection: config.EndorserSelectionOptions{
+				Strategy:         config.HeightSelection,
+				FailureThreshold: 5,
+				OpenDuration:     30 * time.Second,
+			},
+			PreparedTransactions: config.PreparedTransactionsOptions{
+				MaxTimeToLive:              24 * time.Hour,
+				MaxTransactions:            10000,
+				MaxTransactionsPerIdentity: 100,
+			},
+			HTTP: config.HTTPOptions{
+				ListenAddress:      "0.0.0.0:7080",
+				MaxRequestBodySize: 100 * 1024 * 1024,
+			},
 		},
 	}
 
@@ -183,7 +183,27 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 		return nil, errors.New("wrong chain type")
 	}
 
-	pvtData, err := channel.Ledger().GetPvtDataByNum(block.Header.Number, nil)
+	return EligiblePrivateData(block, channelID, channel.Ledger(), bprs.CollectionPolicyChecker, bprs.IdentityDeserializerManager, signedData)
+}
+
+// PrivateDataRetriever retrieves the private data of committed blocks, and the collection configurations they
+// were committed with.
+type PrivateDataRetriever interface {
+	GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error)
+	GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error)
+}
+
+// EligiblePrivateData returns the private data of the block for the collections that the signer of the signed data
+// is eligible to read, as determined by the collection policies.
+func EligiblePrivateData(
+	block *common.Block,
+	channelID string,
+	retriever PrivateDataRetriever,
+	collectionPolicyChecker CollectionPolicyChecker,
+	identityDeserializerMgr IdentityDeserializerManager,
+	signedData *protoutil.SignedData,
+) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	pvtData, err := retriever.GetPvtDataByNum(block.Header.Number, nil)
 	if err != nil {
 		logger.Errorf("Error getting private data by block number %d on channel %s", block.Header.Number, channelID)
 		return nil, errors.Wrapf(err, "error getting private data by block number %d", block.Header.Number)
@@ -191,12 +211,12 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 
 	seqs2Namespaces := aggregatedCollections(make(map[seqAndDataModel]map[string][]*rwset.CollectionPvtReadWriteSet))
 
-	configHistoryRetriever, err := channel.Ledger().GetConfigHistoryRetriever()
+	configHistoryRetriever, err := retriever.GetConfigHistoryRetriever()
 	if err != nil {
 		return nil, err
 	}
 
-	identityDeserializer, err := bprs.IdentityDeserializerManager.Deserializer(channelID)
+	identityDeserializer, err := identityDeserializerMgr.Deserializer(channelID)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +231,7 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 			for _, col := range ns.CollectionPvtRwset {
 				logger.Debugf("Checking policy for namespace %s, collection %s", ns.Namespace, col.CollectionName)
 
-				eligible, err := bprs.CollectionPolicyChecker.CheckCollectionPolicy(block.Header.Number,
+				eligible, err := collectionPolicyChecker.CheckCollectionPolicy(block.Header.Number,
 					ns.Namespace, col.CollectionName, configHistoryRetriever, identityDeserializer, signedData)
 				if err != nil {
 					return nil, err
@@ -289,6 +309,11 @@ func (s *DeliverServer) DeliverWithPrivateData(srv peer.Deliver_DeliverWithPriva
 	return err
 }
 
+// FilteredBlock returns the filtered form of a block, as delivered to DeliverFiltered clients.
+func FilteredBlock(block *common.Block) (*peer.FilteredBlock, error) {
+	return (*blockEvent)(block).toFilteredBlock()
+}
+
 func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
 		Number: block.Header.Number,
@@ -452,6 +477,12 @@ func (ac aggregatedCollections) asPrivateDataMap() map[uint64]*rwset.TxPvtReadWr
 // by routing the call to the msp/mgmt package
 type identityDeserializerMgr struct{}
 
+// NewIdentityDeserializerManager returns the IdentityDeserializerManager
+// of the channels the peer has joined.
+func NewIdentityDeserializerManager() IdentityDeserializerManager {
+	return &identityDeserializerMgr{}
+}
+
 func (*identityDeserializerMgr) Deserializer(channelID string) (msp.IdentityDeserializer, error) {
 	id, ok := mgmt.GetDeserializers()[channelID]
 	if !ok {
@@ -463,6 +494,11 @@ func (*identityDeserializerMgr) Deserializer(channelID string) (msp.IdentityDese
 // collPolicyChecker is the default implementation for CollectionPolicyChecker interface
 type collPolicyChecker struct{}
 
+// NewCollectionPolicyChecker returns the default implementation of CollectionPolicyChecker.
+func NewCollectionPolicyChecker() CollectionPolicyChecker {
+	return &collPolicyChecker{}
+}
+
 // CheckCollectionPolicy checks if the CollectionCriteria meets the policy requirement
 func (cs *collPolicyChecker) CheckCollectionPolicy(
 	blockNum uint64,
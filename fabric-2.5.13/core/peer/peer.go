@@ -369,13 +369,16 @@ func (p *Peer) createChannel(
 		return p.Channel(channelID).MSPManager()
 	}
 	simpleCollectionStore := privdata.NewSimpleCollectionStore(l, deployedCCInfoProvider, idDeserializerFactory)
-	p.GossipService.InitializeChannel(bundle.ConfigtxValidator().ChannelID(), ordererSource, store, gossipservice.Support{
+	err = p.GossipService.InitializeChannel(bundle.ConfigtxValidator().ChannelID(), ordererSource, store, gossipservice.Support{
 		Validator:            validator,
 		Committer:            committer,
 		CollectionStore:      simpleCollectionStore,
 		IdDeserializeFactory: idDeserializerFactory,
 		CapabilityProvider:   channel,
 	})
+	if err != nil {
+		return errors.WithMessagef(err, "[channel %s] failed initializing gossip", bundle.ConfigtxValidator().ChannelID())
+	}
 
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -98,6 +98,7 @@ func NewTestPeer(t *testing.T) (*Peer, func()) {
 	require.NoError(t, err)
 	transientStoreProvider, err := transientstore.NewStoreProvider(
 		filepath.Join(tempdir, "transientstore"),
+		nil,
 	)
 	require.NoError(t, err)
 	peerInstance := &Peer{
@@ -13,6 +13,7 @@ package cscc
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
@@ -40,6 +41,7 @@ func New(
 	nr plugindispatcher.CollectionAndLifecycleResources,
 	p *peer.Peer,
 	bccsp bccsp.BCCSP,
+	snapshotFetcher SnapshotFetcher,
 ) *PeerConfiger {
 	return &PeerConfiger{
 		aclProvider:            aclProvider,
@@ -48,9 +50,20 @@ func New(
 		newLifecycle:           nr,
 		peer:                   p,
 		bccsp:                  bccsp,
+		snapshotFetcher:        snapshotFetcher,
 	}
 }
 
+// SnapshotFetcher downloads the snapshots by which the peer joins channels from other peers.
+type SnapshotFetcher interface {
+	// Fetch starts downloading the snapshot of the channel at the given block number, or the most
+	// recent one if zero, from the peer at the given address, and invokes join with the snapshot dir
+	// once the snapshot is downloaded and verified.
+	Fetch(channelID, address string, tlsRootCert []byte, blockNumber uint64, join func(snapshotDir string) error) error
+	// InProgressDir returns the dir into which a snapshot is being downloaded, if any.
+	InProgressDir() string
+}
+
 func (e *PeerConfiger) Name() string              { return "cscc" }
 func (e *PeerConfiger) Chaincode() shim.Chaincode { return e }
 
@@ -64,6 +77,7 @@ type PeerConfiger struct {
 	newLifecycle           plugindispatcher.CollectionAndLifecycleResources
 	peer                   *peer.Peer
 	bccsp                  bccsp.BCCSP
+	snapshotFetcher        SnapshotFetcher
 }
 
 var cnflogger = flogging.MustGetLogger("cscc")
@@ -76,6 +90,8 @@ const (
 	GetConfigBlock       string = "GetConfigBlock"
 	GetChannelConfig     string = "GetChannelConfig"
 	GetChannels          string = "GetChannels"
+
+	JoinChainBySnapshotFromPeer string = "JoinChainBySnapshotFromPeer"
 )
 
 // Init is mostly useless from an SCC perspective
@@ -180,6 +196,25 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 		snapshotDir := string(args[1])
 		return e.JoinChainBySnapshot(snapshotDir, e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
+	case JoinChainBySnapshotFromPeer:
+		// args[1] is the channel ID, args[2] the address of the peer serving the snapshot, args[3] the
+		// block number of the snapshot and the optional args[4] the TLS root cert of the peer
+		if len(args) < 4 {
+			return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		}
+		// check policy
+		if err = e.aclProvider.CheckACL(resources.Cscc_JoinChainBySnapshot, "", sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s]: [%s]", fname, err))
+		}
+		blockNumber, err := strconv.ParseUint(string(args[3]), 10, 64)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("invalid snapshot block number [%s]", args[3]))
+		}
+		var tlsRootCert []byte
+		if len(args) > 4 {
+			tlsRootCert = args[4]
+		}
+		return e.joinChainBySnapshotFromPeer(string(args[1]), string(args[2]), tlsRootCert, blockNumber)
 	case JoinBySnapshotStatus:
 		if err = e.aclProvider.CheckACL(resources.Cscc_JoinBySnapshotStatus, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("access denied for [%s]: %s", fname, err))
@@ -282,6 +317,29 @@ func (e *PeerConfiger) JoinChainBySnapshot(
 	return shim.Success(nil)
 }
 
+// joinChainBySnapshotFromPeer downloads the snapshot of the channel from the peer at the given address,
+// and joins the channel by the snapshot once it is downloaded. The download and the join are reported
+// by the joinbysnapshot status.
+func (e *PeerConfiger) joinChainBySnapshotFromPeer(channelID, address string, tlsRootCert []byte, blockNumber uint64) pb.Response {
+	if e.snapshotFetcher == nil {
+		return shim.Error("downloading snapshots from other peers is not supported")
+	}
+	if e.peer.Channel(channelID) != nil {
+		return shim.Error(fmt.Sprintf("the peer has already joined channel %s", channelID))
+	}
+	if status := e.peer.JoinBySnaphotStatus(); status.InProgress {
+		return shim.Error(fmt.Sprintf("a joinbysnapshot operation is in progress with snapshot dir %s", status.BootstrappingSnapshotDir))
+	}
+
+	join := func(snapshotDir string) error {
+		return e.peer.CreateChannelFromSnapshot(snapshotDir, e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
+	}
+	if err := e.snapshotFetcher.Fetch(channelID, address, tlsRootCert, blockNumber, join); err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified channelID. If the
 // peer doesn't belong to the channel, return error
 func (e *PeerConfiger) getConfigBlock(channelID []byte) pb.Response {
@@ -341,6 +399,11 @@ func (e *PeerConfiger) getChannels() pb.Response {
 // joinBySnapshotStatus returns information about joinbysnapshot running status.
 func (e *PeerConfiger) joinBySnapshotStatus() pb.Response {
 	status := e.peer.JoinBySnaphotStatus()
+	if e.snapshotFetcher != nil && !status.InProgress {
+		if dir := e.snapshotFetcher.InProgressDir(); dir != "" {
+			status = &pb.JoinBySnapshotStatus{InProgress: true, BootstrappingSnapshotDir: dir}
+		}
+	}
 
 	statusBytes, err := proto.Marshal(status)
 	if err != nil {
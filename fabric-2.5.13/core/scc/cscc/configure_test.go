@@ -72,6 +72,12 @@ type storeProvider interface {
 	transientstore.StoreProvider
 }
 
+//go:generate counterfeiter -o mocks/snapshot_fetcher.go --fake-name SnapshotFetcher . snapshotFetcher
+
+type snapshotFetcher interface {
+	SnapshotFetcher
+}
+
 func TestMain(m *testing.M) {
 	msptesttools.LoadMSPSetupForTesting()
 	rc := m.Run()
@@ -436,6 +442,92 @@ func TestConfigerInvokeJoinChainBySnapshot(t *testing.T) {
 	require.Contains(t, res.Message, "access denied for [JoinChainBySnapshot]")
 }
 
+func TestConfigerInvokeJoinChainBySnapshotFromPeer(t *testing.T) {
+	testDir := t.TempDir()
+	ledgerInitializer := ledgermgmttest.NewInitializer(testDir)
+	ledgerInitializer.CustomTxProcessors = map[cb.HeaderType]ledger.CustomTxProcessor{
+		cb.HeaderType_CONFIG: &peer.ConfigTxProcessor{},
+	}
+	ledgerMgr := ledgermgmt.NewLedgerMgr(ledgerInitializer)
+	defer ledgerMgr.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+
+	cscc := newPeerConfiger(t, ledgerMgr, grpcServer, listener.Addr().String())
+	fakeFetcher := &mocks.SnapshotFetcher{}
+	cscc.snapshotFetcher = fakeFetcher
+
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	channelID := "testjoinchainbysnapshotfrompeer"
+	snapshotDir := ledgermgmttest.CreateSnapshotWithGenesisBlock(t, testDir, channelID, &peer.ConfigTxProcessor{})
+
+	mockACLProvider := cscc.aclProvider.(*mocks.ACLProvider)
+	mockStub := &mocks.ChaincodeStub{}
+	mockStub.GetSignedProposalReturns(validSignedProposal(), nil)
+
+	// the snapshot is reported in progress while it is downloaded
+	fakeFetcher.InProgressDirReturns("/downloaded/" + channelID)
+	resp := cscc.joinBySnapshotStatus()
+	status := &pb.JoinBySnapshotStatus{}
+	require.NoError(t, proto.Unmarshal(resp.Payload, status))
+	require.True(t, status.InProgress)
+	require.Equal(t, "/downloaded/"+channelID, status.BootstrappingSnapshotDir)
+	fakeFetcher.InProgressDirReturns("")
+
+	// successful path, the fetcher joins the channel by the downloaded snapshot
+	fakeFetcher.FetchStub = func(channelID, address string, tlsRootCert []byte, blockNumber uint64, join func(string) error) error {
+		return join(snapshotDir)
+	}
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte(channelID), []byte("peer1:7051"), []byte("10"), []byte("tls-root-cert")})
+	res := cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	require.Equal(t, 1, fakeFetcher.FetchCallCount())
+	cid, address, tlsRootCert, blockNumber, _ := fakeFetcher.FetchArgsForCall(0)
+	require.Equal(t, channelID, cid)
+	require.Equal(t, "peer1:7051", address)
+	require.Equal(t, []byte("tls-root-cert"), tlsRootCert)
+	require.Equal(t, uint64(10), blockNumber)
+
+	require.Eventually(t, func() bool { return cscc.peer.GetLedger(channelID) != nil && cscc.peer.Channel(channelID) != nil }, time.Minute, 100*time.Millisecond)
+
+	// error path due to the channel being already joined
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "the peer has already joined channel "+channelID, res.Message)
+
+	// error path due to fetch error
+	fakeFetcher.FetchReturns(errors.New("a snapshot is already being downloaded"))
+	fakeFetcher.FetchStub = nil
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte("otherchannel"), []byte("peer1:7051"), []byte("0")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "a snapshot is already being downloaded", res.Message)
+
+	// error path due to invalid block number
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte("otherchannel"), []byte("peer1:7051"), []byte("latest")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "invalid snapshot block number [latest]", res.Message)
+
+	// error path due to missing argument
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte("otherchannel"), []byte("peer1:7051")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Incorrect number of arguments, 3", res.Message)
+
+	// error path due to CheckACL error
+	mockACLProvider.CheckACLReturns(errors.New("Failed authorization"))
+	mockStub.GetArgsReturns([][]byte{[]byte(JoinChainBySnapshotFromPeer), []byte("otherchannel"), []byte("peer1:7051"), []byte("0")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "access denied for [JoinChainBySnapshotFromPeer]")
+}
+
 func TestConfigerInvokeGetChannelConfig(t *testing.T) {
 	testDir, err := ioutil.TempDir("", "cscc_test_GetChannelConfig")
 	require.NoError(t, err)
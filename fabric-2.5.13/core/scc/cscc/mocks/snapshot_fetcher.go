@@ -0,0 +1,185 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+)
+
+type SnapshotFetcher struct {
+	FetchStub        func(string, string, []byte, uint64, func(snapshotDir string) error) error
+	fetchMutex       sync.RWMutex
+	fetchArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+		arg4 uint64
+		arg5 func(snapshotDir string) error
+	}
+	fetchReturns struct {
+		result1 error
+	}
+	fetchReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InProgressDirStub        func() string
+	inProgressDirMutex       sync.RWMutex
+	inProgressDirArgsForCall []struct {
+	}
+	inProgressDirReturns struct {
+		result1 string
+	}
+	inProgressDirReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SnapshotFetcher) Fetch(arg1 string, arg2 string, arg3 []byte, arg4 uint64, arg5 func(snapshotDir string) error) error {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.fetchMutex.Lock()
+	ret, specificReturn := fake.fetchReturnsOnCall[len(fake.fetchArgsForCall)]
+	fake.fetchArgsForCall = append(fake.fetchArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+		arg4 uint64
+		arg5 func(snapshotDir string) error
+	}{arg1, arg2, arg3Copy, arg4, arg5})
+	stub := fake.FetchStub
+	fakeReturns := fake.fetchReturns
+	fake.recordInvocation("Fetch", []interface{}{arg1, arg2, arg3Copy, arg4, arg5})
+	fake.fetchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SnapshotFetcher) FetchCallCount() int {
+	fake.fetchMutex.RLock()
+	defer fake.fetchMutex.RUnlock()
+	return len(fake.fetchArgsForCall)
+}
+
+func (fake *SnapshotFetcher) FetchCalls(stub func(string, string, []byte, uint64, func(snapshotDir string) error) error) {
+	fake.fetchMutex.Lock()
+	defer fake.fetchMutex.Unlock()
+	fake.FetchStub = stub
+}
+
+func (fake *SnapshotFetcher) FetchArgsForCall(i int) (string, string, []byte, uint64, func(snapshotDir string) error) {
+	fake.fetchMutex.RLock()
+	defer fake.fetchMutex.RUnlock()
+	argsForCall := fake.fetchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *SnapshotFetcher) FetchReturns(result1 error) {
+	fake.fetchMutex.Lock()
+	defer fake.fetchMutex.Unlock()
+	fake.FetchStub = nil
+	fake.fetchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SnapshotFetcher) FetchReturnsOnCall(i int, result1 error) {
+	fake.fetchMutex.Lock()
+	defer fake.fetchMutex.Unlock()
+	fake.FetchStub = nil
+	if fake.fetchReturnsOnCall == nil {
+		fake.fetchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.fetchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SnapshotFetcher) InProgressDir() string {
+	fake.inProgressDirMutex.Lock()
+	ret, specificReturn := fake.inProgressDirReturnsOnCall[len(fake.inProgressDirArgsForCall)]
+	fake.inProgressDirArgsForCall = append(fake.inProgressDirArgsForCall, struct {
+	}{})
+	stub := fake.InProgressDirStub
+	fakeReturns := fake.inProgressDirReturns
+	fake.recordInvocation("InProgressDir", []interface{}{})
+	fake.inProgressDirMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SnapshotFetcher) InProgressDirCallCount() int {
+	fake.inProgressDirMutex.RLock()
+	defer fake.inProgressDirMutex.RUnlock()
+	return len(fake.inProgressDirArgsForCall)
+}
+
+func (fake *SnapshotFetcher) InProgressDirCalls(stub func() string) {
+	fake.inProgressDirMutex.Lock()
+	defer fake.inProgressDirMutex.Unlock()
+	fake.InProgressDirStub = stub
+}
+
+func (fake *SnapshotFetcher) InProgressDirReturns(result1 string) {
+	fake.inProgressDirMutex.Lock()
+	defer fake.inProgressDirMutex.Unlock()
+	fake.InProgressDirStub = nil
+	fake.inProgressDirReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *SnapshotFetcher) InProgressDirReturnsOnCall(i int, result1 string) {
+	fake.inProgressDirMutex.Lock()
+	defer fake.inProgressDirMutex.Unlock()
+	fake.InProgressDirStub = nil
+	if fake.inProgressDirReturnsOnCall == nil {
+		fake.inProgressDirReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.inProgressDirReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *SnapshotFetcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.fetchMutex.RLock()
+	defer fake.fetchMutex.RUnlock()
+	fake.inProgressDirMutex.RLock()
+	defer fake.inProgressDirMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SnapshotFetcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
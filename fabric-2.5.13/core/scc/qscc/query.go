@@ -40,6 +40,7 @@ func (e *LedgerQuerier) Chaincode() shim.Chaincode { return e }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetTxInvalidationInfo returns the reason for which a transaction was marked invalid
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 	ledgers     LedgerGetter
@@ -49,11 +50,12 @@ var qscclogger = flogging.MustGetLogger("qscc")
 
 // These are function names from Invoke first parameter
 const (
-	GetChainInfo       string = "GetChainInfo"
-	GetBlockByNumber   string = "GetBlockByNumber"
-	GetBlockByHash     string = "GetBlockByHash"
-	GetTransactionByID string = "GetTransactionByID"
-	GetBlockByTxID     string = "GetBlockByTxID"
+	GetChainInfo          string = "GetChainInfo"
+	GetBlockByNumber      string = "GetBlockByNumber"
+	GetBlockByHash        string = "GetBlockByHash"
+	GetTransactionByID    string = "GetTransactionByID"
+	GetBlockByTxID        string = "GetBlockByTxID"
+	GetTxInvalidationInfo string = "GetTxInvalidationInfo"
 )
 
 // Init is called once per chain when the chain is created.
@@ -72,6 +74,7 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetTxInvalidationInfo: Return the reason for which the transaction specified by ID in args[2] was marked invalid
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -124,6 +127,8 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetTxInvalidationInfo:
+		return getTxInvalidationInfo(targetLedger, args[2])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -221,6 +226,24 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getTxInvalidationInfo(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
+	txID := string(rawTxID)
+	info, err := vledger.GetTxInvalidationInfo(txID)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get invalidation info for txID %s, error %s", txID, err))
+	}
+	if info == nil {
+		return shim.Error(fmt.Sprintf("No invalidation info found for txID %s", txID))
+	}
+
+	bytes, err := protoutil.Marshal(info)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
@@ -12,6 +12,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-chaincode-go/shimtest"
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -24,7 +25,9 @@ import (
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -212,6 +215,54 @@ func TestQueryGetBlockByTxID(t *testing.T) {
 	require.Equal(t, int32(shim.ERROR), res.Status, "GetBlockByTxID should have failed with blank txId.")
 }
 
+func TestQueryGetTxInvalidationInfo(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer cleanup()
+
+	lgr := p.GetLedger(chainid)
+	defer lgr.Close()
+	bcInfo, err := lgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	block1 := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+		BlockNum:     1,
+		PreviousHash: bcInfo.CurrentBlockHash,
+		Txs: []*testutil.TxDetails{
+			{TxID: "txid1", Type: common.HeaderType_ENDORSER_TRANSACTION, ChaincodeName: "cc1", ChaincodeVersion: "v1"},
+		},
+	}, false)
+	block1.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txflags.NewWithValues(1, peer2.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	require.NoError(t, lgr.CommitLegacy(
+		&ledger2.BlockAndPvtData{
+			Block:                block1,
+			InvalidationMessages: map[uint64]string{0: "signature set did not satisfy policy"},
+		},
+		&ledger2.CommitOptions{},
+	))
+
+	args := [][]byte{[]byte(GetTxInvalidationInfo), []byte(chainid), []byte("txid1")}
+	prop := resetProvider(resources.Qscc_GetTxInvalidationInfo, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	info := &txinvalidation.TxInvalidationInfo{}
+	require.NoError(t, proto.Unmarshal(res.Payload, info))
+	require.Equal(t, peer2.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, info.ValidationCode)
+	require.Equal(t, uint64(1), info.BlockNumber)
+	require.Equal(t, "signature set did not satisfy policy", info.Message)
+
+	args = [][]byte{[]byte(GetTxInvalidationInfo), []byte(chainid), []byte("unknown-txid")}
+	prop = resetProvider(resources.Qscc_GetTxInvalidationInfo, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "No invalidation info found for txID unknown-txid", res.Message)
+}
+
 func TestFailingCC2CC(t *testing.T) {
 	t.Run("BadProposal", func(t *testing.T) {
 		stub := shimtest.NewMockStub("testchannel", &LedgerQuerier{})
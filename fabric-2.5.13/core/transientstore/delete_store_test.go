@@ -32,7 +32,7 @@ func TestNewStoreProvider(t *testing.T) {
 	defer os.RemoveAll(tempdir)
 
 	storedir := filepath.Join(tempdir, "transientstore")
-	p, err := NewStoreProvider(storedir)
+	p, err := NewStoreProvider(storedir, nil)
 	require.NoError(t, err)
 	require.NotNil(t, p)
 }
@@ -269,7 +269,7 @@ func TestPackageDropTransientStorage(t *testing.T) {
 	// drop the storage
 	require.NoError(t, Drop(env.storedir, ledgerID))
 
-	sp, err := NewStoreProvider(env.storedir)
+	sp, err := NewStoreProvider(env.storedir, nil)
 	require.NoError(t, err)
 	require.NotNil(t, sp)
 	defer sp.Close()
@@ -328,18 +328,18 @@ func TestOneAndOnlyOneTransientStorageProviderMayBeOpened(t *testing.T) {
 	env.storeProvider.Close()
 
 	// open the first provider
-	sp, err := NewStoreProvider(env.storedir)
+	sp, err := NewStoreProvider(env.storedir, nil)
 	require.NoError(t, err)
 	require.NotNil(t, sp)
 
 	// opening a second provider is an error
-	_, err = NewStoreProvider(env.storedir)
+	_, err = NewStoreProvider(env.storedir, nil)
 	require.ErrorContains(t, err, "as another peer node command is executing, wait for that command to complete its execution or terminate it before retrying: lock is already acquired on file")
 
 	// After closing the provider it may be reopened.
 	sp.Close()
 
-	sp, err = NewStoreProvider(env.storedir)
+	sp, err = NewStoreProvider(env.storedir, nil)
 	require.NoError(t, err)
 	require.NotNil(t, sp)
 	defer sp.Close()
@@ -403,7 +403,7 @@ func TestProviderRestartAfterFailedDeletionScrubsPendingDeletions(t *testing.T)
 	env.storeProvider.Close()
 
 	// re-opening the provider will trigger the processPendingStorageDeletions()
-	env.storeProvider, err = NewStoreProvider(env.storedir)
+	env.storeProvider, err = NewStoreProvider(env.storedir, nil)
 	require.NoError(t, err)
 	sp = env.storeProvider.(*storeProvider)
 
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transientstore
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+const (
+	evictionReasonCommitted   = "committed"
+	evictionReasonBlockHeight = "block_height"
+	evictionReasonExpired     = "expired"
+
+	quotaChannel    = "channel"
+	quotaCollection = "collection"
+)
+
+type stats struct {
+	size           metrics.Gauge
+	collectionSize metrics.Gauge
+	evictions      metrics.Counter
+	rejections     metrics.Counter
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		size:           metricsProvider.NewGauge(sizeOpts),
+		collectionSize: metricsProvider.NewGauge(collectionSizeOpts),
+		evictions:      metricsProvider.NewCounter(evictionsOpts),
+		rejections:     metricsProvider.NewCounter(rejectionsOpts),
+	}
+}
+
+type storeStats struct {
+	stats    *stats
+	ledgerID string
+}
+
+func (s *stats) storeStats(ledgerID string) *storeStats {
+	return &storeStats{
+		s, ledgerID,
+	}
+}
+
+func (s *storeStats) updateSize(size uint64) {
+	s.stats.size.With("channel", s.ledgerID).Set(float64(size))
+}
+
+func (s *storeStats) updateCollectionSize(ns, coll string, size uint64) {
+	s.stats.collectionSize.With("channel", s.ledgerID, "chaincode", ns, "collection", coll).Set(float64(size))
+}
+
+func (s *storeStats) updateEvictions(reason string, count int) {
+	if count == 0 {
+		return
+	}
+	s.stats.evictions.With("channel", s.ledgerID, "reason", reason).Add(float64(count))
+}
+
+func (s *storeStats) updateRejections(quota string) {
+	s.stats.rejections.With("channel", s.ledgerID, "quota", quota).Add(1)
+}
+
+var (
+	sizeOpts = metrics.GaugeOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "size",
+		Help:         "Size in bytes of the private write sets in the transient store of a channel.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	collectionSizeOpts = metrics.GaugeOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "collection_size",
+		Help:         "Size in bytes of the private data of a collection in the transient store of a channel.",
+		LabelNames:   []string{"channel", "chaincode", "collection"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{collection}",
+	}
+
+	evictionsOpts = metrics.CounterOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "evictions",
+		Help:         "The number of private write sets removed from the transient store, by reason (committed, block_height or expired).",
+		LabelNames:   []string{"channel", "reason"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{reason}",
+	}
+
+	rejectionsOpts = metrics.CounterOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "rejections",
+		Help:         "The number of private write sets rejected by the transient store, by the quota (channel or collection) that would have been exceeded.",
+		LabelNames:   []string{"channel", "quota"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{quota}",
+	}
+)
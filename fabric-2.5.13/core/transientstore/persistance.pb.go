@@ -59,22 +59,145 @@ func (m *PendingDeleteStorageList) GetList() []string {
 	return nil
 }
 
+// TxPvtEntryInfo is stored in the indexes of a private write set and is used for
+// expiring the private write set and for accounting the size of the transient store
+type TxPvtEntryInfo struct {
+	// persisted_at is the time (unix nanoseconds) at which the private write set was persisted
+	PersistedAt int64 `protobuf:"varint,1,opt,name=persisted_at,json=persistedAt,proto3" json:"persisted_at,omitempty"`
+	// size is the size of the private write set as stored in the transient store
+	Size                 uint64            `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	CollectionSizes      []*CollectionSize `protobuf:"bytes,3,rep,name=collection_sizes,json=collectionSizes,proto3" json:"collection_sizes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TxPvtEntryInfo) Reset()         { *m = TxPvtEntryInfo{} }
+func (m *TxPvtEntryInfo) String() string { return proto.CompactTextString(m) }
+func (*TxPvtEntryInfo) ProtoMessage()    {}
+func (*TxPvtEntryInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a374ca4de69122a4, []int{1}
+}
+
+func (m *TxPvtEntryInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxPvtEntryInfo.Unmarshal(m, b)
+}
+func (m *TxPvtEntryInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxPvtEntryInfo.Marshal(b, m, deterministic)
+}
+func (m *TxPvtEntryInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxPvtEntryInfo.Merge(m, src)
+}
+func (m *TxPvtEntryInfo) XXX_Size() int {
+	return xxx_messageInfo_TxPvtEntryInfo.Size(m)
+}
+func (m *TxPvtEntryInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxPvtEntryInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxPvtEntryInfo proto.InternalMessageInfo
+
+func (m *TxPvtEntryInfo) GetPersistedAt() int64 {
+	if m != nil {
+		return m.PersistedAt
+	}
+	return 0
+}
+
+func (m *TxPvtEntryInfo) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *TxPvtEntryInfo) GetCollectionSizes() []*CollectionSize {
+	if m != nil {
+		return m.CollectionSizes
+	}
+	return nil
+}
+
+type CollectionSize struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	Size                 uint64   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectionSize) Reset()         { *m = CollectionSize{} }
+func (m *CollectionSize) String() string { return proto.CompactTextString(m) }
+func (*CollectionSize) ProtoMessage()    {}
+func (*CollectionSize) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a374ca4de69122a4, []int{2}
+}
+
+func (m *CollectionSize) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionSize.Unmarshal(m, b)
+}
+func (m *CollectionSize) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionSize.Marshal(b, m, deterministic)
+}
+func (m *CollectionSize) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionSize.Merge(m, src)
+}
+func (m *CollectionSize) XXX_Size() int {
+	return xxx_messageInfo_CollectionSize.Size(m)
+}
+func (m *CollectionSize) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionSize.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionSize proto.InternalMessageInfo
+
+func (m *CollectionSize) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *CollectionSize) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *CollectionSize) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*PendingDeleteStorageList)(nil), "transientstore.PendingDeleteStorageList")
+	proto.RegisterType((*TxPvtEntryInfo)(nil), "transientstore.TxPvtEntryInfo")
+	proto.RegisterType((*CollectionSize)(nil), "transientstore.CollectionSize")
 }
 
 func init() { proto.RegisterFile("persistance.proto", fileDescriptor_a374ca4de69122a4) }
 
 var fileDescriptor_a374ca4de69122a4 = []byte{
-	// 145 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0xcd, 0xb1, 0x0a, 0xc2, 0x30,
-	0x10, 0x06, 0x60, 0x44, 0x11, 0xec, 0x20, 0xd8, 0xa9, 0xa3, 0x38, 0x39, 0x35, 0x48, 0xdf, 0x40,
-	0x1c, 0x1d, 0x44, 0x37, 0xb7, 0x24, 0xfd, 0x4d, 0x0f, 0x62, 0x2e, 0xdc, 0x9d, 0x83, 0x6f, 0x2f,
-	0x74, 0x73, 0xfb, 0xb6, 0xaf, 0xd9, 0x55, 0x88, 0x92, 0x9a, 0x2f, 0x11, 0x7d, 0x15, 0x36, 0x6e,
-	0xb7, 0x26, 0xbe, 0x28, 0xa1, 0x98, 0x1a, 0x0b, 0x0e, 0x7d, 0xd3, 0xdd, 0x50, 0x46, 0x2a, 0xe9,
-	0x82, 0x0c, 0xc3, 0xc3, 0x58, 0x7c, 0xc2, 0x95, 0xd4, 0xda, 0xb6, 0x59, 0x65, 0x52, 0xeb, 0x16,
-	0xfb, 0xe5, 0x71, 0x73, 0x9f, 0x7d, 0x1e, 0x9e, 0xa7, 0x44, 0x36, 0x7d, 0x42, 0x1f, 0xf9, 0xed,
-	0xa6, 0x6f, 0x85, 0x64, 0x8c, 0x09, 0xe2, 0x5e, 0x3e, 0x08, 0x45, 0x17, 0x59, 0xe0, 0xfe, 0x93,
-	0xb0, 0x9e, 0xef, 0xe1, 0x17, 0x00, 0x00, 0xff, 0xff, 0xa1, 0xb1, 0xe2, 0x32, 0x90, 0x00, 0x00,
-	0x00,
+	// 269 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xbf, 0x6a, 0xf3, 0x30,
+	0x14, 0xc5, 0xf1, 0xe7, 0xf0, 0x81, 0x95, 0xe2, 0xb6, 0x9a, 0x3c, 0x94, 0xe0, 0x7a, 0xf2, 0x64,
+	0xd3, 0xe6, 0x09, 0xfa, 0x6f, 0x08, 0x74, 0x08, 0x4a, 0xa7, 0x2e, 0x41, 0x96, 0x6f, 0x1c, 0x81,
+	0x22, 0x19, 0xdd, 0xdb, 0xd2, 0xe4, 0x31, 0xfa, 0xc4, 0x25, 0xa2, 0xd8, 0xf5, 0x76, 0xf4, 0xe3,
+	0x70, 0xf4, 0xe3, 0xb2, 0xeb, 0x1e, 0x3c, 0x6a, 0x24, 0x69, 0x15, 0x54, 0xbd, 0x77, 0xe4, 0x78,
+	0x4a, 0x5e, 0x5a, 0xd4, 0x60, 0x09, 0xc9, 0x79, 0x28, 0x2a, 0x96, 0xad, 0xc1, 0xb6, 0xda, 0x76,
+	0xcf, 0x60, 0x80, 0x60, 0x43, 0xce, 0xcb, 0x0e, 0x5e, 0x35, 0x12, 0xe7, 0x6c, 0x66, 0x34, 0x52,
+	0x16, 0xe5, 0x71, 0x99, 0x88, 0x90, 0x8b, 0xef, 0x88, 0xa5, 0x6f, 0x5f, 0xeb, 0x4f, 0x7a, 0xb1,
+	0xe4, 0x8f, 0x2b, 0xbb, 0x73, 0xfc, 0x96, 0x5d, 0xfc, 0xfe, 0x03, 0xed, 0x56, 0x9e, 0xeb, 0x51,
+	0x19, 0x8b, 0xf9, 0xc0, 0x1e, 0xc2, 0x12, 0xea, 0x13, 0x64, 0xff, 0xf2, 0xa8, 0x9c, 0x89, 0x90,
+	0xf9, 0x8a, 0x5d, 0x29, 0x67, 0x0c, 0x28, 0xd2, 0xce, 0x6e, 0xcf, 0x08, 0xb3, 0x38, 0x8f, 0xcb,
+	0xf9, 0xfd, 0xa2, 0x9a, 0x4a, 0x56, 0x4f, 0x43, 0x6f, 0xa3, 0x4f, 0x20, 0x2e, 0xd5, 0xe4, 0x8d,
+	0x45, 0xc3, 0xd2, 0x69, 0x85, 0xdf, 0xb0, 0xc4, 0xca, 0x03, 0x60, 0x2f, 0x15, 0x04, 0xa1, 0x44,
+	0x8c, 0x80, 0x2f, 0x18, 0x1b, 0x27, 0x82, 0x54, 0x22, 0xfe, 0x90, 0x41, 0x37, 0x1e, 0x75, 0x1f,
+	0x97, 0xef, 0x77, 0x9d, 0xa6, 0xfd, 0x47, 0x53, 0x29, 0x77, 0xa8, 0xf7, 0xc7, 0x1e, 0xbc, 0x81,
+	0xb6, 0x03, 0x5f, 0xef, 0x64, 0xe3, 0xb5, 0xaa, 0x95, 0xf3, 0x50, 0x4f, 0xc5, 0x9b, 0xff, 0xe1,
+	0xe8, 0xcb, 0x9f, 0x01, 0x00, 0xbb, 0x21, 0x03, 0xcd, 0x89, 0x01, 0x00, 0x00,
 }
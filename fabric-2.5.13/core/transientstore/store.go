@@ -7,15 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package transientstore
 
 import (
+	"fmt"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
@@ -30,7 +36,10 @@ var (
 	// transient system namespace is the name of a db used for storage bookkeeping metadata.
 	systemNamespace          = ""
 	underDeletionKey         = []byte("UNDER_DELETION")
+	encryptionKeysKey        = []byte("ENCRYPTION_KEYS")
 	transientStorageLockName = "transientStoreFileLock"
+	// expiryCheckInterval is the interval at which the private write sets older than the TTL are purged
+	expiryCheckInterval = time.Minute
 )
 
 //////////////////////////////////////////////
@@ -63,29 +72,94 @@ type EndorserPvtSimulationResults struct {
 // Implementation
 /////////////////////////////////////////////
 
+// Config is used to configure the transient store provider
+type Config struct {
+	// EncryptionProvider supplies the keys for encrypting the private write sets at rest.
+	// A nil EncryptionProvider stores the private write sets in plaintext.
+	EncryptionProvider *pvtdataencryption.Provider
+	// TTL is the duration after which a private write set is purged from the transient store,
+	// regardless of the block height. A zero TTL disables the time based purge.
+	TTL time.Duration
+	// ChannelQuota is the maximum size in bytes of the private write sets in the transient store
+	// of a channel. A zero ChannelQuota disables the quota.
+	ChannelQuota uint64
+	// CollectionQuota is the maximum size in bytes of the private data of a collection in the
+	// transient store of a channel. A zero CollectionQuota disables the quota.
+	CollectionQuota uint64
+	// MetricsProvider is used for reporting the size of the transient store and the number of
+	// private write sets purged or rejected. A nil MetricsProvider disables the metrics.
+	MetricsProvider metrics.Provider
+}
+
+// QuotaExceededError is returned by Persist when persisting a private write set would exceed
+// the quota of the channel or of one of the collections in the transient store
+type QuotaExceededError struct {
+	ChannelID  string
+	Namespace  string
+	Collection string
+	Quota      uint64
+	Size       uint64
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Collection == "" {
+		return fmt.Sprintf("transient store quota of channel [%s] exceeded: the private write set of [%d] bytes does not fit in the quota of [%d] bytes",
+			e.ChannelID, e.Size, e.Quota)
+	}
+	return fmt.Sprintf("transient store quota of collection [%s:%s] in channel [%s] exceeded: the private data of [%d] bytes does not fit in the quota of [%d] bytes",
+		e.Namespace, e.Collection, e.ChannelID, e.Size, e.Quota)
+}
+
 // storeProvider encapsulates a leveldb provider which is used to store
 // private write sets of simulated transactions, and implements TransientStoreProvider
 // interface.
 type storeProvider struct {
 	dbProvider *leveldbhelper.Provider
 	fileLock   *leveldbhelper.FileLock
+	config     *Config
+	stats      *stats
+
+	storesLock sync.Mutex
+	stores     map[string]*Store
+
+	clock               func() time.Time
+	expiryCheckInterval time.Duration
+	done                chan struct{}
+	wg                  sync.WaitGroup
 }
 
 // store holds an instance of a levelDB.
 type Store struct {
-	db       *leveldbhelper.DBHandle
-	ledgerID string
+	db        *leveldbhelper.DBHandle
+	ledgerID  string
+	encrypter *pvtdataencryption.Encrypter
+	config    *Config
+	stats     *storeStats
+	clock     func() time.Time
+
+	// purgeLock serializes the purges so that the size of a purged private write set is
+	// subtracted from the usage only once
+	purgeLock  sync.Mutex
+	usageLock  sync.Mutex
+	usage      uint64
+	collsUsage map[nsColl]uint64
+}
+
+type nsColl struct {
+	ns, coll string
 }
 
 // RwsetScanner helps iterating over results
 type RwsetScanner struct {
-	txid   string
-	dbItr  iterator.Iterator
-	filter ledger.PvtNsCollFilter
+	txid      string
+	dbItr     iterator.Iterator
+	filter    ledger.PvtNsCollFilter
+	encrypter *pvtdataencryption.Encrypter
 }
 
-// NewStoreProvider instantiates TransientStoreProvider
-func NewStoreProvider(path string) (StoreProvider, error) {
+// NewStoreProvider instantiates TransientStoreProvider. A nil conf uses the defaults, i.e., the private
+// write sets are stored in plaintext and are purged only based on the block height, without any quota.
+func NewStoreProvider(path string, conf *Config) (StoreProvider, error) {
 	// Ensure the routine is invoked while the peer is down.
 	lockPath := filepath.Join(filepath.Dir(path), transientStorageLockName)
 	lock := leveldbhelper.NewFileLock(lockPath)
@@ -99,6 +173,15 @@ func NewStoreProvider(path string) (StoreProvider, error) {
 		lock.Unlock()
 		return nil, errors.WithMessagef(err, "could not construct storage provider in folder [%s]", path)
 	}
+	if conf != nil {
+		provider.config = conf
+	}
+	if provider.config.MetricsProvider != nil {
+		provider.stats = newStats(provider.config.MetricsProvider)
+	}
+	if provider.config.TTL > 0 {
+		provider.launchExpiryProc()
+	}
 
 	return provider, nil
 }
@@ -117,7 +200,15 @@ func newStoreProvider(providerPath string, fileLock *leveldbhelper.FileLock) (*s
 		return nil, errors.WithMessage(err, "could not open dbprovider")
 	}
 
-	provider := &storeProvider{dbProvider: dbProvider, fileLock: fileLock}
+	provider := &storeProvider{
+		dbProvider:          dbProvider,
+		fileLock:            fileLock,
+		config:              &Config{},
+		stats:               newStats(&disabled.Provider{}),
+		stores:              map[string]*Store{},
+		clock:               time.Now,
+		expiryCheckInterval: expiryCheckInterval,
+	}
 
 	// purge any databases marked for deletion.  This may occur at the next peer init after a
 	// transient storage deletion failed due to a crash or system error.
@@ -130,12 +221,70 @@ func newStoreProvider(providerPath string, fileLock *leveldbhelper.FileLock) (*s
 
 // OpenStore returns a handle to a ledgerId in Store
 func (provider *storeProvider) OpenStore(ledgerID string) (*Store, error) {
+	provider.storesLock.Lock()
+	defer provider.storesLock.Unlock()
+	if s, ok := provider.stores[ledgerID]; ok {
+		return s, nil
+	}
+
 	dbHandle := provider.dbProvider.GetDBHandle(ledgerID)
-	return &Store{db: dbHandle, ledgerID: ledgerID}, nil
+	encrypter, err := provider.config.EncryptionProvider.Encrypter(dbHandle, encryptionKeysKey)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not obtain the encryption keys of transient storage [%s]", ledgerID)
+	}
+	s := &Store{
+		db:         dbHandle,
+		ledgerID:   ledgerID,
+		encrypter:  encrypter,
+		config:     provider.config,
+		stats:      provider.stats.storeStats(ledgerID),
+		clock:      provider.clock,
+		collsUsage: map[nsColl]uint64{},
+	}
+	if err := s.loadUsage(); err != nil {
+		return nil, errors.WithMessagef(err, "could not compute the usage of transient storage [%s]", ledgerID)
+	}
+	provider.stores[ledgerID] = s
+	return s, nil
+}
+
+// launchExpiryProc launches a goroutine that periodically purges the private write sets
+// that were persisted earlier than the configured TTL from the stores that are open
+func (provider *storeProvider) launchExpiryProc() {
+	provider.done = make(chan struct{})
+	provider.wg.Add(1)
+	go func() {
+		defer provider.wg.Done()
+		ticker := time.NewTicker(provider.expiryCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-provider.done:
+				return
+			case <-ticker.C:
+			}
+			provider.storesLock.Lock()
+			stores := make([]*Store, 0, len(provider.stores))
+			for _, s := range provider.stores {
+				stores = append(stores, s)
+			}
+			provider.storesLock.Unlock()
+			for _, s := range stores {
+				if err := s.PurgeExpired(); err != nil {
+					logger.Errorw("Failed to purge expired private data from transient store", "ledgerID", s.ledgerID, "error", err)
+				}
+			}
+		}
+	}()
 }
 
 // Close closes the TransientStoreProvider
 func (provider *storeProvider) Close() {
+	if provider.done != nil {
+		close(provider.done)
+		provider.wg.Wait()
+		provider.done = nil
+	}
 	if provider.dbProvider != nil {
 		provider.dbProvider.Close()
 	}
@@ -311,8 +460,52 @@ func Drop(providerPath, ledgerID string) error {
 	return nil
 }
 
+// RotateEncryptionKeys re-wraps the data encryption keys of the transient storage of the given ledgers
+// with a new key encryption key and switches to a new data encryption key for the private write sets
+// that are persisted from now on. The transient storage of a ledger that has never been encrypted is skipped.
+// This function must be invoked while the peer is shut down.
+func RotateEncryptionKeys(providerPath string, ledgerIDs []string, encryptionProvider *pvtdataencryption.Provider) error {
+	if encryptionProvider == nil {
+		return errors.New("encryption of private data is not configured")
+	}
+
+	// Ensure the routine is invoked while the peer is down.
+	lockPath := filepath.Join(filepath.Dir(providerPath), transientStorageLockName)
+	lock := leveldbhelper.NewFileLock(lockPath)
+	if err := lock.Lock(); err != nil {
+		return errors.New("as another peer node command is executing," +
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer lock.Unlock()
+
+	provider, err := newStoreProvider(providerPath, lock)
+	if err != nil {
+		return errors.WithMessagef(err, "constructing provider from path [%s]", providerPath)
+	}
+	defer provider.Close()
+
+	for _, ledgerID := range ledgerIDs {
+		dbHandle := provider.dbProvider.GetDBHandle(ledgerID)
+		keys, err := dbHandle.Get(encryptionKeysKey)
+		if err != nil {
+			return errors.WithMessagef(err, "retrieving the encryption keys of transient storage [%s]", ledgerID)
+		}
+		if keys == nil {
+			logger.Infow("Skipping the rotation of encryption keys of transient storage that has never been encrypted", "ledgerID", ledgerID)
+			continue
+		}
+		if err := encryptionProvider.RotateKeys(dbHandle, encryptionKeysKey); err != nil {
+			return errors.WithMessagef(err, "rotating the encryption keys of transient storage [%s]", ledgerID)
+		}
+		logger.Infow("Rotated the encryption keys of transient storage", "ledgerID", ledgerID)
+	}
+	return nil
+}
+
 // Persist stores the private write set of a transaction along with the collection config
-// in the transient store based on txid and the block height the private data was received at
+// in the transient store based on txid and the block height the private data was received at.
+// A *QuotaExceededError is returned if the private write set does not fit in the quota of
+// the channel or of one of its collections.
 func (s *Store) Persist(txid string, blockHeight uint64,
 	privateSimulationResultsWithConfig *transientstore.TxPvtReadWriteSetWithConfigInfo) error {
 	logger.Debugf("Persisting private data to transient store for txid [%s] at block height [%d]", txid, blockHeight)
@@ -335,22 +528,36 @@ func (s *Store) Persist(txid string, blockHeight uint64,
 	// as a marshaled message can never start with a nil byte. In v1.3, we can avoid prepending the
 	// nil byte.
 	value := append([]byte{nilByte}, privateSimulationResultsWithConfigBytes...)
+	if value, err = s.encrypter.Encrypt(compositeKeyPvtRWSet, value); err != nil {
+		return err
+	}
 	dbBatch.Put(compositeKeyPvtRWSet, value)
 
+	// The info about the entry is stored as the value of its indexes so that the purges can
+	// account for the size of the entry without reading the (potentially large) private write set
+	info := newTxPvtEntryInfo(s.clock(), value, privateSimulationResultsWithConfig.GetPvtRwset())
+	infoBytes, err := proto.Marshal(info)
+	if err != nil {
+		return err
+	}
+	if err := s.reserve(info); err != nil {
+		return err
+	}
+
 	// Create two index: (i) by txid, and (ii) by height
 
 	// Create compositeKey for purge index by height with appropriate prefix, blockHeight,
-	// txid, uuid and store the compositeKey (purge index) with a nil byte as value. Note that
+	// txid, uuid and store the compositeKey (purge index) with the entry info as value. Note that
 	// the purge index is used to remove orphan entries in the transient store (which are not removed
 	// by PurgeTxids()) using BTL policy by PurgeBelowHeight(). Note that orphan entries are due to transaction
 	// that gets endorsed but not submitted by the client for commit)
 	compositeKeyPurgeIndexByHeight := createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid)
-	dbBatch.Put(compositeKeyPurgeIndexByHeight, emptyValue)
+	dbBatch.Put(compositeKeyPurgeIndexByHeight, infoBytes)
 
 	// Create compositeKey for purge index by txid with appropriate prefix, txid, uuid,
-	// blockHeight and store the compositeKey (purge index) with a nil byte as value.
+	// blockHeight and store the compositeKey (purge index) with the entry info as value.
 	// Though compositeKeyPvtRWSet itself can be used to purge private write set by txid,
-	// we create a separate composite key with a nil byte as value. The reason is that
+	// we create a separate composite key with a small value. The reason is that
 	// if we use compositeKeyPvtRWSet, we unnecessarily read (potentially large) private write
 	// set associated with the key from db. Note that this purge index is used to remove non-orphan
 	// entries in the transient store and is used by PurgeTxids()
@@ -358,9 +565,19 @@ func (s *Store) Persist(txid string, blockHeight uint64,
 	// with purgeIndexByTxidPrefix. For code readability and to be expressive, we use a
 	// createCompositeKeyForPurgeIndexByTxid() instead.
 	compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
-	dbBatch.Put(compositeKeyPurgeIndexByTxid, emptyValue)
+	dbBatch.Put(compositeKeyPurgeIndexByTxid, infoBytes)
 
-	return s.db.WriteBatch(dbBatch, true)
+	// Create compositeKey for the expiry index with appropriate prefix, the time the entry is
+	// persisted at, blockHeight, txid and uuid. The expiry index is used by PurgeExpired() to
+	// remove the entries that are older than the configured TTL.
+	compositeKeyExpiryIndex := createCompositeKeyForExpiryIndex(uint64(info.PersistedAt), blockHeight, txid, uuid)
+	dbBatch.Put(compositeKeyExpiryIndex, emptyValue)
+
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		s.release([]*TxPvtEntryInfo{info})
+		return err
+	}
+	return nil
 }
 
 // GetTxPvtRWSetByTxid returns an iterator due to the fact that the txid may have multiple private
@@ -376,7 +593,7 @@ func (s *Store) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter)
 	if err != nil {
 		return nil, err
 	}
-	return &RwsetScanner{txid, iter, filter}, nil
+	return &RwsetScanner{txid, iter, filter, s.encrypter}, nil
 }
 
 // PurgeByTxids removes private write sets of a given set of transactions from the
@@ -385,7 +602,11 @@ func (s *Store) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter)
 func (s *Store) PurgeByTxids(txids []string) error {
 	logger.Debug("Purging private data from transient store for committed txids")
 
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+
 	dbBatch := s.db.NewUpdateBatch()
+	var purged []*TxPvtEntryInfo
 
 	for _, txid := range txids {
 		// Construct startKey and endKey to do an range query
@@ -401,30 +622,32 @@ func (s *Store) PurgeByTxids(txids []string) error {
 		// write set and the corresponding indexes.
 		for iter.Next() {
 			// For each entry, remove the private read-write set and corresponding indexes
-
-			// Remove private write set
 			compositeKeyPurgeIndexByTxid := iter.Key()
 			// Note: We can create compositeKeyPvtRWSet by just replacing the prefix of compositeKeyPurgeIndexByTxid
 			// with  prwsetPrefix. For code readability and to be expressive, we split and create again.
 			uuid, blockHeight, err := splitCompositeKeyOfPurgeIndexByTxid(compositeKeyPurgeIndexByTxid)
 			if err != nil {
+				iter.Release()
 				return err
 			}
-			compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
-			dbBatch.Delete(compositeKeyPvtRWSet)
-
-			// Remove purge index -- purgeIndexByHeight
-			compositeKeyPurgeIndexByHeight := createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid)
-			dbBatch.Delete(compositeKeyPurgeIndexByHeight)
-
-			// Remove purge index -- purgeIndexByTxid
-			dbBatch.Delete(compositeKeyPurgeIndexByTxid)
+			info, err := unmarshalTxPvtEntryInfo(iter.Value())
+			if err != nil {
+				iter.Release()
+				return err
+			}
+			deleteEntry(dbBatch, txid, uuid, blockHeight, info)
+			purged = append(purged, info)
 		}
 		iter.Release()
 	}
 	// If peer fails before/while writing the batch to golevelDB, these entries will be
 	// removed as per BTL policy later by PurgeBelowHeight()
-	return s.db.WriteBatch(dbBatch, true)
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.release(purged)
+	s.stats.updateEvictions(evictionReasonCommitted, len(purged))
+	return nil
 }
 
 // PurgeBelowHeight removes private write sets at block height lesser than
@@ -436,6 +659,9 @@ func (s *Store) PurgeByTxids(txids []string) error {
 func (s *Store) PurgeBelowHeight(maxBlockNumToRetain uint64) error {
 	logger.Debugf("Purging orphaned private data from transient store received prior to block [%d]", maxBlockNumToRetain)
 
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+
 	// Do a range query with 0 as startKey and maxBlockNumToRetain-1 as endKey
 	startKey := createPurgeIndexByHeightRangeStartKey(0)
 	endKey := createPurgeIndexByHeightRangeEndKey(maxBlockNumToRetain - 1)
@@ -443,35 +669,255 @@ func (s *Store) PurgeBelowHeight(maxBlockNumToRetain uint64) error {
 	if err != nil {
 		return err
 	}
+	defer iter.Release()
 
 	dbBatch := s.db.NewUpdateBatch()
+	var purged []*TxPvtEntryInfo
 
 	// Get all txid and uuid from above result and remove it from transient store (both
 	// write set and the corresponding index.
 	for iter.Next() {
 		// For each entry, remove the private read-write set and corresponding indexes
-
-		// Remove private write set
 		compositeKeyPurgeIndexByHeight := iter.Key()
 		txid, uuid, blockHeight, err := splitCompositeKeyOfPurgeIndexByHeight(compositeKeyPurgeIndexByHeight)
 		if err != nil {
 			return err
 		}
+		info, err := unmarshalTxPvtEntryInfo(iter.Value())
+		if err != nil {
+			return err
+		}
 		logger.Debugf("Purging from transient store private data simulated at block [%d]: txid [%s] uuid [%s]", blockHeight, txid, uuid)
+		deleteEntry(dbBatch, txid, uuid, blockHeight, info)
+		purged = append(purged, info)
+	}
+
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.release(purged)
+	s.stats.updateEvictions(evictionReasonBlockHeight, len(purged))
+	return nil
+}
 
-		compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
-		dbBatch.Delete(compositeKeyPvtRWSet)
+// PurgeExpired removes the private write sets that were persisted earlier than the TTL
+// configured for the transient store. It does nothing if no TTL is configured.
+func (s *Store) PurgeExpired() error {
+	if s.config.TTL <= 0 {
+		return nil
+	}
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
 
-		// Remove purge index -- purgeIndexByTxid
-		compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
-		dbBatch.Delete(compositeKeyPurgeIndexByTxid)
+	expireBefore := s.clock().Add(-s.config.TTL).UnixNano()
+	if expireBefore <= 0 {
+		return nil
+	}
+	logger.Debugf("Purging private data from transient store persisted before [%s]", time.Unix(0, expireBefore))
 
-		// Remove purge index -- purgeIndexByHeight
-		dbBatch.Delete(compositeKeyPurgeIndexByHeight)
+	startKey := []byte{expiryIndexPrefix, compositeKeySep}
+	endKey := createExpiryIndexRangeEndKey(uint64(expireBefore - 1))
+	iter, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return err
 	}
-	iter.Release()
+	defer iter.Release()
 
-	return s.db.WriteBatch(dbBatch, true)
+	dbBatch := s.db.NewUpdateBatch()
+	var purged []*TxPvtEntryInfo
+
+	for iter.Next() {
+		txid, uuid, blockHeight, err := splitCompositeKeyOfExpiryIndex(iter.Key())
+		if err != nil {
+			return err
+		}
+		// The entry info is read from the purge index by txid, which is missing if the entry
+		// has already been purged by txid or by height
+		infoBytes, err := s.db.Get(createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight))
+		if err != nil {
+			return err
+		}
+		if infoBytes == nil {
+			dbBatch.Delete(iter.Key())
+			continue
+		}
+		info, err := unmarshalTxPvtEntryInfo(infoBytes)
+		if err != nil {
+			return err
+		}
+		logger.Debugf("Purging expired private data from transient store: txid [%s] uuid [%s]", txid, uuid)
+		deleteEntry(dbBatch, txid, uuid, blockHeight, info)
+		purged = append(purged, info)
+	}
+
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.release(purged)
+	s.stats.updateEvictions(evictionReasonExpired, len(purged))
+	return nil
+}
+
+// deleteEntry adds to the batch the deletion of a private write set and of its indexes
+func deleteEntry(dbBatch *leveldbhelper.UpdateBatch, txid, uuid string, blockHeight uint64, info *TxPvtEntryInfo) {
+	dbBatch.Delete(createCompositeKeyForPvtRWSet(txid, uuid, blockHeight))
+	dbBatch.Delete(createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid))
+	dbBatch.Delete(createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight))
+	// entries persisted by a previous version of the peer do not have an expiry index
+	if info.PersistedAt != 0 {
+		dbBatch.Delete(createCompositeKeyForExpiryIndex(uint64(info.PersistedAt), blockHeight, txid, uuid))
+	}
+}
+
+func newTxPvtEntryInfo(persistedAt time.Time, value []byte, pvtRWSet *rwset.TxPvtReadWriteSet) *TxPvtEntryInfo {
+	info := &TxPvtEntryInfo{
+		PersistedAt: persistedAt.UnixNano(),
+		Size:        uint64(len(value)),
+	}
+	for _, nsPvtRWSet := range pvtRWSet.GetNsPvtRwset() {
+		for _, collPvtRWSet := range nsPvtRWSet.GetCollectionPvtRwset() {
+			info.CollectionSizes = append(info.CollectionSizes, &CollectionSize{
+				Namespace:  nsPvtRWSet.Namespace,
+				Collection: collPvtRWSet.CollectionName,
+				Size:       uint64(len(collPvtRWSet.Rwset)),
+			})
+		}
+	}
+	return info
+}
+
+// unmarshalTxPvtEntryInfo unmarshals the value of a purge index. The indexes of the entries
+// persisted by a previous version of the peer have an empty value, which results in an empty info.
+func unmarshalTxPvtEntryInfo(value []byte) (*TxPvtEntryInfo, error) {
+	info := &TxPvtEntryInfo{}
+	if err := proto.Unmarshal(value, info); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling the info of a private write set")
+	}
+	return info, nil
+}
+
+// loadUsage computes the size of the private write sets in the store from the purge index by txid
+func (s *Store) loadUsage() error {
+	startKey := []byte{purgeIndexByTxidPrefix, compositeKeySep}
+	endKey := []byte{purgeIndexByTxidPrefix, compositeKeySep + 1}
+	iter, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	var infos []*TxPvtEntryInfo
+	for iter.Next() {
+		info, err := unmarshalTxPvtEntryInfo(iter.Value())
+		if err != nil {
+			return err
+		}
+		infos = append(infos, info)
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrap(err, "error while iterating over the purge index")
+	}
+
+	s.usageLock.Lock()
+	defer s.usageLock.Unlock()
+	for _, info := range infos {
+		s.usage += info.Size
+		for _, c := range info.CollectionSizes {
+			s.collsUsage[nsColl{c.Namespace, c.Collection}] += c.Size
+		}
+	}
+	s.updateUsageStats(infos)
+	return nil
+}
+
+// reserve adds the size of an entry to the usage of the store, unless it exceeds a quota.
+// When a quota would be exceeded and a TTL is configured, the expired entries are purged
+// first to make room for the entry.
+func (s *Store) reserve(info *TxPvtEntryInfo) error {
+	err := s.tryReserve(info)
+	if err == nil || s.config.TTL <= 0 {
+		return s.rejected(err)
+	}
+	if purgeErr := s.PurgeExpired(); purgeErr != nil {
+		logger.Warnw("Failed to purge expired private data from transient store", "ledgerID", s.ledgerID, "error", purgeErr)
+	}
+	return s.rejected(s.tryReserve(info))
+}
+
+func (s *Store) rejected(err error) error {
+	if quotaErr, ok := err.(*QuotaExceededError); ok {
+		quota := quotaCollection
+		if quotaErr.Collection == "" {
+			quota = quotaChannel
+		}
+		s.stats.updateRejections(quota)
+	}
+	return err
+}
+
+func (s *Store) tryReserve(info *TxPvtEntryInfo) error {
+	s.usageLock.Lock()
+	defer s.usageLock.Unlock()
+
+	if quota := s.config.ChannelQuota; quota > 0 && s.usage+info.Size > quota {
+		return &QuotaExceededError{ChannelID: s.ledgerID, Quota: quota, Size: info.Size}
+	}
+	if quota := s.config.CollectionQuota; quota > 0 {
+		collsSize := map[nsColl]uint64{}
+		for _, c := range info.CollectionSizes {
+			collsSize[nsColl{c.Namespace, c.Collection}] += c.Size
+		}
+		for _, c := range info.CollectionSizes {
+			key := nsColl{c.Namespace, c.Collection}
+			if s.collsUsage[key]+collsSize[key] > quota {
+				return &QuotaExceededError{
+					ChannelID:  s.ledgerID,
+					Namespace:  c.Namespace,
+					Collection: c.Collection,
+					Quota:      quota,
+					Size:       collsSize[key],
+				}
+			}
+		}
+	}
+
+	s.usage += info.Size
+	for _, c := range info.CollectionSizes {
+		s.collsUsage[nsColl{c.Namespace, c.Collection}] += c.Size
+	}
+	s.updateUsageStats([]*TxPvtEntryInfo{info})
+	return nil
+}
+
+// release subtracts the size of the given entries from the usage of the store
+func (s *Store) release(infos []*TxPvtEntryInfo) {
+	if len(infos) == 0 {
+		return
+	}
+	s.usageLock.Lock()
+	defer s.usageLock.Unlock()
+	for _, info := range infos {
+		s.usage -= min(info.Size, s.usage)
+		for _, c := range info.CollectionSizes {
+			key := nsColl{c.Namespace, c.Collection}
+			s.collsUsage[key] -= min(c.Size, s.collsUsage[key])
+			if s.collsUsage[key] == 0 {
+				delete(s.collsUsage, key)
+			}
+		}
+	}
+	s.updateUsageStats(infos)
+}
+
+// updateUsageStats reports the usage of the store and of the collections of the given
+// entries. It must be invoked while holding the usageLock.
+func (s *Store) updateUsageStats(infos []*TxPvtEntryInfo) {
+	s.stats.updateSize(s.usage)
+	for _, info := range infos {
+		for _, c := range info.CollectionSizes {
+			s.stats.updateCollectionSize(c.Namespace, c.Collection, s.collsUsage[nsColl{c.Namespace, c.Collection}])
+		}
+	}
 }
 
 // GetMinTransientBlkHt returns the lowest block height remaining in transient store
@@ -514,6 +960,9 @@ func (scanner *RwsetScanner) Next() (*EndorserPvtSimulationResults, error) {
 	if err != nil {
 		return nil, err
 	}
+	if dbVal, err = scanner.encrypter.Decrypt(dbKey, dbVal); err != nil {
+		return nil, err
+	}
 
 	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
 	txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
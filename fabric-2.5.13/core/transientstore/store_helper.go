@@ -20,6 +20,7 @@ var (
 	prwsetPrefix             = []byte("P")[0] // key prefix for storing private write set in transient store.
 	purgeIndexByHeightPrefix = []byte("H")[0] // key prefix for storing index on private write set using received at block height.
 	purgeIndexByTxidPrefix   = []byte("T")[0] // key prefix for storing index on private write set using txid
+	expiryIndexPrefix        = []byte("E")[0] // key prefix for storing index on private write set using the time it was persisted at
 	compositeKeySep          = byte(0x00)
 )
 
@@ -74,6 +75,24 @@ func createCompositeKeyForPurgeIndexByHeight(blockHeight uint64, txid string, uu
 	return compositeKey
 }
 
+// createCompositeKeyForExpiryIndex creates a key to index private write set based on
+// the time it was persisted at such that purge based on time can be achieved. The structure
+// of the key is <expiryIndexPrefix>~persistedAt~blockHeight~txid~uuid.
+func createCompositeKeyForExpiryIndex(persistedAt uint64, blockHeight uint64, txid string, uuid string) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, expiryIndexPrefix)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(persistedAt)...)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(blockHeight)...)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, []byte(txid)...)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, []byte(uuid)...)
+
+	return compositeKey
+}
+
 // splitCompositeKeyOfPvtRWSet splits the compositeKey (<prwsetPrefix>~txid~uuid~blockHeight)
 // into uuid and blockHeight.
 func splitCompositeKeyOfPvtRWSet(compositeKey []byte) (uuid string, blockHeight uint64, err error) {
@@ -100,6 +119,24 @@ func splitCompositeKeyOfPurgeIndexByHeight(compositeKey []byte) (txid string, uu
 	return
 }
 
+// splitCompositeKeyOfExpiryIndex splits the compositeKey (<expiryIndexPrefix>~persistedAt~blockHeight~txid~uuid)
+// into txid, uuid and blockHeight.
+func splitCompositeKeyOfExpiryIndex(compositeKey []byte) (txid string, uuid string, blockHeight uint64, err error) {
+	_, n, err := util.DecodeOrderPreservingVarUint64(compositeKey[2:])
+	if err != nil {
+		return
+	}
+	var m int
+	blockHeight, m, err = util.DecodeOrderPreservingVarUint64(compositeKey[n+3:])
+	if err != nil {
+		return
+	}
+	splits := bytes.Split(compositeKey[n+m+4:], []byte{compositeKeySep})
+	txid = string(splits[0])
+	uuid = string(splits[1])
+	return
+}
+
 // splitCompositeKeyWithoutPrefixForTxid splits the composite key txid~uuid~blockHeight into
 // uuid and blockHeight
 func splitCompositeKeyWithoutPrefixForTxid(compositeKey []byte) (uuid string, blockHeight uint64, err error) {
@@ -155,6 +192,17 @@ func createPurgeIndexByHeightRangeEndKey(blockHeight uint64) []byte {
 	return endKey
 }
 
+// createExpiryIndexRangeEndKey returns a endKey to do a range query on index stored in transient store
+// using the time private write sets were persisted at
+func createExpiryIndexRangeEndKey(persistedAt uint64) []byte {
+	var endKey []byte
+	endKey = append(endKey, expiryIndexPrefix)
+	endKey = append(endKey, compositeKeySep)
+	endKey = append(endKey, util.EncodeOrderPreservingVarUint64(persistedAt)...)
+	endKey = append(endKey, byte(0xff))
+	return endKey
+}
+
 // createPurgeIndexByTxidRangeStartKey returns a startKey to do a range query on index stored in transient store
 // using txid
 func createPurgeIndexByTxidRangeStartKey(txid string) []byte {
@@ -12,16 +12,21 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/policydsl"
 	commonutil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/stretchr/testify/require"
 )
@@ -51,7 +56,7 @@ func initTestEnv(t *testing.T) *testEnv {
 	require.NoErrorf(t, err, "failed to create test directory [%s]", tempdir)
 
 	storedir := filepath.Join(tempdir, "transientstore")
-	storeProvider, err := NewStoreProvider(storedir)
+	storeProvider, err := NewStoreProvider(storedir, nil)
 	require.NoError(t, err)
 	require.NotNil(t, storeProvider)
 
@@ -224,6 +229,286 @@ func TestTransientStorePersistAndRetrieveBothOldAndNewProto(t *testing.T) {
 	require.Equal(expectedEndorsersResults, actualEndorsersResults)
 }
 
+func TestTransientStoreEncryption(t *testing.T) {
+	tempdir := t.TempDir()
+	storedir := filepath.Join(tempdir, "transientstore")
+	csp, err := sw.NewDefaultSecurityLevel(filepath.Join(tempdir, "keystore"))
+	require.NoError(t, err)
+	encryptionProvider, err := pvtdataencryption.NewProvider(csp, true)
+	require.NoError(t, err)
+
+	storeProvider, err := NewStoreProvider(storedir, &Config{EncryptionProvider: encryptionProvider})
+	require.NoError(t, err)
+	testStore, err := storeProvider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	txid := "txid-1"
+	samplePvtRWSet := samplePvtData(t)
+	require.NoError(t, testStore.persistOldProto(txid, 10, samplePvtRWSet))
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	require.NoError(t, testStore.Persist(txid, 10, samplePvtRWSetWithConfig))
+
+	// only the write set that was persisted via Persist is encrypted
+	itr, err := testStore.db.GetIterator(createTxidRangeStartKey(txid), createTxidRangeEndKey(txid))
+	require.NoError(t, err)
+	numEncrypted := 0
+	for itr.Next() {
+		if pvtdataencryption.IsEncrypted(itr.Value()) {
+			require.NotContains(t, string(itr.Value()), "RandomBytes-PvtRWSet")
+			numEncrypted++
+		}
+	}
+	itr.Release()
+	require.Equal(t, 1, numEncrypted)
+
+	expectedResults := []*EndorserPvtSimulationResults{
+		{
+			ReceivedAtBlockHeight:          10,
+			PvtSimulationResultsWithConfig: &transientstore.TxPvtReadWriteSetWithConfigInfo{PvtRwset: samplePvtRWSet},
+		},
+		{
+			ReceivedAtBlockHeight:          10,
+			PvtSimulationResultsWithConfig: samplePvtRWSetWithConfig,
+		},
+	}
+	verifyResults := func(testStore *Store) {
+		iter, err := testStore.GetTxPvtRWSetByTxid(txid, nil)
+		require.NoError(t, err)
+		defer iter.Close()
+		var actualResults []*EndorserPvtSimulationResults
+		for {
+			result, err := iter.Next()
+			require.NoError(t, err)
+			if result == nil {
+				break
+			}
+			actualResults = append(actualResults, result)
+		}
+		sortResults(expectedResults)
+		sortResults(actualResults)
+		require.Equal(t, expectedResults, actualResults)
+	}
+	verifyResults(testStore)
+
+	t.Run("rotation requires the peer to be stopped", func(t *testing.T) {
+		err := RotateEncryptionKeys(storedir, []string{"TestStore"}, encryptionProvider)
+		require.EqualError(t, err, "as another peer node command is executing, wait for that command to complete its execution or terminate it before retrying")
+	})
+	storeProvider.Close()
+
+	require.NoError(t, RotateEncryptionKeys(storedir, []string{"TestStore", "NeverEncryptedStore"}, encryptionProvider))
+
+	storeProvider, err = NewStoreProvider(storedir, &Config{EncryptionProvider: encryptionProvider})
+	require.NoError(t, err)
+	testStore, err = storeProvider.OpenStore("TestStore")
+	require.NoError(t, err)
+	verifyResults(testStore)
+	storeProvider.Close()
+
+	t.Run("encrypted data without keys", func(t *testing.T) {
+		storeProvider, err := NewStoreProvider(storedir, nil)
+		require.NoError(t, err)
+		defer storeProvider.Close()
+		testStore, err := storeProvider.OpenStore("TestStore")
+		require.NoError(t, err)
+		iter, err := testStore.GetTxPvtRWSetByTxid(txid, nil)
+		require.NoError(t, err)
+		defer iter.Close()
+		var errs []string
+		for {
+			result, err := iter.Next()
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			if result == nil {
+				break
+			}
+		}
+		require.Equal(t, []string{"encountered an encrypted value but no encryption keys are available"}, errs)
+	})
+}
+
+func TestTransientStoreExpiry(t *testing.T) {
+	storedir := filepath.Join(t.TempDir(), "transientstore")
+	fakeProvider := &metricsfakes.Provider{}
+	fakeEvictions := &metricsfakes.Counter{}
+	fakeEvictions.WithReturns(fakeEvictions)
+	fakeProvider.NewCounterReturns(fakeEvictions)
+	fakeSize := &metricsfakes.Gauge{}
+	fakeSize.WithReturns(fakeSize)
+	fakeProvider.NewGaugeReturns(fakeSize)
+
+	sp, err := NewStoreProvider(storedir, &Config{TTL: time.Hour, MetricsProvider: fakeProvider})
+	require.NoError(t, err)
+	defer sp.Close()
+	now := time.Unix(1000000, 0)
+	sp.(*storeProvider).clock = func() time.Time { return now }
+	testStore, err := sp.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	require.NoError(t, testStore.persistOldProto("txid-legacy", 10, samplePvtData(t)))
+	require.NoError(t, testStore.Persist("txid-1", 10, samplePvtDataWithConfigInfo(t)))
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, testStore.Persist("txid-2", 10, samplePvtDataWithConfigInfo(t)))
+	require.NoError(t, testStore.Persist("txid-3", 10, samplePvtDataWithConfigInfo(t)))
+	require.NoError(t, testStore.PurgeByTxids([]string{"txid-3"}))
+
+	countResults := func(txid string) int {
+		iter, err := testStore.GetTxPvtRWSetByTxid(txid, nil)
+		require.NoError(t, err)
+		defer iter.Close()
+		count := 0
+		for {
+			result, err := iter.Next()
+			require.NoError(t, err)
+			if result == nil {
+				return count
+			}
+			count++
+		}
+	}
+
+	now = now.Add(31 * time.Minute)
+	require.NoError(t, testStore.PurgeExpired())
+	require.Equal(t, 0, countResults("txid-1"))
+	require.Equal(t, 1, countResults("txid-2"))
+	// the private write sets persisted by a previous version of the peer are only purged by height
+	require.Equal(t, 1, countResults("txid-legacy"))
+	require.Equal(t, 2, fakeEvictions.AddCallCount())
+	require.Equal(t, []string{"channel", "TestStore", "reason", "expired"}, fakeEvictions.WithArgsForCall(fakeEvictions.WithCallCount()-1))
+	require.Equal(t, float64(1), fakeEvictions.AddArgsForCall(1))
+
+	// the expiry index of the purged entries is removed as well
+	itr, err := testStore.db.GetIterator([]byte{expiryIndexPrefix, compositeKeySep}, createExpiryIndexRangeEndKey(uint64(now.UnixNano())))
+	require.NoError(t, err)
+	numExpiryKeys := 0
+	for itr.Next() {
+		numExpiryKeys++
+	}
+	itr.Release()
+	require.Equal(t, 1, numExpiryKeys)
+
+	t.Run("background purge", func(t *testing.T) {
+		storedir := filepath.Join(t.TempDir(), "transientstore")
+		sp, err := newStoreProviderWithExpiryCheckInterval(storedir, &Config{TTL: time.Hour}, 10*time.Millisecond)
+		require.NoError(t, err)
+		defer sp.Close()
+		var lock sync.Mutex
+		now := time.Unix(1000000, 0)
+		sp.clock = func() time.Time {
+			lock.Lock()
+			defer lock.Unlock()
+			return now
+		}
+		testStore, err := sp.OpenStore("TestStore")
+		require.NoError(t, err)
+		require.NoError(t, testStore.Persist("txid-1", 10, samplePvtDataWithConfigInfo(t)))
+		_, err = testStore.GetMinTransientBlkHt()
+		require.NoError(t, err)
+
+		lock.Lock()
+		now = now.Add(2 * time.Hour)
+		lock.Unlock()
+		require.Eventually(t, func() bool {
+			_, err := testStore.GetMinTransientBlkHt()
+			return err == ErrStoreEmpty
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func newStoreProviderWithExpiryCheckInterval(path string, conf *Config, interval time.Duration) (*storeProvider, error) {
+	saved := expiryCheckInterval
+	expiryCheckInterval = interval
+	defer func() { expiryCheckInterval = saved }()
+	sp, err := NewStoreProvider(path, conf)
+	if err != nil {
+		return nil, err
+	}
+	return sp.(*storeProvider), nil
+}
+
+func TestTransientStoreQuotas(t *testing.T) {
+	storedir := filepath.Join(t.TempDir(), "transientstore")
+	fakeProvider := &metricsfakes.Provider{}
+	fakeRejections := &metricsfakes.Counter{}
+	fakeRejections.WithReturns(fakeRejections)
+	fakeProvider.NewCounterReturns(fakeRejections)
+	fakeSize := &metricsfakes.Gauge{}
+	fakeSize.WithReturns(fakeSize)
+	fakeProvider.NewGaugeReturns(fakeSize)
+
+	// each collection of the sample private data has 30 bytes
+	conf := &Config{
+		CollectionQuota: 70,
+		ChannelQuota:    2000,
+		MetricsProvider: fakeProvider,
+	}
+	sp, err := NewStoreProvider(storedir, conf)
+	require.NoError(t, err)
+	testStore, err := sp.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	require.NoError(t, testStore.Persist("txid-1", 10, samplePvtDataWithConfigInfo(t)))
+	require.NoError(t, testStore.Persist("txid-2", 10, samplePvtDataWithConfigInfo(t)))
+	err = testStore.Persist("txid-3", 10, samplePvtDataWithConfigInfo(t))
+	require.IsType(t, &QuotaExceededError{}, err)
+	require.EqualError(t, err, "transient store quota of collection [ns-1:coll-1] in channel [TestStore] exceeded: the private data of [30] bytes does not fit in the quota of [70] bytes")
+	require.Equal(t, 1, fakeRejections.AddCallCount())
+	require.Equal(t, []string{"channel", "TestStore", "quota", "collection"}, fakeRejections.WithArgsForCall(fakeRejections.WithCallCount()-1))
+
+	// the rejected private write set is not persisted
+	iter, err := testStore.GetTxPvtRWSetByTxid("txid-3", nil)
+	require.NoError(t, err)
+	result, err := iter.Next()
+	require.NoError(t, err)
+	require.Nil(t, result)
+	iter.Close()
+
+	// the usage is restored when the store is reopened
+	sp.Close()
+	sp, err = NewStoreProvider(storedir, conf)
+	require.NoError(t, err)
+	defer sp.Close()
+	testStore, err = sp.OpenStore("TestStore")
+	require.NoError(t, err)
+	require.IsType(t, &QuotaExceededError{}, testStore.Persist("txid-3", 10, samplePvtDataWithConfigInfo(t)))
+	require.Equal(t, uint64(60), testStore.collsUsage[nsColl{"ns-1", "coll-1"}])
+
+	// purging frees the quota
+	require.NoError(t, testStore.PurgeByTxids([]string{"txid-1"}))
+	require.Equal(t, uint64(30), testStore.collsUsage[nsColl{"ns-1", "coll-1"}])
+	require.NoError(t, testStore.Persist("txid-3", 10, samplePvtDataWithConfigInfo(t)))
+	require.NoError(t, testStore.PurgeBelowHeight(11))
+	require.Zero(t, testStore.usage)
+	require.Empty(t, testStore.collsUsage)
+	lastSize := -1.0
+	for i := 0; i < fakeSize.WithCallCount(); i++ {
+		if len(fakeSize.WithArgsForCall(i)) == 2 {
+			lastSize = fakeSize.SetArgsForCall(i)
+		}
+	}
+	require.Equal(t, float64(0), lastSize)
+
+	t.Run("channel quota", func(t *testing.T) {
+		testStore.config = &Config{ChannelQuota: testStore.usage + 100}
+		err := testStore.Persist("txid-4", 10, samplePvtDataWithConfigInfo(t))
+		require.IsType(t, &QuotaExceededError{}, err)
+		require.Regexp(t, `^transient store quota of channel \[TestStore\] exceeded: the private write set of \[\d+\] bytes does not fit in the quota of \[100\] bytes$`, err.Error())
+		require.Equal(t, []string{"channel", "TestStore", "quota", "channel"}, fakeRejections.WithArgsForCall(fakeRejections.WithCallCount()-1))
+	})
+
+	t.Run("expired entries make room", func(t *testing.T) {
+		now := time.Unix(1000000, 0)
+		testStore.clock = func() time.Time { return now }
+		testStore.config = &Config{CollectionQuota: 30, TTL: time.Minute}
+		require.NoError(t, testStore.Persist("txid-5", 10, samplePvtDataWithConfigInfo(t)))
+		require.IsType(t, &QuotaExceededError{}, testStore.Persist("txid-6", 10, samplePvtDataWithConfigInfo(t)))
+		now = now.Add(2 * time.Minute)
+		require.NoError(t, testStore.Persist("txid-6", 10, samplePvtDataWithConfigInfo(t)))
+	})
+}
+
 func TestTransientStorePurgeByTxids(t *testing.T) {
 	env := initTestEnv(t)
 	defer env.cleanup()
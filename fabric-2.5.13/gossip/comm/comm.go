@@ -50,6 +50,9 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// MessageStats returns the number of messages sent, received and dropped, per message type
+	MessageStats() []MessageStats
+
 	// Stop stops the module
 	Stop()
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
 
@@ -75,16 +76,21 @@ func NewCommInstance(s *grpc.Server, certs *common.TLSCertificates, idStore iden
 		exitChan:        make(chan struct{}),
 		subscriptions:   make([]chan protoext.ReceivedMessage, 0),
 		tlsCerts:        certs,
-		metrics:         commMetrics,
+		msgStats:        newMessageStats(commMetrics),
 		dialTimeout:     config.DialTimeout,
 		connTimeout:     config.ConnTimeout,
 		recvBuffSize:    config.RecvBuffSize,
 		sendBuffSize:    config.SendBuffSize,
+		compression:     config.CompressionEnabled,
+		compressionMin:  config.CompressionMinSize,
+		maxRecvMsgSize:  config.MaxRecvMsgSize,
 	}
 
 	connConfig := ConnConfig{
-		RecvBuffSize: config.RecvBuffSize,
-		SendBuffSize: config.SendBuffSize,
+		RecvBuffSize:       config.RecvBuffSize,
+		SendBuffSize:       config.SendBuffSize,
+		CompressionMinSize: config.CompressionMinSize,
+		MaxRecvMsgSize:     config.MaxRecvMsgSize,
 	}
 
 	commInst.connStore = newConnStore(commInst, commInst.logger, connConfig)
@@ -100,6 +106,10 @@ type CommConfig struct {
 	ConnTimeout  time.Duration // Connection timeout
 	RecvBuffSize int           // Buffer size of received messages
 	SendBuffSize int           // Buffer size of sending messages
+
+	CompressionEnabled bool // Whether to negotiate compression with remote peers
+	CompressionMinSize int  // Minimum payload size of a message to be compressed
+	MaxRecvMsgSize     int  // Maximum size of a received message, once decompressed
 }
 
 type commImpl struct {
@@ -121,11 +131,14 @@ type commImpl struct {
 	stopWG          sync.WaitGroup
 	subscriptions   []chan protoext.ReceivedMessage
 	stopping        int32
-	metrics         *metrics.CommMetrics
+	msgStats        *messageStats
 	dialTimeout     time.Duration
 	connTimeout     time.Duration
 	recvBuffSize    int
 	sendBuffSize    int
+	compression     bool
+	compressionMin  int
+	maxRecvMsgSize  int
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -163,7 +176,11 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 	}
 
 	ctx, cancel = context.WithCancel(context.Background())
-	if stream, err = cl.GossipStream(ctx); err == nil {
+	streamCtx := ctx
+	if c.compression {
+		streamCtx = withCapabilities(ctx, gzipCompressionCapability)
+	}
+	if stream, err = cl.GossipStream(streamCtx); err == nil {
 		connInfo, err = c.authenticateRemotePeer(stream, true, false)
 		if err == nil {
 			pkiID = connInfo.ID
@@ -185,10 +202,18 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 				}
 			}
 			connConfig := ConnConfig{
-				RecvBuffSize: c.recvBuffSize,
-				SendBuffSize: c.sendBuffSize,
+				RecvBuffSize:       c.recvBuffSize,
+				SendBuffSize:       c.sendBuffSize,
+				CompressionMinSize: c.compressionMin,
+				MaxRecvMsgSize:     c.maxRecvMsgSize,
+			}
+			conn := newConnection(cl, cc, stream, c.msgStats, connConfig)
+			if c.compression {
+				// The remote peer advertises its capabilities in the header it sent
+				// along with its handshake message, so this doesn't block
+				header, err := stream.Header()
+				conn.compress = err == nil && hasCapability(header, gzipCompressionCapability)
 			}
-			conn := newConnection(cl, cc, stream, c.metrics, connConfig)
 			conn.pkiID = pkiID
 			conn.info = connInfo
 			conn.logger = c.logger
@@ -390,6 +415,12 @@ func (c *commImpl) Stop() {
 	c.closeSubscriptions()
 }
 
+// MessageStats returns the number of messages sent, received and dropped
+// by this instance, per message type
+func (c *commImpl) MessageStats() []MessageStats {
+	return c.msgStats.snapshot()
+}
+
 func (c *commImpl) GetPKIid() common.PKIidType {
 	return c.PKIID
 }
@@ -561,6 +592,18 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	if c.isStopping() {
 		return errors.New("shutting down")
 	}
+
+	// Compression is used only if the remote peer advertises it, and
+	// the remote peer uses it only if we advertise it in return
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	compress := c.compression && hasCapability(md, gzipCompressionCapability)
+	if compress {
+		if err := stream.SetHeader(capabilitiesMetadata(gzipCompressionCapability)); err != nil {
+			c.logger.Warningf("Failed advertising capabilities to %s: %v", extractRemoteAddress(stream), err)
+			compress = false
+		}
+	}
+
 	connInfo, err := c.authenticateRemotePeer(stream, false, false)
 
 	if err == errProbe {
@@ -574,7 +617,7 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	}
 	c.logger.Debug("Servicing", extractRemoteAddress(stream))
 
-	conn := c.connStore.onConnected(stream, connInfo, c.metrics)
+	conn := c.connStore.onConnected(stream, connInfo, c.msgStats, compress)
 
 	h := func(m *protoext.SignedGossipMessage) {
 		c.msgPublisher.DeMultiplex(&ReceivedMessageImpl{
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// capabilitiesMetadataKey is the gRPC metadata key of the gossip stream under which
+	// peers advertise their capabilities upon connection establishment. Peers which do
+	// not advertise a capability are never sent messages that require it, hence peers
+	// that are not aware of the capabilities keep working.
+	capabilitiesMetadataKey = "gossip-capabilities"
+
+	// gzipCompressionCapability is advertised by peers that compress messages with gzip
+	gzipCompressionCapability = "compression/gzip"
+
+	// DefCompressionMinSize is the default minimum payload size of a message to be compressed
+	DefCompressionMinSize = 64 * 1024
+
+	// compressedPayloadPrefix is the first byte of a compressed envelope payload.
+	// A marshaled GossipMessage never starts with a zero byte, as zero isn't a
+	// valid protobuf field tag.
+	compressedPayloadPrefix byte = 0
+
+	// DefMaxRecvMsgSize is the default maximum size of a message received from a remote peer,
+	// which bounds the size of a decompressed payload as well
+	DefMaxRecvMsgSize = 100 * 1024 * 1024
+)
+
+// withCapabilities returns a context which advertises the given capabilities
+// in the metadata of a gossip stream created with it
+func withCapabilities(ctx context.Context, capabilities ...string) context.Context {
+	for _, capability := range capabilities {
+		ctx = metadata.AppendToOutgoingContext(ctx, capabilitiesMetadataKey, capability)
+	}
+	return ctx
+}
+
+// capabilitiesMetadata returns the metadata which advertises the given capabilities
+func capabilitiesMetadata(capabilities ...string) metadata.MD {
+	return metadata.MD{capabilitiesMetadataKey: capabilities}
+}
+
+// hasCapability returns whether the given metadata advertises the given capability
+func hasCapability(md metadata.MD, capability string) bool {
+	for _, c := range md.Get(capabilitiesMetadataKey) {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// isCompressible returns whether messages of the given type are worth compressing,
+// namely messages that carry blocks or private data
+func isCompressible(msg *proto.GossipMessage) bool {
+	return msg.GetDataMsg() != nil || msg.GetStateResponse() != nil ||
+		msg.GetPrivateData() != nil || msg.GetPrivateRes() != nil
+}
+
+// compressEnvelope returns a copy of the given envelope with a compressed payload, or
+// the envelope itself if compressing the payload doesn't reduce its size. The signature
+// of the envelope remains valid, as it is verified over the decompressed payload.
+func compressEnvelope(envelope *proto.Envelope) (*proto.Envelope, error) {
+	buff := &bytes.Buffer{}
+	buff.WriteByte(compressedPayloadPrefix)
+	w := gzip.NewWriter(buff)
+	if _, err := w.Write(envelope.Payload); err != nil {
+		return nil, errors.Wrap(err, "failed compressing payload")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed compressing payload")
+	}
+	if buff.Len() >= len(envelope.Payload) {
+		return envelope, nil
+	}
+	return &proto.Envelope{
+		Payload:        buff.Bytes(),
+		Signature:      envelope.Signature,
+		SecretEnvelope: envelope.SecretEnvelope,
+	}, nil
+}
+
+// decompressEnvelope returns a copy of the given envelope with a decompressed payload
+// if its payload is compressed, or the envelope itself otherwise. The decompressed
+// payload may not exceed maxSize bytes.
+func decompressEnvelope(envelope *proto.Envelope, maxSize int) (*proto.Envelope, error) {
+	if len(envelope.Payload) == 0 || envelope.Payload[0] != compressedPayloadPrefix {
+		return envelope, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(envelope.Payload[1:]))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decompressing payload")
+	}
+	defer r.Close()
+	payload, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decompressing payload")
+	}
+	if len(payload) > maxSize {
+		return nil, errors.Errorf("decompressed payload exceeds %d bytes", maxSize)
+	}
+	return &proto.Envelope{
+		Payload:        payload,
+		Signature:      envelope.Signature,
+		SecretEnvelope: envelope.SecretEnvelope,
+	}, nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/common/flogging"
+	gmocks "github.com/hyperledger/fabric/gossip/comm/mocks"
+	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCompressEnvelope(t *testing.T) {
+	payload := bytes.Repeat([]byte("block"), 1000)
+	envelope := &proto.Envelope{
+		Payload:        payload,
+		Signature:      []byte("signature"),
+		SecretEnvelope: &proto.SecretEnvelope{Payload: []byte("secret")},
+	}
+
+	compressed, err := compressEnvelope(envelope)
+	require.NoError(t, err)
+	require.Equal(t, compressedPayloadPrefix, compressed.Payload[0])
+	require.Less(t, len(compressed.Payload), len(payload))
+	require.Equal(t, envelope.Signature, compressed.Signature)
+	require.Equal(t, envelope.SecretEnvelope, compressed.SecretEnvelope)
+
+	decompressed, err := decompressEnvelope(compressed, DefMaxRecvMsgSize)
+	require.NoError(t, err)
+	require.Equal(t, envelope, decompressed)
+
+	// Payloads that don't shrink are sent as is
+	incompressible := &proto.Envelope{Payload: []byte{1, 2, 3}}
+	compressed, err = compressEnvelope(incompressible)
+	require.NoError(t, err)
+	require.Same(t, incompressible, compressed)
+
+	// Uncompressed payloads are received as is
+	decompressed, err = decompressEnvelope(incompressible, DefMaxRecvMsgSize)
+	require.NoError(t, err)
+	require.Same(t, incompressible, decompressed)
+
+	_, err = decompressEnvelope(&proto.Envelope{Payload: []byte{compressedPayloadPrefix, 1, 2, 3}}, DefMaxRecvMsgSize)
+	require.EqualError(t, err, "failed decompressing payload: unexpected EOF")
+}
+
+func TestDecompressEnvelopeTooLarge(t *testing.T) {
+	buff := &bytes.Buffer{}
+	buff.WriteByte(compressedPayloadPrefix)
+	w := gzip.NewWriter(buff)
+	_, err := w.Write(make([]byte, 1025))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = decompressEnvelope(&proto.Envelope{Payload: buff.Bytes()}, 1025)
+	require.NoError(t, err)
+	_, err = decompressEnvelope(&proto.Envelope{Payload: buff.Bytes()}, 1024)
+	require.EqualError(t, err, "decompressed payload exceeds 1024 bytes")
+}
+
+func TestCapabilities(t *testing.T) {
+	md := capabilitiesMetadata(gzipCompressionCapability)
+	require.True(t, hasCapability(md, gzipCompressionCapability))
+	require.False(t, hasCapability(md, "compression/zstd"))
+	require.False(t, hasCapability(nil, gzipCompressionCapability))
+
+	ctx := withCapabilities(context.Background(), gzipCompressionCapability)
+	md, _ = metadata.FromOutgoingContext(ctx)
+	require.True(t, hasCapability(md, gzipCompressionCapability))
+}
+
+func newCommInstanceWithCompression(t *testing.T, compressionEnabled bool) (*commGRPC, int) {
+	port, gRPCServer, certs, secureDialOpts, dialOpts := util.CreateGRPCLayer()
+	_, portString, err := net.SplitHostPort(gRPCServer.Address())
+	require.NoError(t, err)
+
+	id := []byte(fmt.Sprintf("127.0.0.1:%s", portString))
+	identityMapper := identity.NewIdentityMapper(naiveSec, id, noopPurgeIdentity, naiveSec)
+
+	config := testCommConfig
+	config.CompressionEnabled = compressionEnabled
+	config.CompressionMinSize = 1024
+	commInst, err := NewCommInstance(gRPCServer.Server(), certs, identityMapper, id, secureDialOpts,
+		naiveSec, disabledMetrics, config, dialOpts...)
+	require.NoError(t, err)
+
+	go func() {
+		err := gRPCServer.Start()
+		require.NoError(t, err)
+	}()
+
+	return &commGRPC{commInst.(*commImpl), gRPCServer}, port
+}
+
+func createDataMsg(size int) *protoext.SignedGossipMessage {
+	msg, _ := protoext.NoopSign(&proto.GossipMessage{
+		Tag:   proto.GossipMessage_EMPTY,
+		Nonce: uint64(r.Int()),
+		Content: &proto.GossipMessage_DataMsg{
+			DataMsg: &proto.DataMessage{
+				Payload: &proto.Payload{
+					SeqNum: 1,
+					Data:   bytes.Repeat([]byte("block"), size/5),
+				},
+			},
+		},
+	})
+	return msg
+}
+
+func connCompressed(c *commGRPC, port int) bool {
+	c.connStore.RLock()
+	defer c.connStore.RUnlock()
+	conn, exists := c.connStore.pki2Conn[string(remotePeer(port).PKIID)]
+	return exists && conn.compress
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	for _, test := range []struct {
+		name                string
+		enabled1, enabled2  bool
+		expectedCompression bool
+	}{
+		{name: "both enabled", enabled1: true, enabled2: true, expectedCompression: true},
+		{name: "initiator disabled", enabled1: false, enabled2: true},
+		{name: "responder disabled", enabled1: true, enabled2: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			comm1, port1 := newCommInstanceWithCompression(t, test.enabled1)
+			defer comm1.Stop()
+			comm2, port2 := newCommInstanceWithCompression(t, test.enabled2)
+			defer comm2.Stop()
+
+			inc1 := comm1.Accept(acceptAll)
+			inc2 := comm2.Accept(acceptAll)
+
+			largeMsg := createDataMsg(64 * 1024)
+			comm1.Send(largeMsg, remotePeer(port2))
+			select {
+			case m := <-inc2:
+				require.Equal(t, largeMsg.Envelope.Payload, m.GetSourceEnvelope().Payload)
+				require.Equal(t, largeMsg.GossipMessage.GetDataMsg().Payload.Data, m.GetGossipMessage().GetDataMsg().Payload.Data)
+			case <-time.After(10 * time.Second):
+				t.Fatal("Didn't receive message")
+			}
+
+			comm2.Send(largeMsg, remotePeer(port1))
+			select {
+			case m := <-inc1:
+				require.Equal(t, largeMsg.Envelope.Payload, m.GetSourceEnvelope().Payload)
+			case <-time.After(10 * time.Second):
+				t.Fatal("Didn't receive message")
+			}
+
+			require.Equal(t, test.expectedCompression, connCompressed(comm1, port2))
+			require.Equal(t, test.expectedCompression, connCompressed(comm2, port1))
+		})
+	}
+}
+
+func TestMaybeCompress(t *testing.T) {
+	conn := newConnection(nil, nil, nil, newMessageStats(disabledMetrics), ConnConfig{CompressionMinSize: 1024})
+
+	largeMsg := createDataMsg(64 * 1024)
+	smallMsg := createDataMsg(100)
+	aliveMsg, _ := protoext.NoopSign(&proto.GossipMessage{
+		Content: &proto.GossipMessage_AliveMsg{
+			AliveMsg: &proto.AliveMessage{
+				Identity: bytes.Repeat([]byte("identity"), 1024),
+			},
+		},
+	})
+
+	// Compression was not negotiated
+	require.Same(t, largeMsg.Envelope, conn.maybeCompress(largeMsg))
+
+	conn.compress = true
+	require.Less(t, len(conn.maybeCompress(largeMsg).Payload), len(largeMsg.Envelope.Payload))
+	require.Same(t, smallMsg.Envelope, conn.maybeCompress(smallMsg))
+	require.Same(t, aliveMsg.Envelope, conn.maybeCompress(aliveMsg))
+}
+
+func TestReadFromStreamDecompression(t *testing.T) {
+	msg := createDataMsg(64 * 1024)
+	compressed, err := compressEnvelope(msg.Envelope)
+	require.NoError(t, err)
+
+	read := func(compress bool, maxRecvMsgSize int) (*protoext.SignedGossipMessage, error) {
+		stream := &gmocks.MockStream{}
+		stream.On("Recv").Return(compressed, nil).Once()
+		stream.On("Recv").Return(nil, errors.New("stream closed")).Once()
+		conn := newConnection(nil, nil, stream, newMessageStats(disabledMetrics), ConnConfig{MaxRecvMsgSize: maxRecvMsgSize})
+		conn.logger = flogging.MustGetLogger("test")
+		conn.compress = compress
+
+		errChan := make(chan error, 2)
+		msgChan := make(chan *protoext.SignedGossipMessage, 1)
+		conn.readFromStream(errChan, msgChan)
+		select {
+		case m := <-msgChan:
+			return m, nil
+		default:
+			return nil, <-errChan
+		}
+	}
+
+	m, err := read(true, 0)
+	require.NoError(t, err)
+	require.Equal(t, msg.Envelope.Payload, m.Envelope.Payload)
+
+	// the decompressed payload is bounded by the maximum size of a received message
+	_, err = read(true, 1024)
+	require.EqualError(t, err, "decompressed payload exceeds 1024 bytes")
+
+	// a compressed payload is not decompressed if compression was not negotiated
+	_, err = read(false, 0)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "stream closed")
+}
@@ -9,10 +9,10 @@ package comm
 import (
 	"context"
 	"sync"
+	"time"
 
 	proto "github.com/hyperledger/fabric-protos-go/gossip"
 	"github.com/hyperledger/fabric/gossip/common"
-	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/protoext"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/pkg/errors"
@@ -161,7 +161,7 @@ func (cs *connectionStore) shutdown() {
 // onConnected closes any connection to the remote peer and creates a new connection object to it in order to have only
 // one single bi-directional connection between a pair of peers
 func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamServer,
-	connInfo *protoext.ConnectionInfo, metrics *metrics.CommMetrics) *connection {
+	connInfo *protoext.ConnectionInfo, stats *messageStats, compress bool) *connection {
 	cs.Lock()
 	defer cs.Unlock()
 
@@ -169,7 +169,8 @@ func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamSer
 		c.close()
 	}
 
-	conn := newConnection(nil, nil, serverStream, metrics, cs.config)
+	conn := newConnection(nil, nil, serverStream, stats, cs.config)
+	conn.compress = compress
 	conn.pkiID = connInfo.ID
 	conn.info = connInfo
 	conn.logger = cs.logger
@@ -186,15 +187,21 @@ func (cs *connectionStore) closeConnByPKIid(pkiID common.PKIidType) {
 	}
 }
 
-func newConnection(cl proto.GossipClient, c *grpc.ClientConn, s stream, metrics *metrics.CommMetrics, config ConnConfig) *connection {
+func newConnection(cl proto.GossipClient, c *grpc.ClientConn, s stream, stats *messageStats, config ConnConfig) *connection {
 	connection := &connection{
-		metrics:      metrics,
+		stats:        stats,
 		outBuff:      make(chan *msgSending, config.SendBuffSize),
 		cl:           cl,
 		conn:         c,
 		gossipStream: s,
 		stopChan:     make(chan struct{}, 1),
 		recvBuffSize: config.RecvBuffSize,
+
+		compressionMinSize: config.CompressionMinSize,
+		maxRecvMsgSize:     config.MaxRecvMsgSize,
+	}
+	if connection.maxRecvMsgSize <= 0 {
+		connection.maxRecvMsgSize = DefMaxRecvMsgSize
 	}
 	return connection
 }
@@ -203,11 +210,17 @@ func newConnection(cl proto.GossipClient, c *grpc.ClientConn, s stream, metrics
 type ConnConfig struct {
 	RecvBuffSize int
 	SendBuffSize int
+	// CompressionMinSize is the minimum payload size of a message
+	// to be compressed, if compression was negotiated with the remote peer
+	CompressionMinSize int
+	// MaxRecvMsgSize is the maximum size of a message received from the remote peer,
+	// which bounds the size of a decompressed payload as well
+	MaxRecvMsgSize int
 }
 
 type connection struct {
 	recvBuffSize int
-	metrics      *metrics.CommMetrics
+	stats        *messageStats
 	cancel       context.CancelFunc
 	info         *protoext.ConnectionInfo
 	outBuff      chan *msgSending
@@ -219,6 +232,10 @@ type connection struct {
 	gossipStream stream             // there can only be one
 	stopChan     chan struct{}      // a method to stop the server-side gRPC call from a different go-routine
 	stopOnce     sync.Once          // once to ensure close is called only once
+
+	compress           bool // whether compression was negotiated with the remote peer
+	compressionMinSize int  // minimum payload size of a message to be compressed
+	maxRecvMsgSize     int  // maximum size of a received message, once decompressed
 }
 
 func (conn *connection) close() {
@@ -237,8 +254,10 @@ func (conn *connection) close() {
 
 func (conn *connection) send(msg *protoext.SignedGossipMessage, onErr func(error), shouldBlock blockingBehavior) {
 	m := &msgSending{
-		envelope: msg.Envelope,
+		envelope: conn.maybeCompress(msg),
 		onErr:    onErr,
+		msgType:  protoext.MessageType(msg.GossipMessage),
+		queuedAt: time.Now(),
 	}
 
 	select {
@@ -253,12 +272,26 @@ func (conn *connection) send(msg *protoext.SignedGossipMessage, onErr func(error
 			case <-conn.stopChan: // stop blocking if the connection is closing
 			}
 		} else {
-			conn.metrics.BufferOverflow.Add(1)
+			conn.stats.dropped(m.msgType, dropReasonBufferOverflow)
 			conn.logger.Debugf("Buffer to %s overflowed, dropping message %s", conn.info.Endpoint, msg)
 		}
 	}
 }
 
+// maybeCompress returns the envelope of the given message with a compressed payload, if
+// compression was negotiated with the remote peer and the message is worth compressing
+func (conn *connection) maybeCompress(msg *protoext.SignedGossipMessage) *proto.Envelope {
+	if !conn.compress || len(msg.Envelope.Payload) < conn.compressionMinSize || !isCompressible(msg.GossipMessage) {
+		return msg.Envelope
+	}
+	envelope, err := compressEnvelope(msg.Envelope)
+	if err != nil {
+		conn.logger.Warningf("Sending message %s to %s uncompressed: %v", msg, conn.info.Endpoint, err)
+		return msg.Envelope
+	}
+	return envelope
+}
+
 func (conn *connection) serviceConnection() error {
 	errChan := make(chan error, 1)
 	msgChan := make(chan *protoext.SignedGossipMessage, conn.recvBuffSize)
@@ -291,10 +324,11 @@ func (conn *connection) writeToStream() {
 		case m := <-conn.outBuff:
 			err := stream.Send(m.envelope)
 			if err != nil {
+				conn.stats.dropped(m.msgType, dropReasonSendFailure)
 				go m.onErr(err)
 				return
 			}
-			conn.metrics.SentMessages.Add(1)
+			conn.stats.sent(m.msgType, time.Since(m.queuedAt))
 		case <-conn.stopChan:
 			conn.logger.Debug("Closing writing to stream")
 			return
@@ -315,13 +349,24 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *protoex
 				conn.logger.Debugf("Got error, aborting: %v", err)
 				return
 			}
-			conn.metrics.ReceivedMessages.Add(1)
+			// a peer that did not negotiate compression sends its messages uncompressed
+			if conn.compress {
+				envelope, err = decompressEnvelope(envelope, conn.maxRecvMsgSize)
+				if err != nil {
+					conn.stats.received(malformedMessageType)
+					errChan <- err
+					conn.logger.Warningf("Got error, aborting: %v", err)
+					return
+				}
+			}
 			msg, err := protoext.EnvelopeToGossipMessage(envelope)
 			if err != nil {
+				conn.stats.received(malformedMessageType)
 				errChan <- err
 				conn.logger.Warningf("Got error, aborting: %v", err)
 				return
 			}
+			conn.stats.received(protoext.MessageType(msg.GossipMessage))
 			select {
 			case <-conn.stopChan:
 			case msgChan <- msg:
@@ -333,6 +378,8 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *protoex
 type msgSending struct {
 	envelope *proto.Envelope
 	onErr    func(error)
+	msgType  string
+	queuedAt time.Time
 }
 
 //go:generate mockery -dir . -name MockStream -case underscore -output mocks/
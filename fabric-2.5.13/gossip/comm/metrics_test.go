@@ -81,4 +81,35 @@ func TestMetrics(t *testing.T) {
 	)
 
 	require.Equal(t, uint32(1), atomic.LoadUint32(&overflown))
+
+	require.Equal(t,
+		[]string{"message_type", "data"},
+		testMetricProvider.FakeSentMessagesByType.WithArgsForCall(0),
+	)
+
+	require.Equal(t,
+		[]string{"message_type", "data"},
+		testMetricProvider.FakeReceivedMessagesByType.WithArgsForCall(0),
+	)
+
+	require.Equal(t,
+		[]string{"message_type", "data", "reason", "buffer_overflow"},
+		testMetricProvider.FakeDroppedMessages.WithArgsForCall(0),
+	)
+
+	require.Equal(t,
+		[]string{"message_type", "data"},
+		testMetricProvider.FakeSendLatency.WithArgsForCall(0),
+	)
+
+	stats := comm1.MessageStats()
+	require.Len(t, stats, 1)
+	require.Equal(t, "data", stats[0].Type)
+	require.NotZero(t, stats[0].Sent)
+	require.NotZero(t, stats[0].Dropped)
+	require.Zero(t, stats[0].Received)
+
+	stats = comm2.MessageStats()
+	require.Len(t, stats, 1)
+	require.NotZero(t, stats[0].Received)
 }
@@ -189,6 +189,11 @@ func (mock *commMock) CloseConn(peer *comm.RemotePeer) {
 	// NOOP
 }
 
+// MessageStats returns the number of messages sent, received and dropped, per message type
+func (mock *commMock) MessageStats() []comm.MessageStats {
+	return nil
+}
+
 // Stop stops the module
 func (mock *commMock) Stop() {
 	logger.Debug("Stopping communication module, closing all accepting channels.")
@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/metrics"
+)
+
+const (
+	dropReasonBufferOverflow = "buffer_overflow"
+	dropReasonSendFailure    = "send_failure"
+
+	// malformedMessageType is the type of received messages that could not be parsed
+	malformedMessageType = "malformed"
+)
+
+// MessageStats summarizes the messages of a certain type
+// that were sent, received and dropped by this peer
+type MessageStats struct {
+	Type     string `json:"type"`
+	Sent     uint64 `json:"sent"`
+	Received uint64 `json:"received"`
+	Dropped  uint64 `json:"dropped"`
+	// AvgSendLatency is the average time in seconds it took from
+	// queueing an outgoing message until it was sent
+	AvgSendLatency float64 `json:"avg_send_latency"`
+}
+
+// messageStats records the messages sent, received and dropped by
+// all connections, both in the metrics and per message type
+type messageStats struct {
+	metrics *metrics.CommMetrics
+
+	lock             sync.Mutex
+	byType           map[string]*MessageStats
+	totalSendLatency map[string]time.Duration
+}
+
+func newMessageStats(metrics *metrics.CommMetrics) *messageStats {
+	return &messageStats{
+		metrics:          metrics,
+		byType:           make(map[string]*MessageStats),
+		totalSendLatency: make(map[string]time.Duration),
+	}
+}
+
+func (s *messageStats) sent(msgType string, latency time.Duration) {
+	s.metrics.SentMessages.Add(1)
+	s.metrics.SentMessagesByType.With("message_type", msgType).Add(1)
+	s.metrics.SendLatency.With("message_type", msgType).Observe(latency.Seconds())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stats(msgType).Sent++
+	s.totalSendLatency[msgType] += latency
+}
+
+func (s *messageStats) received(msgType string) {
+	s.metrics.ReceivedMessages.Add(1)
+	s.metrics.ReceivedMessagesByType.With("message_type", msgType).Add(1)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stats(msgType).Received++
+}
+
+func (s *messageStats) dropped(msgType string, reason string) {
+	if reason == dropReasonBufferOverflow {
+		s.metrics.BufferOverflow.Add(1)
+	}
+	s.metrics.DroppedMessages.With("message_type", msgType, "reason", reason).Add(1)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stats(msgType).Dropped++
+}
+
+// stats returns the stats of the given message type, s.lock must be held
+func (s *messageStats) stats(msgType string) *MessageStats {
+	stats, exists := s.byType[msgType]
+	if !exists {
+		stats = &MessageStats{Type: msgType}
+		s.byType[msgType] = stats
+	}
+	return stats
+}
+
+// snapshot returns the stats of all message types, sorted by type
+func (s *messageStats) snapshot() []MessageStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	res := make([]MessageStats, 0, len(s.byType))
+	for msgType, stats := range s.byType {
+		stats := *stats
+		if stats.Sent > 0 {
+			stats.AvgSendLatency = s.totalSendLatency[msgType].Seconds() / float64(stats.Sent)
+		}
+		res = append(res, stats)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Type < res[j].Type
+	})
+	return res
+}
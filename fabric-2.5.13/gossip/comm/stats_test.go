@@ -0,0 +1,33 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageStats(t *testing.T) {
+	stats := newMessageStats(disabledMetrics)
+	require.Empty(t, stats.snapshot())
+
+	stats.sent("data", time.Second)
+	stats.sent("data", 3*time.Second)
+	stats.received("data")
+	stats.received("alive")
+	stats.dropped("alive", dropReasonBufferOverflow)
+	stats.dropped("alive", dropReasonSendFailure)
+	stats.received(malformedMessageType)
+
+	require.Equal(t, []MessageStats{
+		{Type: "alive", Received: 1, Dropped: 2},
+		{Type: "data", Sent: 2, Received: 1, AvgSendLatency: 2},
+		{Type: "malformed", Received: 1},
+	}, stats.snapshot())
+}
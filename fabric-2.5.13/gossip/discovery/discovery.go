@@ -8,11 +8,13 @@ package discovery
 
 import (
 	"fmt"
+	"time"
 
 	protolib "github.com/golang/protobuf/proto"
 	proto "github.com/hyperledger/fabric-protos-go/gossip"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/pkg/errors"
 )
 
 // CryptoService is an interface that the discovery expects to be implemented and passed on creation
@@ -125,6 +127,15 @@ func (n NetworkMember) PreferredEndpoint() string {
 	return n.Endpoint
 }
 
+// MemberState is the state of a member in the view of a discovery instance
+type MemberState struct {
+	NetworkMember
+	// Alive indicates whether the member is considered alive or dead
+	Alive bool
+	// LastSeen is the time the last alive message of the member was received
+	LastSeen time.Time
+}
+
 // PeerIdentification encompasses a remote peer's
 // PKI-ID and whether its in the same org as the current
 // peer or not
@@ -135,6 +146,10 @@ type PeerIdentification struct {
 
 type identifier func() (*PeerIdentification, error)
 
+// ErrStopConnecting is returned by an identifier to stop the attempts
+// to connect to a member, e.g., when it is no longer a bootstrap peer
+var ErrStopConnecting = errors.New("stopped connecting")
+
 // Discovery is the interface that represents a discovery module
 type Discovery interface {
 	// Lookup returns a network member, or nil if not found
@@ -149,12 +164,20 @@ type Discovery interface {
 	// UpdateEndpoint updates this instance's endpoint
 	UpdateEndpoint(string)
 
+	// UpdateBootstrapPeers updates the bootstrap peers, which are never
+	// removed from the membership even if they are dead
+	UpdateBootstrapPeers([]string)
+
 	// Stops this instance
 	Stop()
 
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// MembershipState returns the alive and the dead members in the view,
+	// along with the time they were last seen alive
+	MembershipState() []MemberState
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
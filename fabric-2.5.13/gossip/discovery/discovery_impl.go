@@ -156,6 +156,10 @@ func (d *gossipDiscoveryImpl) Connect(member NetworkMember, id identifier) {
 				if d.toDie() {
 					return
 				}
+				if errors.Cause(err) == ErrStopConnecting {
+					d.logger.Debugf("Stopped connecting to %v", member)
+					return
+				}
 				d.logger.Warningf("Could not connect to %v : %v", member, err)
 				time.Sleep(d.reconnectInterval)
 				continue
@@ -962,6 +966,36 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 	return response
 }
 
+func (d *gossipDiscoveryImpl) MembershipState() []MemberState {
+	if d.toDie() {
+		return []MemberState{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	response := []MemberState{}
+	for _, membership := range []struct {
+		lastTS map[string]*timestamp
+		alive  bool
+	}{
+		{lastTS: d.aliveLastTS, alive: true},
+		{lastTS: d.deadLastTS, alive: false},
+	} {
+		for id, ts := range membership.lastTS {
+			member, exists := d.id2Member[id]
+			if !exists {
+				continue
+			}
+			response = append(response, MemberState{
+				NetworkMember: member.Clone(),
+				Alive:         membership.alive,
+				LastSeen:      ts.lastSeen,
+			})
+		}
+	}
+	return response
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -979,6 +1013,13 @@ func (d *gossipDiscoveryImpl) UpdateEndpoint(endpoint string) {
 	d.self.Endpoint = endpoint
 }
 
+func (d *gossipDiscoveryImpl) UpdateBootstrapPeers(bootstrapPeers []string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.bootstrapPeers = bootstrapPeers
+}
+
 func (d *gossipDiscoveryImpl) Self() NetworkMember {
 	var env *proto.Envelope
 	msg, _ := d.aliveMsgAndInternalEndpoint()
@@ -28,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/gossip/msgstore"
 	"github.com/hyperledger/fabric/gossip/protoext"
 	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -590,6 +591,27 @@ func TestConnect(t *testing.T) {
 	}
 }
 
+func TestConnectStopped(t *testing.T) {
+	t.Parallel()
+
+	config := defaultTestConfig
+	config.ReconnectInterval = 100 * time.Millisecond
+	inst := createDiscoveryInstanceCustomConfig(14611, "d1", nil, config)
+	defer inst.Stop()
+
+	var attempts uint32
+	inst.Connect(NetworkMember{Endpoint: "localhost:14612", InternalEndpoint: "localhost:14612"}, func() (*PeerIdentification, error) {
+		if atomic.AddUint32(&attempts, 1) == 1 {
+			return nil, errors.New("unreachable")
+		}
+		return nil, errors.WithStack(ErrStopConnecting)
+	})
+
+	waitUntilOrFail(t, func() bool { return atomic.LoadUint32(&attempts) == 2 })
+	time.Sleep(5 * config.ReconnectInterval)
+	require.Equal(t, uint32(2), atomic.LoadUint32(&attempts))
+}
+
 func TestNoSigningIfNoMembership(t *testing.T) {
 	t.Parallel()
 
@@ -901,6 +923,21 @@ func TestExpiration(t *testing.T) {
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
 
+	waitUntilOrFail(t, func() bool {
+		alive, dead := 0, 0
+		for _, member := range instances[0].MembershipState() {
+			if member.Alive {
+				alive++
+			} else {
+				dead++
+			}
+			if member.LastSeen.IsZero() {
+				return false
+			}
+		}
+		return alive == nodeNum-3 && dead == 2
+	})
+
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
 		if i+2 == nodeNum {
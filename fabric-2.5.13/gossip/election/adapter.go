@@ -43,6 +43,10 @@ func (pi *peerImpl) ID() peerID {
 	return peerID(pi.member.PKIid)
 }
 
+func (pi *peerImpl) Weight() uint32 {
+	return weightFromMetadata(pi.member.Metadata)
+}
+
 type gossip interface {
 	// PeersOfChannel returns the NetworkMembers considered alive in a channel
 	PeersOfChannel(channel common.ChannelID) []discovery.NetworkMember
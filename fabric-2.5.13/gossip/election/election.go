@@ -8,6 +8,7 @@ package election
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"sync"
 	"sync/atomic"
@@ -18,7 +19,8 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers with a higher weight are better leader candidates,
+//   and peers with equal weights break symmetry by comparing IDs
 // - Each peer is either a leader or a follower,
 //   and the aim is to have exactly 1 leader if the membership view
 //   is the same for all peers
@@ -50,9 +52,12 @@ import (
 //		If you are the leader:
 //			Broadcast leadership declaration
 //			If a leadership declaration was received from
-// 			a peer with a lower ID,
+// 			a better candidate,
 //			become a follower
 //		Else, you're a follower:
+//			If a leadership declaration was received from
+//			a peer with a lower weight:
+//				become a leader
 //			If haven't received a leadership declaration within
 // 			a time threshold:
 //				set leaderKnown to false
@@ -63,9 +68,12 @@ import (
 //	If received a leadership declaration:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
+// 	If a proposal message from a better candidate
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// A peer is a better candidate than another peer if its weight is higher,
+// or if their weights are equal and its ID is lower.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -114,6 +122,8 @@ func (p peerID) String() string {
 type Peer interface {
 	// ID returns the ID of the peer
 	ID() peerID
+	// Weight returns the election weight of the peer
+	Weight() uint32
 }
 
 // Msg describes a message sent from a remote peer
@@ -141,6 +151,26 @@ type ElectionConfig struct {
 	MembershipSampleInterval time.Duration
 	LeaderAliveThreshold     time.Duration
 	LeaderElectionDuration   time.Duration
+	// Weight is the election weight of the peer. Peers with a higher weight are
+	// preferred as leaders, and take over the leadership from peers with a lower weight.
+	Weight uint32
+}
+
+// WeightMetadata returns the membership metadata through which
+// a peer publishes its election weight to other peers
+func WeightMetadata(weight uint32) []byte {
+	metadata := make([]byte, 4)
+	binary.BigEndian.PutUint32(metadata, weight)
+	return metadata
+}
+
+// weightFromMetadata returns the election weight published in the given
+// membership metadata, or zero if the peer doesn't publish a weight
+func weightFromMetadata(metadata []byte) uint32 {
+	if len(metadata) != 4 {
+		return 0
+	}
+	return binary.BigEndian.Uint32(metadata)
 }
 
 // NewLeaderElectionService returns a new LeaderElectionService
@@ -154,6 +184,7 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 		adapter:       adapter,
 		stopChan:      make(chan struct{}),
 		interruptChan: make(chan struct{}, 1),
+		takeoverChan:  make(chan struct{}, 1),
 		logger:        util.GetLogger(util.ElectionLogger, ""),
 		callback:      noopCallback,
 		config:        config,
@@ -174,6 +205,7 @@ type leaderElectionSvcImpl struct {
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
+	takeoverChan  chan struct{}
 	stopWG        sync.WaitGroup
 	isLeader      int32
 	leaderExists  int32
@@ -183,7 +215,10 @@ type leaderElectionSvcImpl struct {
 	logger        util.Logger
 	callback      leadershipCallback
 	yieldTimer    *time.Timer
-	config        ElectionConfig
+	// yieldedAt is the last time the peer yielded, after which
+	// it doesn't take over the leadership for a while
+	yieldedAt time.Time
+	config    ElectionConfig
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -228,8 +263,12 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
-			le.stopBeingLeader()
+		if le.IsLeader() {
+			if le.isBetterCandidate(msg.SenderID()) {
+				le.stopBeingLeader()
+			}
+		} else if le.shouldTakeOver(msg.SenderID()) && len(le.takeoverChan) == 0 {
+			le.takeoverChan <- struct{}{}
 		}
 	} else {
 		// We shouldn't get here
@@ -310,7 +349,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
 		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		if le.isBetterCandidate(peerID(id)) {
 			return
 		}
 	}
@@ -335,8 +374,18 @@ func (le *leaderElectionSvcImpl) follower() {
 	le.proposals.Clear()
 	atomic.StoreInt32(&le.leaderExists, int32(0))
 	le.adapter.ReportMetrics(false)
+	// Ignore take over requests that were made
+	// before the peer became a follower
+	le.Lock()
+	if len(le.takeoverChan) == 1 {
+		<-le.takeoverChan
+	}
+	le.Unlock()
 	select {
 	case <-time.After(le.config.LeaderAliveThreshold):
+	case <-le.takeoverChan:
+		le.logger.Info(le.id, ": Taking over the leadership from a peer with a lower weight")
+		le.beLeader()
 	case <-le.stopChan:
 	}
 }
@@ -373,6 +422,36 @@ func (le *leaderElectionSvcImpl) drainInterruptChannel() {
 	}
 }
 
+// isBetterCandidate returns whether the peer of the given id
+// is a better leader candidate than this peer
+func (le *leaderElectionSvcImpl) isBetterCandidate(id peerID) bool {
+	if weight := le.weightOf(id); weight != le.config.Weight {
+		return weight > le.config.Weight
+	}
+	return bytes.Compare(id, le.id) < 0
+}
+
+// shouldTakeOver returns whether this peer should take over the leadership
+// from the peer of the given id, which declared itself as a leader.
+// The peer doesn't take over the leadership while yielding, and for
+// a while after it yielded, to let the leadership be moved to other peers.
+func (le *leaderElectionSvcImpl) shouldTakeOver(id peerID) bool {
+	if le.isYielding() || time.Since(le.yieldedAt) < le.config.LeaderAliveThreshold*6 {
+		return false
+	}
+	return le.weightOf(id) < le.config.Weight
+}
+
+// weightOf returns the election weight of the peer of given id
+func (le *leaderElectionSvcImpl) weightOf(id peerID) uint32 {
+	for _, p := range le.adapter.Peers() {
+		if bytes.Equal(p.ID(), id) {
+			return p.Weight()
+		}
+	}
+	return 0
+}
+
 // isAlive returns whether peer of given id is considered alive
 func (le *leaderElectionSvcImpl) isAlive(id peerID) bool {
 	for _, p := range le.adapter.Peers() {
@@ -437,6 +516,7 @@ func (le *leaderElectionSvcImpl) Yield() {
 	}
 	// Turn on the yield flag
 	atomic.StoreInt32(&le.yield, int32(1))
+	le.yieldedAt = time.Now()
 	// Stop being a leader
 	le.stopBeingLeader()
 	// Clear the leader exists flag since it could be that we are the leader
@@ -53,6 +53,7 @@ type peer struct {
 	mockedMethods map[string]struct{}
 	mock.Mock
 	id                 string
+	weight             uint32
 	peers              map[string]*peer
 	sharedLock         *sync.RWMutex
 	msgChan            chan Msg
@@ -73,6 +74,10 @@ func (p *peer) ID() peerID {
 	return peerID(p.id)
 }
 
+func (p *peer) Weight() uint32 {
+	return p.weight
+}
+
 func (p *peer) Gossip(m Msg) {
 	p.sharedLock.RLock()
 	defer p.sharedLock.RUnlock()
@@ -115,8 +120,8 @@ func (p *peer) Peers() []Peer {
 	}
 
 	var peers []Peer
-	for id := range p.peers {
-		peers = append(peers, &peer{id: id})
+	for id, remotePeer := range p.peers {
+		peers = append(peers, &peer{id: id, weight: remotePeer.weight})
 	}
 	return peers
 }
@@ -158,16 +163,35 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 	return peers
 }
 
+func createWeightedPeers(spawnInterval time.Duration, weights map[int]uint32, ids ...int) []*peer {
+	peers := make([]*peer, len(ids))
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	for i, id := range ids {
+		p := createWeightedPeer(id, weights[id], peerMap, l, func(mock.Arguments) {})
+		if spawnInterval != 0 {
+			time.Sleep(spawnInterval)
+		}
+		peers[i] = p
+	}
+	return peers
+}
+
 func createPeerWithCostumeMetrics(id int, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
+	return createWeightedPeer(id, 0, peerMap, l, f)
+}
+
+func createWeightedPeer(id int, weight uint32, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
-	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
+	p := &peer{id: idStr, weight: weight, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
 	p.On("ReportMetrics", mock.Anything).Run(f)
 	config := ElectionConfig{
 		StartupGracePeriod:       testStartupGracePeriod,
 		MembershipSampleInterval: testMembershipSampleInterval,
 		LeaderAliveThreshold:     testLeaderAliveThreshold,
 		LeaderElectionDuration:   testLeaderElectionDuration,
+		Weight:                   weight,
 	}
 	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback, config)
 	l.Lock()
@@ -414,6 +438,98 @@ func TestYieldAllPeers(t *testing.T) {
 	require.Equal(t, "p0", leaders[0])
 }
 
+func TestWeightedInitPeersAtSameTime(t *testing.T) {
+	// Scenario: Peers are spawned at the same time, and p3 has the highest weight
+	// expected outcome: p3 is the leader although p0 has the lowest ID
+	peers := createWeightedPeers(0, map[int]uint32{3: 10, 5: 5}, 5, 4, 3, 2, 1, 0)
+	time.Sleep(testStartupGracePeriod + testLeaderElectionDuration)
+	leaders := waitForLeaderElection(t, peers)
+	require.Equal(t, []string{"p3"}, leaders)
+	waitForBoolFunc(t, peers[2].isLeaderFromCallback, true, "Leadership callback result is wrong for ", peers[2].id)
+}
+
+func TestWeightedInitPeersTie(t *testing.T) {
+	// Scenario: Peers are spawned at the same time, and p2 and p4 have the same highest weight
+	// expected outcome: the peer with the lowest ID among them is the leader
+	peers := createWeightedPeers(0, map[int]uint32{2: 10, 4: 10}, 5, 4, 3, 2, 1, 0)
+	time.Sleep(testStartupGracePeriod + testLeaderElectionDuration)
+	leaders := waitForLeaderElection(t, peers)
+	require.Equal(t, []string{"p2"}, leaders)
+}
+
+func TestWeightedLeadershipTakeover(t *testing.T) {
+	// Scenario: Peers spawn one by one, and the last peer has the highest weight
+	// expected outcome: the last peer takes over the leadership from the first peer
+	peers := createWeightedPeers(testStartupGracePeriod+testLeadershipDeclarationInterval, map[int]uint32{2: 1}, 0, 1, 2)
+	time.Sleep(testLeadershipDeclarationInterval + testLeaderAliveThreshold*2)
+	ensureP2isTheLeader := func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] == "p2"
+	}
+	waitForBoolFunc(t, ensureP2isTheLeader, true)
+	waitForBoolFunc(t, peers[0].isLeaderFromCallback, false, "Leadership callback result is wrong for ", peers[0].id)
+}
+
+func TestWeightedConvergence(t *testing.T) {
+	// Scenario: 2 peer groups with tied weights converge their views
+	// expected outcome: only 1 leader is left out of the 2,
+	// and it is the leader with the lowest ID among the peers with the highest weight
+	weights := map[int]uint32{1: 5, 6: 5}
+	peers1 := createWeightedPeers(0, weights, 3, 2, 1, 0)
+	peers2 := createWeightedPeers(0, weights, 4, 5, 6, 7)
+	require.Equal(t, []string{"p1"}, waitForLeaderElection(t, peers1))
+	require.Equal(t, []string{"p6"}, waitForLeaderElection(t, peers2))
+	combinedPeers := append(peers1, peers2...)
+
+	var allPeers []Peer
+	for _, p := range combinedPeers {
+		allPeers = append(allPeers, &peer{id: p.id, weight: p.weight})
+	}
+
+	for i, p := range combinedPeers {
+		index := i
+		gossipFunc := func(args mock.Arguments) {
+			msg := args.Get(0).(Msg)
+			for j := range combinedPeers {
+				if index == j {
+					continue
+				}
+				combinedPeers[j].msgChan <- msg
+			}
+		}
+		p.On("Gossip", mock.Anything).Run(gossipFunc)
+		p.On("Peers").Return(allPeers)
+	}
+
+	time.Sleep(testLeaderAliveThreshold * 5)
+	finalLeaders := waitForLeaderElection(t, combinedPeers)
+	require.Equal(t, []string{"p1"}, finalLeaders)
+}
+
+func TestWeightedYield(t *testing.T) {
+	// Scenario: Peers spawn and the peer with the highest weight is elected, and then yields.
+	// Expected outcome: a new leader is elected, and the peer with the
+	// highest weight doesn't take over the leadership right away
+	peers := createWeightedPeers(0, map[int]uint32{2: 1}, 0, 1, 2)
+	leaders := waitForLeaderElection(t, peers)
+	require.Equal(t, []string{"p2"}, leaders)
+	peers[2].Yield()
+
+	ensureP2isNotAleader := func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] != "p2"
+	}
+	waitForBoolFunc(t, ensureP2isNotAleader, true)
+	time.Sleep(testLeaderAliveThreshold * 2)
+	waitForBoolFunc(t, ensureP2isNotAleader, true)
+}
+
+func TestWeightMetadata(t *testing.T) {
+	require.Equal(t, uint32(7), weightFromMetadata(WeightMetadata(7)))
+	require.Equal(t, uint32(0), weightFromMetadata(nil))
+	require.Equal(t, uint32(0), weightFromMetadata([]byte{1, 2, 3}))
+}
+
 func TestPartition(t *testing.T) {
 	// Scenario: peers spawn together, and then after a while a network partition occurs
 	// and no peer can communicate with another peer
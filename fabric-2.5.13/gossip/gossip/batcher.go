@@ -36,6 +36,13 @@ type batchingEmitter interface {
 // latency: the maximum delay that each message can be stored without being forwarded
 // cb: a callback that is called in order for the forwarding to take place
 func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emitBatchCallback) batchingEmitter {
+	return newSizeAwareBatchingEmitter(iterations, burstSize, 0, latency, nil, cb)
+}
+
+// newSizeAwareBatchingEmitter accepts the parameters of newBatchingEmitter, and also:
+// burstBytes: a threshold of the total size of the stored messages that triggers a forwarding, ignored if zero
+// sizeOf: a function that returns the size of a message
+func newSizeAwareBatchingEmitter(iterations, burstSize, burstBytes int, latency time.Duration, sizeOf func(interface{}) int, cb emitBatchCallback) batchingEmitter {
 	if iterations < 0 {
 		panic(errors.Errorf("Got a negative iterations number"))
 	}
@@ -45,6 +52,8 @@ func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emi
 		delay:      latency,
 		iterations: iterations,
 		burstSize:  burstSize,
+		burstBytes: burstBytes,
+		sizeOf:     sizeOf,
 		lock:       &sync.Mutex{},
 		buff:       make([]*batchedMessage, 0),
 		stopFlag:   int32(0),
@@ -88,6 +97,7 @@ func (p *batchingEmitterImpl) decrementCounters() {
 		msg := p.buff[i]
 		msg.iterationsLeft--
 		if msg.iterationsLeft == 0 {
+			p.bytes -= msg.size
 			p.buff = append(p.buff[:i], p.buff[i+1:]...)
 			n--
 			i--
@@ -102,6 +112,9 @@ func (p *batchingEmitterImpl) toDie() bool {
 type batchingEmitterImpl struct {
 	iterations int
 	burstSize  int
+	burstBytes int
+	bytes      int
+	sizeOf     func(interface{}) int
 	delay      time.Duration
 	cb         emitBatchCallback
 	lock       *sync.Mutex
@@ -111,6 +124,7 @@ type batchingEmitterImpl struct {
 
 type batchedMessage struct {
 	data           interface{}
+	size           int
 	iterationsLeft int
 }
 
@@ -131,9 +145,14 @@ func (p *batchingEmitterImpl) Add(message interface{}) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	p.buff = append(p.buff, &batchedMessage{data: message, iterationsLeft: p.iterations})
+	var size int
+	if p.burstBytes > 0 {
+		size = p.sizeOf(message)
+	}
+	p.buff = append(p.buff, &batchedMessage{data: message, size: size, iterationsLeft: p.iterations})
+	p.bytes += size
 
-	if len(p.buff) >= p.burstSize {
+	if len(p.buff) >= p.burstSize || (p.burstBytes > 0 && p.bytes >= p.burstBytes) {
 		p.emit()
 	}
 }
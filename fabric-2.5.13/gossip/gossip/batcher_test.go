@@ -112,3 +112,37 @@ func TestBatchingEmitterBurstSizeCap(t *testing.T) {
 	}
 	require.Equal(t, int32(5), atomic.LoadInt32(&disseminationAttempts))
 }
+
+// TestBatchingEmitterBurstBytesCap tests that the emitter triggers a forwarding
+// once the total size of the stored messages reaches the burst bytes threshold
+func TestBatchingEmitterBurstBytesCap(t *testing.T) {
+	var emitted [][]interface{}
+	cb := func(a []interface{}) {
+		emitted = append(emitted, a)
+	}
+	sizeOf := func(m interface{}) int {
+		return m.(int)
+	}
+	emitter := newSizeAwareBatchingEmitter(1, 10, 100, time.Hour, sizeOf, cb)
+	defer emitter.Stop()
+
+	emitter.Add(10)
+	emitter.Add(50)
+	require.Empty(t, emitted)
+	require.Equal(t, 2, emitter.Size())
+
+	emitter.Add(40)
+	require.Equal(t, [][]interface{}{{10, 50, 40}}, emitted)
+	require.Equal(t, 0, emitter.Size())
+
+	// A single message larger than the threshold is forwarded right away
+	emitter.Add(200)
+	require.Equal(t, [][]interface{}{{10, 50, 40}, {200}}, emitted)
+	require.Equal(t, 0, emitter.Size())
+
+	// The count threshold still applies
+	for i := 0; i < 10; i++ {
+		emitter.Add(1)
+	}
+	require.Len(t, emitted, 3)
+}
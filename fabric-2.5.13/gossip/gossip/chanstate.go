@@ -95,6 +95,20 @@ func (cs *channelState) getGossipChannelByChainID(channelID common.ChannelID) ch
 	return cs.channels[string(channelID)]
 }
 
+// getGossipChannels returns the gossip channels this peer has joined, by channel ID
+func (cs *channelState) getGossipChannels() map[string]channel.GossipChannel {
+	if cs.isStopping() {
+		return nil
+	}
+	cs.RLock()
+	defer cs.RUnlock()
+	channels := make(map[string]channel.GossipChannel, len(cs.channels))
+	for channelID, gc := range cs.channels {
+		channels[channelID] = gc
+	}
+	return channels
+}
+
 func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, channelID common.ChannelID,
 	metrics *metrics.MembershipMetrics) {
 	if cs.isStopping() {
@@ -40,6 +40,9 @@ type Config struct {
 	MaxPropagationBurstSize int
 	// MaxPropagationBurstLatency is the max time between consecutive message pushes.
 	MaxPropagationBurstLatency time.Duration
+	// MaxPropagationBurstBytes is the max total payload size of the messages stored until it triggers
+	// a push to remote peers, zero means the push is triggered only by the number of messages.
+	MaxPropagationBurstBytes int
 
 	// PullInterval determines frequency of pull phases.
 	PullInterval time.Duration
@@ -81,6 +84,14 @@ type Config struct {
 	RecvBuffSize int
 	// SendBuffSize is the buffer size of sending message.
 	SendBuffSize int
+	// CompressionEnabled determines whether block and private data messages are compressed
+	// when sent to peers that support compression.
+	CompressionEnabled bool
+	// CompressionMinSize is the minimum payload size of a message to be compressed.
+	CompressionMinSize int
+	// MaxRecvMsgSize is the maximum size of a message received from a peer, which bounds
+	// the size of the decompressed payload of a compressed message as well.
+	MaxRecvMsgSize int
 
 	// MsgExpirationTimeout indicate leadership message expiration timeout.
 	MsgExpirationTimeout time.Duration
@@ -122,6 +133,7 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 	c.MaxBlockCountToStore = util.GetIntOrDefault("peer.gossip.maxBlockCountToStore", 10)
 	c.MaxPropagationBurstLatency = util.GetDurationOrDefault("peer.gossip.maxPropagationBurstLatency", 10*time.Millisecond)
 	c.MaxPropagationBurstSize = util.GetIntOrDefault("peer.gossip.maxPropagationBurstSize", 10)
+	c.MaxPropagationBurstBytes = viper.GetInt("peer.gossip.maxPropagationBurstBytes")
 	c.PropagateIterations = util.GetIntOrDefault("peer.gossip.propagateIterations", 1)
 	c.PropagatePeerNum = util.GetIntOrDefault("peer.gossip.propagatePeerNum", 3)
 	c.PullInterval = util.GetDurationOrDefault("peer.gossip.pullInterval", 4*time.Second)
@@ -141,6 +153,9 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 	c.ConnTimeout = util.GetDurationOrDefault("peer.gossip.connTimeout", comm.DefConnTimeout)
 	c.RecvBuffSize = util.GetIntOrDefault("peer.gossip.recvBuffSize", comm.DefRecvBuffSize)
 	c.SendBuffSize = util.GetIntOrDefault("peer.gossip.sendBuffSize", comm.DefSendBuffSize)
+	c.CompressionEnabled = viper.GetBool("peer.gossip.compression.enabled")
+	c.CompressionMinSize = util.GetIntOrDefault("peer.gossip.compression.minSize", comm.DefCompressionMinSize)
+	c.MaxRecvMsgSize = util.GetIntOrDefault("peer.maxRecvMsgSize", comm.DefMaxRecvMsgSize)
 	c.MsgExpirationTimeout = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold) * 10
 	c.AliveTimeInterval = util.GetDurationOrDefault("peer.gossip.aliveTimeInterval", discovery.DefAliveTimeInterval)
 	c.AliveExpirationTimeout = util.GetDurationOrDefault("peer.gossip.aliveExpirationTimeout", 5*c.AliveTimeInterval)
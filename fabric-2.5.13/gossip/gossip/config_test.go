@@ -32,6 +32,7 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.maxBlockCountToStore", 1)
 	viper.Set("peer.gossip.maxPropagationBurstLatency", "2s")
 	viper.Set("peer.gossip.maxPropagationBurstSize", 3)
+	viper.Set("peer.gossip.maxPropagationBurstBytes", 1048576)
 	viper.Set("peer.gossip.propagateIterations", 4)
 	viper.Set("peer.gossip.propagatePeerNum", 5)
 	viper.Set("peer.gossip.pullInterval", "6s")
@@ -50,6 +51,9 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.connTimeout", "16s")
 	viper.Set("peer.gossip.recvBuffSize", 17)
 	viper.Set("peer.gossip.sendBuffSize", 18)
+	viper.Set("peer.gossip.compression.enabled", true)
+	viper.Set("peer.gossip.compression.minSize", 4096)
+	viper.Set("peer.maxRecvMsgSize", 1048576)
 	viper.Set("peer.gossip.election.leaderAliveThreshold", "19s")
 	viper.Set("peer.gossip.aliveTimeInterval", "20s")
 	viper.Set("peer.gossip.aliveExpirationTimeout", "21s")
@@ -72,6 +76,7 @@ func TestGlobalConfig(t *testing.T) {
 		MaxBlockCountToStore:         1,
 		MaxPropagationBurstLatency:   2 * time.Second,
 		MaxPropagationBurstSize:      3,
+		MaxPropagationBurstBytes:     1048576,
 		PropagateIterations:          4,
 		PropagatePeerNum:             5,
 		PullInterval:                 6 * time.Second,
@@ -91,6 +96,9 @@ func TestGlobalConfig(t *testing.T) {
 		ConnTimeout:                  16 * time.Second,
 		RecvBuffSize:                 17,
 		SendBuffSize:                 18,
+		CompressionEnabled:           true,
+		CompressionMinSize:           4096,
+		MaxRecvMsgSize:               1048576,
 		MsgExpirationTimeout:         19 * time.Second * 10, // LeaderAliveThreshold * 10
 		AliveTimeInterval:            20 * time.Second,
 		AliveExpirationTimeout:       21 * time.Second,
@@ -145,6 +153,8 @@ func TestGlobalConfigDefaults(t *testing.T) {
 		ConnTimeout:                  comm.DefConnTimeout,
 		RecvBuffSize:                 comm.DefRecvBuffSize,
 		SendBuffSize:                 comm.DefSendBuffSize,
+		CompressionMinSize:           comm.DefCompressionMinSize,
+		MaxRecvMsgSize:               comm.DefMaxRecvMsgSize,
 		MsgExpirationTimeout:         election.DefLeaderAliveThreshold * 10,
 		AliveTimeInterval:            discovery.DefAliveTimeInterval,
 		AliveExpirationTimeout:       5 * discovery.DefAliveTimeInterval,
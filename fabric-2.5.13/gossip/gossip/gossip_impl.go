@@ -66,6 +66,9 @@ type Node struct {
 	stateInfoMsgStore msgstore.MessageStore
 	certPuller        pull.Mediator
 	gossipMetrics     *metrics.GossipMetrics
+
+	bootstrapLock  sync.Mutex
+	bootstrapPeers []string
 }
 
 // New creates a gossip instance attached to a gRPC server
@@ -92,6 +95,7 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 		stopSignal:            &sync.WaitGroup{},
 		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
 		gossipMetrics:         gossipMetrics,
+		bootstrapPeers:        conf.BootstrapPeers,
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
 
@@ -117,10 +121,13 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	}, sa)
 
 	commConfig := comm.CommConfig{
-		DialTimeout:  conf.DialTimeout,
-		ConnTimeout:  conf.ConnTimeout,
-		RecvBuffSize: conf.RecvBuffSize,
-		SendBuffSize: conf.SendBuffSize,
+		DialTimeout:        conf.DialTimeout,
+		ConnTimeout:        conf.ConnTimeout,
+		RecvBuffSize:       conf.RecvBuffSize,
+		SendBuffSize:       conf.SendBuffSize,
+		CompressionEnabled: conf.CompressionEnabled,
+		CompressionMinSize: conf.CompressionMinSize,
+		MaxRecvMsgSize:     conf.MaxRecvMsgSize,
 	}
 	g.comm, err = comm.NewCommInstance(s, conf.TLSCerts, g.idMapper, selfIdentity, secureDialOpts, sa,
 		gossipMetrics.CommMetrics, commConfig)
@@ -131,9 +138,9 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	}
 
 	g.chanState = newChannelState(g)
-	g.emitter = newBatchingEmitter(conf.PropagateIterations,
-		conf.MaxPropagationBurstSize, conf.MaxPropagationBurstLatency,
-		g.sendGossipBatch)
+	g.emitter = newSizeAwareBatchingEmitter(conf.PropagateIterations,
+		conf.MaxPropagationBurstSize, conf.MaxPropagationBurstBytes, conf.MaxPropagationBurstLatency,
+		emittedMessageSize, g.sendGossipBatch)
 
 	g.discAdapter = g.newDiscoveryAdapter()
 	g.disSecAdap = g.newDiscoverySecurityAdapter()
@@ -163,7 +170,7 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	// acceptMessages goRoutines to block on Wait
 	g.stopSignal.Add(2)
 	go g.start()
-	go g.connect2BootstrapPeers()
+	go g.connect2BootstrapPeers(conf.BootstrapPeers)
 
 	return g
 }
@@ -186,7 +193,9 @@ func (g *Node) selfNetworkMember() discovery.NetworkMember {
 		InternalEndpoint: g.conf.InternalEndpoint,
 	}
 	if g.disc != nil {
-		self.Metadata = g.disc.Self().Metadata
+		discSelf := g.disc.Self()
+		self.Metadata = discSelf.Metadata
+		self.Endpoint = discSelf.Endpoint
 	}
 	return self
 }
@@ -437,6 +446,11 @@ func (g *Node) validateMsg(msg protoext.ReceivedMessage) bool {
 	return true
 }
 
+// emittedMessageSize returns the payload size of an emitted gossip message
+func emittedMessageSize(m interface{}) int {
+	return len(m.(*emittedGossipMessage).Envelope.Payload)
+}
+
 func (g *Node) sendGossipBatch(a []interface{}) {
 	msgs2Gossip := make([]*emittedGossipMessage, len(a))
 	for i, e := range a {
@@ -740,6 +754,12 @@ func (g *Node) PeersOfChannel(channel common.ChannelID) []discovery.NetworkMembe
 	return gc.GetPeers()
 }
 
+// Probe probes a remote peer and returns nil if it is responsive,
+// and an error if it's not
+func (g *Node) Probe(peer *comm.RemotePeer) error {
+	return g.comm.Probe(peer)
+}
+
 // SelfMembershipInfo returns the peer's membership information
 func (g *Node) SelfMembershipInfo() discovery.NetworkMember {
 	return g.disc.Self()
@@ -788,6 +808,43 @@ func (g *Node) UpdateMetadata(md []byte) {
 	g.disc.UpdateMetadata(md)
 }
 
+// UpdateExternalEndpoint updates the endpoint the peer publishes
+// to peers of foreign organizations
+func (g *Node) UpdateExternalEndpoint(endpoint string) {
+	if endpoint == "" {
+		g.logger.Warning("External endpoint is empty, peer will not be accessible outside of its organization")
+	}
+	g.disc.UpdateEndpoint(endpoint)
+}
+
+// UpdateBootstrapPeers replaces the bootstrap peers, and connects
+// to the bootstrap peers that were added
+func (g *Node) UpdateBootstrapPeers(bootstrapPeers []string) {
+	g.bootstrapLock.Lock()
+	defer g.bootstrapLock.Unlock()
+
+	var added []string
+	for _, endpoint := range bootstrapPeers {
+		if !util.Contains(endpoint, g.bootstrapPeers) {
+			added = append(added, endpoint)
+		}
+	}
+	g.bootstrapPeers = bootstrapPeers
+	g.disc.UpdateBootstrapPeers(bootstrapPeers)
+
+	if len(added) == 0 {
+		return
+	}
+	g.logger.Infof("Connecting to added bootstrap peers %v", added)
+	go g.connect2BootstrapPeers(added)
+}
+
+func (g *Node) isBootstrapPeer(endpoint string) bool {
+	g.bootstrapLock.Lock()
+	defer g.bootstrapLock.Unlock()
+	return util.Contains(endpoint, g.bootstrapPeers)
+}
+
 // UpdateLedgerHeight updates the ledger height the peer
 // publishes to other peers in the channel
 func (g *Node) UpdateLedgerHeight(height uint64, channelID common.ChannelID) {
@@ -1171,10 +1228,14 @@ func (g *Node) sameOrgOrOurOrgPullFilter(msg protoext.ReceivedMessage) func(stri
 	}
 }
 
-func (g *Node) connect2BootstrapPeers() {
-	for _, endpoint := range g.conf.BootstrapPeers {
+func (g *Node) connect2BootstrapPeers(bootstrapPeers []string) {
+	for _, endpoint := range bootstrapPeers {
 		endpoint := endpoint
 		identifier := func() (*discovery.PeerIdentification, error) {
+			if !g.isBootstrapPeer(endpoint) {
+				g.logger.Infof("%s was removed from the bootstrap peers, no longer connecting to it", endpoint)
+				return nil, discovery.ErrStopConnecting
+			}
 			remotePeerIdentity, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
 			if err != nil {
 				return nil, errors.WithStack(err)
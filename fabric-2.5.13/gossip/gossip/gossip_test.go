@@ -394,6 +394,47 @@ func TestLeaveChannel(t *testing.T) {
 	waitUntilOrFail(t, countMembership(p2, 0), "waiting for p2 to update membership view")
 }
 
+func TestMembershipView(t *testing.T) {
+	// Scenario: Have 2 peers in a channel, and ensure the membership view of one peer
+	// reports the other peer in the channel, and as dead once it is stopped.
+
+	port0, grpc0, certs0, secDialOpts0, _ := util.CreateGRPCLayer()
+	port1, grpc1, certs1, secDialOpts1, _ := util.CreateGRPCLayer()
+
+	p0 := newGossipInstanceWithGRPC(0, port0, grpc0, certs0, secDialOpts0, 100, port1)
+	p0.JoinChan(&joinChanMsg{}, common.ChannelID("A"))
+	p0.UpdateLedgerHeight(3, common.ChannelID("A"))
+	defer p0.Stop()
+
+	p1 := newGossipInstanceWithGRPC(1, port1, grpc1, certs1, secDialOpts1, 100, port0)
+	p1.JoinChan(&joinChanMsg{}, common.ChannelID("A"))
+	p1.UpdateLedgerHeight(5, common.ChannelID("A"))
+
+	p1Endpoint := fmt.Sprintf("127.0.0.1:%d", port1)
+	waitUntilOrFail(t, func() bool {
+		view := p0.MembershipView()
+		return len(view.Channels) == 1 && len(view.Channels[0].Peers) == 1 &&
+			view.Channels[0].Peers[0].LedgerHeight == 5
+	}, "waiting for p0 to learn the ledger height of p1")
+
+	view := p0.MembershipView()
+	require.Equal(t, p0.SelfMembershipInfo().PKIid.String(), view.Self.PKIid)
+	require.Len(t, view.Members, 1)
+	require.Equal(t, p1Endpoint, view.Members[0].InternalEndpoint)
+	require.True(t, view.Members[0].Alive)
+	require.NotNil(t, view.Members[0].LastSeen)
+	require.Equal(t, "A", view.Channels[0].Channel)
+	require.Equal(t, uint64(3), view.Channels[0].LedgerHeight)
+	require.Equal(t, p1Endpoint, view.Channels[0].Peers[0].InternalEndpoint)
+	require.NotEmpty(t, view.Messages)
+
+	p1.Stop()
+	waitUntilOrFail(t, func() bool {
+		view := p0.MembershipView()
+		return len(view.Members) == 1 && !view.Members[0].Alive
+	}, "waiting for p0 to consider p1 dead")
+}
+
 func TestPull(t *testing.T) {
 	t1 := time.Now()
 	// Scenario: Turn off forwarding and use only pull-based gossip.
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/discovery"
+)
+
+// MembershipView is a snapshot of the membership known to a peer,
+// and of the messages it exchanged with the other peers
+type MembershipView struct {
+	Self     Member              `json:"self"`
+	Members  []Member            `json:"members"`
+	Channels []ChannelMembership `json:"channels"`
+	Messages []comm.MessageStats `json:"messages"`
+}
+
+// Member is a peer in the membership view
+type Member struct {
+	Endpoint         string `json:"endpoint"`
+	InternalEndpoint string `json:"internal_endpoint,omitempty"`
+	PKIid            string `json:"pki_id"`
+	Alive            bool   `json:"alive"`
+	// LastSeen is the time the last alive message of the peer was received,
+	// it is not set for the peer itself
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
+
+// ChannelMembership is the membership of a channel, as published by
+// the peers of the channel in their state info messages
+type ChannelMembership struct {
+	Channel string `json:"channel"`
+	// LedgerHeight is the ledger height of the peer itself
+	LedgerHeight uint64          `json:"ledger_height"`
+	Peers        []ChannelMember `json:"peers"`
+}
+
+// ChannelMember is an alive peer of a channel
+type ChannelMember struct {
+	Member
+	LedgerHeight uint64      `json:"ledger_height"`
+	LeftChannel  bool        `json:"left_channel"`
+	Chaincodes   []Chaincode `json:"chaincodes"`
+}
+
+// Chaincode is a chaincode installed on a peer of a channel
+type Chaincode struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// MembershipView returns the current membership view of this peer, that is, the alive and the dead
+// peers along with the time they were last seen alive, the peers of each channel, and the
+// number of messages sent, received and dropped per message type.
+func (g *Node) MembershipView() MembershipView {
+	self := g.disc.Self()
+	view := MembershipView{
+		Self: Member{
+			Endpoint:         self.Endpoint,
+			InternalEndpoint: self.InternalEndpoint,
+			PKIid:            self.PKIid.String(),
+			Alive:            true,
+		},
+		Members:  []Member{},
+		Channels: []ChannelMembership{},
+		Messages: g.comm.MessageStats(),
+	}
+
+	lastSeen := make(map[string]time.Time)
+	for _, m := range g.disc.MembershipState() {
+		lastSeen[string(m.PKIid)] = m.LastSeen
+		view.Members = append(view.Members, newMember(m.NetworkMember, m.Alive, m.LastSeen))
+	}
+	sort.Slice(view.Members, func(i, j int) bool {
+		return view.Members[i].Endpoint < view.Members[j].Endpoint
+	})
+
+	for channelID, gc := range g.chanState.getGossipChannels() {
+		chanMembership := ChannelMembership{
+			Channel: channelID,
+			Peers:   []ChannelMember{},
+		}
+		if selfStateInfo := gc.Self(); selfStateInfo != nil && selfStateInfo.GetStateInfo().Properties != nil {
+			chanMembership.LedgerHeight = selfStateInfo.GetStateInfo().Properties.LedgerHeight
+		}
+		for _, peer := range gc.GetPeers() {
+			member := ChannelMember{
+				Member:     newMember(peer, true, lastSeen[string(peer.PKIid)]),
+				Chaincodes: []Chaincode{},
+			}
+			if peer.Properties != nil {
+				member.LedgerHeight = peer.Properties.LedgerHeight
+				member.LeftChannel = peer.Properties.LeftChannel
+				for _, cc := range peer.Properties.Chaincodes {
+					member.Chaincodes = append(member.Chaincodes, Chaincode{Name: cc.Name, Version: cc.Version})
+				}
+			}
+			chanMembership.Peers = append(chanMembership.Peers, member)
+		}
+		sort.Slice(chanMembership.Peers, func(i, j int) bool {
+			return chanMembership.Peers[i].Endpoint < chanMembership.Peers[j].Endpoint
+		})
+		view.Channels = append(view.Channels, chanMembership)
+	}
+	sort.Slice(view.Channels, func(i, j int) bool {
+		return view.Channels[i].Channel < view.Channels[j].Channel
+	})
+
+	return view
+}
+
+func newMember(m discovery.NetworkMember, alive bool, lastSeen time.Time) Member {
+	member := Member{
+		Endpoint:         m.Endpoint,
+		InternalEndpoint: m.InternalEndpoint,
+		PKIid:            m.PKIid.String(),
+		Alive:            alive,
+	}
+	if !lastSeen.IsZero() {
+		member.LastSeen = &lastSeen
+	}
+	return member
+}
@@ -93,16 +93,24 @@ var LeaderDeclerationOpts = metrics.GaugeOpts{
 
 // CommMetrics encapsulates gossip communication related metrics
 type CommMetrics struct {
-	SentMessages     metrics.Counter
-	BufferOverflow   metrics.Counter
-	ReceivedMessages metrics.Counter
+	SentMessages           metrics.Counter
+	BufferOverflow         metrics.Counter
+	ReceivedMessages       metrics.Counter
+	SentMessagesByType     metrics.Counter
+	ReceivedMessagesByType metrics.Counter
+	DroppedMessages        metrics.Counter
+	SendLatency            metrics.Histogram
 }
 
 func newCommMetrics(p metrics.Provider) *CommMetrics {
 	return &CommMetrics{
-		SentMessages:     p.NewCounter(SentMessagesOpts),
-		BufferOverflow:   p.NewCounter(BufferOverflowOpts),
-		ReceivedMessages: p.NewCounter(ReceivedMessagesOpts),
+		SentMessages:           p.NewCounter(SentMessagesOpts),
+		BufferOverflow:         p.NewCounter(BufferOverflowOpts),
+		ReceivedMessages:       p.NewCounter(ReceivedMessagesOpts),
+		SentMessagesByType:     p.NewCounter(SentMessagesByTypeOpts),
+		ReceivedMessagesByType: p.NewCounter(ReceivedMessagesByTypeOpts),
+		DroppedMessages:        p.NewCounter(DroppedMessagesOpts),
+		SendLatency:            p.NewHistogram(SendLatencyOpts),
 	}
 }
 
@@ -130,6 +138,42 @@ var (
 		Help:         "Number of messages received",
 		StatsdFormat: "%{#fqname}",
 	}
+
+	SentMessagesByTypeOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "messages_sent_by_type",
+		Help:         "Number of messages sent, by message type",
+		LabelNames:   []string{"message_type"},
+		StatsdFormat: "%{#fqname}.%{message_type}",
+	}
+
+	ReceivedMessagesByTypeOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "messages_received_by_type",
+		Help:         "Number of messages received, by message type",
+		LabelNames:   []string{"message_type"},
+		StatsdFormat: "%{#fqname}.%{message_type}",
+	}
+
+	DroppedMessagesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "messages_dropped",
+		Help:         "Number of outgoing messages dropped because the queue buffer overflowed or the message could not be sent, by message type",
+		LabelNames:   []string{"message_type", "reason"},
+		StatsdFormat: "%{#fqname}.%{message_type}.%{reason}",
+	}
+
+	SendLatencyOpts = metrics.HistogramOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "send_latency",
+		Help:         "Time it takes from queueing an outgoing message until it is sent (in seconds), by message type",
+		LabelNames:   []string{"message_type"},
+		StatsdFormat: "%{#fqname}.%{message_type}",
+	}
 )
 
 // MembershipMetrics encapsulates gossip channel membership related metrics
@@ -41,6 +41,10 @@ func TestMetrics(t *testing.T) {
 	require.NotNil(t, gossipMetrics.CommMetrics.SentMessages)
 	require.NotNil(t, gossipMetrics.CommMetrics.ReceivedMessages)
 	require.NotNil(t, gossipMetrics.CommMetrics.BufferOverflow)
+	require.NotNil(t, gossipMetrics.CommMetrics.SentMessagesByType)
+	require.NotNil(t, gossipMetrics.CommMetrics.ReceivedMessagesByType)
+	require.NotNil(t, gossipMetrics.CommMetrics.DroppedMessages)
+	require.NotNil(t, gossipMetrics.CommMetrics.SendLatency)
 
 	require.NotNil(t, gossipMetrics.MembershipMetrics)
 	require.NotNil(t, gossipMetrics.MembershipMetrics.Total)
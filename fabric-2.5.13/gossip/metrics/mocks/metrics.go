@@ -25,6 +25,11 @@ type TestMetricProvider struct {
 	FakeBufferOverflow   *metricsfakes.Counter
 	FakeReceivedMessages *metricsfakes.Counter
 
+	FakeSentMessagesByType     *metricsfakes.Counter
+	FakeReceivedMessagesByType *metricsfakes.Counter
+	FakeDroppedMessages        *metricsfakes.Counter
+	FakeSendLatency            *metricsfakes.Histogram
+
 	FakeTotalGauge *metricsfakes.Gauge
 
 	FakeValidationDuration             *metricsfakes.Histogram
@@ -51,6 +56,11 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeBufferOverflow := testUtilConstructCounter()
 	fakeReceivedMessages := testUtilConstructCounter()
 
+	fakeSentMessagesByType := testUtilConstructCounter()
+	fakeReceivedMessagesByType := testUtilConstructCounter()
+	fakeDroppedMessages := testUtilConstructCounter()
+	fakeSendLatency := testUtilConstructHist()
+
 	fakeTotalGauge := testUtilConstructGauge()
 
 	fakeValidationDuration := testUtilConstructHist()
@@ -71,6 +81,12 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeSentMessages
 		case gmetrics.ReceivedMessagesOpts.Name:
 			return fakeReceivedMessages
+		case gmetrics.SentMessagesByTypeOpts.Name:
+			return fakeSentMessagesByType
+		case gmetrics.ReceivedMessagesByTypeOpts.Name:
+			return fakeReceivedMessagesByType
+		case gmetrics.DroppedMessagesOpts.Name:
+			return fakeDroppedMessages
 		}
 		return nil
 	}
@@ -96,6 +112,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakePullDuration
 		case gmetrics.RetrieveDurationOpts.Name:
 			return fakeRetrieveDuration
+		case gmetrics.SendLatencyOpts.Name:
+			return fakeSendLatency
 		}
 		return nil
 	}
@@ -122,6 +140,10 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeSentMessages,
 		fakeBufferOverflow,
 		fakeReceivedMessages,
+		fakeSentMessagesByType,
+		fakeReceivedMessagesByType,
+		fakeDroppedMessages,
+		fakeSendLatency,
 		fakeTotalGauge,
 		fakeValidationDuration,
 		fakeListMissingPrivateDataDuration,
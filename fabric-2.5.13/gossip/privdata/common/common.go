@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package common
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric-protos-go/gossip"
 	"github.com/hyperledger/fabric-protos-go/peer"
 )
@@ -32,3 +34,11 @@ type FetchedPvtDataContainer struct {
 	AvailableElements []*gossip.PvtDataElement
 	PurgedElements    []*gossip.PvtDataDigest
 }
+
+// SourceAttempt reports the requests for missing private data sent to a peer
+type SourceAttempt struct {
+	Endpoint    string    `json:"endpoint"`
+	Requested   uint64    `json:"requested"`
+	Received    uint64    `json:"received"`
+	LastAttempt time.Time `json:"last_attempt"`
+}
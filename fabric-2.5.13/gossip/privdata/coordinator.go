@@ -174,6 +174,9 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		PvtData:        make(ledger.TxPvtDataMap),
 		MissingPvtData: make(ledger.TxMissingPvtData),
 	}
+	if p, ok := c.Validator.(txvalidator.InvalidationMessagesProvider); ok {
+		blockAndPvtData.InvalidationMessages = p.InvalidationMessages(block.Header.Number)
+	}
 
 	exist, err := c.DoesPvtDataInfoExistInLedger(block.Header.Number)
 	if err != nil {
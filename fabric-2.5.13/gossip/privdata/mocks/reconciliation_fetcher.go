@@ -32,3 +32,19 @@ func (_m *ReconciliationFetcher) FetchReconciledItems(dig2collectionConfig commo
 
 	return r0, r1
 }
+
+// SourceAttempts provides a mock function with given fields:
+func (_m *ReconciliationFetcher) SourceAttempts() []*common.SourceAttempt {
+	ret := _m.Called()
+
+	var r0 []*common.SourceAttempt
+	if rf, ok := ret.Get(0).(func() []*common.SourceAttempt); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*common.SourceAttempt)
+		}
+	}
+
+	return r0
+}
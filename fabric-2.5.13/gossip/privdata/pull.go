@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand/v2"
+	"sort"
 	"sync"
 	"time"
 
@@ -78,6 +79,8 @@ type puller struct {
 	channel       string
 	cs            privdata.CollectionStore
 	btlPullMargin uint64
+	// reconciliationSources records the requests sent to peers for reconciling missing private data
+	reconciliationSources *sourceAttempts
 	gossip
 	PrivateDataRetriever
 	CollectionAccessFactory
@@ -94,6 +97,7 @@ func NewPuller(metrics *metrics.PrivdataMetrics, cs privdata.CollectionStore, g
 		channel:                 channel,
 		cs:                      cs,
 		btlPullMargin:           btlPullMargin,
+		reconciliationSources:   newSourceAttempts(),
 		gossip:                  g,
 		PrivateDataRetriever:    dataRetriever,
 		CollectionAccessFactory: factory,
@@ -230,7 +234,7 @@ func (p *puller) fetch(dig2src dig2sources) (*privdatacommon.FetchedPvtDataConta
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return p.fetchPrivateData(dig2Filter)
+	return p.fetchPrivateData(dig2Filter, nil)
 }
 
 func (p *puller) FetchReconciledItems(dig2collectionConfig privdatacommon.Dig2CollectionConfig) (*privdatacommon.FetchedPvtDataContainer, error) {
@@ -239,10 +243,15 @@ func (p *puller) FetchReconciledItems(dig2collectionConfig privdatacommon.Dig2Co
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return p.fetchPrivateData(dig2Filter)
+	return p.fetchPrivateData(dig2Filter, p.reconciliationSources)
 }
 
-func (p *puller) fetchPrivateData(dig2Filter digestToFilterMapping) (*privdatacommon.FetchedPvtDataContainer, error) {
+// SourceAttempts returns, per peer, the requests for missing private data sent during reconciliation
+func (p *puller) SourceAttempts() []*privdatacommon.SourceAttempt {
+	return p.reconciliationSources.list()
+}
+
+func (p *puller) fetchPrivateData(dig2Filter digestToFilterMapping, sources *sourceAttempts) (*privdatacommon.FetchedPvtDataContainer, error) {
 	// Get a list of peers per channel
 	allFilters := dig2Filter.flattenFilterValues()
 	members := p.waitForMembership()
@@ -293,6 +302,7 @@ func (p *puller) fetchPrivateData(dig2Filter digestToFilterMapping) (*privdataco
 		p.logger.Debug("Matched", len(dig2Filter), "digests to", len(peer2digests), "peer(s)")
 		subscriptions := p.scatterRequests(peer2digests)
 		responses := p.gatherResponses(subscriptions)
+		sources.record(peer2digests, responses)
 		for _, resp := range responses {
 			if len(resp.Payload) == 0 {
 				p.logger.Debug("Got empty response for", resp.Digest)
@@ -702,6 +712,72 @@ func digestsAsPointerSlice(digests []protosgossip.PvtDataDigest) []*protosgossip
 	return res
 }
 
+type sourceAttempts struct {
+	lock     sync.Mutex
+	attempts map[string]*privdatacommon.SourceAttempt
+}
+
+func newSourceAttempts() *sourceAttempts {
+	return &sourceAttempts{attempts: map[string]*privdatacommon.SourceAttempt{}}
+}
+
+// record accounts for the digests requested from each peer and for the private data received in response
+func (s *sourceAttempts) record(peer2digests peer2Digests, responses []*protosgossip.PvtDataElement) {
+	if s == nil {
+		return
+	}
+	now := time.Now()
+	dig2endpoint := make(map[privdatacommon.DigKey]string)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for peer, digests := range peer2digests {
+		attempt, exists := s.attempts[peer.endpoint]
+		if !exists {
+			attempt = &privdatacommon.SourceAttempt{Endpoint: peer.endpoint}
+			s.attempts[peer.endpoint] = attempt
+		}
+		attempt.Requested += uint64(len(digests))
+		attempt.LastAttempt = now
+		for _, dig := range digests {
+			dig2endpoint[digKeyOf(&dig)] = peer.endpoint
+		}
+	}
+	for _, resp := range responses {
+		if len(resp.Payload) == 0 {
+			continue
+		}
+		if endpoint, exists := dig2endpoint[digKeyOf(resp.Digest)]; exists {
+			s.attempts[endpoint].Received++
+		}
+	}
+}
+
+// list returns a copy of the attempts, ordered by peer endpoint
+func (s *sourceAttempts) list() []*privdatacommon.SourceAttempt {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	res := make([]*privdatacommon.SourceAttempt, 0, len(s.attempts))
+	for _, attempt := range s.attempts {
+		a := *attempt
+		res = append(res, &a)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Endpoint < res[j].Endpoint
+	})
+	return res
+}
+
+func digKeyOf(dig *protosgossip.PvtDataDigest) privdatacommon.DigKey {
+	return privdatacommon.DigKey{
+		TxId:       dig.TxId,
+		Namespace:  dig.Namespace,
+		Collection: dig.Collection,
+		BlockSeq:   dig.BlockSeq,
+		SeqInBlock: dig.SeqInBlock,
+	}
+}
+
 type remotePeer struct {
 	endpoint string
 	pkiID    string
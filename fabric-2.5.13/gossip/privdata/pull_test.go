@@ -1073,7 +1073,7 @@ func TestPullerIntegratedWithDataRetreiver(t *testing.T) {
 		t.Fatalf("Failed to create test directory, got err %s", err)
 		return
 	}
-	storeProvider, err := transientstore.NewStoreProvider(tempdir)
+	storeProvider, err := transientstore.NewStoreProvider(tempdir, nil)
 	if err != nil {
 		t.Fatalf("Failed to open store, got err %s", err)
 		return
@@ -1227,3 +1227,32 @@ func TestPullerMetrics(t *testing.T) {
 	)
 	require.True(t, testMetricProvider.FakeRetrieveDuration.ObserveArgsForCall(0) > 0)
 }
+
+func TestSourceAttempts(t *testing.T) {
+	sources := newSourceAttempts()
+	dig1 := proto.PvtDataDigest{TxId: "tx1", BlockSeq: 1, Namespace: "ns1", Collection: "c1"}
+	dig2 := proto.PvtDataDigest{TxId: "tx2", BlockSeq: 2, Namespace: "ns1", Collection: "c1"}
+	dig3 := proto.PvtDataDigest{TxId: "tx3", BlockSeq: 3, Namespace: "ns1", Collection: "c1"}
+	peer2digests := peer2Digests{
+		remotePeer{endpoint: "p2", pkiID: "p2"}: {dig1, dig2},
+		remotePeer{endpoint: "p1", pkiID: "p1"}: {dig3},
+	}
+	sources.record(peer2digests, []*proto.PvtDataElement{
+		{Digest: &dig1, Payload: [][]byte{[]byte("rws")}},
+		{Digest: &dig2},
+	})
+	sources.record(peer2Digests{remotePeer{endpoint: "p1", pkiID: "p1"}: {dig1}}, nil)
+
+	attempts := sources.list()
+	require.Len(t, attempts, 2)
+	require.Equal(t, "p1", attempts[0].Endpoint)
+	require.Equal(t, uint64(2), attempts[0].Requested)
+	require.Equal(t, uint64(0), attempts[0].Received)
+	require.Equal(t, "p2", attempts[1].Endpoint)
+	require.Equal(t, uint64(2), attempts[1].Requested)
+	require.Equal(t, uint64(1), attempts[1].Received)
+	require.False(t, attempts[1].LastAttempt.IsZero())
+
+	var noSources *sourceAttempts
+	noSources.record(peer2digests, nil)
+}
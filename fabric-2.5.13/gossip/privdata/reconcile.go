@@ -9,6 +9,7 @@ package privdata
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -42,6 +43,8 @@ type ConfigHistoryRetriever interface {
 // private data elements that have to be reconciled.
 type ReconciliationFetcher interface {
 	FetchReconciledItems(dig2collectionConfig privdatacommon.Dig2CollectionConfig) (*privdatacommon.FetchedPvtDataContainer, error)
+	// SourceAttempts returns, per peer, the requests for missing private data sent during reconciliation
+	SourceAttempts() []*privdatacommon.SourceAttempt
 }
 
 // PvtDataReconciler completes missing parts of private data that weren't available during commit time.
@@ -51,6 +54,45 @@ type PvtDataReconciler interface {
 	Start()
 	// Stop function stops reconciler
 	Stop()
+	// Pause suspends the scheduled reconciliation until Resume is called
+	Pause() error
+	// Resume resumes the scheduled reconciliation
+	Resume() error
+	// Reconcile immediately reconciles the missing private data selected by the filter,
+	// and returns the number of private data items that were reconciled
+	Reconcile(filter *ReconciliationFilter) (int, error)
+	// ListMissing returns up to limit missing private data items selected by the filter,
+	// starting from the most recent block. A limit of zero returns all the items.
+	ListMissing(filter *ReconciliationFilter, limit int) ([]*MissingPvtData, error)
+	// Status returns the status of the reconciler
+	Status() *ReconcilerStatus
+}
+
+// ReconciliationFilter selects missing private data by block range and collection.
+// A zero ToBlock does not bound the range and an empty Namespace or Collection matches any.
+type ReconciliationFilter struct {
+	FromBlock  uint64
+	ToBlock    uint64
+	Namespace  string
+	Collection string
+}
+
+// MissingPvtData identifies the private data of a collection missing for a transaction
+type MissingPvtData struct {
+	BlockNum   uint64 `json:"block_num"`
+	TxNum      uint64 `json:"tx_num"`
+	Namespace  string `json:"namespace"`
+	Collection string `json:"collection"`
+}
+
+// ReconcilerStatus reports the state of the reconciler of a channel
+type ReconcilerStatus struct {
+	Enabled        bool                            `json:"enabled"`
+	Paused         bool                            `json:"paused"`
+	LastRun        *time.Time                      `json:"last_run,omitempty"`
+	LastReconciled int                             `json:"last_reconciled"`
+	LastError      string                          `json:"last_error,omitempty"`
+	SourceAttempts []*privdatacommon.SourceAttempt `json:"source_attempts"`
 }
 
 type Reconciler struct {
@@ -62,6 +104,14 @@ type Reconciler struct {
 	stopChan               chan struct{}
 	startOnce              sync.Once
 	stopOnce               sync.Once
+	// reconcileLock serializes the scheduled and the operator triggered reconciliations, and the listings
+	// of the missing private data
+	reconcileLock sync.Mutex
+	statusLock    sync.Mutex
+	paused        bool
+	lastRun       time.Time
+	lastCount     int
+	lastErr       error
 	ReconciliationFetcher
 	committer.Committer
 }
@@ -70,6 +120,8 @@ type Reconciler struct {
 // in case reconciliation has been disabled
 type NoOpReconciler struct{}
 
+var errReconciliationDisabled = errors.New("private data reconciliation is disabled")
+
 func (*NoOpReconciler) Start() {
 	// do nothing
 	logger.Debug("Private data reconciliation has been disabled")
@@ -79,6 +131,26 @@ func (*NoOpReconciler) Stop() {
 	// do nothing
 }
 
+func (*NoOpReconciler) Pause() error {
+	return errReconciliationDisabled
+}
+
+func (*NoOpReconciler) Resume() error {
+	return errReconciliationDisabled
+}
+
+func (*NoOpReconciler) Reconcile(filter *ReconciliationFilter) (int, error) {
+	return 0, errReconciliationDisabled
+}
+
+func (*NoOpReconciler) ListMissing(filter *ReconciliationFilter, limit int) ([]*MissingPvtData, error) {
+	return nil, errReconciliationDisabled
+}
+
+func (*NoOpReconciler) Status() *ReconcilerStatus {
+	return &ReconcilerStatus{}
+}
+
 // NewReconciler creates a new instance of reconciler
 func NewReconciler(channel string, metrics *metrics.PrivdataMetrics, c committer.Committer,
 	fetcher ReconciliationFetcher, config *PrivdataConfig) *Reconciler {
@@ -114,24 +186,140 @@ func (r *Reconciler) run() {
 		case <-r.stopChan:
 			return
 		case <-time.After(r.ReconcileSleepInterval):
+			if r.isPaused() {
+				r.logger.Debug("Reconciliation is paused, skipping")
+				continue
+			}
 			r.logger.Debug("Start reconcile missing private info")
-			if err := r.reconcile(); err != nil {
+			if _, err := r.reconcileAndRecord(nil); err != nil {
 				r.logger.Error("Failed to reconcile missing private info, error: ", err.Error())
 			}
 		}
 	}
 }
 
-// returns the number of items that were reconciled , minBlock, maxBlock (blocks range) and an error
+// Pause suspends the scheduled reconciliation until Resume is called
+func (r *Reconciler) Pause() error {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	r.paused = true
+	r.logger.Info("Paused private data reconciliation")
+	return nil
+}
+
+// Resume resumes the scheduled reconciliation
+func (r *Reconciler) Resume() error {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	r.paused = false
+	r.logger.Info("Resumed private data reconciliation")
+	return nil
+}
+
+func (r *Reconciler) isPaused() bool {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+	return r.paused
+}
+
+// Reconcile immediately reconciles the missing private data selected by the filter, even if
+// the scheduled reconciliation is paused, and returns the number of reconciled items
+func (r *Reconciler) Reconcile(filter *ReconciliationFilter) (int, error) {
+	r.logger.Infof("Reconciling missing private data on demand, filter %+v", filter)
+	return r.reconcileAndRecord(filter)
+}
+
+// Status returns the status of the reconciler
+func (r *Reconciler) Status() *ReconcilerStatus {
+	r.statusLock.Lock()
+	status := &ReconcilerStatus{
+		Enabled:        true,
+		Paused:         r.paused,
+		LastReconciled: r.lastCount,
+	}
+	if !r.lastRun.IsZero() {
+		lastRun := r.lastRun
+		status.LastRun = &lastRun
+	}
+	if r.lastErr != nil {
+		status.LastError = r.lastErr.Error()
+	}
+	r.statusLock.Unlock()
+
+	status.SourceAttempts = r.SourceAttempts()
+	return status
+}
+
+// ListMissing returns up to limit missing private data items selected by the filter, starting
+// from the most recent block. A limit of zero returns all the items. As reading the missing private
+// data moves the state the ledger keeps for alternating between the prioritized and the deprioritized
+// missing data, the listing is serialized with the reconciliations.
+func (r *Reconciler) ListMissing(filter *ReconciliationFilter, limit int) ([]*MissingPvtData, error) {
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
+	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	if err != nil {
+		return nil, err
+	}
+	if missingPvtDataTracker == nil {
+		return nil, errors.New("got nil as MissingPvtDataTracker")
+	}
+
+	var res []*MissingPvtData
+	for limit <= 0 || len(res) < limit {
+		missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(r.batchSize())
+		if err != nil {
+			return nil, err
+		}
+		if len(missingPvtDataInfo) == 0 || filter.isBelowRange(missingPvtDataInfo) {
+			break
+		}
+		res = append(res, sortedMissingPvtData(filter.apply(missingPvtDataInfo))...)
+	}
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (r *Reconciler) batchSize() int {
+	if r.ReconcileBatchSize < 1 {
+		return 1
+	}
+	return r.ReconcileBatchSize
+}
+
+// reconcileAndRecord runs a reconciliation and records its outcome in the status of the reconciler
+func (r *Reconciler) reconcileAndRecord(filter *ReconciliationFilter) (int, error) {
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
+	count, err := r.reconcileMissing(filter)
+
+	r.statusLock.Lock()
+	r.lastRun = time.Now()
+	r.lastCount = count
+	r.lastErr = err
+	r.statusLock.Unlock()
+	return count, err
+}
+
 func (r *Reconciler) reconcile() error {
+	_, err := r.reconcileMissing(nil)
+	return err
+}
+
+// returns the number of items that were reconciled and an error
+func (r *Reconciler) reconcileMissing(filter *ReconciliationFilter) (int, error) {
 	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
 	if err != nil {
 		r.logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
-		return err
+		return 0, err
 	}
 	if missingPvtDataTracker == nil {
 		r.logger.Error("got nil as MissingPvtDataTracker, exiting...")
-		return errors.New("got nil as MissingPvtDataTracker, exiting...")
+		return 0, errors.New("got nil as MissingPvtDataTracker, exiting...")
 	}
 	totalReconciled, minBlock, maxBlock := 0, uint64(math.MaxUint64), uint64(0)
 
@@ -141,7 +329,12 @@ func (r *Reconciler) reconcile() error {
 		missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(r.ReconcileBatchSize)
 		if err != nil {
 			r.logger.Error("reconciliation error when trying to get missing pvt data info recent blocks:", err)
-			return err
+			return totalReconciled, err
+		}
+		// as the missing private data is returned from the most recent block on, there is no
+		// missing private data left in the range of the filter once the blocks are below the range
+		if filter.isBelowRange(missingPvtDataInfo) {
+			missingPvtDataInfo = nil
 		}
 		// if missingPvtDataInfo is nil, len will return 0
 		if len(missingPvtDataInfo) == 0 {
@@ -150,7 +343,13 @@ func (r *Reconciler) reconcile() error {
 			} else {
 				r.logger.Debug("Reconciliation cycle finished successfully. no items to reconcile")
 			}
-			return nil
+			return totalReconciled, nil
+		}
+		// the missing private data that does not match the filter is left untouched, so that it
+		// is reconciled by a later reconciliation
+		missingPvtDataInfo = filter.apply(missingPvtDataInfo)
+		if len(missingPvtDataInfo) == 0 {
+			continue
 		}
 
 		r.logger.Debug("got from ledger", len(missingPvtDataInfo), "blocks with missing private data, trying to reconcile...")
@@ -159,14 +358,14 @@ func (r *Reconciler) reconcile() error {
 		fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
 		if err != nil {
 			r.logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
-			return err
+			return totalReconciled, err
 		}
 
 		pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
 		unreconciled := constructUnreconciledMissingData(dig2collectionCfg, fetchedData.AvailableElements)
 		pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit, unreconciled)
 		if err != nil {
-			return errors.Wrap(err, "failed to commit private data")
+			return totalReconciled, errors.Wrap(err, "failed to commit private data")
 		}
 		r.logMismatched(pvtdataHashMismatch)
 		if minB < minBlock {
@@ -179,6 +378,80 @@ func (r *Reconciler) reconcile() error {
 	}
 }
 
+// isBelowRange returns true if all the blocks of the missing private data are below the range of the filter
+func (f *ReconciliationFilter) isBelowRange(missingPvtDataInfo ledger.MissingPvtDataInfo) bool {
+	if f == nil || len(missingPvtDataInfo) == 0 {
+		return false
+	}
+	for blockNum := range missingPvtDataInfo {
+		if blockNum >= f.FromBlock {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *ReconciliationFilter) matches(blockNum uint64, info *ledger.MissingCollectionPvtDataInfo) bool {
+	if f == nil {
+		return true
+	}
+	if blockNum < f.FromBlock || (f.ToBlock != 0 && blockNum > f.ToBlock) {
+		return false
+	}
+	return (f.Namespace == "" || f.Namespace == info.Namespace) &&
+		(f.Collection == "" || f.Collection == info.Collection)
+}
+
+// apply returns the missing private data that matches the filter
+func (f *ReconciliationFilter) apply(missingPvtDataInfo ledger.MissingPvtDataInfo) ledger.MissingPvtDataInfo {
+	if f == nil {
+		return missingPvtDataInfo
+	}
+	filtered := ledger.MissingPvtDataInfo{}
+	for blockNum, blockPvtDataInfo := range missingPvtDataInfo {
+		for seqInBlock, collectionPvtDataInfo := range blockPvtDataInfo {
+			for _, pvtDataInfo := range collectionPvtDataInfo {
+				if f.matches(blockNum, pvtDataInfo) {
+					filtered.Add(blockNum, seqInBlock, pvtDataInfo.Namespace, pvtDataInfo.Collection)
+				}
+			}
+		}
+	}
+	return filtered
+}
+
+// sortedMissingPvtData flattens the missing private data, ordered by descending block number
+// and then by transaction number, namespace and collection
+func sortedMissingPvtData(missingPvtDataInfo ledger.MissingPvtDataInfo) []*MissingPvtData {
+	var res []*MissingPvtData
+	for blockNum, blockPvtDataInfo := range missingPvtDataInfo {
+		for seqInBlock, collectionPvtDataInfo := range blockPvtDataInfo {
+			for _, pvtDataInfo := range collectionPvtDataInfo {
+				res = append(res, &MissingPvtData{
+					BlockNum:   blockNum,
+					TxNum:      seqInBlock,
+					Namespace:  pvtDataInfo.Namespace,
+					Collection: pvtDataInfo.Collection,
+				})
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i], res[j]
+		if a.BlockNum != b.BlockNum {
+			return a.BlockNum > b.BlockNum
+		}
+		if a.TxNum != b.TxNum {
+			return a.TxNum < b.TxNum
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Collection < b.Collection
+	})
+	return res
+}
+
 func (r *Reconciler) reportReconciliationDuration(startTime time.Time) {
 	r.metrics.ReconciliationDuration.With("channel", r.channel).Observe(time.Since(startTime).Seconds())
 }
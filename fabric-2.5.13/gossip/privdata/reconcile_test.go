@@ -708,3 +708,211 @@ func TestConstructUnreconciledMissingData(t *testing.T) {
 		})
 	}
 }
+
+func newTestMissingPvtDataTracker(batches ...ledger.MissingPvtDataInfo) *mocks.MissingPvtDataTracker {
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+	for _, batch := range batches {
+		missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(batch, nil).Once()
+	}
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(nil, nil)
+	return missingPvtDataTracker
+}
+
+func TestReconcileWithFilter(t *testing.T) {
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := newTestMissingPvtDataTracker(
+		ledger.MissingPvtDataInfo{
+			9: ledger.MissingBlockPvtdataInfo{1: {{Namespace: "ns1", Collection: "col1"}}},
+		},
+		ledger.MissingPvtDataInfo{
+			7: ledger.MissingBlockPvtdataInfo{
+				1: {{Namespace: "ns1", Collection: "col1"}, {Namespace: "ns1", Collection: "col2"}},
+				2: {{Namespace: "ns2", Collection: "col1"}},
+			},
+		},
+		ledger.MissingPvtDataInfo{
+			3: ledger.MissingBlockPvtdataInfo{1: {{Namespace: "ns1", Collection: "col1"}}},
+		},
+		ledger.MissingPvtDataInfo{
+			2: ledger.MissingBlockPvtdataInfo{1: {{Namespace: "ns1", Collection: "col1"}}},
+		},
+	)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(&ledger.CollectionConfigInfo{
+		CollectionConfig: &peer.CollectionConfigPackage{
+			Config: []*peer.CollectionConfig{
+				{Payload: &peer.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: &peer.StaticCollectionConfig{Name: "col1"}}},
+				{Payload: &peer.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: &peer.StaticCollectionConfig{Name: "col2"}}},
+			},
+		},
+	}, nil)
+
+	var requested []privdatacommon.DigKey
+	fetcher.On("FetchReconciledItems", mock.Anything).Return(func(dig2CollectionConfig privdatacommon.Dig2CollectionConfig) *privdatacommon.FetchedPvtDataContainer {
+		result := &privdatacommon.FetchedPvtDataContainer{}
+		for digest := range dig2CollectionConfig {
+			requested = append(requested, digest)
+			result.AvailableElements = append(result.AvailableElements, &gossip2.PvtDataElement{
+				Digest: &gossip2.PvtDataDigest{
+					BlockSeq:   digest.BlockSeq,
+					SeqInBlock: digest.SeqInBlock,
+					Namespace:  digest.Namespace,
+					Collection: digest.Collection,
+				},
+				Payload: [][]byte{[]byte("rws")},
+			})
+		}
+		return result
+	}, nil)
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything, mock.Anything).Return(nil, nil)
+	fetcher.On("SourceAttempts").Return([]*privdatacommon.SourceAttempt{{Endpoint: "peer1:7051", Requested: 2, Received: 1}})
+
+	r := NewReconciler("mychannel", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: time.Minute, ReconcileBatchSize: 1, ReconciliationEnabled: true})
+
+	reconciled, err := r.Reconcile(&ReconciliationFilter{FromBlock: 4, ToBlock: 8, Collection: "col1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, reconciled)
+	require.ElementsMatch(t, []privdatacommon.DigKey{
+		{BlockSeq: 7, SeqInBlock: 1, Namespace: "ns1", Collection: "col1"},
+		{BlockSeq: 7, SeqInBlock: 2, Namespace: "ns2", Collection: "col1"},
+	}, requested)
+	// the reconciliation stops once the blocks are below the range of the filter
+	missingPvtDataTracker.AssertNumberOfCalls(t, "GetMissingPvtDataInfoForMostRecentBlocks", 3)
+
+	status := r.Status()
+	require.True(t, status.Enabled)
+	require.False(t, status.Paused)
+	require.NotNil(t, status.LastRun)
+	require.Equal(t, 2, status.LastReconciled)
+	require.Empty(t, status.LastError)
+	require.Equal(t, []*privdatacommon.SourceAttempt{{Endpoint: "peer1:7051", Requested: 2, Received: 1}}, status.SourceAttempts)
+}
+
+func TestListMissingPvtData(t *testing.T) {
+	committer := &mocks.Committer{}
+	committer.On("GetMissingPvtDataTracker").Return(newTestMissingPvtDataTracker(
+		ledger.MissingPvtDataInfo{
+			9: ledger.MissingBlockPvtdataInfo{
+				2: {{Namespace: "ns1", Collection: "col1"}},
+				1: {{Namespace: "ns2", Collection: "col1"}, {Namespace: "ns1", Collection: "col2"}},
+			},
+		},
+		ledger.MissingPvtDataInfo{
+			3: ledger.MissingBlockPvtdataInfo{1: {{Namespace: "ns1", Collection: "col1"}}},
+		},
+	), nil)
+	r := NewReconciler("mychannel", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer, &mocks.ReconciliationFetcher{},
+		&PrivdataConfig{ReconcileSleepInterval: time.Minute, ReconcileBatchSize: 1, ReconciliationEnabled: true})
+
+	missing, err := r.ListMissing(nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*MissingPvtData{
+		{BlockNum: 9, TxNum: 1, Namespace: "ns1", Collection: "col2"},
+		{BlockNum: 9, TxNum: 1, Namespace: "ns2", Collection: "col1"},
+		{BlockNum: 9, TxNum: 2, Namespace: "ns1", Collection: "col1"},
+		{BlockNum: 3, TxNum: 1, Namespace: "ns1", Collection: "col1"},
+	}, missing)
+
+	committer.Mock = mock.Mock{}
+	committer.On("GetMissingPvtDataTracker").Return(newTestMissingPvtDataTracker(
+		ledger.MissingPvtDataInfo{
+			9: ledger.MissingBlockPvtdataInfo{
+				2: {{Namespace: "ns1", Collection: "col1"}},
+				1: {{Namespace: "ns2", Collection: "col1"}},
+			},
+		},
+		ledger.MissingPvtDataInfo{
+			3: ledger.MissingBlockPvtdataInfo{1: {{Namespace: "ns1", Collection: "col1"}}},
+		},
+	), nil)
+	missing, err = r.ListMissing(&ReconciliationFilter{Namespace: "ns1"}, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*MissingPvtData{{BlockNum: 9, TxNum: 2, Namespace: "ns1", Collection: "col1"}}, missing)
+}
+
+func TestListMissingPvtDataWaitsForReconciliation(t *testing.T) {
+	committer := &mocks.Committer{}
+	inReconciliation := make(chan struct{})
+	release := make(chan struct{})
+	committer.On("GetMissingPvtDataTracker").Return(nil, errors.New("no tracker")).Run(func(mock.Arguments) {
+		select {
+		case inReconciliation <- struct{}{}:
+			<-release
+		default:
+		}
+	})
+	fetcher := &mocks.ReconciliationFetcher{}
+	r := NewReconciler("mychannel", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: time.Minute, ReconcileBatchSize: 1, ReconciliationEnabled: true})
+
+	go r.Reconcile(nil)
+	<-inReconciliation
+
+	listed := make(chan struct{})
+	go func() {
+		r.ListMissing(nil, 0)
+		close(listed)
+	}()
+	require.Never(t, func() bool {
+		select {
+		case <-listed:
+			return true
+		default:
+			return false
+		}
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		select {
+		case <-listed:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReconcilerPause(t *testing.T) {
+	committer := &mocks.Committer{}
+	reconciled := make(chan struct{}, 10)
+	committer.On("GetMissingPvtDataTracker").Return(nil, errors.New("no tracker")).Run(func(mock.Arguments) {
+		reconciled <- struct{}{}
+	})
+	fetcher := &mocks.ReconciliationFetcher{}
+	fetcher.On("SourceAttempts").Return(nil)
+	r := NewReconciler("mychannel", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: 10 * time.Millisecond, ReconcileBatchSize: 1, ReconciliationEnabled: true})
+
+	require.NoError(t, r.Pause())
+	require.True(t, r.Status().Paused)
+	r.Start()
+	defer r.Stop()
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, reconciled)
+
+	// an immediate reconciliation is performed even while paused
+	_, err := r.Reconcile(nil)
+	require.EqualError(t, err, "no tracker")
+	require.Len(t, reconciled, 1)
+	require.Equal(t, "no tracker", r.Status().LastError)
+
+	require.NoError(t, r.Resume())
+	require.False(t, r.Status().Paused)
+	require.Eventually(t, func() bool { return len(reconciled) > 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestNoOpReconcilerControls(t *testing.T) {
+	r := &NoOpReconciler{}
+	require.EqualError(t, r.Pause(), "private data reconciliation is disabled")
+	require.EqualError(t, r.Resume(), "private data reconciliation is disabled")
+	_, err := r.Reconcile(nil)
+	require.EqualError(t, err, "private data reconciliation is disabled")
+	_, err = r.ListMissing(nil, 0)
+	require.EqualError(t, err, "private data reconciliation is disabled")
+	require.False(t, r.Status().Enabled)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/fabhttp"
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+const (
+	// ReconciliationPath is the path of the operations endpoint that reports the status of the
+	// reconciler of a channel, and that triggers, pauses and resumes the reconciliation.
+	ReconciliationPath = "/pvtdata/reconciliation"
+	// MissingPvtDataPath is the path of the operations endpoint that lists the missing private data of a channel.
+	MissingPvtDataPath = "/pvtdata/missing"
+)
+
+// ReconcilerFunc returns the reconciler of a channel, and false if the channel does not exist.
+type ReconcilerFunc func(channelID string) (PvtDataReconciler, bool)
+
+// ReconciliationStatusResponse is the response of the reconciliation handler to a status request,
+// and to a request to pause or resume the reconciliation.
+type ReconciliationStatusResponse struct {
+	Channel string `json:"channel"`
+	*ReconcilerStatus
+}
+
+// ReconcileResponse is the response of the reconciliation handler to a request to reconcile.
+type ReconcileResponse struct {
+	Channel    string `json:"channel"`
+	Reconciled int    `json:"reconciled"`
+}
+
+// MissingPvtDataResponse is the response of the reconciliation handler to a request to list the
+// missing private data.
+type MissingPvtDataResponse struct {
+	Channel string            `json:"channel"`
+	Missing []*MissingPvtData `json:"missing"`
+}
+
+// ReconciliationHandler serves the operations endpoints for steering the reconciliation of
+// missing private data, for the channel specified via the query parameter "channel".
+//
+// GET  /pvtdata/reconciliation returns the status of the reconciler.
+// POST /pvtdata/reconciliation?action=pause|resume pauses or resumes the scheduled reconciliation.
+// POST /pvtdata/reconciliation?action=reconcile reconciles the missing private data immediately.
+// GET  /pvtdata/missing lists the missing private data, up to the query parameter "limit" items.
+//
+// The query parameters "from", "to", "namespace" and "collection" select the missing private data
+// to reconcile or to list.
+type ReconciliationHandler struct {
+	Reconciler ReconcilerFunc
+	Logger     *flogging.FabricLogger
+}
+
+func NewReconciliationHandler(reconciler ReconcilerFunc) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		Reconciler: reconciler,
+		Logger:     flogging.MustGetLogger("gossip.privdata.reconciliation"),
+	}
+}
+
+func (h *ReconciliationHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	channelID := req.URL.Query().Get("channel")
+	if channelID == "" {
+		fabhttp.SendResponse(resp, http.StatusBadRequest, fmt.Errorf("missing query parameter: channel"), h.Logger)
+		return
+	}
+	reconciler, ok := h.Reconciler(channelID)
+	if !ok {
+		fabhttp.SendResponse(resp, http.StatusNotFound, fmt.Errorf("channel does not exist: %s", channelID), h.Logger)
+		return
+	}
+
+	switch {
+	case req.URL.Path == MissingPvtDataPath && req.Method == http.MethodGet:
+		h.listMissing(resp, req, channelID, reconciler)
+	case req.URL.Path == ReconciliationPath && req.Method == http.MethodGet:
+		fabhttp.SendResponse(resp, http.StatusOK, &ReconciliationStatusResponse{Channel: channelID, ReconcilerStatus: reconciler.Status()}, h.Logger)
+	case req.URL.Path == ReconciliationPath && req.Method == http.MethodPost:
+		h.act(resp, req, channelID, reconciler)
+	default:
+		fabhttp.SendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method), h.Logger)
+	}
+}
+
+func (h *ReconciliationHandler) listMissing(resp http.ResponseWriter, req *http.Request, channelID string, reconciler PvtDataReconciler) {
+	filter, err := parseReconciliationFilter(req)
+	if err != nil {
+		fabhttp.SendResponse(resp, http.StatusBadRequest, err, h.Logger)
+		return
+	}
+	limit := 0
+	if l := req.URL.Query().Get("limit"); l != "" {
+		if limit, err = strconv.Atoi(l); err != nil || limit < 0 {
+			fabhttp.SendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid query parameter limit: %s", l), h.Logger)
+			return
+		}
+	}
+
+	missing, err := reconciler.ListMissing(filter, limit)
+	if err != nil {
+		fabhttp.SendResponse(resp, http.StatusServiceUnavailable, err, h.Logger)
+		return
+	}
+	if missing == nil {
+		missing = []*MissingPvtData{}
+	}
+	fabhttp.SendResponse(resp, http.StatusOK, &MissingPvtDataResponse{Channel: channelID, Missing: missing}, h.Logger)
+}
+
+func (h *ReconciliationHandler) act(resp http.ResponseWriter, req *http.Request, channelID string, reconciler PvtDataReconciler) {
+	var err error
+	switch action := req.URL.Query().Get("action"); action {
+	case "pause":
+		err = reconciler.Pause()
+	case "resume":
+		err = reconciler.Resume()
+	case "reconcile":
+		var filter *ReconciliationFilter
+		if filter, err = parseReconciliationFilter(req); err != nil {
+			fabhttp.SendResponse(resp, http.StatusBadRequest, err, h.Logger)
+			return
+		}
+		var reconciled int
+		if reconciled, err = reconciler.Reconcile(filter); err != nil {
+			fabhttp.SendResponse(resp, http.StatusServiceUnavailable, err, h.Logger)
+			return
+		}
+		fabhttp.SendResponse(resp, http.StatusOK, &ReconcileResponse{Channel: channelID, Reconciled: reconciled}, h.Logger)
+		return
+	default:
+		fabhttp.SendResponse(resp, http.StatusBadRequest, fmt.Errorf("invalid query parameter action: %q", action), h.Logger)
+		return
+	}
+	if err != nil {
+		fabhttp.SendResponse(resp, http.StatusServiceUnavailable, err, h.Logger)
+		return
+	}
+	fabhttp.SendResponse(resp, http.StatusOK, &ReconciliationStatusResponse{Channel: channelID, ReconcilerStatus: reconciler.Status()}, h.Logger)
+}
+
+// parseReconciliationFilter returns the filter specified by the query parameters, or nil if none is specified
+func parseReconciliationFilter(req *http.Request) (*ReconciliationFilter, error) {
+	query := req.URL.Query()
+	filter := &ReconciliationFilter{
+		Namespace:  query.Get("namespace"),
+		Collection: query.Get("collection"),
+	}
+	for param, block := range map[string]*uint64{"from": &filter.FromBlock, "to": &filter.ToBlock} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		blockNum, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query parameter %s: %s", param, value)
+		}
+		*block = blockNum
+	}
+	if filter.ToBlock != 0 && filter.ToBlock < filter.FromBlock {
+		return nil, fmt.Errorf("invalid block range: from %d is greater than to %d", filter.FromBlock, filter.ToBlock)
+	}
+	if *filter == (ReconciliationFilter{}) {
+		return nil, nil
+	}
+	return filter, nil
+}
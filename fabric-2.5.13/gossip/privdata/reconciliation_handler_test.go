@@ -0,0 +1,211 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReconciler struct {
+	NoOpReconciler
+	paused    bool
+	filter    *ReconciliationFilter
+	limit     int
+	reconcile error
+}
+
+func (f *fakeReconciler) Pause() error {
+	f.paused = true
+	return nil
+}
+
+func (f *fakeReconciler) Resume() error {
+	f.paused = false
+	return nil
+}
+
+func (f *fakeReconciler) Reconcile(filter *ReconciliationFilter) (int, error) {
+	f.filter = filter
+	return 3, f.reconcile
+}
+
+func (f *fakeReconciler) ListMissing(filter *ReconciliationFilter, limit int) ([]*MissingPvtData, error) {
+	f.filter = filter
+	f.limit = limit
+	if filter != nil && filter.Namespace == "none" {
+		return nil, nil
+	}
+	return []*MissingPvtData{{BlockNum: 5, TxNum: 1, Namespace: "ns1", Collection: "col1"}}, nil
+}
+
+func (f *fakeReconciler) Status() *ReconcilerStatus {
+	return &ReconcilerStatus{Enabled: true, Paused: f.paused, LastReconciled: 3}
+}
+
+func TestReconciliationHandler(t *testing.T) {
+	reconciler := &fakeReconciler{}
+	handler := NewReconciliationHandler(func(channelID string) (PvtDataReconciler, bool) {
+		switch channelID {
+		case "mychannel":
+			return reconciler, true
+		case "disabled":
+			return &NoOpReconciler{}, true
+		default:
+			return nil, false
+		}
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		expectedCode   int
+		expectedBody   string
+		expectedFilter *ReconciliationFilter
+		expectedLimit  int
+	}{
+		{
+			name:         "status",
+			method:       http.MethodGet,
+			url:          "/pvtdata/reconciliation?channel=mychannel",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"channel":"mychannel","enabled":true,"paused":false,"last_reconciled":3,"source_attempts":null}`,
+		},
+		{
+			name:         "pause",
+			method:       http.MethodPost,
+			url:          "/pvtdata/reconciliation?channel=mychannel&action=pause",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"channel":"mychannel","enabled":true,"paused":true,"last_reconciled":3,"source_attempts":null}`,
+		},
+		{
+			name:         "resume",
+			method:       http.MethodPost,
+			url:          "/pvtdata/reconciliation?channel=mychannel&action=resume",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"channel":"mychannel","enabled":true,"paused":false,"last_reconciled":3,"source_attempts":null}`,
+		},
+		{
+			name:           "reconcile",
+			method:         http.MethodPost,
+			url:            "/pvtdata/reconciliation?channel=mychannel&action=reconcile&from=2&to=9&namespace=ns1&collection=col1",
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"channel":"mychannel","reconciled":3}`,
+			expectedFilter: &ReconciliationFilter{FromBlock: 2, ToBlock: 9, Namespace: "ns1", Collection: "col1"},
+		},
+		{
+			name:         "reconcile everything",
+			method:       http.MethodPost,
+			url:          "/pvtdata/reconciliation?channel=mychannel&action=reconcile",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"channel":"mychannel","reconciled":3}`,
+		},
+		{
+			name:           "list missing",
+			method:         http.MethodGet,
+			url:            "/pvtdata/missing?channel=mychannel&collection=col1&limit=10",
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"channel":"mychannel","missing":[{"block_num":5,"tx_num":1,"namespace":"ns1","collection":"col1"}]}`,
+			expectedFilter: &ReconciliationFilter{Collection: "col1"},
+			expectedLimit:  10,
+		},
+		{
+			name:           "nothing missing",
+			method:         http.MethodGet,
+			url:            "/pvtdata/missing?channel=mychannel&namespace=none",
+			expectedCode:   http.StatusOK,
+			expectedBody:   `{"channel":"mychannel","missing":[]}`,
+			expectedFilter: &ReconciliationFilter{Namespace: "none"},
+		},
+		{
+			name:         "invalid action",
+			method:       http.MethodPost,
+			url:          "/pvtdata/reconciliation?channel=mychannel&action=stop",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid query parameter action: \"stop\""}`,
+		},
+		{
+			name:         "invalid block",
+			method:       http.MethodPost,
+			url:          "/pvtdata/reconciliation?channel=mychannel&action=reconcile&from=a",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid query parameter from: a"}`,
+		},
+		{
+			name:         "invalid block range",
+			method:       http.MethodGet,
+			url:          "/pvtdata/missing?channel=mychannel&from=5&to=4",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid block range: from 5 is greater than to 4"}`,
+		},
+		{
+			name:         "invalid limit",
+			method:       http.MethodGet,
+			url:          "/pvtdata/missing?channel=mychannel&limit=-1",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid query parameter limit: -1"}`,
+		},
+		{
+			name:         "missing channel",
+			method:       http.MethodGet,
+			url:          "/pvtdata/reconciliation",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"missing query parameter: channel"}`,
+		},
+		{
+			name:         "unknown channel",
+			method:       http.MethodGet,
+			url:          "/pvtdata/reconciliation?channel=unknown",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"channel does not exist: unknown"}`,
+		},
+		{
+			name:         "reconciliation disabled",
+			method:       http.MethodPost,
+			url:          "/pvtdata/reconciliation?channel=disabled&action=pause",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: `{"error":"private data reconciliation is disabled"}`,
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodPost,
+			url:          "/pvtdata/missing?channel=mychannel",
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: `{"error":"invalid request method: POST"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler.filter = nil
+			reconciler.limit = 0
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			handler.ServeHTTP(resp, req)
+			require.Equal(t, tt.expectedCode, resp.Code)
+			require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+			require.JSONEq(t, tt.expectedBody, resp.Body.String())
+			require.Equal(t, tt.expectedFilter, reconciler.filter)
+			require.Equal(t, tt.expectedLimit, reconciler.limit)
+		})
+	}
+
+	t.Run("reconciliation failure", func(t *testing.T) {
+		reconciler.reconcile = errors.New("no peers")
+		defer func() { reconciler.reconcile = nil }()
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/pvtdata/reconciliation?channel=mychannel&action=reconcile", nil)
+		handler.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		require.JSONEq(t, `{"error":"no peers"}`, resp.Body.String())
+	})
+}
@@ -124,6 +124,57 @@ func IsLeadershipMsg(m *gossip.GossipMessage) bool {
 	return m.GetLeadershipMsg() != nil
 }
 
+// MessageType returns the type of the content of this GossipMessage, for example: alive, state_info, etc.
+// If the content is not set, "unknown" is returned.
+func MessageType(m *gossip.GossipMessage) string {
+	switch m.Content.(type) {
+	case *gossip.GossipMessage_AliveMsg:
+		return "alive"
+	case *gossip.GossipMessage_MemReq:
+		return "mem_req"
+	case *gossip.GossipMessage_MemRes:
+		return "mem_res"
+	case *gossip.GossipMessage_DataMsg:
+		return "data"
+	case *gossip.GossipMessage_Hello:
+		return "hello"
+	case *gossip.GossipMessage_DataDig:
+		return "data_dig"
+	case *gossip.GossipMessage_DataReq:
+		return "data_req"
+	case *gossip.GossipMessage_DataUpdate:
+		return "data_update"
+	case *gossip.GossipMessage_Empty:
+		return "empty"
+	case *gossip.GossipMessage_Conn:
+		return "conn"
+	case *gossip.GossipMessage_StateInfo:
+		return "state_info"
+	case *gossip.GossipMessage_StateSnapshot:
+		return "state_snapshot"
+	case *gossip.GossipMessage_StateInfoPullReq:
+		return "state_info_pull_req"
+	case *gossip.GossipMessage_StateRequest:
+		return "state_request"
+	case *gossip.GossipMessage_StateResponse:
+		return "state_response"
+	case *gossip.GossipMessage_LeadershipMsg:
+		return "leadership"
+	case *gossip.GossipMessage_PeerIdentity:
+		return "peer_identity"
+	case *gossip.GossipMessage_Ack:
+		return "ack"
+	case *gossip.GossipMessage_PrivateReq:
+		return "private_req"
+	case *gossip.GossipMessage_PrivateRes:
+		return "private_res"
+	case *gossip.GossipMessage_PrivateData:
+		return "private_data"
+	default:
+		return "unknown"
+	}
+}
+
 // IsTagLegal checks the GossipMessage tags and inner type
 // and returns an error if the tag doesn't match the type.
 func IsTagLegal(m *gossip.GossipMessage) error {
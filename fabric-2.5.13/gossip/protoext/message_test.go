@@ -194,6 +194,39 @@ func TestGossipPullMessageType(t *testing.T) {
 	require.Equal(t, protoext.GetPullMsgType(msg), gossip.PullMsgType_UNDEFINED)
 }
 
+func TestMessageType(t *testing.T) {
+	tests := []struct {
+		msg      *gossip.GossipMessage
+		expected string
+	}{
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_AliveMsg{}}, "alive"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_MemReq{}}, "mem_req"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_MemRes{}}, "mem_res"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_DataMsg{}}, "data"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_Hello{}}, "hello"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_DataDig{}}, "data_dig"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_DataReq{}}, "data_req"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_DataUpdate{}}, "data_update"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_Empty{}}, "empty"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_Conn{}}, "conn"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_StateInfo{}}, "state_info"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_StateSnapshot{}}, "state_snapshot"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_StateInfoPullReq{}}, "state_info_pull_req"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_StateRequest{}}, "state_request"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_StateResponse{}}, "state_response"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_LeadershipMsg{}}, "leadership"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_PeerIdentity{}}, "peer_identity"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_Ack{}}, "ack"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_PrivateReq{}}, "private_req"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_PrivateRes{}}, "private_res"},
+		{&gossip.GossipMessage{Content: &gossip.GossipMessage_PrivateData{}}, "private_data"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, protoext.MessageType(test.msg))
+	}
+	require.Equal(t, "unknown", protoext.MessageType(&gossip.GossipMessage{}))
+}
+
 func TestGossipMessageDataMessageTagType(t *testing.T) {
 	var msg *gossip.GossipMessage
 
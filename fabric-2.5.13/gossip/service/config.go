@@ -8,8 +8,11 @@ package service
 import (
 	"time"
 
+	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -40,6 +43,9 @@ type ServiceConfig struct {
 	// ElectionLeaderElectionDuration is the time passes since last declaration message before peer decides to perform
 	// leader election (unit: second).
 	ElectionLeaderElectionDuration time.Duration
+	// ElectionWeight is the weight of the peer in leader election. Peers with a higher
+	// weight are preferred as leaders over peers with a lower weight.
+	ElectionWeight uint32
 	// PvtDataPullRetryThreshold determines the maximum duration of time private data corresponding for
 	// a given block.
 	PvtDataPullRetryThreshold time.Duration
@@ -52,6 +58,15 @@ type ServiceConfig struct {
 	// TransientstoreMaxBlockRetention defines the maximum difference between the current ledger's height upon commit,
 	// and the private data residing inside the transient store that is guaranteed not to be purged.
 	TransientstoreMaxBlockRetention uint64
+	// TransientstoreTTL is the duration after which private data residing inside the transient store
+	// is purged regardless of the ledger's height. Zero disables the time based purge.
+	TransientstoreTTL time.Duration
+	// TransientstoreChannelQuota is the maximum size in bytes of the private data residing inside the
+	// transient store of a channel. Zero disables the quota.
+	TransientstoreChannelQuota uint64
+	// TransientstoreCollectionQuota is the maximum size in bytes of the private data of a collection
+	// residing inside the transient store of a channel. Zero disables the quota.
+	TransientstoreCollectionQuota uint64
 	// SkipPullingInvalidTransactionsDuringCommit is a flag that indicates whether pulling of invalid
 	// transaction's private data from other peers need to be skipped during the commit time and pulled
 	// only through reconciler.
@@ -75,6 +90,7 @@ func (c *ServiceConfig) loadGossipConfig() {
 	c.ElectionMembershipSampleInterval = util.GetDurationOrDefault("peer.gossip.election.membershipSampleInterval", election.DefMembershipSampleInterval)
 	c.ElectionLeaderAliveThreshold = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold)
 	c.ElectionLeaderElectionDuration = util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", election.DefLeaderElectionDuration)
+	c.ElectionWeight = viper.GetUint32("peer.gossip.election.weight")
 
 	c.PvtDataPushAckTimeout = viper.GetDuration("peer.gossip.pvtData.pushAckTimeout")
 	c.PvtDataPullRetryThreshold = viper.GetDuration("peer.gossip.pvtData.pullRetryThreshold")
@@ -93,4 +109,34 @@ func (c *ServiceConfig) loadGossipConfig() {
 		logger.Warning("Configuration key peer.gossip.pvtData.transientstoreMaxBlockRetention isn't set, defaulting to", transientBlockRetentionDefault)
 		c.TransientstoreMaxBlockRetention = transientBlockRetentionDefault
 	}
+	c.TransientstoreTTL = viper.GetDuration("peer.gossip.pvtData.transientstoreTTL")
+	c.TransientstoreChannelQuota = uint64(viper.GetInt64("peer.gossip.pvtData.transientstoreChannelQuota"))
+	c.TransientstoreCollectionQuota = uint64(viper.GetInt64("peer.gossip.pvtData.transientstoreCollectionQuota"))
+}
+
+// ReloadableConfig is the gossip and block delivery configuration
+// that can be changed while the peer is running
+type ReloadableConfig struct {
+	// BootstrapPeers are the peers of the organization the peer connects to at startup.
+	BootstrapPeers []string
+	// ExternalEndpoint is the endpoint the peer publishes to peers of foreign organizations.
+	ExternalEndpoint string
+	// OrgLeader statically defines peer to be an organization "leader".
+	OrgLeader bool
+	// OrdererEndpointOverrides maps orderer endpoint addresses to the endpoints the peer connects to instead.
+	OrdererEndpointOverrides map[string]*orderers.Endpoint
+}
+
+// LoadReloadableConfig loads the reloadable configuration from the given viper instance
+func LoadReloadableConfig(v *viper.Viper) (*ReloadableConfig, error) {
+	overrides, err := deliverservice.LoadOverridesMapFrom(v)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed loading orderer endpoint overrides")
+	}
+	return &ReloadableConfig{
+		BootstrapPeers:           v.GetStringSlice("peer.gossip.bootstrap"),
+		ExternalEndpoint:         v.GetString("peer.gossip.externalEndpoint"),
+		OrgLeader:                v.GetBool("peer.gossip.orgLeader"),
+		OrdererEndpointOverrides: overrides,
+	}, nil
 }
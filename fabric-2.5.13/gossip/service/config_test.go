@@ -28,8 +28,12 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.orgLeader", true)
 	viper.Set("peer.gossip.election.leaderAliveThreshold", "10m")
 	viper.Set("peer.gossip.election.leaderElectionDuration", "5s")
+	viper.Set("peer.gossip.election.weight", 10)
 	viper.Set("peer.gossip.pvtData.btlPullMargin", 15)
 	viper.Set("peer.gossip.pvtData.transientstoreMaxBlockRetention", 1000)
+	viper.Set("peer.gossip.pvtData.transientstoreTTL", "1h")
+	viper.Set("peer.gossip.pvtData.transientstoreChannelQuota", 1073741824)
+	viper.Set("peer.gossip.pvtData.transientstoreCollectionQuota", 104857600)
 	viper.Set("peer.gossip.pvtData.skipPullingInvalidTransactionsDuringCommit", false)
 
 	coreConfig := service.GlobalConfig()
@@ -46,8 +50,12 @@ func TestGlobalConfig(t *testing.T) {
 		ElectionLeaderElectionDuration:             5 * time.Second,
 		ElectionStartupGracePeriod:                 election.DefStartupGracePeriod,
 		ElectionMembershipSampleInterval:           election.DefMembershipSampleInterval,
+		ElectionWeight:                             10,
 		BtlPullMargin:                              15,
 		TransientstoreMaxBlockRetention:            uint64(1000),
+		TransientstoreTTL:                          time.Hour,
+		TransientstoreChannelQuota:                 uint64(1073741824),
+		TransientstoreCollectionQuota:              uint64(104857600),
 		SkipPullingInvalidTransactionsDuringCommit: false,
 	}
 
@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/fabhttp"
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// YieldLeadershipPath is the path of the operations endpoint that makes the peer yield its leadership of a channel.
+const YieldLeadershipPath = "/gossip/election/yield"
+
+// YieldLeadershipFunc makes the peer relinquish its leadership of the given channel.
+type YieldLeadershipFunc func(channelID string) error
+
+// YieldLeadershipHandler serves the operations endpoint that makes the peer relinquish its leadership
+// of a channel, for instance before maintenance, for another peer of its organization to be elected
+// as the leader.
+//
+// POST /gossip/election/yield?channel=<channel> yields the leadership of the given channel.
+type YieldLeadershipHandler struct {
+	YieldLeadership YieldLeadershipFunc
+	Logger          *flogging.FabricLogger
+}
+
+func NewYieldLeadershipHandler(yieldLeadership YieldLeadershipFunc) *YieldLeadershipHandler {
+	return &YieldLeadershipHandler{
+		YieldLeadership: yieldLeadership,
+		Logger:          flogging.MustGetLogger("gossip.service.election"),
+	}
+}
+
+func (h *YieldLeadershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		fabhttp.SendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method), h.Logger)
+		return
+	}
+
+	channelID := req.URL.Query().Get("channel")
+	if channelID == "" {
+		fabhttp.SendResponse(resp, http.StatusBadRequest, fmt.Errorf("channel is required"), h.Logger)
+		return
+	}
+
+	if err := h.YieldLeadership(channelID); err != nil {
+		fabhttp.SendResponse(resp, http.StatusBadRequest, err, h.Logger)
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
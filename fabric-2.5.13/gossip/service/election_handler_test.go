@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYieldLeadershipHandler(t *testing.T) {
+	var yielded []string
+	handler := NewYieldLeadershipHandler(func(channelID string) error {
+		if channelID != "mychannel" {
+			return errors.Errorf("channel %s does not exist", channelID)
+		}
+		yielded = append(yielded, channelID)
+		return nil
+	})
+
+	tests := []struct {
+		name            string
+		method          string
+		url             string
+		expectedCode    int
+		expectedBody    string
+		expectedYielded []string
+	}{
+		{
+			name:            "yield",
+			method:          http.MethodPost,
+			url:             YieldLeadershipPath + "?channel=mychannel",
+			expectedCode:    http.StatusNoContent,
+			expectedYielded: []string{"mychannel"},
+		},
+		{
+			name:         "yield failure",
+			method:       http.MethodPost,
+			url:          YieldLeadershipPath + "?channel=otherchannel",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"channel otherchannel does not exist"}`,
+		},
+		{
+			name:         "missing channel",
+			method:       http.MethodPost,
+			url:          YieldLeadershipPath,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"channel is required"}`,
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodGet,
+			url:          YieldLeadershipPath + "?channel=mychannel",
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: `{"error":"invalid request method: GET"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yielded = nil
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			handler.ServeHTTP(resp, req)
+
+			require.Equal(t, tt.expectedCode, resp.Result().StatusCode)
+			require.Equal(t, tt.expectedYielded, yielded)
+			if tt.expectedBody != "" {
+				require.JSONEq(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
@@ -60,10 +60,26 @@ type gossipSvc interface {
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChannelID) []discovery.NetworkMember
 
+	// Probe probes a remote peer and returns nil if it is responsive,
+	// and an error if it's not
+	Probe(peer *comm.RemotePeer) error
+
+	// MembershipView returns the current membership view of the peer,
+	// and the number of messages exchanged per message type
+	MembershipView() gossip.MembershipView
+
 	// UpdateMetadata updates the self metadata of the discovery layer
 	// the peer publishes to other peers
 	UpdateMetadata(metadata []byte)
 
+	// UpdateExternalEndpoint updates the endpoint the peer publishes
+	// to peers of foreign organizations
+	UpdateExternalEndpoint(endpoint string)
+
+	// UpdateBootstrapPeers replaces the bootstrap peers, and connects
+	// to the bootstrap peers that were added
+	UpdateBootstrapPeers(bootstrapPeers []string)
+
 	// UpdateLedgerHeight updates the ledger height the peer
 	// publishes to other peers in the channel
 	UpdateLedgerHeight(height uint64, channelID common.ChannelID)
@@ -173,6 +189,16 @@ type GossipService struct {
 	serviceConfig     *ServiceConfig
 	privdataConfig    *gossipprivdata.PrivdataConfig
 	anchorPeerTracker *anchorPeerTracker
+	// blocksCrossCheck is set when every peer pulls blocks from the ordering service
+	// and cross-checks them with other ordering service nodes, instead of relying on
+	// a leader to disseminate blocks via gossip
+	blocksCrossCheck bool
+	// ordererSources are the orderer connection sources of the channels
+	ordererSources map[string]*orderers.ConnectionSource
+	// ordererOverrides are the orderer endpoint overrides of the last reload,
+	// and are applied to channels initialized afterwards if overridesReloaded is set
+	ordererOverrides  map[string]*orderers.Endpoint
+	overridesReloaded bool
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -262,6 +288,12 @@ func New(
 		anchorPeerTracker,
 	)
 
+	if serviceConfig.ElectionWeight > 0 {
+		// The election weight is published via the membership metadata,
+		// for other peers to prefer peers with a higher weight as leaders
+		gossipComponent.UpdateMetadata(election.WeightMetadata(serviceConfig.ElectionWeight))
+	}
+
 	return &GossipService{
 		gossipSvc:       gossipComponent,
 		mcs:             mcs,
@@ -269,6 +301,7 @@ func New(
 		chains:          make(map[string]state.GossipStateProvider),
 		leaderElection:  make(map[string]election.LeaderElectionService),
 		deliveryService: make(map[string]deliverservice.DeliverService),
+		ordererSources:  make(map[string]*orderers.ConnectionSource),
 		deliveryFactory: &deliveryFactoryImpl{
 			signer:               peerIdentity,
 			credentialSupport:    credSupport,
@@ -280,6 +313,7 @@ func New(
 		serviceConfig:     serviceConfig,
 		privdataConfig:    privdataConfig,
 		anchorPeerTracker: anchorPeerTracker,
+		blocksCrossCheck:  deliverServiceConfig.CrossCheckEnabled,
 	}, nil
 }
 
@@ -306,6 +340,18 @@ func (g *GossipService) DistributePrivateData(channelID string, txID string, pri
 	return nil
 }
 
+// PvtDataReconciler returns the private data reconciler of a channel, and false if the
+// channel has not been initialized
+func (g *GossipService) PvtDataReconciler(channelID string) (gossipprivdata.PvtDataReconciler, bool) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	handler, exists := g.privateHandlers[channelID]
+	if !exists {
+		return nil, false
+	}
+	return handler.reconciler, true
+}
+
 // NewConfigEventer creates a ConfigProcessor which the channelconfig.BundleSource can ultimately route config updates to
 func (g *GossipService) NewConfigEventer() ConfigProcessor {
 	return newConfigEventer(g)
@@ -322,12 +368,19 @@ type Support struct {
 }
 
 // InitializeChannel allocates the state provider and should be invoked once per channel per execution
-func (g *GossipService) InitializeChannel(channelID string, ordererSource *orderers.ConnectionSource, store *transientstore.Store, support Support) {
+func (g *GossipService) InitializeChannel(channelID string, ordererSource *orderers.ConnectionSource, store *transientstore.Store, support Support) error {
 	g.lock.Lock()
 	defer g.lock.Unlock()
+
+	stateConfig := state.GlobalConfig()
+	peerSelector, err := state.NewPeerSelector(stateConfig, g)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to create the peer selector for state transfer on channel %s", channelID)
+	}
+
 	// Initialize new state provider for given committer
 	logger.Debug("Creating state provider for channelID", channelID)
-	servicesAdapter := &state.ServicesMediator{GossipAdapter: g, MCSAdapter: g.mcs}
+	servicesAdapter := &state.ServicesMediator{GossipAdapter: g, MCSAdapter: g.mcs, PeerSelector: peerSelector}
 
 	// Initialize private data fetcher
 	dataRetriever := gossipprivdata.NewDataRetriever(channelID, store, support.Committer)
@@ -371,7 +424,6 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 	g.privateHandlers[channelID].reconciler.Start()
 
 	blockingMode := !g.serviceConfig.NonBlockingCommitMode
-	stateConfig := state.GlobalConfig()
 	g.chains[channelID] = state.NewGossipStateProvider(
 		flogging.MustGetLogger(util.StateLogger),
 		channelID,
@@ -380,8 +432,12 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 		g.metrics.StateMetrics,
 		blockingMode,
 		stateConfig)
+	if g.overridesReloaded {
+		ordererSource.SetOverrides(g.ordererOverrides)
+	}
+	g.ordererSources[channelID] = ordererSource
 	if g.deliveryService[channelID] == nil {
-		g.deliveryService[channelID] = g.deliveryFactory.Service(g, ordererSource, g.mcs, g.serviceConfig.OrgLeader)
+		g.deliveryService[channelID] = g.deliveryFactory.Service(g, ordererSource, g.mcs, g.serviceConfig.OrgLeader || g.blocksCrossCheck)
 	}
 
 	// Delivery service might be nil only if it was not able to get connected
@@ -400,7 +456,13 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 			logger.Panic("Setting both orgLeader and useLeaderElection to true isn't supported, aborting execution")
 		}
 
-		if leaderElection {
+		if g.blocksCrossCheck {
+			if leaderElection {
+				logger.Info("Blocks cross-check is enabled, every peer pulls blocks from the ordering service and peer.gossip.useLeaderElection is ignored, channel", channelID)
+			}
+			logger.Debug("This peer pulls blocks from the ordering service and cross-checks them with other ordering service nodes, channel", channelID)
+			g.deliveryService[channelID].StartDeliverForChannel(channelID, support.Committer, func() {})
+		} else if leaderElection {
 			logger.Debug("Delivery uses dynamic leader election mechanism, channel", channelID)
 			g.leaderElection[channelID] = g.newLeaderElectionComponent(channelID, g.onStatusChangeFactory(channelID,
 				support.Committer), g.metrics.ElectionMetrics)
@@ -413,6 +475,7 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 	} else {
 		logger.Warning("Delivery client is down won't be able to pull blocks for chain", channelID)
 	}
+	return nil
 }
 
 func (g *GossipService) createSelfSignedData() protoutil.SignedData {
@@ -485,6 +548,82 @@ func (g *GossipService) Stop() {
 	g.gossipSvc.Stop()
 }
 
+// Reload applies the given configuration to the gossip component and to the
+// block delivery of all the channels the peer has joined
+func (g *GossipService) Reload(conf *ReloadableConfig) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if conf.OrgLeader && g.serviceConfig.UseLeaderElection {
+		return errors.New("setting both orgLeader and useLeaderElection to true isn't supported")
+	}
+
+	logger.Infof("Reloading gossip configuration with bootstrap peers %v and external endpoint %s", conf.BootstrapPeers, conf.ExternalEndpoint)
+	g.gossipSvc.UpdateBootstrapPeers(conf.BootstrapPeers)
+	g.gossipSvc.UpdateExternalEndpoint(conf.ExternalEndpoint)
+
+	g.ordererOverrides = conf.OrdererEndpointOverrides
+	g.overridesReloaded = true
+	for _, ordererSource := range g.ordererSources {
+		ordererSource.SetOverrides(conf.OrdererEndpointOverrides)
+	}
+
+	if conf.OrgLeader == g.serviceConfig.OrgLeader {
+		return nil
+	}
+	serviceConfig := *g.serviceConfig
+	serviceConfig.OrgLeader = conf.OrgLeader
+	g.serviceConfig = &serviceConfig
+	if g.serviceConfig.UseLeaderElection || g.blocksCrossCheck {
+		// Block delivery doesn't depend on the static leader configuration
+		return nil
+	}
+
+	for channelID, ordererSource := range g.ordererSources {
+		if ds := g.deliveryService[channelID]; ds != nil {
+			ds.Stop()
+		}
+		// The delivery service is recreated, as whether the peer is a static
+		// leader is determined when the delivery service is created
+		g.deliveryService[channelID] = g.deliveryFactory.Service(g, ordererSource, g.mcs, conf.OrgLeader)
+		if g.deliveryService[channelID] == nil {
+			logger.Warning("Delivery client is down won't be able to pull blocks for chain", channelID)
+			continue
+		}
+		if !conf.OrgLeader {
+			logger.Info("This peer is no longer configured to connect to ordering service for blocks delivery, channel", channelID)
+			continue
+		}
+		logger.Info("This peer is now configured to connect to ordering service for blocks delivery, channel", channelID)
+		committer := g.privateHandlers[channelID].support.Committer
+		if err := g.deliveryService[channelID].StartDeliverForChannel(channelID, committer, func() {}); err != nil {
+			return errors.WithMessagef(err, "failed starting blocks delivery for channel %s", channelID)
+		}
+	}
+	return nil
+}
+
+// YieldLeadership makes the peer relinquish its leadership of the given channel,
+// for another peer of its organization to be elected as the leader
+func (g *GossipService) YieldLeadership(channelID string) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, exists := g.chains[channelID]; !exists {
+		return errors.Errorf("channel %s does not exist", channelID)
+	}
+	le, exists := g.leaderElection[channelID]
+	if !exists {
+		return errors.Errorf("leader election is not used in channel %s", channelID)
+	}
+	if !le.IsLeader() {
+		return errors.Errorf("peer is not the leader of channel %s", channelID)
+	}
+	logger.Infof("Yielding the leadership of channel %s", channelID)
+	le.Yield()
+	return nil
+}
+
 func (g *GossipService) newLeaderElectionComponent(channelID string, callback func(bool),
 	electionMetrics *gossipmetrics.ElectionMetrics) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
@@ -494,6 +633,7 @@ func (g *GossipService) newLeaderElectionComponent(channelID string, callback fu
 		MembershipSampleInterval: g.serviceConfig.ElectionMembershipSampleInterval,
 		LeaderAliveThreshold:     g.serviceConfig.ElectionLeaderAliveThreshold,
 		LeaderElectionDuration:   g.serviceConfig.ElectionLeaderElectionDuration,
+		Weight:                   g.serviceConfig.ElectionWeight,
 	}
 	return election.NewLeaderElectionService(adapter, string(PKIid), callback, config)
 }
@@ -46,6 +46,7 @@ import (
 	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 )
@@ -76,7 +77,7 @@ func newTransientStore(t *testing.T) *testTransientStore {
 		t.Fatalf("Failed to create test directory, got err %s", err)
 		return s
 	}
-	s.storeProvider, err = transientstore.NewStoreProvider(s.tempdir)
+	s.storeProvider, err = transientstore.NewStoreProvider(s.tempdir, nil)
 	if err != nil {
 		t.Fatalf("Failed to open store, got err %s", err)
 		return s
@@ -223,6 +224,64 @@ func TestLeaderElectionWithDeliverClient(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestWeightedLeaderElectionAndYield(t *testing.T) {
+	// Scenario: 3 peers use leader election, and the last peer has the highest election weight.
+	// The last peer is elected as the leader, and then yields its leadership.
+	// Expected outcome: another peer becomes the leader, and the deliver client of the
+	// last peer is stopped.
+
+	n := 3
+	serviceConfig := &ServiceConfig{
+		UseLeaderElection:                true,
+		OrgLeader:                        false,
+		ElectionStartupGracePeriod:       time.Second * 3,
+		ElectionMembershipSampleInterval: time.Millisecond * 500,
+		ElectionLeaderAliveThreshold:     time.Second * 2,
+		ElectionLeaderElectionDuration:   time.Second,
+	}
+	gossips := startPeers(serviceConfig, n, 0)
+	defer stopPeers(gossips)
+
+	weightedConfig := *serviceConfig
+	weightedConfig.ElectionWeight = 1
+	gossips[2].serviceConfig = &weightedConfig
+	gossips[2].UpdateMetadata(election.WeightMetadata(1))
+
+	channelName := "chanA"
+	addPeersToChannel(channelName, gossips, []int{0, 1, 2})
+	waitForFullMembershipOrFailNow(t, channelName, gossips, n, TIMEOUT, time.Second*2)
+
+	store := newTransientStore(t)
+	defer store.tearDown()
+
+	services := make([]*electionService, n)
+	for i := 0; i < n; i++ {
+		gossips[i].deliveryFactory = &mockDeliverServiceFactory{
+			service: &mockDeliverService{
+				running: map[string]bool{channelName: false},
+			},
+		}
+		gossips[i].InitializeChannel(channelName, orderers.NewConnectionSource(flogging.MustGetLogger("peer.orderers"), nil), store.Store, Support{
+			Committer: &mockLedgerInfo{1},
+		})
+		services[i] = &electionService{LeaderElectionService: gossips[i].leaderElection[channelName]}
+	}
+
+	require.True(t, waitForLeaderElection(services, time.Second*30, time.Second), "One leader should be selected")
+	require.True(t, services[2].IsLeader(), "The peer with the highest weight should be the leader")
+	require.True(t, gossips[2].deliveryService[channelName].(*mockDeliverService).running[channelName])
+
+	require.EqualError(t, gossips[0].YieldLeadership(channelName), "peer is not the leader of channel chanA")
+	require.EqualError(t, gossips[2].YieldLeadership("chanB"), "channel chanB does not exist")
+	require.NoError(t, gossips[2].YieldLeadership(channelName))
+	require.False(t, gossips[2].deliveryService[channelName].(*mockDeliverService).running[channelName])
+
+	require.Eventually(t, func() bool {
+		return services[0].IsLeader() || services[1].IsLeader()
+	}, time.Second*30, time.Second, "Another peer should take over the leadership")
+	require.False(t, services[2].IsLeader())
+}
+
 func TestWithStaticDeliverClientLeader(t *testing.T) {
 	// Tests check if static leader flag works ok.
 	// Leader election flag set to false, and static leader flag set to true
@@ -333,6 +392,132 @@ func TestWithStaticDeliverClientNotLeader(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestWithCrossCheckDeliverClient(t *testing.T) {
+	serviceConfig := &ServiceConfig{
+		UseLeaderElection:                true,
+		OrgLeader:                        false,
+		ElectionStartupGracePeriod:       election.DefStartupGracePeriod,
+		ElectionMembershipSampleInterval: election.DefMembershipSampleInterval,
+		ElectionLeaderAliveThreshold:     election.DefLeaderAliveThreshold,
+		ElectionLeaderElectionDuration:   election.DefLeaderElectionDuration,
+	}
+	n := 2
+	gossips := startPeers(serviceConfig, n, 0, 1)
+
+	channelName := "chanA"
+	peerIndexes := make([]int, n)
+	for i := 0; i < n; i++ {
+		peerIndexes[i] = i
+	}
+	addPeersToChannel(channelName, gossips, peerIndexes)
+
+	waitForFullMembershipOrFailNow(t, channelName, gossips, n, TIMEOUT, time.Second*2)
+
+	store := newTransientStore(t)
+	defer store.tearDown()
+
+	deliverServiceFactory := &mockDeliverServiceFactory{
+		service: &mockDeliverService{
+			running: make(map[string]bool),
+		},
+	}
+
+	for i := 0; i < n; i++ {
+		gossips[i].deliveryFactory = deliverServiceFactory
+		gossips[i].blocksCrossCheck = true
+		deliverServiceFactory.service.running[channelName] = false
+		gossips[i].InitializeChannel(channelName, orderers.NewConnectionSource(flogging.MustGetLogger("peer.orderers"), nil), store.Store, Support{
+			Committer: &mockLedgerInfo{1},
+		})
+		require.True(t, deliverServiceFactory.service.running[channelName], "Block deliverer not started for peer %d", i)
+		require.Nil(t, gossips[i].leaderElection[channelName], "Leader election should not be started for peer %d", i)
+	}
+
+	stopPeers(gossips)
+}
+
+func TestReload(t *testing.T) {
+	serviceConfig := &ServiceConfig{
+		UseLeaderElection: false,
+		OrgLeader:         false,
+	}
+	n := 2
+	gossips := startPeers(serviceConfig, n)
+	defer stopPeers(gossips)
+
+	channelName := "chanA"
+	addPeersToChannel(channelName, gossips, []int{0, 1})
+
+	store := newTransientStore(t)
+	defer store.tearDown()
+
+	deliverServiceFactory := &mockDeliverServiceFactory{
+		service: &mockDeliverService{
+			running: map[string]bool{channelName: false},
+		},
+	}
+	ordererSource := orderers.NewConnectionSource(flogging.MustGetLogger("peer.orderers"), nil)
+	ordererSource.Update([]string{"orderer-address"}, nil)
+	gossips[1].deliveryFactory = deliverServiceFactory
+	gossips[1].InitializeChannel(channelName, ordererSource, store.Store, Support{
+		Committer: &mockLedgerInfo{1},
+	})
+	require.False(t, deliverServiceFactory.service.running[channelName])
+
+	overrides := map[string]*orderers.Endpoint{
+		"orderer-address": {Address: "override-address"},
+	}
+	err := gossips[1].Reload(&ReloadableConfig{
+		BootstrapPeers:           []string{gossips[0].grpc.Address()},
+		ExternalEndpoint:         "peer1.example.com:7051",
+		OrgLeader:                true,
+		OrdererEndpointOverrides: overrides,
+	})
+	require.NoError(t, err)
+	require.True(t, deliverServiceFactory.service.running[channelName])
+	require.True(t, gossips[1].serviceConfig.OrgLeader)
+	require.False(t, serviceConfig.OrgLeader)
+	require.Equal(t, "peer1.example.com:7051", gossips[1].SelfMembershipInfo().Endpoint)
+	endpoints := ordererSource.Endpoints()
+	require.Len(t, endpoints, 1)
+	require.Equal(t, "override-address", endpoints[0].Address)
+
+	// The peers find each other via the reloaded bootstrap peers
+	waitForFullMembershipOrFailNow(t, channelName, gossips, n, TIMEOUT, time.Second*2)
+
+	err = gossips[1].Reload(&ReloadableConfig{
+		BootstrapPeers: []string{gossips[0].grpc.Address()},
+		OrgLeader:      false,
+	})
+	require.NoError(t, err)
+	require.False(t, deliverServiceFactory.service.running[channelName])
+	endpoints = ordererSource.Endpoints()
+	require.Len(t, endpoints, 1)
+	require.Equal(t, "orderer-address", endpoints[0].Address)
+
+	gossips[1].serviceConfig.UseLeaderElection = true
+	err = gossips[1].Reload(&ReloadableConfig{OrgLeader: true})
+	require.EqualError(t, err, "setting both orgLeader and useLeaderElection to true isn't supported")
+}
+
+func TestInitializeChannelUnknownPeerSelectionStrategy(t *testing.T) {
+	viper.Set("peer.gossip.state.peerSelection.strategy", "fastest")
+	defer viper.Reset()
+
+	serviceConfig := &ServiceConfig{}
+	gossips := startPeers(serviceConfig, 1)
+	defer stopPeers(gossips)
+
+	store := newTransientStore(t)
+	defer store.tearDown()
+
+	err := gossips[0].InitializeChannel("chanA", orderers.NewConnectionSource(flogging.MustGetLogger("peer.orderers"), nil), store.Store, Support{
+		Committer: &mockLedgerInfo{1},
+	})
+	require.EqualError(t, err, "failed to create the peer selector for state transfer on channel chanA: unknown peer selection strategy: fastest")
+	require.NotContains(t, gossips[0].chains, "chanA")
+}
+
 func TestWithStaticDeliverClientBothStaticAndLeaderElection(t *testing.T) {
 	serviceConfig := &ServiceConfig{
 		UseLeaderElection:                true,
@@ -399,6 +584,9 @@ func (ds *mockDeliverService) StopDeliverForChannel(chainID string) error {
 }
 
 func (ds *mockDeliverService) Stop() {
+	for chainID := range ds.running {
+		ds.running[chainID] = false
+	}
 }
 
 type mockLedgerInfo struct {
@@ -770,6 +958,7 @@ func newGossipInstance(serviceConfig *ServiceConfig, port int, id int, gRPCServe
 		leaderElection:  make(map[string]election.LeaderElectionService),
 		privateHandlers: make(map[string]privateHandler),
 		deliveryService: make(map[string]deliverservice.DeliverService),
+		ordererSources:  make(map[string]*orderers.ConnectionSource),
 		deliveryFactory: &deliveryFactoryImpl{
 			credentialSupport: comm.NewCredentialSupport(),
 		},
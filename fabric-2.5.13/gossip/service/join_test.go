@@ -65,6 +65,14 @@ func (*gossipMock) Peers() []discovery.NetworkMember {
 	panic("implement me")
 }
 
+func (*gossipMock) Probe(*comm.RemotePeer) error {
+	panic("implement me")
+}
+
+func (*gossipMock) MembershipView() gossip.MembershipView {
+	panic("implement me")
+}
+
 func (*gossipMock) PeersOfChannel(common.ChannelID) []discovery.NetworkMember {
 	panic("implement me")
 }
@@ -73,6 +81,14 @@ func (*gossipMock) UpdateMetadata(metadata []byte) {
 	panic("implement me")
 }
 
+func (*gossipMock) UpdateExternalEndpoint(endpoint string) {
+	panic("implement me")
+}
+
+func (*gossipMock) UpdateBootstrapPeers(bootstrapPeers []string) {
+	panic("implement me")
+}
+
 // UpdateLedgerHeight updates the ledger height the peer
 // publishes to other peers in the channel
 func (*gossipMock) UpdateLedgerHeight(height uint64, channelID common.ChannelID) {
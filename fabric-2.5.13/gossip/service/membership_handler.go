@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/fabhttp"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/gossip/gossip"
+)
+
+// MembershipPath is the path of the operations endpoint that reports the gossip membership view of the peer.
+const MembershipPath = "/gossip/membership"
+
+// MembershipViewFunc returns the current gossip membership view of the peer.
+type MembershipViewFunc func() gossip.MembershipView
+
+// MembershipHandler serves the operations endpoint that reports the gossip membership view of the peer:
+// the alive and the dead peers along with the time they were last seen alive, the endpoints, ledger
+// heights and chaincodes of the peers of each channel, and the number of messages sent, received and
+// dropped per message type.
+//
+// GET /gossip/membership returns the membership view of all channels.
+// GET /gossip/membership?channel=<channel> returns the membership view of the given channel only.
+type MembershipHandler struct {
+	MembershipView MembershipViewFunc
+	Logger         *flogging.FabricLogger
+}
+
+func NewMembershipHandler(membershipView MembershipViewFunc) *MembershipHandler {
+	return &MembershipHandler{
+		MembershipView: membershipView,
+		Logger:         flogging.MustGetLogger("gossip.service.membership"),
+	}
+}
+
+func (h *MembershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		fabhttp.SendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method), h.Logger)
+		return
+	}
+
+	view := h.MembershipView()
+	if channelID := req.URL.Query().Get("channel"); channelID != "" {
+		var channels []gossip.ChannelMembership
+		for _, ch := range view.Channels {
+			if ch.Channel == channelID {
+				channels = append(channels, ch)
+			}
+		}
+		if len(channels) == 0 {
+			fabhttp.SendResponse(resp, http.StatusNotFound, fmt.Errorf("channel does not exist: %s", channelID), h.Logger)
+			return
+		}
+		view.Channels = channels
+	}
+
+	fabhttp.SendResponse(resp, http.StatusOK, view, h.Logger)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipHandler(t *testing.T) {
+	lastSeen := time.Date(2021, time.March, 4, 10, 20, 30, 0, time.UTC)
+	handler := NewMembershipHandler(func() gossip.MembershipView {
+		return gossip.MembershipView{
+			Self: gossip.Member{Endpoint: "peer0:7051", PKIid: "01", Alive: true},
+			Members: []gossip.Member{
+				{Endpoint: "peer1:7051", PKIid: "02", Alive: true, LastSeen: &lastSeen},
+				{Endpoint: "peer2:7051", PKIid: "03", Alive: false, LastSeen: &lastSeen},
+			},
+			Channels: []gossip.ChannelMembership{
+				{
+					Channel:      "mychannel",
+					LedgerHeight: 10,
+					Peers: []gossip.ChannelMember{
+						{
+							Member:       gossip.Member{Endpoint: "peer1:7051", PKIid: "02", Alive: true, LastSeen: &lastSeen},
+							LedgerHeight: 9,
+							Chaincodes:   []gossip.Chaincode{{Name: "mycc", Version: "1.0"}},
+						},
+					},
+				},
+				{Channel: "otherchannel", LedgerHeight: 3, Peers: []gossip.ChannelMember{}},
+			},
+			Messages: []comm.MessageStats{{Type: "alive", Sent: 4, Received: 5, Dropped: 1, AvgSendLatency: 0.5}},
+		}
+	})
+
+	members := `"self":{"endpoint":"peer0:7051","pki_id":"01","alive":true},` +
+		`"members":[{"endpoint":"peer1:7051","pki_id":"02","alive":true,"last_seen":"2021-03-04T10:20:30Z"},` +
+		`{"endpoint":"peer2:7051","pki_id":"03","alive":false,"last_seen":"2021-03-04T10:20:30Z"}]`
+	mychannel := `{"channel":"mychannel","ledger_height":10,"peers":[{"endpoint":"peer1:7051","pki_id":"02","alive":true,` +
+		`"last_seen":"2021-03-04T10:20:30Z","ledger_height":9,"left_channel":false,"chaincodes":[{"name":"mycc","version":"1.0"}]}]}`
+	messages := `"messages":[{"type":"alive","sent":4,"received":5,"dropped":1,"avg_send_latency":0.5}]`
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "all channels",
+			method:       http.MethodGet,
+			url:          "/gossip/membership",
+			expectedCode: http.StatusOK,
+			expectedBody: `{` + members + `,"channels":[` + mychannel + `,{"channel":"otherchannel","ledger_height":3,"peers":[]}],` + messages + `}`,
+		},
+		{
+			name:         "single channel",
+			method:       http.MethodGet,
+			url:          "/gossip/membership?channel=mychannel",
+			expectedCode: http.StatusOK,
+			expectedBody: `{` + members + `,"channels":[` + mychannel + `],` + messages + `}`,
+		},
+		{
+			name:         "unknown channel",
+			method:       http.MethodGet,
+			url:          "/gossip/membership?channel=unknown",
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"channel does not exist: unknown"}`,
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodPost,
+			url:          "/gossip/membership",
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: `{"error":"invalid request method: POST"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			handler.ServeHTTP(resp, req)
+			require.Equal(t, tt.expectedCode, resp.Code)
+			require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+			require.JSONEq(t, tt.expectedBody, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/fabhttp"
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// ReloadPath is the path of the operations endpoint that reloads the gossip configuration of the peer.
+const ReloadPath = "/gossip/reload"
+
+// ReloadFunc reloads the gossip and block delivery configuration of the peer.
+type ReloadFunc func() error
+
+// ReloadHandler serves the operations endpoint that reloads the gossip bootstrap peers, the
+// external endpoint and the static organization leader configuration, along with the orderer
+// endpoint overrides of the delivery client, from the configuration of the peer.
+//
+// POST /gossip/reload reloads the configuration and applies it to all channels.
+type ReloadHandler struct {
+	Reload ReloadFunc
+	Logger *flogging.FabricLogger
+}
+
+func NewReloadHandler(reload ReloadFunc) *ReloadHandler {
+	return &ReloadHandler{
+		Reload: reload,
+		Logger: flogging.MustGetLogger("gossip.service.reload"),
+	}
+}
+
+func (h *ReloadHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		fabhttp.SendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method), h.Logger)
+		return
+	}
+
+	if err := h.Reload(); err != nil {
+		h.Logger.Errorw("failed to reload gossip configuration", "error", err)
+		fabhttp.SendResponse(resp, http.StatusInternalServerError, err, h.Logger)
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
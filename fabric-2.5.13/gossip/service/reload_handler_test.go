@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadHandler(t *testing.T) {
+	var reloadErr error
+	reloads := 0
+	handler := NewReloadHandler(func() error {
+		reloads++
+		return reloadErr
+	})
+
+	tests := []struct {
+		name            string
+		method          string
+		reloadErr       error
+		expectedCode    int
+		expectedBody    string
+		expectedReloads int
+	}{
+		{
+			name:            "reload",
+			method:          http.MethodPost,
+			expectedCode:    http.StatusNoContent,
+			expectedReloads: 1,
+		},
+		{
+			name:            "reload failure",
+			method:          http.MethodPost,
+			reloadErr:       errors.New("setting both orgLeader and useLeaderElection to true isn't supported"),
+			expectedCode:    http.StatusInternalServerError,
+			expectedBody:    `{"error":"setting both orgLeader and useLeaderElection to true isn't supported"}`,
+			expectedReloads: 1,
+		},
+		{
+			name:         "invalid method",
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: `{"error":"invalid request method: GET"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reloads = 0
+			reloadErr = tt.reloadErr
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, ReloadPath, nil)
+			handler.ServeHTTP(resp, req)
+
+			require.Equal(t, tt.expectedCode, resp.Result().StatusCode)
+			require.Equal(t, tt.expectedReloads, reloads)
+			if tt.expectedBody != "" {
+				require.JSONEq(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
@@ -20,6 +20,10 @@ const (
 	DefStateBlockBufferSize = 20
 	DefStateChannelSize     = 100
 	DefStateEnabled         = false
+
+	DefStatePeerSelectionStrategy  = RandomPeerSelection
+	DefStateLatencyRefreshInterval = time.Minute
+	DefStateMaxBlocksPerSecond     = 0
 )
 
 type StateConfig struct {
@@ -32,6 +36,19 @@ type StateConfig struct {
 	StateEnabled         bool
 	UseLeaderElection    bool
 	OrgLeader            bool
+
+	// StatePeerSelectionStrategy is the strategy used to select the peer
+	// to request missing blocks from
+	StatePeerSelectionStrategy string
+	// StatePreferredPeers are the endpoints of the peers to request missing blocks
+	// from when the preferred peer selection strategy is used
+	StatePreferredPeers []string
+	// StateLatencyRefreshInterval is the interval at which the latency of a peer is
+	// measured again when the latency peer selection strategy is used
+	StateLatencyRefreshInterval time.Duration
+	// StateMaxBlocksPerSecond is the maximum number of blocks per second sent to
+	// other peers in state transfer responses, 0 means no limit
+	StateMaxBlocksPerSecond int
 }
 
 func GlobalConfig() *StateConfig {
@@ -69,6 +86,19 @@ func (c *StateConfig) loadStateConfig() {
 	if viper.IsSet("peer.gossip.state.enabled") {
 		c.StateEnabled = viper.GetBool("peer.gossip.state.enabled")
 	}
+	c.StatePeerSelectionStrategy = DefStatePeerSelectionStrategy
+	if viper.IsSet("peer.gossip.state.peerSelection.strategy") {
+		c.StatePeerSelectionStrategy = viper.GetString("peer.gossip.state.peerSelection.strategy")
+	}
+	c.StatePreferredPeers = viper.GetStringSlice("peer.gossip.state.peerSelection.preferredPeers")
+	c.StateLatencyRefreshInterval = DefStateLatencyRefreshInterval
+	if viper.IsSet("peer.gossip.state.peerSelection.latencyRefreshInterval") {
+		c.StateLatencyRefreshInterval = viper.GetDuration("peer.gossip.state.peerSelection.latencyRefreshInterval")
+	}
+	c.StateMaxBlocksPerSecond = DefStateMaxBlocksPerSecond
+	if viper.IsSet("peer.gossip.state.maxBlocksPerSecond") {
+		c.StateMaxBlocksPerSecond = viper.GetInt("peer.gossip.state.maxBlocksPerSecond")
+	}
 	// The below two configuration parameters are used for straggler() which warns
 	// if our peer is lagging behind the rest and has no way to catch up.
 	c.UseLeaderElection = viper.GetBool("peer.gossip.useLeaderElection")
@@ -24,6 +24,10 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.state.blockBufferSize", 5)
 	viper.Set("peer.gossip.state.channelSize", 6)
 	viper.Set("peer.gossip.state.enabled", true)
+	viper.Set("peer.gossip.state.peerSelection.strategy", "preferred")
+	viper.Set("peer.gossip.state.peerSelection.preferredPeers", []string{"peer0:7051", "peer1:7051"})
+	viper.Set("peer.gossip.state.peerSelection.latencyRefreshInterval", "30s")
+	viper.Set("peer.gossip.state.maxBlocksPerSecond", 50)
 
 	coreConfig := state.GlobalConfig()
 
@@ -35,6 +39,11 @@ func TestGlobalConfig(t *testing.T) {
 		StateBlockBufferSize: 5,
 		StateChannelSize:     6,
 		StateEnabled:         true,
+
+		StatePeerSelectionStrategy:  "preferred",
+		StatePreferredPeers:         []string{"peer0:7051", "peer1:7051"},
+		StateLatencyRefreshInterval: 30 * time.Second,
+		StateMaxBlocksPerSecond:     50,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
@@ -53,6 +62,10 @@ func TestGlobalConfigDefaults(t *testing.T) {
 		StateBlockBufferSize: 20,
 		StateChannelSize:     100,
 		StateEnabled:         false,
+
+		StatePeerSelectionStrategy:  "random",
+		StateLatencyRefreshInterval: time.Minute,
+		StateMaxBlocksPerSecond:     0,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
@@ -0,0 +1,266 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
+)
+
+// Peer selection strategies
+const (
+	// RandomPeerSelection selects a random peer
+	RandomPeerSelection = "random"
+	// LatencyPeerSelection selects the peer with the lowest gossip ping latency
+	LatencyPeerSelection = "latency"
+	// SameOrgPeerSelection selects a random peer of the same organization,
+	// or a random peer if there is none
+	SameOrgPeerSelection = "sameOrg"
+	// PreferredPeerSelection selects a random preferred peer,
+	// or a random peer if there is none
+	PreferredPeerSelection = "preferred"
+)
+
+// latencyProbeTimeout is how long the latency peer selector waits for the probes of the candidates
+const latencyProbeTimeout = 500 * time.Millisecond
+
+// PeerSelector selects the peer to request missing blocks from
+type PeerSelector interface {
+	// SelectPeer selects a peer among the given candidates, which all have
+	// the requested blocks. The candidates are never empty.
+	SelectPeer(candidates []discovery.NetworkMember) discovery.NetworkMember
+}
+
+// PeerSelectionAdapter defines the gossip capabilities required by the peer selection strategies
+type PeerSelectionAdapter interface {
+	// Probe probes a remote peer and returns nil if it is responsive,
+	// and an error if it's not
+	Probe(peer *comm.RemotePeer) error
+
+	// IdentityInfo returns information known peer identities
+	IdentityInfo() api.PeerIdentitySet
+
+	// SelfMembershipInfo returns the peer's membership information
+	SelfMembershipInfo() discovery.NetworkMember
+}
+
+// NewPeerSelector creates the PeerSelector for the peer selection strategy of the given configuration
+func NewPeerSelector(config *StateConfig, adapter PeerSelectionAdapter) (PeerSelector, error) {
+	switch config.StatePeerSelectionStrategy {
+	case RandomPeerSelection, "":
+		return &randomPeerSelector{}, nil
+	case LatencyPeerSelection:
+		return &latencyPeerSelector{
+			probe:           adapter.Probe,
+			refreshInterval: config.StateLatencyRefreshInterval,
+			probeTimeout:    latencyProbeTimeout,
+			latencies:       make(map[string]*latencyMeasurement),
+			probing:         make(map[string]struct{}),
+		}, nil
+	case SameOrgPeerSelection:
+		return &sameOrgPeerSelector{adapter: adapter}, nil
+	case PreferredPeerSelection:
+		if len(config.StatePreferredPeers) == 0 {
+			return nil, errors.New("no preferred peers configured for the preferred peer selection strategy")
+		}
+		preferred := make(map[string]struct{}, len(config.StatePreferredPeers))
+		for _, endpoint := range config.StatePreferredPeers {
+			preferred[endpoint] = struct{}{}
+		}
+		return &preferredPeerSelector{preferred: preferred}, nil
+	default:
+		return nil, errors.Errorf("unknown peer selection strategy: %s", config.StatePeerSelectionStrategy)
+	}
+}
+
+func selectRandomPeer(candidates []discovery.NetworkMember) discovery.NetworkMember {
+	return candidates[util.RandomInt(len(candidates))]
+}
+
+// randomPeerSelector selects a random peer
+type randomPeerSelector struct{}
+
+func (*randomPeerSelector) SelectPeer(candidates []discovery.NetworkMember) discovery.NetworkMember {
+	return selectRandomPeer(candidates)
+}
+
+// sameOrgPeerSelector selects a random peer of the organization of this peer, in
+// order to keep the state transfer within the organization whenever possible
+type sameOrgPeerSelector struct {
+	adapter PeerSelectionAdapter
+}
+
+func (s *sameOrgPeerSelector) SelectPeer(candidates []discovery.NetworkMember) discovery.NetworkMember {
+	identities := s.adapter.IdentityInfo().ByID()
+	self, exists := identities[string(s.adapter.SelfMembershipInfo().PKIid)]
+	if !exists {
+		return selectRandomPeer(candidates)
+	}
+
+	var sameOrg []discovery.NetworkMember
+	for _, candidate := range candidates {
+		identity, exists := identities[string(candidate.PKIid)]
+		if exists && bytes.Equal(identity.Organization, self.Organization) {
+			sameOrg = append(sameOrg, candidate)
+		}
+	}
+	if len(sameOrg) == 0 {
+		return selectRandomPeer(candidates)
+	}
+	return selectRandomPeer(sameOrg)
+}
+
+// preferredPeerSelector selects a random peer among the configured preferred peers
+type preferredPeerSelector struct {
+	preferred map[string]struct{}
+}
+
+func (s *preferredPeerSelector) SelectPeer(candidates []discovery.NetworkMember) discovery.NetworkMember {
+	var preferred []discovery.NetworkMember
+	for _, candidate := range candidates {
+		_, endpointPreferred := s.preferred[candidate.Endpoint]
+		_, internalEndpointPreferred := s.preferred[candidate.InternalEndpoint]
+		if endpointPreferred || internalEndpointPreferred {
+			preferred = append(preferred, candidate)
+		}
+	}
+	if len(preferred) == 0 {
+		return selectRandomPeer(candidates)
+	}
+	return selectRandomPeer(preferred)
+}
+
+type latencyMeasurement struct {
+	latency    time.Duration
+	failed     bool
+	measuredAt time.Time
+}
+
+// latencyObserver is implemented by the peer selectors that take into account the round trip time of the
+// state requests that are sent to the selected peers
+type latencyObserver interface {
+	// ObserveLatency records the round trip time of a state request sent to the given peer
+	ObserveLatency(pkiID common.PKIidType, latency time.Duration)
+	// ObserveFailure records that the given peer did not respond to a state request in time
+	ObserveFailure(pkiID common.PKIidType)
+}
+
+// latencyPeerSelector selects the peer with the lowest round trip time. The latency of a peer is
+// primarily the round trip time of the state requests that were sent to it. A peer that has not been
+// sent any state request yet, or whose latency is older than the refresh interval, is probed with a
+// gossip ping. The probes are performed in parallel and are only awaited for the probe timeout, and
+// the peers that did not respond by then are left out of the selection.
+type latencyPeerSelector struct {
+	probe           func(peer *comm.RemotePeer) error
+	refreshInterval time.Duration
+	probeTimeout    time.Duration
+
+	lock      sync.Mutex
+	latencies map[string]*latencyMeasurement
+	probing   map[string]struct{}
+}
+
+func (s *latencyPeerSelector) SelectPeer(candidates []discovery.NetworkMember) discovery.NetworkMember {
+	done := s.probeOutdated(candidates)
+	select {
+	case <-done:
+	case <-time.After(s.probeTimeout):
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var selected *discovery.NetworkMember
+	var lowestLatency time.Duration
+	for i, candidate := range candidates {
+		m, exists := s.latencies[string(candidate.PKIid)]
+		if !exists || m.failed {
+			continue
+		}
+		if selected == nil || m.latency < lowestLatency {
+			selected = &candidates[i]
+			lowestLatency = m.latency
+		}
+	}
+	if selected == nil {
+		return selectRandomPeer(candidates)
+	}
+	return *selected
+}
+
+// probeOutdated probes, in parallel, the candidates that were not measured yet or whose measurement is
+// outdated, and that are not being probed already. The returned channel is closed once all the probes
+// are done.
+func (s *latencyPeerSelector) probeOutdated(candidates []discovery.NetworkMember) <-chan struct{} {
+	s.lock.Lock()
+	var outdated []discovery.NetworkMember
+	for _, candidate := range candidates {
+		m, exists := s.latencies[string(candidate.PKIid)]
+		if exists && time.Since(m.measuredAt) < s.refreshInterval {
+			continue
+		}
+		if _, inProgress := s.probing[string(candidate.PKIid)]; inProgress {
+			continue
+		}
+		s.probing[string(candidate.PKIid)] = struct{}{}
+		outdated = append(outdated, candidate)
+	}
+	s.lock.Unlock()
+
+	var wg sync.WaitGroup
+	for _, peer := range outdated {
+		wg.Add(1)
+		go func(peer discovery.NetworkMember) {
+			defer wg.Done()
+			start := time.Now()
+			err := s.probe(&comm.RemotePeer{Endpoint: peer.PreferredEndpoint(), PKIID: peer.PKIid})
+			latency := time.Since(start)
+
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			delete(s.probing, string(peer.PKIid))
+			s.latencies[string(peer.PKIid)] = &latencyMeasurement{
+				latency:    latency,
+				failed:     err != nil,
+				measuredAt: time.Now(),
+			}
+		}(peer)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+func (s *latencyPeerSelector) ObserveLatency(pkiID common.PKIidType, latency time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.latencies[string(pkiID)] = &latencyMeasurement{
+		latency:    latency,
+		measuredAt: time.Now(),
+	}
+}
+
+func (s *latencyPeerSelector) ObserveFailure(pkiID common.PKIidType) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.latencies[string(pkiID)] = &latencyMeasurement{
+		failed:     true,
+		measuredAt: time.Now(),
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type peerSelectionAdapterMock struct {
+	self       discovery.NetworkMember
+	identities api.PeerIdentitySet
+	probe      func(peer *comm.RemotePeer) error
+}
+
+func (m *peerSelectionAdapterMock) Probe(peer *comm.RemotePeer) error {
+	return m.probe(peer)
+}
+
+func (m *peerSelectionAdapterMock) IdentityInfo() api.PeerIdentitySet {
+	return m.identities
+}
+
+func (m *peerSelectionAdapterMock) SelfMembershipInfo() discovery.NetworkMember {
+	return m.self
+}
+
+func member(endpoint string) discovery.NetworkMember {
+	return discovery.NetworkMember{Endpoint: endpoint, PKIid: common.PKIidType(endpoint)}
+}
+
+func TestNewPeerSelector(t *testing.T) {
+	adapter := &peerSelectionAdapterMock{}
+	for strategy, expected := range map[string]PeerSelector{
+		"":        &randomPeerSelector{},
+		"random":  &randomPeerSelector{},
+		"sameOrg": &sameOrgPeerSelector{adapter: adapter},
+	} {
+		selector, err := NewPeerSelector(&StateConfig{StatePeerSelectionStrategy: strategy}, adapter)
+		require.NoError(t, err)
+		require.Equal(t, expected, selector)
+	}
+
+	selector, err := NewPeerSelector(&StateConfig{StatePeerSelectionStrategy: "latency", StateLatencyRefreshInterval: time.Minute}, adapter)
+	require.NoError(t, err)
+	require.IsType(t, &latencyPeerSelector{}, selector)
+	require.Equal(t, time.Minute, selector.(*latencyPeerSelector).refreshInterval)
+
+	selector, err = NewPeerSelector(&StateConfig{StatePeerSelectionStrategy: "preferred", StatePreferredPeers: []string{"p1:7051"}}, adapter)
+	require.NoError(t, err)
+	require.Equal(t, &preferredPeerSelector{preferred: map[string]struct{}{"p1:7051": {}}}, selector)
+
+	_, err = NewPeerSelector(&StateConfig{StatePeerSelectionStrategy: "preferred"}, adapter)
+	require.EqualError(t, err, "no preferred peers configured for the preferred peer selection strategy")
+
+	_, err = NewPeerSelector(&StateConfig{StatePeerSelectionStrategy: "closest"}, adapter)
+	require.EqualError(t, err, "unknown peer selection strategy: closest")
+}
+
+func TestSameOrgPeerSelector(t *testing.T) {
+	adapter := &peerSelectionAdapterMock{
+		self: member("p0:7051"),
+		identities: api.PeerIdentitySet{
+			{PKIId: common.PKIidType("p0:7051"), Organization: api.OrgIdentityType("Org1MSP")},
+			{PKIId: common.PKIidType("p1:7051"), Organization: api.OrgIdentityType("Org2MSP")},
+			{PKIId: common.PKIidType("p2:7051"), Organization: api.OrgIdentityType("Org1MSP")},
+			{PKIId: common.PKIidType("p3:7051"), Organization: api.OrgIdentityType("Org2MSP")},
+		},
+	}
+	selector := &sameOrgPeerSelector{adapter: adapter}
+
+	candidates := []discovery.NetworkMember{member("p1:7051"), member("p2:7051"), member("p3:7051")}
+	for i := 0; i < 10; i++ {
+		require.Equal(t, member("p2:7051"), selector.SelectPeer(candidates))
+	}
+
+	// No peer of the same organization
+	candidates = []discovery.NetworkMember{member("p1:7051"), member("p3:7051")}
+	require.Contains(t, candidates, selector.SelectPeer(candidates))
+
+	// Unknown identity of the peer itself
+	adapter.self = member("p9:7051")
+	require.Contains(t, candidates, selector.SelectPeer(candidates))
+}
+
+func TestPreferredPeerSelector(t *testing.T) {
+	selector := &preferredPeerSelector{preferred: map[string]struct{}{"p2:7051": {}, "p3.internal:7051": {}}}
+
+	p3 := member("p3:7051")
+	p3.InternalEndpoint = "p3.internal:7051"
+	candidates := []discovery.NetworkMember{member("p1:7051"), member("p2:7051"), p3}
+	selected := map[string]struct{}{}
+	for i := 0; i < 100; i++ {
+		selected[selector.SelectPeer(candidates).Endpoint] = struct{}{}
+	}
+	require.Equal(t, map[string]struct{}{"p2:7051": {}, "p3:7051": {}}, selected)
+
+	// No preferred peer
+	candidates = []discovery.NetworkMember{member("p1:7051"), member("p4:7051")}
+	require.Contains(t, candidates, selector.SelectPeer(candidates))
+}
+
+func TestLatencyPeerSelector(t *testing.T) {
+	latencies := map[string]time.Duration{
+		"p1:7051": 30 * time.Millisecond,
+		"p2:7051": 10 * time.Millisecond,
+		"p3:7051": 0,
+		"p6:7051": time.Second,
+	}
+	var lock sync.Mutex
+	probes := map[string]int{}
+	probeCount := func(endpoint string) int {
+		lock.Lock()
+		defer lock.Unlock()
+		return probes[endpoint]
+	}
+	adapter := &peerSelectionAdapterMock{
+		probe: func(peer *comm.RemotePeer) error {
+			lock.Lock()
+			probes[peer.Endpoint]++
+			lock.Unlock()
+			latency, exists := latencies[peer.Endpoint]
+			if !exists {
+				return errors.New("unreachable")
+			}
+			time.Sleep(latency)
+			return nil
+		},
+	}
+	selector, err := NewPeerSelector(&StateConfig{StatePeerSelectionStrategy: "latency", StateLatencyRefreshInterval: time.Hour}, adapter)
+	require.NoError(t, err)
+	require.Equal(t, latencyProbeTimeout, selector.(*latencyPeerSelector).probeTimeout)
+	selector.(*latencyPeerSelector).probeTimeout = 200 * time.Millisecond
+
+	// p3 is the fastest but is not a candidate, p4 is unreachable
+	candidates := []discovery.NetworkMember{member("p1:7051"), member("p2:7051"), member("p4:7051")}
+	require.Equal(t, member("p2:7051"), selector.SelectPeer(candidates))
+	require.Equal(t, member("p2:7051"), selector.SelectPeer(candidates))
+	require.Equal(t, 1, probeCount("p1:7051"))
+	require.Equal(t, 1, probeCount("p2:7051"))
+	require.Equal(t, 1, probeCount("p4:7051"))
+
+	candidates = append(candidates, member("p3:7051"))
+	require.Equal(t, member("p3:7051"), selector.SelectPeer(candidates))
+	require.Equal(t, 1, probeCount("p3:7051"))
+
+	// No reachable peer
+	candidates = []discovery.NetworkMember{member("p4:7051"), member("p5:7051")}
+	require.Contains(t, candidates, selector.SelectPeer(candidates))
+
+	// The selection does not wait for slow probes, which are not repeated while in progress
+	candidates = []discovery.NetworkMember{member("p1:7051"), member("p6:7051")}
+	start := time.Now()
+	require.Equal(t, member("p1:7051"), selector.SelectPeer(candidates))
+	require.Equal(t, member("p1:7051"), selector.SelectPeer(candidates))
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, 1, probeCount("p6:7051"))
+
+	// The round trip times of the state requests take precedence over the probes
+	selector.(latencyObserver).ObserveLatency(member("p1:7051").PKIid, time.Hour)
+	require.Equal(t, member("p2:7051"), selector.SelectPeer([]discovery.NetworkMember{member("p1:7051"), member("p2:7051")}))
+	selector.(latencyObserver).ObserveFailure(member("p2:7051").PKIid)
+	require.Equal(t, member("p1:7051"), selector.SelectPeer([]discovery.NetworkMember{member("p1:7051"), member("p2:7051")}))
+	require.Equal(t, 1, probeCount("p1:7051"))
+
+	// Outdated measurements are refreshed
+	selector.(*latencyPeerSelector).refreshInterval = 0
+	selector.SelectPeer([]discovery.NetworkMember{member("p1:7051")})
+	require.Equal(t, 2, probeCount("p1:7051"))
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// blockRateLimiter limits the rate of the blocks sent in state transfer responses.
+// It is a token bucket that is refilled with blocksPerSecond tokens per second
+// and holds up to a second worth of tokens.
+type blockRateLimiter struct {
+	blocksPerSecond float64
+
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newBlockRateLimiter(blocksPerSecond int) *blockRateLimiter {
+	return &blockRateLimiter{
+		blocksPerSecond: float64(blocksPerSecond),
+		tokens:          float64(blocksPerSecond),
+		last:            time.Now(),
+		now:             time.Now,
+	}
+}
+
+// reserve takes a token for a block and returns the time to wait before the block can be sent.
+// No token is taken, and false is returned, if the block cannot be sent within maxDelay.
+func (l *blockRateLimiter) reserve(maxDelay time.Duration) (time.Duration, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.blocksPerSecond
+	if l.tokens > l.blocksPerSecond {
+		l.tokens = l.blocksPerSecond
+	}
+	l.last = now
+
+	delay := time.Duration(0)
+	if l.tokens < 1 {
+		delay = time.Duration((1 - l.tokens) / l.blocksPerSecond * float64(time.Second))
+	}
+	if delay > maxDelay {
+		return 0, false
+	}
+	l.tokens--
+	return delay, true
+}
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newBlockRateLimiter(2)
+	limiter.now = func() time.Time { return now }
+	limiter.last = now
+
+	reserve := func(maxDelay time.Duration) time.Duration {
+		delay, ok := limiter.reserve(maxDelay)
+		require.True(t, ok)
+		return delay
+	}
+
+	// A second worth of blocks is sent right away
+	require.Zero(t, reserve(0))
+	require.Zero(t, reserve(0))
+	require.Equal(t, 500*time.Millisecond, reserve(time.Minute))
+
+	// No token is taken for a block that cannot be sent within the maximum delay
+	_, ok := limiter.reserve(999 * time.Millisecond)
+	require.False(t, ok)
+	require.Equal(t, time.Second, reserve(time.Second))
+
+	now = now.Add(2 * time.Second)
+	require.Zero(t, reserve(0))
+
+	// The tokens do not accumulate beyond a second worth of blocks
+	now = now.Add(time.Hour)
+	require.Zero(t, reserve(0))
+	require.Zero(t, reserve(0))
+	require.Equal(t, 500*time.Millisecond, reserve(time.Minute))
+}
@@ -125,6 +125,10 @@ type ledgerResources interface {
 type ServicesMediator struct {
 	GossipAdapter
 	MCSAdapter
+
+	// PeerSelector selects the peer to request missing blocks from.
+	// If not set, a random peer is selected.
+	PeerSelector PeerSelector
 }
 
 // GossipStateProviderImpl the implementation of the GossipStateProvider interface
@@ -157,6 +161,12 @@ type GossipStateProviderImpl struct {
 
 	requestValidator *stateRequestValidator
 
+	peerSelector PeerSelector
+
+	// blockRateLimiter limits the rate of the blocks sent in state responses,
+	// it is nil if the rate is not limited
+	blockRateLimiter *blockRateLimiter
+
 	blockingMode bool
 
 	config *StateConfig
@@ -255,6 +265,21 @@ func NewGossipStateProvider(
 		config:              config,
 	}
 
+	s.peerSelector = services.PeerSelector
+	if s.peerSelector == nil {
+		s.peerSelector = &randomPeerSelector{}
+	}
+	if config.StateMaxBlocksPerSecond > 0 {
+		s.blockRateLimiter = newBlockRateLimiter(config.StateMaxBlocksPerSecond)
+		// a response carries up to a second worth of blocks right away, and the blocks sent within the maximum delay
+		maxBlocks := uint64(float64(config.StateMaxBlocksPerSecond) * (1 + maxStateResponseDelay(config).Seconds()))
+		if maxBlocks < config.StateBatchSize {
+			logger.Warningf("The maximum of %d blocks per second limits the state responses of channel %s to about %d blocks "+
+				"instead of the batch size of %d, as a response is not delayed by more than half of the response timeout of %v",
+				config.StateMaxBlocksPerSecond, chainID, maxBlocks, config.StateBatchSize, config.StateResponseTimeout)
+		}
+	}
+
 	logger.Infof("Updating metadata information for channel %s, "+
 		"current ledger sequence is at = %d, next expected block is = %d", chainID, height-1, s.payloads.Next())
 	logger.Debug("Updating gossip ledger height to", height)
@@ -438,8 +463,14 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg protoext.ReceivedMessag
 
 	endSeqNum := min(currentHeight, request.EndSeqNum)
 
+	// the blocks that cannot be sent within the maximum delay because of the rate limit are left
+	// out of the response, and are requested again by the requester
+	deadline := time.Now().Add(maxStateResponseDelay(s.config))
 	response := &proto.RemoteStateResponse{Payloads: make([]*proto.Payload, 0)}
 	for seqNum := request.StartSeqNum; seqNum <= endSeqNum; seqNum++ {
+		if !s.waitForBlockRateLimit(time.Until(deadline)) {
+			break
+		}
 		s.logger.Debug("Reading block ", seqNum, " with private data from the coordinator service")
 		connInfo := msg.GetConnectionInfo()
 		peerAuthInfo := protoutil.SignedData{
@@ -481,6 +512,10 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg protoext.ReceivedMessag
 			PrivateData: pvtBytes,
 		})
 	}
+	if len(response.Payloads) == 0 {
+		s.logger.Debugf("No block of [%d...%d] can be sent within the rate limit, ignoring request", request.StartSeqNum, endSeqNum)
+		return
+	}
 	// Sending back response with missing blocks
 	msg.Respond(&proto.GossipMessage{
 		// Copy nonce field from the request, so it will be possible to match response
@@ -491,6 +526,35 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg protoext.ReceivedMessag
 	})
 }
 
+// maxStateResponseDelay returns the maximum time for which a state response is delayed by the rate
+// limit, which is half of the response timeout so that the requester receives the response in time
+func maxStateResponseDelay(config *StateConfig) time.Duration {
+	return config.StateResponseTimeout / 2
+}
+
+// waitForBlockRateLimit waits until the next block can be sent without exceeding the maximum
+// rate of blocks sent in state responses. It returns false, without waiting, if the block cannot
+// be sent within maxDelay, and false if the state provider was stopped meanwhile.
+func (s *GossipStateProviderImpl) waitForBlockRateLimit(maxDelay time.Duration) bool {
+	if s.blockRateLimiter == nil {
+		return true
+	}
+	delay, ok := s.blockRateLimiter.reserve(maxDelay)
+	if !ok {
+		return false
+	}
+	if delay <= 0 {
+		return true
+	}
+	s.logger.Debugf("Delaying state response by %v to limit the rate of blocks sent", delay)
+	select {
+	case <-time.After(delay):
+		return true
+	case <-s.stopCh:
+		return false
+	}
+}
+
 func (s *GossipStateProviderImpl) handleStateResponse(msg protoext.ReceivedMessage) (uint64, error) {
 	max := uint64(0)
 	// Send signal that response for given nonce has been received
@@ -657,6 +721,7 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 			s.logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d), "+
 				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
 
+			sentAt := time.Now()
 			s.mediator.Send(gossipMsg, peer)
 			tryCounts++
 
@@ -670,6 +735,9 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 					gossipMsg.Nonce {
 					continue
 				}
+				if observer, ok := s.peerSelector.(latencyObserver); ok {
+					observer.ObserveLatency(peer.PKIID, time.Since(sentAt))
+				}
 				// Got corresponding response for state request, can continue
 				index, err := s.handleStateResponse(msg)
 				if err != nil {
@@ -680,6 +748,9 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 				prev = index + 1
 				responseReceived = true
 			case <-time.After(s.config.StateResponseTimeout):
+				if observer, ok := s.peerSelector.(latencyObserver); ok {
+					observer.ObserveFailure(peer.PKIID)
+				}
 			}
 		}
 	}
@@ -705,22 +776,22 @@ func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.
 	// Filter peers which posses required range of missing blocks
 	peers := s.filterPeers(s.hasRequiredHeight(height))
 
-	n := len(peers)
-	if n == 0 {
+	if len(peers) == 0 {
 		return nil, errors.New("there are no peers to ask for missing blocks from")
 	}
 
 	// Select peer to ask for blocks
-	return peers[util.RandomInt(n)], nil
+	peer := s.peerSelector.SelectPeer(peers)
+	return &comm.RemotePeer{Endpoint: peer.PreferredEndpoint(), PKIID: peer.PKIid}, nil
 }
 
 // filterPeers returns list of peers which aligns the predicate provided
-func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.NetworkMember) bool) []*comm.RemotePeer {
-	var peers []*comm.RemotePeer
+func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.NetworkMember) bool) []discovery.NetworkMember {
+	var peers []discovery.NetworkMember
 
 	for _, member := range s.mediator.PeersOfChannel(common2.ChannelID(s.chainID)) {
 		if predicate(member) {
-			peers = append(peers, &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid})
+			peers = append(peers, member)
 		}
 	}
 
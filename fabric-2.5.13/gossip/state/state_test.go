@@ -1724,6 +1724,59 @@ func TestTransferOfPvtDataBetweenPeers(t *testing.T) {
 	}
 }
 
+func TestSelectPeerToRequestFrom(t *testing.T) {
+	g := &mocks.GossipMock{}
+	g.On("PeersOfChannel", mock.Anything).Return([]discovery.NetworkMember{
+		{PKIid: common.PKIidType("a"), Endpoint: "a:7051", Properties: &proto.Properties{LedgerHeight: 5}},
+		{PKIid: common.PKIidType("b"), Endpoint: "b:7051", InternalEndpoint: "b.internal:7051", Properties: &proto.Properties{LedgerHeight: 10}},
+		{PKIid: common.PKIidType("c"), Endpoint: "c:7051", Properties: &proto.Properties{LedgerHeight: 10}},
+		{PKIid: common.PKIidType("d"), Endpoint: "d:7051"},
+	})
+	s := &GossipStateProviderImpl{
+		logger:       flogging.MustGetLogger(gossiputil.StateLogger),
+		chainID:      "testchannelid",
+		mediator:     &ServicesMediator{GossipAdapter: g},
+		peerSelector: &preferredPeerSelector{preferred: map[string]struct{}{"a:7051": {}, "b:7051": {}}},
+	}
+
+	// a is preferred but does not have the requested blocks
+	for i := 0; i < 10; i++ {
+		peer, err := s.selectPeerToRequestFrom(10)
+		require.NoError(t, err)
+		require.Equal(t, &comm.RemotePeer{Endpoint: "b.internal:7051", PKIID: common.PKIidType("b")}, peer)
+	}
+
+	_, err := s.selectPeerToRequestFrom(11)
+	require.EqualError(t, err, "there are no peers to ask for missing blocks from")
+}
+
+func TestWaitForBlockRateLimit(t *testing.T) {
+	s := &GossipStateProviderImpl{
+		logger: flogging.MustGetLogger(gossiputil.StateLogger),
+		stopCh: make(chan struct{}),
+	}
+	require.True(t, s.waitForBlockRateLimit(0))
+
+	s.blockRateLimiter = newBlockRateLimiter(10)
+	start := time.Now()
+	for i := 0; i < 12; i++ {
+		require.True(t, s.waitForBlockRateLimit(time.Second))
+	}
+	require.True(t, time.Since(start) >= 150*time.Millisecond)
+
+	// A block that cannot be sent within the maximum delay is not waited for
+	start = time.Now()
+	require.False(t, s.waitForBlockRateLimit(time.Millisecond))
+	require.True(t, time.Since(start) < 50*time.Millisecond)
+
+	// Stopping the state provider aborts the wait
+	close(s.stopCh)
+	for i := 0; i < 10; i++ {
+		s.blockRateLimiter.reserve(time.Minute)
+	}
+	require.False(t, s.waitForBlockRateLimit(time.Minute))
+}
+
 func TestStateRequestValidator(t *testing.T) {
 	validator := &stateRequestValidator{}
 	err := validator.validate(&proto.RemoteStateRequest{
@@ -106,11 +106,15 @@ type GossipElection struct {
 	MembershipSampleInterval time.Duration `yaml:"membershipSampleInterval,omitempty"`
 	LeaderAliveThreshold     time.Duration `yaml:"leaderAliveThreshold,omitempty"`
 	LeaderElectionDuration   time.Duration `yaml:"leaderElectionDuration,omitempty"`
+	Weight                   uint32        `yaml:"weight,omitempty"`
 }
 
 type GossipPvtData struct {
 	PullRetryThreshold                         time.Duration                   `yaml:"pullRetryThreshold,omitempty"`
 	TransientstoreMaxBlockRetention            int                             `yaml:"transientstoreMaxBlockRetention,omitempty"`
+	TransientstoreTTL                          time.Duration                   `yaml:"transientstoreTTL,omitempty"`
+	TransientstoreChannelQuota                 int64                           `yaml:"transientstoreChannelQuota,omitempty"`
+	TransientstoreCollectionQuota              int64                           `yaml:"transientstoreCollectionQuota,omitempty"`
 	PushAckTimeout                             time.Duration                   `yaml:"pushAckTimeout,omitempty"`
 	BtlPullMargin                              int                             `yaml:"btlPullMargin,omitempty"`
 	ReconcileBatchSize                         int                             `yaml:"reconcileBatchSize,omitempty"`
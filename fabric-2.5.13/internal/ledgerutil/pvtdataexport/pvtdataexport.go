@@ -0,0 +1,232 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataexport
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/hyperledger/fabric/internal/ledgerutil/jsonrw"
+	"github.com/pkg/errors"
+)
+
+const (
+	ledgersDataDirName = "ledgersData"
+	nsJoiner           = "$$"
+	pvtDataPrefix      = "p"
+	hashDataPrefix     = "h"
+)
+
+// Collection identifies a private data collection of a chaincode
+type Collection struct {
+	Namespace  string
+	Collection string
+}
+
+// ParseCollection parses a collection specified as <namespace>:<collection>
+func ParseCollection(s string) (*Collection, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("invalid collection [%s], expected format is <namespace>:<collection>", s)
+	}
+	return &Collection{Namespace: parts[0], Collection: parts[1]}, nil
+}
+
+// Summary reports the outcome of the export of a collection
+type Summary struct {
+	Namespace  string
+	Collection string
+	// Entries is the number of private data keys exported
+	Entries int
+	// HashMismatches is the number of exported keys for which the hash of the value does not match the
+	// hash maintained in the public state
+	HashMismatches int
+	// MissingValues is the number of keys that have a hash in the public state but no private value on this peer
+	MissingValues int
+}
+
+// pvtDataEntry represents the current value of a private data key in the export output
+type pvtDataEntry struct {
+	Key       string `json:"key"`
+	Value     []byte `json:"value"`
+	KeyHash   string `json:"keyHash"`
+	ValueHash string `json:"valueHash"`
+	HashMatch bool   `json:"valueHashMatch"`
+	BlockNum  uint64 `json:"blockNum"`
+	TxNum     uint64 `json:"txNum"`
+}
+
+// ExportPvtData - Exports the current private state of the given collections of a channel from the LevelDB
+// state database of a peer, whose file system path is fsPath. The peer must be stopped.
+// It creates a directory in outputDirLoc for the channel and stores a JSON file for each collection in
+// the directory. Each exported key carries the hash of the key and the hash of the value that are maintained
+// in the public state, that is, the value returned by GetPrivateDataHash, for cross-checking the export.
+func ExportPvtData(fsPath, channelID string, collections []*Collection, outputDirLoc string) ([]*Summary, error) {
+	if len(collections) == 0 {
+		return nil, errors.New("no collections specified. Aborting pvtdata export")
+	}
+
+	stateDBPath := kvledger.StateDBPath(filepath.Join(fsPath, ledgersDataDirName))
+	exists, err := fileutil.DirExists(stateDBPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("LevelDB state database not found in provided path %s. Only the state stored in LevelDB can be exported "+
+			"offline, use the private data export service of the peer for CouchDB. Aborting pvtdata export", fsPath)
+	}
+
+	// Output directory creation
+	outputDirName := fmt.Sprintf("%s_pvtdata_export", channelID)
+	outputDirPath := filepath.Join(outputDirLoc, outputDirName)
+	empty, err := fileutil.CreateDirIfMissing(outputDirPath)
+	if err != nil {
+		return nil, err
+	}
+	if !empty {
+		return nil, errors.Errorf("%s already exists in %s. Choose a different location or remove the existing results. Aborting pvtdata export", outputDirName, outputDirLoc)
+	}
+
+	dbProvider, err := stateleveldb.NewVersionedDBProvider(stateDBPath)
+	if err != nil {
+		return nil, err
+	}
+	defer dbProvider.Close()
+	db, err := dbProvider.GetDBHandle(channelID, nil)
+	if err != nil {
+		return nil, err
+	}
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, errors.Errorf("state database for %s does not exist. Aborting pvtdata export", channelID)
+	}
+
+	var summaries []*Summary
+	for _, c := range collections {
+		summary, err := exportCollection(db, channelID, savepoint.BlockNum, c, outputDirPath)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// exportCollection writes the private data of a collection as a JSON object to a file named after the collection
+func exportCollection(db statedb.VersionedDB, channelID string, savepoint uint64, c *Collection, outputDirPath string) (*Summary, error) {
+	writer, err := jsonrw.NewJSONFileWriter(filepath.Join(outputDirPath, fmt.Sprintf("%s_%s.json", c.Namespace, c.Collection)))
+	if err != nil {
+		return nil, err
+	}
+	if err = writer.OpenObject(); err != nil {
+		return nil, err
+	}
+	for _, field := range []struct {
+		k string
+		v interface{}
+	}{
+		{"ledgerid", channelID},
+		{"savepoint", savepoint},
+		{"namespace", c.Namespace},
+		{"collection", c.Collection},
+	} {
+		if err = writer.AddField(field.k, field.v); err != nil {
+			return nil, err
+		}
+	}
+	if err = writer.AddField("entries", []*pvtDataEntry{}); err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{Namespace: c.Namespace, Collection: c.Collection}
+	exportedKeyHashes := map[string]struct{}{}
+
+	pvtDataNs := c.Namespace + nsJoiner + pvtDataPrefix + c.Collection
+	hashedDataNs := c.Namespace + nsJoiner + hashDataPrefix + c.Collection
+	itr, err := db.GetStateRangeScanIterator(pvtDataNs, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+	for {
+		kv, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if kv == nil {
+			break
+		}
+		keyHash := util.ComputeStringHash(kv.Key)
+		hashedValue, err := db.GetState(hashedDataNs, string(keyHash))
+		if err != nil {
+			return nil, err
+		}
+		entry := &pvtDataEntry{
+			Key:      kv.Key,
+			Value:    kv.Value,
+			KeyHash:  hex.EncodeToString(keyHash),
+			BlockNum: kv.Version.BlockNum,
+			TxNum:    kv.Version.TxNum,
+		}
+		if hashedValue != nil {
+			entry.ValueHash = hex.EncodeToString(hashedValue.Value)
+			entry.HashMatch = bytes.Equal(hashedValue.Value, util.ComputeHash(kv.Value))
+		}
+		if !entry.HashMatch {
+			summary.HashMismatches++
+		}
+		if err = writer.AddEntry(entry); err != nil {
+			return nil, err
+		}
+		exportedKeyHashes[string(keyHash)] = struct{}{}
+		summary.Entries++
+	}
+
+	// Count the keys whose private value is missing on this peer, for example, because it has not been reconciled yet
+	hashItr, err := db.GetStateRangeScanIterator(hashedDataNs, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer hashItr.Close()
+	for {
+		kv, err := hashItr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if kv == nil {
+			break
+		}
+		if _, ok := exportedKeyHashes[kv.Key]; !ok {
+			summary.MissingValues++
+		}
+	}
+
+	if err = writer.CloseList(); err != nil {
+		return nil, err
+	}
+	if err = writer.AddField("missingValues", summary.MissingValues); err != nil {
+		return nil, err
+	}
+	if err = writer.CloseObject(); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataexport
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/require"
+)
+
+// SamplePvtDataDir contains the state database of the channel mychannel with the private data of the
+// collection ns1:coll1 at height 5. The hash of the value of key2 does not match the private value, and
+// the private value of key4 is missing.
+const SamplePvtDataDir = "../testdata/sample_pvtdata/"
+
+type exportedCollection struct {
+	Ledgerid      string          `json:"ledgerid"`
+	Savepoint     uint64          `json:"savepoint"`
+	Namespace     string          `json:"namespace"`
+	Collection    string          `json:"collection"`
+	Entries       []*pvtDataEntry `json:"entries"`
+	MissingValues int             `json:"missingValues"`
+}
+
+func TestExportPvtData(t *testing.T) {
+	fsPath := t.TempDir()
+	require.NoError(t, testutil.CopyDir(SamplePvtDataDir, fsPath, false))
+
+	t.Run("export collections", func(t *testing.T) {
+		outputDir := t.TempDir()
+		summaries, err := ExportPvtData(fsPath, "mychannel", []*Collection{
+			{Namespace: "ns1", Collection: "coll1"},
+			{Namespace: "ns1", Collection: "coll2"},
+		}, outputDir)
+		require.NoError(t, err)
+		require.Equal(t, []*Summary{
+			{Namespace: "ns1", Collection: "coll1", Entries: 3, HashMismatches: 1, MissingValues: 1},
+			{Namespace: "ns1", Collection: "coll2", Entries: 0},
+		}, summaries)
+
+		exported := readExportedCollection(t, filepath.Join(outputDir, "mychannel_pvtdata_export", "ns1_coll1.json"))
+		require.Equal(t, "mychannel", exported.Ledgerid)
+		require.Equal(t, uint64(5), exported.Savepoint)
+		require.Equal(t, "ns1", exported.Namespace)
+		require.Equal(t, "coll1", exported.Collection)
+		require.Equal(t, 1, exported.MissingValues)
+		require.Equal(t, []*pvtDataEntry{
+			{
+				Key:       "key1",
+				Value:     []byte("value1"),
+				KeyHash:   hex.EncodeToString(util.ComputeStringHash("key1")),
+				ValueHash: hex.EncodeToString(util.ComputeHash([]byte("value1"))),
+				HashMatch: true,
+				BlockNum:  2,
+				TxNum:     1,
+			},
+			{
+				Key:       "key2",
+				Value:     []byte("value2"),
+				KeyHash:   hex.EncodeToString(util.ComputeStringHash("key2")),
+				ValueHash: hex.EncodeToString(util.ComputeHash([]byte("tampered"))),
+				HashMatch: false,
+				BlockNum:  3,
+				TxNum:     0,
+			},
+			{
+				Key:       "key3",
+				Value:     []byte("value3"),
+				KeyHash:   hex.EncodeToString(util.ComputeStringHash("key3")),
+				ValueHash: hex.EncodeToString(util.ComputeHash([]byte("value3"))),
+				HashMatch: true,
+				BlockNum:  5,
+				TxNum:     2,
+			},
+		}, exported.Entries)
+
+		exported = readExportedCollection(t, filepath.Join(outputDir, "mychannel_pvtdata_export", "ns1_coll2.json"))
+		require.Empty(t, exported.Entries)
+	})
+
+	t.Run("output directory already exists", func(t *testing.T) {
+		outputDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(outputDir, "mychannel_pvtdata_export", "previous"), 0o755))
+		_, err := ExportPvtData(fsPath, "mychannel", []*Collection{{Namespace: "ns1", Collection: "coll1"}}, outputDir)
+		require.EqualError(t, err, "mychannel_pvtdata_export already exists in "+outputDir+". Choose a different location or remove the existing results. Aborting pvtdata export")
+	})
+
+	t.Run("channel does not exist", func(t *testing.T) {
+		_, err := ExportPvtData(fsPath, "otherchannel", []*Collection{{Namespace: "ns1", Collection: "coll1"}}, t.TempDir())
+		require.EqualError(t, err, "state database for otherchannel does not exist. Aborting pvtdata export")
+	})
+
+	t.Run("state database does not exist", func(t *testing.T) {
+		emptyPath := t.TempDir()
+		_, err := ExportPvtData(emptyPath, "mychannel", []*Collection{{Namespace: "ns1", Collection: "coll1"}}, t.TempDir())
+		require.EqualError(t, err, "LevelDB state database not found in provided path "+emptyPath+". Only the state stored in LevelDB can be exported "+
+			"offline, use the private data export service of the peer for CouchDB. Aborting pvtdata export")
+	})
+
+	t.Run("no collections", func(t *testing.T) {
+		_, err := ExportPvtData(fsPath, "mychannel", nil, t.TempDir())
+		require.EqualError(t, err, "no collections specified. Aborting pvtdata export")
+	})
+}
+
+func TestParseCollection(t *testing.T) {
+	c, err := ParseCollection("ns1:coll1")
+	require.NoError(t, err)
+	require.Equal(t, &Collection{Namespace: "ns1", Collection: "coll1"}, c)
+
+	for _, invalid := range []string{"ns1", "ns1:", ":coll1", "ns1:coll1:key"} {
+		_, err := ParseCollection(invalid)
+		require.EqualError(t, err, "invalid collection ["+invalid+"], expected format is <namespace>:<collection>")
+	}
+}
+
+func readExportedCollection(t *testing.T, path string) *exportedCollection {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	exported := &exportedCollection{}
+	require.NoError(t, json.Unmarshal(content, exported))
+	return exported
+}
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// Gets whether an OSN is in maintenance mode, and the channels it is the Raft leader of.
+func MaintenanceStatus(osnURL string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/maintenance", osnURL)
+
+	return httpGet(url, caCertPool, tlsClientCert)
+}
+
+// Puts an OSN in maintenance mode, in which it rejects broadcast requests and
+// transfers the leadership of the Raft clusters it leads to other consenters.
+func EnterMaintenance(osnURL string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/maintenance", osnURL)
+
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, caCertPool, tlsClientCert)
+}
+
+// Takes an OSN out of maintenance mode.
+func ExitMaintenance(osnURL string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/maintenance", osnURL)
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, caCertPool, tlsClientCert)
+}
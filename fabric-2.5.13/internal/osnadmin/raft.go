@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// Gets the status of the Raft node of an OSN for a channel, and of its storage.
+func RaftStatus(osnURL, channelID string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/raft", osnURL, channelID)
+
+	return httpGet(url, caCertPool, tlsClientCert)
+}
+
+// Takes a snapshot of the Raft node of an OSN for a channel, and purges the
+// write-ahead log files and snapshots that precede it.
+func RaftSnapshot(osnURL, channelID string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/raft/snapshot", osnURL, channelID)
+
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, caCertPool, tlsClientCert)
+}
+
+// Transfers the leadership of the Raft cluster of a channel to the consenter
+// with the given Raft ID, or to any recently active consenter if it is zero.
+func TransferLeader(osnURL, channelID string, to uint64, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := fmt.Sprintf("%s/participation/v1/channels/%s/raft/transfer-leader", osnURL, channelID)
+	if to != 0 {
+		url = fmt.Sprintf("%s?to=%d", url, to)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, caCertPool, tlsClientCert)
+}
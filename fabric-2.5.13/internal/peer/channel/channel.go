@@ -36,7 +36,10 @@ var (
 	genesisBlockPath string
 
 	// joinbysnapshot related variables
-	snapshotPath string
+	snapshotPath            string
+	fromPeer                string
+	fromPeerTLSRootCertFile string
+	fromPeerBlockNumber     uint64
 
 	// create related variables
 	channelID     string
@@ -82,6 +85,9 @@ func resetFlags() {
 
 	flags.StringVarP(&genesisBlockPath, "blockpath", "b", common.UndefinedParamValue, "Path to file containing genesis block")
 	flags.StringVarP(&snapshotPath, "snapshotpath", "", common.UndefinedParamValue, "Path to the snapshot directory")
+	flags.StringVarP(&fromPeer, "from-peer", "", "", "Address of the peer from which the joining peer downloads the snapshot, instead of --snapshotpath")
+	flags.StringVarP(&fromPeerTLSRootCertFile, "from-peer-tls-rootcert", "", "", "Path to the TLS root cert file of the peer from which the snapshot is downloaded, the TLS root cert of the joining peer is used if not set")
+	flags.Uint64VarP(&fromPeerBlockNumber, "from-peer-block", "", 0, "Block number of the snapshot to download, the most recent snapshot available on the peer is downloaded if not set")
 	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "In case of a newChain command, the channel ID to create. It must be all lower case, less than 250 characters long and match the regular expression: [a-z][a-z0-9.-]*")
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
-	"errors"
+	"io/ioutil"
+	"strconv"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -20,13 +22,19 @@ func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
 	joinbysnapshotCmd := &cobra.Command{
 		Use:   "joinbysnapshot",
 		Short: "Joins the peer to a channel by the specified snapshot",
-		Long:  "Joins the peer to a channel by the specified snapshot",
+		Long: "Joins the peer to a channel by the specified snapshot. When --from-peer is set instead of --snapshotpath, the peer " +
+			"downloads the snapshot of the channel from that peer, verifies it against the hashes in the snapshot metadata and " +
+			"joins the channel once the download completes. An interrupted download is resumed when the command is rerun.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return joinBySnapshot(cmd, args, cf)
 		},
 	}
 	flagList := []string{
 		"snapshotpath",
+		"channelID",
+		"from-peer",
+		"from-peer-tls-rootcert",
+		"from-peer-block",
 	}
 	attachFlags(joinbysnapshotCmd, flagList)
 
@@ -34,7 +42,23 @@ func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
 }
 
 func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
-	if snapshotPath == common.UndefinedParamValue {
+	var input [][]byte
+	switch {
+	case fromPeer != "":
+		if channelID == common.UndefinedParamValue {
+			return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+		}
+		input = [][]byte{[]byte(cscc.JoinChainBySnapshotFromPeer), []byte(channelID), []byte(fromPeer), []byte(strconv.FormatUint(fromPeerBlockNumber, 10))}
+		if fromPeerTLSRootCertFile != "" {
+			tlsRootCert, err := ioutil.ReadFile(fromPeerTLSRootCertFile)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read TLS root cert file of peer %s", fromPeer)
+			}
+			input = append(input, tlsRootCert)
+		}
+	case snapshotPath != common.UndefinedParamValue:
+		input = [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(snapshotPath)}
+	default:
 		return errors.New("the required parameter 'snapshotpath' is empty. Rerun the command with --snapshotpath flag")
 	}
 
@@ -52,7 +76,7 @@ func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) er
 	spec := &pb.ChaincodeSpec{
 		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
 		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
-		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(snapshotPath)}},
+		Input:       &pb.ChaincodeInput{Args: input},
 	}
 
 	if err = executeJoin(cf, spec); err != nil {
@@ -49,6 +49,27 @@ func TestJoinBySnapshot(t *testing.T) {
 	cmd.SetArgs([]string{})
 	require.EqualError(t, cmd.Execute(), "the required parameter 'snapshotpath' is empty. Rerun the command with --snapshotpath flag")
 
+	// error due to missing channelID when downloading from a peer
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--from-peer", "peer1:7051"})
+	require.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -c flag")
+
+	// successful test when the joining peer downloads the snapshot from another peer
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--from-peer", "peer1:7051", "-c", "mychannel", "--from-peer-block", "10"})
+	require.NoError(t, cmd.Execute())
+
+	// error due to missing TLS root cert file of the peer serving the snapshot
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--from-peer", "peer1:7051", "-c", "mychannel", "--from-peer-tls-rootcert", "missing.crt"})
+	require.EqualError(t, cmd.Execute(), "failed to read TLS root cert file of peer peer1:7051: open missing.crt: no such file or directory")
+
 	// error due to EndoserClient returning bad response
 	mockResponse.Response = &pb.Response{Status: 500}
 	resetFlags()
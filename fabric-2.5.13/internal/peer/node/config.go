@@ -46,6 +46,16 @@ func ledgerConfig() *ledger.Config {
 		purgedKeyAuditLogging = viper.GetBool("ledger.pvtdataStore.purgedKeyAuditLogging")
 	}
 
+	hotKeysCapacity := 100
+	if viper.IsSet("ledger.state.hotKeysCapacity") {
+		hotKeysCapacity = viper.GetInt("ledger.state.hotKeysCapacity")
+	}
+
+	txInvalidationInfoRetentionBlocks := uint64(10000)
+	if viper.IsSet("ledger.txInvalidationInfo.retentionBlocks") {
+		txInvalidationInfoRetentionBlocks = viper.GetUint64("ledger.txInvalidationInfo.retentionBlocks")
+	}
+
 	fsPath := coreconfig.GetPath("peer.fileSystemPath")
 	ledgersDataRootDir := filepath.Join(fsPath, "ledgersData")
 	snapshotsRootDir := viper.GetString("ledger.snapshots.rootDir")
@@ -55,8 +65,10 @@ func ledgerConfig() *ledger.Config {
 	conf := &ledger.Config{
 		RootFSPath: ledgersDataRootDir,
 		StateDBConfig: &ledger.StateDBConfig{
-			StateDatabase: viper.GetString("ledger.state.stateDatabase"),
-			CouchDB:       &ledger.CouchDBConfig{},
+			StateDatabase:         viper.GetString("ledger.state.stateDatabase"),
+			CouchDB:               &ledger.CouchDBConfig{},
+			ValidationParallelism: viper.GetInt("ledger.state.validationParallelism"),
+			HotKeysCapacity:       hotKeysCapacity,
 		},
 		PrivateDataConfig: &ledger.PrivateDataConfig{
 			MaxBatchSize:                        collElgProcMaxDbBatchSize,
@@ -64,6 +76,7 @@ func ledgerConfig() *ledger.Config {
 			PurgeInterval:                       purgeInterval,
 			DeprioritizedDataReconcilerInterval: deprioritizedDataReconcilerInterval,
 			PurgedKeyAuditLogging:               purgedKeyAuditLogging,
+			EncryptionEnabled:                   viper.GetBool("ledger.pvtdataStore.encryption.enabled"),
 		},
 		HistoryDBConfig: &ledger.HistoryDBConfig{
 			Enabled: viper.GetBool("ledger.history.enableHistoryDatabase"),
@@ -71,6 +84,9 @@ func ledgerConfig() *ledger.Config {
 		SnapshotsConfig: &ledger.SnapshotsConfig{
 			RootDir: snapshotsRootDir,
 		},
+		TxInvalidationInfoConfig: &ledger.TxInvalidationInfoConfig{
+			RetentionBlocks: txInvalidationInfoRetentionBlocks,
+		},
 	}
 
 	if conf.StateDBConfig.StateDatabase == ledger.CouchDB {
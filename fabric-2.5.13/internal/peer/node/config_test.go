@@ -31,8 +31,9 @@ func TestLedgerConfig(t *testing.T) {
 			expected: &ledger.Config{
 				RootFSPath: "/peerfs/ledgersData",
 				StateDBConfig: &ledger.StateDBConfig{
-					StateDatabase: "goleveldb",
-					CouchDB:       &ledger.CouchDBConfig{},
+					StateDatabase:   "goleveldb",
+					CouchDB:         &ledger.CouchDBConfig{},
+					HotKeysCapacity: 100,
 				},
 				PrivateDataConfig: &ledger.PrivateDataConfig{
 					MaxBatchSize:                        5000,
@@ -47,6 +48,9 @@ func TestLedgerConfig(t *testing.T) {
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/snapshots",
 				},
+				TxInvalidationInfoConfig: &ledger.TxInvalidationInfoConfig{
+					RetentionBlocks: 10000,
+				},
 			},
 		},
 		{
@@ -80,6 +84,7 @@ func TestLedgerConfig(t *testing.T) {
 						RedoLogPath:           "/peerfs/ledgersData/couchdbRedoLogs",
 						UserCacheSizeMBs:      64,
 					},
+					HotKeysCapacity: 100,
 				},
 				PrivateDataConfig: &ledger.PrivateDataConfig{
 					MaxBatchSize:                        5000,
@@ -94,6 +99,9 @@ func TestLedgerConfig(t *testing.T) {
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/snapshots",
 				},
+				TxInvalidationInfoConfig: &ledger.TxInvalidationInfoConfig{
+					RetentionBlocks: 10000,
+				},
 			},
 		},
 		{
@@ -111,13 +119,17 @@ func TestLedgerConfig(t *testing.T) {
 				"ledger.state.couchDBConfig.maxBatchUpdateSize":           600,
 				"ledger.state.couchDBConfig.createGlobalChangesDB":        true,
 				"ledger.state.couchDBConfig.cacheSize":                    64,
+				"ledger.state.validationParallelism":                      8,
+				"ledger.state.hotKeysCapacity":                            20,
 				"ledger.pvtdataStore.collElgProcMaxDbBatchSize":           50000,
 				"ledger.pvtdataStore.collElgProcDbBatchesInterval":        10000,
 				"ledger.pvtdataStore.purgeInterval":                       1000,
 				"ledger.pvtdataStore.purgedKeyAuditLogging":               false,
+				"ledger.pvtdataStore.encryption.enabled":                  true,
 				"ledger.pvtdataStore.deprioritizedDataReconcilerInterval": "180m",
 				"ledger.history.enableHistoryDatabase":                    true,
 				"ledger.snapshots.rootDir":                                "/peerfs/customLocationForsnapshots",
+				"ledger.txInvalidationInfo.retentionBlocks":               500,
 			},
 			expected: &ledger.Config{
 				RootFSPath: "/peerfs/ledgersData",
@@ -136,6 +148,8 @@ func TestLedgerConfig(t *testing.T) {
 						RedoLogPath:           "/peerfs/ledgersData/couchdbRedoLogs",
 						UserCacheSizeMBs:      64,
 					},
+					ValidationParallelism: 8,
+					HotKeysCapacity:       20,
 				},
 				PrivateDataConfig: &ledger.PrivateDataConfig{
 					MaxBatchSize:                        50000,
@@ -143,6 +157,7 @@ func TestLedgerConfig(t *testing.T) {
 					PurgeInterval:                       1000,
 					DeprioritizedDataReconcilerInterval: 180 * time.Minute,
 					PurgedKeyAuditLogging:               false,
+					EncryptionEnabled:                   true,
 				},
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: true,
@@ -150,6 +165,9 @@ func TestLedgerConfig(t *testing.T) {
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/customLocationForsnapshots",
 				},
+				TxInvalidationInfoConfig: &ledger.TxInvalidationInfoConfig{
+					RetentionBlocks: 500,
+				},
 			},
 		},
 	}
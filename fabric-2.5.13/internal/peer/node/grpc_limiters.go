@@ -16,6 +16,9 @@ import (
 	"google.golang.org/grpc"
 )
 
+// gatewayServiceName is the name of the gateway service, whose concurrency limit applies to the gateway REST API as well
+const gatewayServiceName = "/gateway.Gateway"
+
 func initGrpcSemaphores(config *peer.Config) map[string]semaphore.Semaphore {
 	semaphores := make(map[string]semaphore.Semaphore)
 	endorserConcurrency := config.LimitsConcurrencyEndorserService
@@ -35,7 +38,9 @@ func initGrpcSemaphores(config *peer.Config) map[string]semaphore.Semaphore {
 	}
 	if gatewayConcurrency != 0 {
 		logger.Infof("concurrency limit for gateway service is %d", gatewayConcurrency)
-		semaphores["/gateway.Gateway"] = semaphore.New(gatewayConcurrency)
+		semaphores[gatewayServiceName] = semaphore.New(gatewayConcurrency)
+		// The gateway block events service is defined in this repository, and shares the gateway service limit.
+		semaphores["/blockevents.BlockEvents"] = semaphores[gatewayServiceName]
 	}
 
 	return semaphores
@@ -24,7 +24,8 @@ func TestInitGrpcSemaphores(t *testing.T) {
 		LimitsConcurrencyGatewayService:  5,
 	}
 	semaphores := initGrpcSemaphores(&config)
-	require.Equal(t, 3, len(semaphores))
+	require.Equal(t, 4, len(semaphores))
+	require.Equal(t, semaphores["/gateway.Gateway"], semaphores["/blockevents.BlockEvents"])
 }
 
 func TestInitGrpcNoSemaphores(t *testing.T) {
@@ -82,4 +83,5 @@ func TestGetServiceName(t *testing.T) {
 	require.Equal(t, "/gateway.Gateway", getServiceName("/gateway.Gateway/Submit"))
 	require.Equal(t, "/gateway.Gateway", getServiceName("/gateway.Gateway/CommitStatus"))
 	require.Equal(t, "/gateway.Gateway", getServiceName("/gateway.Gateway/ChaincodeEvents"))
+	require.Equal(t, "/blockevents.BlockEvents", getServiceName("/blockevents.BlockEvents/FilteredBlockEvents"))
 }
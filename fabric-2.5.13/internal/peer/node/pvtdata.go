@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/fabhttp"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	operationsAddress string
+	operationsCAFile  string
+	operationsCert    string
+	operationsKey     string
+	fromBlock         uint64
+	toBlock           uint64
+	pvtdataNamespace  string
+	pvtdataCollection string
+	missingLimit      int
+)
+
+func pvtdataCmd() *cobra.Command {
+	nodePvtdataCmd.ResetCommands()
+	nodePvtdataCmd.ResetFlags()
+	flags := nodePvtdataCmd.PersistentFlags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel of the private data.")
+	flags.StringVar(&operationsAddress, "operationsAddress", "", "Address of the operations service of the peer. Defaults to operations.listenAddress of the peer configuration.")
+	flags.StringVar(&operationsCAFile, "cafile", "", "Path to file containing PEM-encoded TLS CA certificate(s) for the operations service. TLS is used if set.")
+	flags.StringVar(&operationsCert, "certfile", "", "Path to file containing PEM-encoded X509 public key to use for mutual TLS communication with the operations service.")
+	flags.StringVar(&operationsKey, "keyfile", "", "Path to file containing PEM-encoded private key to use for mutual TLS communication with the operations service.")
+
+	nodePvtdataCmd.AddCommand(pvtdataStatusCmd)
+	nodePvtdataCmd.AddCommand(pvtdataPauseCmd)
+	nodePvtdataCmd.AddCommand(pvtdataResumeCmd)
+
+	pvtdataListMissingCmd.ResetFlags()
+	addPvtdataFilterFlags(pvtdataListMissingCmd)
+	pvtdataListMissingCmd.Flags().IntVar(&missingLimit, "limit", 100, "Maximum number of missing private data items to list. 0 lists all the items.")
+	nodePvtdataCmd.AddCommand(pvtdataListMissingCmd)
+
+	pvtdataReconcileCmd.ResetFlags()
+	addPvtdataFilterFlags(pvtdataReconcileCmd)
+	nodePvtdataCmd.AddCommand(pvtdataReconcileCmd)
+
+	return nodePvtdataCmd
+}
+
+func addPvtdataFilterFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.Uint64Var(&fromBlock, "fromBlock", 0, "Lowest block number of the missing private data.")
+	flags.Uint64Var(&toBlock, "toBlock", 0, "Highest block number of the missing private data. 0 does not bound the range.")
+	flags.StringVar(&pvtdataNamespace, "namespace", "", "Chaincode of the missing private data.")
+	flags.StringVar(&pvtdataCollection, "collection", "", "Collection of the missing private data.")
+}
+
+var nodePvtdataCmd = &cobra.Command{
+	Use:   "pvtdata",
+	Short: "Controls the reconciliation of missing private data on a running peer.",
+	Long: "Controls the reconciliation of missing private data on a running peer, through the operations service of the peer." +
+		" The private data of a collection that a peer is eligible for may be missing when the peers that hold it were not" +
+		" reachable at the time the block was committed.",
+}
+
+var pvtdataStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows the status of the reconciliation of a channel.",
+	Long:  "Shows whether the reconciliation of a channel is paused, the outcome of the last reconciliation, and the requests sent to each peer for missing private data.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return callReconciliationService(cmd, http.MethodGet, gossipprivdata.ReconciliationPath, nil)
+	},
+}
+
+var pvtdataPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pauses the scheduled reconciliation of a channel.",
+	Long:  "Pauses the reconciliation of missing private data that is scheduled every peer.gossip.pvtData.reconcileSleepInterval, until it is resumed or the peer restarts.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return callReconciliationService(cmd, http.MethodPost, gossipprivdata.ReconciliationPath, url.Values{"action": {"pause"}})
+	},
+}
+
+var pvtdataResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resumes the scheduled reconciliation of a channel.",
+	Long:  "Resumes the reconciliation of missing private data that is scheduled every peer.gossip.pvtData.reconcileSleepInterval.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return callReconciliationService(cmd, http.MethodPost, gossipprivdata.ReconciliationPath, url.Values{"action": {"resume"}})
+	},
+}
+
+var pvtdataListMissingCmd = &cobra.Command{
+	Use:   "list-missing",
+	Short: "Lists the missing private data of a channel.",
+	Long:  "Lists the missing private data of a channel by block, transaction and collection, starting from the most recent block.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := pvtdataFilterQuery()
+		query.Set("limit", strconv.Itoa(missingLimit))
+		return callReconciliationService(cmd, http.MethodGet, gossipprivdata.MissingPvtDataPath, query)
+	},
+}
+
+var pvtdataReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Reconciles the missing private data of a channel immediately.",
+	Long: "Pulls from the other peers the missing private data of a channel in the given block range and collection, even if the" +
+		" scheduled reconciliation is paused, and reports the number of private data items that were reconciled.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := pvtdataFilterQuery()
+		query.Set("action", "reconcile")
+		return callReconciliationService(cmd, http.MethodPost, gossipprivdata.ReconciliationPath, query)
+	},
+}
+
+func pvtdataFilterQuery() url.Values {
+	query := url.Values{}
+	if fromBlock != 0 {
+		query.Set("from", strconv.FormatUint(fromBlock, 10))
+	}
+	if toBlock != 0 {
+		query.Set("to", strconv.FormatUint(toBlock, 10))
+	}
+	if pvtdataNamespace != "" {
+		query.Set("namespace", pvtdataNamespace)
+	}
+	if pvtdataCollection != "" {
+		query.Set("collection", pvtdataCollection)
+	}
+	return query
+}
+
+// callReconciliationService sends a request to the reconciliation endpoints of the operations
+// service of the peer, and writes the response to the output of the command
+func callReconciliationService(cmd *cobra.Command, method, path string, query url.Values) error {
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("channel", channelID)
+
+	client, scheme, err := operationsClient()
+	if err != nil {
+		return err
+	}
+	address := operationsAddress
+	if address == "" {
+		address = viper.GetString("operations.listenAddress")
+	}
+	if address == "" {
+		return errors.New("Must supply the address of the operations service")
+	}
+
+	// Once the arguments are validated, do not print the usage in case of error
+	cmd.SilenceUsage = true
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s://%s%s?%s", scheme, address, path, query.Encode()), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call the operations service")
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the response of the operations service")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResp := &fabhttp.ErrorResponse{}
+		if err := json.Unmarshal(body, errResp); err != nil || errResp.Error == "" {
+			return errors.Errorf("operations service responded with status %s", resp.Status)
+		}
+		return errors.Errorf("operations service responded with status %s: %s", resp.Status, errResp.Error)
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return errors.Wrap(err, "failed to parse the response of the operations service")
+	}
+	_, err = cmd.OutOrStdout().Write(out.Bytes())
+	return err
+}
+
+func operationsClient() (*http.Client, string, error) {
+	if operationsCAFile == "" {
+		return &http.Client{}, "http", nil
+	}
+	caCert, err := os.ReadFile(operationsCAFile)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to read the CA certificate")
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, "", errors.Errorf("failed to add the CA certificate from %s", operationsCAFile)
+	}
+	tlsConfig := &tls.Config{RootCAs: caCertPool}
+	if operationsCert != "" || operationsKey != "" {
+		clientCert, err := tls.LoadX509KeyPair(operationsCert, operationsKey)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to load the client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}, "https", nil
+}
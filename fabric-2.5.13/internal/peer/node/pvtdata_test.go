@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPvtdataCmd(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req)
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Query().Get("channel") == "unknown" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"channel does not exist: unknown"}`))
+			return
+		}
+		w.Write([]byte(`{"channel":"mychannel","reconciled":2}`))
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	run := func(args ...string) (string, error) {
+		cmd := pvtdataCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		_, err := run("status", "--operationsAddress", address)
+		require.EqualError(t, err, "Must supply channel ID")
+	})
+
+	t.Run("reconcile", func(t *testing.T) {
+		requests = nil
+		out, err := run("reconcile", "-c", "mychannel", "--operationsAddress", address, "--fromBlock", "3", "--collection", "col1")
+		require.NoError(t, err)
+		require.Equal(t, "{\n  \"channel\": \"mychannel\",\n  \"reconciled\": 2\n}", out)
+		require.Len(t, requests, 1)
+		require.Equal(t, http.MethodPost, requests[0].Method)
+		require.Equal(t, "/pvtdata/reconciliation", requests[0].URL.Path)
+		require.Equal(t, "action=reconcile&channel=mychannel&collection=col1&from=3", requests[0].URL.RawQuery)
+	})
+
+	t.Run("list missing", func(t *testing.T) {
+		requests = nil
+		_, err := run("list-missing", "-c", "mychannel", "--operationsAddress", address, "--limit", "5")
+		require.NoError(t, err)
+		require.Equal(t, http.MethodGet, requests[0].Method)
+		require.Equal(t, "/pvtdata/missing", requests[0].URL.Path)
+		require.Equal(t, "channel=mychannel&limit=5", requests[0].URL.RawQuery)
+	})
+
+	t.Run("pause, resume and status", func(t *testing.T) {
+		requests = nil
+		for _, subCmd := range []string{"pause", "resume", "status"} {
+			_, err := run(subCmd, "-c", "mychannel", "--operationsAddress", address)
+			require.NoError(t, err)
+		}
+		require.Len(t, requests, 3)
+		require.Equal(t, "action=pause&channel=mychannel", requests[0].URL.RawQuery)
+		require.Equal(t, "action=resume&channel=mychannel", requests[1].URL.RawQuery)
+		require.Equal(t, http.MethodGet, requests[2].Method)
+		require.Equal(t, "channel=mychannel", requests[2].URL.RawQuery)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		_, err := run("status", "-c", "unknown", "--operationsAddress", address)
+		require.EqualError(t, err, "operations service responded with status 404 Not Found: channel does not exist: unknown")
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		_, err := run("status", "-c", "mychannel", "--operationsAddress", address, "--cafile", "testdata/missing.pem")
+		require.ErrorContains(t, err, "failed to read the CA certificate")
+	})
+}
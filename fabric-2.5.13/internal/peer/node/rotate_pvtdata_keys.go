@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	coreconfig "github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/spf13/cobra"
+)
+
+func rotatePvtdataKeysCmd() *cobra.Command {
+	return nodeRotatePvtdataKeysCmd
+}
+
+var nodeRotatePvtdataKeysCmd = &cobra.Command{
+	Use:   "rotate-pvtdata-keys",
+	Short: "Rotates the keys used for encrypting private data.",
+	Long: "Generates a new key encryption key through the BCCSP of the peer and re-wraps the data encryption keys" +
+		" of the private data store and of the transient store of all the channels with it." +
+		" The private data that is stored from now on is encrypted with a new data encryption key." +
+		" When the command is executed, the peer must be offline.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rotatePvtdataKeys(factory.GetDefault())
+	},
+}
+
+func rotatePvtdataKeys(csp bccsp.BCCSP) error {
+	config := ledgerConfig()
+	ledgerIDs, err := kvledger.RotatePvtdataEncryptionKeys(config, csp)
+	if err != nil {
+		return err
+	}
+
+	encryptionProvider, err := pvtdataencryption.NewProvider(csp, true)
+	if err != nil {
+		return err
+	}
+	transientStoragePath := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "transientstore")
+	return transientstore.RotateEncryptionKeys(transientStoragePath, ledgerIDs, encryptionProvider)
+}
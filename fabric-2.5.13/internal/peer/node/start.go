@@ -16,6 +16,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -37,10 +38,12 @@ import (
 	floggingmetrics "github.com/hyperledger/fabric/common/flogging/metrics"
 	"github.com/hyperledger/fabric/common/grpclogging"
 	"github.com/hyperledger/fabric/common/grpcmetrics"
+	"github.com/hyperledger/fabric/common/grpctracing"
 	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/cclifecycle"
 	"github.com/hyperledger/fabric/core/chaincode"
@@ -66,8 +69,11 @@ import (
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/hotkeys"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataexport"
 	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
@@ -89,10 +95,15 @@ import (
 	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
 	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
 	gossipservice "github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/internal/peer/common"
 	peergossip "github.com/hyperledger/fabric/internal/peer/gossip"
 	"github.com/hyperledger/fabric/internal/peer/version"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/gateway"
+	gatewayblockevents "github.com/hyperledger/fabric/internal/pkg/gateway/blockevents"
+	gatewayconfig "github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	gatewayprepared "github.com/hyperledger/fabric/internal/pkg/gateway/prepared"
+	gatewayrest "github.com/hyperledger/fabric/internal/pkg/gateway/rest"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protoutil"
@@ -240,6 +251,17 @@ func serve(args []string) error {
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
 	flogging.SetObserver(logObserver)
 
+	shutdownTracing, err := tracing.Initialize("peer", coreConfig.PeerID, tracing.Config{
+		Provider:    coreConfig.TracingProvider,
+		Endpoint:    coreConfig.TracingOTLPEndpoint,
+		Insecure:    coreConfig.TracingOTLPInsecure,
+		SampleRatio: coreConfig.TracingSampleRatio,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to initialize tracing")
+	}
+	defer shutdownTracing()
+
 	chaincodeInstallPath := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "lifecycle", "chaincodes")
 	ccStore := persistence.NewStore(chaincodeInstallPath)
 	ccPackageParser := &persistence.ChaincodePackageParser{
@@ -261,11 +283,13 @@ func serve(args []string) error {
 	serverConfig.ServerStatsHandler = comm.NewServerStatsHandler(metricsProvider)
 	serverConfig.UnaryInterceptors = append(
 		serverConfig.UnaryInterceptors,
+		grpctracing.UnaryServerInterceptor(),
 		grpcmetrics.UnaryServerInterceptor(grpcmetrics.NewUnaryMetrics(metricsProvider)),
 		grpclogging.UnaryServerInterceptor(flogging.MustGetLogger("comm.grpc.server").Zap()),
 	)
 	serverConfig.StreamInterceptors = append(
 		serverConfig.StreamInterceptors,
+		grpctracing.StreamServerInterceptor(),
 		grpcmetrics.StreamServerInterceptor(grpcmetrics.NewStreamMetrics(metricsProvider)),
 		grpclogging.StreamServerInterceptor(flogging.MustGetLogger("comm.grpc.server").Zap()),
 	)
@@ -289,8 +313,24 @@ func serve(args []string) error {
 		cs.SetClientCertificate(clientCert)
 	}
 
+	pvtdataEncryptionProvider, err := pvtdataencryption.NewProvider(
+		factory.GetDefault(),
+		ledgerConfig().PrivateDataConfig.EncryptionEnabled,
+	)
+	if err != nil {
+		return errors.WithMessage(err, "failed to initialize encryption of private data")
+	}
+
+	gossipServiceConfig := gossipservice.GlobalConfig()
 	transientStoreProvider, err := transientstore.NewStoreProvider(
 		filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "transientstore"),
+		&transientstore.Config{
+			EncryptionProvider: pvtdataEncryptionProvider,
+			TTL:                gossipServiceConfig.TransientstoreTTL,
+			ChannelQuota:       gossipServiceConfig.TransientstoreChannelQuota,
+			CollectionQuota:    gossipServiceConfig.TransientstoreCollectionQuota,
+			MetricsProvider:    metricsProvider,
+		},
 	)
 	if err != nil {
 		return errors.WithMessage(err, "failed to open transient store")
@@ -306,6 +346,18 @@ func serve(args []string) error {
 		OrdererEndpointOverrides: deliverServiceConfig.OrdererEndpointOverrides,
 	}
 
+	opsSystem.RegisterHandler(
+		"/ledger/hotkeys",
+		hotkeys.NewHandler(func(channelID string) ([]*hotkeys.HotKey, bool) {
+			l := peerInstance.GetLedger(channelID)
+			if l == nil {
+				return nil, false
+			}
+			return l.GetHotKeys(), true
+		}),
+		coreConfig.OperationsTLSEnabled,
+	)
+
 	identityDeserializerFactory := func(channelName string) msp.IdentityDeserializer {
 		if channel := peerInstance.Channel(channelName); channel != nil {
 			return channel.MSPManager()
@@ -442,6 +494,7 @@ func serve(args []string) error {
 		cb.HeaderType_CONFIG: &peer.ConfigTxProcessor{},
 	}
 
+	peerLedgerConfig := ledgerConfig()
 	peerInstance.LedgerMgr = ledgermgmt.NewLedgerMgr(
 		&ledgermgmt.Initializer{
 			CustomTxProcessors:              txProcessors,
@@ -451,9 +504,10 @@ func serve(args []string) error {
 			MetricsProvider:                 metricsProvider,
 			HealthCheckRegistry:             opsSystem,
 			StateListeners:                  []ledger.StateListener{lifecycleCache},
-			Config:                          ledgerConfig(),
+			Config:                          peerLedgerConfig,
 			HashProvider:                    factory.GetDefault(),
 			EbMetadataProvider:              ebMetadataProvider,
+			CryptoProvider:                  factory.GetDefault(),
 		},
 	)
 
@@ -481,6 +535,26 @@ func serve(args []string) error {
 
 	peerInstance.GossipService = gossipService
 
+	reconciliationHandler := gossipprivdata.NewReconciliationHandler(gossipService.PvtDataReconciler)
+	opsSystem.RegisterHandler(gossipprivdata.ReconciliationPath, reconciliationHandler, coreConfig.OperationsTLSEnabled)
+	opsSystem.RegisterHandler(gossipprivdata.MissingPvtDataPath, reconciliationHandler, coreConfig.OperationsTLSEnabled)
+	opsSystem.RegisterHandler(
+		gossipservice.MembershipPath,
+		gossipservice.NewMembershipHandler(gossipService.MembershipView),
+		coreConfig.OperationsTLSEnabled,
+	)
+	opsSystem.RegisterHandler(
+		gossipservice.YieldLeadershipPath,
+		gossipservice.NewYieldLeadershipHandler(gossipService.YieldLeadership),
+		coreConfig.OperationsTLSEnabled,
+	)
+	reloadGossip := reloadGossipConfig(gossipService)
+	opsSystem.RegisterHandler(
+		gossipservice.ReloadPath,
+		gossipservice.NewReloadHandler(reloadGossip),
+		coreConfig.OperationsTLSEnabled,
+	)
+
 	if err := lifecycleCache.InitializeLocalChaincodes(); err != nil {
 		return errors.WithMessage(err, "could not initialize local chaincodes")
 	}
@@ -712,6 +786,20 @@ func serve(args []string) error {
 		lifecycleValidatorCommitter,
 		peerInstance,
 		factory.GetDefault(),
+		&snapshotgrpc.PeerFetcher{
+			SnapshotsRootDir: peerLedgerConfig.SnapshotsConfig.RootDir,
+			ClientConfig: comm.ClientConfig{
+				SecOpts: comm.SecureOptions{
+					UseTLS:            serverConfig.SecOpts.UseTLS,
+					RequireClientCert: true,
+					Certificate:       serverConfig.SecOpts.Certificate,
+					Key:               serverConfig.SecOpts.Key,
+					ServerRootCAs:     serverConfig.SecOpts.ServerRootCAs,
+				},
+				DialTimeout: comm.DefaultConnectionTimeout,
+			},
+			Signer: signingIdentity,
+		},
 	)
 	qsccInst := scc.SelfDescribingSysCC(qscc.New(aclProvider, peerInstance))
 
@@ -846,8 +934,46 @@ func serve(args []string) error {
 				coreConfig.LocalMSPID,
 				coreConfig.GatewayOptions,
 				builtinSCCs,
+				metricsProvider,
 			)
 			gatewayprotos.RegisterGatewayServer(peerServer.Server(), gatewayServer)
+			gatewayblockevents.RegisterBlockEventsServer(peerServer.Server(), gatewayServer)
+
+			var preparedTransactionsServer *gateway.PreparedTransactionsServer
+			if coreConfig.GatewayOptions.PreparedTransactions.Enabled {
+				preparedTransactionsStore, err := gatewayprepared.NewStore(
+					filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "gateway", "preparedTransactions"),
+					gatewayprepared.Quotas{
+						MaxTransactions:            coreConfig.GatewayOptions.PreparedTransactions.MaxTransactions,
+						MaxTransactionsPerIdentity: coreConfig.GatewayOptions.PreparedTransactions.MaxTransactionsPerIdentity,
+					},
+				)
+				if err != nil {
+					return errors.WithMessage(err, "failed to open gateway prepared transactions store")
+				}
+				defer preparedTransactionsStore.Close()
+
+				preparedTransactionsServer = gateway.NewPreparedTransactionsServer(gatewayServer, preparedTransactionsStore, coreConfig.GatewayOptions.PreparedTransactions)
+				gatewayprepared.RegisterPreparedTransactionsServer(peerServer.Server(), preparedTransactionsServer)
+			}
+
+			if coreConfig.GatewayOptions.HTTP.Enabled {
+				gatewayHTTPServer := newGatewayHTTPServer(coreConfig.GatewayOptions.HTTP)
+				gatewayHTTPHandler := gatewayrest.NewHTTPHandler(gatewayServer, coreConfig.GatewayOptions.HTTP)
+				if sema, ok := semaphores[gatewayServiceName]; ok {
+					gatewayHTTPHandler.LimitConcurrency(sema)
+				}
+				if preparedTransactionsServer != nil {
+					gatewayHTTPHandler.RegisterPreparedTransactions(preparedTransactionsServer)
+				}
+				// Requests are authenticated by the signatures they carry, rather than by client certificates
+				gatewayHTTPServer.RegisterHandler(gatewayrest.URLBaseV1, gatewayHTTPHandler, false)
+				if err := gatewayHTTPServer.Start(); err != nil {
+					return errors.WithMessage(err, "failed to start gateway HTTP server")
+				}
+				defer gatewayHTTPServer.Stop()
+				logger.Infof("Gateway HTTP/JSON endpoint listening on %s", gatewayHTTPServer.Addr())
+			}
 		} else {
 			logger.Warning("Discovery service must be enabled for embedded gateway")
 		}
@@ -877,6 +1003,11 @@ func serve(args []string) error {
 	handleSignals(addPlatformSignals(map[os.Signal]func(){
 		syscall.SIGINT:  func() { containerRouter.Shutdown(5 * time.Second); serve <- nil },
 		syscall.SIGTERM: func() { containerRouter.Shutdown(5 * time.Second); serve <- nil },
+		syscall.SIGHUP: func() {
+			if err := reloadGossip(); err != nil {
+				logger.Errorf("Failed to reload gossip configuration: %s", err)
+			}
+		},
 	}))
 
 	logger.Infof("Started peer with ID=[%s], network ID=[%s], address=[%s]", coreConfig.PeerID, coreConfig.NetworkID, coreConfig.PeerAddress)
@@ -916,6 +1047,17 @@ func serve(args []string) error {
 	snapshotSvc := &snapshotgrpc.SnapshotService{LedgerGetter: peerInstance, ACLProvider: aclProvider}
 	pb.RegisterSnapshotServer(peerServer.Server(), snapshotSvc)
 
+	// register the snapshot transfer server, used by other peers for joining a channel by snapshot
+	snapshotTransferSvc := &snapshotgrpc.TransferService{
+		SnapshotsRootDir: peerLedgerConfig.SnapshotsConfig.RootDir,
+		ACLProvider:      aclProvider,
+	}
+	snapshotgrpc.RegisterSnapshotTransferServer(peerServer.Server(), snapshotTransferSvc)
+
+	// register the private data export server, used by admins for exporting the private data held by the peer
+	pvtdataExportSvc := &pvtdataexport.ExportService{LedgerGetter: peerInstance, ACLProvider: aclProvider}
+	pvtdataexport.RegisterPvtDataExportServer(peerServer.Server(), pvtdataExportSvc)
+
 	go func() {
 		var grpcErr error
 		if grpcErr = peerServer.Start(); grpcErr != nil {
@@ -928,6 +1070,33 @@ func serve(args []string) error {
 	return <-serve
 }
 
+// reloadGossipConfig returns a function that re-reads the peer configuration
+// and applies the reloadable gossip configuration to the gossip service
+func reloadGossipConfig(gossipService *gossipservice.GossipService) gossipservice.ReloadFunc {
+	return func() error {
+		conf, err := readReloadableGossipConfig()
+		if err != nil {
+			return err
+		}
+		return gossipService.Reload(conf)
+	}
+}
+
+// readReloadableGossipConfig reads the peer configuration file into a separate
+// viper instance, so that only the reloadable gossip configuration is taken from
+// it and the rest of the configuration keeps the values read at startup
+func readReloadableGossipConfig() (*gossipservice.ReloadableConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(viper.ConfigFileUsed())
+	v.SetEnvPrefix(common.CmdRoot)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.Wrap(err, "failed reading peer configuration")
+	}
+	return gossipservice.LoadReloadableConfig(v)
+}
+
 func handleSignals(handlers map[os.Signal]func()) {
 	var signals []os.Signal
 	for sig := range handlers {
@@ -1286,6 +1455,20 @@ func newOperationsSystem(coreConfig *peer.Config) *operations.System {
 	})
 }
 
+func newGatewayHTTPServer(options gatewayconfig.HTTPOptions) *fabhttp.Server {
+	return fabhttp.NewServer(fabhttp.Options{
+		Logger:        flogging.MustGetLogger("gateway.http"),
+		ListenAddress: options.ListenAddress,
+		TLS: fabhttp.TLS{
+			Enabled:            options.TLSEnabled,
+			CertFile:           options.TLSCertFile,
+			KeyFile:            options.TLSKeyFile,
+			ClientCertRequired: options.TLSClientAuthRequired,
+			ClientCACertFiles:  options.TLSClientRootCAs,
+		},
+	})
+}
+
 func getDockerHostConfig() *docker.HostConfig {
 	dockerKey := func(key string) string { return "vm.docker.hostConfig." + key }
 	getInt64 := func(key string) int64 { return int64(viper.GetInt(dockerKey(key))) }
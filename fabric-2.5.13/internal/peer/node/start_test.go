@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -216,3 +217,29 @@ func TestResetLoop(t *testing.T) {
 	require.False(t, resetFilter.reject)
 	require.Equal(t, 4, peerLedger.GetBlockchainInfoCallCount())
 }
+
+func TestReadReloadableGossipConfig(t *testing.T) {
+	defer viper.Reset()
+
+	configFile := filepath.Join(t.TempDir(), "core.yaml")
+	writeConfig := func(id, bootstrap string) {
+		config := "peer:\n  id: " + id + "\n  gossip:\n    bootstrap: " + bootstrap + "\n    orgLeader: true\n"
+		require.NoError(t, ioutil.WriteFile(configFile, []byte(config), 0o600))
+	}
+	writeConfig("peer0", "peer1:7051")
+	viper.SetConfigFile(configFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	writeConfig("peer9", "peer2:7051")
+	conf, err := readReloadableGossipConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{"peer2:7051"}, conf.BootstrapPeers)
+	require.True(t, conf.OrgLeader)
+	// The configuration that is not reloadable keeps the values read at startup
+	require.Equal(t, "peer0", viper.GetString("peer.id"))
+	require.Equal(t, []string{"peer1:7051"}, viper.GetStringSlice("peer.gossip.bootstrap"))
+
+	require.NoError(t, os.Remove(configFile))
+	_, err = readReloadableGossipConfig()
+	require.ErrorContains(t, err, "failed reading peer configuration")
+}
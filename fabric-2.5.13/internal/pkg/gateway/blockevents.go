@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	corepeer "github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/blockevents"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/event"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/ledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blockEventsRequest is a verified block events request, and the ledger it reads from.
+type blockEventsRequest struct {
+	*blockevents.BlockEventsRequest
+	signedData *protoutil.SignedData
+	ledger     ledger.Ledger
+}
+
+// blockSender sends a block to the client in the requested form. If afterTransactionID is not empty, the block
+// contains that transaction, which has already been processed by the client along with the transactions before it.
+type blockSender func(request *blockEventsRequest, block *common.Block, afterTransactionID string) error
+
+// BlockEvents supplies a stream of responses, each containing a committed block. The streamed responses are ordered by
+// ascending block number.
+func (gs *Server) BlockEvents(signedRequest *blockevents.SignedBlockEventsRequest, stream blockevents.BlockEvents_BlockEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Gateway_BlockEvents, func(_ *blockEventsRequest, block *common.Block, _ string) error {
+		return stream.Send(&blockevents.BlockEventsResponse{Block: block})
+	})
+}
+
+// FilteredBlockEvents supplies a stream of responses, each containing the filtered form of a committed block, which
+// includes the ID, type, validation code and chaincode event names of each transaction, but not their content. The
+// streamed responses are ordered by ascending block number.
+func (gs *Server) FilteredBlockEvents(signedRequest *blockevents.SignedBlockEventsRequest, stream blockevents.BlockEvents_FilteredBlockEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Gateway_FilteredBlockEvents, func(_ *blockEventsRequest, block *common.Block, afterTransactionID string) error {
+		filteredBlock, err := corepeer.FilteredBlock(block)
+		if err != nil {
+			return status.Errorf(codes.Aborted, "failed to filter block %d: %v", block.GetHeader().GetNumber(), err)
+		}
+
+		filteredBlock.FilteredTransactions = filteredTransactionsAfter(filteredBlock.GetFilteredTransactions(), afterTransactionID)
+
+		return stream.Send(&blockevents.FilteredBlockEventsResponse{FilteredBlock: filteredBlock})
+	})
+}
+
+// BlockAndPrivateDataEvents supplies a stream of responses, each containing a committed block along with the private
+// data of the collections the client is eligible to read. The streamed responses are ordered by ascending block number.
+func (gs *Server) BlockAndPrivateDataEvents(signedRequest *blockevents.SignedBlockEventsRequest, stream blockevents.BlockEvents_BlockAndPrivateDataEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Gateway_BlockAndPrivateDataEvents, func(request *blockEventsRequest, block *common.Block, _ string) error {
+		privateData, err := corepeer.EligiblePrivateData(block, request.GetChannelId(), request.ledger, gs.collectionPolicyChecker, gs.identityDeserializers, request.signedData)
+		if err != nil {
+			return status.Errorf(codes.Aborted, "failed to read private data of block %d: %v", block.GetHeader().GetNumber(), err)
+		}
+
+		response := &blockevents.BlockAndPrivateDataEventsResponse{
+			BlockAndPrivateData: &peer.BlockAndPrivateData{
+				Block:          block,
+				PrivateDataMap: privateData,
+			},
+		}
+		return stream.Send(response)
+	})
+}
+
+// blockEvents reads the blocks requested by a block events request, and passes them to the sender. If the request
+// specifies the last transaction processed by the client, blocks are skipped until the block containing that
+// transaction, in the same way as chaincode events.
+func (gs *Server) blockEvents(signedRequest *blockevents.SignedBlockEventsRequest, resource string, send blockSender) error {
+	if len(signedRequest.GetRequest()) == 0 {
+		return status.Error(codes.InvalidArgument, "a block events request is required")
+	}
+
+	request := &blockevents.BlockEventsRequest{}
+	if err := proto.Unmarshal(signedRequest.GetRequest(), request); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid block events request: %v", err)
+	}
+
+	signedData := &protoutil.SignedData{
+		Data:      signedRequest.GetRequest(),
+		Identity:  request.GetIdentity(),
+		Signature: signedRequest.GetSignature(),
+	}
+	if err := gs.policy.CheckACL(resource, request.GetChannelId(), signedData); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	ledger, err := gs.ledgerProvider.Ledger(request.GetChannelId())
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	startBlock, err := blockEventsStartBlock(ledger, request)
+	if err != nil {
+		return err
+	}
+
+	ledgerIter, err := ledger.GetBlocksIterator(startBlock)
+	if err != nil {
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	blockIter := event.NewBlockIterator(ledgerIter)
+	defer blockIter.Close()
+
+	verifiedRequest := &blockEventsRequest{
+		BlockEventsRequest: request,
+		signedData:         signedData,
+		ledger:             ledger,
+	}
+	afterTransactionID := request.GetAfterTransactionId()
+
+	for {
+		block, err := blockIter.Next()
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+
+		checkpointTransactionID := afterTransactionID
+		if len(afterTransactionID) > 0 {
+			found, last, err := findTransaction(block, afterTransactionID)
+			if err != nil {
+				return status.Error(codes.Aborted, err.Error())
+			}
+			if !found {
+				continue
+			}
+
+			afterTransactionID = ""
+			if last {
+				continue
+			}
+		}
+
+		if err := send(verifiedRequest, block.Block(), checkpointTransactionID); err != nil {
+			if err == io.EOF {
+				// Stream closed by the client
+				return status.Error(codes.Canceled, err.Error())
+			}
+			return err
+		}
+	}
+}
+
+func blockEventsStartBlock(ledger ledger.Ledger, request *blockevents.BlockEventsRequest) (uint64, error) {
+	afterTransactionID := request.GetAfterTransactionId()
+	if len(afterTransactionID) > 0 {
+		if block, err := ledger.GetBlockByTxID(afterTransactionID); err == nil {
+			return block.GetHeader().GetNumber(), nil
+		}
+	}
+
+	return startBlockFromLedgerPosition(ledger, request.GetStartPosition())
+}
+
+// findTransaction returns whether the block contains the transaction, and whether it is the last transaction in the
+// block.
+func findTransaction(block *event.Block, transactionID string) (found bool, last bool, err error) {
+	transactions, err := block.Transactions()
+	if err != nil {
+		return false, false, err
+	}
+
+	for i, transaction := range transactions {
+		if transaction.ID() == transactionID {
+			return true, i == len(transactions)-1, nil
+		}
+	}
+
+	return false, false, nil
+}
+
+func filteredTransactionsAfter(transactions []*peer.FilteredTransaction, transactionID string) []*peer.FilteredTransaction {
+	if len(transactionID) == 0 {
+		return transactions
+	}
+
+	for i, transaction := range transactions {
+		if transaction.GetTxid() == transactionID {
+			return transactions[i+1:]
+		}
+	}
+
+	return transactions
+}
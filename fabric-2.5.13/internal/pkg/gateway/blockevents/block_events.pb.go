@@ -0,0 +1,553 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: block_events.proto
+
+package blockevents
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	orderer "github.com/hyperledger/fabric-protos-go/orderer"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// BlockEventsRequest requests the blocks of a channel, in the form selected by the service invoked.
+type BlockEventsRequest struct {
+	// channel_id is the name of the channel to read blocks from
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// identity is the serialized identity of the client making the request
+	Identity []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	// start_position is the block from which to start reading, if after_transaction_id is not specified, or the
+	// transaction cannot be found. The default is the next block to be committed.
+	StartPosition *orderer.SeekPosition `protobuf:"bytes,3,opt,name=start_position,json=startPosition,proto3" json:"start_position,omitempty"`
+	// after_transaction_id is the ID of the last transaction the client processed. Only the transactions that
+	// follow it are returned.
+	AfterTransactionId   string   `protobuf:"bytes,4,opt,name=after_transaction_id,json=afterTransactionId,proto3" json:"after_transaction_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockEventsRequest) Reset()         { *m = BlockEventsRequest{} }
+func (m *BlockEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockEventsRequest) ProtoMessage()    {}
+func (*BlockEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e3c3ddf12787137, []int{0}
+}
+
+func (m *BlockEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockEventsRequest.Unmarshal(m, b)
+}
+func (m *BlockEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *BlockEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockEventsRequest.Merge(m, src)
+}
+func (m *BlockEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_BlockEventsRequest.Size(m)
+}
+func (m *BlockEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockEventsRequest proto.InternalMessageInfo
+
+func (m *BlockEventsRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *BlockEventsRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *BlockEventsRequest) GetStartPosition() *orderer.SeekPosition {
+	if m != nil {
+		return m.StartPosition
+	}
+	return nil
+}
+
+func (m *BlockEventsRequest) GetAfterTransactionId() string {
+	if m != nil {
+		return m.AfterTransactionId
+	}
+	return ""
+}
+
+// SignedBlockEventsRequest contains a marshalled BlockEventsRequest and the signature of the client
+type SignedBlockEventsRequest struct {
+	Request              []byte   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedBlockEventsRequest) Reset()         { *m = SignedBlockEventsRequest{} }
+func (m *SignedBlockEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedBlockEventsRequest) ProtoMessage()    {}
+func (*SignedBlockEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e3c3ddf12787137, []int{1}
+}
+
+func (m *SignedBlockEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedBlockEventsRequest.Unmarshal(m, b)
+}
+func (m *SignedBlockEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedBlockEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedBlockEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedBlockEventsRequest.Merge(m, src)
+}
+func (m *SignedBlockEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedBlockEventsRequest.Size(m)
+}
+func (m *SignedBlockEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedBlockEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedBlockEventsRequest proto.InternalMessageInfo
+
+func (m *SignedBlockEventsRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedBlockEventsRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// BlockEventsResponse contains a committed block
+type BlockEventsResponse struct {
+	Block                *common.Block `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BlockEventsResponse) Reset()         { *m = BlockEventsResponse{} }
+func (m *BlockEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockEventsResponse) ProtoMessage()    {}
+func (*BlockEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e3c3ddf12787137, []int{2}
+}
+
+func (m *BlockEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockEventsResponse.Unmarshal(m, b)
+}
+func (m *BlockEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockEventsResponse.Merge(m, src)
+}
+func (m *BlockEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockEventsResponse.Size(m)
+}
+func (m *BlockEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockEventsResponse proto.InternalMessageInfo
+
+func (m *BlockEventsResponse) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+// FilteredBlockEventsResponse contains the filtered form of a committed block
+type FilteredBlockEventsResponse struct {
+	FilteredBlock        *peer.FilteredBlock `protobuf:"bytes,1,opt,name=filtered_block,json=filteredBlock,proto3" json:"filtered_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *FilteredBlockEventsResponse) Reset()         { *m = FilteredBlockEventsResponse{} }
+func (m *FilteredBlockEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*FilteredBlockEventsResponse) ProtoMessage()    {}
+func (*FilteredBlockEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e3c3ddf12787137, []int{3}
+}
+
+func (m *FilteredBlockEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilteredBlockEventsResponse.Unmarshal(m, b)
+}
+func (m *FilteredBlockEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilteredBlockEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *FilteredBlockEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilteredBlockEventsResponse.Merge(m, src)
+}
+func (m *FilteredBlockEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_FilteredBlockEventsResponse.Size(m)
+}
+func (m *FilteredBlockEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilteredBlockEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilteredBlockEventsResponse proto.InternalMessageInfo
+
+func (m *FilteredBlockEventsResponse) GetFilteredBlock() *peer.FilteredBlock {
+	if m != nil {
+		return m.FilteredBlock
+	}
+	return nil
+}
+
+// BlockAndPrivateDataEventsResponse contains a committed block, and the private data of the block that the client
+// is eligible to read
+type BlockAndPrivateDataEventsResponse struct {
+	BlockAndPrivateData  *peer.BlockAndPrivateData `protobuf:"bytes,1,opt,name=block_and_private_data,json=blockAndPrivateData,proto3" json:"block_and_private_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *BlockAndPrivateDataEventsResponse) Reset()         { *m = BlockAndPrivateDataEventsResponse{} }
+func (m *BlockAndPrivateDataEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockAndPrivateDataEventsResponse) ProtoMessage()    {}
+func (*BlockAndPrivateDataEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e3c3ddf12787137, []int{4}
+}
+
+func (m *BlockAndPrivateDataEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockAndPrivateDataEventsResponse.Unmarshal(m, b)
+}
+func (m *BlockAndPrivateDataEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockAndPrivateDataEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockAndPrivateDataEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockAndPrivateDataEventsResponse.Merge(m, src)
+}
+func (m *BlockAndPrivateDataEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockAndPrivateDataEventsResponse.Size(m)
+}
+func (m *BlockAndPrivateDataEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockAndPrivateDataEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockAndPrivateDataEventsResponse proto.InternalMessageInfo
+
+func (m *BlockAndPrivateDataEventsResponse) GetBlockAndPrivateData() *peer.BlockAndPrivateData {
+	if m != nil {
+		return m.BlockAndPrivateData
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*BlockEventsRequest)(nil), "blockevents.BlockEventsRequest")
+	proto.RegisterType((*SignedBlockEventsRequest)(nil), "blockevents.SignedBlockEventsRequest")
+	proto.RegisterType((*BlockEventsResponse)(nil), "blockevents.BlockEventsResponse")
+	proto.RegisterType((*FilteredBlockEventsResponse)(nil), "blockevents.FilteredBlockEventsResponse")
+	proto.RegisterType((*BlockAndPrivateDataEventsResponse)(nil), "blockevents.BlockAndPrivateDataEventsResponse")
+}
+
+func init() { proto.RegisterFile("block_events.proto", fileDescriptor_1e3c3ddf12787137) }
+
+var fileDescriptor_1e3c3ddf12787137 = []byte{
+	// 463 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x95, 0xcb, 0x67, 0x26, 0x4d, 0x05, 0x1b, 0x8a, 0x4c, 0x0a, 0x52, 0x30, 0x42, 0xca, 0xc9,
+	0x8e, 0xc2, 0x0d, 0x55, 0x08, 0x2a, 0x40, 0xea, 0x2d, 0x72, 0x39, 0x20, 0x38, 0x58, 0x6b, 0xef,
+	0xc4, 0x59, 0xc5, 0xdd, 0x35, 0xbb, 0x93, 0xa2, 0xfc, 0x3a, 0x8e, 0xfc, 0x2d, 0x94, 0xb5, 0x43,
+	0xed, 0xd6, 0xad, 0x7a, 0xf2, 0xce, 0x9b, 0xe7, 0xf7, 0xe6, 0x0b, 0x58, 0x5a, 0xe8, 0x6c, 0x95,
+	0xe0, 0x05, 0x2a, 0xb2, 0x61, 0x69, 0x34, 0x69, 0xd6, 0x77, 0x58, 0x05, 0x8d, 0x86, 0x99, 0x3e,
+	0x3f, 0xd7, 0x2a, 0xaa, 0x3e, 0x15, 0x63, 0xf4, 0x44, 0x1b, 0x81, 0x06, 0x4d, 0xc4, 0xd3, 0x1a,
+	0x79, 0x5a, 0x22, 0x9a, 0xa8, 0x29, 0x13, 0xfc, 0xf1, 0x80, 0x9d, 0x6c, 0x95, 0xbe, 0x38, 0x34,
+	0xc6, 0x5f, 0x6b, 0xb4, 0xc4, 0x5e, 0x01, 0x64, 0x4b, 0xae, 0x14, 0x16, 0x89, 0x14, 0xbe, 0x37,
+	0xf6, 0x26, 0xbd, 0xb8, 0x57, 0x23, 0xa7, 0x82, 0x8d, 0xe0, 0xb1, 0x14, 0xa8, 0x48, 0xd2, 0xc6,
+	0xdf, 0x1b, 0x7b, 0x93, 0xfd, 0xf8, 0x7f, 0xcc, 0x8e, 0xe1, 0xc0, 0x12, 0x37, 0x94, 0x94, 0xda,
+	0x4a, 0x92, 0x5a, 0xf9, 0xf7, 0xc6, 0xde, 0xa4, 0x3f, 0x3b, 0x0c, 0xeb, 0x7a, 0xc2, 0x33, 0xc4,
+	0xd5, 0xbc, 0x4e, 0xc6, 0x03, 0x47, 0xde, 0x85, 0x6c, 0x0a, 0xcf, 0xf8, 0x82, 0xd0, 0x24, 0x64,
+	0xb8, 0xb2, 0x3c, 0xdb, 0x82, 0xdb, 0x12, 0xee, 0xbb, 0x12, 0x98, 0xcb, 0x7d, 0xbb, 0x4c, 0x9d,
+	0x8a, 0x20, 0x06, 0xff, 0x4c, 0xe6, 0x0a, 0x45, 0x47, 0x1b, 0x3e, 0x3c, 0x32, 0xd5, 0xd3, 0xf5,
+	0xb0, 0x1f, 0xef, 0x42, 0xf6, 0x12, 0x7a, 0x56, 0xe6, 0x8a, 0xd3, 0xda, 0x60, 0xdd, 0xc2, 0x25,
+	0x10, 0xbc, 0x87, 0x61, 0x4b, 0xcd, 0x96, 0x5a, 0x59, 0x64, 0x6f, 0xe0, 0x81, 0x9b, 0xba, 0x13,
+	0xeb, 0xcf, 0x06, 0x61, 0x3d, 0x6f, 0xc7, 0x8d, 0xab, 0x5c, 0xf0, 0x13, 0x8e, 0xbe, 0xca, 0x82,
+	0xd0, 0xa0, 0xe8, 0xd2, 0x38, 0x86, 0x83, 0x45, 0x9d, 0x4e, 0x9a, 0x62, 0x87, 0xd5, 0x42, 0x6c,
+	0xd8, 0xfa, 0x39, 0x1e, 0x2c, 0x9a, 0x61, 0xb0, 0x86, 0xd7, 0xee, 0xf1, 0x49, 0x89, 0xb9, 0x91,
+	0x17, 0x9c, 0xf0, 0x33, 0x27, 0x7e, 0xc5, 0x62, 0x0e, 0xcf, 0xab, 0x83, 0xe1, 0x4a, 0x24, 0x65,
+	0x45, 0x4b, 0x04, 0x27, 0x5e, 0x5b, 0x1d, 0xed, 0xac, 0x3a, 0xa4, 0xe2, 0x61, 0x7a, 0x1d, 0x9c,
+	0xfd, 0xdd, 0x83, 0x7e, 0xa3, 0x19, 0xf6, 0xbd, 0x1d, 0xbe, 0x0d, 0x1b, 0xc7, 0x18, 0xde, 0xb4,
+	0x8d, 0xd1, 0xb8, 0x45, 0xeb, 0x18, 0xce, 0xd4, 0x63, 0x4b, 0x18, 0x76, 0x4c, 0xef, 0xae, 0x0e,
+	0x93, 0x16, 0xed, 0x96, 0x35, 0x4c, 0x3d, 0x46, 0xf0, 0xe2, 0xc6, 0x51, 0xde, 0xd5, 0x2f, 0xbc,
+	0xde, 0xd1, 0x6d, 0x9b, 0x99, 0x7a, 0x27, 0x1f, 0x7f, 0x7c, 0xc8, 0x25, 0x2d, 0xd7, 0xe9, 0xf6,
+	0x76, 0xa2, 0xe5, 0xa6, 0x44, 0x53, 0xa0, 0xc8, 0xd1, 0x44, 0x0b, 0x9e, 0x1a, 0x99, 0x45, 0x52,
+	0x11, 0x1a, 0xc5, 0x8b, 0xa8, 0x5c, 0xe5, 0x51, 0xce, 0x09, 0x7f, 0xf3, 0x4d, 0xd4, 0x70, 0x49,
+	0x1f, 0xba, 0xe5, 0xbd, 0xfb, 0x37, 0x00, 0x1c, 0x64, 0x4f, 0x22, 0x15, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// BlockEventsClient is the client API for BlockEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlockEventsClient interface {
+	// BlockEvents returns a stream of committed blocks.
+	BlockEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_BlockEventsClient, error)
+	// FilteredBlockEvents returns a stream of the filtered form of committed blocks, which omit the transaction
+	// payloads.
+	FilteredBlockEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_FilteredBlockEventsClient, error)
+	// BlockAndPrivateDataEvents returns a stream of committed blocks, with the private data the client is eligible
+	// to read.
+	BlockAndPrivateDataEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_BlockAndPrivateDataEventsClient, error)
+}
+
+type blockEventsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockEventsClient(cc grpc.ClientConnInterface) BlockEventsClient {
+	return &blockEventsClient{cc}
+}
+
+func (c *blockEventsClient) BlockEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_BlockEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockEvents_serviceDesc.Streams[0], "/blockevents.BlockEvents/BlockEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockEventsBlockEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockEvents_BlockEventsClient interface {
+	Recv() (*BlockEventsResponse, error)
+	grpc.ClientStream
+}
+
+type blockEventsBlockEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockEventsBlockEventsClient) Recv() (*BlockEventsResponse, error) {
+	m := new(BlockEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockEventsClient) FilteredBlockEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_FilteredBlockEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockEvents_serviceDesc.Streams[1], "/blockevents.BlockEvents/FilteredBlockEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockEventsFilteredBlockEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockEvents_FilteredBlockEventsClient interface {
+	Recv() (*FilteredBlockEventsResponse, error)
+	grpc.ClientStream
+}
+
+type blockEventsFilteredBlockEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockEventsFilteredBlockEventsClient) Recv() (*FilteredBlockEventsResponse, error) {
+	m := new(FilteredBlockEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockEventsClient) BlockAndPrivateDataEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_BlockAndPrivateDataEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockEvents_serviceDesc.Streams[2], "/blockevents.BlockEvents/BlockAndPrivateDataEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockEventsBlockAndPrivateDataEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockEvents_BlockAndPrivateDataEventsClient interface {
+	Recv() (*BlockAndPrivateDataEventsResponse, error)
+	grpc.ClientStream
+}
+
+type blockEventsBlockAndPrivateDataEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockEventsBlockAndPrivateDataEventsClient) Recv() (*BlockAndPrivateDataEventsResponse, error) {
+	m := new(BlockAndPrivateDataEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlockEventsServer is the server API for BlockEvents service.
+type BlockEventsServer interface {
+	// BlockEvents returns a stream of committed blocks.
+	BlockEvents(*SignedBlockEventsRequest, BlockEvents_BlockEventsServer) error
+	// FilteredBlockEvents returns a stream of the filtered form of committed blocks, which omit the transaction
+	// payloads.
+	FilteredBlockEvents(*SignedBlockEventsRequest, BlockEvents_FilteredBlockEventsServer) error
+	// BlockAndPrivateDataEvents returns a stream of committed blocks, with the private data the client is eligible
+	// to read.
+	BlockAndPrivateDataEvents(*SignedBlockEventsRequest, BlockEvents_BlockAndPrivateDataEventsServer) error
+}
+
+// UnimplementedBlockEventsServer can be embedded to have forward compatible implementations.
+type UnimplementedBlockEventsServer struct {
+}
+
+func (*UnimplementedBlockEventsServer) BlockEvents(req *SignedBlockEventsRequest, srv BlockEvents_BlockEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BlockEvents not implemented")
+}
+func (*UnimplementedBlockEventsServer) FilteredBlockEvents(req *SignedBlockEventsRequest, srv BlockEvents_FilteredBlockEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method FilteredBlockEvents not implemented")
+}
+func (*UnimplementedBlockEventsServer) BlockAndPrivateDataEvents(req *SignedBlockEventsRequest, srv BlockEvents_BlockAndPrivateDataEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BlockAndPrivateDataEvents not implemented")
+}
+
+func RegisterBlockEventsServer(s *grpc.Server, srv BlockEventsServer) {
+	s.RegisterService(&_BlockEvents_serviceDesc, srv)
+}
+
+func _BlockEvents_BlockEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedBlockEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockEventsServer).BlockEvents(m, &blockEventsBlockEventsServer{stream})
+}
+
+type BlockEvents_BlockEventsServer interface {
+	Send(*BlockEventsResponse) error
+	grpc.ServerStream
+}
+
+type blockEventsBlockEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockEventsBlockEventsServer) Send(m *BlockEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockEvents_FilteredBlockEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedBlockEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockEventsServer).FilteredBlockEvents(m, &blockEventsFilteredBlockEventsServer{stream})
+}
+
+type BlockEvents_FilteredBlockEventsServer interface {
+	Send(*FilteredBlockEventsResponse) error
+	grpc.ServerStream
+}
+
+type blockEventsFilteredBlockEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockEventsFilteredBlockEventsServer) Send(m *FilteredBlockEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockEvents_BlockAndPrivateDataEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedBlockEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockEventsServer).BlockAndPrivateDataEvents(m, &blockEventsBlockAndPrivateDataEventsServer{stream})
+}
+
+type BlockEvents_BlockAndPrivateDataEventsServer interface {
+	Send(*BlockAndPrivateDataEventsResponse) error
+	grpc.ServerStream
+}
+
+type blockEventsBlockAndPrivateDataEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockEventsBlockAndPrivateDataEventsServer) Send(m *BlockAndPrivateDataEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _BlockEvents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blockevents.BlockEvents",
+	HandlerType: (*BlockEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BlockEvents",
+			Handler:       _BlockEvents_BlockEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FilteredBlockEvents",
+			Handler:       _BlockEvents_FilteredBlockEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BlockAndPrivateDataEvents",
+			Handler:       _BlockEvents_BlockAndPrivateDataEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "block_events.proto",
+}
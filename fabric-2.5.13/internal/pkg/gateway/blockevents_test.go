@@ -0,0 +1,226 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cp "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	peerledger "github.com/hyperledger/fabric/core/ledger"
+	peermocks "github.com/hyperledger/fabric/core/peer/mock"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/blockevents"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate counterfeiter -o mocks/blockeventsserver.go --fake-name BlockEventsServer github.com/hyperledger/fabric/internal/pkg/gateway/blockevents.BlockEvents_BlockEventsServer
+//go:generate counterfeiter -o mocks/filteredblockeventsserver.go --fake-name FilteredBlockEventsServer github.com/hyperledger/fabric/internal/pkg/gateway/blockevents.BlockEvents_FilteredBlockEventsServer
+//go:generate counterfeiter -o mocks/blockandprivatedataeventsserver.go --fake-name BlockAndPrivateDataEventsServer github.com/hyperledger/fabric/internal/pkg/gateway/blockevents.BlockEvents_BlockAndPrivateDataEventsServer
+
+func newEventsTestBlock(number uint64, transactionIDs ...string) *cp.Block {
+	block := &cp.Block{
+		Header:   &cp.BlockHeader{Number: number},
+		Data:     &cp.BlockData{},
+		Metadata: &cp.BlockMetadata{Metadata: make([][]byte, 5)},
+	}
+	for _, transactionID := range transactionIDs {
+		event := &peer.ChaincodeEvent{ChaincodeId: testChaincode, TxId: transactionID, EventName: "EVENT_NAME", Payload: []byte("PAYLOAD")}
+		envelope := &cp.Envelope{
+			Payload: protoutil.MarshalOrPanic(&cp.Payload{
+				Header: &cp.Header{
+					ChannelHeader: protoutil.MarshalOrPanic(&cp.ChannelHeader{
+						Type:      int32(cp.HeaderType_ENDORSER_TRANSACTION),
+						ChannelId: testChannel,
+						TxId:      transactionID,
+					}),
+				},
+				Data: protoutil.MarshalOrPanic(&peer.Transaction{
+					Actions: []*peer.TransactionAction{{
+						Payload: protoutil.MarshalOrPanic(&peer.ChaincodeActionPayload{
+							Action: &peer.ChaincodeEndorsedAction{
+								ProposalResponsePayload: protoutil.MarshalOrPanic(&peer.ProposalResponsePayload{
+									Extension: protoutil.MarshalOrPanic(&peer.ChaincodeAction{
+										Events: protoutil.MarshalOrPanic(event),
+									}),
+								}),
+							},
+						}),
+					}},
+				}),
+			}),
+		}
+		block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(envelope))
+		block.Metadata.Metadata[cp.BlockMetadataIndex_TRANSACTIONS_FILTER] = append(block.Metadata.Metadata[cp.BlockMetadataIndex_TRANSACTIONS_FILTER], byte(peer.TxValidationCode_VALID))
+	}
+	return block
+}
+
+func newBlockEventsRequest(afterTxID string) *blockevents.SignedBlockEventsRequest {
+	request := &blockevents.BlockEventsRequest{
+		ChannelId:          testChannel,
+		Identity:           []byte("IDENTITY"),
+		AfterTransactionId: afterTxID,
+	}
+	return &blockevents.SignedBlockEventsRequest{
+		Request:   protoutil.MarshalOrPanic(request),
+		Signature: []byte("SIGNATURE"),
+	}
+}
+
+func TestBlockEvents(t *testing.T) {
+	block1 := newEventsTestBlock(1, "TX1", "TX2")
+	block2 := newEventsTestBlock(2, "TX3", "TX4", "TX5")
+	block3 := newEventsTestBlock(3, "TX6")
+
+	for _, tt := range []struct {
+		name           string
+		afterTxID      string
+		expectedBlocks []*cp.Block
+	}{
+		{
+			name:           "returns blocks",
+			expectedBlocks: []*cp.Block{block1, block2, block3},
+		},
+		{
+			name:           "skips blocks before the block containing the specified transaction",
+			afterTxID:      "TX4",
+			expectedBlocks: []*cp.Block{block2, block3},
+		},
+		{
+			name:           "skips block if specified transaction is the last in the block",
+			afterTxID:      "TX2",
+			expectedBlocks: []*cp.Block{block2, block3},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := prepareTest(t, &testDef{blocks: []*cp.Block{block1, block2, block3}})
+			stream := &mocks.BlockEventsServer{}
+
+			err := test.server.BlockEvents(newBlockEventsRequest(tt.afterTxID), stream)
+			require.EqualError(t, err, "rpc error: code = Aborted desc = NO_MORE_BLOCKS")
+
+			require.Equal(t, len(tt.expectedBlocks), stream.SendCallCount())
+			for i, expected := range tt.expectedBlocks {
+				require.True(t, proto.Equal(expected, stream.SendArgsForCall(i).GetBlock()), "block %d", i)
+			}
+
+			resource, channel, _ := test.policy.CheckACLArgsForCall(0)
+			require.Equal(t, resources.Gateway_BlockEvents, resource)
+			require.Equal(t, testChannel, channel)
+		})
+	}
+}
+
+func TestFilteredBlockEvents(t *testing.T) {
+	test := prepareTest(t, &testDef{
+		blocks: []*cp.Block{
+			newEventsTestBlock(1, "TX1"),
+			newEventsTestBlock(2, "TX2", "TX3", "TX4"),
+		},
+	})
+	stream := &mocks.FilteredBlockEventsServer{}
+
+	err := test.server.FilteredBlockEvents(newBlockEventsRequest("TX2"), stream)
+	require.EqualError(t, err, "rpc error: code = Aborted desc = NO_MORE_BLOCKS")
+
+	require.Equal(t, 1, stream.SendCallCount())
+	filteredBlock := stream.SendArgsForCall(0).GetFilteredBlock()
+	require.EqualValues(t, 2, filteredBlock.GetNumber())
+	require.Equal(t, testChannel, filteredBlock.GetChannelId())
+	require.Len(t, filteredBlock.GetFilteredTransactions(), 2, "transactions up to the specified transaction omitted")
+	require.Equal(t, "TX3", filteredBlock.GetFilteredTransactions()[0].GetTxid())
+	require.Equal(t, peer.TxValidationCode_VALID, filteredBlock.GetFilteredTransactions()[0].GetTxValidationCode())
+	require.Equal(t, "EVENT_NAME", filteredBlock.GetFilteredTransactions()[0].GetTransactionActions().GetChaincodeActions()[0].GetChaincodeEvent().GetEventName())
+	require.Empty(t, filteredBlock.GetFilteredTransactions()[0].GetTransactionActions().GetChaincodeActions()[0].GetChaincodeEvent().GetPayload())
+
+	resource, _, _ := test.policy.CheckACLArgsForCall(0)
+	require.Equal(t, resources.Gateway_FilteredBlockEvents, resource)
+}
+
+func TestBlockAndPrivateDataEvents(t *testing.T) {
+	block := newEventsTestBlock(1, "TX1", "TX2")
+	test := prepareTest(t, &testDef{blocks: []*cp.Block{block}})
+
+	test.ledger.GetPvtDataByNumReturns([]*peerledger.TxPvtData{
+		{
+			SeqInBlock: 1,
+			WriteSet: &rwset.TxPvtReadWriteSet{
+				DataModel: rwset.TxReadWriteSet_KV,
+				NsPvtRwset: []*rwset.NsPvtReadWriteSet{{
+					Namespace: testChaincode,
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+						{CollectionName: "ELIGIBLE", Rwset: []byte("RWSET")},
+						{CollectionName: "INELIGIBLE", Rwset: []byte("RWSET")},
+					},
+				}},
+			},
+		},
+	}, nil)
+	collectionPolicyChecker := &peermocks.CollectionPolicyChecker{}
+	collectionPolicyChecker.CheckCollectionPolicyCalls(func(_ uint64, _ string, collection string, _ peerledger.ConfigHistoryRetriever, _ msp.IdentityDeserializer, _ *protoutil.SignedData) (bool, error) {
+		return collection == "ELIGIBLE", nil
+	})
+	test.server.collectionPolicyChecker = collectionPolicyChecker
+	test.server.identityDeserializers = &peermocks.IdentityDeserializerManager{}
+
+	stream := &mocks.BlockAndPrivateDataEventsServer{}
+	err := test.server.BlockAndPrivateDataEvents(newBlockEventsRequest(""), stream)
+	require.EqualError(t, err, "rpc error: code = Aborted desc = NO_MORE_BLOCKS")
+
+	require.Equal(t, 1, stream.SendCallCount())
+	response := stream.SendArgsForCall(0).GetBlockAndPrivateData()
+	require.True(t, proto.Equal(block, response.GetBlock()))
+	expected := &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{{
+			Namespace:          testChaincode,
+			CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{CollectionName: "ELIGIBLE", Rwset: []byte("RWSET")}},
+		}},
+	}
+	require.Len(t, response.GetPrivateDataMap(), 1)
+	require.True(t, proto.Equal(expected, response.GetPrivateDataMap()[1]), "only eligible collections returned")
+
+	_, _, _, _, _, signedData := collectionPolicyChecker.CheckCollectionPolicyArgsForCall(0)
+	require.Equal(t, []byte("IDENTITY"), signedData.Identity)
+
+	resource, _, _ := test.policy.CheckACLArgsForCall(0)
+	require.Equal(t, resources.Gateway_BlockAndPrivateDataEvents, resource)
+
+	test.ledger.GetPvtDataByNumReturns(nil, errors.New("PVTDATA_ERROR"))
+	test.blockIterator.NextReturns(block, nil)
+	err = test.server.BlockAndPrivateDataEvents(newBlockEventsRequest(""), stream)
+	require.EqualError(t, err, "rpc error: code = Aborted desc = failed to read private data of block 1: error getting private data by block number 1: PVTDATA_ERROR")
+}
+
+func TestBlockEventsErrors(t *testing.T) {
+	test := prepareTest(t, &testDef{blocks: []*cp.Block{newEventsTestBlock(1, "TX1")}})
+	stream := &mocks.BlockEventsServer{}
+
+	err := test.server.BlockEvents(&blockevents.SignedBlockEventsRequest{}, stream)
+	require.EqualError(t, err, "rpc error: code = InvalidArgument desc = a block events request is required")
+
+	err = test.server.BlockEvents(&blockevents.SignedBlockEventsRequest{Request: []byte("GARBAGE")}, stream)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream.SendReturns(io.EOF)
+	err = test.server.BlockEvents(newBlockEventsRequest(""), stream)
+	require.Equal(t, codes.Canceled, status.Code(err), "stream closed by the client")
+
+	test.policy.CheckACLReturns(errors.New("POLICY_ERROR"))
+	err = test.server.BlockEvents(newBlockEventsRequest(""), stream)
+	require.EqualError(t, err, "rpc error: code = PermissionDenied desc = POLICY_ERROR")
+}
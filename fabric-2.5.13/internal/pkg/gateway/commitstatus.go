@@ -11,18 +11,28 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// InvalidationInfoTrailer is the key of the response trailer of CommitStatus that carries the marshalled
+// TxInvalidationInfo of an invalid transaction
+const InvalidationInfoTrailer = "fabric-invalidation-info-bin"
+
 // CommitStatus returns the validation code for a specific transaction on a specific channel. If the transaction is
 // already committed, the status will be returned immediately; otherwise this call will block and return only when
 // the transaction commits or the context is cancelled.
 //
 // If the transaction commit status cannot be returned, for example if the specified channel does not exist, a
 // FailedPrecondition error will be returned.
+//
+// For an invalid transaction, the details of the reason for which it was marked invalid by this peer, if available,
+// are returned as a marshalled TxInvalidationInfo message in the InvalidationInfoTrailer of the response.
 func (gs *Server) CommitStatus(ctx context.Context, signedRequest *gp.SignedCommitStatusRequest) (*gp.CommitStatusResponse, error) {
 	if len(signedRequest.GetRequest()) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "a commit status request is required")
@@ -47,9 +57,39 @@ func (gs *Server) CommitStatus(ctx context.Context, signedRequest *gp.SignedComm
 		return nil, toRpcError(err, codes.Aborted)
 	}
 
+	if txStatus.Code != peer.TxValidationCode_VALID {
+		gs.setInvalidationInfoTrailer(ctx, request.GetChannelId(), request.GetTransactionId())
+	}
+
 	response := &gp.CommitStatusResponse{
 		Result:      txStatus.Code,
 		BlockNumber: txStatus.BlockNumber,
 	}
 	return response, nil
 }
+
+// setInvalidationInfoTrailer attaches the invalidation info of a transaction to the response trailer. Failure to
+// retrieve the invalidation info is not reported to the client as the commit status itself is available.
+func (gs *Server) setInvalidationInfoTrailer(ctx context.Context, channelID string, txID string) {
+	ledger, err := gs.ledgerProvider.Ledger(channelID)
+	if err != nil {
+		gs.logger.Warnw("Failed to get ledger for invalidation info", "channel", channelID, "txID", txID, "err", err)
+		return
+	}
+	info, err := ledger.GetTxInvalidationInfo(txID)
+	if err != nil {
+		gs.logger.Warnw("Failed to get invalidation info", "channel", channelID, "txID", txID, "err", err)
+		return
+	}
+	if info == nil {
+		return
+	}
+	infoBytes, err := proto.Marshal(info)
+	if err != nil {
+		gs.logger.Warnw("Failed to marshal invalidation info", "channel", channelID, "txID", txID, "err", err)
+		return
+	}
+	if err := grpc.SetTrailer(ctx, metadata.Pairs(InvalidationInfoTrailer, string(infoBytes))); err != nil {
+		gs.logger.Warnw("Failed to set invalidation info trailer", "channel", channelID, "txID", txID, "err", err)
+	}
+}
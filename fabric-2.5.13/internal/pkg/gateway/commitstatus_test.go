@@ -13,11 +13,14 @@ import (
 	"github.com/golang/protobuf/proto"
 	pb "github.com/hyperledger/fabric-protos-go/gateway"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestCommitStatus(t *testing.T) {
@@ -144,3 +147,62 @@ func TestCommitStatus(t *testing.T) {
 		})
 	}
 }
+
+type trailerCapturingStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *trailerCapturingStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestCommitStatusInvalidationInfo(t *testing.T) {
+	tt := testDef{
+		finderStatus: &commit.Status{
+			Code:        peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+			BlockNumber: 101,
+		},
+	}
+	test := prepareTest(t, &tt)
+
+	info := &txinvalidation.TxInvalidationInfo{
+		TxId:           "TX_ID",
+		BlockNumber:    101,
+		ValidationCode: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+		Message:        "signature set did not satisfy policy",
+	}
+	test.ledger.GetTxInvalidationInfoReturns(info, nil)
+
+	requestBytes, err := proto.Marshal(&pb.CommitStatusRequest{ChannelId: testChannel, TransactionId: "TX_ID"})
+	require.NoError(t, err)
+	signedRequest := &pb.SignedCommitStatusRequest{Request: requestBytes}
+
+	t.Run("returns invalidation info in trailer", func(t *testing.T) {
+		stream := &trailerCapturingStream{}
+		ctx := grpc.NewContextWithServerTransportStream(test.ctx, stream)
+
+		response, err := test.server.CommitStatus(ctx, signedRequest)
+		require.NoError(t, err)
+		require.Equal(t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, response.Result)
+
+		values := stream.trailer.Get(InvalidationInfoTrailer)
+		require.Len(t, values, 1)
+		actual := &txinvalidation.TxInvalidationInfo{}
+		require.NoError(t, proto.Unmarshal([]byte(values[0]), actual))
+		require.True(t, proto.Equal(info, actual), "incorrect invalidation info", actual)
+		require.Equal(t, "TX_ID", test.ledger.GetTxInvalidationInfoArgsForCall(0))
+	})
+
+	t.Run("still returns status if invalidation info cannot be retrieved", func(t *testing.T) {
+		test.ledger.GetTxInvalidationInfoReturns(nil, errors.New("LEDGER_ERROR"))
+		stream := &trailerCapturingStream{}
+		ctx := grpc.NewContextWithServerTransportStream(test.ctx, stream)
+
+		response, err := test.server.CommitStatus(ctx, signedRequest)
+		require.NoError(t, err)
+		require.Equal(t, uint64(101), response.BlockNumber)
+		require.Empty(t, stream.trailer.Get(InvalidationInfoTrailer))
+	})
+}
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package config
 
 import (
+	"path/filepath"
 	"time"
 
+	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
 )
 
@@ -22,6 +24,92 @@ type Options struct {
 	BroadcastTimeout time.Duration
 	// DialTimeout is used to specify the maximum time to wait for connecting to external peers and orderer nodes.
 	DialTimeout time.Duration
+	// EndorserSelection is used to configure how the gateway selects endorsing peers.
+	EndorserSelection EndorserSelectionOptions
+	// RateLimits is used to limit the requests of each organization and client identity.
+	RateLimits RateLimitOptions
+	// PreparedTransactions is used to configure the storage of prepared transactions for offline signing.
+	PreparedTransactions PreparedTransactionsOptions
+	// HTTP is used to configure the HTTP/JSON endpoint of the gateway.
+	HTTP HTTPOptions
+}
+
+const (
+	// HeightSelection orders the endorsing peers of an organization by decreasing ledger height.
+	HeightSelection = "height"
+	// LeastLatencySelection orders the endorsing peers of an organization by increasing latency score.
+	LeastLatencySelection = "leastLatency"
+	// WeightedRoundRobinSelection spreads the requests across the endorsing peers of an organization, in
+	// proportion to the inverse of their latency scores.
+	WeightedRoundRobinSelection = "weightedRoundRobin"
+)
+
+// EndorserSelectionOptions is used to configure how the gateway selects endorsing peers.
+type EndorserSelectionOptions struct {
+	// Strategy is the strategy used to order the endorsing peers of an organization, one of HeightSelection,
+	// LeastLatencySelection or WeightedRoundRobinSelection.
+	Strategy string
+	// HeightTolerance is the number of blocks an endorsing peer may lag behind the highest peer of its
+	// organization, and still be selected by latency.
+	HeightTolerance uint64
+	// FailureThreshold is the number of consecutive failed requests after which an endorsing peer is
+	// circuit-broken. Zero disables the circuit breaker.
+	FailureThreshold int
+	// OpenDuration is the time a circuit-broken endorsing peer is only used if no other peer is available.
+	OpenDuration time.Duration
+}
+
+// RateLimitOptions is used to limit the Evaluate, Endorse and Submit requests of each organization and client
+// identity.
+type RateLimitOptions struct {
+	// PerMSP limits the requests of all the client identities of an MSP ID.
+	PerMSP RequestLimitOptions
+	// PerClient limits the requests of each client identity.
+	PerClient RequestLimitOptions
+}
+
+// RequestLimitOptions is used to configure a token bucket rate limit and a limit on concurrent requests.
+type RequestLimitOptions struct {
+	// RequestsPerSecond is the rate at which requests are allowed. Zero disables the rate limit.
+	RequestsPerSecond float64
+	// Burst is the number of requests allowed in excess of the rate. Defaults to a second worth of requests.
+	Burst int
+	// Concurrency is the maximum number of requests in progress. Zero disables the concurrency limit.
+	Concurrency int
+}
+
+// PreparedTransactionsOptions is used to configure the storage of prepared transactions, which are held while they
+// are reviewed and signed offline.
+type PreparedTransactionsOptions struct {
+	// Enabled is used to enable the prepared transactions service.
+	Enabled bool
+	// MaxTimeToLive is the maximum time a prepared transaction is stored before it expires.
+	MaxTimeToLive time.Duration
+	// MaxTransactions is the maximum number of prepared transactions stored. A zero value is not enforced.
+	MaxTransactions int
+	// MaxTransactionsPerIdentity is the maximum number of prepared transactions stored by the same client
+	// identity. A zero value is not enforced.
+	MaxTransactionsPerIdentity int
+}
+
+// HTTPOptions is used to configure the HTTP/JSON endpoint of the gateway.
+type HTTPOptions struct {
+	// Enabled is used to enable the HTTP/JSON endpoint.
+	Enabled bool
+	// ListenAddress is the host and port the HTTP/JSON endpoint listens on.
+	ListenAddress string
+	// MaxRequestBodySize is the maximum size in bytes of a request body.
+	MaxRequestBodySize uint32
+	// TLSEnabled is used to enable TLS for the HTTP/JSON endpoint.
+	TLSEnabled bool
+	// TLSCertFile is the path to the PEM encoded server certificate.
+	TLSCertFile string
+	// TLSKeyFile is the path to the PEM encoded server key.
+	TLSKeyFile string
+	// TLSClientAuthRequired is used to require client certificate authentication at the TLS layer.
+	TLSClientAuthRequired bool
+	// TLSClientRootCAs are the paths to the PEM encoded CA certificates trusted for client authentication.
+	TLSClientRootCAs []string
 }
 
 var defaultOptions = Options{
@@ -29,6 +117,23 @@ var defaultOptions = Options{
 	EndorsementTimeout: 10 * time.Second,
 	BroadcastTimeout:   10 * time.Second,
 	DialTimeout:        30 * time.Second,
+	EndorserSelection: EndorserSelectionOptions{
+		Strategy:         HeightSelection,
+		HeightTolerance:  0,
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+	},
+	PreparedTransactions: PreparedTransactionsOptions{
+		Enabled:                    false,
+		MaxTimeToLive:              24 * time.Hour,
+		MaxTransactions:            10000,
+		MaxTransactionsPerIdentity: 100,
+	},
+	HTTP: HTTPOptions{
+		Enabled:            false,
+		ListenAddress:      "0.0.0.0:7080",
+		MaxRequestBodySize: 100 * 1024 * 1024,
+	},
 }
 
 // DefaultOptions gets the default Gateway configuration Options
@@ -46,6 +151,70 @@ func GetOptions(v *viper.Viper) Options {
 	if v.IsSet("peer.gateway.dialTimeout") {
 		options.DialTimeout = v.GetDuration("peer.gateway.dialTimeout")
 	}
+	if v.IsSet("peer.gateway.endorserSelection.strategy") {
+		options.EndorserSelection.Strategy = v.GetString("peer.gateway.endorserSelection.strategy")
+	}
+	if v.IsSet("peer.gateway.endorserSelection.heightTolerance") {
+		options.EndorserSelection.HeightTolerance = v.GetUint64("peer.gateway.endorserSelection.heightTolerance")
+	}
+	if v.IsSet("peer.gateway.endorserSelection.circuitBreaker.failureThreshold") {
+		options.EndorserSelection.FailureThreshold = v.GetInt("peer.gateway.endorserSelection.circuitBreaker.failureThreshold")
+	}
+	if v.IsSet("peer.gateway.endorserSelection.circuitBreaker.openDuration") {
+		options.EndorserSelection.OpenDuration = v.GetDuration("peer.gateway.endorserSelection.circuitBreaker.openDuration")
+	}
+	options.RateLimits.PerMSP = getRequestLimitOptions(v, "peer.gateway.rateLimits.perMSP")
+	options.RateLimits.PerClient = getRequestLimitOptions(v, "peer.gateway.rateLimits.perClient")
+	if v.IsSet("peer.gateway.preparedTransactions.enabled") {
+		options.PreparedTransactions.Enabled = v.GetBool("peer.gateway.preparedTransactions.enabled")
+	}
+	if v.IsSet("peer.gateway.preparedTransactions.maxTimeToLive") {
+		options.PreparedTransactions.MaxTimeToLive = v.GetDuration("peer.gateway.preparedTransactions.maxTimeToLive")
+	}
+	if v.IsSet("peer.gateway.preparedTransactions.maxTransactions") {
+		options.PreparedTransactions.MaxTransactions = v.GetInt("peer.gateway.preparedTransactions.maxTransactions")
+	}
+	if v.IsSet("peer.gateway.preparedTransactions.maxTransactionsPerIdentity") {
+		options.PreparedTransactions.MaxTransactionsPerIdentity = v.GetInt("peer.gateway.preparedTransactions.maxTransactionsPerIdentity")
+	}
+	options.HTTP = getHTTPOptions(v)
+
+	return options
+}
+
+func getRequestLimitOptions(v *viper.Viper, key string) RequestLimitOptions {
+	return RequestLimitOptions{
+		RequestsPerSecond: v.GetFloat64(key + ".requestsPerSecond"),
+		Burst:             v.GetInt(key + ".burst"),
+		Concurrency:       v.GetInt(key + ".concurrency"),
+	}
+}
+
+func getHTTPOptions(v *viper.Viper) HTTPOptions {
+	options := defaultOptions.HTTP
+	if v.IsSet("peer.gateway.http.enabled") {
+		options.Enabled = v.GetBool("peer.gateway.http.enabled")
+	}
+	if v.IsSet("peer.gateway.http.listenAddress") {
+		options.ListenAddress = v.GetString("peer.gateway.http.listenAddress")
+	}
+	if v.IsSet("peer.gateway.http.maxRequestBodySize") {
+		options.MaxRequestBodySize = v.GetUint32("peer.gateway.http.maxRequestBodySize")
+	}
+
+	// The paths of the TLS files may be relative to the configuration file
+	configDir := filepath.Dir(v.ConfigFileUsed())
+	options.TLSEnabled = v.GetBool("peer.gateway.http.tls.enabled")
+	if certFile := v.GetString("peer.gateway.http.tls.cert.file"); certFile != "" {
+		options.TLSCertFile = coreconfig.TranslatePath(configDir, certFile)
+	}
+	if keyFile := v.GetString("peer.gateway.http.tls.key.file"); keyFile != "" {
+		options.TLSKeyFile = coreconfig.TranslatePath(configDir, keyFile)
+	}
+	options.TLSClientAuthRequired = v.GetBool("peer.gateway.http.tls.clientAuthRequired")
+	for _, rca := range v.GetStringSlice("peer.gateway.http.tls.clientRootCAs.files") {
+		options.TLSClientRootCAs = append(options.TLSClientRootCAs, coreconfig.TranslatePath(configDir, rca))
+	}
 
 	return options
 }
@@ -22,6 +22,23 @@ peer:
     endorsementTimeout: 30s
     broadcastTimeout: 20s
     dialTimeout: 2m
+    endorserSelection:
+      strategy: leastLatency
+      heightTolerance: 2
+      circuitBreaker:
+        failureThreshold: 3
+        openDuration: 1m
+    rateLimits:
+      perMSP:
+        requestsPerSecond: 100
+        burst: 200
+      perClient:
+        requestsPerSecond: 0.5
+        concurrency: 2
+    preparedTransactions:
+      enabled: true
+      maxTimeToLive: 1h
+      maxTransactionsPerIdentity: 10
 `)
 
 var testConfigOff = []byte(`
@@ -30,6 +47,25 @@ peer:
     enabled: false
 `)
 
+var testConfigHTTP = []byte(`
+peer:
+  gateway:
+    http:
+      enabled: true
+      listenAddress: 127.0.0.1:8080
+      maxRequestBodySize: 1024
+      tls:
+        enabled: true
+        cert:
+          file: tls/server.crt
+        key:
+          file: /etc/tls/server.key
+        clientAuthRequired: true
+        clientRootCAs:
+          files:
+            - tls/ca.crt
+`)
+
 func TestDefaultOptions(t *testing.T) {
 	v := viper.New()
 	options := GetOptions(v)
@@ -47,6 +83,18 @@ func TestOverriddenOptions(t *testing.T) {
 		EndorsementTimeout: 30 * time.Second,
 		BroadcastTimeout:   20 * time.Second,
 		DialTimeout:        2 * time.Minute,
+		EndorserSelection: EndorserSelectionOptions{
+			Strategy:         LeastLatencySelection,
+			HeightTolerance:  2,
+			FailureThreshold: 3,
+			OpenDuration:     time.Minute,
+		},
+		RateLimits: RateLimitOptions{
+			PerMSP:    RequestLimitOptions{RequestsPerSecond: 100, Burst: 200},
+			PerClient: RequestLimitOptions{RequestsPerSecond: 0.5, Concurrency: 2},
+		},
+		PreparedTransactions: PreparedTransactionsOptions{Enabled: true, MaxTimeToLive: time.Hour, MaxTransactions: 10000, MaxTransactionsPerIdentity: 10},
+		HTTP:                 defaultOptions.HTTP,
 	}
 	require.Equal(t, expectedOptions, options)
 }
@@ -58,10 +106,33 @@ func TestDisabledGatewayOption(t *testing.T) {
 	options := GetOptions(v)
 
 	expectedOptions := Options{
-		Enabled:            false,
-		EndorsementTimeout: 10 * time.Second,
-		BroadcastTimeout:   10 * time.Second,
-		DialTimeout:        30 * time.Second,
+		Enabled:              false,
+		EndorsementTimeout:   10 * time.Second,
+		BroadcastTimeout:     10 * time.Second,
+		DialTimeout:          30 * time.Second,
+		EndorserSelection:    defaultOptions.EndorserSelection,
+		PreparedTransactions: defaultOptions.PreparedTransactions,
+		HTTP:                 defaultOptions.HTTP,
 	}
 	require.Equal(t, expectedOptions, options)
 }
+
+func TestHTTPOptions(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetConfigFile("/etc/hyperledger/fabric/core.yaml")
+	v.ReadConfig(bytes.NewBuffer(testConfigHTTP))
+	options := GetOptions(v)
+
+	expectedOptions := HTTPOptions{
+		Enabled:               true,
+		ListenAddress:         "127.0.0.1:8080",
+		MaxRequestBodySize:    1024,
+		TLSEnabled:            true,
+		TLSCertFile:           "/etc/hyperledger/fabric/tls/server.crt",
+		TLSKeyFile:            "/etc/tls/server.key",
+		TLSClientAuthRequired: true,
+		TLSClientRootCAs:      []string{"/etc/hyperledger/fabric/tls/ca.crt"},
+	}
+	require.Equal(t, expectedOptions, options.HTTP)
+}
@@ -9,6 +9,7 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	gp "github.com/hyperledger/fabric-protos-go/gateway"
@@ -50,6 +51,12 @@ func (gs *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	release, err := gs.limits.acquire(ctx, "Endorse", channelHeader.GetChannelId(), proposalSignedData(signedProposal))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	channel := channelHeader.GetChannelId()
 	chaincodeID := spec.GetChaincodeSpec().GetChaincodeId().GetName()
 	hasTransientData := len(payload.GetTransientMap()) > 0
@@ -121,6 +128,7 @@ func (gs *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.
 type ppResponse struct {
 	response *peer.ProposalResponse
 	err      error
+	elapsed  time.Duration
 }
 
 // processProposal will invoke the given endorsing peer to process the signed proposal, and will update the plan accordingly.
@@ -134,13 +142,19 @@ func (gs *Server) processProposal(ctx context.Context, plan *plan, endorser *end
 		logger.Debugw("Sending to endorser:", "MSPID", endorser.mspid, "endpoint", endorser.address)
 		ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout) // timeout of individual endorsement
 		defer cancel()
+		ctx, span := startEndpointSpan(ctx, "gateway.endorse", endorser.endpointConfig)
+		start := time.Now()
 		response, err := endorser.client.ProcessProposal(ctx, signedProposal)
-		done <- &ppResponse{response: response, err: err}
+		elapsed := time.Since(start)
+		code, message, _, _ := responseStatus(response, err)
+		endEndpointSpan(span, code, message)
+		done <- &ppResponse{response: response, err: err, elapsed: elapsed}
 	}()
 	select {
 	case resp := <-done:
 		// Endorser completedLayout normally
 		code, message, _, remove := responseStatus(resp.response, resp.err)
+		gs.registry.scores.record(endorser, resp.elapsed, endorserFailed(resp.response, resp.err, remove))
 		if code != codes.OK {
 			logger.Warnw("Endorse call to endorser failed", "MSPID", endorser.mspid, "endpoint", endorser.address, "error", message)
 			if remove {
@@ -202,8 +216,12 @@ func (gs *Server) planFromFirstEndorser(ctx context.Context, channel string, cha
 
 			ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout)
 			defer cancel()
+			ctx, span := startEndpointSpan(ctx, "gateway.endorse", firstEndorser.endpointConfig)
+			start := time.Now()
 			firstResponse, err = firstEndorser.client.ProcessProposal(ctx, signedProposal)
 			code, message, _, remove := responseStatus(firstResponse, err)
+			gs.registry.scores.record(firstEndorser, time.Since(start), endorserFailed(firstResponse, err, remove))
+			endEndpointSpan(span, code, message)
 
 			if code != codes.OK {
 				logger.Warnw("Endorse call to endorser failed", "endorserAddress", firstEndorser.address, "endorserMspid", firstEndorser.mspid, "error", message)
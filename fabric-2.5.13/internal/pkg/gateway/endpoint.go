@@ -13,6 +13,7 @@ import (
 
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/grpctracing"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
@@ -122,6 +123,10 @@ func (ef *endpointFactory) newConnection(address string, tlsRootCerts [][]byte)
 	if err != nil {
 		return nil, err
 	}
+	dialOpts = append(dialOpts,
+		grpc.WithChainUnaryInterceptor(grpctracing.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(grpctracing.StreamClientInterceptor()),
+	)
 
 	ctx, cancel := context.WithTimeout(context.Background(), ef.timeout)
 	defer cancel()
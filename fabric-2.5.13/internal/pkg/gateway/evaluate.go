@@ -9,6 +9,7 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	gp "github.com/hyperledger/fabric-protos-go/gateway"
@@ -30,6 +31,12 @@ func (gs *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*g
 		return nil, status.Errorf(codes.InvalidArgument, "failed to unpack transaction proposal: %s", err)
 	}
 
+	release, err := gs.limits.acquire(ctx, "Evaluate", channel, proposalSignedData(signedProposal))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	err = gs.registry.connectChannelPeers(channel, false)
 	if err != nil {
 		return nil, status.Errorf(codes.Unavailable, "%s", err)
@@ -61,8 +68,12 @@ func (gs *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*g
 			defer close(done)
 			ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout)
 			defer cancel()
+			ctx, span := startEndpointSpan(ctx, "gateway.evaluate", endorser.endpointConfig)
+			start := time.Now()
 			pr, err := endorser.client.ProcessProposal(ctx, signedProposal)
 			code, message, retry, remove := responseStatus(pr, err)
+			gs.registry.scores.record(endorser, time.Since(start), endorserFailed(pr, err, remove))
+			endEndpointSpan(span, code, message)
 			if code == codes.OK {
 				response = pr.Response
 				// Prefer result from proposal response as Response.Payload is not required to be transaction result
@@ -26,6 +26,10 @@ func (b *Block) Number() uint64 {
 	return b.block.GetHeader().GetNumber()
 }
 
+func (b *Block) Block() *common.Block {
+	return b.block
+}
+
 func (b *Block) Transactions() ([]*Transaction, error) {
 	var err error
 
@@ -10,6 +10,7 @@ import (
 
 	peerproto "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc"
 	gdiscovery "github.com/hyperledger/fabric/gossip/discovery"
@@ -31,6 +32,10 @@ type Server struct {
 	options        config.Options
 	logger         *flogging.FabricLogger
 	ledgerProvider ledger.Provider
+	limits         *requestLimits
+
+	collectionPolicyChecker peer.CollectionPolicyChecker
+	identityDeserializers   peer.IdentityDeserializerManager
 }
 
 type EndorserServerAdapter struct {
@@ -59,6 +64,7 @@ func CreateServer(
 	localMSPID string,
 	options config.Options,
 	systemChaincodes scc.BuiltinSCCs,
+	metricsProvider metrics.Provider,
 ) *Server {
 	adapter := &ledger.PeerAdapter{
 		Peer: peerInstance,
@@ -79,6 +85,7 @@ func CreateServer(
 		options,
 		systemChaincodes,
 		peerInstance.OrdererEndpointOverrides,
+		NewMetrics(metricsProvider),
 	)
 
 	peerInstance.AddConfigCallbacks(server.registry.configUpdate)
@@ -97,7 +104,9 @@ func newServer(localEndorser peerproto.EndorserClient,
 	options config.Options,
 	systemChaincodes scc.BuiltinSCCs,
 	ordererEndpointOverrides map[string]*orderers.Endpoint,
+	metrics *Metrics,
 ) *Server {
+	identityDeserializers := peer.NewIdentityDeserializerManager()
 	return &Server{
 		registry: &registry{
 			localEndorser: &endorser{
@@ -116,11 +125,16 @@ func newServer(localEndorser peerproto.EndorserClient,
 			channelInitialized: map[string]bool{},
 			systemChaincodes:   systemChaincodes,
 			localProvider:      ledgerProvider,
+			scores:             newEndorserScores(options, metrics),
 		},
 		commitFinder:   finder,
 		policy:         policy,
 		options:        options,
 		logger:         logger,
 		ledgerProvider: ledgerProvider,
+		limits:         newRequestLimits(options.RateLimits, identityDeserializers, metrics),
+
+		collectionPolicyChecker: peer.NewCollectionPolicyChecker(),
+		identityDeserializers:   identityDeserializers,
 	}
 }
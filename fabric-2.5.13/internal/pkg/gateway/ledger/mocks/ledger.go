@@ -8,6 +8,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 	ledgerb "github.com/hyperledger/fabric/common/ledger"
 	ledgera "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/ledger"
 )
 
@@ -63,6 +64,45 @@ type Ledger struct {
 		result1 ledgerb.ResultsIterator
 		result2 error
 	}
+	GetConfigHistoryRetrieverStub        func() (ledgera.ConfigHistoryRetriever, error)
+	getConfigHistoryRetrieverMutex       sync.RWMutex
+	getConfigHistoryRetrieverArgsForCall []struct {
+	}
+	getConfigHistoryRetrieverReturns struct {
+		result1 ledgera.ConfigHistoryRetriever
+		result2 error
+	}
+	getConfigHistoryRetrieverReturnsOnCall map[int]struct {
+		result1 ledgera.ConfigHistoryRetriever
+		result2 error
+	}
+	GetPvtDataByNumStub        func(uint64, ledgera.PvtNsCollFilter) ([]*ledgera.TxPvtData, error)
+	getPvtDataByNumMutex       sync.RWMutex
+	getPvtDataByNumArgsForCall []struct {
+		arg1 uint64
+		arg2 ledgera.PvtNsCollFilter
+	}
+	getPvtDataByNumReturns struct {
+		result1 []*ledgera.TxPvtData
+		result2 error
+	}
+	getPvtDataByNumReturnsOnCall map[int]struct {
+		result1 []*ledgera.TxPvtData
+		result2 error
+	}
+	GetTxInvalidationInfoStub        func(string) (*txinvalidation.TxInvalidationInfo, error)
+	getTxInvalidationInfoMutex       sync.RWMutex
+	getTxInvalidationInfoArgsForCall []struct {
+		arg1 string
+	}
+	getTxInvalidationInfoReturns struct {
+		result1 *txinvalidation.TxInvalidationInfo
+		result2 error
+	}
+	getTxInvalidationInfoReturnsOnCall map[int]struct {
+		result1 *txinvalidation.TxInvalidationInfo
+		result2 error
+	}
 	GetTxValidationCodeByTxIDStub        func(string) (peer.TxValidationCode, uint64, error)
 	getTxValidationCodeByTxIDMutex       sync.RWMutex
 	getTxValidationCodeByTxIDArgsForCall []struct {
@@ -330,6 +370,191 @@ func (fake *Ledger) GetBlocksIteratorReturnsOnCall(i int, result1 ledgerb.Result
 	}{result1, result2}
 }
 
+func (fake *Ledger) GetConfigHistoryRetriever() (ledgera.ConfigHistoryRetriever, error) {
+	fake.getConfigHistoryRetrieverMutex.Lock()
+	ret, specificReturn := fake.getConfigHistoryRetrieverReturnsOnCall[len(fake.getConfigHistoryRetrieverArgsForCall)]
+	fake.getConfigHistoryRetrieverArgsForCall = append(fake.getConfigHistoryRetrieverArgsForCall, struct {
+	}{})
+	stub := fake.GetConfigHistoryRetrieverStub
+	fakeReturns := fake.getConfigHistoryRetrieverReturns
+	fake.recordInvocation("GetConfigHistoryRetriever", []interface{}{})
+	fake.getConfigHistoryRetrieverMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Ledger) GetConfigHistoryRetrieverCallCount() int {
+	fake.getConfigHistoryRetrieverMutex.RLock()
+	defer fake.getConfigHistoryRetrieverMutex.RUnlock()
+	return len(fake.getConfigHistoryRetrieverArgsForCall)
+}
+
+func (fake *Ledger) GetConfigHistoryRetrieverCalls(stub func() (ledgera.ConfigHistoryRetriever, error)) {
+	fake.getConfigHistoryRetrieverMutex.Lock()
+	defer fake.getConfigHistoryRetrieverMutex.Unlock()
+	fake.GetConfigHistoryRetrieverStub = stub
+}
+
+func (fake *Ledger) GetConfigHistoryRetrieverReturns(result1 ledgera.ConfigHistoryRetriever, result2 error) {
+	fake.getConfigHistoryRetrieverMutex.Lock()
+	defer fake.getConfigHistoryRetrieverMutex.Unlock()
+	fake.GetConfigHistoryRetrieverStub = nil
+	fake.getConfigHistoryRetrieverReturns = struct {
+		result1 ledgera.ConfigHistoryRetriever
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Ledger) GetConfigHistoryRetrieverReturnsOnCall(i int, result1 ledgera.ConfigHistoryRetriever, result2 error) {
+	fake.getConfigHistoryRetrieverMutex.Lock()
+	defer fake.getConfigHistoryRetrieverMutex.Unlock()
+	fake.GetConfigHistoryRetrieverStub = nil
+	if fake.getConfigHistoryRetrieverReturnsOnCall == nil {
+		fake.getConfigHistoryRetrieverReturnsOnCall = make(map[int]struct {
+			result1 ledgera.ConfigHistoryRetriever
+			result2 error
+		})
+	}
+	fake.getConfigHistoryRetrieverReturnsOnCall[i] = struct {
+		result1 ledgera.ConfigHistoryRetriever
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Ledger) GetPvtDataByNum(arg1 uint64, arg2 ledgera.PvtNsCollFilter) ([]*ledgera.TxPvtData, error) {
+	fake.getPvtDataByNumMutex.Lock()
+	ret, specificReturn := fake.getPvtDataByNumReturnsOnCall[len(fake.getPvtDataByNumArgsForCall)]
+	fake.getPvtDataByNumArgsForCall = append(fake.getPvtDataByNumArgsForCall, struct {
+		arg1 uint64
+		arg2 ledgera.PvtNsCollFilter
+	}{arg1, arg2})
+	stub := fake.GetPvtDataByNumStub
+	fakeReturns := fake.getPvtDataByNumReturns
+	fake.recordInvocation("GetPvtDataByNum", []interface{}{arg1, arg2})
+	fake.getPvtDataByNumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Ledger) GetPvtDataByNumCallCount() int {
+	fake.getPvtDataByNumMutex.RLock()
+	defer fake.getPvtDataByNumMutex.RUnlock()
+	return len(fake.getPvtDataByNumArgsForCall)
+}
+
+func (fake *Ledger) GetPvtDataByNumCalls(stub func(uint64, ledgera.PvtNsCollFilter) ([]*ledgera.TxPvtData, error)) {
+	fake.getPvtDataByNumMutex.Lock()
+	defer fake.getPvtDataByNumMutex.Unlock()
+	fake.GetPvtDataByNumStub = stub
+}
+
+func (fake *Ledger) GetPvtDataByNumArgsForCall(i int) (uint64, ledgera.PvtNsCollFilter) {
+	fake.getPvtDataByNumMutex.RLock()
+	defer fake.getPvtDataByNumMutex.RUnlock()
+	argsForCall := fake.getPvtDataByNumArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Ledger) GetPvtDataByNumReturns(result1 []*ledgera.TxPvtData, result2 error) {
+	fake.getPvtDataByNumMutex.Lock()
+	defer fake.getPvtDataByNumMutex.Unlock()
+	fake.GetPvtDataByNumStub = nil
+	fake.getPvtDataByNumReturns = struct {
+		result1 []*ledgera.TxPvtData
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Ledger) GetPvtDataByNumReturnsOnCall(i int, result1 []*ledgera.TxPvtData, result2 error) {
+	fake.getPvtDataByNumMutex.Lock()
+	defer fake.getPvtDataByNumMutex.Unlock()
+	fake.GetPvtDataByNumStub = nil
+	if fake.getPvtDataByNumReturnsOnCall == nil {
+		fake.getPvtDataByNumReturnsOnCall = make(map[int]struct {
+			result1 []*ledgera.TxPvtData
+			result2 error
+		})
+	}
+	fake.getPvtDataByNumReturnsOnCall[i] = struct {
+		result1 []*ledgera.TxPvtData
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Ledger) GetTxInvalidationInfo(arg1 string) (*txinvalidation.TxInvalidationInfo, error) {
+	fake.getTxInvalidationInfoMutex.Lock()
+	ret, specificReturn := fake.getTxInvalidationInfoReturnsOnCall[len(fake.getTxInvalidationInfoArgsForCall)]
+	fake.getTxInvalidationInfoArgsForCall = append(fake.getTxInvalidationInfoArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetTxInvalidationInfoStub
+	fakeReturns := fake.getTxInvalidationInfoReturns
+	fake.recordInvocation("GetTxInvalidationInfo", []interface{}{arg1})
+	fake.getTxInvalidationInfoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Ledger) GetTxInvalidationInfoCallCount() int {
+	fake.getTxInvalidationInfoMutex.RLock()
+	defer fake.getTxInvalidationInfoMutex.RUnlock()
+	return len(fake.getTxInvalidationInfoArgsForCall)
+}
+
+func (fake *Ledger) GetTxInvalidationInfoCalls(stub func(string) (*txinvalidation.TxInvalidationInfo, error)) {
+	fake.getTxInvalidationInfoMutex.Lock()
+	defer fake.getTxInvalidationInfoMutex.Unlock()
+	fake.GetTxInvalidationInfoStub = stub
+}
+
+func (fake *Ledger) GetTxInvalidationInfoArgsForCall(i int) string {
+	fake.getTxInvalidationInfoMutex.RLock()
+	defer fake.getTxInvalidationInfoMutex.RUnlock()
+	argsForCall := fake.getTxInvalidationInfoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Ledger) GetTxInvalidationInfoReturns(result1 *txinvalidation.TxInvalidationInfo, result2 error) {
+	fake.getTxInvalidationInfoMutex.Lock()
+	defer fake.getTxInvalidationInfoMutex.Unlock()
+	fake.GetTxInvalidationInfoStub = nil
+	fake.getTxInvalidationInfoReturns = struct {
+		result1 *txinvalidation.TxInvalidationInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Ledger) GetTxInvalidationInfoReturnsOnCall(i int, result1 *txinvalidation.TxInvalidationInfo, result2 error) {
+	fake.getTxInvalidationInfoMutex.Lock()
+	defer fake.getTxInvalidationInfoMutex.Unlock()
+	fake.GetTxInvalidationInfoStub = nil
+	if fake.getTxInvalidationInfoReturnsOnCall == nil {
+		fake.getTxInvalidationInfoReturnsOnCall = make(map[int]struct {
+			result1 *txinvalidation.TxInvalidationInfo
+			result2 error
+		})
+	}
+	fake.getTxInvalidationInfoReturnsOnCall[i] = struct {
+		result1 *txinvalidation.TxInvalidationInfo
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Ledger) GetTxValidationCodeByTxID(arg1 string) (peer.TxValidationCode, uint64, error) {
 	fake.getTxValidationCodeByTxIDMutex.Lock()
 	ret, specificReturn := fake.getTxValidationCodeByTxIDReturnsOnCall[len(fake.getTxValidationCodeByTxIDArgsForCall)]
@@ -408,6 +633,12 @@ func (fake *Ledger) Invocations() map[string][][]interface{} {
 	defer fake.getBlockchainInfoMutex.RUnlock()
 	fake.getBlocksIteratorMutex.RLock()
 	defer fake.getBlocksIteratorMutex.RUnlock()
+	fake.getConfigHistoryRetrieverMutex.RLock()
+	defer fake.getConfigHistoryRetrieverMutex.RUnlock()
+	fake.getPvtDataByNumMutex.RLock()
+	defer fake.getPvtDataByNumMutex.RUnlock()
+	fake.getTxInvalidationInfoMutex.RLock()
+	defer fake.getTxInvalidationInfoMutex.RUnlock()
 	fake.getTxValidationCodeByTxIDMutex.RLock()
 	defer fake.getTxValidationCodeByTxIDMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -11,6 +11,7 @@ import (
 	peerproto "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/ledger"
 	peerledger "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/txinvalidation"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/pkg/errors"
 )
@@ -22,6 +23,9 @@ type Ledger interface {
 	GetBlockchainInfo() (*common.BlockchainInfo, error)
 	GetBlocksIterator(startBlockNumber uint64) (ledger.ResultsIterator, error)
 	GetTxValidationCodeByTxID(txID string) (peerproto.TxValidationCode, uint64, error)
+	GetTxInvalidationInfo(txID string) (*txinvalidation.TxInvalidationInfo, error)
+	GetPvtDataByNum(blockNum uint64, filter peerledger.PvtNsCollFilter) ([]*peerledger.TxPvtData, error)
+	GetConfigHistoryRetriever() (peerledger.ConfigHistoryRetriever, error)
 }
 
 // Provider presents a small piece of the Peer in a form that can be easily used (and mocked) by gateway implementation.
@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	endorserLatency = metrics.GaugeOpts{
+		Namespace:    "gateway",
+		Name:         "endorser_latency",
+		Help:         "The rolling latency score of an endorsing peer in seconds, in which failed requests count as the endorsement timeout.",
+		LabelNames:   []string{"mspid", "endpoint"},
+		StatsdFormat: "%{#fqname}.%{mspid}.%{endpoint}",
+	}
+	endorserErrorRate = metrics.GaugeOpts{
+		Namespace:    "gateway",
+		Name:         "endorser_error_rate",
+		Help:         "The rolling rate of failed requests to an endorsing peer.",
+		LabelNames:   []string{"mspid", "endpoint"},
+		StatsdFormat: "%{#fqname}.%{mspid}.%{endpoint}",
+	}
+	endorserCircuitOpen = metrics.GaugeOpts{
+		Namespace:    "gateway",
+		Name:         "endorser_circuit_open",
+		Help:         "Whether an endorsing peer is circuit-broken after consecutive failed requests (1) or not (0).",
+		LabelNames:   []string{"mspid", "endpoint"},
+		StatsdFormat: "%{#fqname}.%{mspid}.%{endpoint}",
+	}
+	throttledRequests = metrics.CounterOpts{
+		Namespace:    "gateway",
+		Name:         "throttled_requests",
+		Help:         "The number of requests rejected for exceeding the rate limit or concurrency limit of their MSP ID or client identity.",
+		LabelNames:   []string{"method", "mspid", "scope", "limit"},
+		StatsdFormat: "%{#fqname}.%{method}.%{mspid}.%{scope}.%{limit}",
+	}
+)
+
+type Metrics struct {
+	EndorserLatency     metrics.Gauge
+	EndorserErrorRate   metrics.Gauge
+	EndorserCircuitOpen metrics.Gauge
+	ThrottledRequests   metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		EndorserLatency:     p.NewGauge(endorserLatency),
+		EndorserErrorRate:   p.NewGauge(endorserErrorRate),
+		EndorserCircuitOpen: p.NewGauge(endorserCircuitOpen),
+		ThrottledRequests:   p.NewCounter(throttledRequests),
+	}
+}
@@ -0,0 +1,805 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: prepared_transactions.proto
+
+package prepared
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// StoreRequest stores a prepared transaction, as returned by the gateway Endorse service, until it is signed and
+// submitted. Transactions are stored under their transaction ID.
+type StoreRequest struct {
+	// identity is the serialized identity of the client storing the transaction, which must be the creator or an endorser of the transaction
+	Identity  []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// prepared_transaction is the endorsed transaction envelope, which need not be signed yet
+	PreparedTransaction *common.Envelope `protobuf:"bytes,3,opt,name=prepared_transaction,json=preparedTransaction,proto3" json:"prepared_transaction,omitempty"`
+	// time_to_live_seconds is the time after which the transaction expires; 0 selects the maximum allowed
+	TimeToLiveSeconds    uint64   `protobuf:"varint,4,opt,name=time_to_live_seconds,json=timeToLiveSeconds,proto3" json:"time_to_live_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreRequest) Reset()         { *m = StoreRequest{} }
+func (m *StoreRequest) String() string { return proto.CompactTextString(m) }
+func (*StoreRequest) ProtoMessage()    {}
+func (*StoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{0}
+}
+
+func (m *StoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreRequest.Unmarshal(m, b)
+}
+func (m *StoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreRequest.Marshal(b, m, deterministic)
+}
+func (m *StoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreRequest.Merge(m, src)
+}
+func (m *StoreRequest) XXX_Size() int {
+	return xxx_messageInfo_StoreRequest.Size(m)
+}
+func (m *StoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreRequest proto.InternalMessageInfo
+
+func (m *StoreRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *StoreRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *StoreRequest) GetPreparedTransaction() *common.Envelope {
+	if m != nil {
+		return m.PreparedTransaction
+	}
+	return nil
+}
+
+func (m *StoreRequest) GetTimeToLiveSeconds() uint64 {
+	if m != nil {
+		return m.TimeToLiveSeconds
+	}
+	return 0
+}
+
+// SignedStoreRequest contains a marshalled StoreRequest and the signature of the client
+type SignedStoreRequest struct {
+	Request              []byte   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedStoreRequest) Reset()         { *m = SignedStoreRequest{} }
+func (m *SignedStoreRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedStoreRequest) ProtoMessage()    {}
+func (*SignedStoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{1}
+}
+
+func (m *SignedStoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedStoreRequest.Unmarshal(m, b)
+}
+func (m *SignedStoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedStoreRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedStoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedStoreRequest.Merge(m, src)
+}
+func (m *SignedStoreRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedStoreRequest.Size(m)
+}
+func (m *SignedStoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedStoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedStoreRequest proto.InternalMessageInfo
+
+func (m *SignedStoreRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedStoreRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetRequest fetches a stored prepared transaction for review or signing
+type GetRequest struct {
+	// identity is the serialized identity of the client fetching the transaction
+	Identity             []byte   `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	ChannelId            string   `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	TransactionId        string   `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRequest) Reset()         { *m = GetRequest{} }
+func (m *GetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRequest) ProtoMessage()    {}
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{2}
+}
+
+func (m *GetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
+}
+func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRequest.Merge(m, src)
+}
+func (m *GetRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRequest.Size(m)
+}
+func (m *GetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+
+func (m *GetRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *GetRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *GetRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+// SignedGetRequest contains a marshalled GetRequest and the signature of the client
+type SignedGetRequest struct {
+	Request              []byte   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedGetRequest) Reset()         { *m = SignedGetRequest{} }
+func (m *SignedGetRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedGetRequest) ProtoMessage()    {}
+func (*SignedGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{3}
+}
+
+func (m *SignedGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedGetRequest.Unmarshal(m, b)
+}
+func (m *SignedGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedGetRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedGetRequest.Merge(m, src)
+}
+func (m *SignedGetRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedGetRequest.Size(m)
+}
+func (m *SignedGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedGetRequest proto.InternalMessageInfo
+
+func (m *SignedGetRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedGetRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// DeleteRequest discards a stored prepared transaction. Only the client that stored the transaction, or the
+// creator of the transaction, may delete it.
+type DeleteRequest struct {
+	// identity is the serialized identity of the client deleting the transaction
+	Identity             []byte   `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	ChannelId            string   `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	TransactionId        string   `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{4}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *DeleteRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+// SignedDeleteRequest contains a marshalled DeleteRequest and the signature of the client
+type SignedDeleteRequest struct {
+	Request              []byte   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedDeleteRequest) Reset()         { *m = SignedDeleteRequest{} }
+func (m *SignedDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedDeleteRequest) ProtoMessage()    {}
+func (*SignedDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{5}
+}
+
+func (m *SignedDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedDeleteRequest.Unmarshal(m, b)
+}
+func (m *SignedDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedDeleteRequest.Merge(m, src)
+}
+func (m *SignedDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedDeleteRequest.Size(m)
+}
+func (m *SignedDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedDeleteRequest proto.InternalMessageInfo
+
+func (m *SignedDeleteRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedDeleteRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type DeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
+func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteResponse) ProtoMessage()    {}
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{6}
+}
+
+func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
+}
+func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteResponse.Merge(m, src)
+}
+func (m *DeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteResponse.Size(m)
+}
+func (m *DeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+
+// SubmitRequest signs a stored prepared transaction with the signature of its creator and submits it to the
+// ordering service. The transaction is deleted once it is successfully submitted.
+type SubmitRequest struct {
+	ChannelId     string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	TransactionId string `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// signature is the signature of the creator of the transaction over the payload of the prepared transaction
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitRequest) Reset()         { *m = SubmitRequest{} }
+func (m *SubmitRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitRequest) ProtoMessage()    {}
+func (*SubmitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{7}
+}
+
+func (m *SubmitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitRequest.Unmarshal(m, b)
+}
+func (m *SubmitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitRequest.Marshal(b, m, deterministic)
+}
+func (m *SubmitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitRequest.Merge(m, src)
+}
+func (m *SubmitRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitRequest.Size(m)
+}
+func (m *SubmitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitRequest proto.InternalMessageInfo
+
+func (m *SubmitRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *SubmitRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *SubmitRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type SubmitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitResponse) Reset()         { *m = SubmitResponse{} }
+func (m *SubmitResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitResponse) ProtoMessage()    {}
+func (*SubmitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{8}
+}
+
+func (m *SubmitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitResponse.Unmarshal(m, b)
+}
+func (m *SubmitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitResponse.Marshal(b, m, deterministic)
+}
+func (m *SubmitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitResponse.Merge(m, src)
+}
+func (m *SubmitResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitResponse.Size(m)
+}
+func (m *SubmitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitResponse proto.InternalMessageInfo
+
+// PreparedTransaction is a stored prepared transaction
+type PreparedTransaction struct {
+	ChannelId           string           `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	TransactionId       string           `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	PreparedTransaction *common.Envelope `protobuf:"bytes,3,opt,name=prepared_transaction,json=preparedTransaction,proto3" json:"prepared_transaction,omitempty"`
+	// stored_by is the serialized identity of the client that stored the transaction
+	StoredBy             []byte                 `protobuf:"bytes,4,opt,name=stored_by,json=storedBy,proto3" json:"stored_by,omitempty"`
+	StoredAt             *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=stored_at,json=storedAt,proto3" json:"stored_at,omitempty"`
+	ExpiresAt            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *PreparedTransaction) Reset()         { *m = PreparedTransaction{} }
+func (m *PreparedTransaction) String() string { return proto.CompactTextString(m) }
+func (*PreparedTransaction) ProtoMessage()    {}
+func (*PreparedTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a8c9ea3c776df1ac, []int{9}
+}
+
+func (m *PreparedTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreparedTransaction.Unmarshal(m, b)
+}
+func (m *PreparedTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreparedTransaction.Marshal(b, m, deterministic)
+}
+func (m *PreparedTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreparedTransaction.Merge(m, src)
+}
+func (m *PreparedTransaction) XXX_Size() int {
+	return xxx_messageInfo_PreparedTransaction.Size(m)
+}
+func (m *PreparedTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreparedTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreparedTransaction proto.InternalMessageInfo
+
+func (m *PreparedTransaction) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *PreparedTransaction) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *PreparedTransaction) GetPreparedTransaction() *common.Envelope {
+	if m != nil {
+		return m.PreparedTransaction
+	}
+	return nil
+}
+
+func (m *PreparedTransaction) GetStoredBy() []byte {
+	if m != nil {
+		return m.StoredBy
+	}
+	return nil
+}
+
+func (m *PreparedTransaction) GetStoredAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.StoredAt
+	}
+	return nil
+}
+
+func (m *PreparedTransaction) GetExpiresAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StoreRequest)(nil), "prepared.StoreRequest")
+	proto.RegisterType((*SignedStoreRequest)(nil), "prepared.SignedStoreRequest")
+	proto.RegisterType((*GetRequest)(nil), "prepared.GetRequest")
+	proto.RegisterType((*SignedGetRequest)(nil), "prepared.SignedGetRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "prepared.DeleteRequest")
+	proto.RegisterType((*SignedDeleteRequest)(nil), "prepared.SignedDeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "prepared.DeleteResponse")
+	proto.RegisterType((*SubmitRequest)(nil), "prepared.SubmitRequest")
+	proto.RegisterType((*SubmitResponse)(nil), "prepared.SubmitResponse")
+	proto.RegisterType((*PreparedTransaction)(nil), "prepared.PreparedTransaction")
+}
+
+func init() { proto.RegisterFile("prepared_transactions.proto", fileDescriptor_a8c9ea3c776df1ac) }
+
+var fileDescriptor_a8c9ea3c776df1ac = []byte{
+	// 552 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x94, 0x51, 0x8b, 0xda, 0x40,
+	0x10, 0xc7, 0x89, 0xde, 0x59, 0x9d, 0xea, 0x61, 0x57, 0xa1, 0x21, 0x77, 0x52, 0x11, 0x0a, 0x3e,
+	0x25, 0x70, 0x7d, 0x28, 0xe5, 0x28, 0xd4, 0xeb, 0x95, 0xe3, 0xca, 0x15, 0x4a, 0xf4, 0xa9, 0x2f,
+	0x21, 0x31, 0x73, 0x71, 0x69, 0xdc, 0xcd, 0xed, 0xae, 0xb6, 0x7e, 0x99, 0x7e, 0x87, 0x7e, 0x82,
+	0x7e, 0xb5, 0x92, 0xac, 0x51, 0x73, 0x17, 0x10, 0x0e, 0xb9, 0x27, 0xdd, 0xf9, 0xcf, 0xcc, 0xfe,
+	0x66, 0x32, 0xb3, 0x70, 0x9a, 0x08, 0x4c, 0x7c, 0x81, 0xa1, 0xa7, 0x84, 0xcf, 0xa4, 0x3f, 0x55,
+	0x94, 0x33, 0x69, 0x27, 0x82, 0x2b, 0x4e, 0xea, 0xb9, 0x68, 0x75, 0xa6, 0x7c, 0x3e, 0xe7, 0xcc,
+	0xd1, 0x3f, 0x5a, 0xb6, 0xde, 0x44, 0x9c, 0x47, 0x31, 0x3a, 0xd9, 0x29, 0x58, 0xdc, 0x39, 0x8a,
+	0xce, 0x51, 0x2a, 0x7f, 0x9e, 0x68, 0x87, 0xc1, 0x3f, 0x03, 0x9a, 0x63, 0xc5, 0x05, 0xba, 0x78,
+	0xbf, 0x40, 0xa9, 0x88, 0x05, 0x75, 0x1a, 0x22, 0x53, 0x54, 0xad, 0x4c, 0xa3, 0x6f, 0x0c, 0x9b,
+	0xee, 0xe6, 0x4c, 0x7a, 0x00, 0xd3, 0x99, 0xcf, 0x18, 0xc6, 0x1e, 0x0d, 0xcd, 0x4a, 0xdf, 0x18,
+	0x36, 0xdc, 0xc6, 0xda, 0x72, 0x13, 0x92, 0xcf, 0xd0, 0x2d, 0x43, 0x35, 0xab, 0x7d, 0x63, 0xf8,
+	0xf2, 0xbc, 0x6d, 0xaf, 0xc9, 0xbe, 0xb0, 0x25, 0xc6, 0x3c, 0x41, 0xb7, 0x93, 0x7b, 0x4f, 0xb6,
+	0xce, 0xc4, 0x81, 0x6e, 0xca, 0xe8, 0x29, 0xee, 0xc5, 0x74, 0x89, 0x9e, 0xc4, 0x29, 0x67, 0xa1,
+	0x34, 0x8f, 0xfa, 0xc6, 0xf0, 0xc8, 0x7d, 0x95, 0x6a, 0x13, 0x7e, 0x4b, 0x97, 0x38, 0xd6, 0xc2,
+	0xe0, 0x16, 0xc8, 0x98, 0x46, 0x0c, 0xc3, 0x42, 0x19, 0x26, 0xbc, 0x10, 0xfa, 0xef, 0xba, 0x8a,
+	0xfc, 0x48, 0xce, 0xa0, 0x21, 0x69, 0xc4, 0x7c, 0xb5, 0x10, 0x98, 0xd5, 0xd0, 0x74, 0xb7, 0x86,
+	0x01, 0x03, 0xb8, 0x46, 0x75, 0x80, 0x66, 0xbc, 0x85, 0x93, 0x9d, 0x1e, 0xa4, 0x2e, 0xd5, 0xcc,
+	0xa5, 0xb5, 0x63, 0xbd, 0x09, 0x07, 0x5f, 0xa1, 0xad, 0xe9, 0x77, 0x6e, 0x7d, 0x2a, 0xfb, 0x3d,
+	0xb4, 0xae, 0x30, 0x46, 0x85, 0xcf, 0x87, 0xff, 0x0d, 0x3a, 0x1a, 0xbf, 0x78, 0xf1, 0x53, 0x2b,
+	0x68, 0xc3, 0x49, 0x9e, 0x48, 0x26, 0x9c, 0x49, 0x1c, 0x48, 0x68, 0x8d, 0x17, 0xc1, 0x9c, 0x6e,
+	0x9a, 0x53, 0xe4, 0x36, 0xf6, 0x73, 0x57, 0x4a, 0xb8, 0x8b, 0x18, 0xd5, 0x12, 0x8c, 0xfc, 0xd2,
+	0x35, 0xc6, 0xdf, 0x0a, 0x74, 0xbe, 0x97, 0x4c, 0xeb, 0x61, 0x68, 0x0e, 0xb2, 0x38, 0xa7, 0xd0,
+	0x90, 0xe9, 0x06, 0x84, 0x5e, 0xb0, 0xca, 0xb6, 0xa5, 0xe9, 0xd6, 0xb5, 0xe1, 0x72, 0x45, 0xde,
+	0x6f, 0x44, 0x5f, 0x99, 0xc7, 0x59, 0x5a, 0xcb, 0xd6, 0x6f, 0x83, 0x9d, 0xbf, 0x0d, 0xf6, 0x24,
+	0x7f, 0x1b, 0xf2, 0xc0, 0x91, 0x22, 0x1f, 0x00, 0xf0, 0x77, 0x42, 0x05, 0xca, 0x34, 0xb2, 0xb6,
+	0x37, 0xb2, 0xb1, 0xf6, 0x1e, 0xa9, 0xf3, 0x3f, 0x15, 0xe8, 0x96, 0xf4, 0x4c, 0x92, 0x2b, 0x38,
+	0xce, 0x76, 0x95, 0x9c, 0xd9, 0x79, 0x21, 0xf6, 0xe3, 0x15, 0xb6, 0x7a, 0x5b, 0xb5, 0xac, 0xf5,
+	0x9f, 0xa0, 0x7a, 0x8d, 0x8a, 0x58, 0x0f, 0x73, 0x6c, 0x17, 0x69, 0x5f, 0x86, 0x11, 0xd4, 0xf4,
+	0xb4, 0x91, 0xde, 0xc3, 0x24, 0x85, 0x71, 0xb6, 0xcc, 0xad, 0x5c, 0x1c, 0x4f, 0x72, 0x01, 0x35,
+	0x3d, 0x29, 0xe4, 0xf5, 0x4e, 0x8a, 0xdd, 0x81, 0xb5, 0xcc, 0xc7, 0x82, 0x0e, 0xbe, 0xfc, 0xf8,
+	0xe3, 0x22, 0xa2, 0x6a, 0xb6, 0x08, 0xd2, 0x0f, 0xec, 0xcc, 0x56, 0x09, 0x8a, 0x18, 0xc3, 0x08,
+	0x85, 0x73, 0xe7, 0x07, 0x82, 0x4e, 0x1d, 0xca, 0x14, 0x0a, 0xe6, 0xc7, 0x4e, 0xf2, 0x33, 0x72,
+	0x22, 0x5f, 0xe1, 0x2f, 0x7f, 0xe5, 0xe4, 0xd9, 0x82, 0x5a, 0xd6, 0xfe, 0x77, 0xff, 0x07, 0x00,
+	0x93, 0x71, 0xfe, 0xef, 0x20, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PreparedTransactionsClient is the client API for PreparedTransactions service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PreparedTransactionsClient interface {
+	// Store stores an endorsed transaction until it expires
+	Store(ctx context.Context, in *SignedStoreRequest, opts ...grpc.CallOption) (*PreparedTransaction, error)
+	// Get returns a stored transaction
+	Get(ctx context.Context, in *SignedGetRequest, opts ...grpc.CallOption) (*PreparedTransaction, error)
+	// Delete discards a stored transaction
+	Delete(ctx context.Context, in *SignedDeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Submit signs and submits a stored transaction
+	Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+}
+
+type preparedTransactionsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPreparedTransactionsClient(cc grpc.ClientConnInterface) PreparedTransactionsClient {
+	return &preparedTransactionsClient{cc}
+}
+
+func (c *preparedTransactionsClient) Store(ctx context.Context, in *SignedStoreRequest, opts ...grpc.CallOption) (*PreparedTransaction, error) {
+	out := new(PreparedTransaction)
+	err := c.cc.Invoke(ctx, "/prepared.PreparedTransactions/Store", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *preparedTransactionsClient) Get(ctx context.Context, in *SignedGetRequest, opts ...grpc.CallOption) (*PreparedTransaction, error) {
+	out := new(PreparedTransaction)
+	err := c.cc.Invoke(ctx, "/prepared.PreparedTransactions/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *preparedTransactionsClient) Delete(ctx context.Context, in *SignedDeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/prepared.PreparedTransactions/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *preparedTransactionsClient) Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, "/prepared.PreparedTransactions/Submit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PreparedTransactionsServer is the server API for PreparedTransactions service.
+type PreparedTransactionsServer interface {
+	// Store stores an endorsed transaction until it expires
+	Store(context.Context, *SignedStoreRequest) (*PreparedTransaction, error)
+	// Get returns a stored transaction
+	Get(context.Context, *SignedGetRequest) (*PreparedTransaction, error)
+	// Delete discards a stored transaction
+	Delete(context.Context, *SignedDeleteRequest) (*DeleteResponse, error)
+	// Submit signs and submits a stored transaction
+	Submit(context.Context, *SubmitRequest) (*SubmitResponse, error)
+}
+
+// UnimplementedPreparedTransactionsServer can be embedded to have forward compatible implementations.
+type UnimplementedPreparedTransactionsServer struct {
+}
+
+func (*UnimplementedPreparedTransactionsServer) Store(ctx context.Context, req *SignedStoreRequest) (*PreparedTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Store not implemented")
+}
+func (*UnimplementedPreparedTransactionsServer) Get(ctx context.Context, req *SignedGetRequest) (*PreparedTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedPreparedTransactionsServer) Delete(ctx context.Context, req *SignedDeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedPreparedTransactionsServer) Submit(ctx context.Context, req *SubmitRequest) (*SubmitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+
+func RegisterPreparedTransactionsServer(s *grpc.Server, srv PreparedTransactionsServer) {
+	s.RegisterService(&_PreparedTransactions_serviceDesc, srv)
+}
+
+func _PreparedTransactions_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PreparedTransactionsServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/prepared.PreparedTransactions/Store",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PreparedTransactionsServer).Store(ctx, req.(*SignedStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PreparedTransactions_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PreparedTransactionsServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/prepared.PreparedTransactions/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PreparedTransactionsServer).Get(ctx, req.(*SignedGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PreparedTransactions_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PreparedTransactionsServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/prepared.PreparedTransactions/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PreparedTransactionsServer).Delete(ctx, req.(*SignedDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PreparedTransactions_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PreparedTransactionsServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/prepared.PreparedTransactions/Submit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PreparedTransactionsServer).Submit(ctx, req.(*SubmitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PreparedTransactions_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "prepared.PreparedTransactions",
+	HandlerType: (*PreparedTransactionsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Store",
+			Handler:    _PreparedTransactions_Store_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _PreparedTransactions_Get_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _PreparedTransactions_Delete_Handler,
+		},
+		{
+			MethodName: "Submit",
+			Handler:    _PreparedTransactions_Submit_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "prepared_transactions.proto",
+}
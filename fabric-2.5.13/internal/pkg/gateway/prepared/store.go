@@ -0,0 +1,370 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prepared
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("gateway.prepared")
+
+const (
+	dbName = "preparedtxs"
+	keySep = byte(0x00)
+
+	txPrefix          = byte(1)
+	expiryIndexPrefix = byte(2)
+
+	// expiryCheckInterval is the interval at which the expired prepared transactions are purged
+	expiryCheckInterval = time.Minute
+)
+
+var emptyValue = []byte{}
+
+// Quotas limits the number of prepared transactions held by the store. A zero quota is not enforced.
+type Quotas struct {
+	// MaxTransactions is the maximum number of prepared transactions stored.
+	MaxTransactions int
+	// MaxTransactionsPerIdentity is the maximum number of prepared transactions stored by the same client identity.
+	MaxTransactionsPerIdentity int
+}
+
+// QuotaExceededError is returned by Put when storing a prepared transaction would exceed the maximum number of
+// transactions of the store, or of the client identity that stored it.
+type QuotaExceededError struct {
+	Quota       int
+	PerIdentity bool
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.PerIdentity {
+		return fmt.Sprintf("the maximum of %d prepared transactions stored by the same client identity is reached", e.Quota)
+	}
+	return fmt.Sprintf("the maximum of %d prepared transactions stored is reached", e.Quota)
+}
+
+// AlreadyStoredError is returned by Put when a prepared transaction that is not expired is already stored with the
+// same channel and transaction ID.
+type AlreadyStoredError struct {
+	TransactionID string
+}
+
+func (e *AlreadyStoredError) Error() string {
+	return fmt.Sprintf("transaction %s is already stored", e.TransactionID)
+}
+
+// Store persists prepared transactions, keyed by channel and transaction ID, until they are purged once expired.
+// The transactions are indexed by expiry time, so that the expired transactions are purged periodically without
+// reading the transactions that are not expired.
+type Store struct {
+	provider *leveldbhelper.Provider
+	db       *leveldbhelper.DBHandle
+	quotas   Quotas
+	clock    func() time.Time
+
+	lock            sync.Mutex
+	count           int
+	countByIdentity map[string]int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewStore opens the store of prepared transactions at the given path, and launches the periodic purge of the
+// expired transactions.
+func NewStore(dbPath string, quotas Quotas) (*Store, error) {
+	provider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to open prepared transactions store at %s", dbPath)
+	}
+	s := &Store{
+		provider:        provider,
+		db:              provider.GetDBHandle(dbName),
+		quotas:          quotas,
+		clock:           time.Now,
+		countByIdentity: map[string]int{},
+	}
+	if err := s.loadCounts(); err != nil {
+		provider.Close()
+		return nil, errors.WithMessagef(err, "failed to count the prepared transactions stored at %s", dbPath)
+	}
+	s.launchExpiryProc(expiryCheckInterval)
+	return s, nil
+}
+
+// launchExpiryProc launches a goroutine that periodically purges the expired prepared transactions.
+func (s *Store) launchExpiryProc(interval time.Duration) {
+	s.done = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-ticker.C:
+			}
+			if purged, err := s.PurgeExpired(s.clock()); err != nil {
+				logger.Errorw("Failed to purge expired prepared transactions", "error", err)
+			} else if purged > 0 {
+				logger.Debugw("Purged expired prepared transactions", "purged", purged)
+			}
+		}
+	}()
+}
+
+// Put stores the prepared transaction, replacing the transaction stored with the same channel and transaction ID if
+// that transaction is expired at the time the new transaction is stored. An *AlreadyStoredError is returned if the
+// stored transaction is not expired, and a *QuotaExceededError if the transaction would exceed the quotas of the store.
+func (s *Store) Put(tx *PreparedTransaction) error {
+	value, err := proto.Marshal(tx)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal prepared transaction")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, err := s.Get(tx.ChannelId, tx.TransactionId)
+	if err != nil {
+		return err
+	}
+	now := s.clock()
+	if tx.StoredAt != nil {
+		now = tx.StoredAt.AsTime()
+	}
+	if existing != nil && !Expired(existing, now) {
+		return &AlreadyStoredError{TransactionID: tx.TransactionId}
+	}
+	if err := s.checkQuotas(tx, existing); err != nil {
+		return err
+	}
+
+	batch := s.db.NewUpdateBatch()
+	if existing != nil {
+		deleteTx(batch, existing)
+	}
+	batch.Put(txKey(tx.ChannelId, tx.TransactionId), value)
+	if tx.ExpiresAt != nil {
+		batch.Put(expiryIndexKey(tx), emptyValue)
+	}
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+
+	if existing != nil {
+		s.release(existing)
+	}
+	s.count++
+	s.countByIdentity[string(tx.StoredBy)]++
+	return nil
+}
+
+// Get returns the prepared transaction stored with the given channel and transaction ID, or nil if there is no
+// such transaction. Expired transactions are returned until they are purged.
+func (s *Store) Get(channelID, txID string) (*PreparedTransaction, error) {
+	value, err := s.db.Get(txKey(channelID, txID))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+	tx := &PreparedTransaction{}
+	if err := proto.Unmarshal(value, tx); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal prepared transaction")
+	}
+	return tx, nil
+}
+
+// Delete discards the prepared transaction stored with the given channel and transaction ID.
+func (s *Store) Delete(channelID, txID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	tx, err := s.Get(channelID, txID)
+	if err != nil || tx == nil {
+		return err
+	}
+	batch := s.db.NewUpdateBatch()
+	deleteTx(batch, tx)
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	s.release(tx)
+	return nil
+}
+
+// PurgeExpired discards the prepared transactions expired at the given time, and returns the number of discarded
+// transactions. Only the expiry index of the expired transactions is read.
+func (s *Store) PurgeExpired(now time.Time) (int, error) {
+	if now.UnixNano() < 0 {
+		return 0, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	itr, err := s.db.GetIterator([]byte{expiryIndexPrefix}, expiryIndexRangeEndKey(now))
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Release()
+
+	batch := s.db.NewUpdateBatch()
+	var purged []*PreparedTransaction
+	for itr.Next() {
+		channelID, txID, err := splitExpiryIndexKey(itr.Key())
+		if err != nil {
+			logger.Warnw("Discarding unreadable expiry index entry", "err", err)
+			batch.Delete(append([]byte{}, itr.Key()...))
+			continue
+		}
+		tx, err := s.Get(channelID, txID)
+		if err != nil {
+			logger.Warnw("Discarding unreadable prepared transaction", "channel", channelID, "txID", txID, "err", err)
+			batch.Delete(txKey(channelID, txID))
+		}
+		if tx == nil {
+			batch.Delete(append([]byte{}, itr.Key()...))
+			continue
+		}
+		deleteTx(batch, tx)
+		purged = append(purged, tx)
+	}
+	if err := itr.Error(); err != nil {
+		return 0, err
+	}
+
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return 0, err
+	}
+	for _, tx := range purged {
+		s.release(tx)
+	}
+	return len(purged), nil
+}
+
+// Close stops the periodic purge of the expired transactions and closes the store.
+func (s *Store) Close() {
+	if s.done != nil {
+		close(s.done)
+		s.wg.Wait()
+		s.done = nil
+	}
+	s.provider.Close()
+}
+
+// loadCounts counts the stored transactions, in total and by the client identity that stored them.
+func (s *Store) loadCounts() error {
+	itr, err := s.db.GetIterator([]byte{txPrefix}, []byte{txPrefix + 1})
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	for itr.Next() {
+		tx := &PreparedTransaction{}
+		if err := proto.Unmarshal(itr.Value(), tx); err != nil {
+			return errors.Wrap(err, "failed to unmarshal prepared transaction")
+		}
+		s.count++
+		s.countByIdentity[string(tx.StoredBy)]++
+	}
+	return itr.Error()
+}
+
+// checkQuotas returns a *QuotaExceededError if storing the transaction, in place of the existing transaction with
+// the same channel and transaction ID, would exceed a quota. It must be invoked while holding the lock.
+func (s *Store) checkQuotas(tx, existing *PreparedTransaction) error {
+	count, identityCount := s.count, s.countByIdentity[string(tx.StoredBy)]
+	if existing != nil {
+		count--
+		if string(existing.StoredBy) == string(tx.StoredBy) {
+			identityCount--
+		}
+	}
+	if s.quotas.MaxTransactions > 0 && count >= s.quotas.MaxTransactions {
+		return &QuotaExceededError{Quota: s.quotas.MaxTransactions}
+	}
+	if s.quotas.MaxTransactionsPerIdentity > 0 && identityCount >= s.quotas.MaxTransactionsPerIdentity {
+		return &QuotaExceededError{Quota: s.quotas.MaxTransactionsPerIdentity, PerIdentity: true}
+	}
+	return nil
+}
+
+// release removes a discarded transaction from the counts. It must be invoked while holding the lock.
+func (s *Store) release(tx *PreparedTransaction) {
+	s.count--
+	identity := string(tx.StoredBy)
+	s.countByIdentity[identity]--
+	if s.countByIdentity[identity] <= 0 {
+		delete(s.countByIdentity, identity)
+	}
+}
+
+// Expired returns whether the prepared transaction is expired at the given time.
+func Expired(tx *PreparedTransaction, now time.Time) bool {
+	return tx.GetExpiresAt() != nil && !now.Before(tx.GetExpiresAt().AsTime())
+}
+
+// deleteTx adds to the batch the deletion of a prepared transaction and of its expiry index entry.
+func deleteTx(batch *leveldbhelper.UpdateBatch, tx *PreparedTransaction) {
+	batch.Delete(txKey(tx.ChannelId, tx.TransactionId))
+	if tx.ExpiresAt != nil {
+		batch.Delete(expiryIndexKey(tx))
+	}
+}
+
+func txKey(channelID, txID string) []byte {
+	return append(append(append([]byte{txPrefix}, channelID...), keySep), txID...)
+}
+
+// expiryIndexKey returns the key of the expiry index entry of the transaction, which is ordered by expiry time.
+func expiryIndexKey(tx *PreparedTransaction) []byte {
+	key := encodeExpiry(tx.ExpiresAt.AsTime())
+	return append(append(append(key, tx.ChannelId...), keySep), tx.TransactionId...)
+}
+
+// expiryIndexRangeEndKey returns the key that follows the expiry index entries of the transactions expired at the
+// given time.
+func expiryIndexRangeEndKey(now time.Time) []byte {
+	return encodeExpiry(now.Add(time.Nanosecond))
+}
+
+func encodeExpiry(expiresAt time.Time) []byte {
+	expiry := expiresAt.UnixNano()
+	if expiry < 0 {
+		expiry = 0
+	}
+	key := make([]byte, 9, 64)
+	key[0] = expiryIndexPrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(expiry))
+	return key
+}
+
+func splitExpiryIndexKey(key []byte) (channelID, txID string, err error) {
+	if len(key) < 9 {
+		return "", "", errors.Errorf("invalid expiry index key %x", key)
+	}
+	for i := 9; i < len(key); i++ {
+		if key[i] == keySep {
+			return string(key[9:i]), string(key[i+1:]), nil
+		}
+	}
+	return "", "", errors.Errorf("invalid expiry index key %x", key)
+}
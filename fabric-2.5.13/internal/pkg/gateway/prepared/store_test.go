@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prepared
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestStore(t *testing.T) {
+	dbPath := t.TempDir()
+	store, err := NewStore(dbPath, Quotas{})
+	require.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+
+	tx1 := &PreparedTransaction{
+		ChannelId:           "mychannel",
+		TransactionId:       "tx1",
+		PreparedTransaction: &common.Envelope{Payload: []byte("payload1")},
+		StoredBy:            []byte("client1"),
+		StoredAt:            timestamppb.New(now),
+		ExpiresAt:           timestamppb.New(now.Add(time.Minute)),
+	}
+	tx2 := &PreparedTransaction{
+		ChannelId:     "mychannel",
+		TransactionId: "tx2",
+		ExpiresAt:     timestamppb.New(now.Add(time.Hour)),
+	}
+	require.NoError(t, store.Put(tx1))
+	require.NoError(t, store.Put(tx2))
+
+	actual, err := store.Get("mychannel", "tx1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(tx1, actual))
+
+	actual, err = store.Get("otherchannel", "tx1")
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	// persisted across restarts
+	store.Close()
+	store, err = NewStore(dbPath, Quotas{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	actual, err = store.Get("mychannel", "tx1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(tx1, actual))
+
+	require.False(t, Expired(actual, now))
+	now = now.Add(time.Minute)
+	require.True(t, Expired(actual, now))
+
+	purged, err := store.PurgeExpired(now)
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	actual, err = store.Get("mychannel", "tx1")
+	require.NoError(t, err)
+	require.Nil(t, actual, "purged")
+
+	actual, err = store.Get("mychannel", "tx2")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(tx2, actual))
+
+	require.NoError(t, store.Delete("mychannel", "tx2"))
+	actual, err = store.Get("mychannel", "tx2")
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	purged, err = store.PurgeExpired(now)
+	require.NoError(t, err)
+	require.Equal(t, 0, purged)
+}
+
+func TestStorePutExisting(t *testing.T) {
+	store, err := NewStore(t.TempDir(), Quotas{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Unix(1000, 0)
+	tx := func(storedAt time.Time) *PreparedTransaction {
+		return &PreparedTransaction{
+			ChannelId:     "mychannel",
+			TransactionId: "tx1",
+			StoredAt:      timestamppb.New(storedAt),
+			ExpiresAt:     timestamppb.New(storedAt.Add(time.Minute)),
+		}
+	}
+	require.NoError(t, store.Put(tx(now)))
+
+	err = store.Put(tx(now.Add(59 * time.Second)))
+	require.EqualError(t, err, "transaction tx1 is already stored")
+	require.IsType(t, &AlreadyStoredError{}, err)
+
+	// an expired transaction is replaced
+	require.NoError(t, store.Put(tx(now.Add(time.Minute))))
+	require.Equal(t, 1, store.count)
+}
+
+func TestStorePurgeExpired(t *testing.T) {
+	store, err := NewStore(t.TempDir(), Quotas{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Unix(1000, 0)
+	for i, ttl := range []time.Duration{3 * time.Minute, time.Minute, 2 * time.Minute} {
+		require.NoError(t, store.Put(&PreparedTransaction{
+			ChannelId:     "mychannel",
+			TransactionId: fmt.Sprintf("tx%d", i),
+			ExpiresAt:     timestamppb.New(now.Add(ttl)),
+		}))
+	}
+	// replaced with a later expiry time, which discards the previous expiry index entry
+	require.NoError(t, store.Put(&PreparedTransaction{
+		ChannelId:     "mychannel",
+		TransactionId: "tx1",
+		ExpiresAt:     timestamppb.New(now.Add(4 * time.Minute)),
+	}))
+
+	purged, err := store.PurgeExpired(now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 0, purged)
+
+	purged, err = store.PurgeExpired(now.Add(2 * time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	actual, err := store.Get("mychannel", "tx2")
+	require.NoError(t, err)
+	require.Nil(t, actual)
+
+	purged, err = store.PurgeExpired(now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 2, purged)
+	require.Equal(t, 0, store.count)
+	require.Empty(t, store.countByIdentity)
+}
+
+func TestStoreExpiryProc(t *testing.T) {
+	store, err := NewStore(t.TempDir(), Quotas{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	// replace the purge launched by NewStore with a purge at a short interval
+	close(store.done)
+	store.wg.Wait()
+	now := time.Unix(1000, 0)
+	store.clock = func() time.Time { return now }
+
+	require.NoError(t, store.Put(&PreparedTransaction{
+		ChannelId:     "mychannel",
+		TransactionId: "tx1",
+		ExpiresAt:     timestamppb.New(now),
+	}))
+	store.launchExpiryProc(10 * time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		actual, err := store.Get("mychannel", "tx1")
+		return err == nil && actual == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStoreQuotas(t *testing.T) {
+	dbPath := t.TempDir()
+	store, err := NewStore(dbPath, Quotas{MaxTransactions: 3, MaxTransactionsPerIdentity: 2})
+	require.NoError(t, err)
+
+	put := func(txID, storedBy string) error {
+		return store.Put(&PreparedTransaction{
+			ChannelId:     "mychannel",
+			TransactionId: txID,
+			StoredBy:      []byte(storedBy),
+			ExpiresAt:     timestamppb.New(time.Unix(1000, 0)),
+		})
+	}
+
+	require.NoError(t, put("tx1", "client1"))
+	require.NoError(t, put("tx2", "client1"))
+	err = put("tx3", "client1")
+	require.EqualError(t, err, "the maximum of 2 prepared transactions stored by the same client identity is reached")
+	require.Equal(t, &QuotaExceededError{Quota: 2, PerIdentity: true}, err)
+	require.NoError(t, put("tx2", "client1"), "replaced")
+
+	require.NoError(t, put("tx3", "client2"))
+	require.EqualError(t, put("tx4", "client2"), "the maximum of 3 prepared transactions stored is reached")
+
+	// counted across restarts
+	store.Close()
+	store, err = NewStore(dbPath, Quotas{MaxTransactions: 3, MaxTransactionsPerIdentity: 2})
+	require.NoError(t, err)
+	defer store.Close()
+	require.EqualError(t, put("tx4", "client2"), "the maximum of 3 prepared transactions stored is reached")
+
+	require.NoError(t, store.Delete("mychannel", "tx1"))
+	require.NoError(t, put("tx4", "client2"))
+	require.Error(t, put("tx5", "client1"))
+
+	purged, err := store.PurgeExpired(time.Unix(1000, 0))
+	require.NoError(t, err)
+	require.Equal(t, 3, purged)
+	require.NoError(t, put("tx5", "client1"))
+}
@@ -0,0 +1,265 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/prepared"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PreparedTransactionStore persists prepared transactions until they expire.
+type PreparedTransactionStore interface {
+	Put(tx *prepared.PreparedTransaction) error
+	Get(channelID, txID string) (*prepared.PreparedTransaction, error)
+	Delete(channelID, txID string) error
+}
+
+// PreparedTransactionsServer holds prepared transactions while they are reviewed and signed offline, possibly by
+// other clients than the one that endorsed them, and submits them through the gateway once signed.
+type PreparedTransactionsServer struct {
+	gateway *Server
+	store   PreparedTransactionStore
+	options config.PreparedTransactionsOptions
+	now     func() time.Time
+}
+
+// NewPreparedTransactionsServer creates a prepared transactions service that submits transactions through the
+// given gateway.
+func NewPreparedTransactionsServer(gateway *Server, store PreparedTransactionStore, options config.PreparedTransactionsOptions) *PreparedTransactionsServer {
+	return &PreparedTransactionsServer{
+		gateway: gateway,
+		store:   store,
+		options: options,
+		now:     time.Now,
+	}
+}
+
+// Store stores an endorsed transaction under its transaction ID until it expires.
+func (ps *PreparedTransactionsServer) Store(ctx context.Context, signedRequest *prepared.SignedStoreRequest) (*prepared.PreparedTransaction, error) {
+	if signedRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "a store request is required")
+	}
+	request := &prepared.StoreRequest{}
+	if err := proto.Unmarshal(signedRequest.GetRequest(), request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid store request: %v", err)
+	}
+	if err := ps.checkACL(resources.Gateway_StorePreparedTransaction, request.GetChannelId(), signedRequest.GetRequest(), request.GetIdentity(), signedRequest.GetSignature()); err != nil {
+		return nil, err
+	}
+
+	txID, err := preparedTransactionID(request.GetChannelId(), request.GetPreparedTransaction())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid prepared transaction: %v", err)
+	}
+	if !isCreatorOrEndorser(request.GetIdentity(), request.GetPreparedTransaction()) {
+		return nil, status.Error(codes.PermissionDenied, "only the creator or an endorser of the transaction may store it")
+	}
+
+	now := ps.now()
+
+	timeToLive := time.Duration(request.GetTimeToLiveSeconds()) * time.Second
+	if timeToLive == 0 || timeToLive > ps.options.MaxTimeToLive {
+		timeToLive = ps.options.MaxTimeToLive
+	}
+	tx := &prepared.PreparedTransaction{
+		ChannelId:           request.GetChannelId(),
+		TransactionId:       txID,
+		PreparedTransaction: request.GetPreparedTransaction(),
+		StoredBy:            request.GetIdentity(),
+		StoredAt:            timestamppb.New(now),
+		ExpiresAt:           timestamppb.New(now.Add(timeToLive)),
+	}
+	if err := ps.store.Put(tx); err != nil {
+		switch err := err.(type) {
+		case *prepared.AlreadyStoredError:
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case *prepared.QuotaExceededError:
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to store prepared transaction: %v", err)
+	}
+
+	ps.gateway.logger.Infow("Stored prepared transaction", "channel", tx.ChannelId, "txID", txID, "expiresAt", tx.ExpiresAt.AsTime())
+	return tx, nil
+}
+
+// Get returns a stored transaction.
+func (ps *PreparedTransactionsServer) Get(ctx context.Context, signedRequest *prepared.SignedGetRequest) (*prepared.PreparedTransaction, error) {
+	if signedRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "a get request is required")
+	}
+	request := &prepared.GetRequest{}
+	if err := proto.Unmarshal(signedRequest.GetRequest(), request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid get request: %v", err)
+	}
+	if err := ps.checkACL(resources.Gateway_GetPreparedTransaction, request.GetChannelId(), signedRequest.GetRequest(), request.GetIdentity(), signedRequest.GetSignature()); err != nil {
+		return nil, err
+	}
+
+	return ps.load(request.GetChannelId(), request.GetTransactionId())
+}
+
+// Delete discards a stored transaction. Only the client that stored the transaction, or the creator of the
+// transaction, may delete it.
+func (ps *PreparedTransactionsServer) Delete(ctx context.Context, signedRequest *prepared.SignedDeleteRequest) (*prepared.DeleteResponse, error) {
+	if signedRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "a delete request is required")
+	}
+	request := &prepared.DeleteRequest{}
+	if err := proto.Unmarshal(signedRequest.GetRequest(), request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid delete request: %v", err)
+	}
+	if err := ps.checkACL(resources.Gateway_DeletePreparedTransaction, request.GetChannelId(), signedRequest.GetRequest(), request.GetIdentity(), signedRequest.GetSignature()); err != nil {
+		return nil, err
+	}
+
+	tx, err := ps.load(request.GetChannelId(), request.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(request.GetIdentity(), tx.GetStoredBy()) && !bytes.Equal(request.GetIdentity(), envelopeCreator(tx.GetPreparedTransaction())) {
+		return nil, status.Error(codes.PermissionDenied, "only the client that stored the transaction, or the creator of the transaction, may delete it")
+	}
+
+	if err := ps.store.Delete(tx.GetChannelId(), tx.GetTransactionId()); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to delete prepared transaction: %v", err)
+	}
+	return &prepared.DeleteResponse{}, nil
+}
+
+// Submit signs a stored transaction with the given signature of its creator and submits it through the gateway.
+// The transaction is deleted once it is successfully submitted.
+func (ps *PreparedTransactionsServer) Submit(ctx context.Context, request *prepared.SubmitRequest) (*prepared.SubmitResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "a submit request is required")
+	}
+	if len(request.GetSignature()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a signature of the prepared transaction is required")
+	}
+
+	tx, err := ps.load(request.GetChannelId(), request.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := &common.Envelope{
+		Payload:   tx.GetPreparedTransaction().GetPayload(),
+		Signature: request.GetSignature(),
+	}
+	_, err = ps.gateway.Submit(ctx, &gp.SubmitRequest{
+		TransactionId:       tx.GetTransactionId(),
+		ChannelId:           tx.GetChannelId(),
+		PreparedTransaction: envelope,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.store.Delete(tx.GetChannelId(), tx.GetTransactionId()); err != nil {
+		ps.gateway.logger.Warnw("Failed to delete submitted prepared transaction", "channel", tx.GetChannelId(), "txID", tx.GetTransactionId(), "err", err)
+	}
+	return &prepared.SubmitResponse{}, nil
+}
+
+func (ps *PreparedTransactionsServer) checkACL(resource, channelID string, data, identity, signature []byte) error {
+	signedData := &protoutil.SignedData{
+		Data:      data,
+		Identity:  identity,
+		Signature: signature,
+	}
+	if err := ps.gateway.policy.CheckACL(resource, channelID, signedData); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+func (ps *PreparedTransactionsServer) load(channelID, txID string) (*prepared.PreparedTransaction, error) {
+	tx, err := ps.store.Get(channelID, txID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to read prepared transaction store: %v", err)
+	}
+	if tx == nil || prepared.Expired(tx, ps.now()) {
+		return nil, status.Errorf(codes.NotFound, "transaction %s is not stored, or expired", txID)
+	}
+	return tx, nil
+}
+
+// preparedTransactionID returns the transaction ID of an endorsed transaction for the given channel, which must be
+// derived from the nonce and the creator of the transaction.
+func preparedTransactionID(channelID string, envelope *common.Envelope) (string, error) {
+	if envelope == nil {
+		return "", errors.New("a prepared transaction is required")
+	}
+	payload, err := protoutil.UnmarshalPayload(envelope.GetPayload())
+	if err != nil {
+		return "", err
+	}
+	channelHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+	if err != nil {
+		return "", err
+	}
+	if channelHeader.GetType() != int32(common.HeaderType_ENDORSER_TRANSACTION) {
+		return "", errors.New("not an endorser transaction")
+	}
+	if channelHeader.GetChannelId() != channelID {
+		return "", errors.Errorf("transaction is for channel %s", channelHeader.GetChannelId())
+	}
+	if channelHeader.GetTxId() == "" {
+		return "", errors.New("no transaction ID")
+	}
+	signatureHeader, err := protoutil.UnmarshalSignatureHeader(payload.GetHeader().GetSignatureHeader())
+	if err != nil {
+		return "", err
+	}
+	if err := protoutil.CheckTxID(channelHeader.GetTxId(), signatureHeader.GetNonce(), signatureHeader.GetCreator()); err != nil {
+		return "", err
+	}
+	return channelHeader.GetTxId(), nil
+}
+
+// isCreatorOrEndorser returns whether the given identity is the creator or one of the endorsers of a transaction.
+func isCreatorOrEndorser(identity []byte, envelope *common.Envelope) bool {
+	if len(identity) == 0 {
+		return false
+	}
+	if bytes.Equal(identity, envelopeCreator(envelope)) {
+		return true
+	}
+	payload, err := protoutil.UnmarshalPayload(envelope.GetPayload())
+	if err != nil {
+		return false
+	}
+	transaction, err := protoutil.UnmarshalTransaction(payload.GetData())
+	if err != nil {
+		return false
+	}
+	for _, action := range transaction.GetActions() {
+		actionPayload, err := protoutil.UnmarshalChaincodeActionPayload(action.GetPayload())
+		if err != nil {
+			return false
+		}
+		for _, endorsement := range actionPayload.GetAction().GetEndorsements() {
+			if bytes.Equal(identity, endorsement.GetEndorser()) {
+				return true
+			}
+		}
+	}
+	return false
+}
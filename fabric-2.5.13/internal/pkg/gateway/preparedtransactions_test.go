@@ -0,0 +1,215 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cp "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/prepared"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type preparedTransactionsTest struct {
+	*preparedTest
+	server     *PreparedTransactionsServer
+	preparedTx *cp.Envelope
+	now        time.Time
+}
+
+func prepareTransactionsTest(t *testing.T) *preparedTransactionsTest {
+	test := prepareTest(t, &testDef{
+		plan: endorsementPlan{
+			"g1": {{endorser: localhostMock, height: 3}},
+		},
+	})
+	// the transaction is created by client1 and endorsed by localhost:7051
+	proposal, _, err := protoutil.CreateChaincodeProposal(cp.HeaderType_ENDORSER_TRANSACTION, testChannel,
+		&peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: &peer.ChaincodeID{Name: testChaincode}}}, []byte("client1"))
+	require.NoError(t, err)
+	signedProposal := &peer.SignedProposal{ProposalBytes: protoutil.MarshalOrPanic(proposal), Signature: []byte("signature")}
+	endorseResponse, err := test.server.Endorse(test.ctx, &pb.EndorseRequest{ProposedTransaction: signedProposal})
+	require.NoError(t, err)
+
+	store, err := prepared.NewStore(t.TempDir(), prepared.Quotas{MaxTransactionsPerIdentity: 1})
+	require.NoError(t, err)
+	t.Cleanup(store.Close)
+
+	pt := &preparedTransactionsTest{
+		preparedTest: test,
+		server:       NewPreparedTransactionsServer(test.server, store, config.PreparedTransactionsOptions{Enabled: true, MaxTimeToLive: time.Hour}),
+		preparedTx:   endorseResponse.GetPreparedTransaction(),
+		now:          time.Unix(1000, 0),
+	}
+	pt.server.now = func() time.Time { return pt.now }
+	return pt
+}
+
+func (pt *preparedTransactionsTest) txID(t *testing.T) string {
+	payload, err := protoutil.UnmarshalPayload(pt.preparedTx.GetPayload())
+	require.NoError(t, err)
+	channelHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+	require.NoError(t, err)
+	return channelHeader.GetTxId()
+}
+
+func (pt *preparedTransactionsTest) store(t *testing.T, identity string, ttl uint64) (*prepared.PreparedTransaction, error) {
+	request := &prepared.StoreRequest{
+		Identity:            []byte(identity),
+		ChannelId:           testChannel,
+		PreparedTransaction: pt.preparedTx,
+		TimeToLiveSeconds:   ttl,
+	}
+	return pt.server.Store(pt.ctx, &prepared.SignedStoreRequest{Request: protoutil.MarshalOrPanic(request), Signature: []byte("signature")})
+}
+
+func (pt *preparedTransactionsTest) get(t *testing.T, identity string) (*prepared.PreparedTransaction, error) {
+	request := &prepared.GetRequest{Identity: []byte(identity), ChannelId: testChannel, TransactionId: pt.txID(t)}
+	return pt.server.Get(pt.ctx, &prepared.SignedGetRequest{Request: protoutil.MarshalOrPanic(request), Signature: []byte("signature")})
+}
+
+func (pt *preparedTransactionsTest) delete(t *testing.T, identity string) error {
+	request := &prepared.DeleteRequest{Identity: []byte(identity), ChannelId: testChannel, TransactionId: pt.txID(t)}
+	_, err := pt.server.Delete(pt.ctx, &prepared.SignedDeleteRequest{Request: protoutil.MarshalOrPanic(request), Signature: []byte("signature")})
+	return err
+}
+
+func TestPreparedTransactionsStoreAndSubmit(t *testing.T) {
+	test := prepareTransactionsTest(t)
+
+	stored, err := test.store(t, "client1", 0)
+	require.NoError(t, err)
+	require.Equal(t, test.txID(t), stored.GetTransactionId())
+	require.Equal(t, []byte("client1"), stored.GetStoredBy())
+	require.True(t, test.now.Add(time.Hour).Equal(stored.GetExpiresAt().AsTime()), "maximum time to live")
+
+	resource, channel, data := test.policy.CheckACLArgsForCall(test.policy.CheckACLCallCount() - 1)
+	require.Equal(t, resources.Gateway_StorePreparedTransaction, resource)
+	require.Equal(t, testChannel, channel)
+	require.Equal(t, []byte("client1"), data.(*protoutil.SignedData).Identity)
+
+	_, err = test.store(t, "client1", 0)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	fetched, err := test.get(t, "client2")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(stored, fetched))
+
+	_, err = test.server.Submit(test.ctx, &prepared.SubmitRequest{ChannelId: testChannel, TransactionId: test.txID(t), Signature: []byte("mysignature")})
+	require.NoError(t, err)
+
+	_, err = test.get(t, "client2")
+	require.Equal(t, codes.NotFound, status.Code(err), "deleted once submitted")
+}
+
+func TestPreparedTransactionsExpiry(t *testing.T) {
+	test := prepareTransactionsTest(t)
+
+	stored, err := test.store(t, "client1", 60)
+	require.NoError(t, err)
+	require.True(t, test.now.Add(time.Minute).Equal(stored.GetExpiresAt().AsTime()))
+
+	test.now = test.now.Add(time.Minute)
+	_, err = test.get(t, "client1")
+	require.EqualError(t, err, fmt.Sprintf("rpc error: code = NotFound desc = transaction %s is not stored, or expired", test.txID(t)))
+
+	_, err = test.store(t, "client1", 60)
+	require.NoError(t, err, "expired transaction replaced")
+}
+
+func TestPreparedTransactionsQuota(t *testing.T) {
+	test := prepareTransactionsTest(t)
+
+	err := test.server.store.Put(&prepared.PreparedTransaction{ChannelId: testChannel, TransactionId: "other", StoredBy: []byte("client1")})
+	require.NoError(t, err)
+
+	_, err = test.store(t, "client1", 0)
+	require.EqualError(t, err, "rpc error: code = ResourceExhausted desc = the maximum of 1 prepared transactions stored by the same client identity is reached")
+
+	_, err = test.store(t, "localhost:7051", 0)
+	require.NoError(t, err)
+}
+
+func TestPreparedTransactionsStoreChecks(t *testing.T) {
+	test := prepareTransactionsTest(t)
+
+	_, err := test.store(t, "client2", 0)
+	require.EqualError(t, err, "rpc error: code = PermissionDenied desc = only the creator or an endorser of the transaction may store it")
+
+	// the endorser may store the transaction
+	_, err = test.store(t, "localhost:7051", 0)
+	require.NoError(t, err)
+
+	// the transaction ID must be derived from the nonce and the creator
+	payload, err := protoutil.UnmarshalPayload(test.preparedTx.GetPayload())
+	require.NoError(t, err)
+	channelHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+	require.NoError(t, err)
+	channelHeader.TxId = "forged"
+	payload.Header.ChannelHeader = protoutil.MarshalOrPanic(channelHeader)
+	test.preparedTx = &cp.Envelope{Payload: protoutil.MarshalOrPanic(payload), Signature: test.preparedTx.GetSignature()}
+	_, err = test.store(t, "client1", 0)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, err.Error(), "invalid prepared transaction: invalid txid")
+}
+
+func TestPreparedTransactionsDelete(t *testing.T) {
+	test := prepareTransactionsTest(t)
+
+	_, err := test.store(t, "client1", 0)
+	require.NoError(t, err)
+
+	err = test.delete(t, "client2")
+	require.EqualError(t, err, "rpc error: code = PermissionDenied desc = only the client that stored the transaction, or the creator of the transaction, may delete it")
+
+	require.NoError(t, test.delete(t, "client1"))
+
+	err = test.delete(t, "client1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestPreparedTransactionsErrors(t *testing.T) {
+	test := prepareTransactionsTest(t)
+
+	_, err := test.server.Store(test.ctx, nil)
+	require.EqualError(t, err, "rpc error: code = InvalidArgument desc = a store request is required")
+
+	_, err = test.server.Store(test.ctx, &prepared.SignedStoreRequest{Request: []byte("garbage")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	request := &prepared.StoreRequest{ChannelId: "other_channel", PreparedTransaction: test.preparedTx}
+	_, err = test.server.Store(test.ctx, &prepared.SignedStoreRequest{Request: protoutil.MarshalOrPanic(request)})
+	require.EqualError(t, err, "rpc error: code = InvalidArgument desc = invalid prepared transaction: transaction is for channel test_channel")
+
+	request = &prepared.StoreRequest{ChannelId: testChannel}
+	_, err = test.server.Store(test.ctx, &prepared.SignedStoreRequest{Request: protoutil.MarshalOrPanic(request)})
+	require.EqualError(t, err, "rpc error: code = InvalidArgument desc = invalid prepared transaction: a prepared transaction is required")
+
+	_, err = test.server.Submit(test.ctx, &prepared.SubmitRequest{ChannelId: testChannel, TransactionId: test.txID(t)})
+	require.EqualError(t, err, "rpc error: code = InvalidArgument desc = a signature of the prepared transaction is required")
+
+	_, err = test.server.Submit(test.ctx, &prepared.SubmitRequest{ChannelId: testChannel, TransactionId: test.txID(t), Signature: []byte("mysignature")})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	test.policy.CheckACLReturns(fmt.Errorf("access denied"))
+	_, err = test.store(t, "client1", 0)
+	require.EqualError(t, err, "rpc error: code = PermissionDenied desc = access denied")
+	_, err = test.get(t, "client1")
+	require.EqualError(t, err, "rpc error: code = PermissionDenied desc = access denied")
+	err = test.delete(t, "client1")
+	require.EqualError(t, err, "rpc error: code = PermissionDenied desc = access denied")
+}
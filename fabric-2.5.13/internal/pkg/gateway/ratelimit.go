@@ -0,0 +1,280 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	corepeer "github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	rateLimit        = "rate"
+	concurrencyLimit = "concurrency"
+
+	// concurrencyRetryAfter is the retry hint given to requests that exceed a concurrency limit, as the time until
+	// a request in progress completes is not known.
+	concurrencyRetryAfter = time.Second
+	// pruneInterval is the interval at which the limits of idle keys are discarded.
+	pruneInterval = time.Minute
+	// unknownMSPID is the MSP ID reported for the requests whose signature can't be verified with the MSPs of
+	// the channel.
+	unknownMSPID = "unknown"
+)
+
+// requestLimit is the state of the limits of a single MSP ID or client identity.
+type requestLimit struct {
+	tokens   float64
+	last     time.Time
+	inflight int
+}
+
+// requestLimiter applies a token bucket rate limit, which holds up to burst tokens, and a limit on the concurrent
+// requests to the requests of each key.
+type requestLimiter struct {
+	options config.RequestLimitOptions
+	burst   float64
+
+	lock      sync.Mutex
+	limits    map[string]*requestLimit
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+func newRequestLimiter(options config.RequestLimitOptions) *requestLimiter {
+	burst := float64(options.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(options.RequestsPerSecond))
+	}
+	return &requestLimiter{
+		options:   options,
+		burst:     burst,
+		limits:    map[string]*requestLimit{},
+		lastPrune: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (l *requestLimiter) enabled() bool {
+	return l.options.RequestsPerSecond > 0 || l.options.Concurrency > 0
+}
+
+// acquire takes a request from the limits of the key. If a limit is exceeded, the name of the limit and the time
+// after which the request may be retried are returned, and release must not be called.
+func (l *requestLimiter) acquire(key string) (exceeded string, retryAfter time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastPrune) >= pruneInterval {
+		l.prune(now)
+	}
+
+	limit, ok := l.limits[key]
+	if !ok {
+		limit = &requestLimit{tokens: l.burst, last: now}
+		l.limits[key] = limit
+	}
+	l.refill(limit, now)
+
+	if l.options.Concurrency > 0 && limit.inflight >= l.options.Concurrency {
+		return concurrencyLimit, concurrencyRetryAfter
+	}
+	if l.options.RequestsPerSecond > 0 {
+		if limit.tokens < 1 {
+			return rateLimit, time.Duration((1 - limit.tokens) / l.options.RequestsPerSecond * float64(time.Second))
+		}
+		limit.tokens--
+	}
+	limit.inflight++
+	return "", 0
+}
+
+// release returns a request acquired for the key once it completes.
+func (l *requestLimiter) release(key string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if limit, ok := l.limits[key]; ok && limit.inflight > 0 {
+		limit.inflight--
+	}
+}
+
+func (l *requestLimiter) refill(limit *requestLimit, now time.Time) {
+	limit.tokens = math.Min(l.burst, limit.tokens+now.Sub(limit.last).Seconds()*l.options.RequestsPerSecond)
+	limit.last = now
+}
+
+// prune discards the limits of keys with no requests in progress and a full token bucket, which are the same as
+// the limits of a new key.
+func (l *requestLimiter) prune(now time.Time) {
+	for key, limit := range l.limits {
+		l.refill(limit, now)
+		if limit.inflight == 0 && limit.tokens >= l.burst {
+			delete(l.limits, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+// requestLimits applies the rate limits and concurrency limits of the MSP ID and client identity that created
+// a request.
+type requestLimits struct {
+	perMSP        *requestLimiter
+	perClient     *requestLimiter
+	deserializers corepeer.IdentityDeserializerManager
+	metrics       *Metrics
+}
+
+func newRequestLimits(options config.RateLimitOptions, deserializers corepeer.IdentityDeserializerManager, metrics *Metrics) *requestLimits {
+	return &requestLimits{
+		perMSP:        newRequestLimiter(options.PerMSP),
+		perClient:     newRequestLimiter(options.PerClient),
+		deserializers: deserializers,
+		metrics:       metrics,
+	}
+}
+
+// acquire takes a request signed by a client of the channel from the limits of its MSP ID and of its identity.
+// The MSP ID and the identity are only trusted once the signature is verified with the MSPs of the channel; the
+// requests whose signature can't be verified are all taken from the limits of a single unknown MSP, which
+// doesn't affect the limits of the MSPs of the channel, and are rejected later on. The returned function
+// releases the request once it completes. A RESOURCE_EXHAUSTED error is returned if a limit is exceeded, and
+// the time after which the request may be retried is set in the retry-after header.
+func (rl *requestLimits) acquire(ctx context.Context, method, channel string, signedData *protoutil.SignedData) (func(), error) {
+	if !rl.perMSP.enabled() && !rl.perClient.enabled() {
+		return func() {}, nil
+	}
+
+	mspid, authenticated := rl.authenticate(channel, signedData)
+	hash := sha256.Sum256(signedData.Identity)
+	client := string(hash[:])
+
+	if rl.perMSP.enabled() {
+		if exceeded, retryAfter := rl.perMSP.acquire(mspid); exceeded != "" {
+			return nil, rl.throttled(ctx, method, mspid, "msp", exceeded, retryAfter)
+		}
+	}
+	limitClient := authenticated && rl.perClient.enabled()
+	if limitClient {
+		if exceeded, retryAfter := rl.perClient.acquire(client); exceeded != "" {
+			if rl.perMSP.enabled() {
+				rl.perMSP.release(mspid)
+			}
+			return nil, rl.throttled(ctx, method, mspid, "client", exceeded, retryAfter)
+		}
+	}
+
+	return func() {
+		if rl.perMSP.enabled() {
+			rl.perMSP.release(mspid)
+		}
+		if limitClient {
+			rl.perClient.release(client)
+		}
+	}, nil
+}
+
+// authenticate returns the MSP ID of the identity that signed the data, and whether the signature could be
+// verified with the MSPs of the channel. An empty MSP ID, which no MSP has, is returned if it couldn't.
+func (rl *requestLimits) authenticate(channel string, signedData *protoutil.SignedData) (string, bool) {
+	if len(signedData.Identity) == 0 {
+		return "", false
+	}
+	deserializer, err := rl.deserializers.Deserializer(channel)
+	if err != nil {
+		return "", false
+	}
+	identity, err := deserializer.DeserializeIdentity(signedData.Identity)
+	if err != nil {
+		return "", false
+	}
+	if err := identity.Verify(signedData.Data, signedData.Signature); err != nil {
+		return "", false
+	}
+	return identity.GetMSPIdentifier(), true
+}
+
+func (rl *requestLimits) throttled(ctx context.Context, method, mspid, scope, limit string, retryAfter time.Duration) error {
+	if mspid == "" {
+		mspid = unknownMSPID
+	}
+	rl.metrics.ThrottledRequests.With("method", method, "mspid", mspid, "scope", scope, "limit", limit).Add(1)
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	// Not available outside of a gRPC request, in which case the hint is only given in the error message
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(seconds)))
+
+	subject := "MSP ID " + mspid
+	if scope == "client" {
+		subject = "client identity of MSP ID " + mspid
+	}
+	return status.Errorf(codes.ResourceExhausted, "%s limit exceeded for %s, retry after %ds", limit, subject, seconds)
+}
+
+// proposalSignedData returns the data signed by the creator of the signed proposal.
+func proposalSignedData(signedProposal *peer.SignedProposal) *protoutil.SignedData {
+	return &protoutil.SignedData{
+		Data:      signedProposal.GetProposalBytes(),
+		Identity:  proposalCreator(signedProposal),
+		Signature: signedProposal.GetSignature(),
+	}
+}
+
+// envelopeSignedData returns the data signed by the creator of the transaction envelope.
+func envelopeSignedData(envelope *common.Envelope) *protoutil.SignedData {
+	return &protoutil.SignedData{
+		Data:      envelope.GetPayload(),
+		Identity:  envelopeCreator(envelope),
+		Signature: envelope.GetSignature(),
+	}
+}
+
+// proposalCreator returns the serialized identity that created the signed proposal, or nil if the proposal can't
+// be unpacked.
+func proposalCreator(signedProposal *peer.SignedProposal) []byte {
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.GetProposalBytes())
+	if err != nil {
+		return nil
+	}
+	header, err := protoutil.UnmarshalHeader(proposal.GetHeader())
+	if err != nil {
+		return nil
+	}
+	signatureHeader, err := protoutil.UnmarshalSignatureHeader(header.GetSignatureHeader())
+	if err != nil {
+		return nil
+	}
+	return signatureHeader.GetCreator()
+}
+
+// envelopeCreator returns the serialized identity that created the transaction envelope, or nil if the envelope
+// can't be unpacked.
+func envelopeCreator(envelope *common.Envelope) []byte {
+	payload, err := protoutil.UnmarshalPayload(envelope.GetPayload())
+	if err != nil {
+		return nil
+	}
+	signatureHeader, err := protoutil.UnmarshalSignatureHeader(payload.GetHeader().GetSignatureHeader())
+	if err != nil {
+		return nil
+	}
+	return signatureHeader.GetCreator()
+}
@@ -0,0 +1,264 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cp "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	peermocks "github.com/hyperledger/fabric/core/peer/mock"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
+	fabricmsp "github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestLimiterRate(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := newRequestLimiter(config.RequestLimitOptions{RequestsPerSecond: 2, Burst: 3})
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		exceeded, _ := limiter.acquire("msp1")
+		require.Empty(t, exceeded, "request %d within burst", i)
+		limiter.release("msp1")
+	}
+
+	exceeded, retryAfter := limiter.acquire("msp1")
+	require.Equal(t, rateLimit, exceeded)
+	require.Equal(t, 500*time.Millisecond, retryAfter)
+
+	exceeded, _ = limiter.acquire("msp2")
+	require.Empty(t, exceeded, "keys are limited separately")
+	limiter.release("msp2")
+
+	now = now.Add(500 * time.Millisecond)
+	exceeded, _ = limiter.acquire("msp1")
+	require.Empty(t, exceeded, "token refilled")
+	limiter.release("msp1")
+
+	exceeded, _ = limiter.acquire("msp1")
+	require.Equal(t, rateLimit, exceeded)
+}
+
+func TestRequestLimiterDefaultBurst(t *testing.T) {
+	limiter := newRequestLimiter(config.RequestLimitOptions{RequestsPerSecond: 0.5})
+	require.Equal(t, float64(1), limiter.burst)
+
+	limiter = newRequestLimiter(config.RequestLimitOptions{RequestsPerSecond: 10})
+	require.Equal(t, float64(10), limiter.burst)
+}
+
+func TestRequestLimiterConcurrency(t *testing.T) {
+	limiter := newRequestLimiter(config.RequestLimitOptions{Concurrency: 2})
+
+	exceeded, _ := limiter.acquire("client1")
+	require.Empty(t, exceeded)
+	exceeded, _ = limiter.acquire("client1")
+	require.Empty(t, exceeded)
+
+	exceeded, retryAfter := limiter.acquire("client1")
+	require.Equal(t, concurrencyLimit, exceeded)
+	require.Equal(t, concurrencyRetryAfter, retryAfter)
+
+	limiter.release("client1")
+	exceeded, _ = limiter.acquire("client1")
+	require.Empty(t, exceeded)
+}
+
+func TestRequestLimiterPrune(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := newRequestLimiter(config.RequestLimitOptions{RequestsPerSecond: 1, Concurrency: 1})
+	limiter.now = func() time.Time { return now }
+	limiter.lastPrune = now
+
+	limiter.acquire("idle")
+	limiter.release("idle")
+	limiter.acquire("busy")
+	require.Len(t, limiter.limits, 2)
+
+	now = now.Add(pruneInterval)
+	limiter.acquire("new")
+	require.Len(t, limiter.limits, 2)
+	require.Contains(t, limiter.limits, "busy")
+	require.Contains(t, limiter.limits, "new")
+}
+
+//go:generate counterfeiter -o mocks/identitydeserializer.go --fake-name IdentityDeserializer . identityDeserializer
+type identityDeserializer interface {
+	fabricmsp.IdentityDeserializer
+}
+
+//go:generate counterfeiter -o mocks/identity.go --fake-name Identity . identity
+type identity interface {
+	fabricmsp.Identity
+}
+
+// newDeserializers returns deserializers of the test channel for which the identity is of the MSP ID set in the
+// serialized identity, and the signature is valid if it is "signature".
+func newDeserializers() *peermocks.IdentityDeserializerManager {
+	deserializer := &mocks.IdentityDeserializer{}
+	deserializer.DeserializeIdentityCalls(func(serialized []byte) (fabricmsp.Identity, error) {
+		sid, err := protoutil.UnmarshalSerializedIdentity(serialized)
+		if err != nil {
+			return nil, err
+		}
+		identity := &mocks.Identity{}
+		identity.GetMSPIdentifierReturns(sid.GetMspid())
+		identity.VerifyCalls(func(_, signature []byte) error {
+			if string(signature) != "signature" {
+				return errors.New("invalid signature")
+			}
+			return nil
+		})
+		return identity, nil
+	})
+	deserializers := &peermocks.IdentityDeserializerManager{}
+	deserializers.DeserializerCalls(func(channel string) (fabricmsp.IdentityDeserializer, error) {
+		if channel != testChannel {
+			return nil, errors.Errorf("channel %s not found", channel)
+		}
+		return deserializer, nil
+	})
+	return deserializers
+}
+
+func signedBy(creator []byte, signature string) *protoutil.SignedData {
+	return &protoutil.SignedData{Data: []byte("data"), Identity: creator, Signature: []byte(signature)}
+}
+
+func TestRequestLimits(t *testing.T) {
+	creator1 := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "msp1", IdBytes: []byte("client1")})
+	creator2 := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "msp1", IdBytes: []byte("client2")})
+
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	provider := &metricsfakes.Provider{}
+	provider.NewCounterReturns(fakeCounter)
+
+	limits := newRequestLimits(config.RateLimitOptions{
+		PerMSP:    config.RequestLimitOptions{Concurrency: 2},
+		PerClient: config.RequestLimitOptions{Concurrency: 1},
+	}, newDeserializers(), NewMetrics(provider))
+	ctx := context.Background()
+
+	release1, err := limits.acquire(ctx, "Evaluate", testChannel, signedBy(creator1, "signature"))
+	require.NoError(t, err)
+
+	_, err = limits.acquire(ctx, "Evaluate", testChannel, signedBy(creator1, "signature"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.EqualError(t, err, "rpc error: code = ResourceExhausted desc = concurrency limit exceeded for client identity of MSP ID msp1, retry after 1s")
+	require.Equal(t, 1, fakeCounter.AddCallCount())
+	require.Equal(t, []string{"method", "Evaluate", "mspid", "msp1", "scope", "client", "limit", "concurrency"}, fakeCounter.WithArgsForCall(0))
+
+	release2, err := limits.acquire(ctx, "Endorse", testChannel, signedBy(creator2, "signature"))
+	require.NoError(t, err, "the MSP request of the throttled client was released")
+
+	_, err = limits.acquire(ctx, "Submit", testChannel, signedBy(creator2, "signature"))
+	require.EqualError(t, err, "rpc error: code = ResourceExhausted desc = concurrency limit exceeded for MSP ID msp1, retry after 1s")
+	require.Equal(t, []string{"method", "Submit", "mspid", "msp1", "scope", "msp", "limit", "concurrency"}, fakeCounter.WithArgsForCall(1))
+
+	release1()
+	release2()
+	release, err := limits.acquire(ctx, "Evaluate", testChannel, signedBy(creator1, "signature"))
+	require.NoError(t, err)
+	release()
+}
+
+func TestRequestLimitsUnauthenticated(t *testing.T) {
+	creator1 := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "msp1", IdBytes: []byte("client1")})
+	creator2 := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "msp1", IdBytes: []byte("client2")})
+
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	provider := &metricsfakes.Provider{}
+	provider.NewCounterReturns(fakeCounter)
+
+	limits := newRequestLimits(config.RateLimitOptions{
+		PerMSP:    config.RequestLimitOptions{Concurrency: 1},
+		PerClient: config.RequestLimitOptions{Concurrency: 1},
+	}, newDeserializers(), NewMetrics(provider))
+	ctx := context.Background()
+
+	// A forged request claiming the identity of client1 is not charged to msp1 nor to client1
+	release, err := limits.acquire(ctx, "Evaluate", testChannel, signedBy(creator1, "forged"))
+	require.NoError(t, err)
+	_, err = limits.acquire(ctx, "Evaluate", testChannel, signedBy(creator2, "forged"))
+	require.EqualError(t, err, "rpc error: code = ResourceExhausted desc = concurrency limit exceeded for MSP ID unknown, retry after 1s")
+	require.Equal(t, []string{"method", "Evaluate", "mspid", "unknown", "scope", "msp", "limit", "concurrency"}, fakeCounter.WithArgsForCall(0))
+
+	authenticated, err := limits.acquire(ctx, "Evaluate", testChannel, signedBy(creator1, "signature"))
+	require.NoError(t, err)
+	authenticated()
+
+	// So are the requests of unknown channels and the requests with no creator
+	_, err = limits.acquire(ctx, "Evaluate", "unknown-channel", signedBy(creator1, "signature"))
+	require.EqualError(t, err, "rpc error: code = ResourceExhausted desc = concurrency limit exceeded for MSP ID unknown, retry after 1s")
+	_, err = limits.acquire(ctx, "Evaluate", testChannel, signedBy(nil, "signature"))
+	require.EqualError(t, err, "rpc error: code = ResourceExhausted desc = concurrency limit exceeded for MSP ID unknown, retry after 1s")
+
+	release()
+	release, err = limits.acquire(ctx, "Evaluate", testChannel, signedBy([]byte("garbage"), "signature"))
+	require.NoError(t, err)
+	release()
+}
+
+func TestRequestCreator(t *testing.T) {
+	creator := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "msp1", IdBytes: []byte("client1")})
+	signedProposal := createCreatorSignedProposal(t, creator)
+	require.Equal(t, creator, proposalCreator(signedProposal))
+	require.Nil(t, proposalCreator(&peer.SignedProposal{ProposalBytes: []byte("garbage")}))
+
+	payload := &cp.Payload{
+		Header: &cp.Header{
+			SignatureHeader: protoutil.MarshalOrPanic(&cp.SignatureHeader{Creator: creator}),
+		},
+	}
+	envelope := &cp.Envelope{Payload: protoutil.MarshalOrPanic(payload)}
+	require.Equal(t, creator, envelopeCreator(envelope))
+	require.Nil(t, envelopeCreator(&cp.Envelope{Payload: []byte("garbage")}))
+}
+
+func TestEvaluateRateLimit(t *testing.T) {
+	test := prepareTest(t, &testDef{localResponse: "lr"})
+	test.server.limits = newRequestLimits(config.RateLimitOptions{
+		PerClient: config.RequestLimitOptions{RequestsPerSecond: 0.1},
+	}, newDeserializers(), NewMetrics(&disabled.Provider{}))
+
+	creator := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "msp1", IdBytes: []byte("client1")})
+	request := &pb.EvaluateRequest{ProposedTransaction: createCreatorSignedProposal(t, creator)}
+
+	response, err := test.server.Evaluate(test.ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, []byte("lr"), response.GetResult().GetPayload())
+
+	_, err = test.server.Evaluate(test.ctx, request)
+	require.EqualError(t, err, "rpc error: code = ResourceExhausted desc = rate limit exceeded for client identity of MSP ID msp1, retry after 10s")
+}
+
+func createCreatorSignedProposal(t *testing.T, creator []byte) *peer.SignedProposal {
+	invocationSpec := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_NODE,
+			ChaincodeId: &peer.ChaincodeID{Name: testChaincode},
+		},
+	}
+	proposal, _, err := protoutil.CreateChaincodeProposal(cp.HeaderType_ENDORSER_TRANSACTION, testChannel, invocationSpec, creator)
+	require.NoError(t, err)
+	return &peer.SignedProposal{ProposalBytes: protoutil.MarshalOrPanic(proposal), Signature: []byte("signature")}
+}
@@ -9,7 +9,6 @@ package gateway
 import (
 	"fmt"
 	"math/rand/v2"
-	"sort"
 	"strings"
 	"sync"
 
@@ -46,12 +45,15 @@ type registry struct {
 	channelOrderers    sync.Map // channel (string) -> orderer addresses (endpointConfig)
 	systemChaincodes   scc.BuiltinSCCs
 	localProvider      ledger.Provider
+	scores             *endorserScores
 }
 
 type endorserState struct {
 	peer     *dp.Peer
 	endorser *endorser
 	height   uint64
+	latency  float64
+	open     bool
 }
 
 // Returns an endorsementPlan for the given chaincode on a channel.
@@ -102,8 +104,8 @@ func (reg *registry) endorsementPlan(channel string, interest *peer.ChaincodeInt
 			}
 			groupPeers = append(groupPeers, &endorserState{peer: peer, endorser: endorser, height: height})
 		}
-		// sort by decreasing height
-		sort.Slice(groupPeers, sorter(groupPeers, reg.localEndorser.address))
+		// sort by the endorser selection strategy, which is by decreasing height by default
+		reg.sortEndorsers(groupPeers, reg.localEndorser.address)
 
 		if len(groupPeers) > 0 {
 			var endorsers []*endorser
@@ -195,7 +197,7 @@ func (reg *registry) endorsersByOrg(channel string, chaincode string) map[string
 
 	// sort by decreasing height in each org
 	for _, es := range endorsersByOrg {
-		sort.Slice(es, sorter(es, reg.localEndorser.address))
+		reg.sortEndorsers(es, reg.localEndorser.address)
 	}
 
 	return endorsersByOrg
@@ -261,8 +263,8 @@ func (reg *registry) evaluator(channel string, chaincode string, targetOrgs []st
 			}
 		}
 	}
-	// sort all the 'other orgs' endorsers by decreasing block height
-	sort.Slice(otherOrgEndorsers, sorter(otherOrgEndorsers, ""))
+	// sort all the 'other orgs' endorsers by the endorser selection strategy
+	reg.sortEndorsers(otherOrgEndorsers, "")
 
 	var allEndorsers []*endorser
 	for _, e := range append(localOrgEndorsers, otherOrgEndorsers...) {
@@ -276,16 +278,6 @@ func (reg *registry) evaluator(channel string, chaincode string, targetOrgs []st
 	return nil, fmt.Errorf("no peers available to evaluate chaincode %s in channel %s", chaincode, channel)
 }
 
-func sorter(e []*endorserState, host string) func(i, j int) bool {
-	return func(i, j int) bool {
-		if e[i].height == e[j].height {
-			// prefer host peer
-			return e[i].endorser.address == host
-		}
-		return e[i].height > e[j].height
-	}
-}
-
 // Returns a set of broadcastClients that can order a transaction for the given channel.
 func (reg *registry) orderers(channel string) ([]*orderer, error) {
 	var orderers []*orderer
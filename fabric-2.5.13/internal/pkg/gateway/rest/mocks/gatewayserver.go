@@ -0,0 +1,438 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/gateway"
+)
+
+type GatewayServer struct {
+	ChaincodeEventsStub        func(*gateway.SignedChaincodeEventsRequest, gateway.Gateway_ChaincodeEventsServer) error
+	chaincodeEventsMutex       sync.RWMutex
+	chaincodeEventsArgsForCall []struct {
+		arg1 *gateway.SignedChaincodeEventsRequest
+		arg2 gateway.Gateway_ChaincodeEventsServer
+	}
+	chaincodeEventsReturns struct {
+		result1 error
+	}
+	chaincodeEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CommitStatusStub        func(context.Context, *gateway.SignedCommitStatusRequest) (*gateway.CommitStatusResponse, error)
+	commitStatusMutex       sync.RWMutex
+	commitStatusArgsForCall []struct {
+		arg1 context.Context
+		arg2 *gateway.SignedCommitStatusRequest
+	}
+	commitStatusReturns struct {
+		result1 *gateway.CommitStatusResponse
+		result2 error
+	}
+	commitStatusReturnsOnCall map[int]struct {
+		result1 *gateway.CommitStatusResponse
+		result2 error
+	}
+	EndorseStub        func(context.Context, *gateway.EndorseRequest) (*gateway.EndorseResponse, error)
+	endorseMutex       sync.RWMutex
+	endorseArgsForCall []struct {
+		arg1 context.Context
+		arg2 *gateway.EndorseRequest
+	}
+	endorseReturns struct {
+		result1 *gateway.EndorseResponse
+		result2 error
+	}
+	endorseReturnsOnCall map[int]struct {
+		result1 *gateway.EndorseResponse
+		result2 error
+	}
+	EvaluateStub        func(context.Context, *gateway.EvaluateRequest) (*gateway.EvaluateResponse, error)
+	evaluateMutex       sync.RWMutex
+	evaluateArgsForCall []struct {
+		arg1 context.Context
+		arg2 *gateway.EvaluateRequest
+	}
+	evaluateReturns struct {
+		result1 *gateway.EvaluateResponse
+		result2 error
+	}
+	evaluateReturnsOnCall map[int]struct {
+		result1 *gateway.EvaluateResponse
+		result2 error
+	}
+	SubmitStub        func(context.Context, *gateway.SubmitRequest) (*gateway.SubmitResponse, error)
+	submitMutex       sync.RWMutex
+	submitArgsForCall []struct {
+		arg1 context.Context
+		arg2 *gateway.SubmitRequest
+	}
+	submitReturns struct {
+		result1 *gateway.SubmitResponse
+		result2 error
+	}
+	submitReturnsOnCall map[int]struct {
+		result1 *gateway.SubmitResponse
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *GatewayServer) ChaincodeEvents(arg1 *gateway.SignedChaincodeEventsRequest, arg2 gateway.Gateway_ChaincodeEventsServer) error {
+	fake.chaincodeEventsMutex.Lock()
+	ret, specificReturn := fake.chaincodeEventsReturnsOnCall[len(fake.chaincodeEventsArgsForCall)]
+	fake.chaincodeEventsArgsForCall = append(fake.chaincodeEventsArgsForCall, struct {
+		arg1 *gateway.SignedChaincodeEventsRequest
+		arg2 gateway.Gateway_ChaincodeEventsServer
+	}{arg1, arg2})
+	stub := fake.ChaincodeEventsStub
+	fakeReturns := fake.chaincodeEventsReturns
+	fake.recordInvocation("ChaincodeEvents", []interface{}{arg1, arg2})
+	fake.chaincodeEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *GatewayServer) ChaincodeEventsCallCount() int {
+	fake.chaincodeEventsMutex.RLock()
+	defer fake.chaincodeEventsMutex.RUnlock()
+	return len(fake.chaincodeEventsArgsForCall)
+}
+
+func (fake *GatewayServer) ChaincodeEventsCalls(stub func(*gateway.SignedChaincodeEventsRequest, gateway.Gateway_ChaincodeEventsServer) error) {
+	fake.chaincodeEventsMutex.Lock()
+	defer fake.chaincodeEventsMutex.Unlock()
+	fake.ChaincodeEventsStub = stub
+}
+
+func (fake *GatewayServer) ChaincodeEventsArgsForCall(i int) (*gateway.SignedChaincodeEventsRequest, gateway.Gateway_ChaincodeEventsServer) {
+	fake.chaincodeEventsMutex.RLock()
+	defer fake.chaincodeEventsMutex.RUnlock()
+	argsForCall := fake.chaincodeEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *GatewayServer) ChaincodeEventsReturns(result1 error) {
+	fake.chaincodeEventsMutex.Lock()
+	defer fake.chaincodeEventsMutex.Unlock()
+	fake.ChaincodeEventsStub = nil
+	fake.chaincodeEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *GatewayServer) ChaincodeEventsReturnsOnCall(i int, result1 error) {
+	fake.chaincodeEventsMutex.Lock()
+	defer fake.chaincodeEventsMutex.Unlock()
+	fake.ChaincodeEventsStub = nil
+	if fake.chaincodeEventsReturnsOnCall == nil {
+		fake.chaincodeEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.chaincodeEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *GatewayServer) CommitStatus(arg1 context.Context, arg2 *gateway.SignedCommitStatusRequest) (*gateway.CommitStatusResponse, error) {
+	fake.commitStatusMutex.Lock()
+	ret, specificReturn := fake.commitStatusReturnsOnCall[len(fake.commitStatusArgsForCall)]
+	fake.commitStatusArgsForCall = append(fake.commitStatusArgsForCall, struct {
+		arg1 context.Context
+		arg2 *gateway.SignedCommitStatusRequest
+	}{arg1, arg2})
+	stub := fake.CommitStatusStub
+	fakeReturns := fake.commitStatusReturns
+	fake.recordInvocation("CommitStatus", []interface{}{arg1, arg2})
+	fake.commitStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *GatewayServer) CommitStatusCallCount() int {
+	fake.commitStatusMutex.RLock()
+	defer fake.commitStatusMutex.RUnlock()
+	return len(fake.commitStatusArgsForCall)
+}
+
+func (fake *GatewayServer) CommitStatusCalls(stub func(context.Context, *gateway.SignedCommitStatusRequest) (*gateway.CommitStatusResponse, error)) {
+	fake.commitStatusMutex.Lock()
+	defer fake.commitStatusMutex.Unlock()
+	fake.CommitStatusStub = stub
+}
+
+func (fake *GatewayServer) CommitStatusArgsForCall(i int) (context.Context, *gateway.SignedCommitStatusRequest) {
+	fake.commitStatusMutex.RLock()
+	defer fake.commitStatusMutex.RUnlock()
+	argsForCall := fake.commitStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *GatewayServer) CommitStatusReturns(result1 *gateway.CommitStatusResponse, result2 error) {
+	fake.commitStatusMutex.Lock()
+	defer fake.commitStatusMutex.Unlock()
+	fake.CommitStatusStub = nil
+	fake.commitStatusReturns = struct {
+		result1 *gateway.CommitStatusResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GatewayServer) CommitStatusReturnsOnCall(i int, result1 *gateway.CommitStatusResponse, result2 error) {
+	fake.commitStatusMutex.Lock()
+	defer fake.commitStatusMutex.Unlock()
+	fake.CommitStatusStub = nil
+	if fake.commitStatusReturnsOnCall == nil {
+		fake.commitStatusReturnsOnCall = make(map[int]struct {
+			result1 *gateway.CommitStatusResponse
+			result2 error
+		})
+	}
+	fake.commitStatusReturnsOnCall[i] = struct {
+		result1 *gateway.CommitStatusResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GatewayServer) Endorse(arg1 context.Context, arg2 *gateway.EndorseRequest) (*gateway.EndorseResponse, error) {
+	fake.endorseMutex.Lock()
+	ret, specificReturn := fake.endorseReturnsOnCall[len(fake.endorseArgsForCall)]
+	fake.endorseArgsForCall = append(fake.endorseArgsForCall, struct {
+		arg1 context.Context
+		arg2 *gateway.EndorseRequest
+	}{arg1, arg2})
+	stub := fake.EndorseStub
+	fakeReturns := fake.endorseReturns
+	fake.recordInvocation("Endorse", []interface{}{arg1, arg2})
+	fake.endorseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *GatewayServer) EndorseCallCount() int {
+	fake.endorseMutex.RLock()
+	defer fake.endorseMutex.RUnlock()
+	return len(fake.endorseArgsForCall)
+}
+
+func (fake *GatewayServer) EndorseCalls(stub func(context.Context, *gateway.EndorseRequest) (*gateway.EndorseResponse, error)) {
+	fake.endorseMutex.Lock()
+	defer fake.endorseMutex.Unlock()
+	fake.EndorseStub = stub
+}
+
+func (fake *GatewayServer) EndorseArgsForCall(i int) (context.Context, *gateway.EndorseRequest) {
+	fake.endorseMutex.RLock()
+	defer fake.endorseMutex.RUnlock()
+	argsForCall := fake.endorseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *GatewayServer) EndorseReturns(result1 *gateway.EndorseResponse, result2 error) {
+	fake.endorseMutex.Lock()
+	defer fake.endorseMutex.Unlock()
+	fake.EndorseStub = nil
+	fake.endorseReturns = struct {
+		result1 *gateway.EndorseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GatewayServer) EndorseReturnsOnCall(i int, result1 *gateway.EndorseResponse, result2 error) {
+	fake.endorseMutex.Lock()
+	defer fake.endorseMutex.Unlock()
+	fake.EndorseStub = nil
+	if fake.endorseReturnsOnCall == nil {
+		fake.endorseReturnsOnCall = make(map[int]struct {
+			result1 *gateway.EndorseResponse
+			result2 error
+		})
+	}
+	fake.endorseReturnsOnCall[i] = struct {
+		result1 *gateway.EndorseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GatewayServer) Evaluate(arg1 context.Context, arg2 *gateway.EvaluateRequest) (*gateway.EvaluateResponse, error) {
+	fake.evaluateMutex.Lock()
+	ret, specificReturn := fake.evaluateReturnsOnCall[len(fake.evaluateArgsForCall)]
+	fake.evaluateArgsForCall = append(fake.evaluateArgsForCall, struct {
+		arg1 context.Context
+		arg2 *gateway.EvaluateRequest
+	}{arg1, arg2})
+	stub := fake.EvaluateStub
+	fakeReturns := fake.evaluateReturns
+	fake.recordInvocation("Evaluate", []interface{}{arg1, arg2})
+	fake.evaluateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *GatewayServer) EvaluateCallCount() int {
+	fake.evaluateMutex.RLock()
+	defer fake.evaluateMutex.RUnlock()
+	return len(fake.evaluateArgsForCall)
+}
+
+func (fake *GatewayServer) EvaluateCalls(stub func(context.Context, *gateway.EvaluateRequest) (*gateway.EvaluateResponse, error)) {
+	fake.evaluateMutex.Lock()
+	defer fake.evaluateMutex.Unlock()
+	fake.EvaluateStub = stub
+}
+
+func (fake *GatewayServer) EvaluateArgsForCall(i int) (context.Context, *gateway.EvaluateRequest) {
+	fake.evaluateMutex.RLock()
+	defer fake.evaluateMutex.RUnlock()
+	argsForCall := fake.evaluateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *GatewayServer) EvaluateReturns(result1 *gateway.EvaluateResponse, result2 error) {
+	fake.evaluateMutex.Lock()
+	defer fake.evaluateMutex.Unlock()
+	fake.EvaluateStub = nil
+	fake.evaluateReturns = struct {
+		result1 *gateway.EvaluateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GatewayServer) EvaluateReturnsOnCall(i int, result1 *gateway.EvaluateResponse, result2 error) {
+	fake.evaluateMutex.Lock()
+	defer fake.evaluateMutex.Unlock()
+	fake.EvaluateStub = nil
+	if fake.evaluateReturnsOnCall == nil {
+		fake.evaluateReturnsOnCall = make(map[int]struct {
+			result1 *gateway.EvaluateResponse
+			result2 error
+		})
+	}
+	fake.evaluateReturnsOnCall[i] = struct {
+		result1 *gateway.EvaluateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GatewayServer) Submit(arg1 context.Context, arg2 *gateway.SubmitRequest) (*gateway.SubmitResponse, error) {
+	fake.submitMutex.Lock()
+	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
+	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
+		arg1 context.Context
+		arg2 *gateway.SubmitRequest
+	}{arg1, arg2})
+	stub := fake.SubmitStub
+	fakeReturns := fake.submitReturns
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
+	fake.submitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *GatewayServer) SubmitCallCount() int {
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	return len(fake.submitArgsForCall)
+}
+
+func (fake *GatewayServer) SubmitCalls(stub func(context.Context, *gateway.SubmitRequest) (*gateway.SubmitResponse, error)) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = stub
+}
+
+func (fake *GatewayServer) SubmitArgsForCall(i int) (context.Context, *gateway.SubmitRequest) {
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	argsForCall := fake.submitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *GatewayServer) SubmitReturns(result1 *gateway.SubmitResponse, result2 error) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = nil
+	fake.submitReturns = struct {
+		result1 *gateway.SubmitResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GatewayServer) SubmitReturnsOnCall(i int, result1 *gateway.SubmitResponse, result2 error) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = nil
+	if fake.submitReturnsOnCall == nil {
+		fake.submitReturnsOnCall = make(map[int]struct {
+			result1 *gateway.SubmitResponse
+			result2 error
+		})
+	}
+	fake.submitReturnsOnCall[i] = struct {
+		result1 *gateway.SubmitResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GatewayServer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.chaincodeEventsMutex.RLock()
+	defer fake.chaincodeEventsMutex.RUnlock()
+	fake.commitStatusMutex.RLock()
+	defer fake.commitStatusMutex.RUnlock()
+	fake.endorseMutex.RLock()
+	defer fake.endorseMutex.RUnlock()
+	fake.evaluateMutex.RLock()
+	defer fake.evaluateMutex.RUnlock()
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *GatewayServer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gateway.GatewayServer = new(GatewayServer)
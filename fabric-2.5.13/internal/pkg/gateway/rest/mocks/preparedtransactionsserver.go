@@ -0,0 +1,362 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/internal/pkg/gateway/prepared"
+)
+
+type PreparedTransactionsServer struct {
+	DeleteStub        func(context.Context, *prepared.SignedDeleteRequest) (*prepared.DeleteResponse, error)
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 context.Context
+		arg2 *prepared.SignedDeleteRequest
+	}
+	deleteReturns struct {
+		result1 *prepared.DeleteResponse
+		result2 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 *prepared.DeleteResponse
+		result2 error
+	}
+	GetStub        func(context.Context, *prepared.SignedGetRequest) (*prepared.PreparedTransaction, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 context.Context
+		arg2 *prepared.SignedGetRequest
+	}
+	getReturns struct {
+		result1 *prepared.PreparedTransaction
+		result2 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 *prepared.PreparedTransaction
+		result2 error
+	}
+	StoreStub        func(context.Context, *prepared.SignedStoreRequest) (*prepared.PreparedTransaction, error)
+	storeMutex       sync.RWMutex
+	storeArgsForCall []struct {
+		arg1 context.Context
+		arg2 *prepared.SignedStoreRequest
+	}
+	storeReturns struct {
+		result1 *prepared.PreparedTransaction
+		result2 error
+	}
+	storeReturnsOnCall map[int]struct {
+		result1 *prepared.PreparedTransaction
+		result2 error
+	}
+	SubmitStub        func(context.Context, *prepared.SubmitRequest) (*prepared.SubmitResponse, error)
+	submitMutex       sync.RWMutex
+	submitArgsForCall []struct {
+		arg1 context.Context
+		arg2 *prepared.SubmitRequest
+	}
+	submitReturns struct {
+		result1 *prepared.SubmitResponse
+		result2 error
+	}
+	submitReturnsOnCall map[int]struct {
+		result1 *prepared.SubmitResponse
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PreparedTransactionsServer) Delete(arg1 context.Context, arg2 *prepared.SignedDeleteRequest) (*prepared.DeleteResponse, error) {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 context.Context
+		arg2 *prepared.SignedDeleteRequest
+	}{arg1, arg2})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreparedTransactionsServer) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *PreparedTransactionsServer) DeleteCalls(stub func(context.Context, *prepared.SignedDeleteRequest) (*prepared.DeleteResponse, error)) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *PreparedTransactionsServer) DeleteArgsForCall(i int) (context.Context, *prepared.SignedDeleteRequest) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PreparedTransactionsServer) DeleteReturns(result1 *prepared.DeleteResponse, result2 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 *prepared.DeleteResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreparedTransactionsServer) DeleteReturnsOnCall(i int, result1 *prepared.DeleteResponse, result2 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 *prepared.DeleteResponse
+			result2 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 *prepared.DeleteResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreparedTransactionsServer) Get(arg1 context.Context, arg2 *prepared.SignedGetRequest) (*prepared.PreparedTransaction, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 context.Context
+		arg2 *prepared.SignedGetRequest
+	}{arg1, arg2})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1, arg2})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreparedTransactionsServer) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *PreparedTransactionsServer) GetCalls(stub func(context.Context, *prepared.SignedGetRequest) (*prepared.PreparedTransaction, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *PreparedTransactionsServer) GetArgsForCall(i int) (context.Context, *prepared.SignedGetRequest) {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PreparedTransactionsServer) GetReturns(result1 *prepared.PreparedTransaction, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 *prepared.PreparedTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreparedTransactionsServer) GetReturnsOnCall(i int, result1 *prepared.PreparedTransaction, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 *prepared.PreparedTransaction
+			result2 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 *prepared.PreparedTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreparedTransactionsServer) Store(arg1 context.Context, arg2 *prepared.SignedStoreRequest) (*prepared.PreparedTransaction, error) {
+	fake.storeMutex.Lock()
+	ret, specificReturn := fake.storeReturnsOnCall[len(fake.storeArgsForCall)]
+	fake.storeArgsForCall = append(fake.storeArgsForCall, struct {
+		arg1 context.Context
+		arg2 *prepared.SignedStoreRequest
+	}{arg1, arg2})
+	stub := fake.StoreStub
+	fakeReturns := fake.storeReturns
+	fake.recordInvocation("Store", []interface{}{arg1, arg2})
+	fake.storeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreparedTransactionsServer) StoreCallCount() int {
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	return len(fake.storeArgsForCall)
+}
+
+func (fake *PreparedTransactionsServer) StoreCalls(stub func(context.Context, *prepared.SignedStoreRequest) (*prepared.PreparedTransaction, error)) {
+	fake.storeMutex.Lock()
+	defer fake.storeMutex.Unlock()
+	fake.StoreStub = stub
+}
+
+func (fake *PreparedTransactionsServer) StoreArgsForCall(i int) (context.Context, *prepared.SignedStoreRequest) {
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	argsForCall := fake.storeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PreparedTransactionsServer) StoreReturns(result1 *prepared.PreparedTransaction, result2 error) {
+	fake.storeMutex.Lock()
+	defer fake.storeMutex.Unlock()
+	fake.StoreStub = nil
+	fake.storeReturns = struct {
+		result1 *prepared.PreparedTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreparedTransactionsServer) StoreReturnsOnCall(i int, result1 *prepared.PreparedTransaction, result2 error) {
+	fake.storeMutex.Lock()
+	defer fake.storeMutex.Unlock()
+	fake.StoreStub = nil
+	if fake.storeReturnsOnCall == nil {
+		fake.storeReturnsOnCall = make(map[int]struct {
+			result1 *prepared.PreparedTransaction
+			result2 error
+		})
+	}
+	fake.storeReturnsOnCall[i] = struct {
+		result1 *prepared.PreparedTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreparedTransactionsServer) Submit(arg1 context.Context, arg2 *prepared.SubmitRequest) (*prepared.SubmitResponse, error) {
+	fake.submitMutex.Lock()
+	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
+	fake.submitArgsForCall = append(fake.submitArgsForCall, struct {
+		arg1 context.Context
+		arg2 *prepared.SubmitRequest
+	}{arg1, arg2})
+	stub := fake.SubmitStub
+	fakeReturns := fake.submitReturns
+	fake.recordInvocation("Submit", []interface{}{arg1, arg2})
+	fake.submitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreparedTransactionsServer) SubmitCallCount() int {
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	return len(fake.submitArgsForCall)
+}
+
+func (fake *PreparedTransactionsServer) SubmitCalls(stub func(context.Context, *prepared.SubmitRequest) (*prepared.SubmitResponse, error)) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = stub
+}
+
+func (fake *PreparedTransactionsServer) SubmitArgsForCall(i int) (context.Context, *prepared.SubmitRequest) {
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	argsForCall := fake.submitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PreparedTransactionsServer) SubmitReturns(result1 *prepared.SubmitResponse, result2 error) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = nil
+	fake.submitReturns = struct {
+		result1 *prepared.SubmitResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreparedTransactionsServer) SubmitReturnsOnCall(i int, result1 *prepared.SubmitResponse, result2 error) {
+	fake.submitMutex.Lock()
+	defer fake.submitMutex.Unlock()
+	fake.SubmitStub = nil
+	if fake.submitReturnsOnCall == nil {
+		fake.submitReturnsOnCall = make(map[int]struct {
+			result1 *prepared.SubmitResponse
+			result2 error
+		})
+	}
+	fake.submitReturnsOnCall[i] = struct {
+		result1 *prepared.SubmitResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreparedTransactionsServer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	fake.submitMutex.RLock()
+	defer fake.submitMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PreparedTransactionsServer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ prepared.PreparedTransactionsServer = new(PreparedTransactionsServer)
@@ -0,0 +1,423 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/semaphore"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/prepared"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	URLBaseV1                = "/gateway/v1/"
+	URLBaseV1Evaluate        = URLBaseV1 + "evaluate"
+	URLBaseV1Endorse         = URLBaseV1 + "endorse"
+	URLBaseV1Submit          = URLBaseV1 + "submit"
+	URLBaseV1CommitStatus    = URLBaseV1 + "commit-status"
+	URLBaseV1ChaincodeEvents = URLBaseV1 + "chaincode-events"
+
+	URLBaseV1PreparedTransactions       = URLBaseV1 + "prepared-transactions/"
+	URLBaseV1PreparedTransactionsStore  = URLBaseV1PreparedTransactions + "store"
+	URLBaseV1PreparedTransactionsGet    = URLBaseV1PreparedTransactions + "get"
+	URLBaseV1PreparedTransactionsDelete = URLBaseV1PreparedTransactions + "delete"
+	URLBaseV1PreparedTransactionsSubmit = URLBaseV1PreparedTransactions + "submit"
+
+	contentTypeJSON        = "application/json"
+	contentTypeEventStream = "text/event-stream"
+
+	// query parameters of the chaincode events request, for clients that cannot send a request body
+	requestQueryKey   = "request"
+	signatureQueryKey = "signature"
+)
+
+// ErrorResponse carries the error response of an HTTP request.
+type ErrorResponse struct {
+	// Error is the message of the gRPC status returned by the gateway.
+	Error string `json:"error"`
+	// Code is the name of the gRPC status code returned by the gateway.
+	Code string `json:"code,omitempty"`
+	// Details are the errors returned by the endorsing peers or ordering nodes the gateway invoked.
+	Details []ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorDetail carries the error returned by a single endorsing peer or ordering node.
+type ErrorDetail struct {
+	Address string `json:"address"`
+	MspID   string `json:"mspId"`
+	Message string `json:"message"`
+}
+
+// HTTPHandler handles all the HTTP requests to the gateway REST API. Requests and responses are the JSON encoding of
+// the gateway protobuf messages, so clients sign proposals, transactions and requests exactly as they would with
+// the gRPC API, and their private keys never leave the client.
+type HTTPHandler struct {
+	logger      *flogging.FabricLogger
+	options     config.HTTPOptions
+	server      gp.GatewayServer
+	prepared    prepared.PreparedTransactionsServer
+	router      *mux.Router
+	marshaler   *jsonpb.Marshaler
+	unmarshaler *jsonpb.Unmarshaler
+	concurrency semaphore.Semaphore
+}
+
+// NewHTTPHandler creates a handler that maps the REST API of the gateway to the given gateway server.
+func NewHTTPHandler(server gp.GatewayServer, options config.HTTPOptions) *HTTPHandler {
+	handler := &HTTPHandler{
+		logger:      flogging.MustGetLogger("gateway.rest"),
+		options:     options,
+		server:      server,
+		router:      mux.NewRouter(),
+		marshaler:   &jsonpb.Marshaler{},
+		unmarshaler: &jsonpb.Unmarshaler{},
+	}
+
+	handler.handleUnary(URLBaseV1Evaluate, handler.serveEvaluate)
+	handler.handleUnary(URLBaseV1Endorse, handler.serveEndorse)
+	handler.handleUnary(URLBaseV1Submit, handler.serveSubmit)
+	handler.handleUnary(URLBaseV1CommitStatus, handler.serveCommitStatus)
+
+	// Browsers can only open server-sent event streams with a GET request, which carries the signed request in
+	// its query parameters, while other clients may also POST the signed request as the request body.
+	handler.router.HandleFunc(URLBaseV1ChaincodeEvents, handler.serveChaincodeEvents).Methods(http.MethodGet)
+	handler.router.HandleFunc(URLBaseV1ChaincodeEvents, handler.serveChaincodeEvents).Methods(http.MethodPost).HeadersRegexp(
+		"Content-Type", contentTypeJSON)
+	handler.router.HandleFunc(URLBaseV1ChaincodeEvents, handler.serveBadContentType).Methods(http.MethodPost)
+	handler.router.HandleFunc(URLBaseV1ChaincodeEvents, handler.serveNotAllowed(http.MethodGet, http.MethodPost))
+
+	return handler
+}
+
+// RegisterPreparedTransactions adds the routes of the prepared transactions service to the REST API.
+func (h *HTTPHandler) RegisterPreparedTransactions(server prepared.PreparedTransactionsServer) {
+	h.prepared = server
+	h.handleUnary(URLBaseV1PreparedTransactionsStore, h.servePreparedStore)
+	h.handleUnary(URLBaseV1PreparedTransactionsGet, h.servePreparedGet)
+	h.handleUnary(URLBaseV1PreparedTransactionsDelete, h.servePreparedDelete)
+	h.handleUnary(URLBaseV1PreparedTransactionsSubmit, h.servePreparedSubmit)
+}
+
+// LimitConcurrency applies the concurrency limit of the gateway service to the requests of the REST API, which
+// therefore share the limit with the requests of the gRPC API.
+func (h *HTTPHandler) LimitConcurrency(concurrency semaphore.Semaphore) {
+	h.concurrency = concurrency
+}
+
+// acquire takes a permit of the concurrency limit, if any, and sends an error response if the limit is reached.
+// The returned function releases the permit.
+func (h *HTTPHandler) acquire(resp http.ResponseWriter) (release func(), ok bool) {
+	if h.concurrency == nil {
+		return func() {}, true
+	}
+	if !h.concurrency.TryAcquire() {
+		h.logger.Errorf("Too many gateway REST requests, exceeding concurrency limit (%d)", cap(h.concurrency))
+		h.sendResponseRPCError(resp, status.Errorf(codes.ResourceExhausted, "too many requests, exceeding concurrency limit (%d)", cap(h.concurrency)))
+		return nil, false
+	}
+	return h.concurrency.Release, true
+}
+
+func (h *HTTPHandler) handleUnary(url string, serve http.HandlerFunc) {
+	h.router.HandleFunc(url, serve).Methods(http.MethodPost).HeadersRegexp("Content-Type", contentTypeJSON)
+	h.router.HandleFunc(url, h.serveBadContentType).Methods(http.MethodPost)
+	h.router.HandleFunc(url, h.serveNotAllowed(http.MethodPost))
+}
+
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	h.router.ServeHTTP(resp, req)
+}
+
+func (h *HTTPHandler) serveEvaluate(resp http.ResponseWriter, req *http.Request) {
+	request := &gp.EvaluateRequest{}
+	h.serveUnary(resp, req, request, func(ctx context.Context) (proto.Message, error) {
+		return h.server.Evaluate(ctx, request)
+	})
+}
+
+func (h *HTTPHandler) serveEndorse(resp http.ResponseWriter, req *http.Request) {
+	request := &gp.EndorseRequest{}
+	h.serveUnary(resp, req, request, func(ctx context.Context) (proto.Message, error) {
+		return h.server.Endorse(ctx, request)
+	})
+}
+
+func (h *HTTPHandler) serveSubmit(resp http.ResponseWriter, req *http.Request) {
+	request := &gp.SubmitRequest{}
+	h.serveUnary(resp, req, request, func(ctx context.Context) (proto.Message, error) {
+		return h.server.Submit(ctx, request)
+	})
+}
+
+func (h *HTTPHandler) serveCommitStatus(resp http.ResponseWriter, req *http.Request) {
+	request := &gp.SignedCommitStatusRequest{}
+	h.serveUnary(resp, req, request, func(ctx context.Context) (proto.Message, error) {
+		return h.server.CommitStatus(ctx, request)
+	})
+}
+
+func (h *HTTPHandler) servePreparedStore(resp http.ResponseWriter, req *http.Request) {
+	request := &prepared.SignedStoreRequest{}
+	h.serveUnary(resp, req, request, func(ctx context.Context) (proto.Message, error) {
+		return h.prepared.Store(ctx, request)
+	})
+}
+
+func (h *HTTPHandler) servePreparedGet(resp http.ResponseWriter, req *http.Request) {
+	request := &prepared.SignedGetRequest{}
+	h.serveUnary(resp, req, request, func(ctx context.Context) (proto.Message, error) {
+		return h.prepared.Get(ctx, request)
+	})
+}
+
+func (h *HTTPHandler) servePreparedDelete(resp http.ResponseWriter, req *http.Request) {
+	request := &prepared.SignedDeleteRequest{}
+	h.serveUnary(resp, req, request, func(ctx context.Context) (proto.Message, error) {
+		return h.prepared.Delete(ctx, request)
+	})
+}
+
+func (h *HTTPHandler) servePreparedSubmit(resp http.ResponseWriter, req *http.Request) {
+	request := &prepared.SubmitRequest{}
+	h.serveUnary(resp, req, request, func(ctx context.Context) (proto.Message, error) {
+		return h.prepared.Submit(ctx, request)
+	})
+}
+
+// serveUnary decodes the request, invokes the gateway, and encodes its response. The headers and trailers the
+// gateway sets are returned as HTTP headers.
+func (h *HTTPHandler) serveUnary(resp http.ResponseWriter, req *http.Request, request proto.Message, invoke func(ctx context.Context) (proto.Message, error)) {
+	if err := negotiateContentType(req, contentTypeJSON); err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	if err := h.readRequest(resp, req, request); err != nil {
+		h.sendResponseJsonError(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	release, ok := h.acquire(resp)
+	if !ok {
+		return
+	}
+	defer release()
+
+	stream := &transportStream{method: req.URL.Path}
+	response, err := invoke(grpc.NewContextWithServerTransportStream(req.Context(), stream))
+	stream.writeMetadata(resp.Header())
+	if err != nil {
+		h.sendResponseRPCError(resp, err)
+		return
+	}
+
+	h.sendResponseOK(resp, response)
+}
+
+// serveChaincodeEvents streams the chaincode events responses of the gateway as server-sent events.
+func (h *HTTPHandler) serveChaincodeEvents(resp http.ResponseWriter, req *http.Request) {
+	if err := negotiateContentType(req, contentTypeEventStream); err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	request := &gp.SignedChaincodeEventsRequest{}
+	var err error
+	if req.Method == http.MethodGet {
+		err = requestFromQuery(req, request)
+	} else {
+		err = h.readRequest(resp, req, request)
+	}
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	release, ok := h.acquire(resp)
+	if !ok {
+		return
+	}
+	defer release()
+
+	stream := newEventStream(resp, req.Context(), h.marshaler)
+	err = h.server.ChaincodeEvents(request, stream)
+	if err == nil {
+		return
+	}
+	if !stream.started {
+		stream.writeMetadata(resp.Header())
+		h.sendResponseRPCError(resp, err)
+		return
+	}
+	if status.Code(err) == codes.Canceled {
+		h.logger.Debugf("Chaincode events stream closed by the client: %s", err)
+		return
+	}
+	if err := stream.sendError(errorResponse(err)); err != nil {
+		h.logger.Debugf("Failed to send chaincode events error: %s", err)
+	}
+}
+
+func (h *HTTPHandler) readRequest(resp http.ResponseWriter, req *http.Request, request proto.Message) error {
+	body := http.MaxBytesReader(resp, req.Body, int64(h.options.MaxRequestBodySize))
+	if err := h.unmarshaler.Unmarshal(body, request); err != nil {
+		return errors.Wrap(err, "cannot unmarshal request body")
+	}
+	return nil
+}
+
+// requestFromQuery reads the signed chaincode events request from the base64 encoded request and signature
+// query parameters.
+func requestFromQuery(req *http.Request, request *gp.SignedChaincodeEventsRequest) error {
+	query := req.URL.Query()
+
+	requestBytes, err := decodeBase64(query.Get(requestQueryKey))
+	if err != nil {
+		return errors.Wrapf(err, "cannot decode query parameter %s", requestQueryKey)
+	}
+	signature, err := decodeBase64(query.Get(signatureQueryKey))
+	if err != nil {
+		return errors.Wrapf(err, "cannot decode query parameter %s", signatureQueryKey)
+	}
+
+	request.Request = requestBytes
+	request.Signature = signature
+	return nil
+}
+
+// decodeBase64 decodes both the standard and the URL safe base64 encodings, with or without padding.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func (h *HTTPHandler) serveBadContentType(resp http.ResponseWriter, req *http.Request) {
+	err := errors.Errorf("unsupported Content-Type: %s", req.Header.Values("Content-Type"))
+	h.sendResponseJsonError(resp, http.StatusUnsupportedMediaType, err)
+}
+
+func (h *HTTPHandler) serveNotAllowed(allow ...string) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		err := errors.Errorf("invalid request method: %s", req.Method)
+		resp.Header().Set("Allow", strings.Join(allow, ", "))
+		h.sendResponseJsonError(resp, http.StatusMethodNotAllowed, err)
+	}
+}
+
+func negotiateContentType(req *http.Request, contentType string) error {
+	acceptReq := req.Header.Get("Accept")
+	if len(acceptReq) == 0 {
+		return nil
+	}
+
+	mediaRange := contentType[:strings.Index(contentType, "/")] + "/*"
+	options := strings.Split(acceptReq, ",")
+	for _, opt := range options {
+		if strings.Contains(opt, contentType) ||
+			strings.Contains(opt, mediaRange) ||
+			strings.Contains(opt, "*/*") {
+			return nil
+		}
+	}
+
+	return errors.Errorf("response Content-Type is %s only", contentType)
+}
+
+// errorResponse converts the gRPC status error returned by the gateway to an error response.
+func errorResponse(err error) *ErrorResponse {
+	st := status.Convert(err)
+	response := &ErrorResponse{
+		Error: st.Message(),
+		Code:  st.Code().String(),
+	}
+	for _, detail := range st.Details() {
+		if errorDetail, ok := detail.(*gp.ErrorDetail); ok {
+			response.Details = append(response.Details, ErrorDetail{
+				Address: errorDetail.GetAddress(),
+				MspID:   errorDetail.GetMspId(),
+				Message: errorDetail.GetMessage(),
+			})
+		}
+	}
+	return response
+}
+
+// httpStatusCode maps the gRPC status codes returned by the gateway to HTTP status codes.
+func httpStatusCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *HTTPHandler) sendResponseRPCError(resp http.ResponseWriter, err error) {
+	response := errorResponse(err)
+	code := httpStatusCode(status.Code(err))
+	h.logger.Debugf("Gateway request failed with status %d: %s", code, err)
+
+	encoder := json.NewEncoder(resp)
+	resp.Header().Set("Content-Type", contentTypeJSON)
+	resp.WriteHeader(code)
+	if err := encoder.Encode(response); err != nil {
+		h.logger.Errorf("failed to encode error, err: %s", err)
+	}
+}
+
+func (h *HTTPHandler) sendResponseJsonError(resp http.ResponseWriter, code int, err error) {
+	encoder := json.NewEncoder(resp)
+	resp.Header().Set("Content-Type", contentTypeJSON)
+	resp.WriteHeader(code)
+	if err := encoder.Encode(&ErrorResponse{Error: err.Error()}); err != nil {
+		h.logger.Errorf("failed to encode error, err: %s", err)
+	}
+}
+
+func (h *HTTPHandler) sendResponseOK(resp http.ResponseWriter, content proto.Message) {
+	resp.Header().Set("Content-Type", contentTypeJSON)
+	resp.Header().Set("Cache-Control", "no-store")
+	resp.WriteHeader(http.StatusOK)
+	if err := h.marshaler.Marshal(resp, content); err != nil {
+		h.logger.Errorf("failed to encode content, err: %s", err)
+	}
+}
@@ -0,0 +1,438 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/semaphore"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/prepared"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/rest"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/rest/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate counterfeiter -o mocks/gatewayserver.go --fake-name GatewayServer github.com/hyperledger/fabric-protos-go/gateway.GatewayServer
+//go:generate counterfeiter -o mocks/preparedtransactionsserver.go --fake-name PreparedTransactionsServer github.com/hyperledger/fabric/internal/pkg/gateway/prepared.PreparedTransactionsServer
+
+func setup() (*mocks.GatewayServer, *rest.HTTPHandler) {
+	fakeServer := &mocks.GatewayServer{}
+	h := rest.NewHTTPHandler(fakeServer, config.HTTPOptions{MaxRequestBodySize: 1024})
+	return fakeServer, h
+}
+
+func newRequest(t *testing.T, method string, target string, message proto.Message) *http.Request {
+	var body io.Reader
+	if message != nil {
+		data, err := (&jsonpb.Marshaler{}).MarshalToString(message)
+		require.NoError(t, err)
+		body = strings.NewReader(data)
+	}
+	req := httptest.NewRequest(method, target, body)
+	if message != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+func checkErrorResponse(t *testing.T, expectedCode int, expectedErrMsg string, resp *httptest.ResponseRecorder) *rest.ErrorResponse {
+	require.Equal(t, expectedCode, resp.Result().StatusCode)
+	require.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+
+	errorResponse := &rest.ErrorResponse{}
+	err := json.Unmarshal(resp.Body.Bytes(), errorResponse)
+	require.NoError(t, err, "body: %s", resp.Body.String())
+	require.Equal(t, expectedErrMsg, errorResponse.Error)
+	return errorResponse
+}
+
+func TestHTTPHandler_ServeHTTP_InvalidMethods(t *testing.T) {
+	_, h := setup()
+
+	for _, url := range []string{rest.URLBaseV1Evaluate, rest.URLBaseV1Endorse, rest.URLBaseV1Submit, rest.URLBaseV1CommitStatus} {
+		for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodPatch} {
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, httptest.NewRequest(method, url, nil))
+			checkErrorResponse(t, http.StatusMethodNotAllowed, fmt.Sprintf("invalid request method: %s", method), resp)
+			require.Equal(t, "POST", resp.Result().Header.Get("Allow"))
+		}
+	}
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, rest.URLBaseV1ChaincodeEvents, nil))
+	checkErrorResponse(t, http.StatusMethodNotAllowed, "invalid request method: PUT", resp)
+	require.Equal(t, "GET, POST", resp.Result().Header.Get("Allow"))
+}
+
+func TestHTTPHandler_ServeHTTP_RequestErrors(t *testing.T) {
+	fakeServer, h := setup()
+
+	t.Run("bad resource", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, rest.URLBaseV1+"oops", nil))
+		require.Equal(t, http.StatusNotFound, resp.Result().StatusCode)
+	})
+
+	t.Run("bad Content-Type", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, rest.URLBaseV1Evaluate, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "text/plain")
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusUnsupportedMediaType, "unsupported Content-Type: [text/plain]", resp)
+	})
+
+	t.Run("bad Accept header", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := newRequest(t, http.MethodPost, rest.URLBaseV1Evaluate, &gp.EvaluateRequest{})
+		req.Header.Set("Accept", "text/html")
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotAcceptable, "response Content-Type is application/json only", resp)
+	})
+
+	t.Run("bad request body", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, rest.URLBaseV1Evaluate, strings.NewReader(`{"oops": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		h.ServeHTTP(resp, req)
+		errorResponse := checkErrorResponse(t, http.StatusBadRequest, `cannot unmarshal request body: unknown field "oops" in gateway.EvaluateRequest`, resp)
+		require.Empty(t, errorResponse.Code)
+	})
+
+	t.Run("request body too large", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := newRequest(t, http.MethodPost, rest.URLBaseV1Submit, &gp.SubmitRequest{TransactionId: strings.Repeat("x", 2048)})
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusBadRequest, resp.Result().StatusCode)
+		require.Contains(t, resp.Body.String(), "cannot unmarshal request body")
+	})
+
+	t.Run("bad chaincode events query", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, rest.URLBaseV1ChaincodeEvents+"?request=%25%25", nil))
+		require.Equal(t, http.StatusBadRequest, resp.Result().StatusCode)
+		require.Contains(t, resp.Body.String(), "cannot decode query parameter request")
+	})
+
+	require.Zero(t, fakeServer.EvaluateCallCount())
+	require.Zero(t, fakeServer.SubmitCallCount())
+	require.Zero(t, fakeServer.ChaincodeEventsCallCount())
+}
+
+func TestHTTPHandler_ServeHTTP_Evaluate(t *testing.T) {
+	fakeServer, h := setup()
+	expectedResponse := &gp.EvaluateResponse{
+		Result: &peer.Response{Status: 200, Payload: []byte("result")},
+	}
+	fakeServer.EvaluateReturns(expectedResponse, nil)
+
+	request := &gp.EvaluateRequest{TransactionId: "txid", ChannelId: "mychannel"}
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1Evaluate, request))
+
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+	response := &gp.EvaluateResponse{}
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, response))
+	require.True(t, proto.Equal(expectedResponse, response))
+
+	require.Equal(t, 1, fakeServer.EvaluateCallCount())
+	_, actualRequest := fakeServer.EvaluateArgsForCall(0)
+	require.True(t, proto.Equal(request, actualRequest))
+}
+
+func TestHTTPHandler_ServeHTTP_ConcurrencyLimit(t *testing.T) {
+	fakeServer, h := setup()
+	sema := semaphore.New(1)
+	h.LimitConcurrency(sema)
+
+	// a permit is held for the duration of a request
+	fakeServer.EvaluateStub = func(context.Context, *gp.EvaluateRequest) (*gp.EvaluateResponse, error) {
+		require.False(t, sema.TryAcquire())
+		return &gp.EvaluateResponse{}, nil
+	}
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1Evaluate, &gp.EvaluateRequest{}))
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+
+	// the permits are shared with the gRPC requests
+	require.True(t, sema.TryAcquire())
+	defer sema.Release()
+
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1Evaluate, &gp.EvaluateRequest{}))
+	checkErrorResponse(t, http.StatusTooManyRequests, "too many requests, exceeding concurrency limit (1)", resp)
+
+	req := httptest.NewRequest(http.MethodGet, rest.URLBaseV1ChaincodeEvents+"?request=cmVxdWVzdA==&signature=c2lnbmF0dXJl", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	checkErrorResponse(t, http.StatusTooManyRequests, "too many requests, exceeding concurrency limit (1)", resp)
+
+	require.Equal(t, 1, fakeServer.EvaluateCallCount())
+	require.Zero(t, fakeServer.ChaincodeEventsCallCount())
+}
+
+func TestHTTPHandler_ServeHTTP_EndorseAndSubmit(t *testing.T) {
+	fakeServer, h := setup()
+	preparedTransaction := &common.Envelope{Payload: []byte("payload"), Signature: []byte("signature")}
+	expectedResponse := &gp.EndorseResponse{
+		PreparedTransaction: preparedTransaction,
+	}
+	fakeServer.EndorseReturns(expectedResponse, nil)
+	fakeServer.SubmitReturns(&gp.SubmitResponse{}, nil)
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1Endorse, &gp.EndorseRequest{TransactionId: "txid"}))
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	response := &gp.EndorseResponse{}
+	require.NoError(t, jsonpb.Unmarshal(resp.Body, response))
+	require.True(t, proto.Equal(expectedResponse, response))
+
+	request := &gp.SubmitRequest{TransactionId: "txid", PreparedTransaction: preparedTransaction}
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1Submit, request))
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.Equal(t, 1, fakeServer.SubmitCallCount())
+	_, actualRequest := fakeServer.SubmitArgsForCall(0)
+	require.True(t, proto.Equal(request, actualRequest))
+}
+
+func TestHTTPHandler_ServeHTTP_RPCError(t *testing.T) {
+	fakeServer, h := setup()
+	st, err := status.New(codes.Aborted, "failed to endorse transaction").WithDetails(&gp.ErrorDetail{
+		Address: "peer0:7051",
+		MspId:   "Org1MSP",
+		Message: "chaincode response 500",
+	})
+	require.NoError(t, err)
+	fakeServer.EndorseReturns(nil, st.Err())
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1Endorse, &gp.EndorseRequest{}))
+
+	errorResponse := checkErrorResponse(t, http.StatusConflict, "failed to endorse transaction", resp)
+	require.Equal(t, "Aborted", errorResponse.Code)
+	require.Equal(t, []rest.ErrorDetail{{Address: "peer0:7051", MspID: "Org1MSP", Message: "chaincode response 500"}}, errorResponse.Details)
+
+	for code, httpCode := range map[codes.Code]int{
+		codes.InvalidArgument:   http.StatusBadRequest,
+		codes.PermissionDenied:  http.StatusForbidden,
+		codes.NotFound:          http.StatusNotFound,
+		codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+		codes.Unavailable:       http.StatusServiceUnavailable,
+		codes.ResourceExhausted: http.StatusTooManyRequests,
+		codes.Internal:          http.StatusInternalServerError,
+	} {
+		fakeServer.EvaluateReturns(nil, status.Error(code, "oops"))
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1Evaluate, &gp.EvaluateRequest{}))
+		errorResponse := checkErrorResponse(t, httpCode, "oops", resp)
+		require.Equal(t, code.String(), errorResponse.Code)
+	}
+}
+
+func TestHTTPHandler_ServeHTTP_CommitStatus(t *testing.T) {
+	fakeServer, h := setup()
+	fakeServer.CommitStatusStub = func(ctx context.Context, request *gp.SignedCommitStatusRequest) (*gp.CommitStatusResponse, error) {
+		err := grpc.SetTrailer(ctx, metadata.Pairs("fabric-invalidation-info-bin", "\x01\x02"))
+		require.NoError(t, err)
+		return &gp.CommitStatusResponse{Result: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 7}, nil
+	}
+
+	request := &gp.SignedCommitStatusRequest{Request: []byte("request"), Signature: []byte("signature")}
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1CommitStatus, request))
+
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.JSONEq(t, `{"result":"MVCC_READ_CONFLICT","blockNumber":"7"}`, resp.Body.String())
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("\x01\x02")), resp.Result().Header.Get("Fabric-Invalidation-Info-Bin"))
+
+	_, actualRequest := fakeServer.CommitStatusArgsForCall(0)
+	require.True(t, proto.Equal(request, actualRequest))
+}
+
+func TestHTTPHandler_ServeHTTP_PreparedTransactions(t *testing.T) {
+	_, h := setup()
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1PreparedTransactionsGet, &prepared.SignedGetRequest{}))
+	require.Equal(t, http.StatusNotFound, resp.Result().StatusCode, "routes not registered")
+
+	fakePrepared := &mocks.PreparedTransactionsServer{}
+	h.RegisterPreparedTransactions(fakePrepared)
+
+	stored := &prepared.PreparedTransaction{ChannelId: "mychannel", TransactionId: "tx1"}
+	fakePrepared.StoreReturns(stored, nil)
+	fakePrepared.GetReturns(stored, nil)
+	fakePrepared.DeleteReturns(&prepared.DeleteResponse{}, nil)
+	fakePrepared.SubmitReturns(nil, status.Error(codes.NotFound, "transaction tx1 is not stored, or expired"))
+
+	storeRequest := &prepared.SignedStoreRequest{Request: []byte("request"), Signature: []byte("signature")}
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1PreparedTransactionsStore, storeRequest))
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.JSONEq(t, `{"channelId":"mychannel","transactionId":"tx1"}`, resp.Body.String())
+	_, actualStoreRequest := fakePrepared.StoreArgsForCall(0)
+	require.True(t, proto.Equal(storeRequest, actualStoreRequest))
+
+	getRequest := &prepared.SignedGetRequest{Request: []byte("request"), Signature: []byte("signature")}
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1PreparedTransactionsGet, getRequest))
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	_, actualGetRequest := fakePrepared.GetArgsForCall(0)
+	require.True(t, proto.Equal(getRequest, actualGetRequest))
+
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1PreparedTransactionsDelete, &prepared.SignedDeleteRequest{Request: []byte("request")}))
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.Equal(t, 1, fakePrepared.DeleteCallCount())
+
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1PreparedTransactionsSubmit, &prepared.SubmitRequest{ChannelId: "mychannel", TransactionId: "tx1"}))
+	checkErrorResponse(t, http.StatusNotFound, "transaction tx1 is not stored, or expired", resp)
+}
+
+func TestHTTPHandler_ServeHTTP_ChaincodeEvents(t *testing.T) {
+	responses := []*gp.ChaincodeEventsResponse{
+		{BlockNumber: 1, Events: []*peer.ChaincodeEvent{{ChaincodeId: "cc", TxId: "tx1", EventName: "event1"}}},
+		{BlockNumber: 2, Events: []*peer.ChaincodeEvent{{ChaincodeId: "cc", TxId: "tx2", EventName: "event2"}}},
+	}
+	request := &gp.SignedChaincodeEventsRequest{Request: []byte("request?"), Signature: []byte("signature>")}
+
+	sendResponses := func(err error) func(*gp.SignedChaincodeEventsRequest, gp.Gateway_ChaincodeEventsServer) error {
+		return func(_ *gp.SignedChaincodeEventsRequest, stream gp.Gateway_ChaincodeEventsServer) error {
+			for _, response := range responses {
+				if err := stream.Send(response); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+
+	readEvents := func(t *testing.T, body *bytes.Buffer) []string {
+		var events []string
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			events = append(events, scanner.Text())
+		}
+		return events
+	}
+
+	t.Run("GET with query parameters", func(t *testing.T) {
+		fakeServer, h := setup()
+		fakeServer.ChaincodeEventsStub = sendResponses(nil)
+
+		target := fmt.Sprintf("%s?request=%s&signature=%s", rest.URLBaseV1ChaincodeEvents,
+			base64.URLEncoding.EncodeToString(request.Request), base64.RawStdEncoding.EncodeToString(request.Signature))
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept", "text/event-stream")
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.Equal(t, "text/event-stream", resp.Result().Header.Get("Content-Type"))
+		require.True(t, resp.Flushed)
+		events := readEvents(t, resp.Body)
+		require.Len(t, events, 4)
+		for i, response := range responses {
+			require.True(t, strings.HasPrefix(events[2*i], "data: "))
+			actual := &gp.ChaincodeEventsResponse{}
+			require.NoError(t, jsonpb.UnmarshalString(strings.TrimPrefix(events[2*i], "data: "), actual))
+			require.True(t, proto.Equal(response, actual))
+			require.Empty(t, events[2*i+1])
+		}
+
+		actualRequest, _ := fakeServer.ChaincodeEventsArgsForCall(0)
+		require.True(t, proto.Equal(request, actualRequest))
+	})
+
+	t.Run("POST with request body", func(t *testing.T) {
+		fakeServer, h := setup()
+		fakeServer.ChaincodeEventsStub = sendResponses(nil)
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1ChaincodeEvents, request))
+
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.Len(t, readEvents(t, resp.Body), 4)
+		actualRequest, _ := fakeServer.ChaincodeEventsArgsForCall(0)
+		require.True(t, proto.Equal(request, actualRequest))
+	})
+
+	t.Run("error before the stream started", func(t *testing.T) {
+		fakeServer, h := setup()
+		fakeServer.ChaincodeEventsReturns(status.Error(codes.PermissionDenied, "access denied"))
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1ChaincodeEvents, request))
+
+		errorResponse := checkErrorResponse(t, http.StatusForbidden, "access denied", resp)
+		require.Equal(t, "PermissionDenied", errorResponse.Code)
+	})
+
+	t.Run("error after the stream started", func(t *testing.T) {
+		fakeServer, h := setup()
+		fakeServer.ChaincodeEventsStub = sendResponses(status.Error(codes.Aborted, "iterator failed"))
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, newRequest(t, http.MethodPost, rest.URLBaseV1ChaincodeEvents, request))
+
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		events := readEvents(t, resp.Body)
+		require.Len(t, events, 7)
+		require.Equal(t, "event: error", events[4])
+		require.JSONEq(t, `{"error":"iterator failed","code":"Aborted"}`, strings.TrimPrefix(events[5], "data: "))
+	})
+
+	t.Run("stream closed by the client", func(t *testing.T) {
+		fakeServer, h := setup()
+		var sendErr error
+		fakeServer.ChaincodeEventsStub = func(_ *gp.SignedChaincodeEventsRequest, stream gp.Gateway_ChaincodeEventsServer) error {
+			sendErr = stream.Send(responses[0])
+			return status.Error(codes.Canceled, sendErr.Error())
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req := newRequest(t, http.MethodPost, rest.URLBaseV1ChaincodeEvents, request).WithContext(ctx)
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+
+		require.Equal(t, io.EOF, sendErr)
+		require.Equal(t, http.StatusRequestTimeout, resp.Result().StatusCode)
+	})
+
+	t.Run("bad Accept header", func(t *testing.T) {
+		_, h := setup()
+		req := newRequest(t, http.MethodPost, rest.URLBaseV1ChaincodeEvents, request)
+		req.Header.Set("Accept", "application/json")
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotAcceptable, "response Content-Type is text/event-stream only", resp)
+	})
+}
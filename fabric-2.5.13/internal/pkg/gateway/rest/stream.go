@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+// transportStream collects the headers and trailers the gateway sets on a unary request, in place of the gRPC
+// transport.
+type transportStream struct {
+	method string
+	mutex  sync.Mutex
+	md     metadata.MD
+}
+
+func (s *transportStream) Method() string {
+	return s.method
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.md = metadata.Join(s.md, md)
+	return nil
+}
+
+func (s *transportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *transportStream) writeMetadata(header http.Header) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	writeMetadata(header, s.md)
+}
+
+// writeMetadata adds the gRPC metadata to the HTTP headers. As in gRPC, the values of binary metadata, whose keys
+// end with -bin, are base64 encoded.
+func writeMetadata(header http.Header, md metadata.MD) {
+	for key, values := range md {
+		for _, value := range values {
+			if strings.HasSuffix(key, "-bin") {
+				value = base64.StdEncoding.EncodeToString([]byte(value))
+			}
+			header.Add(key, value)
+		}
+	}
+}
+
+// eventStream implements the server side of the gateway chaincode events stream, sending each response to the
+// client as a server-sent event.
+type eventStream struct {
+	resp       http.ResponseWriter
+	controller *http.ResponseController
+	ctx        context.Context
+	marshaler  *jsonpb.Marshaler
+	header     metadata.MD
+	started    bool
+}
+
+func newEventStream(resp http.ResponseWriter, ctx context.Context, marshaler *jsonpb.Marshaler) *eventStream {
+	return &eventStream{
+		resp:       resp,
+		controller: http.NewResponseController(resp),
+		ctx:        ctx,
+		marshaler:  marshaler,
+	}
+}
+
+func (s *eventStream) Send(response *gp.ChaincodeEventsResponse) error {
+	return s.SendMsg(response)
+}
+
+func (s *eventStream) SetHeader(md metadata.MD) error {
+	if s.started {
+		return errors.New("stream already started")
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *eventStream) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	s.start()
+	return s.controller.Flush()
+}
+
+// SetTrailer is a no-op, as trailers can't be sent once the event stream started.
+func (s *eventStream) SetTrailer(md metadata.MD) {}
+
+func (s *eventStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *eventStream) SendMsg(m interface{}) error {
+	message, ok := m.(proto.Message)
+	if !ok {
+		return errors.Errorf("unexpected message type %T", m)
+	}
+	data, err := s.marshaler.MarshalToString(message)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event")
+	}
+	return s.sendEvent("", data)
+}
+
+func (s *eventStream) RecvMsg(m interface{}) error {
+	return io.EOF
+}
+
+// sendError sends the error response as an event of type error.
+func (s *eventStream) sendError(response *ErrorResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode error")
+	}
+	return s.sendEvent("error", string(data))
+}
+
+// sendEvent sends a server-sent event of the given type, with the given single line data. An io.EOF error is
+// returned if the stream was closed by the client.
+func (s *eventStream) sendEvent(eventType string, data string) error {
+	if s.ctx.Err() != nil {
+		return io.EOF
+	}
+	s.start()
+
+	var event strings.Builder
+	if eventType != "" {
+		fmt.Fprintf(&event, "event: %s\n", eventType)
+	}
+	fmt.Fprintf(&event, "data: %s\n\n", data)
+
+	if _, err := io.WriteString(s.resp, event.String()); err != nil {
+		return io.EOF
+	}
+	if err := s.controller.Flush(); err != nil {
+		return io.EOF
+	}
+	return nil
+}
+
+// start writes the response headers of the event stream. Event streams are long lived, so the write deadline of
+// the HTTP server is lifted.
+func (s *eventStream) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+
+	writeMetadata(s.resp.Header(), s.header)
+	s.resp.Header().Set("Content-Type", contentTypeEventStream)
+	s.resp.Header().Set("Cache-Control", "no-cache")
+	s.resp.WriteHeader(http.StatusOK)
+	// Not supported by every response writer, in which case the stream is bound by the server write timeout
+	_ = s.controller.SetWriteDeadline(time.Time{})
+}
+
+func (s *eventStream) writeMetadata(header http.Header) {
+	writeMetadata(header, s.header)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+)
+
+const (
+	// scoreSmoothing is the weight of the latest request in the rolling scores of an endorser.
+	scoreSmoothing = 0.2
+	// minimumLatency bounds the weight of endorsers with no latency in the weighted round-robin selection.
+	minimumLatency = time.Millisecond
+)
+
+// endorserScore is the rolling latency and error score of an endorser.
+type endorserScore struct {
+	latency   float64 // seconds, in which failed requests count as the failure penalty
+	errorRate float64
+	samples   int
+	failures  int // consecutive failed requests
+	openUntil time.Time
+	current   float64 // current weight in the smooth weighted round-robin selection
+}
+
+// endorserScores keeps the scores of the endorsers known to the gateway, keyed by PKI ID so that they survive
+// the reconnection of an endorser.
+type endorserScores struct {
+	lock    sync.Mutex
+	scores  map[string]*endorserScore
+	options config.EndorserSelectionOptions
+	penalty time.Duration
+	metrics *Metrics
+	now     func() time.Time
+}
+
+func newEndorserScores(options config.Options, metrics *Metrics) *endorserScores {
+	selection := options.EndorserSelection
+	switch selection.Strategy {
+	case config.HeightSelection, config.LeastLatencySelection, config.WeightedRoundRobinSelection:
+	case "":
+		selection.Strategy = config.HeightSelection
+	default:
+		logger.Warnw("Unknown endorser selection strategy, selecting endorsers by height", "strategy", selection.Strategy)
+		selection.Strategy = config.HeightSelection
+	}
+
+	return &endorserScores{
+		scores:  map[string]*endorserScore{},
+		options: selection,
+		penalty: options.EndorsementTimeout,
+		metrics: metrics,
+		now:     time.Now,
+	}
+}
+
+func (s *endorserScores) scoreOf(e *endorser) *endorserScore {
+	key := e.pkiid.String()
+	if key == "" {
+		key = e.address
+	}
+	score, ok := s.scores[key]
+	if !ok {
+		score = &endorserScore{}
+		s.scores[key] = score
+	}
+	return score
+}
+
+// endorserFailed returns whether the outcome of a request to an endorser indicates that the endorser is unavailable
+// or unhealthy, namely that it could not be reached or that its chaincode container terminated. The error responses
+// of the chaincode, and the errors of the peer in processing the proposal, are not failures of the endorser.
+func endorserFailed(response *peer.ProposalResponse, err error, remove bool) bool {
+	return (err != nil && response == nil) || remove
+}
+
+// record updates the score of the endorser with the outcome of a request. A failed request is one that
+// indicates the endorser is unavailable or unhealthy, rather than a chaincode or client error.
+func (s *endorserScores) record(e *endorser, elapsed time.Duration, failed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	score := s.scoreOf(e)
+	latency := elapsed.Seconds()
+	errorRate := 0.0
+	if failed {
+		latency = math.Max(latency, s.penalty.Seconds())
+		errorRate = 1
+		score.failures++
+	} else {
+		score.failures = 0
+		score.openUntil = time.Time{}
+	}
+
+	if score.samples == 0 {
+		score.latency = latency
+		score.errorRate = errorRate
+	} else {
+		score.latency += scoreSmoothing * (latency - score.latency)
+		score.errorRate += scoreSmoothing * (errorRate - score.errorRate)
+	}
+	score.samples++
+
+	if failed && s.options.FailureThreshold > 0 && score.failures >= s.options.FailureThreshold {
+		if !score.openUntil.After(s.now()) {
+			logger.Warnw("Circuit breaking endorser after consecutive failures", "endpoint", e.logAddress, "mspid", e.mspid, "failures", score.failures, "duration", s.options.OpenDuration)
+		}
+		score.openUntil = s.now().Add(s.options.OpenDuration)
+	}
+
+	s.metrics.EndorserLatency.With("mspid", e.mspid, "endpoint", e.logAddress).Set(score.latency)
+	s.metrics.EndorserErrorRate.With("mspid", e.mspid, "endpoint", e.logAddress).Set(score.errorRate)
+	s.metrics.EndorserCircuitOpen.With("mspid", e.mspid, "endpoint", e.logAddress).Set(boolToFloat(score.openUntil.After(s.now())))
+}
+
+// load sets the latency score and circuit breaker state of each endorser.
+func (s *endorserScores) load(es []*endorserState) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	for _, e := range es {
+		score := s.scoreOf(e.endorser)
+		e.latency = score.latency
+		e.open = score.openUntil.After(now)
+		if !e.open && !score.openUntil.IsZero() {
+			// the circuit breaker expired - the endorser will be tried again
+			score.openUntil = time.Time{}
+			s.metrics.EndorserCircuitOpen.With("mspid", e.endorser.mspid, "endpoint", e.endorser.logAddress).Set(0)
+		}
+	}
+}
+
+// roundRobin moves the endorser chosen by the smooth weighted round-robin algorithm among the first candidates
+// endorsers to the front. Endorsers are weighted by the inverse of their latency score.
+func (s *endorserScores) roundRobin(es []*endorserState, candidates int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var total float64
+	var chosen int
+	var chosenScore *endorserScore
+	for i := 0; i < candidates; i++ {
+		score := s.scoreOf(es[i].endorser)
+		weight := 1 / math.Max(es[i].latency, minimumLatency.Seconds())
+		score.current += weight
+		total += weight
+		if chosenScore == nil || score.current > chosenScore.current {
+			chosen, chosenScore = i, score
+		}
+	}
+	if chosenScore == nil {
+		return
+	}
+	chosenScore.current -= total
+
+	first := es[chosen]
+	copy(es[1:chosen+1], es[:chosen])
+	es[0] = first
+}
+
+// sortEndorsers orders the endorsers of a group according to the endorser selection strategy, preferring the
+// host peer among otherwise equal endorsers. Circuit-broken endorsers are placed last, so that they are only
+// used if no other endorser is available.
+func (reg *registry) sortEndorsers(es []*endorserState, host string) {
+	reg.scores.load(es)
+	strategy := reg.scores.options.Strategy
+
+	var maxHeight uint64
+	for _, e := range es {
+		if !e.open && e.height > maxHeight {
+			maxHeight = e.height
+		}
+	}
+	// endorsers within the height tolerance of the highest endorser are selected by latency
+	heightBand := func(e *endorserState) uint64 {
+		if strategy != config.HeightSelection && e.height+reg.scores.options.HeightTolerance >= maxHeight {
+			return maxHeight
+		}
+		return e.height
+	}
+
+	sort.SliceStable(es, func(i, j int) bool {
+		if es[i].open != es[j].open {
+			return !es[i].open
+		}
+		if bi, bj := heightBand(es[i]), heightBand(es[j]); bi != bj {
+			return bi > bj
+		}
+		if strategy != config.HeightSelection && es[i].latency != es[j].latency {
+			return es[i].latency < es[j].latency
+		}
+		// prefer host peer
+		return es[i].endorser.address == host && es[j].endorser.address != host
+	})
+
+	if strategy == config.WeightedRoundRobinSelection && len(es) > 1 {
+		candidates := 0
+		for candidates < len(es) && !es[candidates].open && heightBand(es[candidates]) == heightBand(es[0]) {
+			candidates++
+		}
+		reg.scores.roundRobin(es, candidates)
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
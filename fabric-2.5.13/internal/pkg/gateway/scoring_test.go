@@ -0,0 +1,220 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newScoringRegistry(selection config.EndorserSelectionOptions) *registry {
+	options := config.Options{EndorsementTimeout: 10 * time.Second, EndorserSelection: selection}
+	return &registry{scores: newEndorserScores(options, NewMetrics(&disabled.Provider{}))}
+}
+
+func newScoringEndorser(address string) *endorser {
+	return &endorser{endpointConfig: &endpointConfig{pkiid: []byte(address), address: address, logAddress: address, mspid: "msp1"}}
+}
+
+func addresses(es []*endorserState) []string {
+	var result []string
+	for _, e := range es {
+		result = append(result, e.endorser.address)
+	}
+	return result
+}
+
+func TestSortEndorsers(t *testing.T) {
+	peer1 := newScoringEndorser("peer1:7051")
+	peer2 := newScoringEndorser("peer2:7051")
+	peer3 := newScoringEndorser("peer3:7051")
+
+	endorsers := func() []*endorserState {
+		return []*endorserState{
+			{endorser: peer1, height: 4},
+			{endorser: peer2, height: 5},
+			{endorser: peer3, height: 5},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		selection config.EndorserSelectionOptions
+		host      string
+		expected  []string
+	}{
+		{
+			name:      "height",
+			selection: config.EndorserSelectionOptions{Strategy: config.HeightSelection},
+			expected:  []string{"peer2:7051", "peer3:7051", "peer1:7051"},
+		},
+		{
+			name:      "height prefers host",
+			selection: config.EndorserSelectionOptions{Strategy: config.HeightSelection},
+			host:      "peer3:7051",
+			expected:  []string{"peer3:7051", "peer2:7051", "peer1:7051"},
+		},
+		{
+			name:      "height by default",
+			selection: config.EndorserSelectionOptions{},
+			expected:  []string{"peer2:7051", "peer3:7051", "peer1:7051"},
+		},
+		{
+			name:      "height for unknown strategy",
+			selection: config.EndorserSelectionOptions{Strategy: "fastest"},
+			expected:  []string{"peer2:7051", "peer3:7051", "peer1:7051"},
+		},
+		{
+			name:      "least latency",
+			selection: config.EndorserSelectionOptions{Strategy: config.LeastLatencySelection},
+			expected:  []string{"peer3:7051", "peer2:7051", "peer1:7051"},
+		},
+		{
+			name:      "least latency within height tolerance",
+			selection: config.EndorserSelectionOptions{Strategy: config.LeastLatencySelection, HeightTolerance: 1},
+			expected:  []string{"peer1:7051", "peer3:7051", "peer2:7051"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := newScoringRegistry(tt.selection)
+			reg.scores.record(peer1, 10*time.Millisecond, false)
+			reg.scores.record(peer2, 300*time.Millisecond, false)
+			reg.scores.record(peer3, 20*time.Millisecond, false)
+
+			es := endorsers()
+			reg.sortEndorsers(es, tt.host)
+			require.Equal(t, tt.expected, addresses(es))
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	peer1 := newScoringEndorser("peer1:7051")
+	peer2 := newScoringEndorser("peer2:7051")
+
+	reg := newScoringRegistry(config.EndorserSelectionOptions{
+		Strategy:         config.HeightSelection,
+		FailureThreshold: 2,
+		OpenDuration:     time.Minute,
+	})
+	now := time.Unix(1000, 0)
+	reg.scores.now = func() time.Time { return now }
+
+	endorsers := func() []*endorserState {
+		return []*endorserState{
+			{endorser: peer1, height: 5},
+			{endorser: peer2, height: 4},
+		}
+	}
+
+	reg.scores.record(peer1, time.Millisecond, true)
+	es := endorsers()
+	reg.sortEndorsers(es, "")
+	require.Equal(t, []string{"peer1:7051", "peer2:7051"}, addresses(es), "below the failure threshold")
+
+	reg.scores.record(peer1, time.Millisecond, true)
+	es = endorsers()
+	reg.sortEndorsers(es, "")
+	require.Equal(t, []string{"peer2:7051", "peer1:7051"}, addresses(es), "circuit open")
+	require.True(t, es[1].open)
+
+	now = now.Add(time.Minute)
+	es = endorsers()
+	reg.sortEndorsers(es, "")
+	require.Equal(t, []string{"peer1:7051", "peer2:7051"}, addresses(es), "circuit expired")
+
+	reg.scores.record(peer1, time.Millisecond, true)
+	es = endorsers()
+	reg.sortEndorsers(es, "")
+	require.Equal(t, []string{"peer2:7051", "peer1:7051"}, addresses(es), "circuit reopened by the next failure")
+
+	reg.scores.record(peer1, time.Millisecond, false)
+	es = endorsers()
+	reg.sortEndorsers(es, "")
+	require.Equal(t, []string{"peer1:7051", "peer2:7051"}, addresses(es), "circuit closed by a success")
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	peer1 := newScoringEndorser("peer1:7051")
+	peer2 := newScoringEndorser("peer2:7051")
+	peer3 := newScoringEndorser("peer3:7051")
+
+	reg := newScoringRegistry(config.EndorserSelectionOptions{Strategy: config.WeightedRoundRobinSelection})
+	reg.scores.record(peer1, 10*time.Millisecond, false)
+	reg.scores.record(peer2, 30*time.Millisecond, false)
+	reg.scores.record(peer3, 10*time.Millisecond, false)
+
+	selected := map[string]int{}
+	for i := 0; i < 80; i++ {
+		es := []*endorserState{
+			{endorser: peer1, height: 5},
+			{endorser: peer2, height: 5},
+			{endorser: peer3, height: 4},
+		}
+		reg.sortEndorsers(es, "")
+		require.Len(t, es, 3)
+		selected[es[0].endorser.address]++
+	}
+
+	// peer3 is behind on height, and peer1 is weighted three times more than peer2
+	require.Equal(t, map[string]int{"peer1:7051": 60, "peer2:7051": 20}, selected)
+}
+
+func TestEndorserScoreMetrics(t *testing.T) {
+	peer1 := newScoringEndorser("peer1:7051")
+
+	fakeGauge := &metricsfakes.Gauge{}
+	fakeGauge.WithReturns(fakeGauge)
+	provider := &metricsfakes.Provider{}
+	provider.NewGaugeReturns(fakeGauge)
+
+	options := config.Options{
+		EndorsementTimeout: 10 * time.Second,
+		EndorserSelection:  config.EndorserSelectionOptions{FailureThreshold: 1, OpenDuration: time.Minute},
+	}
+	scores := newEndorserScores(options, NewMetrics(provider))
+
+	scores.record(peer1, 100*time.Millisecond, false)
+	scores.record(peer1, time.Second, true)
+
+	require.Equal(t, 6, fakeGauge.SetCallCount())
+	require.Equal(t, []string{"mspid", "msp1", "endpoint", "peer1:7051"}, fakeGauge.WithArgsForCall(0))
+	require.Equal(t, 0.1, fakeGauge.SetArgsForCall(0))
+	require.InDelta(t, 0.1+0.2*(10-0.1), fakeGauge.SetArgsForCall(3), 1e-9, "failure counts as the endorsement timeout")
+	require.InDelta(t, 0.2, fakeGauge.SetArgsForCall(4), 1e-9)
+	require.Equal(t, float64(1), fakeGauge.SetArgsForCall(5))
+}
+
+func TestEndorserFailed(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		response *peer.ProposalResponse
+		err      error
+		failed   bool
+	}{
+		{"success", &peer.ProposalResponse{Response: &peer.Response{Status: 200}}, nil, false},
+		{"unavailable", nil, errors.New("connection refused"), true},
+		{"client error", &peer.ProposalResponse{Response: &peer.Response{Status: 500}}, errors.New("access denied"), false},
+		{"chaincode error", &peer.ProposalResponse{Response: &peer.Response{Status: 400, Message: "invalid"}, Payload: []byte("payload")}, nil, false},
+		{"peer error", &peer.ProposalResponse{Response: &peer.Response{Status: 500, Message: "chaincode definition not found"}}, nil, false},
+		{"chaincode terminated", &peer.ProposalResponse{Response: &peer.Response{Status: 500, Message: "error in simulation: " + chaincode.ErrorStreamTerminated}}, nil, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, remove := responseStatus(tt.response, tt.err)
+			require.Equal(t, tt.failed, endorserFailed(tt.response, tt.err, remove))
+		})
+	}
+}
@@ -35,6 +35,12 @@ func (gs *Server) Submit(ctx context.Context, request *gp.SubmitRequest) (*gp.Su
 	if len(txn.Signature) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "prepared transaction must be signed")
 	}
+
+	release, err := gs.limits.acquire(ctx, "Submit", request.GetChannelId(), envelopeSignedData(txn))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	orderers, err := gs.registry.orderers(request.ChannelId)
 	if err != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
@@ -93,6 +99,20 @@ func (gs *Server) Submit(ctx context.Context, request *gp.SubmitRequest) (*gp.Su
 }
 
 func (gs *Server) broadcast(ctx context.Context, orderer *orderer, txn *common.Envelope) (*ab.BroadcastResponse, error) {
+	ctx, span := startEndpointSpan(ctx, "gateway.broadcast", orderer.endpointConfig)
+	response, err := broadcastTransaction(ctx, orderer, txn)
+	if err != nil {
+		endEndpointSpan(span, codes.Unavailable, err.Error())
+	} else if response.GetStatus() != common.Status_SUCCESS {
+		endEndpointSpan(span, codes.Aborted, response.GetStatus().String())
+	} else {
+		endEndpointSpan(span, codes.OK, "")
+	}
+
+	return response, err
+}
+
+func broadcastTransaction(ctx context.Context, orderer *orderer, txn *common.Envelope) (*ab.BroadcastResponse, error) {
 	broadcast, err := orderer.client.Broadcast(ctx)
 	if err != nil {
 		return nil, err
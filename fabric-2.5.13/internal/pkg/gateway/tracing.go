@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+)
+
+const tracerName = "github.com/hyperledger/fabric/internal/pkg/gateway"
+
+// startEndpointSpan starts a span recording a request sent by the gateway to an endorser or orderer, so the time
+// spent by each node within a trace can be attributed to its address and organization.
+func startEndpointSpan(ctx context.Context, name string, endpoint *endpointConfig, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	attributes = append(attributes,
+		attribute.String("fabric.endpoint", endpoint.logAddress),
+		attribute.String("fabric.mspid", endpoint.mspid),
+	)
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// endEndpointSpan records the outcome of the request on the span, and ends it.
+func endEndpointSpan(span trace.Span, code codes.Code, message string) {
+	if code != codes.OK {
+		span.SetStatus(otelcodes.Error, message)
+	}
+	span.End()
+}
@@ -9,6 +9,7 @@ package blocksprovider
 import (
 	"context"
 	"math"
+	"math/rand"
 	"time"
 
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -71,6 +72,7 @@ type BlockVerifier interface {
 //go:generate counterfeiter -o fake/orderer_connection_source.go --fake-name OrdererConnectionSource . OrdererConnectionSource
 type OrdererConnectionSource interface {
 	RandomEndpoint() (*orderers.Endpoint, error)
+	Endpoints() []*orderers.Endpoint
 }
 
 //go:generate counterfeiter -o fake/dialer.go --fake-name Dialer . Dialer
@@ -105,7 +107,22 @@ type Deliverer struct {
 	// TLSCertHash should be nil when TLS is not enabled
 	TLSCertHash []byte // util.ComputeSHA256(b.credSupport.GetClientCertificate().Certificate[0])
 
+	// CrossCheckEnabled makes the deliverer periodically fetch the newest block of
+	// other ordering service nodes, and switch to a different ordering service node
+	// when the one it pulls blocks from is suspected of withholding blocks.
+	CrossCheckEnabled bool
+	// CrossCheckOrderers is the number of other ordering service nodes to fetch the
+	// newest block from, zero means all of them.
+	CrossCheckOrderers int
+	CrossCheckInterval time.Duration
+	// SuspicionThreshold is the time an ordering service node is given to deliver
+	// a block that other ordering service nodes already have.
+	SuspicionThreshold time.Duration
+
 	sleeper sleeper
+	// suspects holds the addresses of the ordering service nodes which are suspected
+	// of withholding blocks, and is only accessed by the DeliverBlocks go routine.
+	suspects map[string]struct{}
 }
 
 const backoffExponentBase = 1.2
@@ -169,6 +186,14 @@ func (d *Deliverer) DeliverBlocks() {
 		connLogger := d.Logger.With("orderer-address", endpoint.Address)
 		connLogger.Infow("Pulling next blocks from ordering service", "nextBlock", ledgerHeight)
 
+		var checker *crossChecker
+		var suspicionC <-chan error
+		if d.CrossCheckEnabled {
+			checker = newCrossChecker(d, endpoint, ledgerHeight)
+			suspicionC = checker.suspicionC
+			go checker.run()
+		}
+
 		recv := make(chan *orderer.DeliverResponse)
 		go func() {
 			for {
@@ -206,11 +231,24 @@ func (d *Deliverer) DeliverBlocks() {
 					break RecvLoop
 				}
 				failureCounter = 0
+				if checker != nil && response.GetBlock() != nil {
+					checker.blockReceived(response.GetBlock())
+				}
+			case err := <-suspicionC:
+				connLogger.Warningf("Ordering service node is suspected of withholding blocks, switching to another ordering service node: %s", err)
+				if d.suspects == nil {
+					d.suspects = map[string]struct{}{}
+				}
+				d.suspects[endpoint.Address] = struct{}{}
+				break RecvLoop
 			case <-d.DoneC:
 				break RecvLoop
 			}
 		}
 
+		if checker != nil {
+			checker.stop()
+		}
 		// cancel and wait for our spawned go routine to exit
 		cancel()
 		<-recv
@@ -285,7 +323,7 @@ func (d *Deliverer) Stop() {
 }
 
 func (d *Deliverer) connect(seekInfoEnv *common.Envelope) (orderer.AtomicBroadcast_DeliverClient, *orderers.Endpoint, func(), error) {
-	endpoint, err := d.Orderers.RandomEndpoint()
+	endpoint, err := d.selectEndpoint()
 	if err != nil {
 		return nil, nil, nil, errors.WithMessage(err, "could not get orderer endpoints")
 	}
@@ -319,28 +357,53 @@ func (d *Deliverer) connect(seekInfoEnv *common.Envelope) (orderer.AtomicBroadca
 	}, nil
 }
 
+// selectEndpoint returns a random endpoint among the endpoints which are not suspected
+// of withholding blocks. If all endpoints are suspected, the suspicions are cleared.
+func (d *Deliverer) selectEndpoint() (*orderers.Endpoint, error) {
+	if len(d.suspects) == 0 {
+		return d.Orderers.RandomEndpoint()
+	}
+
+	var candidates []*orderers.Endpoint
+	for _, endpoint := range d.Orderers.Endpoints() {
+		if _, suspected := d.suspects[endpoint.Address]; !suspected {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	if len(candidates) == 0 {
+		d.Logger.Warning("All ordering service nodes are suspected of withholding blocks, clearing suspicions")
+		d.suspects = nil
+		return d.Orderers.RandomEndpoint()
+	}
+	return candidates[rand.Intn(len(candidates))], nil
+}
+
 func (d *Deliverer) createSeekInfo(ledgerHeight uint64) (*common.Envelope, error) {
-	return protoutil.CreateSignedEnvelopeWithTLSBinding(
-		common.HeaderType_DELIVER_SEEK_INFO,
-		d.ChannelID,
-		d.Signer,
-		&orderer.SeekInfo{
-			Start: &orderer.SeekPosition{
-				Type: &orderer.SeekPosition_Specified{
-					Specified: &orderer.SeekSpecified{
-						Number: ledgerHeight,
-					},
+	return d.createSignedSeekInfo(&orderer.SeekInfo{
+		Start: &orderer.SeekPosition{
+			Type: &orderer.SeekPosition_Specified{
+				Specified: &orderer.SeekSpecified{
+					Number: ledgerHeight,
 				},
 			},
-			Stop: &orderer.SeekPosition{
-				Type: &orderer.SeekPosition_Specified{
-					Specified: &orderer.SeekSpecified{
-						Number: math.MaxUint64,
-					},
+		},
+		Stop: &orderer.SeekPosition{
+			Type: &orderer.SeekPosition_Specified{
+				Specified: &orderer.SeekSpecified{
+					Number: math.MaxUint64,
 				},
 			},
-			Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
 		},
+		Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+	})
+}
+
+func (d *Deliverer) createSignedSeekInfo(seekInfo *orderer.SeekInfo) (*common.Envelope, error) {
+	return protoutil.CreateSignedEnvelopeWithTLSBinding(
+		common.HeaderType_DELIVER_SEEK_INFO,
+		d.ChannelID,
+		d.Signer,
+		seekInfo,
 		int32(0),
 		uint64(0),
 		d.TLSCertHash,
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package blocksprovider_test
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -597,4 +598,66 @@ var _ = Describe("Blocksprovider", func() {
 			})
 		})
 	})
+
+	When("cross checking is enabled", func() {
+		var fakeProbeClient *fake.DeliverClient
+
+		BeforeEach(func() {
+			fakeOrdererConnectionSource.EndpointsReturns([]*orderers.Endpoint{
+				{Address: "orderer-address"},
+				{Address: "orderer-address-2"},
+			})
+
+			fakeProbeClient = &fake.DeliverClient{}
+			fakeProbeClient.RecvReturns(&orderer.DeliverResponse{
+				Type: &orderer.DeliverResponse_Block{
+					Block: &common.Block{
+						Header: &common.BlockHeader{
+							Number: 100,
+						},
+					},
+				},
+			}, nil)
+
+			// appease the race detector
+			fakeDeliverClient := fakeDeliverClient
+			fakeProbeClient := fakeProbeClient
+
+			// the newest block requests of the cross checker are bounded by a deadline
+			fakeDeliverStreamer.DeliverStub = func(ctx context.Context, _ *grpc.ClientConn) (orderer.AtomicBroadcast_DeliverClient, error) {
+				if _, ok := ctx.Deadline(); ok {
+					return fakeProbeClient, nil
+				}
+				return fakeDeliverClient, nil
+			}
+
+			d.CrossCheckEnabled = true
+			d.CrossCheckInterval = 10 * time.Millisecond
+			d.SuspicionThreshold = 0
+		})
+
+		It("requests the newest block of the other ordering service node", func() {
+			Eventually(fakeProbeClient.SendCallCount).ShouldNot(BeZero())
+			env := fakeProbeClient.SendArgsForCall(0)
+			seekInfo := &orderer.SeekInfo{}
+			_, err := protoutil.UnmarshalEnvelopeOfType(env, common.HeaderType_DELIVER_SEEK_INFO, seekInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seekInfo.Start.GetNewest()).NotTo(BeNil())
+			Expect(seekInfo.Stop.GetNewest()).NotTo(BeNil())
+			Expect(seekInfo.Behavior).To(Equal(orderer.SeekInfo_FAIL_IF_NOT_READY))
+
+			_, blockNum, _ := fakeBlockVerifier.VerifyBlockArgsForCall(0)
+			Expect(blockNum).To(Equal(uint64(100)))
+		})
+
+		It("switches to another ordering service node when the blocks are withheld", func() {
+			Eventually(fakeDeliverClient.SendCallCount).Should(BeNumerically(">=", 2))
+			Expect(fakeOrdererConnectionSource.EndpointsCallCount()).To(BeNumerically(">", 0))
+			Expect(fakeSleeper.SleepCallCount()).To(Equal(0))
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			Expect(fakeDialer.DialArgsForCall(0)).To(Equal("orderer-address"))
+		})
+	})
 })
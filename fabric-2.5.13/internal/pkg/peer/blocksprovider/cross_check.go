@@ -0,0 +1,228 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocksprovider
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// headerHistorySize is the number of the most recent block header hashes received from
+// the source ordering service node that are kept for comparison with the blocks
+// fetched from other ordering service nodes
+const headerHistorySize = 100
+
+// crossChecker periodically fetches the newest block of ordering service nodes other
+// than the source ordering service node blocks are pulled from. The source is suspected
+// of withholding blocks if it does not deliver a block that another ordering service
+// node has within the suspicion threshold, or if it delivered a block whose header
+// differs from the header of a block with the same number of another ordering service node.
+type crossChecker struct {
+	deliverer   *Deliverer
+	source      *orderers.Endpoint
+	fetchNewest func(endpoint *orderers.Endpoint) (*common.Block, error)
+	now         func() time.Time
+
+	lock    sync.Mutex
+	height  uint64
+	headers map[uint64][]byte
+
+	// pendingBlock is the number of a block that other ordering service nodes
+	// have, and the source did not deliver yet since pendingSince
+	pendingBlock uint64
+	pendingSince time.Time
+
+	suspicionC chan error
+	doneC      chan struct{}
+	stopOnce   sync.Once
+}
+
+func newCrossChecker(d *Deliverer, source *orderers.Endpoint, ledgerHeight uint64) *crossChecker {
+	c := &crossChecker{
+		deliverer:  d,
+		source:     source,
+		now:        time.Now,
+		height:     ledgerHeight,
+		headers:    map[uint64][]byte{},
+		suspicionC: make(chan error, 1),
+		doneC:      make(chan struct{}),
+	}
+	c.fetchNewest = c.fetchNewestBlock
+	return c
+}
+
+// blockReceived records a verified block delivered by the source
+func (c *crossChecker) blockReceived(block *common.Block) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	number := block.Header.Number
+	c.headers[number] = protoutil.BlockHeaderHash(block.Header)
+	if number >= headerHistorySize {
+		delete(c.headers, number-headerHistorySize)
+	}
+	if number+1 > c.height {
+		c.height = number + 1
+	}
+}
+
+func (c *crossChecker) run() {
+	ticker := time.NewTicker(c.deliverer.CrossCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.doneC:
+			return
+		case <-c.deliverer.DoneC:
+			return
+		}
+
+		if err := c.check(); err != nil {
+			c.suspicionC <- err
+			return
+		}
+	}
+}
+
+func (c *crossChecker) stop() {
+	c.stopOnce.Do(func() {
+		close(c.doneC)
+	})
+}
+
+// check fetches the newest block of the other ordering service nodes and returns an
+// error if the source is suspected of withholding blocks
+func (c *crossChecker) check() error {
+	var newest *common.Block
+	var newestAddress string
+	for _, endpoint := range c.endpointsToCheck() {
+		block, err := c.fetchNewest(endpoint)
+		if err != nil {
+			c.deliverer.Logger.Debugf("Could not fetch the newest block from ordering service node '%s': %s", endpoint.Address, err)
+			continue
+		}
+		if err := c.compareHeader(block, endpoint.Address); err != nil {
+			return err
+		}
+		if newest == nil || block.Header.Number > newest.Header.Number {
+			newest = block
+			newestAddress = endpoint.Address
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.pendingSince.IsZero() && c.height > c.pendingBlock {
+		c.pendingSince = time.Time{}
+	}
+	if newest == nil || newest.Header.Number < c.height {
+		return nil
+	}
+	if c.pendingSince.IsZero() {
+		c.pendingBlock = c.height
+		c.pendingSince = c.now()
+		c.deliverer.Logger.Debugf("Ordering service node '%s' has block [%d] which was not delivered yet by '%s'", newestAddress, c.pendingBlock, c.source.Address)
+		return nil
+	}
+	if elapsed := c.now().Sub(c.pendingSince); elapsed >= c.deliverer.SuspicionThreshold {
+		return errors.Errorf("block [%d] was not delivered by '%s' within %v, while ordering service node '%s' has blocks up to [%d]",
+			c.pendingBlock, c.source.Address, c.deliverer.SuspicionThreshold, newestAddress, newest.Header.Number)
+	}
+	return nil
+}
+
+// compareHeader returns an error if the source delivered a block with the number of
+// the given block, but with a different header
+func (c *crossChecker) compareHeader(block *common.Block, address string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	received, exists := c.headers[block.Header.Number]
+	if !exists || bytes.Equal(received, protoutil.BlockHeaderHash(block.Header)) {
+		return nil
+	}
+	c.deliverer.Logger.Errorf("Block [%d] delivered by '%s' differs from the block with the same number of ordering service node '%s'",
+		block.Header.Number, c.source.Address, address)
+	return errors.Errorf("header of block [%d] delivered by '%s' differs from the header of ordering service node '%s'",
+		block.Header.Number, c.source.Address, address)
+}
+
+// endpointsToCheck returns a random selection of CrossCheckOrderers endpoints other than
+// the source, or all of them if CrossCheckOrderers is zero
+func (c *crossChecker) endpointsToCheck() []*orderers.Endpoint {
+	var endpoints []*orderers.Endpoint
+	for _, endpoint := range c.deliverer.Orderers.Endpoints() {
+		if endpoint.Address != c.source.Address {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	rand.Shuffle(len(endpoints), func(i, j int) {
+		endpoints[i], endpoints[j] = endpoints[j], endpoints[i]
+	})
+	if c.deliverer.CrossCheckOrderers > 0 && len(endpoints) > c.deliverer.CrossCheckOrderers {
+		endpoints = endpoints[:c.deliverer.CrossCheckOrderers]
+	}
+	return endpoints
+}
+
+// fetchNewestBlock retrieves and verifies the newest block of the given ordering service node
+func (c *crossChecker) fetchNewestBlock(endpoint *orderers.Endpoint) (*common.Block, error) {
+	d := c.deliverer
+	seekInfoEnv, err := d.createSignedSeekInfo(&orderer.SeekInfo{
+		Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+		Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+		Behavior: orderer.SeekInfo_FAIL_IF_NOT_READY,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create a signed Deliver SeekInfo message")
+	}
+
+	conn, err := d.Dialer.Dial(endpoint.Address, endpoint.RootCerts)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not dial endpoint '%s'", endpoint.Address)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.CrossCheckInterval)
+	defer cancel()
+
+	deliverClient, err := d.DeliverStreamer.Deliver(ctx, conn)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not create deliver client to endpoint '%s'", endpoint.Address)
+	}
+	defer deliverClient.CloseSend()
+
+	if err := deliverClient.Send(seekInfoEnv); err != nil {
+		return nil, errors.WithMessagef(err, "could not send deliver seek info to '%s'", endpoint.Address)
+	}
+
+	response, err := deliverClient.Recv()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not receive the newest block from '%s'", endpoint.Address)
+	}
+	block := response.GetBlock()
+	if block == nil {
+		return nil, errors.Errorf("expected a block from '%s' but got status %v", endpoint.Address, response.GetStatus())
+	}
+	if err := d.BlockVerifier.VerifyBlock(gossipcommon.ChannelID(d.ChannelID), block.Header.Number, block); err != nil {
+		return nil, errors.WithMessagef(err, "block from '%s' could not be verified", endpoint.Address)
+	}
+	return block, nil
+}
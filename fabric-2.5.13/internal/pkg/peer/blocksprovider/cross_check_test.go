@@ -0,0 +1,220 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocksprovider
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
+)
+
+type endpointsSource struct {
+	endpoints      []*orderers.Endpoint
+	endpointsCalls int
+}
+
+func (s *endpointsSource) RandomEndpoint() (*orderers.Endpoint, error) {
+	return s.endpoints[0], nil
+}
+
+func (s *endpointsSource) Endpoints() []*orderers.Endpoint {
+	s.endpointsCalls++
+	return s.endpoints
+}
+
+var _ = Describe("CrossChecker", func() {
+	var (
+		d             *Deliverer
+		c             *crossChecker
+		ordererSource *endpointsSource
+		source        *orderers.Endpoint
+		newestBlocks  map[string]*common.Block
+		now           time.Time
+	)
+
+	block := func(number uint64, data string) *common.Block {
+		return &common.Block{
+			Header: &common.BlockHeader{
+				Number:   number,
+				DataHash: []byte(data),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		source = &orderers.Endpoint{Address: "orderer-1"}
+		ordererSource = &endpointsSource{
+			endpoints: []*orderers.Endpoint{
+				source,
+				{Address: "orderer-2"},
+				{Address: "orderer-3"},
+			},
+		}
+
+		d = &Deliverer{
+			ChannelID:          "channel-id",
+			Orderers:           ordererSource,
+			DoneC:              make(chan struct{}),
+			Logger:             flogging.MustGetLogger("blocksprovider"),
+			CrossCheckEnabled:  true,
+			CrossCheckInterval: time.Second,
+			SuspicionThreshold: 10 * time.Second,
+		}
+
+		newestBlocks = map[string]*common.Block{}
+		now = time.Now()
+		c = newCrossChecker(d, source, 5)
+		c.now = func() time.Time { return now }
+		c.fetchNewest = func(endpoint *orderers.Endpoint) (*common.Block, error) {
+			block, ok := newestBlocks[endpoint.Address]
+			if !ok {
+				return nil, fmt.Errorf("unreachable")
+			}
+			return block, nil
+		}
+	})
+
+	It("checks all the other ordering service nodes", func() {
+		endpoints := c.endpointsToCheck()
+		Expect(endpoints).To(ConsistOf(
+			&orderers.Endpoint{Address: "orderer-2"},
+			&orderers.Endpoint{Address: "orderer-3"},
+		))
+	})
+
+	When("the number of ordering service nodes to check is limited", func() {
+		BeforeEach(func() {
+			d.CrossCheckOrderers = 1
+		})
+
+		It("checks a subset of the other ordering service nodes", func() {
+			endpoints := c.endpointsToCheck()
+			Expect(endpoints).To(HaveLen(1))
+			Expect(endpoints[0].Address).NotTo(Equal("orderer-1"))
+		})
+	})
+
+	It("does not suspect the source when other ordering service nodes are unreachable", func() {
+		Expect(c.check()).To(Succeed())
+		Expect(c.pendingSince.IsZero()).To(BeTrue())
+	})
+
+	It("does not suspect the source when other ordering service nodes are not ahead", func() {
+		newestBlocks["orderer-2"] = block(4, "block-4")
+		Expect(c.check()).To(Succeed())
+		Expect(c.pendingSince.IsZero()).To(BeTrue())
+	})
+
+	When("another ordering service node has blocks the source did not deliver", func() {
+		BeforeEach(func() {
+			newestBlocks["orderer-2"] = block(4, "block-4")
+			newestBlocks["orderer-3"] = block(7, "block-7")
+			Expect(c.check()).To(Succeed())
+		})
+
+		It("waits for the source to deliver the next block", func() {
+			Expect(c.pendingBlock).To(Equal(uint64(5)))
+			Expect(c.pendingSince).To(Equal(now))
+
+			now = now.Add(5 * time.Second)
+			Expect(c.check()).To(Succeed())
+		})
+
+		It("suspects the source once the suspicion threshold is exceeded", func() {
+			now = now.Add(10 * time.Second)
+			Expect(c.check()).To(MatchError("block [5] was not delivered by 'orderer-1' within 10s, while ordering service node 'orderer-3' has blocks up to [7]"))
+		})
+
+		It("does not suspect the source once it delivered the block", func() {
+			c.blockReceived(block(5, "block-5"))
+			now = now.Add(10 * time.Second)
+			Expect(c.check()).To(Succeed())
+			Expect(c.pendingBlock).To(Equal(uint64(6)))
+			Expect(c.pendingSince).To(Equal(now))
+		})
+	})
+
+	It("suspects the source when it delivered a block with a different header", func() {
+		c.blockReceived(block(5, "block-5"))
+		newestBlocks["orderer-2"] = block(5, "block-5")
+		Expect(c.check()).To(Succeed())
+
+		newestBlocks["orderer-3"] = block(5, "forked-block-5")
+		Expect(c.check()).To(MatchError("header of block [5] delivered by 'orderer-1' differs from the header of ordering service node 'orderer-3'"))
+	})
+
+	It("keeps a bounded history of the block headers", func() {
+		for i := uint64(5); i < 5+2*headerHistorySize; i++ {
+			c.blockReceived(block(i, fmt.Sprintf("block-%d", i)))
+		}
+		Expect(c.headers).To(HaveLen(headerHistorySize))
+		Expect(c.height).To(Equal(uint64(5 + 2*headerHistorySize)))
+	})
+
+	It("reports the suspicion and stops", func() {
+		d.CrossCheckInterval = 10 * time.Millisecond
+		d.SuspicionThreshold = 0
+		newestBlocks["orderer-2"] = block(9, "block-9")
+
+		go c.run()
+		var err error
+		Eventually(c.suspicionC).Should(Receive(&err))
+		Expect(err).To(MatchError(ContainSubstring("block [5] was not delivered by 'orderer-1'")))
+		c.stop()
+		c.stop()
+	})
+})
+
+var _ = Describe("selectEndpoint", func() {
+	var (
+		d             *Deliverer
+		ordererSource *endpointsSource
+	)
+
+	BeforeEach(func() {
+		ordererSource = &endpointsSource{
+			endpoints: []*orderers.Endpoint{
+				{Address: "orderer-1"},
+				{Address: "orderer-2"},
+			},
+		}
+		d = &Deliverer{
+			Orderers: ordererSource,
+			Logger:   flogging.MustGetLogger("blocksprovider"),
+		}
+	})
+
+	It("selects a random endpoint when no endpoint is suspected", func() {
+		endpoint, err := d.selectEndpoint()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(endpoint.Address).To(Equal("orderer-1"))
+		Expect(ordererSource.endpointsCalls).To(Equal(0))
+	})
+
+	It("does not select suspected endpoints", func() {
+		d.suspects = map[string]struct{}{"orderer-1": {}}
+		for i := 0; i < 10; i++ {
+			endpoint, err := d.selectEndpoint()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoint.Address).To(Equal("orderer-2"))
+		}
+	})
+
+	It("clears the suspicions when all endpoints are suspected", func() {
+		d.suspects = map[string]struct{}{"orderer-1": {}, "orderer-2": {}}
+		endpoint, err := d.selectEndpoint()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(endpoint.Address).To(Equal("orderer-1"))
+		Expect(d.suspects).To(BeEmpty())
+	})
+})
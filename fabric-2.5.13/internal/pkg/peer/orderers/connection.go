@@ -23,6 +23,11 @@ type ConnectionSource struct {
 	orgToEndpointsHash map[string][]byte
 	logger             *flogging.FabricLogger
 	overrides          map[string]*Endpoint
+
+	// globalAddrs and orgs are the addresses of the last update,
+	// from which the endpoints are rebuilt when the overrides change
+	globalAddrs []string
+	orgs        map[string]OrdererOrg
 }
 
 type Endpoint struct {
@@ -53,11 +58,23 @@ func (cs *ConnectionSource) RandomEndpoint() (*Endpoint, error) {
 	return cs.allEndpoints[rand.IntN(len(cs.allEndpoints))], nil
 }
 
+// Endpoints returns all the endpoints currently defined
+func (cs *ConnectionSource) Endpoints() []*Endpoint {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+	endpoints := make([]*Endpoint, len(cs.allEndpoints))
+	copy(endpoints, cs.allEndpoints)
+	return endpoints
+}
+
 func (cs *ConnectionSource) Update(globalAddrs []string, orgs map[string]OrdererOrg) {
 	cs.mutex.Lock()
 	defer cs.mutex.Unlock()
 	cs.logger.Debug("Processing updates for orderer endpoints")
 
+	cs.globalAddrs = globalAddrs
+	cs.orgs = orgs
+
 	newOrgToEndpointsHash := map[string][]byte{}
 
 	anyChange := false
@@ -133,6 +150,54 @@ func (cs *ConnectionSource) Update(globalAddrs []string, orgs map[string]Orderer
 		return
 	}
 
+	cs.refresh(globalAddrs, orgs, hasOrgEndpoints)
+}
+
+// SetOverrides replaces the orderer endpoint overrides, and rebuilds the
+// endpoints of the last update with the new overrides
+func (cs *ConnectionSource) SetOverrides(overrides map[string]*Endpoint) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	if overridesEqual(cs.overrides, overrides) {
+		cs.logger.Debugf("Orderer endpoint overrides were not changed")
+		return
+	}
+	cs.overrides = overrides
+
+	if cs.globalAddrs == nil && cs.orgs == nil {
+		return
+	}
+	hasOrgEndpoints := false
+	for _, org := range cs.orgs {
+		if len(org.Addresses) > 0 {
+			hasOrgEndpoints = true
+		}
+	}
+	cs.logger.Infof("Orderer endpoint overrides were changed, refreshing orderer endpoints")
+	cs.refresh(cs.globalAddrs, cs.orgs, hasOrgEndpoints)
+}
+
+func overridesEqual(o1, o2 map[string]*Endpoint) bool {
+	if len(o1) != len(o2) {
+		return false
+	}
+	for from, e1 := range o1 {
+		e2, ok := o2[from]
+		if !ok || e1.Address != e2.Address || len(e1.RootCerts) != len(e2.RootCerts) {
+			return false
+		}
+		for i := range e1.RootCerts {
+			if !bytes.Equal(e1.RootCerts[i], e2.RootCerts[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// refresh closes the refresh channels of the current endpoints and rebuilds the endpoints
+func (cs *ConnectionSource) refresh(globalAddrs []string, orgs map[string]OrdererOrg, hasOrgEndpoints bool) {
 	for _, endpoint := range cs.allEndpoints {
 		// Alert any existing consumers that have a reference to the old endpoints
 		// that their reference is now stale and they should get a new one.
@@ -145,6 +145,78 @@ var _ = Describe("Connection", func() {
 		})
 	})
 
+	When("the overrides are set to the same overrides", func() {
+		BeforeEach(func() {
+			cs.SetOverrides(map[string]*orderers.Endpoint{
+				"override-address": {
+					Address:   "re-mapped-address",
+					RootCerts: overrideCerts,
+				},
+			})
+		})
+
+		It("does not update the endpoints", func() {
+			Expect(cs.Endpoints()).To(Equal(endpoints))
+			for _, endpoint := range endpoints {
+				Expect(endpoint.Refreshed).NotTo(BeClosed())
+			}
+		})
+	})
+
+	When("the overrides are set to override an existing endpoint", func() {
+		BeforeEach(func() {
+			cs.SetOverrides(map[string]*orderers.Endpoint{
+				"org1-address1": {
+					Address:   "re-mapped-address",
+					RootCerts: overrideCerts,
+				},
+			})
+		})
+
+		It("creates a new set of orderer endpoints with the override", func() {
+			Expect(stripEndpoints(cs.Endpoints())).To(ConsistOf(
+				stripEndpoints([]*orderers.Endpoint{
+					{
+						Address:   "re-mapped-address",
+						RootCerts: overrideCerts,
+					},
+					{
+						Address:   "org1-address2",
+						RootCerts: org1Certs,
+					},
+					{
+						Address:   "org2-address1",
+						RootCerts: org2Certs,
+					},
+					{
+						Address:   "org2-address2",
+						RootCerts: org2Certs,
+					},
+				}),
+			))
+		})
+
+		It("closes the refresh channel for all of the old endpoints", func() {
+			for _, endpoint := range endpoints {
+				Expect(endpoint.Refreshed).To(BeClosed())
+			}
+		})
+
+		It("applies the override to subsequent updates", func() {
+			org1.Addresses = []string{"org1-address1"}
+			cs.Update(nil, map[string]orderers.OrdererOrg{
+				"org1": org1,
+				"org2": org2,
+			})
+			Expect(stripEndpoints(cs.Endpoints())).To(ContainElement(
+				stripEndpoints([]*orderers.Endpoint{{
+					Address:   "re-mapped-address",
+					RootCerts: overrideCerts,
+				}})[0],
+			))
+		})
+	})
+
 	When("an update change's an org's TLS CA", func() {
 		BeforeEach(func() {
 			org1.RootCerts = [][]byte{cert1}
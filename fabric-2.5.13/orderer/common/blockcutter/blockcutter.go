@@ -39,6 +39,9 @@ type receiver struct {
 	PendingBatchStartTime time.Time
 	ChannelID             string
 	Metrics               *Metrics
+
+	// reorderer, if set, reorders the transactions of a batch when it is cut
+	reorderer *reorderer
 }
 
 // NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
@@ -50,6 +53,22 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 	}
 }
 
+// NewReorderingReceiver creates a Receiver implementation that, in addition, reorders the endorser
+// transactions of a batch, based on their read-write sets, when the batch is cut so as to minimize
+// the MVCC read conflicts amongst the transactions of a block. The transactions that are bound to be
+// invalidated due to the conflicts are placed at the end of the batch.
+func NewReorderingReceiver(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics) Receiver {
+	return &receiver{
+		sharedConfigFetcher: sharedConfigFetcher,
+		Metrics:             metrics,
+		ChannelID:           channelID,
+		reorderer: &reorderer{
+			channelID: channelID,
+			metrics:   metrics,
+		},
+	}
+}
+
 // Ordered should be invoked sequentially as messages are ordered
 //
 // messageBatches length: 0, pending: false
@@ -135,6 +154,9 @@ func (r *receiver) Cut() []*cb.Envelope {
 	}
 	r.PendingBatchStartTime = time.Time{}
 	batch := r.pendingBatch
+	if r.reorderer != nil && len(batch) > 1 {
+		batch = r.reorderer.reorder(batch)
+	}
 	r.pendingBatch = nil
 	r.pendingBatchSizeBytes = 0
 	return batch
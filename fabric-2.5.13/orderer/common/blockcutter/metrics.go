@@ -16,12 +16,32 @@ var blockFillDuration = metrics.HistogramOpts{
 	StatsdFormat: "%{#fqname}.%{channel}",
 }
 
+var reorderedTransactions = metrics.CounterOpts{
+	Namespace:    "blockcutter",
+	Name:         "reordered_transactions",
+	Help:         "The number of transactions placed at a different position in a block than the one in which they were ordered.",
+	LabelNames:   []string{"channel"},
+	StatsdFormat: "%{#fqname}.%{channel}",
+}
+
+var conflictingTransactions = metrics.CounterOpts{
+	Namespace:    "blockcutter",
+	Name:         "conflicting_transactions",
+	Help:         "The number of transactions placed at the end of a block as they are bound to be invalidated due to conflicts with other transactions of the block.",
+	LabelNames:   []string{"channel"},
+	StatsdFormat: "%{#fqname}.%{channel}",
+}
+
 type Metrics struct {
-	BlockFillDuration metrics.Histogram
+	BlockFillDuration       metrics.Histogram
+	ReorderedTransactions   metrics.Counter
+	ConflictingTransactions metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
-		BlockFillDuration: p.NewHistogram(blockFillDuration),
+		BlockFillDuration:       p.NewHistogram(blockFillDuration),
+		ReorderedTransactions:   p.NewCounter(reorderedTransactions),
+		ConflictingTransactions: p.NewCounter(conflictingTransactions),
 	}
 }
@@ -21,6 +21,7 @@ var _ = Describe("Metrics", func() {
 		BeforeEach(func() {
 			fakeProvider = &mock.MetricsProvider{}
 			fakeProvider.NewHistogramReturns(&mock.MetricsHistogram{})
+			fakeProvider.NewCounterReturns(&mock.MetricsCounter{})
 		})
 
 		It("uses the provider to initialize its field", func() {
@@ -28,7 +29,11 @@ var _ = Describe("Metrics", func() {
 			Expect(metrics).NotTo(BeNil())
 			Expect(metrics.BlockFillDuration).To(Equal(&mock.MetricsHistogram{}))
 
+			Expect(metrics.ReorderedTransactions).To(Equal(&mock.MetricsCounter{}))
+			Expect(metrics.ConflictingTransactions).To(Equal(&mock.MetricsCounter{}))
+
 			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
+			Expect(fakeProvider.NewCounterCallCount()).To(Equal(2))
 		})
 	})
 })
@@ -0,0 +1,413 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"container/heap"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// reorderer reorders the transactions of a batch so as to minimize the number of transactions
+// that the peers invalidate with MVCC_READ_CONFLICT, in the spirit of Fabric++.
+//
+// A transaction that reads a key (or queries a range that includes the key) must be placed before
+// any other transaction of the block that writes the key, as the read would otherwise be invalidated
+// by the write. These constraints form a directed graph over the transactions of a batch. The
+// transactions that participate in a cycle cannot all be valid; the cycles are broken by removing
+// the transactions with the most dependencies. Similarly, out of the transactions that read different
+// versions of the same key, all but the ones that read the most recent version are bound to be
+// invalidated. The remaining transactions are ordered as per the dependencies, preserving the
+// original order of the independent transactions, followed by the doomed transactions. The doomed
+// transactions are kept in the block so that the peers invalidate them and their clients receive
+// a commit status for them.
+type reorderer struct {
+	channelID string
+	metrics   *Metrics
+}
+
+// rwFootprint captures the keys read and written by a transaction. The keys of the public and
+// hashed data are distinguished by the collection name, which is empty for the public data.
+type rwFootprint struct {
+	reads        map[stateKey]*kvrwset.Version
+	rangeQueries []keyRange
+	writes       []stateKey
+}
+
+type stateKey struct {
+	ns, coll, key string
+}
+
+type keyRange struct {
+	ns, startKey, endKey string
+	// endInclusive is set when the iterator was not exhausted, in which case the end key is the
+	// last key that was read rather than the end key of the query
+	endInclusive bool
+}
+
+// contains returns true if the key could be a part of the range query results. An empty end key
+// denotes a range that is unbounded
+func (r keyRange) contains(k stateKey) bool {
+	if k.coll != "" || k.ns != r.ns || k.key < r.startKey {
+		return false
+	}
+	switch {
+	case r.endKey == "":
+		return true
+	case r.endInclusive:
+		return k.key <= r.endKey
+	default:
+		return k.key < r.endKey
+	}
+}
+
+func (r *reorderer) reorder(batch []*cb.Envelope) []*cb.Envelope {
+	footprints := make([]*rwFootprint, len(batch))
+	for i, env := range batch {
+		footprints[i] = extractFootprint(env)
+	}
+
+	doomed := staleReaders(footprints)
+	successors := dependencyGraph(footprints, doomed)
+	for _, i := range cycleVictims(successors, doomed) {
+		doomed[i] = true
+	}
+
+	order := topologicalOrder(successors, doomed)
+	reordered := make([]*cb.Envelope, 0, len(batch))
+	var moved int
+	for pos, i := range order {
+		if pos != i {
+			moved++
+		}
+		reordered = append(reordered, batch[i])
+	}
+
+	var conflicting int
+	for i := range batch {
+		if !doomed[i] {
+			continue
+		}
+		conflicting++
+		if len(reordered) != i {
+			moved++
+		}
+		reordered = append(reordered, batch[i])
+	}
+
+	if moved > 0 || conflicting > 0 {
+		logger.Debugf("[channel: %s] Reordered %d out of %d transactions of the batch, %d of which are bound to be invalidated", r.channelID, moved, len(batch), conflicting)
+	}
+	r.metrics.ReorderedTransactions.With("channel", r.channelID).Add(float64(moved))
+	r.metrics.ConflictingTransactions.With("channel", r.channelID).Add(float64(conflicting))
+	return reordered
+}
+
+// extractFootprint returns the keys read and written by an endorser transaction. An empty footprint is
+// returned for the other types of transactions and for the envelopes that cannot be parsed; such
+// transactions do not constrain the order of the other transactions.
+func extractFootprint(env *cb.Envelope) *rwFootprint {
+	fp := &rwFootprint{reads: map[stateKey]*kvrwset.Version{}}
+
+	chdr, err := protoutil.ChannelHeader(env)
+	if err != nil || cb.HeaderType(chdr.Type) != cb.HeaderType_ENDORSER_TRANSACTION {
+		return fp
+	}
+	action, err := protoutil.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		logger.Debugf("Could not extract the chaincode action from transaction [%s]: %s", chdr.TxId, err)
+		return fp
+	}
+	txRWSet := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(action.Results, txRWSet); err != nil {
+		logger.Debugf("Could not unmarshal the read-write set of transaction [%s]: %s", chdr.TxId, err)
+		return fp
+	}
+
+	for _, nsRWSet := range txRWSet.NsRwset {
+		ns := nsRWSet.Namespace
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			logger.Debugf("Could not unmarshal the read-write set of namespace [%s] in transaction [%s]: %s", ns, chdr.TxId, err)
+			return &rwFootprint{reads: map[stateKey]*kvrwset.Version{}}
+		}
+		for _, read := range kvRWSet.Reads {
+			fp.reads[stateKey{ns: ns, key: read.Key}] = read.Version
+		}
+		for _, rqi := range kvRWSet.RangeQueriesInfo {
+			fp.rangeQueries = append(fp.rangeQueries, keyRange{
+				ns:           ns,
+				startKey:     rqi.StartKey,
+				endKey:       rqi.EndKey,
+				endInclusive: !rqi.ItrExhausted,
+			})
+		}
+		for _, write := range kvRWSet.Writes {
+			fp.writes = append(fp.writes, stateKey{ns: ns, key: write.Key})
+		}
+		for _, metadataWrite := range kvRWSet.MetadataWrites {
+			fp.writes = append(fp.writes, stateKey{ns: ns, key: metadataWrite.Key})
+		}
+
+		for _, collHashedRWSet := range nsRWSet.CollectionHashedRwset {
+			coll := collHashedRWSet.CollectionName
+			hashedRWSet := &kvrwset.HashedRWSet{}
+			if err := proto.Unmarshal(collHashedRWSet.HashedRwset, hashedRWSet); err != nil {
+				logger.Debugf("Could not unmarshal the hashed read-write set of collection [%s:%s] in transaction [%s]: %s", ns, coll, chdr.TxId, err)
+				return &rwFootprint{reads: map[stateKey]*kvrwset.Version{}}
+			}
+			for _, read := range hashedRWSet.HashedReads {
+				fp.reads[stateKey{ns: ns, coll: coll, key: string(read.KeyHash)}] = read.Version
+			}
+			for _, write := range hashedRWSet.HashedWrites {
+				fp.writes = append(fp.writes, stateKey{ns: ns, coll: coll, key: string(write.KeyHash)})
+			}
+			for _, metadataWrite := range hashedRWSet.MetadataWrites {
+				fp.writes = append(fp.writes, stateKey{ns: ns, coll: coll, key: string(metadataWrite.KeyHash)})
+			}
+		}
+	}
+	return fp
+}
+
+// staleReaders returns the transactions that read a version of a key which is older than the version
+// read by another transaction in the batch. As the version of a key only moves forward, the older
+// version cannot be the committed version when the block is validated.
+func staleReaders(footprints []*rwFootprint) []bool {
+	latest := map[stateKey]*kvrwset.Version{}
+	for _, fp := range footprints {
+		for k, ver := range fp.reads {
+			if ver != nil && isNewer(ver, latest[k]) {
+				latest[k] = ver
+			}
+		}
+	}
+
+	doomed := make([]bool, len(footprints))
+	for i, fp := range footprints {
+		for k, ver := range fp.reads {
+			if ver != nil && isNewer(latest[k], ver) {
+				doomed[i] = true
+				break
+			}
+		}
+	}
+	return doomed
+}
+
+func isNewer(v1, v2 *kvrwset.Version) bool {
+	if v2 == nil {
+		return v1 != nil
+	}
+	return v1.BlockNum > v2.BlockNum || (v1.BlockNum == v2.BlockNum && v1.TxNum > v2.TxNum)
+}
+
+// dependencyGraph returns, for every transaction, the transactions that must be placed after it,
+// i.e., the transactions that write a key the transaction reads. The doomed transactions are excluded.
+func dependencyGraph(footprints []*rwFootprint, doomed []bool) [][]int {
+	writers := map[stateKey][]int{}
+	for j, fp := range footprints {
+		if doomed[j] {
+			continue
+		}
+		for _, k := range fp.writes {
+			writers[k] = append(writers[k], j)
+		}
+	}
+
+	successors := make([][]int, len(footprints))
+	for i, fp := range footprints {
+		if doomed[i] {
+			continue
+		}
+		seen := map[int]struct{}{i: {}}
+		addSuccessor := func(j int) {
+			if _, ok := seen[j]; !ok {
+				seen[j] = struct{}{}
+				successors[i] = append(successors[i], j)
+			}
+		}
+		for k := range fp.reads {
+			for _, j := range writers[k] {
+				addSuccessor(j)
+			}
+		}
+		for _, r := range fp.rangeQueries {
+			for k, ws := range writers {
+				if r.contains(k) {
+					for _, j := range ws {
+						addSuccessor(j)
+					}
+				}
+			}
+		}
+	}
+	return successors
+}
+
+// cycleVictims returns the transactions to remove from the dependency graph so that it becomes acyclic.
+// Within each strongly connected component, the transaction with the highest number of dependencies
+// within the component is removed, preferring the latest transaction in case of a tie, until no
+// component contains more than one transaction.
+func cycleVictims(successors [][]int, doomed []bool) []int {
+	removed := make([]bool, len(successors))
+	copy(removed, doomed)
+
+	var victims []int
+	for {
+		components := stronglyConnectedComponents(successors, removed)
+		found := false
+		for _, component := range components {
+			if len(component) < 2 {
+				continue
+			}
+			found = true
+
+			inComponent := map[int]struct{}{}
+			for _, i := range component {
+				inComponent[i] = struct{}{}
+			}
+			degree := map[int]int{}
+			for _, i := range component {
+				for _, j := range successors[i] {
+					if _, ok := inComponent[j]; ok {
+						degree[i]++
+						degree[j]++
+					}
+				}
+			}
+
+			victim := component[0]
+			for _, i := range component[1:] {
+				if degree[i] > degree[victim] || (degree[i] == degree[victim] && i > victim) {
+					victim = i
+				}
+			}
+			removed[victim] = true
+			victims = append(victims, victim)
+		}
+		if !found {
+			return victims
+		}
+	}
+}
+
+// stronglyConnectedComponents implements Tarjan's algorithm over the transactions that are not removed
+func stronglyConnectedComponents(successors [][]int, removed []bool) [][]int {
+	n := len(successors)
+	index := make([]int, n)
+	lowLink := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range index {
+		index[i] = -1
+	}
+
+	var components [][]int
+	var stack []int
+	nextIndex := 0
+
+	var strongConnect func(v int)
+	strongConnect = func(v int) {
+		index[v], lowLink[v] = nextIndex, nextIndex
+		nextIndex++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range successors[v] {
+			if removed[w] {
+				continue
+			}
+			if index[w] == -1 {
+				strongConnect(w)
+				if lowLink[w] < lowLink[v] {
+					lowLink[v] = lowLink[w]
+				}
+			} else if onStack[w] && index[w] < lowLink[v] {
+				lowLink[v] = index[w]
+			}
+		}
+
+		if lowLink[v] == index[v] {
+			var component []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+
+	for v := 0; v < n; v++ {
+		if !removed[v] && index[v] == -1 {
+			strongConnect(v)
+		}
+	}
+	return components
+}
+
+// topologicalOrder orders the transactions that are not removed as per the dependencies. Amongst
+// the transactions that are ready to be placed, the one that was ordered first is picked, so that
+// the original order is retained as much as possible.
+func topologicalOrder(successors [][]int, removed []bool) []int {
+	inDegree := make([]int, len(successors))
+	for i := range successors {
+		if removed[i] {
+			continue
+		}
+		for _, j := range successors[i] {
+			if !removed[j] {
+				inDegree[j]++
+			}
+		}
+	}
+
+	ready := &intHeap{}
+	for i := range successors {
+		if !removed[i] && inDegree[i] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+
+	var order []int
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		order = append(order, i)
+		for _, j := range successors[i] {
+			if removed[j] {
+				continue
+			}
+			inDegree[j]--
+			if inDegree[j] == 0 {
+				heap.Push(ready, j)
+			}
+		}
+	}
+	return order
+}
+
+type intHeap []int
+
+func (h intHeap) Len() int            { return len(h) }
+func (h intHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+var _ = Describe("Reordering", func() {
+	var (
+		fakeConfig                  *mock.OrdererConfig
+		fakeConfigFetcher           *mock.OrdererConfigFetcher
+		fakeReorderedTransactions   *mock.MetricsCounter
+		fakeConflictingTransactions *mock.MetricsCounter
+		metrics                     *blockcutter.Metrics
+	)
+
+	BeforeEach(func() {
+		fakeConfig = &mock.OrdererConfig{}
+		fakeConfig.BatchSizeReturns(&ab.BatchSize{
+			MaxMessageCount:   10,
+			PreferredMaxBytes: 100000,
+		})
+		fakeConfigFetcher = &mock.OrdererConfigFetcher{}
+		fakeConfigFetcher.OrdererConfigReturns(fakeConfig, true)
+
+		fakeBlockFillDuration := &mock.MetricsHistogram{}
+		fakeBlockFillDuration.WithReturns(fakeBlockFillDuration)
+		fakeReorderedTransactions = &mock.MetricsCounter{}
+		fakeReorderedTransactions.WithReturns(fakeReorderedTransactions)
+		fakeConflictingTransactions = &mock.MetricsCounter{}
+		fakeConflictingTransactions.WithReturns(fakeConflictingTransactions)
+		metrics = &blockcutter.Metrics{
+			BlockFillDuration:       fakeBlockFillDuration,
+			ReorderedTransactions:   fakeReorderedTransactions,
+			ConflictingTransactions: fakeConflictingTransactions,
+		}
+	})
+
+	cut := func(bc blockcutter.Receiver, envs ...*cb.Envelope) []*cb.Envelope {
+		for _, env := range envs {
+			bc.Ordered(env)
+		}
+		return bc.Cut()
+	}
+
+	It("places the readers of a key before its writers", func() {
+		bc := blockcutter.NewReorderingReceiver("mychannel", fakeConfigFetcher, metrics)
+		writer := endorserTx("writer", txRWSet{writes: []string{"key1"}})
+		reader1 := endorserTx("reader1", txRWSet{reads: map[string]*kvrwset.Version{"key1": {BlockNum: 1}}})
+		independent := endorserTx("independent", txRWSet{writes: []string{"key2"}})
+		reader2 := endorserTx("reader2", txRWSet{reads: map[string]*kvrwset.Version{"key1": {BlockNum: 1}}, writes: []string{"key3"}})
+
+		batch := cut(bc, writer, reader1, independent, reader2)
+		Expect(batch).To(Equal([]*cb.Envelope{reader1, independent, reader2, writer}))
+
+		Expect(fakeReorderedTransactions.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+		Expect(fakeReorderedTransactions.AddArgsForCall(0)).To(Equal(float64(4)))
+		Expect(fakeConflictingTransactions.AddArgsForCall(0)).To(Equal(float64(0)))
+	})
+
+	It("places the range queries before the writes within the range", func() {
+		bc := blockcutter.NewReorderingReceiver("mychannel", fakeConfigFetcher, metrics)
+		writerInRange := endorserTx("writerInRange", txRWSet{writes: []string{"key5"}})
+		writerOutOfRange := endorserTx("writerOutOfRange", txRWSet{writes: []string{"key9"}})
+		rangeQuery := endorserTx("rangeQuery", txRWSet{rangeStart: "key1", rangeEnd: "key9", itrExhausted: true})
+
+		batch := cut(bc, writerInRange, writerOutOfRange, rangeQuery)
+		Expect(batch).To(Equal([]*cb.Envelope{writerOutOfRange, rangeQuery, writerInRange}))
+	})
+
+	It("includes the last key read in the range when the iterator was not exhausted", func() {
+		bc := blockcutter.NewReorderingReceiver("mychannel", fakeConfigFetcher, metrics)
+		writerOfLastKey := endorserTx("writerOfLastKey", txRWSet{writes: []string{"key9"}})
+		writerOutOfRange := endorserTx("writerOutOfRange", txRWSet{writes: []string{"key95"}})
+		rangeQuery := endorserTx("rangeQuery", txRWSet{rangeStart: "key1", rangeEnd: "key9"})
+
+		batch := cut(bc, writerOfLastKey, writerOutOfRange, rangeQuery)
+		Expect(batch).To(Equal([]*cb.Envelope{writerOutOfRange, rangeQuery, writerOfLastKey}))
+	})
+
+	It("breaks the cycles of dependencies", func() {
+		bc := blockcutter.NewReorderingReceiver("mychannel", fakeConfigFetcher, metrics)
+		// tx1 and tx2 both read and write key1; only one of them can be valid
+		tx1 := endorserTx("tx1", txRWSet{reads: map[string]*kvrwset.Version{"key1": {BlockNum: 1}}, writes: []string{"key1"}})
+		tx2 := endorserTx("tx2", txRWSet{reads: map[string]*kvrwset.Version{"key1": {BlockNum: 1}}, writes: []string{"key1"}})
+		tx3 := endorserTx("tx3", txRWSet{writes: []string{"key2"}})
+
+		batch := cut(bc, tx1, tx2, tx3)
+		Expect(batch).To(Equal([]*cb.Envelope{tx1, tx3, tx2}))
+	})
+
+	It("keeps the doomed transactions at the end of the block", func() {
+		bc := blockcutter.NewReorderingReceiver("mychannel", fakeConfigFetcher, metrics)
+		tx1 := endorserTx("tx1", txRWSet{reads: map[string]*kvrwset.Version{"key1": {BlockNum: 1}}, writes: []string{"key1"}})
+		tx2 := endorserTx("tx2", txRWSet{reads: map[string]*kvrwset.Version{"key1": {BlockNum: 1}}, writes: []string{"key1"}})
+		// tx3 read an older version of key2 than tx4
+		tx3 := endorserTx("tx3", txRWSet{reads: map[string]*kvrwset.Version{"key2": {BlockNum: 1}}})
+		tx4 := endorserTx("tx4", txRWSet{reads: map[string]*kvrwset.Version{"key2": {BlockNum: 2}}})
+
+		batch := cut(bc, tx1, tx2, tx3, tx4)
+		Expect(batch).To(Equal([]*cb.Envelope{tx1, tx4, tx2, tx3}))
+		Expect(fakeConflictingTransactions.AddArgsForCall(0)).To(Equal(float64(2)))
+	})
+
+	It("does not reorder the transactions that cannot be parsed", func() {
+		bc := blockcutter.NewReorderingReceiver("mychannel", fakeConfigFetcher, metrics)
+		garbage1 := &cb.Envelope{Payload: []byte("garbage1")}
+		garbage2 := &cb.Envelope{Payload: []byte("garbage2")}
+		configTx := &cb.Envelope{Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_CONFIG)})},
+		})}
+
+		batch := cut(bc, garbage1, configTx, garbage2)
+		Expect(batch).To(Equal([]*cb.Envelope{garbage1, configTx, garbage2}))
+		Expect(fakeReorderedTransactions.AddArgsForCall(0)).To(Equal(float64(0)))
+	})
+
+	It("does not reorder when not enabled", func() {
+		bc := blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
+		writer := endorserTx("writer", txRWSet{writes: []string{"key1"}})
+		reader := endorserTx("reader", txRWSet{reads: map[string]*kvrwset.Version{"key1": {BlockNum: 1}}})
+
+		batch := cut(bc, writer, reader)
+		Expect(batch).To(Equal([]*cb.Envelope{writer, reader}))
+		Expect(fakeReorderedTransactions.AddCallCount()).To(Equal(0))
+	})
+})
+
+type txRWSet struct {
+	reads                map[string]*kvrwset.Version
+	writes               []string
+	rangeStart, rangeEnd string
+	itrExhausted         bool
+}
+
+func endorserTx(txID string, rws txRWSet) *cb.Envelope {
+	kvRWSet := &kvrwset.KVRWSet{}
+	for key, ver := range rws.reads {
+		kvRWSet.Reads = append(kvRWSet.Reads, &kvrwset.KVRead{Key: key, Version: ver})
+	}
+	for _, key := range rws.writes {
+		kvRWSet.Writes = append(kvRWSet.Writes, &kvrwset.KVWrite{Key: key, Value: []byte("value")})
+	}
+	if rws.rangeStart != "" {
+		kvRWSet.RangeQueriesInfo = append(kvRWSet.RangeQueriesInfo, &kvrwset.RangeQueryInfo{StartKey: rws.rangeStart, EndKey: rws.rangeEnd, ItrExhausted: rws.itrExhausted})
+	}
+
+	results := protoutil.MarshalOrPanic(&rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset:   []*rwset.NsReadWriteSet{{Namespace: "mycc", Rwset: protoutil.MarshalOrPanic(kvRWSet)}},
+	})
+	prp := protoutil.MarshalOrPanic(&pb.ProposalResponsePayload{
+		Extension: protoutil.MarshalOrPanic(&pb.ChaincodeAction{Results: results}),
+	})
+	ccActionPayload := protoutil.MarshalOrPanic(&pb.ChaincodeActionPayload{
+		Action: &pb.ChaincodeEndorsedAction{ProposalResponsePayload: prp},
+	})
+	tx := protoutil.MarshalOrPanic(&pb.Transaction{
+		Actions: []*pb.TransactionAction{{Payload: ccActionPayload}},
+	})
+	return &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: "mychannel",
+					TxId:      txID,
+				}),
+			},
+			Data: tx,
+		}),
+	}
+}
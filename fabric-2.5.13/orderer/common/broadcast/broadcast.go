@@ -15,10 +15,17 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
-var logger = flogging.MustGetLogger("orderer.common.broadcast")
+var (
+	logger = flogging.MustGetLogger("orderer.common.broadcast")
+	tracer = otel.Tracer("github.com/hyperledger/fabric/orderer/common/broadcast")
+)
 
 //go:generate counterfeiter -o mock/channel_support_registrar.go --fake-name ChannelSupportRegistrar . ChannelSupportRegistrar
 
@@ -77,7 +84,14 @@ func (bh *Handler) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return err
 		}
 
+		_, span := tracer.Start(srv.Context(), "broadcast.ProcessMessage")
 		resp := bh.ProcessMessage(msg, addr)
+		span.SetAttributes(attribute.String("fabric.status", resp.Status.String()))
+		if resp.Status != cb.Status_SUCCESS {
+			span.SetStatus(codes.Error, resp.Info)
+		}
+		span.End()
+
 		err = srv.Send(resp)
 		if resp.Status != cb.Status_SUCCESS {
 			return err
@@ -153,7 +167,7 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	}
 	if err != nil {
 		logger.Warningf("[channel: %s] Could not get message processor for serving %s: %s", tracker.ChannelID, addr, err)
-		return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+		return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 	}
 
 	if !isConfig {
@@ -212,7 +226,7 @@ func ClassifyError(err error) cb.Status {
 		return cb.Status_NOT_FOUND
 	case msgprocessor.ErrPermissionDenied:
 		return cb.Status_FORBIDDEN
-	case msgprocessor.ErrMaintenanceMode:
+	case msgprocessor.ErrMaintenanceMode, types.ErrOrdererInMaintenance:
 		return cb.Status_SERVICE_UNAVAILABLE
 	default:
 		return cb.Status_BAD_REQUEST
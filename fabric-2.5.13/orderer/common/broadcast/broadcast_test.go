@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/broadcast/mock"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
 )
 
 var _ = Describe("Broadcast", func() {
@@ -147,6 +148,26 @@ var _ = Describe("Broadcast", func() {
 				).To(BeTrue())
 			})
 
+			Context("when the orderer is in maintenance mode", func() {
+				BeforeEach(func() {
+					fakeSupportRegistrar.BroadcastChannelSupportReturns(&cb.ChannelHeader{
+						Type:      2,
+						ChannelId: "fake-channel",
+					}, false, nil, types.ErrOrdererInMaintenance)
+				})
+
+				It("returns the error to the client with a service unavailable status", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeABServer.SendCallCount()).To(Equal(1))
+					Expect(proto.Equal(
+						fakeABServer.SendArgsForCall(0),
+						&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: "orderer is in maintenance mode"}),
+					).To(BeTrue())
+				})
+			})
+
 			Context("when the channel header is not validly decoded", func() {
 				BeforeEach(func() {
 					fakeSupportRegistrar.BroadcastChannelSupportReturns(nil, false, nil, fmt.Errorf("support-error"))
@@ -13,6 +13,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -28,10 +29,15 @@ import (
 const (
 	URLBaseV1              = "/participation/v1/"
 	URLBaseV1Channels      = URLBaseV1 + "channels"
+	URLBaseV1Maintenance   = URLBaseV1 + "maintenance"
 	FormDataConfigBlockKey = "config-block"
+	TransferLeaderToKey    = "to"
 
-	channelIDKey        = "channelID"
-	urlWithChannelIDKey = URLBaseV1Channels + "/{" + channelIDKey + "}"
+	channelIDKey              = "channelID"
+	urlWithChannelIDKey       = URLBaseV1Channels + "/{" + channelIDKey + "}"
+	urlWithRaftStatus         = urlWithChannelIDKey + "/raft"
+	urlWithRaftSnapshot       = urlWithRaftStatus + "/snapshot"
+	urlWithRaftTransferLeader = urlWithRaftStatus + "/transfer-leader"
 )
 
 //go:generate counterfeiter -o mocks/channel_management.go -fake-name ChannelManagement . ChannelManagement
@@ -52,6 +58,31 @@ type ChannelManagement interface {
 
 	// RemoveChannel instructs the orderer to remove a channel.
 	RemoveChannel(channelID string) error
+
+	// RaftStatus provides the state of the Raft node of a channel and of its storage.
+	// The URL field is empty, and is to be completed by the caller.
+	RaftStatus(channelID string) (types.RaftStatus, error)
+
+	// RaftSnapshot instructs the orderer to take a snapshot of the Raft node of a channel, and to purge the
+	// write-ahead log files and snapshots that precede it.
+	// The URL field is empty, and is to be completed by the caller.
+	RaftSnapshot(channelID string) (types.RaftStatus, error)
+
+	// TransferLeadership instructs the orderer to transfer the leadership of the Raft cluster of a channel to the
+	// consenter with the given Raft ID, or, if it is zero and the orderer is the leader, to any recently active
+	// consenter.
+	// The URL field is empty, and is to be completed by the caller.
+	TransferLeadership(channelID string, to uint64) (types.RaftStatus, error)
+
+	// MaintenanceStatus reports whether the orderer is in maintenance mode, and the channels it is the Raft leader of.
+	MaintenanceStatus() types.MaintenanceStatus
+
+	// EnterMaintenance instructs the orderer to stop accepting broadcast requests, and to transfer the leadership
+	// of the Raft clusters it leads to other consenters.
+	EnterMaintenance() types.MaintenanceStatus
+
+	// ExitMaintenance instructs the orderer to accept broadcast requests again.
+	ExitMaintenance() types.MaintenanceStatus
 }
 
 // HTTPHandler handles all the HTTP requests to the channel participation API.
@@ -118,6 +149,106 @@ func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelMa
 	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveRemove).Methods(http.MethodDelete)
 	handler.router.HandleFunc(urlWithChannelIDKey, handler.serveNotAllowed)
 
+	// swagger:operation GET /v1/participation/channels/{channelID}/raft channels raftStatus
+	// ---
+	// summary: Returns the status of the Raft node of a channel, and of its write-ahead log and snapshots.
+	// parameters:
+	// - name: channelID
+	//   in: path
+	//   description: Channel ID
+	//   required: true
+	//   type: string
+	// responses:
+	//    '200':
+	//       description: Successfully retrieved the Raft status.
+	//       schema:
+	//         "$ref": "#/definitions/raftStatus"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+	//    '400':
+	//      description: Bad request.
+	//    '404':
+	//      description: The channel does not exist, or the orderer is not a consenter of an etcdraft cluster for it.
+	//    '409':
+	//      description: The channel is pending removal.
+
+	handler.router.HandleFunc(urlWithRaftStatus, handler.serveRaftStatus).Methods(http.MethodGet)
+	handler.router.HandleFunc(urlWithRaftStatus, handler.serveNotAllowed)
+
+	// swagger:operation POST /v1/participation/channels/{channelID}/raft/snapshot channels raftSnapshot
+	// ---
+	// summary: Takes a snapshot of the Raft node of a channel, and purges the write-ahead log files and snapshots that precede it.
+	// parameters:
+	// - name: channelID
+	//   in: path
+	//   description: Channel ID
+	//   required: true
+	//   type: string
+	// responses:
+	//    '200':
+	//       description: Successfully took the snapshot and purged the storage.
+	//       schema:
+	//         "$ref": "#/definitions/raftStatus"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+	//    '400':
+	//      description: Bad request, or the snapshot could not be taken.
+	//    '404':
+	//      description: The channel does not exist, or the orderer is not a consenter of an etcdraft cluster for it.
+	//    '409':
+	//      description: The channel is pending removal.
+
+	handler.router.HandleFunc(urlWithRaftSnapshot, handler.serveRaftSnapshot).Methods(http.MethodPost)
+	handler.router.HandleFunc(urlWithRaftSnapshot, handler.serveNotAllowed)
+
+	// swagger:operation POST /v1/participation/channels/{channelID}/raft/transfer-leader channels transferLeader
+	// ---
+	// summary: Transfers the leadership of the Raft cluster of a channel to another consenter.
+	// description: If no consenter is specified and the Ordering Service Node (OSN) is the leader, the leadership is transferred to any recently active consenter.
+	// parameters:
+	// - name: channelID
+	//   in: path
+	//   description: Channel ID
+	//   required: true
+	//   type: string
+	// - name: to
+	//   in: query
+	//   description: The Raft ID of the consenter to transfer the leadership to
+	//   required: false
+	//   type: integer
+	//   format: uint64
+	// responses:
+	//    '200':
+	//       description: Successfully transferred the leadership.
+	//       schema:
+	//         "$ref": "#/definitions/raftStatus"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+	//    '400':
+	//      description: Bad request, or the leadership could not be transferred.
+	//    '404':
+	//      description: The channel does not exist, or the orderer is not a consenter of an etcdraft cluster for it.
+	//    '409':
+	//      description: The channel is pending removal.
+
+	handler.router.HandleFunc(urlWithRaftTransferLeader, handler.serveTransferLeader).Methods(http.MethodPost)
+	handler.router.HandleFunc(urlWithRaftTransferLeader, handler.serveNotAllowed)
+
 	// swagger:operation GET /v1/participation/channels channels listChannels
 	// ---
 	// summary: Returns the complete list of channels an Ordering Service Node (OSN) has joined.
@@ -179,6 +310,62 @@ func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelMa
 
 	handler.router.HandleFunc(URLBaseV1Channels, handler.serveNotAllowed)
 
+	// swagger:operation GET /v1/participation/maintenance maintenance maintenanceStatus
+	// ---
+	// summary: Returns whether the Ordering Service Node (OSN) is in maintenance mode, and the channels it is the Raft leader of.
+	// responses:
+	//    '200':
+	//       description: Successfully retrieved the maintenance status.
+	//       schema:
+	//         "$ref": "#/definitions/maintenanceStatus"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+
+	handler.router.HandleFunc(URLBaseV1Maintenance, handler.serveMaintenanceStatus).Methods(http.MethodGet)
+
+	// swagger:operation POST /v1/participation/maintenance maintenance enterMaintenance
+	// ---
+	// summary: Puts the Ordering Service Node (OSN) in maintenance mode.
+	// description: The OSN stops accepting broadcast requests, and transfers the leadership of the Raft clusters it leads to other consenters. Maintenance mode ends when the OSN restarts.
+	// responses:
+	//    '200':
+	//       description: Entered maintenance mode. The outcome of each leadership transfer is reported.
+	//       schema:
+	//         "$ref": "#/definitions/maintenanceStatus"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+
+	handler.router.HandleFunc(URLBaseV1Maintenance, handler.serveEnterMaintenance).Methods(http.MethodPost)
+
+	// swagger:operation DELETE /v1/participation/maintenance maintenance exitMaintenance
+	// ---
+	// summary: Takes the Ordering Service Node (OSN) out of maintenance mode, so that it accepts broadcast requests again.
+	// responses:
+	//    '200':
+	//       description: Exited maintenance mode.
+	//       schema:
+	//         "$ref": "#/definitions/maintenanceStatus"
+	//       headers:
+	//        Content-Type:
+	//          description: The media type of the resource
+	//          type: string
+	//        Cache-Control:
+	//         description: The directives for caching responses
+	//         type: string
+
+	handler.router.HandleFunc(URLBaseV1Maintenance, handler.serveExitMaintenance).Methods(http.MethodDelete)
+	handler.router.HandleFunc(URLBaseV1Maintenance, handler.serveNotAllowed)
+
 	handler.router.HandleFunc(URLBaseV1, handler.redirectBaseV1).Methods(http.MethodGet)
 
 	return handler
@@ -397,6 +584,129 @@ func (h *HTTPHandler) serveRemove(resp http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// Get the Raft status of a channel
+func (h *HTTPHandler) serveRaftStatus(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	raftStatus, err := h.registrar.RaftStatus(channelID)
+	if err != nil {
+		h.sendRaftError(err, "cannot get Raft status", resp)
+		return
+	}
+	raftStatus.URL = path.Join(URLBaseV1Channels, raftStatus.Name)
+
+	resp.Header().Set("Cache-Control", "no-store")
+	h.sendResponseOK(resp, raftStatus)
+}
+
+// Take a Raft snapshot of a channel, and purge the storage that precedes it
+func (h *HTTPHandler) serveRaftSnapshot(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	raftStatus, err := h.registrar.RaftSnapshot(channelID)
+	if err != nil {
+		h.sendRaftError(err, "cannot take Raft snapshot", resp)
+		return
+	}
+	h.logger.Infof("Took Raft snapshot of channel: %s, at index: %d", channelID, raftStatus.AppliedIndex)
+	raftStatus.URL = path.Join(URLBaseV1Channels, raftStatus.Name)
+
+	resp.Header().Set("Cache-Control", "no-store")
+	h.sendResponseOK(resp, raftStatus)
+}
+
+// Transfer the leadership of the Raft cluster of a channel
+func (h *HTTPHandler) serveTransferLeader(resp http.ResponseWriter, req *http.Request) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	channelID, err := h.extractChannelID(req, resp)
+	if err != nil {
+		return
+	}
+
+	var to uint64
+	if toValue := req.URL.Query().Get(TransferLeaderToKey); toValue != "" {
+		to, err = strconv.ParseUint(toValue, 10, 64)
+		if err != nil {
+			h.sendResponseJsonError(resp, http.StatusBadRequest, errors.Errorf("invalid Raft ID of transferee: %s", toValue))
+			return
+		}
+	}
+
+	raftStatus, err := h.registrar.TransferLeadership(channelID, to)
+	if err != nil {
+		h.sendRaftError(err, "cannot transfer leadership", resp)
+		return
+	}
+	h.logger.Infof("Transferred leadership of channel: %s, to: %d", channelID, raftStatus.Leader)
+	raftStatus.URL = path.Join(URLBaseV1Channels, raftStatus.Name)
+
+	resp.Header().Set("Cache-Control", "no-store")
+	h.sendResponseOK(resp, raftStatus)
+}
+
+// Get the maintenance status
+func (h *HTTPHandler) serveMaintenanceStatus(resp http.ResponseWriter, req *http.Request) {
+	h.serveMaintenance(resp, req, h.registrar.MaintenanceStatus)
+}
+
+// Enter maintenance mode
+func (h *HTTPHandler) serveEnterMaintenance(resp http.ResponseWriter, req *http.Request) {
+	h.serveMaintenance(resp, req, h.registrar.EnterMaintenance)
+}
+
+// Exit maintenance mode
+func (h *HTTPHandler) serveExitMaintenance(resp http.ResponseWriter, req *http.Request) {
+	h.serveMaintenance(resp, req, h.registrar.ExitMaintenance)
+}
+
+func (h *HTTPHandler) serveMaintenance(resp http.ResponseWriter, req *http.Request, maintenance func() types.MaintenanceStatus) {
+	_, err := negotiateContentType(req) // Only application/json responses for now
+	if err != nil {
+		h.sendResponseJsonError(resp, http.StatusNotAcceptable, err)
+		return
+	}
+
+	status := maintenance()
+
+	resp.Header().Set("Cache-Control", "no-store")
+	h.sendResponseOK(resp, status)
+}
+
+func (h *HTTPHandler) sendRaftError(err error, message string, resp http.ResponseWriter) {
+	h.logger.Debugf("Failed Raft request: %s", err)
+	switch err {
+	case types.ErrChannelNotExist, types.ErrNotRaftConsenter:
+		h.sendResponseJsonError(resp, http.StatusNotFound, errors.WithMessage(err, message))
+	case types.ErrChannelPendingRemoval:
+		h.sendResponseJsonError(resp, http.StatusConflict, errors.WithMessage(err, message))
+	default:
+		h.sendResponseJsonError(resp, http.StatusBadRequest, errors.WithMessage(err, message))
+	}
+}
+
 func (h *HTTPHandler) serveBadContentType(resp http.ResponseWriter, req *http.Request) {
 	err := errors.Errorf("unsupported Content-Type: %s", req.Header.Values("Content-Type"))
 	h.sendResponseJsonError(resp, http.StatusBadRequest, err)
@@ -405,6 +715,18 @@ func (h *HTTPHandler) serveBadContentType(resp http.ResponseWriter, req *http.Re
 func (h *HTTPHandler) serveNotAllowed(resp http.ResponseWriter, req *http.Request) {
 	err := errors.Errorf("invalid request method: %s", req.Method)
 
+	switch template, _ := mux.CurrentRoute(req).GetPathTemplate(); template {
+	case urlWithRaftStatus:
+		h.sendResponseNotAllowed(resp, err, http.MethodGet)
+		return
+	case urlWithRaftSnapshot, urlWithRaftTransferLeader:
+		h.sendResponseNotAllowed(resp, err, http.MethodPost)
+		return
+	case URLBaseV1Maintenance:
+		h.sendResponseNotAllowed(resp, err, http.MethodGet, http.MethodPost, http.MethodDelete)
+		return
+	}
+
 	if _, ok := mux.Vars(req)[channelIDKey]; ok {
 		h.sendResponseNotAllowed(resp, err, http.MethodGet, http.MethodDelete)
 		return
@@ -488,6 +488,297 @@ func TestHTTPHandler_ServeHTTP_Remove(t *testing.T) {
 	})
 }
 
+func TestHTTPHandler_ServeHTTP_RaftStatus(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true}
+	fakeManager, h := setup(config, t)
+
+	t.Run("success", func(t *testing.T) {
+		fakeManager.RaftStatusReturns(types.RaftStatus{
+			Name:           "app-channel",
+			NodeID:         2,
+			State:          "StateFollower",
+			Term:           3,
+			Leader:         1,
+			CommittedIndex: 12,
+			AppliedIndex:   12,
+			LastSnapshot:   &types.RaftSnapshot{Term: 3, Index: 10, BlockNumber: 7},
+			SnapshotFiles:  1,
+			WALFiles:       2,
+			WALSize:        1024,
+		}, nil)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/raft", nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+		require.Equal(t, "no-store", resp.Result().Header.Get("Cache-Control"))
+		require.Equal(t, "app-channel", fakeManager.RaftStatusArgsForCall(0))
+
+		statusResp := types.RaftStatus{}
+		err := json.Unmarshal(resp.Body.Bytes(), &statusResp)
+		require.NoError(t, err, "cannot be unmarshaled")
+		require.Equal(t, types.RaftStatus{
+			Name:           "app-channel",
+			URL:            channelparticipation.URLBaseV1Channels + "/app-channel",
+			NodeID:         2,
+			State:          "StateFollower",
+			Term:           3,
+			Leader:         1,
+			CommittedIndex: 12,
+			AppliedIndex:   12,
+			LastSnapshot:   &types.RaftSnapshot{Term: 3, Index: 10, BlockNumber: 7},
+			SnapshotFiles:  1,
+			WALFiles:       2,
+			WALSize:        1024,
+		}, statusResp)
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels+"/app-channel/raft", nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusMethodNotAllowed, "invalid request method: POST", resp)
+		require.Equal(t, "GET", resp.Result().Header.Get("Allow"))
+	})
+
+	testRaftErrors(t, http.MethodGet, "/raft", "cannot get Raft status", fakeManager.RaftStatusReturns, h)
+}
+
+func TestHTTPHandler_ServeHTTP_RaftSnapshot(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true}
+	fakeManager, h := setup(config, t)
+
+	t.Run("success", func(t *testing.T) {
+		fakeManager.RaftSnapshotReturns(types.RaftStatus{
+			Name:          "app-channel",
+			AppliedIndex:  12,
+			LastSnapshot:  &types.RaftSnapshot{Term: 3, Index: 12, BlockNumber: 9},
+			SnapshotFiles: 1,
+			WALFiles:      1,
+			WALSize:       512,
+		}, nil)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels+"/app-channel/raft/snapshot", nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.Equal(t, "no-store", resp.Result().Header.Get("Cache-Control"))
+		require.Equal(t, "app-channel", fakeManager.RaftSnapshotArgsForCall(0))
+
+		statusResp := types.RaftStatus{}
+		err := json.Unmarshal(resp.Body.Bytes(), &statusResp)
+		require.NoError(t, err, "cannot be unmarshaled")
+		require.Equal(t, channelparticipation.URLBaseV1Channels+"/app-channel", statusResp.URL)
+		require.Equal(t, &types.RaftSnapshot{Term: 3, Index: 12, BlockNumber: 9}, statusResp.LastSnapshot)
+		require.Equal(t, int64(512), statusResp.WALSize)
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/raft/snapshot", nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusMethodNotAllowed, "invalid request method: GET", resp)
+		require.Equal(t, "POST", resp.Result().Header.Get("Allow"))
+	})
+
+	testRaftErrors(t, http.MethodPost, "/raft/snapshot", "cannot take Raft snapshot", fakeManager.RaftSnapshotReturns, h)
+}
+
+func TestHTTPHandler_ServeHTTP_TransferLeader(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true}
+	fakeManager, h := setup(config, t)
+
+	t.Run("success", func(t *testing.T) {
+		fakeManager.TransferLeadershipReturns(types.RaftStatus{
+			Name:   "app-channel",
+			NodeID: 1,
+			State:  "StateFollower",
+			Leader: 3,
+		}, nil)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels+"/app-channel/raft/transfer-leader?to=3", nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		require.Equal(t, "no-store", resp.Result().Header.Get("Cache-Control"))
+		channelID, to := fakeManager.TransferLeadershipArgsForCall(0)
+		require.Equal(t, "app-channel", channelID)
+		require.Equal(t, uint64(3), to)
+
+		statusResp := types.RaftStatus{}
+		err := json.Unmarshal(resp.Body.Bytes(), &statusResp)
+		require.NoError(t, err, "cannot be unmarshaled")
+		require.Equal(t, types.RaftStatus{
+			Name:   "app-channel",
+			URL:    channelparticipation.URLBaseV1Channels + "/app-channel",
+			NodeID: 1,
+			State:  "StateFollower",
+			Leader: 3,
+		}, statusResp)
+	})
+
+	t.Run("any consenter", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels+"/app-channel/raft/transfer-leader", nil)
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		_, to := fakeManager.TransferLeadershipArgsForCall(1)
+		require.Equal(t, uint64(0), to)
+	})
+
+	t.Run("bad transferee", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels+"/app-channel/raft/transfer-leader?to=oops", nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusBadRequest, "invalid Raft ID of transferee: oops", resp)
+		require.Equal(t, 2, fakeManager.TransferLeadershipCallCount())
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app-channel/raft/transfer-leader", nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusMethodNotAllowed, "invalid request method: GET", resp)
+		require.Equal(t, "POST", resp.Result().Header.Get("Allow"))
+	})
+
+	testRaftErrors(t, http.MethodPost, "/raft/transfer-leader", "cannot transfer leadership", func(status types.RaftStatus, err error) {
+		fakeManager.TransferLeadershipReturns(status, err)
+	}, h)
+}
+
+func TestHTTPHandler_ServeHTTP_Maintenance(t *testing.T) {
+	config := localconfig.ChannelParticipation{Enabled: true}
+	fakeManager, h := setup(config, t)
+
+	fakeManager.MaintenanceStatusReturns(types.MaintenanceStatus{
+		Enabled:  false,
+		LeaderOf: []string{"app-channel"},
+	})
+	fakeManager.EnterMaintenanceReturns(types.MaintenanceStatus{
+		Enabled: true,
+		LeadershipTransfers: []types.LeadershipTransfer{
+			{Name: "app-channel", Leader: 2},
+		},
+	})
+	fakeManager.ExitMaintenanceReturns(types.MaintenanceStatus{
+		Enabled: false,
+	})
+
+	testCases := []struct {
+		name     string
+		method   string
+		expected types.MaintenanceStatus
+	}{
+		{
+			name:     "status",
+			method:   http.MethodGet,
+			expected: types.MaintenanceStatus{Enabled: false, LeaderOf: []string{"app-channel"}},
+		},
+		{
+			name:     "enter",
+			method:   http.MethodPost,
+			expected: types.MaintenanceStatus{Enabled: true, LeadershipTransfers: []types.LeadershipTransfer{{Name: "app-channel", Leader: 2}}},
+		},
+		{
+			name:     "exit",
+			method:   http.MethodDelete,
+			expected: types.MaintenanceStatus{Enabled: false},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, channelparticipation.URLBaseV1Maintenance, nil)
+			h.ServeHTTP(resp, req)
+			require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+			require.Equal(t, "application/json", resp.Result().Header.Get("Content-Type"))
+			require.Equal(t, "no-store", resp.Result().Header.Get("Cache-Control"))
+
+			statusResp := types.MaintenanceStatus{}
+			err := json.Unmarshal(resp.Body.Bytes(), &statusResp)
+			require.NoError(t, err, "cannot be unmarshaled")
+			require.Equal(t, testCase.expected, statusResp)
+		})
+	}
+
+	require.Equal(t, 1, fakeManager.MaintenanceStatusCallCount())
+	require.Equal(t, 1, fakeManager.EnterMaintenanceCallCount())
+	require.Equal(t, 1, fakeManager.ExitMaintenanceCallCount())
+
+	t.Run("invalid method", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, channelparticipation.URLBaseV1Maintenance, nil)
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusMethodNotAllowed, "invalid request method: PUT", resp)
+		require.Equal(t, "GET, POST, DELETE", resp.Result().Header.Get("Allow"))
+	})
+
+	t.Run("bad Accept header", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Maintenance, nil)
+		req.Header.Set("Accept", "text/html")
+		h.ServeHTTP(resp, req)
+		checkErrorResponse(t, http.StatusNotAcceptable, "response Content-Type is application/json only", resp)
+		require.Equal(t, 1, fakeManager.EnterMaintenanceCallCount())
+	})
+}
+
+func testRaftErrors(t *testing.T, method, suffix, message string, fakeReturns func(types.RaftStatus, error), h *channelparticipation.HTTPHandler) {
+	testCases := []struct {
+		name         string
+		channel      string
+		fakeReturns  error
+		expectedCode int
+		expectedErr  string
+	}{
+		{
+			name:         "bad channel ID",
+			channel:      "My-Channel",
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "invalid channel ID: 'My-Channel' contains illegal characters",
+		},
+		{
+			name:         "channel does not exist",
+			channel:      "my-channel",
+			fakeReturns:  types.ErrChannelNotExist,
+			expectedCode: http.StatusNotFound,
+			expectedErr:  message + ": channel does not exist",
+		},
+		{
+			name:         "not a raft consenter",
+			channel:      "my-channel",
+			fakeReturns:  types.ErrNotRaftConsenter,
+			expectedCode: http.StatusNotFound,
+			expectedErr:  message + ": orderer is not a consenter of an etcdraft cluster for this channel",
+		},
+		{
+			name:         "channel pending removal",
+			channel:      "my-channel",
+			fakeReturns:  types.ErrChannelPendingRemoval,
+			expectedCode: http.StatusConflict,
+			expectedErr:  message + ": channel pending removal",
+		},
+		{
+			name:         "some other error",
+			channel:      "my-channel",
+			fakeReturns:  os.ErrInvalid,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  message + ": invalid argument",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeReturns(types.RaftStatus{}, testCase.fakeReturns)
+			resp := httptest.NewRecorder()
+			target := path.Join(channelparticipation.URLBaseV1Channels, testCase.channel) + suffix
+			req := httptest.NewRequest(method, target, nil)
+			h.ServeHTTP(resp, req)
+			checkErrorResponse(t, testCase.expectedCode, testCase.expectedErr, resp)
+		})
+	}
+}
+
 func setup(config localconfig.ChannelParticipation, t *testing.T) (*mocks.ChannelManagement, *channelparticipation.HTTPHandler) {
 	fakeManager := &mocks.ChannelManagement{}
 	h := channelparticipation.NewHTTPHandler(config, fakeManager)
@@ -29,6 +29,7 @@ type TopLevel struct {
 	Consensus            interface{}
 	Operations           Operations
 	Metrics              Metrics
+	Tracing              Tracing
 	ChannelParticipation ChannelParticipation
 	Admin                Admin
 }
@@ -52,6 +53,7 @@ type General struct {
 	Authentication    Authentication
 	MaxRecvMsgSize    int32
 	MaxSendMsgSize    int32
+	BlockCutter       BlockCutter
 }
 
 type Cluster struct {
@@ -106,6 +108,19 @@ type Authentication struct {
 	NoExpirationChecks bool
 }
 
+// BlockCutter contains configuration parameters for cutting the ordered
+// transactions into batches.
+type BlockCutter struct {
+	Reordering Reordering
+}
+
+// Reordering contains configuration parameters for reordering the
+// transactions of a batch so as to minimize the MVCC read conflicts
+// amongst the transactions of a block.
+type Reordering struct {
+	Channels []string
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -202,6 +217,19 @@ type Statsd struct {
 	Prefix        string
 }
 
+// Tracing configures the export of request traces from the orderer.
+type Tracing struct {
+	Provider    string
+	OTLP        OTLP
+	SampleRatio float64
+}
+
+// OTLP configures the OpenTelemetry collector to which trace spans are exported.
+type OTLP struct {
+	Endpoint string
+	Insecure bool
+}
+
 // Admin configures the admin endpoint for the orderer.
 type Admin struct {
 	ListenAddress string
@@ -291,6 +319,14 @@ var Defaults = TopLevel{
 	Metrics: Metrics{
 		Provider: "disabled",
 	},
+	Tracing: Tracing{
+		Provider: "disabled",
+		OTLP: OTLP{
+			Endpoint: "127.0.0.1:4317",
+			Insecure: true,
+		},
+		SampleRatio: 1.0,
+	},
 	ChannelParticipation: ChannelParticipation{
 		Enabled:            false,
 		MaxRequestBodySize: 1024 * 1024,
@@ -63,9 +63,10 @@ func newChainSupport(
 	cs := &ChainSupport{
 		ledgerResources:  ledgerResources,
 		SignerSerializer: signer,
-		cutter: blockcutter.NewReceiverImpl(
+		cutter: newBlockCutter(
 			ledgerResources.ConfigtxValidator().ChannelID(),
 			ledgerResources,
+			registrar.config.General.BlockCutter,
 			blockcutterMetrics,
 		),
 		BCCSP: bccsp,
@@ -107,6 +108,37 @@ func newChainSupport(
 	return cs, nil
 }
 
+// reorderingConsensusTypes are the consensus types for which the blocks of a channel are cut by a
+// single orderer at a time, and replicated as is by the other orderers. The reordering changes the
+// content of the blocks, hence enabling it for a consensus type where every orderer cuts the blocks
+// independently, such as kafka, would fork the channel amongst differently configured orderers.
+var reorderingConsensusTypes = map[string]struct{}{
+	"etcdraft": {},
+	"solo":     {},
+}
+
+// newBlockCutter creates the block cutter for a channel, which reorders the transactions of
+// a batch if the reordering is enabled for the channel in the local configuration
+func newBlockCutter(channelID string, ledgerResources *ledgerResources, conf localconfig.BlockCutter, metrics *blockcutter.Metrics) blockcutter.Receiver {
+	for _, c := range conf.Reordering.Channels {
+		if c != channelID {
+			continue
+		}
+		oc, ok := ledgerResources.OrdererConfig()
+		if !ok {
+			logger.Warningf("[channel: %s] Reordering of transactions in the block cutter is not enabled as the channel has no orderer config", channelID)
+			break
+		}
+		if _, ok := reorderingConsensusTypes[oc.ConsensusType()]; !ok {
+			logger.Warningf("[channel: %s] Reordering of transactions in the block cutter is not enabled as it is not supported for consensus type %s", channelID, oc.ConsensusType())
+			break
+		}
+		logger.Infof("[channel: %s] Reordering of transactions in the block cutter is enabled", channelID)
+		return blockcutter.NewReorderingReceiver(channelID, ledgerResources, metrics)
+	}
+	return blockcutter.NewReceiverImpl(channelID, ledgerResources, metrics)
+}
+
 func (cs *ChainSupport) Reader() blockledger.Reader {
 	return cs
 }
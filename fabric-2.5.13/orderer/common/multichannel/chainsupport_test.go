@@ -9,11 +9,14 @@ package multichannel
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/common/types"
 
 	"github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	msgprocessormocks "github.com/hyperledger/fabric/orderer/common/msgprocessor/mocks"
 	"github.com/hyperledger/fabric/orderer/common/multichannel/mocks"
@@ -115,3 +118,50 @@ func TestNewOnboardingChainSupport(t *testing.T) {
 			ChannelId: "mychannel",
 		}), "Message processor is initialized")
 }
+
+func TestNewBlockCutter(t *testing.T) {
+	conf := localconfig.BlockCutter{Reordering: localconfig.Reordering{Channels: []string{"mychannel"}}}
+
+	tests := []struct {
+		name            string
+		channelID       string
+		consensusType   string
+		expectReordered bool
+	}{
+		{name: "etcdraft", channelID: "mychannel", consensusType: "etcdraft", expectReordered: true},
+		{name: "solo", channelID: "mychannel", consensusType: "solo", expectReordered: true},
+		{name: "kafka", channelID: "mychannel", consensusType: "kafka", expectReordered: false},
+		{name: "channel not configured", channelID: "otherchannel", consensusType: "etcdraft", expectReordered: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrderer := &mocks.OrdererConfig{}
+			mockOrderer.ConsensusTypeReturns(tt.consensusType)
+			mockOrderer.BatchSizeReturns(&ab.BatchSize{MaxMessageCount: 10, PreferredMaxBytes: 1000})
+			mockResources := &mocks.Resources{}
+			mockResources.OrdererConfigReturns(mockOrderer, true)
+			ledgerRes := &ledgerResources{
+				configResources: &configResources{
+					mutableResources: &mutableResourcesMock{Resources: mockResources},
+				},
+			}
+
+			fakeHistogram := &metricsfakes.Histogram{}
+			fakeHistogram.WithReturns(fakeHistogram)
+			fakeCounter := &metricsfakes.Counter{}
+			fakeCounter.WithReturns(fakeCounter)
+			metrics := &blockcutter.Metrics{
+				BlockFillDuration:       fakeHistogram,
+				ReorderedTransactions:   fakeCounter,
+				ConflictingTransactions: fakeCounter,
+			}
+
+			cutter := newBlockCutter(tt.channelID, ledgerRes, conf, metrics)
+			cutter.Ordered(&common.Envelope{Payload: []byte("payload1")})
+			cutter.Ordered(&common.Envelope{Payload: []byte("payload2")})
+			require.Len(t, cutter.Cut(), 2)
+			require.Equal(t, tt.expectReordered, fakeCounter.AddCallCount() > 0)
+		})
+	}
+}
@@ -0,0 +1,662 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/orderer/common/types"
+)
+
+type RaftChain struct {
+	ConfigureStub        func(*common.Envelope, uint64) error
+	configureMutex       sync.RWMutex
+	configureArgsForCall []struct {
+		arg1 *common.Envelope
+		arg2 uint64
+	}
+	configureReturns struct {
+		result1 error
+	}
+	configureReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ErroredStub        func() <-chan struct{}
+	erroredMutex       sync.RWMutex
+	erroredArgsForCall []struct {
+	}
+	erroredReturns struct {
+		result1 <-chan struct{}
+	}
+	erroredReturnsOnCall map[int]struct {
+		result1 <-chan struct{}
+	}
+	HaltStub        func()
+	haltMutex       sync.RWMutex
+	haltArgsForCall []struct {
+	}
+	IsRaftLeaderStub        func() bool
+	isRaftLeaderMutex       sync.RWMutex
+	isRaftLeaderArgsForCall []struct {
+	}
+	isRaftLeaderReturns struct {
+		result1 bool
+	}
+	isRaftLeaderReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	OrderStub        func(*common.Envelope, uint64) error
+	orderMutex       sync.RWMutex
+	orderArgsForCall []struct {
+		arg1 *common.Envelope
+		arg2 uint64
+	}
+	orderReturns struct {
+		result1 error
+	}
+	orderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RaftSnapshotStub        func() (types.RaftStatus, error)
+	raftSnapshotMutex       sync.RWMutex
+	raftSnapshotArgsForCall []struct {
+	}
+	raftSnapshotReturns struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	raftSnapshotReturnsOnCall map[int]struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	RaftStatusStub        func() (types.RaftStatus, error)
+	raftStatusMutex       sync.RWMutex
+	raftStatusArgsForCall []struct {
+	}
+	raftStatusReturns struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	raftStatusReturnsOnCall map[int]struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	StartStub        func()
+	startMutex       sync.RWMutex
+	startArgsForCall []struct {
+	}
+	TransferLeadershipStub        func(uint64) (types.RaftStatus, error)
+	transferLeadershipMutex       sync.RWMutex
+	transferLeadershipArgsForCall []struct {
+		arg1 uint64
+	}
+	transferLeadershipReturns struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	transferLeadershipReturnsOnCall map[int]struct {
+		result1 types.RaftStatus
+		result2 error
+	}
+	WaitReadyStub        func() error
+	waitReadyMutex       sync.RWMutex
+	waitReadyArgsForCall []struct {
+	}
+	waitReadyReturns struct {
+		result1 error
+	}
+	waitReadyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RaftChain) Configure(arg1 *common.Envelope, arg2 uint64) error {
+	fake.configureMutex.Lock()
+	ret, specificReturn := fake.configureReturnsOnCall[len(fake.configureArgsForCall)]
+	fake.configureArgsForCall = append(fake.configureArgsForCall, struct {
+		arg1 *common.Envelope
+		arg2 uint64
+	}{arg1, arg2})
+	stub := fake.ConfigureStub
+	fakeReturns := fake.configureReturns
+	fake.recordInvocation("Configure", []interface{}{arg1, arg2})
+	fake.configureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RaftChain) ConfigureCallCount() int {
+	fake.configureMutex.RLock()
+	defer fake.configureMutex.RUnlock()
+	return len(fake.configureArgsForCall)
+}
+
+func (fake *RaftChain) ConfigureCalls(stub func(*common.Envelope, uint64) error) {
+	fake.configureMutex.Lock()
+	defer fake.configureMutex.Unlock()
+	fake.ConfigureStub = stub
+}
+
+func (fake *RaftChain) ConfigureArgsForCall(i int) (*common.Envelope, uint64) {
+	fake.configureMutex.RLock()
+	defer fake.configureMutex.RUnlock()
+	argsForCall := fake.configureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *RaftChain) ConfigureReturns(result1 error) {
+	fake.configureMutex.Lock()
+	defer fake.configureMutex.Unlock()
+	fake.ConfigureStub = nil
+	fake.configureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RaftChain) ConfigureReturnsOnCall(i int, result1 error) {
+	fake.configureMutex.Lock()
+	defer fake.configureMutex.Unlock()
+	fake.ConfigureStub = nil
+	if fake.configureReturnsOnCall == nil {
+		fake.configureReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.configureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RaftChain) Errored() <-chan struct{} {
+	fake.erroredMutex.Lock()
+	ret, specificReturn := fake.erroredReturnsOnCall[len(fake.erroredArgsForCall)]
+	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
+	}{})
+	stub := fake.ErroredStub
+	fakeReturns := fake.erroredReturns
+	fake.recordInvocation("Errored", []interface{}{})
+	fake.erroredMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RaftChain) ErroredCallCount() int {
+	fake.erroredMutex.RLock()
+	defer fake.erroredMutex.RUnlock()
+	return len(fake.erroredArgsForCall)
+}
+
+func (fake *RaftChain) ErroredCalls(stub func() <-chan struct{}) {
+	fake.erroredMutex.Lock()
+	defer fake.erroredMutex.Unlock()
+	fake.ErroredStub = stub
+}
+
+func (fake *RaftChain) ErroredReturns(result1 <-chan struct{}) {
+	fake.erroredMutex.Lock()
+	defer fake.erroredMutex.Unlock()
+	fake.ErroredStub = nil
+	fake.erroredReturns = struct {
+		result1 <-chan struct{}
+	}{result1}
+}
+
+func (fake *RaftChain) ErroredReturnsOnCall(i int, result1 <-chan struct{}) {
+	fake.erroredMutex.Lock()
+	defer fake.erroredMutex.Unlock()
+	fake.ErroredStub = nil
+	if fake.erroredReturnsOnCall == nil {
+		fake.erroredReturnsOnCall = make(map[int]struct {
+			result1 <-chan struct{}
+		})
+	}
+	fake.erroredReturnsOnCall[i] = struct {
+		result1 <-chan struct{}
+	}{result1}
+}
+
+func (fake *RaftChain) Halt() {
+	fake.haltMutex.Lock()
+	fake.haltArgsForCall = append(fake.haltArgsForCall, struct {
+	}{})
+	stub := fake.HaltStub
+	fake.recordInvocation("Halt", []interface{}{})
+	fake.haltMutex.Unlock()
+	if stub != nil {
+		fake.HaltStub()
+	}
+}
+
+func (fake *RaftChain) HaltCallCount() int {
+	fake.haltMutex.RLock()
+	defer fake.haltMutex.RUnlock()
+	return len(fake.haltArgsForCall)
+}
+
+func (fake *RaftChain) HaltCalls(stub func()) {
+	fake.haltMutex.Lock()
+	defer fake.haltMutex.Unlock()
+	fake.HaltStub = stub
+}
+
+func (fake *RaftChain) IsRaftLeader() bool {
+	fake.isRaftLeaderMutex.Lock()
+	ret, specificReturn := fake.isRaftLeaderReturnsOnCall[len(fake.isRaftLeaderArgsForCall)]
+	fake.isRaftLeaderArgsForCall = append(fake.isRaftLeaderArgsForCall, struct {
+	}{})
+	stub := fake.IsRaftLeaderStub
+	fakeReturns := fake.isRaftLeaderReturns
+	fake.recordInvocation("IsRaftLeader", []interface{}{})
+	fake.isRaftLeaderMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RaftChain) IsRaftLeaderCallCount() int {
+	fake.isRaftLeaderMutex.RLock()
+	defer fake.isRaftLeaderMutex.RUnlock()
+	return len(fake.isRaftLeaderArgsForCall)
+}
+
+func (fake *RaftChain) IsRaftLeaderCalls(stub func() bool) {
+	fake.isRaftLeaderMutex.Lock()
+	defer fake.isRaftLeaderMutex.Unlock()
+	fake.IsRaftLeaderStub = stub
+}
+
+func (fake *RaftChain) IsRaftLeaderReturns(result1 bool) {
+	fake.isRaftLeaderMutex.Lock()
+	defer fake.isRaftLeaderMutex.Unlock()
+	fake.IsRaftLeaderStub = nil
+	fake.isRaftLeaderReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *RaftChain) IsRaftLeaderReturnsOnCall(i int, result1 bool) {
+	fake.isRaftLeaderMutex.Lock()
+	defer fake.isRaftLeaderMutex.Unlock()
+	fake.IsRaftLeaderStub = nil
+	if fake.isRaftLeaderReturnsOnCall == nil {
+		fake.isRaftLeaderReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isRaftLeaderReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *RaftChain) Order(arg1 *common.Envelope, arg2 uint64) error {
+	fake.orderMutex.Lock()
+	ret, specificReturn := fake.orderReturnsOnCall[len(fake.orderArgsForCall)]
+	fake.orderArgsForCall = append(fake.orderArgsForCall, struct {
+		arg1 *common.Envelope
+		arg2 uint64
+	}{arg1, arg2})
+	stub := fake.OrderStub
+	fakeReturns := fake.orderReturns
+	fake.recordInvocation("Order", []interface{}{arg1, arg2})
+	fake.orderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RaftChain) OrderCallCount() int {
+	fake.orderMutex.RLock()
+	defer fake.orderMutex.RUnlock()
+	return len(fake.orderArgsForCall)
+}
+
+func (fake *RaftChain) OrderCalls(stub func(*common.Envelope, uint64) error) {
+	fake.orderMutex.Lock()
+	defer fake.orderMutex.Unlock()
+	fake.OrderStub = stub
+}
+
+func (fake *RaftChain) OrderArgsForCall(i int) (*common.Envelope, uint64) {
+	fake.orderMutex.RLock()
+	defer fake.orderMutex.RUnlock()
+	argsForCall := fake.orderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *RaftChain) OrderReturns(result1 error) {
+	fake.orderMutex.Lock()
+	defer fake.orderMutex.Unlock()
+	fake.OrderStub = nil
+	fake.orderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RaftChain) OrderReturnsOnCall(i int, result1 error) {
+	fake.orderMutex.Lock()
+	defer fake.orderMutex.Unlock()
+	fake.OrderStub = nil
+	if fake.orderReturnsOnCall == nil {
+		fake.orderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.orderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RaftChain) RaftSnapshot() (types.RaftStatus, error) {
+	fake.raftSnapshotMutex.Lock()
+	ret, specificReturn := fake.raftSnapshotReturnsOnCall[len(fake.raftSnapshotArgsForCall)]
+	fake.raftSnapshotArgsForCall = append(fake.raftSnapshotArgsForCall, struct {
+	}{})
+	stub := fake.RaftSnapshotStub
+	fakeReturns := fake.raftSnapshotReturns
+	fake.recordInvocation("RaftSnapshot", []interface{}{})
+	fake.raftSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RaftChain) RaftSnapshotCallCount() int {
+	fake.raftSnapshotMutex.RLock()
+	defer fake.raftSnapshotMutex.RUnlock()
+	return len(fake.raftSnapshotArgsForCall)
+}
+
+func (fake *RaftChain) RaftSnapshotCalls(stub func() (types.RaftStatus, error)) {
+	fake.raftSnapshotMutex.Lock()
+	defer fake.raftSnapshotMutex.Unlock()
+	fake.RaftSnapshotStub = stub
+}
+
+func (fake *RaftChain) RaftSnapshotReturns(result1 types.RaftStatus, result2 error) {
+	fake.raftSnapshotMutex.Lock()
+	defer fake.raftSnapshotMutex.Unlock()
+	fake.RaftSnapshotStub = nil
+	fake.raftSnapshotReturns = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RaftChain) RaftSnapshotReturnsOnCall(i int, result1 types.RaftStatus, result2 error) {
+	fake.raftSnapshotMutex.Lock()
+	defer fake.raftSnapshotMutex.Unlock()
+	fake.RaftSnapshotStub = nil
+	if fake.raftSnapshotReturnsOnCall == nil {
+		fake.raftSnapshotReturnsOnCall = make(map[int]struct {
+			result1 types.RaftStatus
+			result2 error
+		})
+	}
+	fake.raftSnapshotReturnsOnCall[i] = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RaftChain) RaftStatus() (types.RaftStatus, error) {
+	fake.raftStatusMutex.Lock()
+	ret, specificReturn := fake.raftStatusReturnsOnCall[len(fake.raftStatusArgsForCall)]
+	fake.raftStatusArgsForCall = append(fake.raftStatusArgsForCall, struct {
+	}{})
+	stub := fake.RaftStatusStub
+	fakeReturns := fake.raftStatusReturns
+	fake.recordInvocation("RaftStatus", []interface{}{})
+	fake.raftStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RaftChain) RaftStatusCallCount() int {
+	fake.raftStatusMutex.RLock()
+	defer fake.raftStatusMutex.RUnlock()
+	return len(fake.raftStatusArgsForCall)
+}
+
+func (fake *RaftChain) RaftStatusCalls(stub func() (types.RaftStatus, error)) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = stub
+}
+
+func (fake *RaftChain) RaftStatusReturns(result1 types.RaftStatus, result2 error) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = nil
+	fake.raftStatusReturns = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RaftChain) RaftStatusReturnsOnCall(i int, result1 types.RaftStatus, result2 error) {
+	fake.raftStatusMutex.Lock()
+	defer fake.raftStatusMutex.Unlock()
+	fake.RaftStatusStub = nil
+	if fake.raftStatusReturnsOnCall == nil {
+		fake.raftStatusReturnsOnCall = make(map[int]struct {
+			result1 types.RaftStatus
+			result2 error
+		})
+	}
+	fake.raftStatusReturnsOnCall[i] = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RaftChain) Start() {
+	fake.startMutex.Lock()
+	fake.startArgsForCall = append(fake.startArgsForCall, struct {
+	}{})
+	stub := fake.StartStub
+	fake.recordInvocation("Start", []interface{}{})
+	fake.startMutex.Unlock()
+	if stub != nil {
+		fake.StartStub()
+	}
+}
+
+func (fake *RaftChain) StartCallCount() int {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return len(fake.startArgsForCall)
+}
+
+func (fake *RaftChain) StartCalls(stub func()) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = stub
+}
+
+func (fake *RaftChain) TransferLeadership(arg1 uint64) (types.RaftStatus, error) {
+	fake.transferLeadershipMutex.Lock()
+	ret, specificReturn := fake.transferLeadershipReturnsOnCall[len(fake.transferLeadershipArgsForCall)]
+	fake.transferLeadershipArgsForCall = append(fake.transferLeadershipArgsForCall, struct {
+		arg1 uint64
+	}{arg1})
+	stub := fake.TransferLeadershipStub
+	fakeReturns := fake.transferLeadershipReturns
+	fake.recordInvocation("TransferLeadership", []interface{}{arg1})
+	fake.transferLeadershipMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RaftChain) TransferLeadershipCallCount() int {
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
+	return len(fake.transferLeadershipArgsForCall)
+}
+
+func (fake *RaftChain) TransferLeadershipCalls(stub func(uint64) (types.RaftStatus, error)) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = stub
+}
+
+func (fake *RaftChain) TransferLeadershipArgsForCall(i int) uint64 {
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
+	argsForCall := fake.transferLeadershipArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RaftChain) TransferLeadershipReturns(result1 types.RaftStatus, result2 error) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = nil
+	fake.transferLeadershipReturns = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RaftChain) TransferLeadershipReturnsOnCall(i int, result1 types.RaftStatus, result2 error) {
+	fake.transferLeadershipMutex.Lock()
+	defer fake.transferLeadershipMutex.Unlock()
+	fake.TransferLeadershipStub = nil
+	if fake.transferLeadershipReturnsOnCall == nil {
+		fake.transferLeadershipReturnsOnCall = make(map[int]struct {
+			result1 types.RaftStatus
+			result2 error
+		})
+	}
+	fake.transferLeadershipReturnsOnCall[i] = struct {
+		result1 types.RaftStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RaftChain) WaitReady() error {
+	fake.waitReadyMutex.Lock()
+	ret, specificReturn := fake.waitReadyReturnsOnCall[len(fake.waitReadyArgsForCall)]
+	fake.waitReadyArgsForCall = append(fake.waitReadyArgsForCall, struct {
+	}{})
+	stub := fake.WaitReadyStub
+	fakeReturns := fake.waitReadyReturns
+	fake.recordInvocation("WaitReady", []interface{}{})
+	fake.waitReadyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *RaftChain) WaitReadyCallCount() int {
+	fake.waitReadyMutex.RLock()
+	defer fake.waitReadyMutex.RUnlock()
+	return len(fake.waitReadyArgsForCall)
+}
+
+func (fake *RaftChain) WaitReadyCalls(stub func() error) {
+	fake.waitReadyMutex.Lock()
+	defer fake.waitReadyMutex.Unlock()
+	fake.WaitReadyStub = stub
+}
+
+func (fake *RaftChain) WaitReadyReturns(result1 error) {
+	fake.waitReadyMutex.Lock()
+	defer fake.waitReadyMutex.Unlock()
+	fake.WaitReadyStub = nil
+	fake.waitReadyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RaftChain) WaitReadyReturnsOnCall(i int, result1 error) {
+	fake.waitReadyMutex.Lock()
+	defer fake.waitReadyMutex.Unlock()
+	fake.WaitReadyStub = nil
+	if fake.waitReadyReturnsOnCall == nil {
+		fake.waitReadyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitReadyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RaftChain) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.configureMutex.RLock()
+	defer fake.configureMutex.RUnlock()
+	fake.erroredMutex.RLock()
+	defer fake.erroredMutex.RUnlock()
+	fake.haltMutex.RLock()
+	defer fake.haltMutex.RUnlock()
+	fake.isRaftLeaderMutex.RLock()
+	defer fake.isRaftLeaderMutex.RUnlock()
+	fake.orderMutex.RLock()
+	defer fake.orderMutex.RUnlock()
+	fake.raftSnapshotMutex.RLock()
+	defer fake.raftSnapshotMutex.RUnlock()
+	fake.raftStatusMutex.RLock()
+	defer fake.raftStatusMutex.RUnlock()
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	fake.transferLeadershipMutex.RLock()
+	defer fake.transferLeadershipMutex.RUnlock()
+	fake.waitReadyMutex.RLock()
+	defer fake.waitReadyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RaftChain) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -12,6 +12,7 @@ package multichannel
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -69,6 +70,9 @@ type Registrar struct {
 	channelParticipationMetrics *Metrics
 
 	joinBlockFileRepo *filerepo.Repo
+
+	// maintenance is set while the orderer is in maintenance mode, in which it rejects broadcast requests.
+	maintenance bool
 }
 
 // ConfigBlockOrPanic retrieves the last configuration block from the given ledger.
@@ -433,6 +437,13 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 		return nil, false, nil, errors.WithMessage(err, "could not determine channel ID")
 	}
 
+	r.lock.RLock()
+	maintenance := r.maintenance
+	r.lock.RUnlock()
+	if maintenance {
+		return chdr, false, nil, types.ErrOrdererInMaintenance
+	}
+
 	cs := r.GetChain(chdr.ChannelId)
 	// New channel creation
 	if cs == nil {
@@ -738,6 +749,161 @@ func (r *Registrar) ChannelInfo(channelID string) (types.ChannelInfo, error) {
 	return types.ChannelInfo{}, types.ErrChannelNotExist
 }
 
+// RaftStatus provides the state of the Raft node of a channel and of its storage.
+// The URL field is empty, and is to be completed by the caller.
+func (r *Registrar) RaftStatus(channelID string) (types.RaftStatus, error) {
+	reporter, err := r.raftStatusReporter(channelID)
+	if err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	return reporter.RaftStatus()
+}
+
+// RaftSnapshot instructs the orderer to take a snapshot of the Raft node of a channel, and to purge the write-ahead
+// log files and snapshots that precede it.
+// The URL field is empty, and is to be completed by the caller.
+func (r *Registrar) RaftSnapshot(channelID string) (types.RaftStatus, error) {
+	reporter, err := r.raftStatusReporter(channelID)
+	if err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	return reporter.RaftSnapshot()
+}
+
+// TransferLeadership transfers the leadership of the Raft cluster of a channel to the consenter with the given
+// Raft ID, or, if it is zero and the orderer is the leader, to any recently active consenter.
+// The URL field is empty, and is to be completed by the caller.
+func (r *Registrar) TransferLeadership(channelID string, to uint64) (types.RaftStatus, error) {
+	reporter, err := r.raftStatusReporter(channelID)
+	if err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	return reporter.TransferLeadership(to)
+}
+
+// MaintenanceStatus reports whether the orderer is in maintenance mode, and the channels it is the Raft leader of.
+func (r *Registrar) MaintenanceStatus() types.MaintenanceStatus {
+	r.lock.RLock()
+	enabled := r.maintenance
+	reporters := r.raftStatusReporters()
+	r.lock.RUnlock()
+
+	return types.MaintenanceStatus{
+		Enabled:  enabled,
+		LeaderOf: leaderOf(reporters),
+	}
+}
+
+// EnterMaintenance puts the orderer in maintenance mode, in which it rejects broadcast requests, and transfers the
+// leadership of the Raft clusters it leads to other consenters, so that the orderer can be stopped without
+// disrupting ordering. Maintenance mode is not persisted: it ends when the orderer restarts.
+func (r *Registrar) EnterMaintenance() types.MaintenanceStatus {
+	r.lock.Lock()
+	r.maintenance = true
+	reporters := r.raftStatusReporters()
+	r.lock.Unlock()
+
+	logger.Infof("Entered maintenance mode, broadcast requests are rejected")
+
+	transferC := make(chan types.LeadershipTransfer, len(reporters))
+	var wg sync.WaitGroup
+	for channelID, reporter := range reporters {
+		if !reporter.IsRaftLeader() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(channelID string, reporter consensus.RaftStatusReporter) {
+			defer wg.Done()
+
+			transfer := types.LeadershipTransfer{Name: channelID}
+			raftStatus, err := reporter.TransferLeadership(0)
+			if err != nil {
+				logger.Warningf("Failed to transfer leadership of channel %s: %s", channelID, err)
+				transfer.Error = err.Error()
+			} else {
+				logger.Infof("Transferred leadership of channel %s to %d", channelID, raftStatus.Leader)
+				transfer.Leader = raftStatus.Leader
+			}
+			transferC <- transfer
+		}(channelID, reporter)
+	}
+	wg.Wait()
+	close(transferC)
+
+	status := types.MaintenanceStatus{
+		Enabled:  true,
+		LeaderOf: leaderOf(reporters),
+	}
+	for transfer := range transferC {
+		status.LeadershipTransfers = append(status.LeadershipTransfers, transfer)
+	}
+	sort.Slice(status.LeadershipTransfers, func(i, j int) bool {
+		return status.LeadershipTransfers[i].Name < status.LeadershipTransfers[j].Name
+	})
+
+	return status
+}
+
+// ExitMaintenance takes the orderer out of maintenance mode, so that it accepts broadcast requests again.
+func (r *Registrar) ExitMaintenance() types.MaintenanceStatus {
+	r.lock.Lock()
+	r.maintenance = false
+	r.lock.Unlock()
+
+	logger.Infof("Exited maintenance mode, broadcast requests are accepted")
+
+	return r.MaintenanceStatus()
+}
+
+// raftStatusReporters returns the chains that run a Raft node, by channel.
+// Must be called with the lock held.
+func (r *Registrar) raftStatusReporters() map[string]consensus.RaftStatusReporter {
+	reporters := make(map[string]consensus.RaftStatusReporter)
+	for channelID, cs := range r.chains {
+		if reporter, ok := cs.Chain.(consensus.RaftStatusReporter); ok {
+			reporters[channelID] = reporter
+		}
+	}
+	return reporters
+}
+
+func leaderOf(reporters map[string]consensus.RaftStatusReporter) []string {
+	var channels []string
+	for channelID, reporter := range reporters {
+		if reporter.IsRaftLeader() {
+			channels = append(channels, channelID)
+		}
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+func (r *Registrar) raftStatusReporter(channelID string) (consensus.RaftStatusReporter, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if cs, ok := r.chains[channelID]; ok {
+		if reporter, ok := cs.Chain.(consensus.RaftStatusReporter); ok {
+			return reporter, nil
+		}
+		return nil, types.ErrNotRaftConsenter
+	}
+
+	if _, ok := r.followers[channelID]; ok {
+		return nil, types.ErrNotRaftConsenter
+	}
+
+	if _, ok := r.pendingRemoval[channelID]; ok {
+		return nil, types.ErrChannelPendingRemoval
+	}
+
+	return nil, types.ErrChannelNotExist
+}
+
 // JoinChannel instructs the orderer to create a channel and join it with the provided config block.
 // The URL field is empty, and is to be completed by the caller.
 func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block, isAppChannel bool) (info types.ChannelInfo, err error) {
@@ -34,11 +34,13 @@ import (
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/follower"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/multichannel/mocks"
 	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -87,6 +89,13 @@ type consenter interface {
 	consensus.ClusterConsenter
 }
 
+//go:generate counterfeiter -o mocks/raft_chain.go --fake-name RaftChain . raftChain
+
+type raftChain interface {
+	consensus.Chain
+	consensus.RaftStatusReporter
+}
+
 func mockCrypto() *mocks.SignerSerializer {
 	return &mocks.SignerSerializer{}
 }
@@ -1883,3 +1892,126 @@ func TestRegistrar_ConfigBlockOrPanic(t *testing.T) {
 		assert.Equal(t, genesisBlockSys.Data, cBlock.Data)
 	})
 }
+
+func TestRegistrar_RaftStatus(t *testing.T) {
+	registrar := &Registrar{
+		chains: map[string]*ChainSupport{
+			"raft-channel":  {Chain: &etcdraft.Chain{}},
+			"other-channel": {Chain: &inactive.Chain{}},
+		},
+		followers: map[string]*follower.Chain{
+			"follower-channel": {},
+		},
+		pendingRemoval: map[string]consensus.StaticStatusReporter{
+			"removed-channel": {ConsensusRelation: types.ConsensusRelationConsenter, Status: types.StatusInactive},
+		},
+	}
+
+	for _, tt := range []struct {
+		channelID string
+		expected  string
+	}{
+		{channelID: "raft-channel", expected: "chain is not started"},
+		{channelID: "other-channel", expected: "orderer is not a consenter of an etcdraft cluster for this channel"},
+		{channelID: "follower-channel", expected: "orderer is not a consenter of an etcdraft cluster for this channel"},
+		{channelID: "removed-channel", expected: "channel pending removal"},
+		{channelID: "missing-channel", expected: "channel does not exist"},
+	} {
+		t.Run(tt.channelID, func(t *testing.T) {
+			_, err := registrar.RaftStatus(tt.channelID)
+			require.EqualError(t, err, tt.expected)
+
+			_, err = registrar.RaftSnapshot(tt.channelID)
+			require.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestRegistrar_TransferLeadership(t *testing.T) {
+	raftChain := &mocks.RaftChain{}
+	raftChain.TransferLeadershipReturns(types.RaftStatus{Name: "raft-channel", NodeID: 1, Leader: 2}, nil)
+	registrar := &Registrar{
+		chains: map[string]*ChainSupport{
+			"raft-channel":  {Chain: raftChain},
+			"other-channel": {Chain: &inactive.Chain{}},
+		},
+	}
+
+	raftStatus, err := registrar.TransferLeadership("raft-channel", 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), raftStatus.Leader)
+	require.Equal(t, 1, raftChain.TransferLeadershipCallCount())
+	require.Equal(t, uint64(2), raftChain.TransferLeadershipArgsForCall(0))
+
+	_, err = registrar.TransferLeadership("other-channel", 2)
+	require.Equal(t, types.ErrNotRaftConsenter, err)
+
+	_, err = registrar.TransferLeadership("missing-channel", 2)
+	require.Equal(t, types.ErrChannelNotExist, err)
+}
+
+func TestRegistrar_Maintenance(t *testing.T) {
+	// The orderer leads "leader-channel" until it transfers the leadership to node 2.
+	leaderChain := &mocks.RaftChain{}
+	leaderChain.IsRaftLeaderReturns(true)
+	leaderChain.TransferLeadershipStub = func(to uint64) (types.RaftStatus, error) {
+		leaderChain.IsRaftLeaderReturns(false)
+		return types.RaftStatus{NodeID: 1, Leader: 2}, nil
+	}
+
+	// The orderer leads "stuck-channel", but fails to transfer the leadership.
+	stuckChain := &mocks.RaftChain{}
+	stuckChain.IsRaftLeaderReturns(true)
+	stuckChain.TransferLeadershipReturns(types.RaftStatus{}, errors.New("leadership transfer timed out"))
+
+	// The orderer follows "follower-channel".
+	followerChain := &mocks.RaftChain{}
+	followerChain.IsRaftLeaderReturns(false)
+
+	registrar := &Registrar{
+		chains: map[string]*ChainSupport{
+			"leader-channel":   {Chain: leaderChain},
+			"stuck-channel":    {Chain: stuckChain},
+			"follower-channel": {Chain: followerChain},
+			"other-channel":    {Chain: &inactive.Chain{}},
+		},
+	}
+
+	require.Equal(t, types.MaintenanceStatus{
+		Enabled:  false,
+		LeaderOf: []string{"leader-channel", "stuck-channel"},
+	}, registrar.MaintenanceStatus())
+
+	status := registrar.EnterMaintenance()
+	require.Equal(t, types.MaintenanceStatus{
+		Enabled:  true,
+		LeaderOf: []string{"stuck-channel"},
+		LeadershipTransfers: []types.LeadershipTransfer{
+			{Name: "leader-channel", Leader: 2},
+			{Name: "stuck-channel", Error: "leadership transfer timed out"},
+		},
+	}, status)
+	require.Equal(t, uint64(0), leaderChain.TransferLeadershipArgsForCall(0))
+	require.Equal(t, 0, followerChain.TransferLeadershipCallCount())
+
+	env := protoutil.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_ENDORSER_TRANSACTION), ChannelId: "leader-channel"}),
+		},
+	})
+	chdr, _, _, err := registrar.BroadcastChannelSupport(&cb.Envelope{Payload: env})
+	require.Equal(t, types.ErrOrdererInMaintenance, err)
+	require.Equal(t, "leader-channel", chdr.ChannelId)
+
+	status = registrar.ExitMaintenance()
+	require.Equal(t, types.MaintenanceStatus{
+		Enabled:  false,
+		LeaderOf: []string{"stuck-channel"},
+	}, status)
+	require.False(t, registrar.MaintenanceStatus().Enabled)
+
+	// leadership is decided without reading the status of the Raft storage
+	for _, chain := range []*mocks.RaftChain{leaderChain, stuckChain, followerChain} {
+		require.Zero(t, chain.RaftStatusCallCount())
+	}
+}
@@ -33,9 +33,11 @@ import (
 	floggingmetrics "github.com/hyperledger/fabric/common/flogging/metrics"
 	"github.com/hyperledger/fabric/common/grpclogging"
 	"github.com/hyperledger/fabric/common/grpcmetrics"
+	"github.com/hyperledger/fabric/common/grpctracing"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
@@ -52,6 +54,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -104,6 +107,12 @@ func Main() {
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
 	flogging.SetObserver(logObserver)
 
+	shutdownTracing, err := initializeTracing(conf.Tracing)
+	if err != nil {
+		logger.Panicf("Failed to initialize tracing: %s", err)
+	}
+	defer shutdownTracing()
+
 	serverConfig := initializeServerConfig(conf, metricsProvider)
 	grpcServer := initializeGrpcServer(conf, serverConfig)
 	caMgr := &caManager{
@@ -659,10 +668,12 @@ func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.
 		ServerStatsHandler: comm.NewServerStatsHandler(metricsProvider),
 		ConnectionTimeout:  conf.General.ConnectionTimeout,
 		StreamInterceptors: []grpc.StreamServerInterceptor{
+			grpctracing.StreamServerInterceptor(),
 			grpcmetrics.StreamServerInterceptor(grpcmetrics.NewStreamMetrics(metricsProvider)),
 			grpclogging.StreamServerInterceptor(flogging.MustGetLogger("comm.grpc.server").Zap()),
 		},
 		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			grpctracing.UnaryServerInterceptor(),
 			grpcmetrics.UnaryServerInterceptor(grpcmetrics.NewUnaryMetrics(metricsProvider)),
 			grpclogging.UnaryServerInterceptor(
 				flogging.MustGetLogger("comm.grpc.server").Zap(),
@@ -674,6 +685,20 @@ func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.
 	}
 }
 
+func initializeTracing(conf localconfig.Tracing) (shutdown func(), err error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get hostname")
+	}
+
+	return tracing.Initialize("orderer", hostname, tracing.Config{
+		Provider:    conf.Provider,
+		Endpoint:    conf.OTLP.Endpoint,
+		Insecure:    conf.OTLP.Insecure,
+		SampleRatio: conf.SampleRatio,
+	})
+}
+
 func grpcLeveler(ctx context.Context, fullMethod string) zapcore.Level {
 	switch fullMethod {
 	case "/orderer.Cluster/Step":
@@ -84,3 +84,46 @@ type ChannelInfo struct {
 	// Current block height.
 	Height uint64 `json:"height"`
 }
+
+// RaftStatus carries the response to an HTTP request for the status of the Raft node of a channel.
+// This is marshaled into the body of the HTTP response.
+// swagger:model raftStatus
+type RaftStatus struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+	// The Raft ID of the orderer.
+	NodeID uint64 `json:"nodeID"`
+	// The role of the orderer in the Raft cluster.
+	// Possible values: "StateFollower", "StateCandidate", "StateLeader", "StatePreCandidate".
+	State string `json:"state"`
+	// The current Raft term.
+	Term uint64 `json:"term"`
+	// The Raft ID of the leader, 0 if there is no leader.
+	Leader uint64 `json:"leader"`
+	// The index of the last Raft entry known to be committed.
+	CommittedIndex uint64 `json:"committedIndex"`
+	// The index of the last Raft entry applied by the orderer.
+	AppliedIndex uint64 `json:"appliedIndex"`
+	// The number of bytes of block data after which a snapshot is taken.
+	SnapshotIntervalSize uint32 `json:"snapshotIntervalSize"`
+	// The last snapshot taken by the orderer, nil if there is no snapshot.
+	LastSnapshot *RaftSnapshot `json:"lastSnapshot"`
+	// The number of snapshot files stored on disk.
+	SnapshotFiles int `json:"snapshotFiles"`
+	// The number of write-ahead log (WAL) files stored on disk.
+	WALFiles int `json:"walFiles"`
+	// The total size of the write-ahead log (WAL) files, in bytes.
+	WALSize int64 `json:"walSize"`
+}
+
+// RaftSnapshot carries the position of a Raft snapshot.
+type RaftSnapshot struct {
+	// The Raft term of the last entry included in the snapshot.
+	Term uint64 `json:"term"`
+	// The Raft index of the last entry included in the snapshot.
+	Index uint64 `json:"index"`
+	// The number of the last block included in the snapshot.
+	BlockNumber uint64 `json:"blockNumber"`
+}
@@ -27,3 +27,10 @@ var ErrChannelPendingRemoval = errors.New("channel pending removal")
 
 // ErrChannelRemovalFailure is returned when a removal attempt failure has been recorded.
 var ErrChannelRemovalFailure = errors.New("channel removal failure")
+
+// ErrNotRaftConsenter is returned when trying to read or manage the Raft storage of a channel for which the orderer
+// is not a consenter of an etcdraft cluster.
+var ErrNotRaftConsenter = errors.New("orderer is not a consenter of an etcdraft cluster for this channel")
+
+// ErrOrdererInMaintenance is returned when a broadcast request is received while the orderer is in maintenance mode.
+var ErrOrdererInMaintenance = errors.New("orderer is in maintenance mode")
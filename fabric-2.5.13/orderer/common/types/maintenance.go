@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+// MaintenanceStatus carries the response to an HTTP request for the maintenance mode of the orderer.
+// This is marshaled into the body of the HTTP response.
+// swagger:model maintenanceStatus
+type MaintenanceStatus struct {
+	// Whether the orderer is in maintenance mode, in which it rejects broadcast requests.
+	Enabled bool `json:"enabled"`
+	// The channels for which the orderer is the leader of the Raft cluster, nil or empty if there are none.
+	LeaderOf []string `json:"leaderOf"`
+	// The leadership transfers attempted when entering maintenance mode, one per channel the orderer was leading.
+	LeadershipTransfers []LeadershipTransfer `json:"leadershipTransfers,omitempty"`
+}
+
+// LeadershipTransfer carries the outcome of the transfer of the leadership of the Raft cluster of a channel.
+type LeadershipTransfer struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The Raft ID of the leader after the transfer, 0 if there is no leader.
+	Leader uint64 `json:"leader"`
+	// The reason the transfer failed, empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
@@ -30,3 +30,28 @@ type StaticStatusReporter struct {
 func (s StaticStatusReporter) StatusReport() (types.ConsensusRelation, types.Status) {
 	return s.ConsensusRelation, s.Status
 }
+
+// RaftStatusReporter is implemented by Chain implementations that run a Raft node, i.e. etcdraft.
+// It allows the node to report the state of the Raft node and of its storage, to compact that storage on demand,
+// and to transfer the leadership of the cluster.
+// This is used to serve the Raft requests of the channel participation API.
+type RaftStatusReporter interface {
+	// RaftStatus provides the state of the Raft node and of its storage.
+	// The URL field is empty, and is to be completed by the caller.
+	RaftStatus() (types.RaftStatus, error)
+
+	// IsRaftLeader reports whether the Raft node is running and is the leader of the cluster. Unlike RaftStatus,
+	// it does not access the storage of the Raft node.
+	IsRaftLeader() bool
+
+	// RaftSnapshot takes a snapshot of the entries applied since the last snapshot, if any, and purges the
+	// write-ahead log files and the snapshots that precede it. It returns the state after the purge.
+	// The URL field is empty, and is to be completed by the caller.
+	RaftSnapshot() (types.RaftStatus, error)
+
+	// TransferLeadership transfers the leadership of the Raft cluster to the consenter with the given Raft ID, or,
+	// if it is zero and the node is the leader, to any recently active consenter. It returns the state after the
+	// transfer.
+	// The URL field is empty, and is to be completed by the caller.
+	TransferLeadership(to uint64) (types.RaftStatus, error)
+}
@@ -143,6 +143,10 @@ type gc struct {
 	index uint64
 	state raftpb.ConfState
 	data  []byte
+
+	// done is set when the snapshot was requested by an operator, in which case the
+	// storage is also compacted, and the outcome is reported on it.
+	done chan error
 }
 
 // Chain implements consensus.Chain interface.
@@ -165,6 +169,7 @@ type Chain struct {
 	startC   chan struct{}         // Closes when the node is started
 	snapC    chan *raftpb.Snapshot // Signal to catch up with snapshot
 	gcC      chan *gc              // Signal to take snapshot
+	snapReqC chan chan error       // Signal to take a snapshot requested by an operator
 
 	errorCLock sync.RWMutex
 	errorC     chan struct{} // returned by Errored()
@@ -211,6 +216,7 @@ type Chain struct {
 	CryptoProvider bccsp.BCCSP
 
 	leadershipTransferInProgress uint32
+	leadershipTransferRequested  uint32 // Set while a leadership transfer requested by an operator is in progress
 }
 
 // NewChain constructs a chain object.
@@ -270,6 +276,7 @@ func NewChain(
 		snapC:             make(chan *raftpb.Snapshot),
 		errorC:            make(chan struct{}),
 		gcC:               make(chan *gc),
+		snapReqC:          make(chan chan error),
 		observeC:          observeC,
 		support:           support,
 		fresh:             fresh,
@@ -843,6 +850,19 @@ func (c *Chain) run() {
 			c.logger.Debugf("Batch timer expired, creating block")
 			c.propose(propC, bc, batch) // we are certain this is normal block, no need to block
 
+		case done := <-c.snapReqC:
+			g := &gc{index: c.appliedIndex, state: c.confState, data: protoutil.MarshalOrPanic(c.lastBlock), done: done}
+			select {
+			case c.gcC <- g:
+				c.logger.Infof("Taking snapshot at block [%d] (index: %d) as requested, last snapshotted block number is %d, current nodes: %+v",
+					c.lastBlock.Header.Number, c.appliedIndex, c.lastSnapBlockNum, c.confState.Voters)
+				c.accDataSize = 0
+				c.lastSnapBlockNum = c.lastBlock.Header.Number
+				c.Metrics.SnapshotBlockNumber.Set(float64(c.lastBlock.Header.Number))
+			default:
+				done <- errors.Errorf("snapshotting is in progress")
+			}
+
 		case sn := <-c.snapC:
 			if sn.Metadata.Index != 0 {
 				if sn.Metadata.Index <= c.appliedIndex {
@@ -1096,7 +1116,7 @@ func (c *Chain) detectConfChange(block *common.Block) *MembershipChanges {
 		configMetadata.Options.SnapshotIntervalSize != c.sizeLimit {
 		c.logger.Infof("Update snapshot interval size to %d bytes (was %d)",
 			configMetadata.Options.SnapshotIntervalSize, c.sizeLimit)
-		c.sizeLimit = configMetadata.Options.SnapshotIntervalSize
+		atomic.StoreUint32(&c.sizeLimit, configMetadata.Options.SnapshotIntervalSize)
 	}
 
 	changes, err := ComputeMembershipChanges(c.opts.BlockMetadata, c.opts.Consenters, configMetadata.Consenters)
@@ -1218,7 +1238,11 @@ func (c *Chain) gc() {
 	for {
 		select {
 		case g := <-c.gcC:
-			c.Node.takeSnapshot(g.index, g.state, g.data)
+			if g.done == nil {
+				c.Node.takeSnapshot(g.index, g.state, g.data)
+				continue
+			}
+			g.done <- c.Node.compactStorage(g.index, g.state, g.data)
 		case <-c.doneC:
 			c.logger.Infof("Stop garbage collecting")
 			return
@@ -1492,6 +1516,110 @@ func (c *Chain) StatusReport() (types.ConsensusRelation, types.Status) {
 	return c.consensusRelation, c.status
 }
 
+// RaftStatus returns the state of the Raft node and of its storage.
+func (c *Chain) RaftStatus() (types.RaftStatus, error) {
+	if err := c.isRunning(); err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	status := c.Node.Status()
+	raftStatus := types.RaftStatus{
+		Name:                 c.channelID,
+		NodeID:               c.raftID,
+		State:                status.RaftState.String(),
+		Term:                 status.Term,
+		Leader:               status.Lead,
+		CommittedIndex:       status.Commit,
+		AppliedIndex:         status.Applied,
+		SnapshotIntervalSize: atomic.LoadUint32(&c.sizeLimit),
+	}
+
+	if snap := c.Node.storage.Snapshot(); !raft.IsEmptySnap(snap) {
+		block, err := protoutil.UnmarshalBlock(snap.Data)
+		if err != nil {
+			return types.RaftStatus{}, errors.Errorf("failed to unmarshal block from snapshot: %s", err)
+		}
+		raftStatus.LastSnapshot = &types.RaftSnapshot{
+			Term:        snap.Metadata.Term,
+			Index:       snap.Metadata.Index,
+			BlockNumber: block.Header.Number,
+		}
+	}
+
+	walFiles, walSize, snapFiles, err := c.Node.storage.DiskUsage()
+	if err != nil {
+		return types.RaftStatus{}, err
+	}
+	raftStatus.WALFiles = walFiles
+	raftStatus.WALSize = walSize
+	raftStatus.SnapshotFiles = snapFiles
+
+	return raftStatus, nil
+}
+
+// IsRaftLeader reports whether the chain is running and its Raft node is the leader of the cluster.
+// It only reads the state of the Raft node, without accessing its storage.
+func (c *Chain) IsRaftLeader() bool {
+	if c.isRunning() != nil {
+		return false
+	}
+	return c.Node.Status().RaftState == raft.StateLeader
+}
+
+// RaftSnapshot takes a snapshot of the entries applied since the last snapshot, if any, and
+// purges the wal files and snapshots that precede it. It returns the state of the Raft node
+// and of its storage after the purge.
+func (c *Chain) RaftSnapshot() (types.RaftStatus, error) {
+	if err := c.isRunning(); err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	done := make(chan error, 1)
+	select {
+	case c.snapReqC <- done:
+	case <-c.doneC:
+		return types.RaftStatus{}, errors.Errorf("chain is stopped")
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return types.RaftStatus{}, errors.WithMessage(err, "failed to take snapshot")
+		}
+	case <-c.doneC:
+		return types.RaftStatus{}, errors.Errorf("chain is stopped")
+	}
+
+	return c.RaftStatus()
+}
+
+// TransferLeadership transfers the leadership of the Raft cluster to the consenter with the given Raft ID.
+// If to is raft.None and this node is the leader, the leadership is transferred to any recently active
+// follower. It blocks until the leadership is transferred or the transfer times out, and returns the
+// state of the Raft node afterwards.
+func (c *Chain) TransferLeadership(to uint64) (types.RaftStatus, error) {
+	if err := c.isRunning(); err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	if !atomic.CompareAndSwapUint32(&c.leadershipTransferRequested, 0, 1) {
+		return types.RaftStatus{}, errors.Errorf("leadership transfer is already in progress")
+	}
+	defer atomic.StoreUint32(&c.leadershipTransferRequested, 0)
+
+	var err error
+	if to == raft.None {
+		err = c.Node.abdicateLeadership()
+	} else {
+		err = c.Node.transferLeadership(to)
+	}
+	if err != nil {
+		return types.RaftStatus{}, errors.WithMessage(err, "failed to transfer leadership")
+	}
+
+	return c.RaftStatus()
+}
+
 func (c *Chain) suspectEviction() bool {
 	if c.isRunning() != nil {
 		return false
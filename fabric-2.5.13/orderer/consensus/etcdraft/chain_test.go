@@ -1262,6 +1262,61 @@ var _ = Describe("Chain", func() {
 							Eventually(countFiles, LongEventualTimeout).Should(Equal(1))
 						})
 					})
+
+					Context("when a snapshot is requested", func() {
+						BeforeEach(func() {
+							opts.SnapshotIntervalSize = 1024
+						})
+
+						It("takes a snapshot and reports it in the Raft status", func() {
+							Expect(chain.Order(env, uint64(0))).To(Succeed())
+							Eventually(support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+							Expect(chain.Order(env, uint64(0))).To(Succeed())
+							Eventually(support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(2))
+
+							status, err := chain.RaftStatus()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(status.Name).To(Equal(channelID))
+							Expect(status.NodeID).To(Equal(uint64(1)))
+							Expect(status.State).To(Equal("StateLeader"))
+							Expect(status.Leader).To(Equal(uint64(1)))
+							Expect(status.SnapshotIntervalSize).To(Equal(uint32(1024)))
+							Expect(status.LastSnapshot).To(BeNil())
+							Expect(status.SnapshotFiles).To(Equal(0))
+							Expect(status.WALFiles).To(Equal(1))
+							Expect(status.WALSize).To(BeNumerically(">", 0))
+
+							status, err = chain.RaftSnapshot()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(status.LastSnapshot).NotTo(BeNil())
+							Expect(status.LastSnapshot.BlockNumber).To(Equal(uint64(2)))
+							Expect(status.LastSnapshot.Index).To(Equal(status.AppliedIndex))
+							Expect(status.SnapshotFiles).To(Equal(1))
+							Expect(countFiles()).To(Equal(1))
+							Expect(fakeFields.fakeSnapshotBlockNumber.SetArgsForCall(1)).To(Equal(float64(2)))
+
+							By("requesting another snapshot without new entries")
+							status, err = chain.RaftSnapshot()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(status.LastSnapshot.BlockNumber).To(Equal(uint64(2)))
+							Expect(countFiles()).To(Equal(1))
+						})
+
+						It("reports whether the node is the leader", func() {
+							Expect(chain.IsRaftLeader()).To(BeTrue())
+
+							chain.Halt()
+							Expect(chain.IsRaftLeader()).To(BeFalse())
+						})
+
+						It("fails when the chain is halted", func() {
+							chain.Halt()
+							_, err := chain.RaftStatus()
+							Expect(err).To(MatchError("chain is stopped"))
+							_, err = chain.RaftSnapshot()
+							Expect(err).To(MatchError("chain is stopped"))
+						})
+					})
 				})
 			})
 
@@ -1668,6 +1723,53 @@ var _ = Describe("Chain", func() {
 			os.RemoveAll(dataDir)
 		})
 
+		When("an operator requests a leadership transfer", func() {
+			BeforeEach(func() {
+				network.init()
+				network.start()
+				network.elect(1)
+
+				c1.cutter.CutNext = true
+				Expect(c1.Order(env, 0)).To(Succeed())
+				network.exec(func(c *chain) {
+					Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+				})
+			})
+
+			It("transfers leadership to the requested consenter", func() {
+				status, err := c1.TransferLeadership(3)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.NodeID).To(Equal(uint64(1)))
+				Expect(status.Leader).To(Equal(uint64(3)))
+				Eventually(c3.observe, LongEventualTimeout).Should(Receive(StateEqual(3, raft.StateLeader)))
+			})
+
+			It("transfers leadership when the request is made to a follower", func() {
+				status, err := c2.TransferLeadership(2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Leader).To(Equal(uint64(2)))
+				Eventually(c2.observe, LongEventualTimeout).Should(Receive(StateEqual(2, raft.StateLeader)))
+			})
+
+			It("transfers leadership to any active consenter when none is requested", func() {
+				status, err := c1.TransferLeadership(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Leader).To(Or(Equal(uint64(2)), Equal(uint64(3))))
+				Eventually(c1.observe, LongEventualTimeout).Should(Receive(BeFollower()))
+			})
+
+			It("does nothing when the requested consenter is already the leader", func() {
+				status, err := c2.TransferLeadership(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Leader).To(Equal(uint64(1)))
+			})
+
+			It("fails when the requested transferee is not a consenter", func() {
+				_, err := c1.TransferLeadership(4)
+				Expect(err).To(MatchError("failed to transfer leadership: leadership transferee is not a consenter"))
+			})
+		})
+
 		When("2/3 nodes are running", func() {
 			It("late node can catch up", func() {
 				network.init()
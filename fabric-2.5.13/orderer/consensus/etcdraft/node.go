@@ -29,6 +29,8 @@ var (
 	ErrNoAvailableLeaderCandidate = errors.New("leadership transfer failed to identify transferee")
 	ErrTimedOutLeaderTransfer     = errors.New("leadership transfer timed out")
 	ErrNoLeader                   = errors.New("no leader")
+	ErrTransfereeNotConsenter     = errors.New("leadership transferee is not a consenter")
+	ErrTransfereeNotActive        = errors.New("leadership transferee is not recently active")
 )
 
 type node struct {
@@ -259,10 +261,6 @@ func (n *node) abdicateLeadership() error {
 		return nil
 	}
 
-	// register to leader changes
-	notifyC, unsubscribe := n.subscribeToLeaderChange()
-	defer unsubscribe()
-
 	var transferee uint64
 	for id, pr := range status.Progress {
 		if id == status.ID {
@@ -282,14 +280,65 @@ func (n *node) abdicateLeadership() error {
 		return ErrNoAvailableLeaderCandidate
 	}
 
+	// Any node other than this one becoming the leader completes the abdication.
+	return n.awaitLeadershipTransfer(status.Lead, transferee, func(leader uint64) bool {
+		return leader != n.config.ID
+	})
+}
+
+// transferLeadership attempts to transfer leadership to the given node, which may be this node.
+// Blocks until the given node becomes the leader or when a timeout expires.
+// Returns error upon failure.
+func (n *node) transferLeadership(transferee uint64) error {
+	start := time.Now()
+	defer func() {
+		n.logger.Infof("transferLeadership took %v", time.Since(start))
+	}()
+
+	status := n.Status()
+
+	if status.Lead == raft.None {
+		n.logger.Warn("No leader, cannot transfer leadership")
+		return ErrNoLeader
+	}
+
+	if status.Lead == transferee {
+		n.logger.Infof("Node %d is already the leader", transferee)
+		return nil
+	}
+
+	if _, ok := status.Config.Voters.IDs()[transferee]; !ok {
+		n.logger.Warnf("Node %d is not a consenter, cannot transfer leadership to it", transferee)
+		return ErrTransfereeNotConsenter
+	}
+
+	// The progress of followers is only tracked by the leader. A follower forwards
+	// the transfer request to the leader, which ignores it if the transferee lags behind.
+	if pr, ok := status.Progress[transferee]; ok && (!pr.RecentActive || pr.IsPaused()) {
+		n.logger.Warnf("Node %d is not qualified as transferee because it's either paused or not active", transferee)
+		return ErrTransfereeNotActive
+	}
+
+	return n.awaitLeadershipTransfer(status.Lead, transferee, func(leader uint64) bool {
+		return leader == transferee
+	})
+}
+
+// awaitLeadershipTransfer asks the leader to transfer leadership to the transferee, and blocks until
+// a leader change satisfying done happens or when a timeout expires.
+func (n *node) awaitLeadershipTransfer(lead, transferee uint64, done func(leader uint64) bool) error {
+	// register to leader changes
+	notifyC, unsubscribe := n.subscribeToLeaderChange(done)
+	defer unsubscribe()
+
 	n.logger.Infof("Transferring leadership to %d", transferee)
 
 	timeToWait := time.Duration(n.config.ElectionTick) * n.tickInterval
-	n.logger.Infof("Will wait %v time to abdicate", timeToWait)
+	n.logger.Infof("Will wait %v for the leadership transfer", timeToWait)
 	ctx, cancel := context.WithTimeout(context.TODO(), timeToWait)
 	defer cancel()
 
-	n.TransferLeadership(ctx, status.ID, transferee)
+	n.TransferLeadership(ctx, lead, transferee)
 
 	timer := n.clock.NewTimer(timeToWait)
 	defer timer.Stop()
@@ -300,7 +349,7 @@ func (n *node) abdicateLeadership() error {
 			n.logger.Warn("Leader transfer timed out")
 			return ErrTimedOutLeaderTransfer
 		case l := <-notifyC:
-			n.logger.Infof("Leader has been transferred from %d to %d", n.config.ID, l)
+			n.logger.Infof("Leader has been transferred from %d to %d", lead, l)
 			return nil
 		case <-n.chain.doneC:
 			n.logger.Infof("Returning early because chain is halting")
@@ -309,7 +358,7 @@ func (n *node) abdicateLeadership() error {
 	}
 }
 
-func (n *node) subscribeToLeaderChange() (chan uint64, func()) {
+func (n *node) subscribeToLeaderChange(accept func(leader uint64) bool) (chan uint64, func()) {
 	notifyC := make(chan uint64, 1)
 	subscriptionActive := uint32(1)
 	unsubscribe := func() {
@@ -319,7 +368,7 @@ func (n *node) subscribeToLeaderChange() (chan uint64, func()) {
 		if atomic.LoadUint32(&subscriptionActive) == 0 {
 			return
 		}
-		if leader != n.config.ID {
+		if accept(leader) {
 			select {
 			case notifyC <- leader:
 			default:
@@ -347,6 +396,19 @@ func (n *node) takeSnapshot(index uint64, cs raftpb.ConfState, data []byte) {
 	}
 }
 
+// compactStorage takes a snapshot at index i, unless no entry was applied since the last snapshot,
+// and purges the wal files and snapshots that precede the last snapshot.
+func (n *node) compactStorage(i uint64, cs raftpb.ConfState, data []byte) error {
+	if i > n.storage.Snapshot().Metadata.Index {
+		if err := n.storage.TakeSnapshot(i, cs, data); err != nil {
+			return err
+		}
+	}
+
+	n.storage.PurgeToLastSnapshot()
+	return nil
+}
+
 func (n *node) lastIndex() uint64 {
 	i, _ := n.storage.ram.LastIndex()
 	return i
@@ -339,7 +339,56 @@ func (rs *RaftStorage) gc() {
 	rs.snapshotIndex = rs.snapshotIndex[len(rs.snapshotIndex)-MaxSnapshotFiles:]
 
 	rs.purgeWAL()
-	rs.purgeSnap()
+	rs.purgeSnap(MaxSnapshotFiles)
+}
+
+// PurgeToLastSnapshot removes the wal files that precede the last snapshot, along with the
+// snapshots taken before it. Unlike gc, it doesn't retain older snapshots to fall back to
+// if the last snapshot is corrupted, and is meant to be requested by an operator to reclaim
+// disk space.
+func (rs *RaftStorage) PurgeToLastSnapshot() {
+	if len(rs.snapshotIndex) == 0 {
+		rs.lg.Debugf("No snapshot on disk, no need to purge wal/snapshot")
+		return
+	}
+
+	rs.snapshotIndex = rs.snapshotIndex[len(rs.snapshotIndex)-1:]
+
+	rs.purgeWAL()
+	rs.purgeSnap(1)
+}
+
+// DiskUsage returns the number of wal files and their total size in bytes, along with
+// the number of snapshot files.
+func (rs *RaftStorage) DiskUsage() (walFiles int, walSize int64, snapFiles int, err error) {
+	err = filepath.Walk(rs.walDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(path, ".wal") {
+			walFiles++
+			walSize += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, 0, errors.Errorf("failed to read WAL directory %s: %s", rs.walDir, err)
+	}
+
+	err = filepath.Walk(rs.snapDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(path, ".snap") {
+			snapFiles++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, 0, errors.Errorf("failed to read Snapshot directory %s: %s", rs.snapDir, err)
+	}
+
+	return walFiles, walSize, snapFiles, nil
 }
 
 func (rs *RaftStorage) purgeWAL() {
@@ -380,7 +429,7 @@ func (rs *RaftStorage) purgeWAL() {
 	rs.purge(files[:len(files)-1])
 }
 
-func (rs *RaftStorage) purgeSnap() {
+func (rs *RaftStorage) purgeSnap(retain int) {
 	var files []string
 	err := filepath.Walk(rs.snapDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -400,11 +449,11 @@ func (rs *RaftStorage) purgeSnap() {
 	}
 
 	l := len(files)
-	if l <= MaxSnapshotFiles {
+	if l <= retain {
 		return
 	}
 
-	rs.purge(files[:l-MaxSnapshotFiles]) // retain last MaxSnapshotFiles snapshot files
+	rs.purge(files[:l-retain]) // retain last snapshot files
 }
 
 func (rs *RaftStorage) purge(files []string) {
@@ -345,6 +345,67 @@ func TestTakeSnapshot(t *testing.T) {
 	})
 }
 
+func TestPurgeToLastSnapshot(t *testing.T) {
+	backup := MaxSnapshotFiles
+	MaxSnapshotFiles = 2
+	defer func() { MaxSnapshotFiles = backup }()
+
+	setup(t)
+	defer clean(t)
+
+	// set SegmentSizeBytes to a small value so that
+	// every entry persisted to wal would result in
+	// a new wal being created.
+	oldSegmentSizeBytes := wal.SegmentSizeBytes
+	wal.SegmentSizeBytes = 10
+	defer func() {
+		wal.SegmentSizeBytes = oldSegmentSizeBytes
+	}()
+
+	t.Run("No snapshot", func(t *testing.T) {
+		store.PurgeToLastSnapshot()
+		assertFileCount(t, 1, 0)
+	})
+
+	// create 10 new wal files
+	for i := 0; i < 10; i++ {
+		store.Store(
+			[]raftpb.Entry{{Index: uint64(i), Data: make([]byte, 100)}},
+			raftpb.HardState{},
+			raftpb.Snapshot{},
+		)
+	}
+
+	// Two snapshots at index 3, 5. And we keep one extra wal file prior to oldest snapshot.
+	// So we should have pruned wal file with index [0, 1]
+	err = store.TakeSnapshot(uint64(3), raftpb.ConfState{Voters: []uint64{1}}, make([]byte, 10))
+	require.NoError(t, err)
+	err = store.TakeSnapshot(uint64(5), raftpb.ConfState{Voters: []uint64{1}}, make([]byte, 10))
+	require.NoError(t, err)
+	assertFileCount(t, 9, 2)
+
+	walFiles, walSize, snapFiles, err := store.DiskUsage()
+	require.NoError(t, err)
+	require.Equal(t, 9, walFiles)
+	require.NotZero(t, walSize)
+	require.Equal(t, 2, snapFiles)
+
+	// Only the snapshot at index 5 is kept, along with one extra wal file prior to it.
+	// So we should have pruned wal file with index [2, 3]
+	store.PurgeToLastSnapshot()
+	assertFileCount(t, 7, 1)
+
+	walFiles, purgedWALSize, snapFiles, err := store.DiskUsage()
+	require.NoError(t, err)
+	require.Equal(t, 7, walFiles)
+	require.Less(t, purgedWALSize, walSize)
+	require.Equal(t, 1, snapFiles)
+
+	// Purging again is a no-op
+	store.PurgeToLastSnapshot()
+	assertFileCount(t, 7, 1)
+}
+
 func TestApplyOutOfDateSnapshot(t *testing.T) {
 	t.Run("Apply out of date snapshot", func(t *testing.T) {
 		setup(t)
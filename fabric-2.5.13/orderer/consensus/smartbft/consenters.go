@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package smartbft provides the building blocks of a Byzantine fault tolerant ordering service, in which the
+// consenters of a channel agree on each block and sign it, and a block is trusted once a quorum of the consenters
+// signed it. The consenters communicate over the cluster communication of the orderer.
+//
+// The consensus protocol itself is not part of this package: it requires the SmartBFT library, and the channel
+// configuration of a BFT consenter set, which the fabric-protos-go messages used by this release do not define.
+package smartbft
+
+import (
+	"encoding/pem"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/pkg/errors"
+)
+
+// Consenter is a member of the BFT consenter set of a channel.
+type Consenter struct {
+	// ID identifies the consenter in the cluster communication, and cannot be 0.
+	ID   uint64
+	Host string
+	Port uint32
+	// MSPID is the ID of the MSP of the consenter.
+	MSPID string
+	// Identity is the PEM encoded certificate with which the consenter signs blocks.
+	Identity []byte
+	// ClientTLSCert is the PEM encoded TLS client certificate of the consenter.
+	ClientTLSCert []byte
+	// ServerTLSCert is the PEM encoded TLS server certificate of the consenter.
+	ServerTLSCert []byte
+}
+
+// Endpoint returns the endpoint of the consenter, in host:port format.
+func (c *Consenter) Endpoint() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
+// SerializedIdentity returns the serialized identity with which the consenter signs blocks, which is the creator of
+// the signature header of its block signatures.
+func (c *Consenter) SerializedIdentity() ([]byte, error) {
+	return proto.Marshal(&msp.SerializedIdentity{Mspid: c.MSPID, IdBytes: c.Identity})
+}
+
+// ValidateConsenters returns an error if the consenter set is empty, or if a consenter has no ID, shares its ID or
+// endpoint with another consenter, has no MSP ID, or has a certificate that is not PEM encoded.
+func ValidateConsenters(consenters []*Consenter) error {
+	if len(consenters) == 0 {
+		return errors.New("empty consenter set")
+	}
+	ids := map[uint64]struct{}{}
+	endpoints := map[string]struct{}{}
+	for _, consenter := range consenters {
+		if consenter.ID == 0 {
+			return errors.Errorf("consenter %s has no ID", consenter.Endpoint())
+		}
+		if _, exists := ids[consenter.ID]; exists {
+			return errors.Errorf("duplicate consenter ID %d", consenter.ID)
+		}
+		ids[consenter.ID] = struct{}{}
+		if _, exists := endpoints[consenter.Endpoint()]; exists {
+			return errors.Errorf("duplicate consenter endpoint %s", consenter.Endpoint())
+		}
+		endpoints[consenter.Endpoint()] = struct{}{}
+		if consenter.MSPID == "" {
+			return errors.Errorf("consenter %d has no MSP ID", consenter.ID)
+		}
+		for certType, cert := range map[string][]byte{
+			"identity":   consenter.Identity,
+			"client TLS": consenter.ClientTLSCert,
+			"server TLS": consenter.ServerTLSCert,
+		} {
+			if _, err := pemToDER(cert); err != nil {
+				return errors.WithMessagef(err, "invalid %s certificate of consenter %d", certType, consenter.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// RemoteNodes returns the cluster members of the consenter set, other than the consenter with the given ID, with
+// which the cluster communication of a channel is configured.
+func RemoteNodes(consenters []*Consenter, selfID uint64) ([]cluster.RemoteNode, error) {
+	var nodes []cluster.RemoteNode
+	for _, consenter := range consenters {
+		if consenter.ID == selfID {
+			continue
+		}
+		serverCert, err := pemToDER(consenter.ServerTLSCert)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid server TLS certificate of consenter %d", consenter.ID)
+		}
+		clientCert, err := pemToDER(consenter.ClientTLSCert)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid client TLS certificate of consenter %d", consenter.ID)
+		}
+		nodes = append(nodes, cluster.RemoteNode{
+			ID:            consenter.ID,
+			Endpoint:      consenter.Endpoint(),
+			ServerTLSCert: serverCert,
+			ClientTLSCert: clientCert,
+		})
+	}
+	return nodes, nil
+}
+
+func pemToDER(pemBytes []byte) ([]byte, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return nil, errors.New("invalid PEM block")
+	}
+	return bl.Bytes, nil
+}
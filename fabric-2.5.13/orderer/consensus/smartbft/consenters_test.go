@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package smartbft
+
+import (
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/stretchr/testify/require"
+)
+
+func newConsenters(t *testing.T, n int) []*Consenter {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	newCert := func() []byte {
+		keyPair, err := ca.NewClientCertKeyPair()
+		require.NoError(t, err)
+		return keyPair.Cert
+	}
+
+	consenters := make([]*Consenter, n)
+	for i := range consenters {
+		consenters[i] = &Consenter{
+			ID:            uint64(i + 1),
+			Host:          fmt.Sprintf("orderer%d", i+1),
+			Port:          7050,
+			MSPID:         fmt.Sprintf("Org%dMSP", i+1),
+			Identity:      newCert(),
+			ClientTLSCert: newCert(),
+			ServerTLSCert: newCert(),
+		}
+	}
+	return consenters
+}
+
+func TestConsenterSerializedIdentity(t *testing.T) {
+	consenter := newConsenters(t, 1)[0]
+	identity, err := consenter.SerializedIdentity()
+	require.NoError(t, err)
+
+	sID := &msp.SerializedIdentity{}
+	require.NoError(t, proto.Unmarshal(identity, sID))
+	require.Equal(t, "Org1MSP", sID.Mspid)
+	require.Equal(t, consenter.Identity, sID.IdBytes)
+}
+
+func TestValidateConsenters(t *testing.T) {
+	require.NoError(t, ValidateConsenters(newConsenters(t, 4)))
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(consenters []*Consenter) []*Consenter
+		err    string
+	}{
+		{
+			name:   "Empty",
+			mutate: func([]*Consenter) []*Consenter { return nil },
+			err:    "empty consenter set",
+		},
+		{
+			name: "NoID",
+			mutate: func(consenters []*Consenter) []*Consenter {
+				consenters[1].ID = 0
+				return consenters
+			},
+			err: "consenter orderer2:7050 has no ID",
+		},
+		{
+			name: "DuplicateID",
+			mutate: func(consenters []*Consenter) []*Consenter {
+				consenters[1].ID = 1
+				return consenters
+			},
+			err: "duplicate consenter ID 1",
+		},
+		{
+			name: "DuplicateEndpoint",
+			mutate: func(consenters []*Consenter) []*Consenter {
+				consenters[1].Host = "orderer1"
+				return consenters
+			},
+			err: "duplicate consenter endpoint orderer1:7050",
+		},
+		{
+			name: "NoMSPID",
+			mutate: func(consenters []*Consenter) []*Consenter {
+				consenters[1].MSPID = ""
+				return consenters
+			},
+			err: "consenter 2 has no MSP ID",
+		},
+		{
+			name: "InvalidIdentity",
+			mutate: func(consenters []*Consenter) []*Consenter {
+				consenters[1].Identity = []byte("not a certificate")
+				return consenters
+			},
+			err: "invalid identity certificate of consenter 2: invalid PEM block",
+		},
+		{
+			name: "InvalidServerTLSCert",
+			mutate: func(consenters []*Consenter) []*Consenter {
+				consenters[1].ServerTLSCert = nil
+				return consenters
+			},
+			err: "invalid server TLS certificate of consenter 2: invalid PEM block",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConsenters(tc.mutate(newConsenters(t, 4)))
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRemoteNodes(t *testing.T) {
+	consenters := newConsenters(t, 3)
+	nodes, err := RemoteNodes(consenters, 2)
+	require.NoError(t, err)
+
+	der := func(pemBytes []byte) []byte {
+		bl, _ := pem.Decode(pemBytes)
+		return bl.Bytes
+	}
+	require.Equal(t, []cluster.RemoteNode{
+		{ID: 1, Endpoint: "orderer1:7050", ServerTLSCert: der(consenters[0].ServerTLSCert), ClientTLSCert: der(consenters[0].ClientTLSCert)},
+		{ID: 3, Endpoint: "orderer3:7050", ServerTLSCert: der(consenters[2].ServerTLSCert), ClientTLSCert: der(consenters[2].ClientTLSCert)},
+	}, nodes)
+
+	consenters[2].ClientTLSCert = []byte("not a certificate")
+	_, err = RemoteNodes(consenters, 2)
+	require.EqualError(t, err, "invalid client TLS certificate of consenter 3: invalid PEM block")
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package smartbft
+
+import (
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// MessageReceiver receives the messages sent by the other consenters of a channel.
+type MessageReceiver interface {
+	// HandleMessage passes a message of the consensus protocol sent by the given consenter.
+	HandleMessage(sender uint64, req *orderer.ConsensusRequest)
+	// HandleRequest passes a transaction forwarded by the given consenter.
+	HandleRequest(sender uint64, req *orderer.SubmitRequest)
+}
+
+// ReceiverGetter obtains instances of MessageReceiver given a channel ID
+type ReceiverGetter interface {
+	// ReceiverByChain returns the MessageReceiver if it exists, or nil if it doesn't
+	ReceiverByChain(channelID string) MessageReceiver
+}
+
+// Dispatcher implements the cluster.Handler that receives the messages sent over the cluster communication, and
+// dispatches them to the designated per chain instances. The cluster communication only accepts messages from the
+// consenters with which the channel is configured, so the sender is a member of the consenter set of the channel.
+type Dispatcher struct {
+	Logger        *flogging.FabricLogger
+	ChainSelector ReceiverGetter
+}
+
+// OnConsensus notifies the Dispatcher for a reception of a consensus message from a given sender on a given channel
+func (d *Dispatcher) OnConsensus(channel string, sender uint64, request *orderer.ConsensusRequest) error {
+	receiver := d.ChainSelector.ReceiverByChain(channel)
+	if receiver == nil {
+		d.Logger.Warningf("An attempt to send a consensus message to a non existing channel (%s) was made by %d", channel, sender)
+		return errors.Errorf("channel %s doesn't exist", channel)
+	}
+	receiver.HandleMessage(sender, request)
+	return nil
+}
+
+// OnSubmit notifies the Dispatcher for a reception of a forwarded transaction from a given sender on a given channel
+func (d *Dispatcher) OnSubmit(channel string, sender uint64, request *orderer.SubmitRequest) error {
+	receiver := d.ChainSelector.ReceiverByChain(channel)
+	if receiver == nil {
+		d.Logger.Warningf("An attempt to submit a transaction to a non existing channel (%s) was made by %d", channel, sender)
+		return errors.Errorf("channel %s doesn't exist", channel)
+	}
+	receiver.HandleRequest(sender, request)
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package smartbft
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/stretchr/testify/require"
+)
+
+type received struct {
+	sender    uint64
+	consensus *orderer.ConsensusRequest
+	submit    *orderer.SubmitRequest
+}
+
+type fakeReceiver struct {
+	received []received
+}
+
+func (r *fakeReceiver) HandleMessage(sender uint64, req *orderer.ConsensusRequest) {
+	r.received = append(r.received, received{sender: sender, consensus: req})
+}
+
+func (r *fakeReceiver) HandleRequest(sender uint64, req *orderer.SubmitRequest) {
+	r.received = append(r.received, received{sender: sender, submit: req})
+}
+
+type receiversByChain map[string]*fakeReceiver
+
+func (r receiversByChain) ReceiverByChain(channelID string) MessageReceiver {
+	if receiver, exists := r[channelID]; exists {
+		return receiver
+	}
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	receiver := &fakeReceiver{}
+	var dispatcher cluster.Handler = &Dispatcher{
+		Logger:        flogging.MustGetLogger("test"),
+		ChainSelector: receiversByChain{"mychannel": receiver},
+	}
+
+	consensusRequest := &orderer.ConsensusRequest{Channel: "mychannel", Payload: []byte("message")}
+	submitRequest := &orderer.SubmitRequest{Channel: "mychannel"}
+	require.NoError(t, dispatcher.OnConsensus("mychannel", 2, consensusRequest))
+	require.NoError(t, dispatcher.OnSubmit("mychannel", 3, submitRequest))
+	require.Equal(t, []received{
+		{sender: 2, consensus: consensusRequest},
+		{sender: 3, submit: submitRequest},
+	}, receiver.received)
+
+	err := dispatcher.OnConsensus("otherchannel", 2, consensusRequest)
+	require.EqualError(t, err, "channel otherchannel doesn't exist")
+	err = dispatcher.OnSubmit("otherchannel", 3, submitRequest)
+	require.EqualError(t, err, "channel otherchannel doesn't exist")
+	require.Len(t, receiver.received, 2)
+}
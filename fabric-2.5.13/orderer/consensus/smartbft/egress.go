@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package smartbft
+
+import (
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// RPC sends messages to the other consenters of a channel, and is implemented by cluster.RPC.
+type RPC interface {
+	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
+	SendSubmit(dest uint64, request *orderer.SubmitRequest, report func(err error)) error
+}
+
+// Egress sends the messages of the consensus protocol of a channel, and forwards transactions, to the other
+// consenters of the channel over the cluster communication. Messages that cannot be sent are logged and dropped,
+// since the consensus protocol tolerates lost messages.
+type Egress struct {
+	Channel string
+	RPC     RPC
+	Logger  *flogging.FabricLogger
+}
+
+// SendConsensus sends a message of the consensus protocol to the given consenter.
+func (e *Egress) SendConsensus(target uint64, message []byte) {
+	if err := e.RPC.SendConsensus(target, &orderer.ConsensusRequest{Channel: e.Channel, Payload: message}); err != nil {
+		e.Logger.Warnf("Failed sending consensus message to %d: %v", target, err)
+	}
+}
+
+// SendTransaction forwards a transaction, validated at the given config sequence, to the given consenter.
+func (e *Egress) SendTransaction(target uint64, env *common.Envelope, configSeq uint64) {
+	request := &orderer.SubmitRequest{Channel: e.Channel, LastValidationSeq: configSeq, Payload: env}
+	report := func(err error) {
+		if err != nil {
+			e.Logger.Warnf("Failed forwarding transaction to %d: %v", target, err)
+		}
+	}
+	if err := e.RPC.SendSubmit(target, request, report); err != nil {
+		e.Logger.Warnf("Failed forwarding transaction to %d: %v", target, err)
+	}
+}
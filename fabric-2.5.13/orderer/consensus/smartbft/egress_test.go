@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package smartbft
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type sent struct {
+	dest      uint64
+	consensus *orderer.ConsensusRequest
+	submit    *orderer.SubmitRequest
+}
+
+type fakeRPC struct {
+	sent      []sent
+	err       error
+	reportErr error
+}
+
+func (r *fakeRPC) SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error {
+	r.sent = append(r.sent, sent{dest: dest, consensus: msg})
+	return r.err
+}
+
+func (r *fakeRPC) SendSubmit(dest uint64, request *orderer.SubmitRequest, report func(err error)) error {
+	r.sent = append(r.sent, sent{dest: dest, submit: request})
+	if r.err != nil {
+		return r.err
+	}
+	report(r.reportErr)
+	return nil
+}
+
+func TestEgress(t *testing.T) {
+	var warnings []string
+	logger := flogging.MustGetLogger("test").WithOptions(zap.Hooks(func(entry zapcore.Entry) error {
+		warnings = append(warnings, entry.Message)
+		return nil
+	}))
+	rpc := &fakeRPC{}
+	egress := &Egress{Channel: "mychannel", RPC: rpc, Logger: logger}
+
+	env := &common.Envelope{Payload: []byte("tx")}
+	egress.SendConsensus(2, []byte("message"))
+	egress.SendTransaction(3, env, 5)
+	require.Equal(t, []sent{
+		{dest: 2, consensus: &orderer.ConsensusRequest{Channel: "mychannel", Payload: []byte("message")}},
+		{dest: 3, submit: &orderer.SubmitRequest{Channel: "mychannel", LastValidationSeq: 5, Payload: env}},
+	}, rpc.sent)
+	require.Empty(t, warnings)
+
+	rpc.reportErr = errors.New("stream aborted")
+	egress.SendTransaction(3, env, 5)
+	rpc.err = errors.New("unknown destination")
+	egress.SendConsensus(4, []byte("message"))
+	egress.SendTransaction(4, env, 5)
+	require.Equal(t, []string{
+		"Failed forwarding transaction to 3: stream aborted",
+		"Failed sending consensus message to 4: unknown destination",
+		"Failed forwarding transaction to 4: unknown destination",
+	}, warnings)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package smartbft
+
+import (
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/pkg/errors"
+)
+
+// ComputeQuorum returns the number of consenters, out of n consenters, that must agree on a block, and the number of
+// faulty consenters that are tolerated. Any two quorums intersect in at least f+1 consenters, at least one of which
+// is not faulty.
+func ComputeQuorum(n int) (q int, f int) {
+	f = (n - 1) / 3
+	// the smallest q such that 2q - n >= f + 1
+	q = (n + f + 2) / 2
+	return q, f
+}
+
+// BlockValidationPolicy returns the BlockValidation policy of a channel ordered by the given consenters, which is
+// satisfied by the signatures of a quorum of distinct consenters. Peers and orderers evaluate the BlockValidation
+// policy against the signatures in the metadata of the blocks they pull, so a block signed by fewer consenters than
+// a quorum is rejected.
+func BlockValidationPolicy(consenters []*Consenter) (*common.SignaturePolicyEnvelope, error) {
+	if err := ValidateConsenters(consenters); err != nil {
+		return nil, err
+	}
+	identities := make([][]byte, len(consenters))
+	signedBy := make([]*common.SignaturePolicy, len(consenters))
+	for i, consenter := range consenters {
+		identity, err := consenter.SerializedIdentity()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize the identity of consenter %d", consenter.ID)
+		}
+		identities[i] = identity
+		signedBy[i] = policydsl.SignedBy(int32(i))
+	}
+	q, _ := ComputeQuorum(len(consenters))
+	return policydsl.Envelope(policydsl.NOutOf(int32(q), signedBy), identities), nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package smartbft
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeQuorum(t *testing.T) {
+	for _, tc := range []struct {
+		n, q, f int
+	}{
+		{n: 1, q: 1, f: 0},
+		{n: 2, q: 2, f: 0},
+		{n: 3, q: 2, f: 0},
+		{n: 4, q: 3, f: 1},
+		{n: 5, q: 4, f: 1},
+		{n: 6, q: 4, f: 1},
+		{n: 7, q: 5, f: 2},
+		{n: 10, q: 7, f: 3},
+	} {
+		q, f := ComputeQuorum(tc.n)
+		require.Equal(t, tc.q, q, "quorum of %d", tc.n)
+		require.Equal(t, tc.f, f, "faults tolerated by %d", tc.n)
+	}
+}
+
+func TestBlockValidationPolicy(t *testing.T) {
+	consenters := newConsenters(t, 4)
+	envelope, err := BlockValidationPolicy(consenters)
+	require.NoError(t, err)
+
+	policy, _, err := cauthdsl.NewPolicyProvider(&mockDeserializer{}).NewPolicy(protoutil.MarshalOrPanic(envelope))
+	require.NoError(t, err)
+
+	signedBy := func(signers ...*Consenter) []*protoutil.SignedData {
+		var signatureSet []*protoutil.SignedData
+		for _, signer := range signers {
+			identity, err := signer.SerializedIdentity()
+			require.NoError(t, err)
+			signatureSet = append(signatureSet, &protoutil.SignedData{Identity: identity, Data: []byte("block"), Signature: []byte("signature")})
+		}
+		return signatureSet
+	}
+
+	require.NoError(t, policy.EvaluateSignedData(signedBy(consenters[0], consenters[1], consenters[3])))
+	require.NoError(t, policy.EvaluateSignedData(signedBy(consenters...)))
+	require.Error(t, policy.EvaluateSignedData(signedBy(consenters[0], consenters[1])), "fewer signatures than a quorum")
+	require.Error(t, policy.EvaluateSignedData(signedBy(consenters[0], consenters[1], consenters[1])), "a consenter signing twice counts once")
+
+	outsider := newConsenters(t, 1)[0]
+	outsider.MSPID = "OtherMSP"
+	require.Error(t, policy.EvaluateSignedData(signedBy(consenters[0], consenters[1], outsider)), "not a consenter")
+
+	_, err = BlockValidationPolicy(nil)
+	require.EqualError(t, err, "empty consenter set")
+}
+
+type mockIdentity struct {
+	idBytes []byte
+}
+
+func (id *mockIdentity) Anonymous() bool      { return false }
+func (id *mockIdentity) ExpiresAt() time.Time { return time.Time{} }
+func (id *mockIdentity) GetIdentifier() *msp.IdentityIdentifier {
+	return &msp.IdentityIdentifier{Id: string(id.idBytes)}
+}
+func (id *mockIdentity) GetMSPIdentifier() string                    { return "Mock" }
+func (id *mockIdentity) Validate() error                             { return nil }
+func (id *mockIdentity) GetOrganizationalUnits() []*msp.OUIdentifier { return nil }
+func (id *mockIdentity) Verify(msg []byte, sig []byte) error         { return nil }
+func (id *mockIdentity) Serialize() ([]byte, error)                  { return id.idBytes, nil }
+func (id *mockIdentity) SatisfiesPrincipal(p *mb.MSPPrincipal) error {
+	if !bytes.Equal(id.idBytes, p.Principal) {
+		return errors.New("principals do not match")
+	}
+	return nil
+}
+
+type mockDeserializer struct{}
+
+func (md *mockDeserializer) IsWellFormed(_ *mb.SerializedIdentity) error {
+	return nil
+}
+
+func (md *mockDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	return &mockIdentity{idBytes: serializedIdentity}, nil
+}
@@ -99,7 +99,7 @@ generateOrCheck \
         docs/wrappers/peer_channel_postscript.md \
         "${commands[@]}"
 
-commands=("peer node pause" "peer node rebuild-dbs" "peer node reset" "peer node resume" "peer node rollback" "peer node start" "peer node unjoin" "peer node upgrade-dbs")
+commands=("peer node pause" "peer node pvtdata status" "peer node pvtdata list-missing" "peer node pvtdata reconcile" "peer node pvtdata pause" "peer node pvtdata resume" "peer node rebuild-dbs" "peer node reset" "peer node resume" "peer node rollback" "peer node rotate-pvtdata-keys" "peer node start" "peer node unjoin" "peer node upgrade-dbs")
 generateOrCheck \
         docs/source/commands/peernode.md \
         docs/wrappers/peer_node_preamble.md \
@@ -134,14 +134,21 @@ generateOrCheck \
         docs/wrappers/configtxlator_postscript.md \
         "${commands[@]}"
 
-commands=("osnadmin channel" "osnadmin channel join" "osnadmin channel list" "osnadmin channel remove")
+commands=("osnadmin channel" "osnadmin channel join" "osnadmin channel list" "osnadmin channel remove" "osnadmin channel raft status" "osnadmin channel raft snapshot" "osnadmin channel transfer-leader")
 generateOrCheck \
         docs/source/commands/osnadminchannel.md \
         docs/wrappers/osnadmin_channel_preamble.md \
         docs/wrappers/osnadmin_channel_postscript.md \
         "${commands[@]}"
 
-commands=("ledgerutil compare" "ledgerutil identifytxs" "ledgerutil verify")
+commands=("osnadmin maintenance" "osnadmin maintenance status" "osnadmin maintenance enter" "osnadmin maintenance exit")
+generateOrCheck \
+        docs/source/commands/osnadminmaintenance.md \
+        docs/wrappers/osnadmin_maintenance_preamble.md \
+        docs/wrappers/osnadmin_maintenance_postscript.md \
+        "${commands[@]}"
+
+commands=("ledgerutil compare" "ledgerutil identifytxs" "ledgerutil verify" "ledgerutil pvtdata export")
 generateOrCheck \
         docs/source/commands/ledgerutil.md \
         docs/wrappers/ledgerutil_preamble.md \